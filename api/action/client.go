@@ -180,3 +180,31 @@ func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, e
 	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
 	return w, nil
 }
+
+// WatchActionOutput returns a watcher that reports on the output
+// streamed by a running action. The result strings are json
+// formatted core.actions.ActionOutput objects.
+func (c *Client) WatchActionOutput(actionId string) (watcher.StringsWatcher, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return nil, errors.Errorf("WatchActionOutput not supported by this version (%d) of Juju", v)
+	}
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: names.NewActionTag(actionId).String()},
+		},
+	}
+	err := c.facade.FacadeCall("WatchActionsOutput", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
@@ -213,6 +213,58 @@ func (s *actionSuite) TestWatchActionProgressNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "WatchActionProgress not supported by this version \\(4\\) of Juju")
 }
 
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				called = true
+				c.Assert(request, gc.Equals, "WatchActionsOutput")
+				c.Assert(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{
+						Tag: "action-666",
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+				*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+					Results: []params.StringsWatchResult{{
+						Error: &params.Error{Message: "FAIL"},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := action.NewClient(apiCaller)
+	w, err := client.WatchActionOutput("666")
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *actionSuite) TestWatchActionOutputNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := action.NewClient(apiCaller)
+	_, err := client.WatchActionOutput("666")
+	c.Assert(err, gc.ErrorMatches, "WatchActionOutput not supported by this version \\(6\\) of Juju")
+}
+
 func (s *actionSuite) TestListOperations(c *gc.C) {
 	var args params.OperationQueryArgs
 	apiCaller := basetesting.BestVersionCaller{
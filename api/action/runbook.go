@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// StartRunbook starts a runbook with the named steps as an
+// operation, and returns the tag of the operation.
+func (c *Client) StartRunbook(summary string, steps []string) (string, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return "", errors.Errorf("StartRunbook not supported by this version (%d) of Juju", v)
+	}
+	arg := params.StartRunbookArg{
+		Summary: summary,
+		Steps:   steps,
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("StartRunbook", arg, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", result.Error
+	}
+	return result.Result, nil
+}
+
+// RunRunbookStep runs a step of a runbook, adding a task
+// to the runbook's operation on each of the step's units.
+func (c *Client) RunRunbookStep(arg params.RunRunbookStepArg) (params.EnqueuedActions, error) {
+	var results params.EnqueuedActions
+	if v := c.BestAPIVersion(); v < 7 {
+		return results, errors.Errorf("RunRunbookStep not supported by this version (%d) of Juju", v)
+	}
+	err := c.facade.FacadeCall("RunRunbookStep", arg, &results)
+	return results, errors.Trace(err)
+}
+
+// SetRunbookStepStatus records the result of a step of a runbook.
+func (c *Client) SetRunbookStepStatus(arg params.RunbookStepStatusArg) error {
+	if v := c.BestAPIVersion(); v < 7 {
+		return errors.Errorf("SetRunbookStepStatus not supported by this version (%d) of Juju", v)
+	}
+	return errors.Trace(c.facade.FacadeCall("SetRunbookStepStatus", arg, nil))
+}
+
+// RunbookHeartbeat records that this client is still
+// running the runbook of the operation.
+func (c *Client) RunbookHeartbeat(operationTag string) error {
+	if v := c.BestAPIVersion(); v < 7 {
+		return errors.Errorf("RunbookHeartbeat not supported by this version (%d) of Juju", v)
+	}
+	arg := params.RunbookHeartbeatArg{OperationTag: operationTag}
+	return errors.Trace(c.facade.FacadeCall("RunbookHeartbeat", arg, nil))
+}
+
+// FinishRunbook completes the operation of a runbook.
+func (c *Client) FinishRunbook(arg params.FinishRunbookArg) error {
+	if v := c.BestAPIVersion(); v < 7 {
+		return errors.Errorf("FinishRunbook not supported by this version (%d) of Juju", v)
+	}
+	return errors.Trace(c.facade.FacadeCall("FinishRunbook", arg, nil))
+}
@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type runbookSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&runbookSuite{})
+
+func runbookCaller(c *gc.C, version int, expectRequest string, expectArgs interface{}, results interface{}) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, expectRequest)
+				c.Assert(a, jc.DeepEquals, expectArgs)
+				switch r := result.(type) {
+				case *params.StringResult:
+					*r = results.(params.StringResult)
+				case *params.EnqueuedActions:
+					*r = results.(params.EnqueuedActions)
+				case nil:
+					if err, ok := results.(error); ok {
+						return err
+					}
+				default:
+					c.Fatalf("unexpected result type %T", result)
+				}
+				return nil
+			},
+		),
+		BestVersion: version,
+	}
+}
+
+func (s *runbookSuite) TestStartRunbook(c *gc.C) {
+	apiCaller := runbookCaller(c, 7, "StartRunbook",
+		params.StartRunbookArg{Summary: "upgrade", Steps: []string{"pause", "backup"}},
+		params.StringResult{Result: "operation-1"},
+	)
+	client := action.NewClient(apiCaller)
+	operationTag, err := client.StartRunbook("upgrade", []string{"pause", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operationTag, gc.Equals, "operation-1")
+}
+
+func (s *runbookSuite) TestStartRunbookError(c *gc.C) {
+	apiCaller := runbookCaller(c, 7, "StartRunbook",
+		params.StartRunbookArg{Summary: "upgrade"},
+		params.StringResult{Error: &params.Error{Message: "runbook with no steps not valid"}},
+	)
+	client := action.NewClient(apiCaller)
+	_, err := client.StartRunbook("upgrade", nil)
+	c.Assert(err, gc.ErrorMatches, "runbook with no steps not valid")
+}
+
+func (s *runbookSuite) TestRunRunbookStep(c *gc.C) {
+	arg := params.RunRunbookStepArg{
+		OperationTag: "operation-1",
+		Step:         1,
+		Action:       "backup",
+		Applications: []string{"mysql"},
+		Leader:       true,
+	}
+	enqueued := params.EnqueuedActions{
+		OperationTag: "operation-1",
+		Actions:      []params.StringResult{{Result: "action-2"}},
+	}
+	apiCaller := runbookCaller(c, 7, "RunRunbookStep", arg, enqueued)
+	client := action.NewClient(apiCaller)
+	result, err := client.RunRunbookStep(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, enqueued)
+}
+
+func (s *runbookSuite) TestSetRunbookStepStatus(c *gc.C) {
+	arg := params.RunbookStepStatusArg{
+		OperationTag: "operation-1",
+		Step:         1,
+		Status:       "failed",
+		Message:      "boom",
+	}
+	apiCaller := runbookCaller(c, 7, "SetRunbookStepStatus", arg, errors.New("kaboom"))
+	client := action.NewClient(apiCaller)
+	err := client.SetRunbookStepStatus(arg)
+	c.Assert(err, gc.ErrorMatches, "kaboom")
+}
+
+func (s *runbookSuite) TestFinishRunbook(c *gc.C) {
+	arg := params.FinishRunbookArg{
+		OperationTag: "operation-1",
+		Status:       "completed",
+	}
+	apiCaller := runbookCaller(c, 7, "FinishRunbook", arg, nil)
+	client := action.NewClient(apiCaller)
+	err := client.FinishRunbook(arg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *runbookSuite) TestRunbookHeartbeat(c *gc.C) {
+	arg := params.RunbookHeartbeatArg{OperationTag: "operation-1"}
+	apiCaller := runbookCaller(c, 7, "RunbookHeartbeat", arg, nil)
+	client := action.NewClient(apiCaller)
+	err := client.RunbookHeartbeat("operation-1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *runbookSuite) TestRunbookNotSupported(c *gc.C) {
+	client := action.NewClient(runbookCaller(c, 6, "", nil, nil))
+	_, err := client.StartRunbook("upgrade", []string{"pause"})
+	c.Assert(err, gc.ErrorMatches, `StartRunbook not supported by this version \(6\) of Juju`)
+	_, err = client.RunRunbookStep(params.RunRunbookStepArg{})
+	c.Assert(err, gc.ErrorMatches, `RunRunbookStep not supported by this version \(6\) of Juju`)
+	err = client.SetRunbookStepStatus(params.RunbookStepStatusArg{})
+	c.Assert(err, gc.ErrorMatches, `SetRunbookStepStatus not supported by this version \(6\) of Juju`)
+	err = client.FinishRunbook(params.FinishRunbookArg{})
+	c.Assert(err, gc.ErrorMatches, `FinishRunbook not supported by this version \(6\) of Juju`)
+	err = client.RunbookHeartbeat("operation-1")
+	c.Assert(err, gc.ErrorMatches, `RunbookHeartbeat not supported by this version \(6\) of Juju`)
+}
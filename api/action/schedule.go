@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddActionSchedule adds a schedule on which an action is run
+// by the controller, and returns it.
+func (c *Client) AddActionSchedule(arg params.AddActionScheduleArg) (params.ActionSchedule, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return params.ActionSchedule{}, errors.Errorf("AddActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	args := params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{arg},
+	}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("AddActionSchedules", args, &results); err != nil {
+		return params.ActionSchedule{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.ActionSchedule{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.ActionSchedule{}, result.Error
+	}
+	return *result.Schedule, nil
+}
+
+// ListActionSchedules returns the action schedules of the model.
+func (c *Client) ListActionSchedules() ([]params.ActionSchedule, error) {
+	if v := c.BestAPIVersion(); v < 7 {
+		return nil, errors.Errorf("ListActionSchedules not supported by this version (%d) of Juju", v)
+	}
+	var results params.ActionScheduleResults
+	if err := c.facade.FacadeCall("ListActionSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	schedules := make([]params.ActionSchedule, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		schedules[i] = *result.Schedule
+	}
+	return schedules, nil
+}
+
+// PauseActionSchedule stops the action of the specified
+// schedule from being run until it is resumed.
+func (c *Client) PauseActionSchedule(id string) error {
+	return c.updateActionSchedule("PauseActionSchedules", id)
+}
+
+// ResumeActionSchedule resumes running the action of the specified schedule.
+func (c *Client) ResumeActionSchedule(id string) error {
+	return c.updateActionSchedule("ResumeActionSchedules", id)
+}
+
+// RemoveActionSchedule removes the specified schedule.
+func (c *Client) RemoveActionSchedule(id string) error {
+	return c.updateActionSchedule("RemoveActionSchedules", id)
+}
+
+func (c *Client) updateActionSchedule(method, id string) error {
+	if v := c.BestAPIVersion(); v < 7 {
+		return errors.Errorf("%s not supported by this version (%d) of Juju", method, v)
+	}
+	args := params.ActionScheduleIds{Ids: []string{id}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return maybeNotFound(err)
+	}
+	return nil
+}
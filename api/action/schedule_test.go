@@ -0,0 +1,136 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func scheduleCaller(c *gc.C, version int, expectRequest string, expectArgs interface{}, results interface{}) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Assert(request, gc.Equals, expectRequest)
+				c.Assert(a, jc.DeepEquals, expectArgs)
+				switch r := result.(type) {
+				case *params.ActionScheduleResults:
+					*r = results.(params.ActionScheduleResults)
+				case *params.ErrorResults:
+					*r = results.(params.ErrorResults)
+				default:
+					c.Fatalf("unexpected result type %T", result)
+				}
+				return nil
+			},
+		),
+		BestVersion: version,
+	}
+}
+
+func (s *scheduleSuite) TestAddActionSchedule(c *gc.C) {
+	arg := params.AddActionScheduleArg{
+		Receiver: "mysql",
+		Name:     "backup",
+		Schedule: "0 3 * * *",
+	}
+	schedule := params.ActionSchedule{
+		Id:       "1",
+		Receiver: "mysql",
+		Name:     "backup",
+		Schedule: "0 3 * * *",
+		Overlap:  "skip",
+		Created:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	apiCaller := scheduleCaller(c, 7, "AddActionSchedules",
+		params.AddActionScheduleArgs{Schedules: []params.AddActionScheduleArg{arg}},
+		params.ActionScheduleResults{Results: []params.ActionScheduleResult{{Schedule: &schedule}}},
+	)
+	client := action.NewClient(apiCaller)
+	result, err := client.AddActionSchedule(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedule)
+}
+
+func (s *scheduleSuite) TestAddActionScheduleError(c *gc.C) {
+	apiCaller := scheduleCaller(c, 7, "AddActionSchedules",
+		params.AddActionScheduleArgs{Schedules: []params.AddActionScheduleArg{{}}},
+		params.ActionScheduleResults{Results: []params.ActionScheduleResult{{
+			Error: &params.Error{Message: "bad schedule"},
+		}}},
+	)
+	client := action.NewClient(apiCaller)
+	_, err := client.AddActionSchedule(params.AddActionScheduleArg{})
+	c.Assert(err, gc.ErrorMatches, "bad schedule")
+}
+
+func (s *scheduleSuite) TestAddActionScheduleNotSupported(c *gc.C) {
+	client := action.NewClient(scheduleCaller(c, 6, "", nil, nil))
+	_, err := client.AddActionSchedule(params.AddActionScheduleArg{})
+	c.Assert(err, gc.ErrorMatches, `AddActionSchedules not supported by this version \(6\) of Juju`)
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{Id: "1"}, {Id: "2"}}
+	apiCaller := scheduleCaller(c, 7, "ListActionSchedules", nil,
+		params.ActionScheduleResults{Results: []params.ActionScheduleResult{
+			{Schedule: &schedules[0]}, {Schedule: &schedules[1]},
+		}},
+	)
+	client := action.NewClient(apiCaller)
+	result, err := client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *scheduleSuite) TestPauseActionSchedule(c *gc.C) {
+	apiCaller := scheduleCaller(c, 7, "PauseActionSchedules",
+		params.ActionScheduleIds{Ids: []string{"1"}},
+		params.ErrorResults{Results: []params.ErrorResult{{}}},
+	)
+	client := action.NewClient(apiCaller)
+	err := client.PauseActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *scheduleSuite) TestResumeActionSchedule(c *gc.C) {
+	apiCaller := scheduleCaller(c, 7, "ResumeActionSchedules",
+		params.ActionScheduleIds{Ids: []string{"1"}},
+		params.ErrorResults{Results: []params.ErrorResult{{}}},
+	)
+	client := action.NewClient(apiCaller)
+	err := client.ResumeActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *scheduleSuite) TestRemoveActionScheduleNotFound(c *gc.C) {
+	apiCaller := scheduleCaller(c, 7, "RemoveActionSchedules",
+		params.ActionScheduleIds{Ids: []string{"42"}},
+		params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Code: params.CodeNotFound, Message: `action schedule "42" not found`},
+		}}},
+	)
+	client := action.NewClient(apiCaller)
+	err := client.RemoveActionSchedule("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `action schedule "42" not found`)
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const actionSchedulerFacade = "ActionScheduler"
+
+// Client provides access to the ActionScheduler API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side ActionScheduler facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, actionSchedulerFacade)}
+}
+
+// WatchActionSchedules returns a watcher that notifies
+// of changes to the action schedules of the model.
+func (c *Client) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchActionSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// ActionScheduleTimes returns the time at which the
+// action of each schedule of the model is next due.
+func (c *Client) ActionScheduleTimes() ([]params.ActionScheduleTime, error) {
+	var result params.ActionScheduleTimes
+	if err := c.facade.FacadeCall("ActionScheduleTimes", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// TriggerActionSchedule enqueues the action of the
+// specified schedule, which was due at the specified time.
+func (c *Client) TriggerActionSchedule(id string, due time.Time) error {
+	args := params.ActionScheduleTriggers{
+		Triggers: []params.ActionScheduleTrigger{{Id: id, Due: due}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TriggerActionSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) TestWatchActionSchedulesError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "WatchActionSchedules")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := actionscheduler.NewClient(apiCaller)
+	_, err := client.WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestActionScheduleTimes(c *gc.C) {
+	times := []params.ActionScheduleTime{{
+		Id:       "1",
+		Schedule: "0 3 * * *",
+		NextRun:  time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "ActionScheduleTimes")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ActionScheduleTimes)) = params.ActionScheduleTimes{Schedules: times}
+		return nil
+	})
+	client := actionscheduler.NewClient(apiCaller)
+	result, err := client.ActionScheduleTimes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, times)
+}
+
+func (s *ClientSuite) TestActionScheduleTimesError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := actionscheduler.NewClient(apiCaller)
+	_, err := client.ActionScheduleTimes()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestTriggerActionSchedule(c *gc.C) {
+	due := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "TriggerActionSchedules")
+		c.Check(arg, jc.DeepEquals, params.ActionScheduleTriggers{
+			Triggers: []params.ActionScheduleTrigger{{Id: "1", Due: due}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Code: params.CodeAlreadyExists, Message: "run already exists"},
+			}},
+		}
+		return nil
+	})
+	client := actionscheduler.NewClient(apiCaller)
+	err := client.TriggerActionSchedule("1", due)
+	c.Assert(err, gc.ErrorMatches, "run already exists")
+	c.Assert(err, jc.Satisfies, params.IsCodeAlreadyExists)
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -162,6 +163,109 @@ func (c *Client) Deploy(args DeployArgs) error {
 	return errors.Trace(results.OneError())
 }
 
+// ImportK8sWorkload deploys an application which manages the workload
+// of the same name already running in the model's cluster, using the
+// specified charm. The deployment type is the type of the workload,
+// either stateful or stateless.
+func (c *Client) ImportK8sWorkload(applicationName string, deploymentType caas.DeploymentType, charmURL *charm.URL) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("ImportK8sWorkload for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(applicationName) {
+		return errors.NotValidf("application name %q", applicationName)
+	}
+	args := params.ImportK8sWorkloadArgs{
+		Workloads: []params.ImportK8sWorkloadArg{{
+			ApplicationName: applicationName,
+			DeploymentType:  string(deploymentType),
+			CharmURL:        charmURL.String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ImportK8sWorkload", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// BackupApplication takes a snapshot of each volume claim owned by the
+// storage of the application, and returns the backup recording them.
+func (c *Client) BackupApplication(applicationName string) (params.ApplicationBackup, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return params.ApplicationBackup{}, errors.NotSupportedf("BackupApplication for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(applicationName) {
+		return params.ApplicationBackup{}, errors.NotValidf("application name %q", applicationName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(applicationName).String()}},
+	}
+	var results params.ApplicationBackupResults
+	if err := c.facade.FacadeCall("BackupApplications", args, &results); err != nil {
+		return params.ApplicationBackup{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ApplicationBackup{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ApplicationBackup{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// RestoreApplication restores the storage of the application from the
+// snapshots of the backup with the specified id, or of its most recent
+// backup if no id is specified. The application must be scaled to 0.
+func (c *Client) RestoreApplication(applicationName, backupId string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("RestoreApplication for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(applicationName) {
+		return errors.NotValidf("application name %q", applicationName)
+	}
+	args := params.RestoreApplicationArgs{
+		Args: []params.RestoreApplicationArg{{
+			ApplicationTag: names.NewApplicationTag(applicationName).String(),
+			BackupId:       backupId,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RestoreApplications", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(results.OneError())
+}
+
+// SetIngressTLSCertificate stores the PEM encoded TLS certificate and
+// key for the ingress of the application in a k8s secret, and returns
+// the name of the secret for the application config to refer to.
+func (c *Client) SetIngressTLSCertificate(applicationName string, certificate, key []byte) (string, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 15 {
+		return "", errors.NotSupportedf("SetIngressTLSCertificate for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(applicationName) {
+		return "", errors.NotValidf("application name %q", applicationName)
+	}
+	args := params.IngressTLSCertificates{
+		Certificates: []params.IngressTLSCertificate{{
+			ApplicationTag: names.NewApplicationTag(applicationName).String(),
+			Certificate:    string(certificate),
+			Key:            string(key),
+		}},
+	}
+	var results params.StringResults
+	if err := c.facade.FacadeCall("SetIngressTLSCertificates", args, &results); err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return "", errors.Trace(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // GetCharmURL returns the charm URL the given application is
 // running at present.
 func (c *Client) GetCharmURL(branchName, applicationName string) (*charm.URL, error) {
@@ -1034,12 +1138,26 @@ type UnitInfo struct {
 	Charm           string
 	Leader          bool
 	RelationData    []EndpointRelationData
+	HookHistory     []HookExecution
 
 	// The following are for CAAS models.
 	ProviderId string
 	Address    string
 }
 
+// HookExecution holds information about a hook, action or command
+// run by a unit.
+type HookExecution struct {
+	Kind     string
+	Name     string
+	Trigger  string
+	Started  time.Time
+	Finished time.Time
+	ExitCode int
+	Error    string
+	Skipped  bool
+}
+
 // RelationData holds information about a unit's relation.
 type RelationData struct {
 	InScope  bool
@@ -1126,5 +1244,17 @@ func unitInfoFromParams(in params.UnitInfoResult) UnitInfo {
 		}
 		info.RelationData = append(info.RelationData, erd)
 	}
+	for _, e := range in.Result.HookHistory {
+		info.HookHistory = append(info.HookHistory, HookExecution{
+			Kind:     e.Kind,
+			Name:     e.Name,
+			Trigger:  e.Trigger,
+			Started:  e.Started,
+			Finished: e.Finished,
+			ExitCode: e.ExitCode,
+			Error:    e.Error,
+			Skipped:  e.Skipped,
+		})
+	}
 	return info
 }
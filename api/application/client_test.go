@@ -19,6 +19,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -1303,6 +1304,176 @@ func (s *applicationSuite) TestScaleApplication(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestImportK8sWorkload(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ImportK8sWorkload")
+			c.Assert(a, jc.DeepEquals, params.ImportK8sWorkloadArgs{
+				Workloads: []params.ImportK8sWorkloadArg{{
+					ApplicationName: "web",
+					DeploymentType:  "stateless",
+					CharmURL:        "local:kubernetes/web-0",
+				}}})
+
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 13})
+	err := client.ImportK8sWorkload("web", caas.DeploymentStateless, charm.MustParseURL("local:kubernetes/web-0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestImportK8sWorkloadInvalidName(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call %q", request)
+			return nil
+		},
+		BestVersion: 13,
+	})
+	err := client.ImportK8sWorkload("Web", caas.DeploymentStateless, charm.MustParseURL("local:kubernetes/web-0"))
+	c.Assert(err, gc.ErrorMatches, `application name "Web" not valid`)
+}
+
+func (s *applicationSuite) TestImportK8sWorkloadNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call %q", request)
+			return nil
+		},
+		BestVersion: 12,
+	})
+	err := client.ImportK8sWorkload("web", caas.DeploymentStateless, charm.MustParseURL("local:kubernetes/web-0"))
+	c.Assert(err, gc.ErrorMatches, "ImportK8sWorkload for Application facade v12 not supported")
+}
+
+func (s *applicationSuite) TestBackupApplication(c *gc.C) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	backup := params.ApplicationBackup{
+		Id:             "20200102-030405",
+		ApplicationTag: "application-gitlab",
+		Created:        created,
+		Snapshots: []params.VolumeSnapshot{{
+			StorageName:  "database",
+			ClaimName:    "database-gitlab-0",
+			SnapshotName: "database-gitlab-0-20200102-030405",
+			Size:         1024,
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "BackupApplications")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-gitlab"}},
+			})
+
+			result, ok := response.(*params.ApplicationBackupResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ApplicationBackupResult{{Result: &backup}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 14})
+	result, err := client.BackupApplication("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, backup)
+}
+
+func (s *applicationSuite) TestBackupApplicationError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			result, ok := response.(*params.ApplicationBackupResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ApplicationBackupResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 14})
+	_, err := client.BackupApplication("gitlab")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestRestoreApplication(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "RestoreApplications")
+			c.Assert(a, jc.DeepEquals, params.RestoreApplicationArgs{
+				Args: []params.RestoreApplicationArg{{
+					ApplicationTag: "application-gitlab",
+					BackupId:       "20200102-030405",
+				}},
+			})
+
+			result, ok := response.(*params.ErrorResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 14})
+	err := client.RestoreApplication("gitlab", "20200102-030405")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestBackupAndRestoreApplicationNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call %q", request)
+			return nil
+		},
+		BestVersion: 13,
+	})
+	_, err := client.BackupApplication("gitlab")
+	c.Assert(err, gc.ErrorMatches, "BackupApplication for Application facade v13 not supported")
+	err = client.RestoreApplication("gitlab", "")
+	c.Assert(err, gc.ErrorMatches, "RestoreApplication for Application facade v13 not supported")
+}
+
+func (s *applicationSuite) TestSetIngressTLSCertificate(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetIngressTLSCertificates")
+			c.Assert(a, jc.DeepEquals, params.IngressTLSCertificates{
+				Certificates: []params.IngressTLSCertificate{{
+					ApplicationTag: "application-gitlab",
+					Certificate:    "cert",
+					Key:            "key",
+				}},
+			})
+
+			result, ok := response.(*params.StringResults)
+			c.Assert(ok, jc.IsTrue)
+			result.Results = []params.StringResult{{Result: "gitlab-tls"}}
+			return nil
+		},
+	)
+	client := application.NewClient(basetesting.BestVersionCaller{APICallerFunc: apiCaller, BestVersion: 15})
+	secretName, err := client.SetIngressTLSCertificate("gitlab", []byte("cert"), []byte("key"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secretName, gc.Equals, "gitlab-tls")
+}
+
+func (s *applicationSuite) TestSetIngressTLSCertificateNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call %q", request)
+			return nil
+		},
+		BestVersion: 14,
+	})
+	_, err := client.SetIngressTLSCertificate("gitlab", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "SetIngressTLSCertificate for Application facade v14 not supported")
+}
+
 func (s *applicationSuite) TestChangeScaleApplication(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, a, response interface{}) error {
@@ -1637,6 +1808,15 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 							},
 						},
 					}},
+					HookHistory: []params.HookExecution{{
+						Kind:     "hook",
+						Name:     "install",
+						Trigger:  "unit deployed",
+						Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+						Finished: time.Date(2020, 6, 1, 12, 0, 5, 0, time.UTC),
+						ExitCode: 1,
+						Error:    "exit status 1",
+					}},
 					ProviderId: "provider-id",
 					Address:    "192.168.1.1",
 				}},
@@ -1676,6 +1856,15 @@ func (s *applicationSuite) TestUnitsInfo(c *gc.C) {
 					},
 				},
 			}},
+			HookHistory: []application.HookExecution{{
+				Kind:     "hook",
+				Name:     "install",
+				Trigger:  "unit deployed",
+				Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+				Finished: time.Date(2020, 6, 1, 12, 0, 5, 0, time.UTC),
+				ExitCode: 1,
+				Error:    "exit status 1",
+			}},
 			ProviderId: "provider-id",
 			Address:    "192.168.1.1",
 		},
@@ -5,10 +5,13 @@ package caasagent
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 )
 
 // Client provides access to an agent's view of state.
@@ -34,3 +37,24 @@ func NewClient(caller base.APICaller) (*Client, error) {
 		ControllerConfigAPI: common.NewControllerConfig(facadeCaller),
 	}, nil
 }
+
+// ZoneCloudSpec returns the cloud spec of the cloud
+// of the specified cluster zone of the model.
+func (c *Client) ZoneCloudSpec(zone string) (environs.CloudSpec, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return environs.CloudSpec{}, errors.NotSupportedf("ZoneCloudSpec for CAASAgent facade v%v", apiVersion)
+	}
+	var results params.CloudSpecResults
+	args := params.Entities{Entities: []params.Entity{{Tag: names.NewCloudTag(zone).String()}}}
+	if err := c.facade.FacadeCall("ZoneCloudSpecs", args, &results); err != nil {
+		return environs.CloudSpec{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return environs.CloudSpec{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return environs.CloudSpec{}, errors.Annotatef(result.Error, "cluster zone %q", zone)
+	}
+	return c.MakeCloudSpec(result.Result)
+}
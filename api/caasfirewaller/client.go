@@ -18,6 +18,7 @@ import (
 
 // Client allows access to the CAAS firewaller API endpoint.
 type Client struct {
+	*common.ModelWatcher
 	facade base.FacadeCaller
 }
 
@@ -25,7 +26,8 @@ type Client struct {
 func NewClient(caller base.APICaller) *Client {
 	facadeCaller := base.NewFacadeCaller(caller, "CAASFirewaller")
 	return &Client{
-		facade: facadeCaller,
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
 	}
 }
 
@@ -130,6 +132,73 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the application config of the specified application.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchApplicationConfig for CAASFirewaller facade v%v", apiVersion)
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// WatchApplicationRelations returns a StringsWatcher that notifies
+// of changes to the relations of the specified application.
+func (c *Client) WatchApplicationRelations(appName string) (watcher.StringsWatcher, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsRelations", entities(appTag), &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), results.Results[0])
+	return w, nil
+}
+
+// RelatedApplications returns the names of the applications
+// related to the specified application.
+func (c *Client) RelatedApplications(appName string) ([]string, error) {
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results params.StringsResults
+	if err := c.facade.FacadeCall("RelatedApplications", entities(appTag), &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return results.Results[0].Result, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
@@ -196,3 +196,92 @@ func (s *FirewallerSuite) TestApplicationConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg, jc.DeepEquals, application.ConfigAttributes{"foo": "bar"})
 }
+
+func (s *FirewallerSuite) TestWatchApplicationRelations(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationsRelations")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+		*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+			Results: []params.StringsWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchApplicationRelations("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchApplicationConfig(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationsConfig")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	}), BestVersion: 2}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	watcher, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchApplicationConfigNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	}), BestVersion: 1}
+
+	client := caasfirewaller.NewClient(apiCaller)
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *FirewallerSuite) TestRelatedApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RelatedApplications")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResults{})
+		*(result.(*params.StringsResults)) = params.StringsResults{
+			Results: []params.StringsResult{{
+				Result: []string{"mysql", "redis"},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(apiCaller)
+	related, err := client.RelatedApplications("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(related, jc.DeepEquals, []string{"mysql", "redis"})
+}
@@ -9,8 +9,10 @@ import (
 	"github.com/juju/version"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
@@ -43,6 +45,40 @@ func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchForModelConfigChanges returns a NotifyWatcher that notifies
+// of changes to the model config.
+func (c *Client) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchForModelConfigChanges for CAASOperatorProvisioner facade v%v", apiVersion)
+	}
+	return common.NewModelWatcher(c.facade).WatchForModelConfigChanges()
+}
+
+// WatchApplicationConfig returns a NotifyWatcher that notifies of
+// changes to the application config of the specified application.
+func (c *Client) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	if apiVersion := c.facade.BestAPIVersion(); apiVersion < 2 {
+		return nil, errors.NotSupportedf("WatchApplicationConfig for CAASOperatorProvisioner facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.NotValidf("application name %q", appName)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(appName).String()}},
+	}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchApplicationsConfig", args, &results); err != nil {
+		return nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, maybeNotFound(err)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), results.Results[0]), nil
+}
+
 // ApplicationPassword holds parameters for setting
 // an application password.
 type ApplicationPassword struct {
@@ -108,6 +144,7 @@ type OperatorProvisioningInfo struct {
 	APIAddresses []string
 	Tags         map[string]string
 	CharmStorage *storage.KubernetesFilesystemParams
+	PodConfig    *caas.OperatorPodConfig
 }
 
 // OperatorProvisioningInfo returns the info needed to provision an operator for an application.
@@ -132,9 +169,25 @@ func (c *Client) OperatorProvisioningInfo(applicationName string) (OperatorProvi
 		APIAddresses: info.APIAddresses,
 		Tags:         info.Tags,
 		CharmStorage: filesystemFromParams(info.CharmStorage),
+		PodConfig:    podConfigFromParams(info.PodConfig),
 	}, nil
 }
 
+func podConfigFromParams(in *params.OperatorPodConfig) *caas.OperatorPodConfig {
+	if in == nil {
+		return nil
+	}
+	return &caas.OperatorPodConfig{
+		CPURequest:        in.CPURequest,
+		CPULimit:          in.CPULimit,
+		MemoryRequest:     in.MemoryRequest,
+		MemoryLimit:       in.MemoryLimit,
+		NodeSelector:      in.NodeSelector,
+		Tolerations:       in.Tolerations,
+		PriorityClassName: in.PriorityClassName,
+	}
+}
+
 func filesystemFromParams(in *params.KubernetesFilesystemParams) *storage.KubernetesFilesystemParams {
 	if in == nil {
 		return nil
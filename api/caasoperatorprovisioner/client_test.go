@@ -14,6 +14,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/storage"
 )
@@ -47,6 +48,43 @@ func (s *provisionerSuite) TestWatchApplications(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 }
 
+func (s *provisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "CAASOperatorProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "WatchApplicationsConfig")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-gitlab"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	})
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *provisionerSuite) TestWatchApplicationConfigNotSupported(c *gc.C) {
+	client := caasoperatorprovisioner.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatalf("unexpected API call %q", request)
+			return nil
+		},
+		BestVersion: 1,
+	})
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.WatchForModelConfigChanges()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *provisionerSuite) TestSetPasswords(c *gc.C) {
 	passwords := []caasoperatorprovisioner.ApplicationPassword{
 		{Name: "app", Password: "secret"},
@@ -176,6 +214,12 @@ func (s *provisionerSuite) TestOperatorProvisioningInfo(c *gc.C) {
 					Tags:        map[string]string{"model": "model-tag"},
 					Attributes:  map[string]interface{}{"key": "value"},
 				},
+				PodConfig: &params.OperatorPodConfig{
+					CPURequest:   "100m",
+					MemoryLimit:  "256Mi",
+					NodeSelector: map[string]string{"pool": "system"},
+					Tolerations:  []string{"dedicated=juju:NoSchedule"},
+				},
 			}}}
 		return nil
 	})
@@ -193,6 +237,12 @@ func (s *provisionerSuite) TestOperatorProvisioningInfo(c *gc.C) {
 			ResourceTags: map[string]string{"model": "model-tag"},
 			Attributes:   map[string]interface{}{"key": "value"},
 		},
+		PodConfig: &caas.OperatorPodConfig{
+			CPURequest:   "100m",
+			MemoryLimit:  "256Mi",
+			NodeSelector: map[string]string{"pool": "system"},
+			Tolerations:  []string{"dedicated=juju:NoSchedule"},
+		},
 	})
 }
 
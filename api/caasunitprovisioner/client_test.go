@@ -240,6 +240,43 @@ func (s *unitprovisionerSuite) TestWatchApplicationScale(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *unitprovisionerSuite) TestWatchApplicationConfig(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchApplicationsConfig")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		return nil
+	}), BestVersion: 2}
+
+	client := caasunitprovisioner.NewClient(apiCaller)
+	watcher, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *unitprovisionerSuite) TestWatchApplicationConfigNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	}), BestVersion: 1}
+
+	client := caasunitprovisioner.NewClient(apiCaller)
+	_, err := client.WatchApplicationConfig("gitlab")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *unitprovisionerSuite) TestApplicationScale(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASUnitProvisioner")
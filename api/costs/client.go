@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the costs API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the costs API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Costs")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// EstimateCosts returns the estimated running costs of deploying the
+// specified applications to the model. A result is returned for each
+// application; the cost of an application that could not be estimated
+// records the error.
+func (c *Client) EstimateCosts(applications []params.ApplicationCostParams) ([]params.ApplicationCostResult, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("cost estimation")
+	}
+	args := params.EstimateCostsArgs{Applications: applications}
+	var results params.ApplicationCostResults
+	if err := c.facade.FacadeCall("EstimateCosts", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(applications) {
+		return nil, errors.Errorf("expected %d results, got %d", len(applications), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ModelCosts returns the estimated running costs of the model's
+// applications.
+func (c *Client) ModelCosts() ([]params.ApplicationCostResult, error) {
+	if c.BestAPIVersion() < 1 {
+		return nil, errors.NotSupportedf("cost estimation")
+	}
+	var results params.ApplicationCostResults
+	if err := c.facade.FacadeCall("ModelCosts", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/costs"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+)
+
+type CostsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CostsSuite{})
+
+func (s *CostsSuite) TestEstimateCosts(c *gc.C) {
+	applications := []params.ApplicationCostParams{{
+		ApplicationName: "web",
+		NumUnits:        2,
+		Constraints:     constraints.MustParse("mem=4G"),
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Costs")
+			c.Check(request, gc.Equals, "EstimateCosts")
+			c.Check(arg, jc.DeepEquals, params.EstimateCostsArgs{Applications: applications})
+			c.Assert(result, gc.FitsTypeOf, &params.ApplicationCostResults{})
+			*(result.(*params.ApplicationCostResults)) = params.ApplicationCostResults{
+				Results: []params.ApplicationCostResult{{
+					ApplicationName: "web",
+					Units:           2,
+					Monthly:         100,
+				}},
+			}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	results, err := costs.NewClient(apiCaller).EstimateCosts(applications)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ApplicationCostResult{{
+		ApplicationName: "web",
+		Units:           2,
+		Monthly:         100,
+	}})
+}
+
+func (s *CostsSuite) TestEstimateCostsResultCount(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			return nil
+		},
+		BestVersion: 1,
+	}
+	_, err := costs.NewClient(apiCaller).EstimateCosts([]params.ApplicationCostParams{{}})
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
+
+func (s *CostsSuite) TestModelCosts(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Costs")
+			c.Check(request, gc.Equals, "ModelCosts")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.ApplicationCostResults)) = params.ApplicationCostResults{
+				Results: []params.ApplicationCostResult{{ApplicationName: "web"}},
+			}
+			return nil
+		},
+		BestVersion: 1,
+	}
+	results, err := costs.NewClient(apiCaller).ModelCosts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ApplicationCostResult{{ApplicationName: "web"}})
+}
+
+func (s *CostsSuite) TestModelCostsError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			return errors.New("boom")
+		},
+		BestVersion: 1,
+	}
+	_, err := costs.NewClient(apiCaller).ModelCosts()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *CostsSuite) TestNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+	}
+	_, err := costs.NewClient(apiCaller).ModelCosts()
+	c.Assert(err, gc.ErrorMatches, "cost estimation not supported")
+}
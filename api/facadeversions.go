@@ -11,27 +11,28 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       6,
+	"Action":                       7,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  12,
+	"Application":                  15,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
 	"Backups":                      2,
 	"Block":                        2,
 	"Bundle":                       4,
-	"CAASAgent":                    1,
+	"CAASAgent":                    2,
 	"CAASAdmission":                1,
-	"CAASFirewaller":               1,
+	"CAASFirewaller":               2,
 	"CAASModelOperator":            1,
 	"CAASOperator":                 1,
-	"CAASOperatorProvisioner":      1,
+	"CAASOperatorProvisioner":      2,
 	"CAASOperatorUpgrader":         1,
-	"CAASUnitProvisioner":          1,
+	"CAASUnitProvisioner":          2,
 	"CharmHub":                     1,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
@@ -39,6 +40,7 @@ var facadeVersions = map[string]int{
 	"Client":                       2,
 	"Cloud":                        7,
 	"Controller":                   9,
+	"Costs":                        1,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
@@ -57,7 +59,7 @@ var facadeVersions = map[string]int{
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
-	"InstancePoller":               4,
+	"InstancePoller":               5,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -96,6 +98,7 @@ var facadeVersions = map[string]int{
 	"RemoteRelationWatcher":        1,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
+	"ResourceTagger":               1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
 	"Singular":                     2,
@@ -108,7 +111,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      4,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       16,
+	"Uniter":                       20,
 	"Upgrader":                     1,
 	"UpgradeSeries":                2,
 	"UpgradeSteps":                 2,
@@ -152,6 +152,21 @@ func (m *Machine) SetInstanceStatus(status status.Status, message string, data m
 	return result.OneError()
 }
 
+// MarkInstanceReclaimed records that the machine's instance was reclaimed
+// by the cloud. A NotSupported error is returned if the machine is not
+// constrained to spot instances.
+func (m *Machine) MarkInstanceReclaimed() error {
+	var result params.ErrorResults
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.tag.String()},
+	}}
+	err := m.facade.FacadeCall("MarkInstancesReclaimed", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // SetProviderNetworkConfig updates the provider addresses for this machine.
 func (m *Machine) SetProviderNetworkConfig(ifList network.InterfaceInfos) (network.ProviderAddresses, bool, error) {
 	var results params.SetProviderNetworkConfigResults
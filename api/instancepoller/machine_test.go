@@ -100,6 +100,10 @@ var machineErrorTests = []struct {
 		return m.SetInstanceStatus("", "", nil)
 	},
 	resultsRef: params.ErrorResults{},
+}, {
+	method:     "MarkInstanceReclaimed",
+	wrapper:    (*instancepoller.Machine).MarkInstanceReclaimed,
+	resultsRef: params.ErrorResults{},
 }, {
 	method: "SetProviderNetworkConfig",
 	wrapper: func(m *instancepoller.Machine) error {
@@ -225,6 +229,18 @@ func (s *MachineSuite) TestSetInstanceStatusSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestMarkInstanceReclaimedSuccess(c *gc.C) {
+	expectArgs := params.Entities{Entities: []params.Entity{{Tag: "machine-42"}}}
+	results := params.ErrorResults{
+		Results: []params.ErrorResult{{Error: nil}},
+	}
+	apiCaller := successAPICaller(c, "MarkInstancesReclaimed", expectArgs, results)
+	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
+	err := machine.MarkInstanceReclaimed()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(apiCaller.CallCount, gc.Equals, 1)
+}
+
 func (s *MachineSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	cfg := network.InterfaceInfos{{
 		DeviceIndex: 0,
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/storage"
+)
+
+// InstanceTags holds the tags to set on the cloud instance of a
+// machine.
+type InstanceTags struct {
+	MachineTag names.MachineTag
+	InstanceId instance.Id
+	Tags       map[string]string
+	Error      error
+}
+
+// VolumeTags holds the tags to set on a volume, along with the
+// storage provider and configuration that manage the volume.
+type VolumeTags struct {
+	VolumeTag  names.VolumeTag
+	VolumeId   string
+	Provider   storage.ProviderType
+	Attributes map[string]interface{}
+	Tags       map[string]string
+	Error      error
+}
+
+// Client provides access to the resource tagger API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client-side resource tagger facade.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "ResourceTagger")}
+}
+
+// WatchResourceTags returns a NotifyWatcher that triggers when the
+// tags of the model's cloud resources may have changed.
+func (c *Client) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchResourceTags", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// InstanceTags returns the tags to set on the cloud instances of the
+// model's machines.
+func (c *Client) InstanceTags() ([]InstanceTags, error) {
+	var results params.InstanceTagsResults
+	if err := c.facade.FacadeCall("InstanceTags", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]InstanceTags, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseMachineTag(result.MachineTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out[i] = InstanceTags{
+			MachineTag: tag,
+			InstanceId: instance.Id(result.InstanceId),
+			Tags:       result.Tags,
+		}
+		if result.Error != nil {
+			out[i].Error = result.Error
+		}
+	}
+	return out, nil
+}
+
+// VolumeTags returns the tags to set on the model's volumes.
+func (c *Client) VolumeTags() ([]VolumeTags, error) {
+	var results params.VolumeTagsResults
+	if err := c.facade.FacadeCall("VolumeTags", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]VolumeTags, len(results.Results))
+	for i, result := range results.Results {
+		tag, err := names.ParseVolumeTag(result.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		out[i] = VolumeTags{
+			VolumeTag:  tag,
+			VolumeId:   result.VolumeId,
+			Provider:   storage.ProviderType(result.Provider),
+			Attributes: result.Attributes,
+			Tags:       result.Tags,
+		}
+		if result.Error != nil {
+			out[i].Error = result.Error
+		}
+	}
+	return out, nil
+}
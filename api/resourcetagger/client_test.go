@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestInstanceTags(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ResourceTagger")
+		c.Check(request, gc.Equals, "InstanceTags")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.InstanceTagsResults{})
+		*(result.(*params.InstanceTagsResults)) = params.InstanceTagsResults{
+			Results: []params.InstanceTagsResult{{
+				MachineTag: "machine-0",
+				InstanceId: "i-0",
+				Tags:       map[string]string{"owner": "finance"},
+			}, {
+				MachineTag: "machine-1",
+				InstanceId: "i-1",
+				Error:      &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := resourcetagger.NewClient(apiCaller)
+	results, err := client.InstanceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, resourcetagger.InstanceTags{
+		MachineTag: names.NewMachineTag("0"),
+		InstanceId: instance.Id("i-0"),
+		Tags:       map[string]string{"owner": "finance"},
+	})
+	c.Assert(results[1].MachineTag, gc.Equals, names.NewMachineTag("1"))
+	c.Assert(results[1].Error, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestInstanceTagsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := resourcetagger.NewClient(apiCaller)
+	_, err := client.InstanceTags()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *clientSuite) TestVolumeTags(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ResourceTagger")
+		c.Check(request, gc.Equals, "VolumeTags")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.VolumeTagsResults{})
+		*(result.(*params.VolumeTagsResults)) = params.VolumeTagsResults{
+			Results: []params.VolumeTagsResult{{
+				VolumeTag:  "volume-0",
+				VolumeId:   "vol-0",
+				Provider:   "ebs",
+				Attributes: map[string]interface{}{"volume-type": "gp2"},
+				Tags:       map[string]string{"owner": "finance"},
+			}},
+		}
+		return nil
+	})
+	client := resourcetagger.NewClient(apiCaller)
+	results, err := client.VolumeTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []resourcetagger.VolumeTags{{
+		VolumeTag:  names.NewVolumeTag("0"),
+		VolumeId:   "vol-0",
+		Provider:   "ebs",
+		Attributes: map[string]interface{}{"volume-type": "gp2"},
+		Tags:       map[string]string{"owner": "finance"},
+	}})
+}
+
+func (s *clientSuite) TestWatchResourceTagsError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "WatchResourceTags")
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := resourcetagger.NewClient(apiCaller)
+	_, err := client.WatchResourceTags()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
@@ -25,8 +27,9 @@ var _ = gc.Suite(&actionSuite{})
 func (s *actionSuite) TestAction(c *gc.C) {
 	actionResult := params.ActionResult{
 		Action: &params.Action{
-			Name:       "backup",
-			Parameters: map[string]interface{}{"foo": "bar"},
+			Name:             "backup",
+			Parameters:       map[string]interface{}{"foo": "bar"},
+			ExecutionTimeout: 5 * time.Minute,
 		},
 	}
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -45,6 +48,7 @@ func (s *actionSuite) TestAction(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(a.Name(), gc.Equals, actionResult.Action.Name)
 	c.Assert(a.Params(), jc.DeepEquals, actionResult.Action.Parameters)
+	c.Assert(a.ExecutionTimeout(), gc.Equals, 5*time.Minute)
 }
 
 func (s *actionSuite) TestActionError(c *gc.C) {
@@ -141,6 +145,40 @@ func (s *actionSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "biff")
 }
 
+func (s *actionSuite) TestLogActionOutput(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "LogActionsOutput")
+		c.Assert(arg, gc.DeepEquals, params.ActionOutputParams{
+			Output: []params.ActionOutputChunk{{Tag: "action-666", Stream: "stdout", Data: "hello"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.LogActionOutput(names.NewActionTag("666"), "stdout", "hello")
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *actionSuite) TestLogActionOutputNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 16}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.LogActionOutput(names.NewActionTag("666"), "stdout", "hello")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *actionSuite) TestWatchActionNotifications(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		if objType == "StringsWatcher" {
@@ -4,11 +4,15 @@
 package uniter
 
 import (
+	"fmt"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/api/common/stream"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
@@ -654,6 +658,109 @@ func (u *Unit) LogActionMessage(tag names.ActionTag, message string) error {
 	return result.OneError()
 }
 
+// LogActionOutput records a chunk of output written by the specified
+// action to the named stream.
+func (u *Unit) LogActionOutput(tag names.ActionTag, stream, data string) error {
+	if u.st.facade.BestAPIVersion() < 17 {
+		return errors.NotImplementedf("LogActionOutput() (need V17+)")
+	}
+
+	var result params.ErrorResults
+	args := params.ActionOutputParams{
+		Output: []params.ActionOutputChunk{{Tag: tag.String(), Stream: stream, Data: data}},
+	}
+	err := u.st.facade.FacadeCall("LogActionsOutput", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// RecordHookExecutions adds the given hook, action and command
+// executions to the unit's hook history.
+func (u *Unit) RecordHookExecutions(executions []params.HookExecution) error {
+	if u.st.facade.BestAPIVersion() < 18 {
+		return errors.NotImplementedf("RecordHookExecutions() (need V18+)")
+	}
+
+	var result params.ErrorResults
+	args := params.RecordHookExecutionsArgs{
+		Args: []params.RecordHookExecutionsArg{{Tag: u.tag.String(), Executions: executions}},
+	}
+	err := u.st.facade.FacadeCall("RecordHookExecutions", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// DebugHooksSession returns the "juju debug-hooks" session, attached
+// through the API, which is waiting to debug the unit's hooks, or a
+// NotFound error if there is none.
+func (u *Unit) DebugHooksSession() (params.DebugHooksSession, error) {
+	if u.st.facade.BestAPIVersion() < 20 {
+		return params.DebugHooksSession{}, errors.NotImplementedf("DebugHooksSession() (need V20+)")
+	}
+
+	var results params.DebugHooksSessionResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("DebugHooksSessions", args, &results)
+	if err != nil {
+		return params.DebugHooksSession{}, err
+	}
+	if len(results.Results) != 1 {
+		return params.DebugHooksSession{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.DebugHooksSession{}, result.Error
+	}
+	if result.Result == nil {
+		return params.DebugHooksSession{}, errors.NotFoundf("debug-hooks session for unit %q", u.Name())
+	}
+	return *result.Result, nil
+}
+
+// WatchDebugHooksSession returns a watcher for observing the start
+// and end of the unit's "juju debug-hooks" session attached through
+// the API.
+func (u *Unit) WatchDebugHooksSession() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 20 {
+		return nil, errors.NotImplementedf("WatchDebugHooksSession() (need V20+)")
+	}
+
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("WatchDebugHooksSessions", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(u.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// ConnectDebugHooks opens a stream which attaches the named hook or
+// action to the unit's debug-hooks session with the given ID.
+func (u *Unit) ConnectDebugHooks(sessionID, hookName string) (base.Stream, error) {
+	path := fmt.Sprintf("/units/%s/debug-hooks/agent", u.tag.String())
+	cfg := params.DebugHooksAgentConfig{
+		Session: sessionID,
+		Hook:    hookName,
+	}
+	return stream.Open(u.st.facade.RawAPICaller(), path, cfg)
+}
+
 // UpgradeSeriesStatus returns the upgrade series status of a unit from remote state
 func (u *Unit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	res, err := u.st.UpgradeSeriesUnitStatus()
@@ -4,6 +4,7 @@
 package uniter_test
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/juju/charm/v7"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/api/base"
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -1042,3 +1044,147 @@ func (s *unitSuite) TestCanApplyLXDProfile(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(canApply, jc.IsTrue)
 }
+
+func (s *unitSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	executions := []params.HookExecution{{
+		Kind:     "hook",
+		Name:     "install",
+		Started:  started,
+		Finished: started.Add(time.Second),
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "RecordHookExecutions")
+		c.Assert(arg, gc.DeepEquals, params.RecordHookExecutionsArgs{
+			Args: []params.RecordHookExecutionsArg{{Tag: "unit-mysql-0", Executions: executions}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{&params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookExecutions(executions)
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *unitSuite) TestRecordHookExecutionsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 17}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	err := unit.RecordHookExecutions(nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestDebugHooksSession(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "DebugHooksSessions")
+		c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.DebugHooksSessionResults{})
+		*(result.(*params.DebugHooksSessionResults)) = params.DebugHooksSessionResults{
+			Results: []params.DebugHooksSessionResult{{
+				Result: &params.DebugHooksSession{ID: "session-id", Hooks: []string{"install"}},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	session, err := unit.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, jc.DeepEquals, params.DebugHooksSession{ID: "session-id", Hooks: []string{"install"}})
+}
+
+func (s *unitSuite) TestDebugHooksSessionNone(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.DebugHooksSessionResults)) = params.DebugHooksSessionResults{
+			Results: []params.DebugHooksSessionResult{{}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *unitSuite) TestDebugHooksSessionNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestWatchDebugHooksSessionNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected api call %q", request)
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.WatchDebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *unitSuite) TestWatchDebugHooksSessionError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "WatchDebugHooksSessions")
+		c.Assert(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "unit-mysql-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	caller := basetesting.BestVersionCaller{apiCaller, 20}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.WatchDebugHooksSession()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *unitSuite) TestConnectDebugHooks(c *gc.C) {
+	caller := &streamCaller{BestVersionCaller: basetesting.BestVersionCaller{BestVersion: 20}}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(client, names.NewUnitTag("mysql/0"))
+	_, err := unit.ConnectDebugHooks("session-id", "install")
+	c.Assert(err, gc.ErrorMatches, "cannot connect to /units/unit-mysql-0/debug-hooks/agent: boom")
+	c.Assert(caller.path, gc.Equals, "/units/unit-mysql-0/debug-hooks/agent")
+	c.Assert(caller.attrs, jc.DeepEquals, url.Values{
+		"session": {"session-id"},
+		"hook":    {"install"},
+	})
+}
+
+// streamCaller records the stream connection requested of it.
+type streamCaller struct {
+	basetesting.BestVersionCaller
+	path  string
+	attrs url.Values
+}
+
+func (c *streamCaller) ConnectStream(path string, attrs url.Values) (base.Stream, error) {
+	c.path = path
+	c.attrs = attrs
+	return nil, errors.New("boom")
+}
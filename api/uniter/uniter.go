@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
@@ -253,8 +254,9 @@ func (st *State) Action(tag names.ActionTag) (*Action, error) {
 		return nil, err
 	}
 	return &Action{
-		name:   result.Action.Name,
-		params: result.Action.Parameters,
+		name:             result.Action.Name,
+		params:           result.Action.Parameters,
+		executionTimeout: result.Action.ExecutionTimeout,
 	}, nil
 }
 
@@ -423,6 +425,57 @@ func (st *State) WatchRelationUnits(
 	return w, nil
 }
 
+// UpdateStatusHookInterval returns the interval at which the unit runs
+// its update-status hook. The interval may be set in the config of the
+// unit's application, overriding the model's update-status-hook-interval.
+func (st *State) UpdateStatusHookInterval() (time.Duration, error) {
+	if st.BestAPIVersion() < 19 {
+		return st.ModelWatcher.UpdateStatusHookInterval()
+	}
+	var results params.UpdateStatusHookIntervalResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("UpdateStatusHookInterval", args, &results)
+	if err != nil {
+		return 0, err
+	}
+	if len(results.Results) != 1 {
+		return 0, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.Interval, nil
+}
+
+// WatchUpdateStatusHookInterval returns a NotifyWatcher that fires when
+// the interval at which the unit runs its update-status hook may have
+// changed.
+func (st *State) WatchUpdateStatusHookInterval() (watcher.NotifyWatcher, error) {
+	if st.BestAPIVersion() < 19 {
+		return st.ModelWatcher.WatchUpdateStatusHookInterval()
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: st.unitTag.String()}},
+	}
+	err := st.facade.FacadeCall("WatchUpdateStatusHookInterval", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // ErrIfNotVersionFn returns a function which can be used to check for
 // the minimum supported version, and, if appropriate, generate an
 // error.
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type updateStatusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&updateStatusSuite{})
+
+func (s *updateStatusSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "UpdateStatusHookInterval")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.UpdateStatusHookIntervalResults{})
+		*(result.(*params.UpdateStatusHookIntervalResults)) = params.UpdateStatusHookIntervalResults{
+			Results: []params.UpdateStatusHookIntervalResult{{Interval: 30 * time.Minute}},
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	interval, err := client.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(interval, gc.Equals, 30*time.Minute)
+}
+
+func (s *updateStatusSuite) TestUpdateStatusHookIntervalError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.UpdateStatusHookIntervalResults)) = params.UpdateStatusHookIntervalResults{
+			Results: []params.UpdateStatusHookIntervalResult{{Error: &params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	_, err := client.UpdateStatusHookInterval()
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *updateStatusSuite) TestUpdateStatusHookIntervalOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		// Older controllers only offer the model's interval.
+		c.Assert(request, gc.Equals, "ModelConfig")
+		return errors.New("boom")
+	})
+	caller := testing.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	_, err := client.UpdateStatusHookInterval()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *updateStatusSuite) TestWatchUpdateStatusHookInterval(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(request, gc.Equals, "WatchUpdateStatusHookInterval")
+		c.Assert(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+		*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+			Results: []params.NotifyWatchResult{{Error: &params.Error{Message: "biff"}}},
+		}
+		return nil
+	})
+	caller := testing.BestVersionCaller{apiCaller, 19}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	_, err := client.WatchUpdateStatusHookInterval()
+	c.Assert(err, gc.ErrorMatches, "biff")
+}
+
+func (s *updateStatusSuite) TestWatchUpdateStatusHookIntervalOldFacadeVersion(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(request, gc.Equals, "WatchForModelConfigChanges")
+		return errors.New("boom")
+	})
+	caller := testing.BestVersionCaller{apiCaller, 18}
+	client := uniter.NewState(caller, names.NewUnitTag("mysql/0"))
+	_, err := client.WatchUpdateStatusHookInterval()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
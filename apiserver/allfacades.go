@@ -54,6 +54,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/costs"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
@@ -73,6 +74,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
@@ -96,6 +98,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
@@ -136,7 +139,9 @@ func AllFacades() *facade.Registry {
 	reg("Action", 4, action.NewActionAPIV4)
 	reg("Action", 5, action.NewActionAPIV5)
 	reg("Action", 6, action.NewActionAPIV6)
+	reg("Action", 7, action.NewActionAPIV7)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -156,6 +161,9 @@ func AllFacades() *facade.Registry {
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
 	reg("Application", 12, application.NewFacadeV12) // Adds UnitsInfo()
+	reg("Application", 13, application.NewFacadeV13) // Adds ImportK8sWorkload()
+	reg("Application", 14, application.NewFacadeV14) // Adds BackupApplications() and RestoreApplications()
+	reg("Application", 15, application.NewFacadeV15) // Adds SetIngressTLSCertificates()
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
@@ -183,14 +191,18 @@ func AllFacades() *facade.Registry {
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
-	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacade)
+	reg("CAASFirewaller", 1, caasfirewaller.NewStateFacadeV1)
+	reg("CAASFirewaller", 2, caasfirewaller.NewStateFacade) // Adds WatchApplicationsConfig, WatchApplicationsRelations, RelatedApplications and model config watching.
 	reg("CAASOperator", 1, caasoperator.NewStateFacade)
 	reg("CAASAdmission", 1, caasadmission.NewStateFacade)
-	reg("CAASAgent", 1, caasagent.NewStateFacade)
+	reg("CAASAgent", 1, caasagent.NewStateFacadeV1)
+	reg("CAASAgent", 2, caasagent.NewStateFacade) // Adds ZoneCloudSpecs(), for operators too.
 	reg("CAASModelOperator", 1, caasmodeloperator.NewAPIFromContext)
-	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI)
+	reg("CAASOperatorProvisioner", 1, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPIV1)
+	reg("CAASOperatorProvisioner", 2, caasoperatorprovisioner.NewStateCAASOperatorProvisionerAPI) // Adds WatchApplicationsConfig() and WatchForModelConfigChanges()
 	reg("CAASOperatorUpgrader", 1, caasoperatorupgrader.NewStateCAASOperatorUpgraderAPI)
-	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacade)
+	reg("CAASUnitProvisioner", 1, caasunitprovisioner.NewStateFacadeV1)
+	reg("CAASUnitProvisioner", 2, caasunitprovisioner.NewStateFacade) // Adds WatchApplicationsConfig()
 
 	reg("Controller", 3, controller.NewControllerAPIv3)
 	reg("Controller", 4, controller.NewControllerAPIv4)
@@ -199,6 +211,7 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
 	reg("Controller", 9, controller.NewControllerAPIv9)
+	reg("Costs", 1, costs.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPIV1)
 	reg("CrossModelRelations", 2, crossmodelrelations.NewStateCrossModelRelationsAPI) // Adds WatchRelationChanges, removes WatchRelationUnits
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
@@ -225,7 +238,8 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 2, instancemutater.NewFacadeV2)
 
 	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
-	reg("InstancePoller", 4, instancepoller.NewFacade)
+	reg("InstancePoller", 4, instancepoller.NewFacadeV4)
+	reg("InstancePoller", 5, instancepoller.NewFacade)
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
@@ -301,6 +315,7 @@ func AllFacades() *facade.Registry {
 
 	reg("Resources", 1, resources.NewPublicFacade)
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
+	reg("ResourceTagger", 1, resourcetagger.NewFacade)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
@@ -342,7 +357,11 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 13, uniter.NewUniterAPIV13)
 	reg("Uniter", 14, uniter.NewUniterAPIV14)
 	reg("Uniter", 15, uniter.NewUniterAPIV15)
-	reg("Uniter", 16, uniter.NewUniterAPI)
+	reg("Uniter", 16, uniter.NewUniterAPIV16)
+	reg("Uniter", 17, uniter.NewUniterAPIV17)
+	reg("Uniter", 18, uniter.NewUniterAPIV18)
+	reg("Uniter", 19, uniter.NewUniterAPIV19)
+	reg("Uniter", 20, uniter.NewUniterAPI)
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 
@@ -587,6 +587,8 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
+	debugHooksClientHandler := newDebugHooksClientHandler(httpCtxt, srv.shared.centralHub)
+	debugHooksAgentHandler := newDebugHooksAgentHandler(httpCtxt, srv.shared.centralHub)
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
@@ -756,6 +758,16 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	}, {
 		pattern: modelRoutePrefix + "/units/:unit/resources/:resource",
 		handler: unitResourcesHandler,
+	}, {
+		pattern:    modelRoutePrefix + "/units/:unit/debug-hooks",
+		handler:    debugHooksClientHandler,
+		tracked:    true,
+		authorizer: tagKindAuthorizer{names.UserTagKind},
+	}, {
+		pattern:    modelRoutePrefix + "/units/:unit/debug-hooks/agent",
+		handler:    debugHooksAgentHandler,
+		tracked:    true,
+		authorizer: tagKindAuthorizer{names.UnitTagKind, names.ApplicationTagKind},
 	}, {
 		pattern: modelRoutePrefix + "/backups",
 		handler: backupHandler,
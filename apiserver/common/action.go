@@ -158,8 +158,9 @@ func Actions(args params.Entities, actionFn func(string) (state.Action, error))
 			continue
 		}
 		results.Results[i].Action = &params.Action{
-			Name:       action.Name(),
-			Parameters: action.Parameters(),
+			Name:             action.Name(),
+			Parameters:       action.Parameters(),
+			ExecutionTimeout: action.ExecutionTimeout(),
 		}
 	}
 
@@ -264,16 +265,17 @@ func ConvertActions(ar state.ActionReceiver, fn GetActionsFn, compat bool) ([]pa
 // MakeActionResult does the actual type conversion from state.Action
 // to params.ActionResult.
 func MakeActionResult(actionReceiverTag names.Tag, action state.Action, compat bool) params.ActionResult {
-	output, message := action.Results()
-	if !compat {
+	output, message, err := action.Results()
+	if err == nil && !compat {
 		convertActionOutput(output)
 	}
 	result := params.ActionResult{
 		Action: &params.Action{
-			Receiver:   actionReceiverTag.String(),
-			Tag:        action.ActionTag().String(),
-			Name:       action.Name(),
-			Parameters: action.Parameters(),
+			Receiver:         actionReceiverTag.String(),
+			Tag:              action.ActionTag().String(),
+			Name:             action.Name(),
+			Parameters:       action.Parameters(),
+			ExecutionTimeout: action.ExecutionTimeout(),
 		},
 		Status:    string(action.Status()),
 		Message:   message,
@@ -288,6 +290,9 @@ func MakeActionResult(actionReceiverTag names.Tag, action state.Action, compat b
 			Message:   m.Message(),
 		})
 	}
+	if err != nil {
+		result.Error = ServerError(err)
+	}
 
 	return result
 }
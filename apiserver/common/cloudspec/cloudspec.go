@@ -87,6 +87,12 @@ func (s cloudSpecAPI) GetCloudSpec(tag names.ModelTag) params.CloudSpecResult {
 		result.Error = common.ServerError(err)
 		return result
 	}
+	return CloudSpecResult(spec)
+}
+
+// CloudSpecResult returns the API result holding the specified cloud spec.
+func CloudSpecResult(spec environs.CloudSpec) params.CloudSpecResult {
+	var result params.CloudSpecResult
 	var paramsCloudCredential *params.CloudCredential
 	if spec.Credential != nil && spec.Credential.AuthType() != "" {
 		paramsCloudCredential = &params.CloudCredential{
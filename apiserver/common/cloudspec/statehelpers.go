@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -60,6 +61,41 @@ func MakeCloudSpecGetterForModel(st *state.State) func(names.ModelTag) (environs
 	}
 }
 
+// MakeZoneCloudSpecGetterForModel returns a function which returns a
+// CloudSpec for one of the clouds listed as cluster zones in the config
+// of the model associated with the given state.State. The credential
+// used is the model owner's credential named for the zone in the
+// model config.
+func MakeZoneCloudSpecGetterForModel(st *state.State) func(string) (environs.CloudSpec, error) {
+	return func(cloudName string) (environs.CloudSpec, error) {
+		m, err := st.Model()
+		if err != nil {
+			return environs.CloudSpec{}, errors.Trace(err)
+		}
+		cfg, err := m.Config()
+		if err != nil {
+			return environs.CloudSpec{}, errors.Trace(err)
+		}
+		credentialName, err := caas.ClusterZoneCredential(cfg, cloudName)
+		if err != nil {
+			return environs.CloudSpec{}, errors.Trace(err)
+		}
+		zoneCloud, err := st.Cloud(cloudName)
+		if err != nil {
+			return environs.CloudSpec{}, errors.Trace(err)
+		}
+		id := cloudName + "/" + m.Owner().Id() + "/" + credentialName
+		if !names.IsValidCloudCredential(id) {
+			return environs.CloudSpec{}, errors.NotValidf("credential %q for cluster zone %q", credentialName, cloudName)
+		}
+		credential, err := st.CloudCredential(names.NewCloudCredentialTag(id))
+		if err != nil {
+			return environs.CloudSpec{}, errors.Trace(err)
+		}
+		return stateenvirons.CloudSpec(zoneCloud, "", &credential)
+	}
+}
+
 // MakeCloudSpecWatcherForModel returns a function which returns a
 // NotifyWatcher for cloud spec changes for a single model.
 // Attempts to request a watcher for any other model other than the
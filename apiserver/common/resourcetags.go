@@ -0,0 +1,151 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+)
+
+// ApplicationConfigGetter provides the application config of the
+// applications in a model.
+type ApplicationConfigGetter interface {
+	// ApplicationConfig returns the application config of the
+	// named application.
+	ApplicationConfig(appName string) (application.ConfigAttributes, error)
+}
+
+// NewStateApplicationConfigGetter returns an ApplicationConfigGetter
+// backed by the given state.
+func NewStateApplicationConfigGetter(st *state.State) ApplicationConfigGetter {
+	return stateApplicationConfigGetter{st}
+}
+
+type stateApplicationConfigGetter struct {
+	st *state.State
+}
+
+// ApplicationConfig is part of the ApplicationConfigGetter interface.
+func (g stateApplicationConfigGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	app, err := g.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ApplicationConfig()
+}
+
+// ResourceTagger computes the tags to set on a model's cloud resources,
+// from the resource tags in the model config and in the application
+// config of the applications that the resources belong to. It
+// implements tags.ApplicationResourceTagger.
+type ResourceTagger struct {
+	config    *config.Config
+	vars      tags.TemplateVars
+	appConfig ApplicationConfigGetter
+	appTags   map[string]map[string]string
+}
+
+// NewResourceTagger returns a new ResourceTagger for the model with the
+// given config and owner. The ResourceTagger caches application resource
+// tags, so it should be discarded once the tags it computes are used.
+func NewResourceTagger(cfg *config.Config, owner names.UserTag, appConfig ApplicationConfigGetter) *ResourceTagger {
+	return &ResourceTagger{
+		config: cfg,
+		vars: tags.TemplateVars{
+			Model:      cfg.Name(),
+			ModelOwner: owner.Id(),
+		},
+		appConfig: appConfig,
+		appTags:   make(map[string]map[string]string),
+	}
+}
+
+// ResourceTags is part of the tags.ResourceTagger interface. It returns
+// the resource tags from the model config.
+func (t *ResourceTagger) ResourceTags() (map[string]string, bool) {
+	return t.config.ResourceTags()
+}
+
+// TemplateVars is part of the tags.ApplicationResourceTagger interface.
+func (t *ResourceTagger) TemplateVars() tags.TemplateVars {
+	return t.vars
+}
+
+// ApplicationResourceTags is part of the tags.ApplicationResourceTagger
+// interface. It returns the resource tags from the application config of
+// the named application. An application that no longer exists has no
+// resource tags.
+func (t *ResourceTagger) ApplicationResourceTags(appName string) (map[string]string, error) {
+	if appTags, ok := t.appTags[appName]; ok {
+		return appTags, nil
+	}
+	cfg, err := t.appConfig.ApplicationConfig(appName)
+	if errors.IsNotFound(err) {
+		cfg = nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	appTags, err := cfg.GetStringMap(application.ResourceTagsConfigOptionName, nil)
+	if err != nil {
+		return nil, errors.Annotatef(err, "application %q resource tags", appName)
+	}
+	t.appTags[appName] = appTags
+	return appTags, nil
+}
+
+// InstanceTags returns the tags to set on the instance of the machine
+// with the given ID, jobs and principal units. The tags include the
+// resource tags of the applications of the units, and templated tag
+// values are expanded with the details of the machine and its units.
+func (t *ResourceTagger) InstanceTags(
+	controllerUUID, machineId string,
+	jobs []model.MachineJob,
+	unitNames []string,
+) (map[string]string, error) {
+	unitNames = append([]string(nil), unitNames...)
+	sort.Strings(unitNames)
+	appNames := set.NewStrings()
+	for _, unitName := range unitNames {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		appNames.Add(appName)
+	}
+
+	instanceTags := instancecfg.InstanceTags(t.config.UUID(), controllerUUID, t, jobs)
+	for _, appName := range appNames.SortedValues() {
+		appTags, err := t.ApplicationResourceTags(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for k, v := range appTags {
+			instanceTags[k] = v
+		}
+	}
+	vars := t.vars
+	vars.Application = strings.Join(appNames.SortedValues(), " ")
+	vars.Unit = strings.Join(unitNames, " ")
+	vars.Machine = machineId
+	instanceTags = tags.ExpandTemplates(instanceTags, vars)
+
+	if len(unitNames) > 0 {
+		instanceTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
+	}
+	machineTag := names.NewMachineTag(machineId)
+	instanceTags[tags.JujuMachine] = fmt.Sprintf("%s-%s", t.config.Name(), machineTag.String())
+	return instanceTags, nil
+}
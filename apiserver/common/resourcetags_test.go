@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/testing"
+)
+
+type resourceTaggerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+type fakeApplicationConfigGetter map[string]application.ConfigAttributes
+
+func (f fakeApplicationConfigGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	cfg, ok := f[appName]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", appName)
+	}
+	return cfg, nil
+}
+
+func (s *resourceTaggerSuite) newTagger(c *gc.C) *common.ResourceTagger {
+	cfg := testing.CustomModelConfig(c, testing.Attrs{
+		"resource-tags": "team=finance owner={model-owner} where={model}/{machine}",
+	})
+	return common.NewResourceTagger(cfg, names.NewUserTag("fred"), fakeApplicationConfigGetter{
+		"mysql": {
+			"resource-tags": map[string]interface{}{
+				"cost-center": "1234",
+				"app":         "{application}",
+			},
+		},
+		"wordpress": {
+			"resource-tags": map[string]interface{}{
+				"cost-center": "5678",
+				"unit":        "{unit}",
+			},
+		},
+		"nginx": {},
+	})
+}
+
+func (s *resourceTaggerSuite) TestApplicationResourceTags(c *gc.C) {
+	tagger := s.newTagger(c)
+	appTags, err := tagger.ApplicationResourceTags("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appTags, jc.DeepEquals, map[string]string{
+		"cost-center": "1234",
+		"app":         "{application}",
+	})
+
+	appTags, err = tagger.ApplicationResourceTags("nginx")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appTags, gc.HasLen, 0)
+
+	appTags, err = tagger.ApplicationResourceTags("missing")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appTags, gc.HasLen, 0)
+}
+
+func (s *resourceTaggerSuite) TestTemplateVars(c *gc.C) {
+	tagger := s.newTagger(c)
+	c.Assert(tagger.TemplateVars(), jc.DeepEquals, tags.TemplateVars{
+		Model:      "testmodel",
+		ModelOwner: "fred",
+	})
+}
+
+func (s *resourceTaggerSuite) TestInstanceTags(c *gc.C) {
+	tagger := s.newTagger(c)
+	instanceTags, err := tagger.InstanceTags(
+		testing.ControllerTag.Id(), "3",
+		[]model.MachineJob{model.JobHostUnits},
+		[]string{"wordpress/0", "mysql/1"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceTags, jc.DeepEquals, map[string]string{
+		tags.JujuModel:         testing.ModelTag.Id(),
+		tags.JujuController:    testing.ControllerTag.Id(),
+		tags.JujuMachine:       "testmodel-machine-3",
+		tags.JujuUnitsDeployed: "mysql/1 wordpress/0",
+		"team":                 "finance",
+		"owner":                "fred",
+		"where":                "testmodel/3",
+		"app":                  "mysql wordpress",
+		"unit":                 "mysql/1 wordpress/0",
+		// Applications are applied in name order.
+		"cost-center": "5678",
+	})
+}
+
+func (s *resourceTaggerSuite) TestInstanceTagsNoUnits(c *gc.C) {
+	tagger := s.newTagger(c)
+	instanceTags, err := tagger.InstanceTags(
+		testing.ControllerTag.Id(), "0",
+		[]model.MachineJob{model.JobManageModel},
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceTags, jc.DeepEquals, map[string]string{
+		tags.JujuModel:        testing.ModelTag.Id(),
+		tags.JujuController:   testing.ControllerTag.Id(),
+		tags.JujuIsController: "true",
+		tags.JujuMachine:      "testmodel-machine-0",
+		"team":                "finance",
+		"owner":               "fred",
+		"where":               "testmodel/0",
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -196,3 +197,21 @@ func (v *fakeFilesystemAttachment) Info() (state.FilesystemAttachmentInfo, error
 	}
 	return *v.info, nil
 }
+
+type fakeApplicationResourceTagger struct {
+	modelTags map[string]string
+	appTags   map[string]map[string]string
+	vars      tags.TemplateVars
+}
+
+func (t *fakeApplicationResourceTagger) ResourceTags() (map[string]string, bool) {
+	return t.modelTags, true
+}
+
+func (t *fakeApplicationResourceTagger) ApplicationResourceTags(appName string) (map[string]string, error) {
+	return t.appTags[appName], nil
+}
+
+func (t *fakeApplicationResourceTagger) TemplateVars() tags.TemplateVars {
+	return t.vars
+}
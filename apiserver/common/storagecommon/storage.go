@@ -263,19 +263,54 @@ func MaybeAssignedStorageInstance(
 
 // StorageTags returns the tags that should be set on a volume or filesystem,
 // if the provider supports them.
+//
+// If the tagger is a tags.ApplicationResourceTagger, the resource tags
+// of the application that owns the storage are included. Templated tag
+// values are expanded with the details of the storage's owner.
 func StorageTags(
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
 	tagger tags.ResourceTagger,
 ) (map[string]string, error) {
+	var (
+		vars    tags.TemplateVars
+		owner   names.Tag
+		ownerOK bool
+	)
+	appTagger, _ := tagger.(tags.ApplicationResourceTagger)
+	if appTagger != nil {
+		vars = appTagger.TemplateVars()
+	}
+	if storageInstance != nil {
+		owner, ownerOK = storageInstance.Owner()
+	}
 	storageTags := tags.ResourceTags(
 		names.NewModelTag(modelUUID),
 		names.NewControllerTag(controllerUUID),
 		tagger,
 	)
+	if ownerOK {
+		switch owner := owner.(type) {
+		case names.UnitTag:
+			vars.Application, _ = names.UnitApplication(owner.Id())
+			vars.Unit = owner.Id()
+		case names.ApplicationTag:
+			vars.Application = owner.Id()
+		}
+		if appTagger != nil && vars.Application != "" {
+			appTags, err := appTagger.ApplicationResourceTags(vars.Application)
+			if err != nil {
+				return nil, errors.Annotatef(err, "getting resource tags for application %q", vars.Application)
+			}
+			for k, v := range appTags {
+				storageTags[k] = v
+			}
+		}
+	}
+	storageTags = tags.ExpandTemplates(storageTags, vars)
 	if storageInstance != nil {
 		storageTags[tags.JujuStorageInstance] = storageInstance.Tag().Id()
-		if owner, ok := storageInstance.Owner(); ok {
+		if ownerOK {
 			storageTags[tags.JujuStorageOwner] = owner.Id()
 		}
 	}
@@ -9,7 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
-	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
@@ -21,7 +21,7 @@ func VolumeParams(
 	v state.Volume,
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
-	environConfig *config.Config,
+	tagger tags.ResourceTagger,
 	poolManager poolmanager.PoolManager,
 	registry storage.ProviderRegistry,
 ) (params.VolumeParams, error) {
@@ -40,7 +40,7 @@ func VolumeParams(
 		size = volumeInfo.Size
 	}
 
-	volumeTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, environConfig)
+	volumeTags, err := StorageTags(storageInstance, modelUUID, controllerUUID, tagger)
 	if err != nil {
 		return params.VolumeParams{}, errors.Annotate(err, "computing storage tags")
 	}
@@ -89,3 +89,40 @@ func (*volumesSuite) TestVolumeParamsStorageTags(c *gc.C) {
 		},
 	})
 }
+
+func (*volumesSuite) TestVolumeParamsApplicationTags(c *gc.C) {
+	volumeTag := names.NewVolumeTag("100")
+	storageTag := names.NewStorageTag("mystore/0")
+	unitTag := names.NewUnitTag("mysql/123")
+	tagger := &fakeApplicationResourceTagger{
+		modelTags: map[string]string{
+			"owner": "{model-owner}",
+			"name":  "{model}-{unit}",
+		},
+		appTags: map[string]map[string]string{
+			"mysql": {"app": "{application}", "owner": "dba"},
+		},
+		vars: tags.TemplateVars{Model: "testmodel", ModelOwner: "fred"},
+	}
+	p, err := storagecommon.VolumeParams(
+		&fakeVolume{tag: volumeTag, params: &state.VolumeParams{
+			Pool: "loop", Size: 1024,
+		}},
+		&fakeStorageInstance{tag: storageTag, owner: unitTag},
+		testing.ModelTag.Id(),
+		testing.ControllerTag.Id(),
+		tagger,
+		&fakePoolManager{},
+		provider.CommonStorageProviders(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Tags, jc.DeepEquals, map[string]string{
+		tags.JujuController:      testing.ControllerTag.Id(),
+		tags.JujuModel:           testing.ModelTag.Id(),
+		tags.JujuStorageInstance: "mystore/0",
+		tags.JujuStorageOwner:    "mysql/123",
+		"owner":                  "dba",
+		"name":                   "testmodel-mysql/123",
+		"app":                    "mysql",
+	})
+}
@@ -0,0 +1,490 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/base64"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/schema"
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/featureflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/pubsub/debughooks"
+	"github.com/juju/juju/state"
+)
+
+// debugHooksPingTimeout is how long to wait for the API server of
+// a debug-hooks client to show that the client is still connected.
+const debugHooksPingTimeout = 10 * time.Second
+
+// debugHooksUnit is the state of a unit used to run
+// its debug-hooks sessions.
+type debugHooksUnit interface {
+	StartDebugHooksSession(hooks []string) (string, error)
+	EndDebugHooksSession(id string) error
+	DebugHooksSession() (state.DebugHooksSession, error)
+}
+
+// debugHooksHandler serves the websockets which attach a "juju
+// debug-hooks" client, and a unit agent, to a debug-hooks session.
+// The messages of a session are relayed between the two over the
+// central hub, so the client and the agent may be connected to
+// different API servers.
+type debugHooksHandler struct {
+	hub         SharedHub
+	stop        <-chan struct{}
+	pingTimeout time.Duration
+
+	// getUnit authenticates the request, and returns the unit it
+	// addresses along with a function releasing the unit's state.
+	getUnit func(req *http.Request) (debugHooksUnit, func(), error)
+
+	// agent is true for the websocket of the unit agent,
+	// and false for that of the client.
+	agent bool
+}
+
+func newDebugHooksClientHandler(ctxt httpContext, hub SharedHub) *debugHooksHandler {
+	getUnit := func(req *http.Request) (debugHooksUnit, func(), error) {
+		st, entity, err := ctxt.stateAndEntityForRequestAuthenticatedUser(req)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		// Debugging hooks gives the same access to
+		// the unit as "juju ssh", so needs the same
+		// permission.
+		if err := checkModelAdmin(st, entity.Tag()); err != nil {
+			st.Release()
+			return nil, nil, errors.Trace(err)
+		}
+		unit, err := debugHooksRequestUnit(st, req)
+		if err != nil {
+			st.Release()
+			return nil, nil, errors.Trace(err)
+		}
+		return unit, func() { st.Release() }, nil
+	}
+	return &debugHooksHandler{
+		hub:         hub,
+		stop:        ctxt.stop(),
+		pingTimeout: debugHooksPingTimeout,
+		getUnit:     getUnit,
+	}
+}
+
+func newDebugHooksAgentHandler(ctxt httpContext, hub SharedHub) *debugHooksHandler {
+	getUnit := func(req *http.Request) (debugHooksUnit, func(), error) {
+		st, entity, err := ctxt.stateForRequestAuthenticatedTag(req, names.UnitTagKind, names.ApplicationTagKind)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		unit, err := debugHooksRequestUnit(st, req)
+		if err != nil {
+			st.Release()
+			return nil, nil, errors.Trace(err)
+		}
+		// Only the agent of the unit, or of its
+		// application, may attach its hooks.
+		if tag := entity.Tag(); tag != unit.Tag() && tag != names.NewApplicationTag(unit.ApplicationName()) {
+			st.Release()
+			return nil, nil, common.ErrPerm
+		}
+		return unit, func() { st.Release() }, nil
+	}
+	return &debugHooksHandler{
+		hub:         hub,
+		stop:        ctxt.stop(),
+		pingTimeout: debugHooksPingTimeout,
+		getUnit:     getUnit,
+		agent:       true,
+	}
+}
+
+// checkModelAdmin returns an error unless the user has admin access
+// to the model, or superuser access to the controller.
+func checkModelAdmin(st *state.PooledState, user names.Tag) error {
+	ok, err := common.HasPermission(st.UserPermission, user, permission.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	ok, err = common.HasPermission(st.UserPermission, user, permission.AdminAccess, names.NewModelTag(st.ModelUUID()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		return nil
+	}
+	return common.ErrPerm
+}
+
+// debugHooksRequestUnit returns the unit named in the request.
+func debugHooksRequestUnit(st *state.PooledState, req *http.Request) (*state.Unit, error) {
+	tag, err := names.ParseUnitTag(req.URL.Query().Get(":unit"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unit, err := st.Unit(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *debugHooksHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		unit, release, err := h.getUnit(req)
+		if err != nil {
+			h.sendError(conn, req, err)
+			return
+		}
+		defer release()
+
+		query := req.URL.Query()
+		query.Del(":modeluuid")
+		query.Del(":unit")
+		if h.agent {
+			var cfg params.DebugHooksAgentConfig
+			if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+				h.sendError(conn, req, errors.Annotate(err, "decoding schema"))
+				return
+			}
+			h.serveAgent(conn, req, unit, cfg)
+		} else {
+			var cfg params.DebugHooksClientConfig
+			if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+				h.sendError(conn, req, errors.Annotate(err, "decoding schema"))
+				return
+			}
+			h.serveClient(conn, req, unit, cfg)
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// serveClient starts a debug-hooks session for the unit, and relays
+// it to and from the client until the client goes away.
+func (h *debugHooksHandler) serveClient(conn *websocket.Conn, req *http.Request, unit debugHooksUnit, cfg params.DebugHooksClientConfig) {
+	id, err := h.startSession(unit, cfg.Hooks)
+	if err != nil {
+		h.sendError(conn, req, err)
+		return
+	}
+	defer func() {
+		// Kill the shell of any hook being debugged.
+		h.publish(debughooks.ToAgentTopic, debughooks.Message{SessionID: id, Type: debughooks.Ended})
+		if err := unit.EndDebugHooksSession(id); err != nil {
+			logger.Errorf("ending debug-hooks session: %v", err)
+		}
+	}()
+	fromAgent, unsubscribe, err := h.subscribe(debughooks.ToClientTopic, id)
+	if err != nil {
+		h.sendError(conn, req, err)
+		return
+	}
+	defer unsubscribe()
+	h.sendError(conn, req, nil)
+
+	fromHub := func(m debughooks.Message) (*params.DebugHooksMessage, bool) {
+		switch m.Type {
+		case debughooks.Ping:
+			h.publish(debughooks.ToAgentTopic, debughooks.Message{SessionID: id, Type: debughooks.Pong})
+		case debughooks.Attached, debughooks.Output, debughooks.Finished:
+			return socketMessage(m), false
+		}
+		return nil, false
+	}
+	fromSocket := func(m params.DebugHooksMessage) bool {
+		return m.Type == debughooks.Input || m.Type == debughooks.EOF
+	}
+	h.relay(conn, id, fromAgent, fromHub, debughooks.ToAgentTopic, fromSocket)
+}
+
+// startSession starts a debug-hooks session for the unit, replacing
+// any session whose client is no longer connected.
+func (h *debugHooksHandler) startSession(unit debugHooksUnit, hooks []string) (string, error) {
+	id, err := unit.StartDebugHooksSession(hooks)
+	if !errors.IsAlreadyExists(err) {
+		return id, errors.Trace(err)
+	}
+	session, err := unit.DebugHooksSession()
+	if errors.IsNotFound(err) {
+		// The session has just ended.
+		return unit.StartDebugHooksSession(hooks)
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if live, err := h.sessionLive(session.ID); err != nil {
+		return "", errors.Trace(err)
+	} else if live {
+		return "", errors.New("unit is already being debugged")
+	}
+	if err := unit.EndDebugHooksSession(session.ID); err != nil {
+		return "", errors.Trace(err)
+	}
+	return unit.StartDebugHooksSession(hooks)
+}
+
+// serveAgent attaches the agent to the unit's debug-hooks session, and
+// relays the session to and from the agent until the hook finishes.
+func (h *debugHooksHandler) serveAgent(conn *websocket.Conn, req *http.Request, unit debugHooksUnit, cfg params.DebugHooksAgentConfig) {
+	session, err := unit.DebugHooksSession()
+	if err == nil && session.ID != cfg.Session {
+		err = errors.NotFoundf("debug-hooks session %q", cfg.Session)
+	}
+	if err != nil {
+		h.sendError(conn, req, err)
+		return
+	}
+	// Check that the client is still there
+	// before stopping the hook for it.
+	if live, err := h.sessionLive(session.ID); err != nil {
+		h.sendError(conn, req, err)
+		return
+	} else if !live {
+		if err := unit.EndDebugHooksSession(session.ID); err != nil {
+			logger.Errorf("ending debug-hooks session: %v", err)
+		}
+		h.sendError(conn, req, errors.NotFoundf("debug-hooks session %q", cfg.Session))
+		return
+	}
+	fromClient, unsubscribe, err := h.subscribe(debughooks.ToAgentTopic, session.ID)
+	if err != nil {
+		h.sendError(conn, req, err)
+		return
+	}
+	defer unsubscribe()
+	h.sendError(conn, req, nil)
+	h.publish(debughooks.ToClientTopic, debughooks.Message{
+		SessionID: session.ID,
+		Type:      debughooks.Attached,
+		Hook:      cfg.Hook,
+	})
+
+	finished := false
+	defer func() {
+		if !finished {
+			h.publish(debughooks.ToClientTopic, debughooks.Message{
+				SessionID: session.ID,
+				Type:      debughooks.Finished,
+				Hook:      cfg.Hook,
+				Error:     "unit agent disconnected",
+			})
+		}
+	}()
+	fromHub := func(m debughooks.Message) (*params.DebugHooksMessage, bool) {
+		switch m.Type {
+		case debughooks.Input, debughooks.EOF:
+			return socketMessage(m), false
+		case debughooks.Ended:
+			finished = true
+			return socketMessage(m), true
+		}
+		return nil, false
+	}
+	fromSocket := func(m params.DebugHooksMessage) bool {
+		switch m.Type {
+		case debughooks.Output:
+			return true
+		case debughooks.Finished:
+			finished = true
+			return true
+		}
+		return false
+	}
+	h.relay(conn, session.ID, fromClient, fromHub, debughooks.ToClientTopic, fromSocket)
+}
+
+// relay sends the messages received from the hub to the websocket,
+// as converted by fromHub, and publishes the messages read from the
+// websocket which are accepted by fromSocket on the given topic. It
+// returns when the websocket closes, or fromHub reports that the
+// session is over.
+func (h *debugHooksHandler) relay(
+	conn *websocket.Conn,
+	id string,
+	received <-chan debughooks.Message,
+	fromHub func(debughooks.Message) (*params.DebugHooksMessage, bool),
+	topic string,
+	fromSocket func(params.DebugHooksMessage) bool,
+) {
+	// Here we configure the ping/pong handling for the websocket so
+	// the server can notice when the other end goes away.
+	// See the long note in logsink.go for the rationale.
+	_ = conn.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+	conn.SetPongHandler(func(string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(websocket.PongDelay))
+		return nil
+	})
+	ticker := time.NewTicker(websocket.PingPeriod)
+	defer ticker.Stop()
+
+	readCh := h.readMessages(conn)
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			deadline := time.Now().Add(websocket.WriteWait)
+			if err := conn.WriteControl(gorillaws.PingMessage, []byte{}, deadline); err != nil {
+				logger.Debugf("failed to write ping: %s", err)
+				return
+			}
+		case m := <-received:
+			out, done := fromHub(m)
+			if out != nil {
+				if err := conn.WriteJSON(out); err != nil {
+					logger.Debugf("failed to write debug-hooks message: %s", err)
+					return
+				}
+			}
+			if done {
+				return
+			}
+		case m, ok := <-readCh:
+			if !ok {
+				return
+			}
+			if !fromSocket(m) {
+				logger.Debugf("ignoring debug-hooks message of type %q", m.Type)
+				continue
+			}
+			h.publish(topic, hubMessage(id, m))
+		}
+	}
+}
+
+// readMessages returns a channel which receives the messages read from
+// the websocket, and which is closed when the websocket closes.
+func (h *debugHooksHandler) readMessages(conn *websocket.Conn) <-chan params.DebugHooksMessage {
+	messageCh := make(chan params.DebugHooksMessage)
+	go func() {
+		defer close(messageCh)
+		for {
+			var m params.DebugHooksMessage
+			if err := conn.ReadJSON(&m); err != nil {
+				if gorillaws.IsUnexpectedCloseError(err) {
+					logger.Tracef("websocket closed")
+				} else {
+					logger.Debugf("debug-hooks receive error: %v", err)
+				}
+				return
+			}
+			select {
+			case <-h.stop:
+				return
+			case messageCh <- m:
+			}
+		}
+	}()
+	return messageCh
+}
+
+// subscribe returns a channel which receives the messages of the
+// session published on the topic, and a function to unsubscribe.
+func (h *debugHooksHandler) subscribe(topic, id string) (<-chan debughooks.Message, func(), error) {
+	messageCh := make(chan debughooks.Message)
+	done := make(chan struct{})
+	unsubscribe, err := h.hub.Subscribe(topic, func(_ string, m debughooks.Message, err error) {
+		if err != nil {
+			logger.Errorf("debug-hooks subscriber error: %v", err)
+			return
+		}
+		if m.SessionID != id {
+			return
+		}
+		select {
+		case messageCh <- m:
+		case <-done:
+		}
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return messageCh, func() {
+		close(done)
+		unsubscribe()
+	}, nil
+}
+
+// sessionLive reports whether the client of the session is still
+// connected, by having its API server answer a ping.
+func (h *debugHooksHandler) sessionLive(id string) (bool, error) {
+	pongs, unsubscribe, err := h.subscribe(debughooks.ToAgentTopic, id)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	defer unsubscribe()
+	h.publish(debughooks.ToClientTopic, debughooks.Message{SessionID: id, Type: debughooks.Ping})
+	timeout := time.After(h.pingTimeout)
+	for {
+		select {
+		case m := <-pongs:
+			if m.Type == debughooks.Pong {
+				return true, nil
+			}
+		case <-timeout:
+			return false, nil
+		case <-h.stop:
+			return false, errors.New("API server stopping")
+		}
+	}
+}
+
+func (h *debugHooksHandler) publish(topic string, m debughooks.Message) {
+	if _, err := h.hub.Publish(topic, m); err != nil {
+		logger.Errorf("publishing debug-hooks message: %v", err)
+	}
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *debugHooksHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	// There is no need to log the error for normal operators as there is nothing
+	// they can action. This is for developers.
+	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
+		logger.Errorf("returning error from %s %s: %s", req.Method, req.URL.Path, errors.Details(err))
+	}
+	if sendErr := ws.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		ws.Close()
+	}
+}
+
+func hubMessage(id string, m params.DebugHooksMessage) debughooks.Message {
+	return debughooks.Message{
+		SessionID: id,
+		Type:      m.Type,
+		Data:      base64.StdEncoding.EncodeToString(m.Data),
+		Hook:      m.Hook,
+		Code:      m.Code,
+		Error:     m.Error,
+	}
+}
+
+func socketMessage(m debughooks.Message) *params.DebugHooksMessage {
+	data, err := base64.StdEncoding.DecodeString(m.Data)
+	if err != nil {
+		logger.Errorf("decoding debug-hooks message: %v", err)
+	}
+	return &params.DebugHooksMessage{
+		Type:  m.Type,
+		Data:  data,
+		Hook:  m.Hook,
+		Code:  m.Code,
+		Error: m.Error,
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	gorillaws "github.com/gorilla/websocket"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/pubsub/debughooks"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type debugHooksSuite struct {
+	coretesting.BaseSuite
+	unit   *fakeDebugHooksUnit
+	stop   chan struct{}
+	client *httptest.Server
+	agent  *httptest.Server
+}
+
+var _ = gc.Suite(&debugHooksSuite{})
+
+func (s *debugHooksSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.unit = &fakeDebugHooksUnit{}
+	s.stop = make(chan struct{})
+	s.AddCleanup(func(*gc.C) { close(s.stop) })
+
+	hub := centralhub.New(names.NewMachineTag("0"))
+	getUnit := func(*http.Request) (debugHooksUnit, func(), error) {
+		return s.unit, func() {}, nil
+	}
+	s.client = httptest.NewServer(&debugHooksHandler{
+		hub:         hub,
+		stop:        s.stop,
+		pingTimeout: 100 * time.Millisecond,
+		getUnit:     getUnit,
+	})
+	s.AddCleanup(func(*gc.C) { s.client.Close() })
+	s.agent = httptest.NewServer(&debugHooksHandler{
+		hub:         hub,
+		stop:        s.stop,
+		pingTimeout: 100 * time.Millisecond,
+		getUnit:     getUnit,
+		agent:       true,
+	})
+	s.AddCleanup(func(*gc.C) { s.agent.Close() })
+}
+
+func (s *debugHooksSuite) dial(c *gc.C, srv *httptest.Server, query string) (*gorillaws.Conn, error) {
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?" + query
+	conn, _, err := gorillaws.DefaultDialer.Dial(url, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var result params.ErrorResult
+	s.readJSON(c, conn, &result)
+	if result.Error != nil {
+		conn.Close()
+		return nil, result.Error
+	}
+	s.AddCleanup(func(*gc.C) { conn.Close() })
+	return conn, nil
+}
+
+func (s *debugHooksSuite) readJSON(c *gc.C, conn *gorillaws.Conn, v interface{}) {
+	err := conn.SetReadDeadline(time.Now().Add(coretesting.LongWait))
+	c.Assert(err, jc.ErrorIsNil)
+	err = conn.ReadJSON(v)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *debugHooksSuite) read(c *gc.C, conn *gorillaws.Conn) params.DebugHooksMessage {
+	var m params.DebugHooksMessage
+	s.readJSON(c, conn, &m)
+	return m
+}
+
+func (s *debugHooksSuite) attach(c *gc.C) (client, agent *gorillaws.Conn) {
+	client, err := s.dial(c, s.client, "hook=install&hook=start")
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.unit.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.Hooks, jc.DeepEquals, []string{"install", "start"})
+
+	agent, err = s.dial(c, s.agent, "session="+session.ID+"&hook=install")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.read(c, client), jc.DeepEquals, params.DebugHooksMessage{
+		Type: debughooks.Attached,
+		Hook: "install",
+	})
+	return client, agent
+}
+
+func (s *debugHooksSuite) TestRelay(c *gc.C) {
+	client, agent := s.attach(c)
+
+	err := client.WriteJSON(params.DebugHooksMessage{Type: debughooks.Input, Data: []byte("ls\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.read(c, agent), jc.DeepEquals, params.DebugHooksMessage{
+		Type: debughooks.Input,
+		Data: []byte("ls\n"),
+	})
+
+	err = agent.WriteJSON(params.DebugHooksMessage{Type: debughooks.Output, Data: []byte("hooks\n")})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.read(c, client), jc.DeepEquals, params.DebugHooksMessage{
+		Type: debughooks.Output,
+		Data: []byte("hooks\n"),
+	})
+
+	err = agent.WriteJSON(params.DebugHooksMessage{Type: debughooks.Finished, Hook: "install", Code: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.read(c, client), jc.DeepEquals, params.DebugHooksMessage{
+		Type: debughooks.Finished,
+		Hook: "install",
+		Code: 3,
+	})
+}
+
+func (s *debugHooksSuite) TestIgnoresUnexpectedMessages(c *gc.C) {
+	client, agent := s.attach(c)
+
+	// The client cannot pretend to be the agent.
+	err := client.WriteJSON(params.DebugHooksMessage{Type: debughooks.Output, Data: []byte("fake")})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.WriteJSON(params.DebugHooksMessage{Type: debughooks.EOF})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.read(c, agent), jc.DeepEquals, params.DebugHooksMessage{Type: debughooks.EOF})
+}
+
+func (s *debugHooksSuite) TestClientDisconnectEndsSession(c *gc.C) {
+	client, agent := s.attach(c)
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.read(c, agent), jc.DeepEquals, params.DebugHooksMessage{Type: debughooks.Ended})
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		_, err = s.unit.DebugHooksSession()
+		if err != nil {
+			break
+		}
+	}
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *debugHooksSuite) TestAgentDisconnectFinishesHook(c *gc.C) {
+	client, agent := s.attach(c)
+	err := agent.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.read(c, client), jc.DeepEquals, params.DebugHooksMessage{
+		Type:  debughooks.Finished,
+		Hook:  "install",
+		Error: "unit agent disconnected",
+	})
+}
+
+func (s *debugHooksSuite) TestAgentUnknownSession(c *gc.C) {
+	_, err := s.dial(c, s.client, "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.dial(c, s.agent, "session=another&hook=install")
+	c.Assert(err, gc.ErrorMatches, `debug-hooks session "another" not found`)
+}
+
+func (s *debugHooksSuite) TestAgentStaleSession(c *gc.C) {
+	id, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.dial(c, s.agent, "session="+id+"&hook=install")
+	c.Assert(err, gc.ErrorMatches, `debug-hooks session ".*" not found`)
+	_, err = s.unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *debugHooksSuite) TestAlreadyDebugged(c *gc.C) {
+	_, err := s.dial(c, s.client, "")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.dial(c, s.client, "")
+	c.Assert(err, gc.ErrorMatches, "unit is already being debugged")
+}
+
+func (s *debugHooksSuite) TestStaleSessionReplaced(c *gc.C) {
+	stale, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.dial(c, s.client, "")
+	c.Assert(err, jc.ErrorIsNil)
+	session, err := s.unit.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.ID, gc.Not(gc.Equals), stale)
+}
+
+// fakeDebugHooksUnit holds a debug-hooks session in memory.
+type fakeDebugHooksUnit struct {
+	mu      sync.Mutex
+	session *state.DebugHooksSession
+	count   int
+}
+
+func (u *fakeDebugHooksUnit) StartDebugHooksSession(hooks []string) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.session != nil {
+		return "", errors.AlreadyExistsf("debug-hooks session")
+	}
+	u.count++
+	u.session = &state.DebugHooksSession{
+		ID:    fmt.Sprintf("session-%d", u.count),
+		Hooks: hooks,
+	}
+	return u.session.ID, nil
+}
+
+func (u *fakeDebugHooksUnit) EndDebugHooksSession(id string) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.session != nil && u.session.ID == id {
+		u.session = nil
+	}
+	return nil
+}
+
+func (u *fakeDebugHooksUnit) DebugHooksSession() (state.DebugHooksSession, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.session == nil {
+		return state.DebugHooksSession{}, errors.NotFoundf("debug-hooks session")
+	}
+	return *u.session, nil
+}
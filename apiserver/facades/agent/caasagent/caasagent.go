@@ -5,10 +5,13 @@ package caasagent
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/cloudspec"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
 )
 
 type Facade struct {
@@ -17,12 +20,36 @@ type Facade struct {
 	cloudspec.CloudSpecAPI
 	*common.ModelWatcher
 	*common.ControllerConfigAPI
+
+	getZoneCloudSpec func(string) (environs.CloudSpec, error)
+}
+
+// FacadeV1 implements the V1 API, which lacks ZoneCloudSpecs
+// and is only available to model and machine agents.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for V1 facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	authorizer := ctx.Auth()
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthModelAgent() {
+		return nil, common.ErrPerm
+	}
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
 }
 
 // NewStateFacade provides the signature required for facade registration.
+// Application operators may use the facade too, to get the cloud specs of
+// cluster zones so that they can run hooks in units placed there, but are
+// not given the model's own cloud spec.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
-	if !authorizer.AuthMachineAgent() && !authorizer.AuthModelAgent() {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthModelAgent() && !authorizer.AuthApplicationAgent() {
 		return nil, common.ErrPerm
 	}
 
@@ -31,13 +58,19 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	getCloudSpecAuthFunc := common.AuthFuncForTag(model.ModelTag())
+	if authorizer.AuthApplicationAgent() {
+		getCloudSpecAuthFunc = func() (common.AuthFunc, error) {
+			return func(names.Tag) bool { return false }, nil
+		}
+	}
 	cloudSpecAPI := cloudspec.NewCloudSpec(
 		resources,
 		cloudspec.MakeCloudSpecGetterForModel(ctx.State()),
 		cloudspec.MakeCloudSpecWatcherForModel(ctx.State()),
 		cloudspec.MakeCloudSpecCredentialWatcherForModel(ctx.State()),
 		cloudspec.MakeCloudSpecCredentialContentWatcherForModel(ctx.State()),
-		common.AuthFuncForTag(model.ModelTag()),
+		getCloudSpecAuthFunc,
 	)
 	return &Facade{
 		CloudSpecAPI:        cloudSpecAPI,
@@ -45,5 +78,31 @@ func NewStateFacade(ctx facade.Context) (*Facade, error) {
 		ControllerConfigAPI: common.NewStateControllerConfig(ctx.State()),
 		auth:                authorizer,
 		resources:           resources,
+		getZoneCloudSpec:    cloudspec.MakeZoneCloudSpecGetterForModel(ctx.State()),
 	}, nil
 }
+
+// ZoneCloudSpecs returns the cloud specs of the specified clouds,
+// which must be cluster zones of the model.
+func (f *Facade) ZoneCloudSpecs(args params.Entities) (params.CloudSpecResults, error) {
+	results := params.CloudSpecResults{
+		Results: make([]params.CloudSpecResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseCloudTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		spec, err := f.getZoneCloudSpec(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = cloudspec.CloudSpecResult(spec)
+	}
+	return results, nil
+}
+
+// ZoneCloudSpecs isn't on the V1 API.
+func (*FacadeV1) ZoneCloudSpecs(_, _ struct{}) {}
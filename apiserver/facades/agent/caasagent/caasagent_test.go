@@ -4,13 +4,18 @@
 package caasagent_test
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade/facadetest"
 	"github.com/juju/juju/apiserver/facades/agent/caasagent"
+	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -37,8 +42,57 @@ func (s *caasagentSuite) SetUpTest(c *gc.C) {
 
 func (s *caasagentSuite) TestPermission(c *gc.C) {
 	s.authorizer = &apiservertesting.FakeAuthorizer{
-		Tag: names.NewApplicationTag("someapp"),
+		Tag: names.NewUnitTag("someapp/0"),
 	}
 	_, err := caasagent.NewStateFacade(facadetest.Context{Auth_: s.authorizer})
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
+
+func (s *caasagentSuite) TestPermissionV1(c *gc.C) {
+	s.authorizer = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewApplicationTag("someapp"),
+	}
+	_, err := caasagent.NewStateFacadeV1(facadetest.Context{Auth_: s.authorizer})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *caasagentSuite) TestZoneCloudSpecs(c *gc.C) {
+	credential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "fred",
+		"password": "secret",
+	})
+	facade := caasagent.NewFacadeForTest(func(cloudName string) (environs.CloudSpec, error) {
+		if cloudName != "west" {
+			return environs.CloudSpec{}, errors.NotFoundf("cluster zone %q", cloudName)
+		}
+		return environs.CloudSpec{
+			Type:       "kubernetes",
+			Name:       "west",
+			Endpoint:   "https://west.example.com",
+			Credential: &credential,
+		}, nil
+	})
+
+	results, err := facade.ZoneCloudSpecs(params.Entities{Entities: []params.Entity{
+		{Tag: "cloud-west"}, {Tag: "cloud-north"}, {Tag: "machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.CloudSpecResults{Results: []params.CloudSpecResult{{
+		Result: &params.CloudSpec{
+			Type:     "kubernetes",
+			Name:     "west",
+			Endpoint: "https://west.example.com",
+			Credential: &params.CloudCredential{
+				AuthType: "userpass",
+				Attributes: map[string]string{
+					"username": "fred",
+					"password": "secret",
+				},
+			},
+		},
+	}, {
+		Error: &params.Error{Code: params.CodeNotFound, Message: `cluster zone "north" not found`},
+	}, {
+		Error: &params.Error{Message: `"machine-0" is not a valid cloud tag`},
+	}}})
+}
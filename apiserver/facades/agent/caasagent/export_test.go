@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasagent
+
+import (
+	"github.com/juju/juju/environs"
+)
+
+// NewFacadeForTest returns a facade which gets the
+// cloud specs of cluster zones with the specified function.
+func NewFacadeForTest(getZoneCloudSpec func(string) (environs.CloudSpec, error)) *Facade {
+	return &Facade{getZoneCloudSpec: getZoneCloudSpec}
+}
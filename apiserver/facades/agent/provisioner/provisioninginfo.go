@@ -6,7 +6,6 @@ package provisioner
 import (
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -16,14 +15,12 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
-	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/storage"
@@ -232,7 +229,7 @@ func (api *ProvisionerAPI) machineVolumeParams(
 	if len(volumeAttachments) == 0 {
 		return nil, nil, nil
 	}
-	modelConfig, err := api.m.ModelConfig()
+	tagger, err := api.resourceTagger()
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -255,8 +252,8 @@ func (api *ProvisionerAPI) machineVolumeParams(
 			return nil, nil, errors.Annotatef(err, "getting volume %q storage instance", volumeTag.Id())
 		}
 		volumeParams, err := storagecommon.VolumeParams(
-			volume, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, api.storagePoolManager, api.storageProviderRegistry,
+			volume, storageInstance, api.m.UUID(), controllerCfg.ControllerUUID(),
+			tagger, api.storagePoolManager, api.storageProviderRegistry,
 		)
 		if err != nil {
 			return nil, nil, errors.Annotatef(err, "getting volume %q parameters", volumeTag.Id())
@@ -308,43 +305,26 @@ func (api *ProvisionerAPI) machineVolumeParams(
 
 // machineTags returns machine-specific tags to set on the instance.
 func (api *ProvisionerAPI) machineTags(m *state.Machine, jobs []model.MachineJob) (map[string]string, error) {
-	// Names of all units deployed to the machine.
-	//
-	// TODO(axw) 2015-06-02 #1461358
-	// We need a worker that periodically updates
-	// instance tags with current deployment info.
-	units, err := m.Units()
+	tagger, err := api.resourceTagger()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	unitNames := make([]string, 0, len(units))
-	for _, unit := range units {
-		if !unit.IsPrincipal() {
-			continue
-		}
-		unitNames = append(unitNames, unit.Name())
-	}
-	sort.Strings(unitNames)
-
-	cfg, err := api.m.ModelConfig()
+	controllerCfg, err := api.st.ControllerConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	machineTags, err := tagger.InstanceTags(controllerCfg.ControllerUUID(), m.Id(), jobs, m.Principals())
+	return machineTags, errors.Trace(err)
+}
 
-	controllerCfg, err := api.st.ControllerConfig()
+// resourceTagger returns a ResourceTagger for computing the tags
+// of the model's cloud resources.
+func (api *ProvisionerAPI) resourceTagger() (*common.ResourceTagger, error) {
+	cfg, err := api.m.ModelConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-
-	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), cfg, jobs)
-	if len(unitNames) > 0 {
-		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
-	}
-
-	machineID := fmt.Sprintf("%s-%s", cfg.Name(), m.Tag().String())
-	machineTags[tags.JujuMachine] = machineID
-
-	return machineTags, nil
+	return common.NewResourceTagger(cfg, api.m.Owner(), common.NewStateApplicationConfigGetter(api.st)), nil
 }
 
 func (api *ProvisionerAPI) machineSpaces(m *state.Machine,
@@ -9,9 +9,11 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
@@ -59,7 +61,9 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
+	common.ApplicationConfigGetter
 
+	Owner() names.UserTag
 	ControllerConfig() (controller.Config, error)
 	MachineInstanceId(names.MachineTag) (instance.Id, error)
 	ModelTag() names.ModelTag
@@ -155,3 +159,11 @@ func (s stateShim) WatchMachine(tag names.MachineTag) (state.NotifyWatcher, erro
 	}
 	return m.Watch(), nil
 }
+
+func (s stateShim) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	app, err := s.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ApplicationConfig()
+}
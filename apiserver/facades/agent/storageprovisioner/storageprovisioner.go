@@ -733,6 +733,7 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 	if err != nil {
 		return params.VolumeParamsResults{}, err
 	}
+	tagger := common.NewResourceTagger(modelCfg, s.st.Owner(), s.st)
 	controllerCfg, err := s.st.ControllerConfig()
 	if err != nil {
 		return params.VolumeParamsResults{}, err
@@ -764,7 +765,7 @@ func (s *StorageProvisionerAPIv3) VolumeParams(args params.Entities) (params.Vol
 		}
 		volumeParams, err := storagecommon.VolumeParams(
 			volume, storageInstance, modelCfg.UUID(), controllerCfg.ControllerUUID(),
-			modelCfg, s.poolManager, s.registry,
+			tagger, s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.VolumeParams{}, err
@@ -885,6 +886,7 @@ func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params
 	if err != nil {
 		return params.FilesystemParamsResults{}, err
 	}
+	tagger := common.NewResourceTagger(modelConfig, s.st.Owner(), s.st)
 	controllerCfg, err := s.st.ControllerConfig()
 	if err != nil {
 		return params.FilesystemParamsResults{}, err
@@ -912,7 +914,7 @@ func (s *StorageProvisionerAPIv3) FilesystemParams(args params.Entities) (params
 		}
 		filesystemParams, err := storagecommon.FilesystemParams(
 			filesystem, storageInstance, modelConfig.UUID(), controllerCfg.ControllerUUID(),
-			modelConfig, s.poolManager, s.registry,
+			tagger, s.poolManager, s.registry,
 		)
 		if err != nil {
 			return params.FilesystemParams{}, err
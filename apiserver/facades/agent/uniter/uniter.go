@@ -37,8 +37,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v16) of the Uniter API, which adds
-// LXDProfileAPIv2.
+// UniterAPI implements the latest version (v20) of the Uniter API, which adds
+// DebugHooksSessions and WatchDebugHooksSessions.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -77,11 +77,35 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV19 implements version (v19) of the Uniter API, which adds
+// UpdateStatusHookInterval and WatchUpdateStatusHookInterval for units.
+type UniterAPIV19 struct {
+	UniterAPI
+}
+
+// UniterAPIV18 implements version (v18) of the Uniter API, which adds
+// RecordHookExecutions.
+type UniterAPIV18 struct {
+	UniterAPIV19
+}
+
+// UniterAPIV17 implements version (v17) of the Uniter API, which adds
+// LogActionsOutput.
+type UniterAPIV17 struct {
+	UniterAPIV18
+}
+
+// UniterAPIV16 implements version (v16) of the Uniter API, which adds
+// LXDProfileAPIv2.
+type UniterAPIV16 struct {
+	UniterAPIV17
+}
+
 // UniterAPIV15 implements version (v15) of the Uniter API, which adds
 // the State, CommitHookChanges, ReadLocalApplicationSettings calls and changes
 // WatchActionNotifications to notify on action changes.
 type UniterAPIV15 struct {
-	UniterAPI
+	UniterAPIV16
 }
 
 // UniterAPIV14 implements version (v14) of the Uniter API,
@@ -250,14 +274,58 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV19 creates an instance of the V19 uniter API.
+func NewUniterAPIV19(context facade.Context) (*UniterAPIV19, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV19{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV18 creates an instance of the V18 uniter API.
+func NewUniterAPIV18(context facade.Context) (*UniterAPIV18, error) {
+	uniterAPI, err := NewUniterAPIV19(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV18{
+		UniterAPIV19: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPIV18(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPIV18: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV16 creates an instance of the V16 uniter API.
+func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
+	uniterAPI, err := NewUniterAPIV17(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV16{
+		UniterAPIV17: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV15 creates an instance of the V15 uniter API.
 func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV16(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV15{
-		UniterAPI: *uniterAPI,
+		UniterAPIV16: *uniterAPI,
 	}, nil
 }
 
@@ -1333,6 +1401,290 @@ func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.
 	return result, nil
 }
 
+// LogActionsOutput records the chunks of output streamed by the specified actions.
+func (u *UniterAPI) LogActionsOutput(args params.ActionOutputParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	m, err := u.st.Model()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, m.ActionByTag)
+
+	oneActionOutput := func(chunk params.ActionOutputChunk) error {
+		action, err := actionFn(chunk.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return action.LogOutput(chunk.Stream, chunk.Data)
+	}
+
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Output)),
+	}
+	for i, chunk := range args.Output {
+		result.Results[i].Error = common.ServerError(oneActionOutput(chunk))
+	}
+	return result, nil
+}
+
+// LogActionsOutput isn't on the v16 API.
+func (u *UniterAPIV16) LogActionsOutput(_ struct{}) {}
+
+// RecordHookExecutions adds the given hook, action and command
+// executions to the hook history of each unit.
+func (u *UniterAPI) RecordHookExecutions(args params.RecordHookExecutionsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		executions := make([]state.HookExecution, len(arg.Executions))
+		for j, e := range arg.Executions {
+			executions[j] = state.HookExecution{
+				Kind:     e.Kind,
+				Name:     e.Name,
+				Trigger:  e.Trigger,
+				Started:  e.Started,
+				Finished: e.Finished,
+				ExitCode: e.ExitCode,
+				Error:    e.Error,
+				Skipped:  e.Skipped,
+			}
+		}
+		if err := unit.RecordHookExecutions(executions); err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+// RecordHookExecutions isn't on the v17 API.
+func (u *UniterAPIV17) RecordHookExecutions(_ struct{}) {}
+
+// UpdateStatusHookInterval returns the interval at which to run the
+// update-status hook for each unit. The interval set in the unit's
+// application config, if any, overrides that of the model.
+func (u *UniterAPI) UpdateStatusHookInterval(args params.Entities) (params.UpdateStatusHookIntervalResults, error) {
+	result := params.UpdateStatusHookIntervalResults{
+		Results: make([]params.UpdateStatusHookIntervalResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.UpdateStatusHookIntervalResults{}, err
+	}
+	modelConfig, err := u.m.ModelConfig()
+	if err != nil {
+		return params.UpdateStatusHookIntervalResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		interval, err := u.updateStatusHookInterval(tag, modelConfig.UpdateStatusHookInterval())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Interval = interval
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) updateStatusHookInterval(tag names.UnitTag, modelInterval time.Duration) (time.Duration, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return 0, err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	interval, ok, err := appConfig.UpdateStatusHookInterval()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if !ok {
+		return modelInterval, nil
+	}
+	return interval, nil
+}
+
+// WatchUpdateStatusHookInterval returns a NotifyWatcher for each unit
+// which fires when the interval at which to run the unit's update-status
+// hook may have changed, either in the model config or in the config
+// of the unit's application.
+func (u *UniterAPI) WatchUpdateStatusHookInterval(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneUpdateStatusHookInterval(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneUpdateStatusHookInterval(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	appConfigWatcher, err := unit.WatchApplicationConfigSettings()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := common.NewMultiNotifyWatcher(u.m.WatchForModelConfigChanges(), appConfigWatcher)
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-w.Changes(); ok {
+		return u.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// Mask UpdateStatusHookInterval and WatchUpdateStatusHookInterval
+// from the v18 API, which only offers the model-wide interval.
+
+// UpdateStatusHookInterval isn't on the v18 API.
+func (u *UniterAPIV18) UpdateStatusHookInterval(_, _ struct{}) {}
+
+// WatchUpdateStatusHookInterval isn't on the v18 API.
+func (u *UniterAPIV18) WatchUpdateStatusHookInterval(_, _ struct{}) {}
+
+// DebugHooksSessions returns, for each unit, the "juju debug-hooks"
+// session attached through the API which is waiting to debug the
+// unit's hooks, if there is one.
+func (u *UniterAPI) DebugHooksSessions(args params.Entities) (params.DebugHooksSessionResults, error) {
+	result := params.DebugHooksSessionResults{
+		Results: make([]params.DebugHooksSessionResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.DebugHooksSessionResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		session, err := unit.DebugHooksSession()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = &params.DebugHooksSession{
+			ID:    session.ID,
+			Hooks: session.Hooks,
+		}
+	}
+	return result, nil
+}
+
+// DebugHooksSessions isn't on the v19 API.
+func (u *UniterAPIV19) DebugHooksSessions(_, _ struct{}) {}
+
+// WatchDebugHooksSessions returns a NotifyWatcher for each unit, which
+// notifies when the unit's "juju debug-hooks" session starts or ends.
+func (u *UniterAPI) WatchDebugHooksSessions(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneDebugHooksSession(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneDebugHooksSession(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	w := unit.WatchDebugHooksSession()
+	// Consume the initial event.
+	if _, ok := <-w.Changes(); ok {
+		return u.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchDebugHooksSessions isn't on the v19 API.
+func (u *UniterAPIV19) WatchDebugHooksSessions(_, _ struct{}) {}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1152,13 +1152,13 @@ func (s *uniterSuite) TestWatchTrustConfigSettingsHash(c *gc.C) {
 func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(anAction.Messages(), gc.HasLen, 0)
 	_, err = anAction.Begin()
 	c.Assert(err, jc.ErrorIsNil)
 
-	wrongAction, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil)
+	wrongAction, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.ActionMessageParams{Messages: []params.EntityString{
@@ -1186,7 +1186,7 @@ func (s *uniterSuite) TestLogActionMessage(c *gc.C) {
 func (s *uniterSuite) TestLogActionMessageAborting(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(anAction.Messages(), gc.HasLen, 0)
 	_, err = anAction.Begin()
@@ -1213,6 +1213,218 @@ func (s *uniterSuite) TestLogActionMessageAborting(c *gc.C) {
 	c.Assert(messages[0].Timestamp(), gc.NotNil)
 }
 
+func (s *uniterSuite) TestLogActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(anAction.Output(), gc.HasLen, 0)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	wrongAction, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionOutputParams{Output: []params.ActionOutputChunk{
+		{Tag: anAction.Tag().String(), Stream: "stdout", Data: "hello\n"},
+		{Tag: anAction.Tag().String(), Stream: "stdin", Data: "nope"},
+		{Tag: wrongAction.Tag().String(), Stream: "stdout", Data: "world"},
+		{Tag: "foo-42", Stream: "stdout", Data: "mars"},
+	}}
+	result, err := s.uniter.LogActionsOutput(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: `output stream "stdin" not valid`}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"foo-42" is not a valid tag`}},
+		},
+	})
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	output := anAction.Output()
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output[0].Stream(), gc.Equals, "stdout")
+	c.Assert(output[0].Data(), gc.Equals, "hello\n")
+}
+
+func (s *uniterSuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	execution := params.HookExecution{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Trigger:  "config change",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}
+	args := params.RecordHookExecutionsArgs{Args: []params.RecordHookExecutionsArg{
+		{Tag: "unit-wordpress-0", Executions: []params.HookExecution{execution}},
+		{Tag: "unit-mysql-0", Executions: []params.HookExecution{execution}},
+		{Tag: "application-wordpress", Executions: []params.HookExecution{execution}},
+	}}
+	result, err := s.uniter.RecordHookExecutions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"application-wordpress" is not a valid unit tag`}},
+		},
+	})
+
+	history, err := s.wordpressUnit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Trigger:  "config change",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}})
+}
+
+func (s *uniterSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	schema := environschema.Fields{
+		"update-status-hook-interval": environschema.Attr{Type: environschema.Tstring},
+	}
+	err := s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"update-status-hook-interval": "30m",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.UpdateStatusHookInterval(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Interval: 30 * time.Minute},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdateStatusHookIntervalModelDefault(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"update-status-hook-interval": "10m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}}
+	result, err := s.uniter.UpdateStatusHookInterval(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.UpdateStatusHookIntervalResults{
+		Results: []params.UpdateStatusHookIntervalResult{{Interval: 10 * time.Minute}},
+	})
+}
+
+func (s *uniterSuite) TestWatchUpdateStatusHookInterval(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchUpdateStatusHookInterval(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	schema := environschema.Fields{
+		"update-status-hook-interval": environschema.Attr{Type: environschema.Tstring},
+	}
+	err = s.wordpress.UpdateApplicationConfig(coreapplication.ConfigAttributes{
+		"update-status-hook-interval": "30m",
+	}, nil, schema, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *uniterSuite) TestDebugHooksSessions(c *gc.C) {
+	id, err := s.wordpressUnit.StartDebugHooksSession([]string{"install"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.DebugHooksSessions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.DebugHooksSessionResults{
+		Results: []params.DebugHooksSessionResult{
+			{Result: &params.DebugHooksSession{ID: id, Hooks: []string{"install"}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.wordpressUnit.EndDebugHooksSession(id)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.DebugHooksSessions(params.Entities{Entities: []params.Entity{{Tag: "unit-wordpress-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.DebugHooksSessionResults{
+		Results: []params.DebugHooksSessionResult{{}},
+	})
+}
+
+func (s *uniterSuite) TestWatchDebugHooksSessions(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchDebugHooksSessions(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.wordpressUnit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -1246,7 +1458,7 @@ func (s *uniterSuite) TestWatchActionNotifications(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	wc.AssertChange(addedAction.Id())
@@ -1268,9 +1480,9 @@ func (s *uniterSuite) TestWatchPreexistingActions(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action1, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	action1, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	action2, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	action2, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	args := params.Entities{Entities: []params.Entity{
@@ -1293,7 +1505,7 @@ func (s *uniterSuite) TestWatchPreexistingActions(c *gc.C) {
 	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
 	wc.AssertNoChange()
 
-	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertChange(addedAction.Id())
 	wc.AssertNoChange()
@@ -1328,7 +1540,7 @@ func (s *uniterSuite) TestWatchActionNotificationsMalformedUnitName(c *gc.C) {
 func (s *uniterSuite) TestWatchActionNotificationsNotUnit(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil)
+	action, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: action.Tag().String()},
@@ -1700,7 +1912,7 @@ func (s *uniterSuite) TestActions(c *gc.C) {
 		a, err := s.wordpressUnit.AddAction(
 			operationID,
 			actionTest.action.Action.Name,
-			actionTest.action.Action.Parameters)
+			actionTest.action.Action.Parameters, 0)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(names.IsValidAction(a.Id()), gc.Equals, true)
 		actionTag := names.NewActionTag(a.Id())
@@ -1747,7 +1959,7 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	action, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	args := params.Entities{
 		Entities: []params.Entity{{
@@ -1763,7 +1975,7 @@ func (s *uniterSuite) TestActionsWrongUnit(c *gc.C) {
 func (s *uniterSuite) TestActionsPermissionDenied(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil)
+	action, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	args := params.Entities{
 		Entities: []params.Entity{{
@@ -1786,7 +1998,7 @@ func (s *uniterSuite) TestFinishActionsSuccess(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.wordpressUnit.AddAction(operationID, testName, nil)
+	action, err := s.wordpressUnit.AddAction(operationID, testName, nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	actionResults := params.ActionExecutionResults{
@@ -1804,7 +2016,8 @@ func (s *uniterSuite) TestFinishActionsSuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(len(results), gc.Equals, 1)
 	c.Assert(results[0].Status(), gc.Equals, state.ActionCompleted)
-	res2, errstr := results[0].Results()
+	res2, errstr, err := results[0].Results()
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errstr, gc.Equals, "")
 	c.Assert(res2, gc.DeepEquals, testOutput)
 	c.Assert(results[0].Name(), gc.Equals, testName)
@@ -1820,7 +2033,7 @@ func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.wordpressUnit.AddAction(operationID, testName, nil)
+	action, err := s.wordpressUnit.AddAction(operationID, testName, nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	actionResults := params.ActionExecutionResults{
@@ -1839,7 +2052,8 @@ func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(len(results), gc.Equals, 1)
 	c.Assert(results[0].Status(), gc.Equals, state.ActionFailed)
-	res2, errstr := results[0].Results()
+	res2, errstr, err := results[0].Results()
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errstr, gc.Equals, testError)
 	c.Assert(res2, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(results[0].Name(), gc.Equals, testName)
@@ -1848,10 +2062,10 @@ func (s *uniterSuite) TestFinishActionsFailure(c *gc.C) {
 func (s *uniterSuite) TestFinishActionsAuthAccess(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	good, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	good, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
-	bad, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil)
+	bad, err := s.mysqlUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	var tests = []struct {
@@ -1892,7 +2106,7 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	ten_seconds_ago := time.Now().Add(-10 * time.Second)
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	good, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	good, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	running, err := s.wordpressUnit.RunningActions()
@@ -5588,7 +5802,7 @@ func (s *uniterV14Suite) TestWatchActionNotificationsLegacy(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil)
+	addedAction, err := s.wordpressUnit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertChange(addedAction.Id())
 
@@ -46,6 +46,11 @@ type APIv5 struct {
 
 // APIv6 provides the Action API facade for version 6.
 type APIv6 struct {
+	*APIv7
+}
+
+// APIv7 provides the Action API facade for version 7.
+type APIv7 struct {
 	*ActionAPI
 }
 
@@ -87,13 +92,22 @@ func NewActionAPIV5(ctx facade.Context) (*APIv5, error) {
 
 // NewActionAPIV6 returns an initialized ActionAPI for version 6.
 func NewActionAPIV6(ctx facade.Context) (*APIv6, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV7(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv6{api}, nil
 }
 
+// NewActionAPIV7 returns an initialized ActionAPI for version 7.
+func NewActionAPIV7(ctx facade.Context) (*APIv7, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv7{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -388,3 +402,33 @@ func (api *ActionAPI) WatchActionsProgress(actions params.Entities) (params.Stri
 	}
 	return results, nil
 }
+
+// WatchActionsOutput creates a watcher that reports on the output
+// streamed by running actions.
+func (api *ActionAPI) WatchActionsOutput(actions params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(actions.Entities)),
+	}
+	for i, arg := range actions.Entities {
+		actionTag, err := names.ParseActionTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+
+		w := api.state.WatchActionOutput(actionTag.Id())
+		// Consume the initial event.
+		changes, ok := <-w.Changes()
+		if !ok {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+
+		results.Results[i].Changes = changes
+		results.Results[i].StringsWatcherId = api.resources.Register(w)
+	}
+	return results, nil
+}
+
+// WatchActionsOutput isn't on the v6 API.
+func (*APIv6) WatchActionsOutput(_, _ struct{}) {}
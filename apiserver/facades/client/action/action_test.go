@@ -626,7 +626,7 @@ func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	added, err := unit.AddAction(operationID, "fakeaction", nil)
+	added, err := unit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	w, err := s.action.WatchActionsProgress(
@@ -665,3 +665,53 @@ func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
 	wc.AssertChange(string(expected))
 	wc.AssertNoChange()
 }
+
+func (s *actionSuite) TestWatchActionOutput(c *gc.C) {
+	s.toSupportNewActionID(c)
+
+	unit, err := s.State.Unit("mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	assertReadyToTest(c, unit)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	added, err := unit.AddAction(operationID, "fakeaction", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.action.WatchActionsOutput(
+		params.Entities{Entities: []params.Entity{{Tag: "action-2"}}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w.Results, gc.HasLen, 1)
+	c.Assert(w.Results[0].Error, gc.IsNil)
+	c.Assert(w.Results[0].Changes, gc.HasLen, 0)
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	// Stream some output and check the watcher result.
+	added, err = added.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = added.LogOutput("stdout", "hello\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.Model.Action("2")
+	c.Assert(err, jc.ErrorIsNil)
+	output := a.Output()
+	c.Assert(output, gc.HasLen, 1)
+	expected, err := json.Marshal(actions.ActionOutput{
+		Stream:    "stdout",
+		Data:      "hello\n",
+		Timestamp: output[0].Timestamp(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	wc.AssertChange(string(expected))
+	wc.AssertNoChange()
+}
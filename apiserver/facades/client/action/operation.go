@@ -45,21 +45,6 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 		return "", params.ActionResults{}, errors.Trace(err)
 	}
 
-	var leaders map[string]string
-	getLeader := func(appName string) (string, error) {
-		if leaders == nil {
-			var err error
-			leaders, err = a.state.ApplicationLeaders()
-			if err != nil {
-				return "", err
-			}
-		}
-		if leader, ok := leaders[appName]; ok {
-			return leader, nil
-		}
-		return "", errors.Errorf("could not determine leader for %q", appName)
-	}
-
 	var operationName string
 	var receivers []string
 	for _, a := range arg.Actions {
@@ -79,6 +64,26 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 	if err != nil {
 		return "", params.ActionResults{}, errors.Annotate(err, "creating operation for actions")
 	}
+	return operationID, a.addActions(operationID, arg), nil
+}
+
+// addActions adds the actions to the operation, each
+// as a task on the the designated ActionReceiver.
+func (a *ActionAPI) addActions(operationID string, arg params.Actions) params.ActionResults {
+	var leaders map[string]string
+	getLeader := func(appName string) (string, error) {
+		if leaders == nil {
+			var err error
+			leaders, err = a.state.ApplicationLeaders()
+			if err != nil {
+				return "", err
+			}
+		}
+		if leader, ok := leaders[appName]; ok {
+			return leader, nil
+		}
+		return "", errors.Errorf("could not determine leader for %q", appName)
+	}
 
 	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
@@ -99,7 +104,7 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
-		enqueued, err := receiver.AddAction(operationID, action.Name, action.Parameters)
+		enqueued, err := receiver.AddAction(operationID, action.Name, action.Parameters, action.ExecutionTimeout)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
 			continue
@@ -107,7 +112,7 @@ func (a *ActionAPI) enqueue(arg params.Actions) (string, params.ActionResults, e
 
 		response.Results[i] = common.MakeActionResult(receiver.Tag(), enqueued, false)
 	}
-	return operationID, response, nil
+	return response
 }
 
 // ListOperations fetches the called actions for specified apps/units.
@@ -176,6 +181,7 @@ func (a *ActionAPI) ListOperations(arg params.OperationQueryArgs) (params.Operat
 			Completed:    r.Operation.Completed(),
 			Status:       string(r.Operation.Status()),
 			Actions:      make([]params.ActionResult, len(r.Actions)),
+			Steps:        runbookSteps(r.Operation),
 		}
 		for j, a := range r.Actions {
 			receiver := names.NewUnitTag(a.Receiver())
@@ -212,6 +218,7 @@ func (a *ActionAPI) Operations(arg params.Entities) (params.OperationResults, er
 			Completed:    op.Operation.Completed(),
 			Status:       string(op.Operation.Status()),
 			Actions:      make([]params.ActionResult, len(op.Actions)),
+			Steps:        runbookSteps(op.Operation),
 		}
 		for j, a := range op.Actions {
 			receiver := names.NewUnitTag(a.Receiver())
@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// StartRunbook isn't on the V6 API.
+func (*APIv6) StartRunbook(_, _ struct{}) {}
+
+// RunRunbookStep isn't on the V6 API.
+func (*APIv6) RunRunbookStep(_, _ struct{}) {}
+
+// SetRunbookStepStatus isn't on the V6 API.
+func (*APIv6) SetRunbookStepStatus(_, _ struct{}) {}
+
+// FinishRunbook isn't on the V6 API.
+func (*APIv6) FinishRunbook(_, _ struct{}) {}
+
+// RunbookHeartbeat isn't on the V6 API.
+func (*APIv6) RunbookHeartbeat(_, _ struct{}) {}
+
+// StartRunbook starts a runbook with the named steps as an operation,
+// and returns the operation's tag. The client runs the steps with
+// RunRunbookStep, and the operation is completed with FinishRunbook.
+func (a *ActionAPI) StartRunbook(arg params.StartRunbookArg) (params.StringResult, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.StringResult{}, errors.Trace(err)
+	}
+	operationID, err := a.model.EnqueueRunbookOperation(arg.Summary, arg.Steps)
+	if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: names.NewOperationTag(operationID).String()}, nil
+}
+
+// RunRunbookStep runs a step of a runbook: the action, or the commands,
+// are added to the runbook's operation as a task on each of the step's
+// units, and the step is marked as running.
+func (a *ActionAPI) RunRunbookStep(arg params.RunRunbookStepArg) (params.EnqueuedActions, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+	if arg.Commands != "" {
+		// Running commands needs the same access as juju exec.
+		if err := a.checkCanAdmin(); err != nil {
+			return params.EnqueuedActions{}, errors.Trace(err)
+		}
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+	tag, err := names.ParseOperationTag(arg.OperationTag)
+	if err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+	actions, err := a.runbookStepActions(arg)
+	if err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+	if err := a.model.SetOperationStepStatus(tag.Id(), arg.Step, state.ActionRunning, ""); err != nil {
+		return params.EnqueuedActions{}, errors.Trace(err)
+	}
+
+	actionResults := a.addActions(tag.Id(), actions)
+	results := params.EnqueuedActions{
+		OperationTag: arg.OperationTag,
+		Actions:      make([]params.StringResult, len(actionResults.Results)),
+	}
+	for i, action := range actionResults.Results {
+		results.Actions[i].Error = action.Error
+		if action.Action != nil {
+			results.Actions[i].Result = action.Action.Tag
+		}
+	}
+	return results, nil
+}
+
+// runbookStepActions returns the actions run on the units of a step.
+func (a *ActionAPI) runbookStepActions(arg params.RunRunbookStepArg) (params.Actions, error) {
+	if (arg.Action == "") == (arg.Commands == "") {
+		return params.Actions{}, errors.NotValidf("runbook step without exactly one of an action and commands")
+	}
+	units := arg.Units
+	applications := arg.Applications
+	if arg.Leader {
+		for _, application := range applications {
+			units = append(units, application+"/leader")
+		}
+		applications = nil
+	}
+	receivers, err := getAllUnitNames(a.state, units, applications)
+	if err != nil {
+		return params.Actions{}, errors.Trace(err)
+	}
+	if len(receivers) == 0 {
+		return params.Actions{}, errors.NotValidf("runbook step with no units")
+	}
+	if arg.Commands != "" {
+		return a.createActionsParams(receivers, arg.Commands, arg.Timeout, false)
+	}
+	actions := params.Actions{Actions: make([]params.Action, len(receivers))}
+	for i, receiver := range receivers {
+		actions.Actions[i] = params.Action{
+			Receiver:   receiver.String(),
+			Name:       arg.Action,
+			Parameters: arg.Parameters,
+		}
+	}
+	return actions, nil
+}
+
+// SetRunbookStepStatus records the result of a step of a runbook.
+func (a *ActionAPI) SetRunbookStepStatus(arg params.RunbookStepStatusArg) error {
+	if err := a.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	tag, err := names.ParseOperationTag(arg.OperationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.model.SetOperationStepStatus(tag.Id(), arg.Step, state.ActionStatus(arg.Status), arg.Message)
+}
+
+// RunbookHeartbeat records that the client running the runbook of the
+// operation is still running it. A runbook whose client has stopped
+// recording heartbeats is failed as abandoned.
+func (a *ActionAPI) RunbookHeartbeat(arg params.RunbookHeartbeatArg) error {
+	if err := a.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	tag, err := names.ParseOperationTag(arg.OperationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.model.RunbookOperationHeartbeat(tag.Id())
+}
+
+// FinishRunbook completes the operation of a runbook with the given
+// status. Any steps which were not run are recorded as cancelled.
+func (a *ActionAPI) FinishRunbook(arg params.FinishRunbookArg) error {
+	if err := a.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	tag, err := names.ParseOperationTag(arg.OperationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return a.model.FinishRunbookOperation(tag.Id(), state.ActionStatus(arg.Status))
+}
+
+// runbookSteps returns the progress of the steps of
+// the operation, if it is the operation of a runbook.
+func runbookSteps(op state.Operation) []params.RunbookStep {
+	var steps []params.RunbookStep
+	for _, step := range op.Steps() {
+		steps = append(steps, params.RunbookStep{
+			Name:    step.Name,
+			Status:  string(step.Status),
+			Message: step.Message,
+		})
+	}
+	return steps
+}
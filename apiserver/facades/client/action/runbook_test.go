@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+type runbookSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&runbookSuite{})
+
+func (s *runbookSuite) startRunbook(c *gc.C, steps ...string) string {
+	s.toSupportNewActionID(c)
+	result, err := s.action.StartRunbook(params.StartRunbookArg{
+		Summary: "upgrade",
+		Steps:   steps,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	return result.Result
+}
+
+func (s *runbookSuite) operation(c *gc.C, operationTag string) params.OperationResult {
+	results, err := s.action.Operations(params.Entities{
+		Entities: []params.Entity{{Tag: operationTag}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return results.Results[0]
+}
+
+func (s *runbookSuite) TestStartRunbook(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause", "backup")
+	result := s.operation(c, operationTag)
+	c.Assert(result.Summary, gc.Equals, "upgrade")
+	c.Assert(result.Status, gc.Equals, "pending")
+	c.Assert(result.Steps, jc.DeepEquals, []params.RunbookStep{
+		{Name: "pause", Status: "pending"},
+		{Name: "backup", Status: "pending"},
+	})
+}
+
+func (s *runbookSuite) TestStartRunbookNoSteps(c *gc.C) {
+	result, err := s.action.StartRunbook(params.StartRunbookArg{Summary: "upgrade"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "runbook with no steps not valid")
+}
+
+func (s *runbookSuite) TestStartRunbookBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestStartRunbookBlocked")
+	_, err := s.action.StartRunbook(params.StartRunbookArg{Summary: "upgrade", Steps: []string{"pause"}})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+func (s *runbookSuite) TestRunRunbookStepAction(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause", "backup")
+	result, err := s.action.RunRunbookStep(params.RunRunbookStepArg{
+		OperationTag: operationTag,
+		Step:         1,
+		Action:       "fakeaction",
+		Parameters:   map[string]interface{}{"outfile": "out.tar"},
+		Applications: []string{"wordpress"},
+		Units:        []string{"mysql/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OperationTag, gc.Equals, operationTag)
+	c.Assert(result.Actions, gc.HasLen, 2)
+
+	operation := s.operation(c, operationTag)
+	c.Assert(operation.Steps[1], jc.DeepEquals, params.RunbookStep{Name: "backup", Status: "running"})
+	c.Assert(operation.Actions, gc.HasLen, 2)
+	var receivers []string
+	for i, task := range operation.Actions {
+		c.Assert(task.Action.Tag, gc.Equals, result.Actions[i].Result)
+		c.Assert(task.Action.Name, gc.Equals, "fakeaction")
+		c.Assert(task.Action.Parameters, jc.DeepEquals, map[string]interface{}{"outfile": "out.tar"})
+		receivers = append(receivers, task.Action.Receiver)
+	}
+	c.Assert(receivers, jc.SameContents, []string{"unit-mysql-0", "unit-wordpress-0"})
+}
+
+func (s *runbookSuite) TestRunRunbookStepLeader(c *gc.C) {
+	claimer, err := s.LeaseManager.Claimer("application-leadership", s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	err = claimer.Claim("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationTag := s.startRunbook(c, "backup")
+	result, err := s.action.RunRunbookStep(params.RunRunbookStepArg{
+		OperationTag: operationTag,
+		Action:       "fakeaction",
+		Applications: []string{"wordpress"},
+		Leader:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Actions, gc.HasLen, 1)
+	operation := s.operation(c, operationTag)
+	c.Assert(operation.Actions[0].Action.Receiver, gc.Equals, "unit-wordpress-0")
+}
+
+func (s *runbookSuite) TestRunRunbookStepCommands(c *gc.C) {
+	operationTag := s.startRunbook(c, "upgrade")
+	result, err := s.action.RunRunbookStep(params.RunRunbookStepArg{
+		OperationTag: operationTag,
+		Commands:     "apt-get upgrade -y",
+		Timeout:      time.Minute,
+		Units:        []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Actions, gc.HasLen, 1)
+	operation := s.operation(c, operationTag)
+	task := operation.Actions[0].Action
+	c.Assert(task.Name, gc.Equals, "juju-run")
+	c.Assert(task.Parameters["command"], gc.Equals, "apt-get upgrade -y")
+}
+
+func (s *runbookSuite) TestRunRunbookStepInvalid(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause")
+	for i, test := range []struct {
+		arg params.RunRunbookStepArg
+		err string
+	}{{
+		arg: params.RunRunbookStepArg{OperationTag: operationTag, Units: []string{"wordpress/0"}},
+		err: "runbook step without exactly one of an action and commands not valid",
+	}, {
+		arg: params.RunRunbookStepArg{OperationTag: operationTag, Action: "fakeaction", Commands: "ls"},
+		err: "runbook step without exactly one of an action and commands not valid",
+	}, {
+		arg: params.RunRunbookStepArg{OperationTag: operationTag, Action: "fakeaction"},
+		err: "runbook step with no units not valid",
+	}, {
+		arg: params.RunRunbookStepArg{OperationTag: operationTag, Step: 3, Action: "fakeaction", Units: []string{"wordpress/0"}},
+		err: `cannot set status of step 3 of operation ".*": step 3 of operation ".*" not found`,
+	}, {
+		arg: params.RunRunbookStepArg{OperationTag: "action-1", Action: "fakeaction", Units: []string{"wordpress/0"}},
+		err: `"action-1" is not a valid operation tag`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.action.RunRunbookStep(test.arg)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *runbookSuite) TestFinishRunbook(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause", "backup")
+	err := s.action.SetRunbookStepStatus(params.RunbookStepStatusArg{
+		OperationTag: operationTag,
+		Status:       "failed",
+		Message:      "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.action.FinishRunbook(params.FinishRunbookArg{
+		OperationTag: operationTag,
+		Status:       "failed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation := s.operation(c, operationTag)
+	c.Assert(operation.Status, gc.Equals, "failed")
+	c.Assert(operation.Completed.IsZero(), jc.IsFalse)
+	c.Assert(operation.Steps, jc.DeepEquals, []params.RunbookStep{
+		{Name: "pause", Status: "failed", Message: "boom"},
+		{Name: "backup", Status: "cancelled"},
+	})
+}
+
+func (s *runbookSuite) TestRunbookHeartbeat(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause")
+	err := s.action.RunbookHeartbeat(params.RunbookHeartbeatArg{OperationTag: operationTag})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.action.FinishRunbook(params.FinishRunbookArg{
+		OperationTag: operationTag,
+		Status:       "completed",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.action.RunbookHeartbeat(params.RunbookHeartbeatArg{OperationTag: operationTag})
+	c.Assert(err, gc.ErrorMatches, `operation ".*" has finished`)
+}
+
+func (s *runbookSuite) TestRunbookOutlivesTasks(c *gc.C) {
+	operationTag := s.startRunbook(c, "pause", "backup")
+	result, err := s.action.RunRunbookStep(params.RunRunbookStepArg{
+		OperationTag: operationTag,
+		Action:       "fakeaction",
+		Units:        []string{"wordpress/0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tag, err := names.ParseActionTag(result.Actions[0].Result)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.Model.Action(tag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation := s.operation(c, operationTag)
+	c.Assert(operation.Status, gc.Equals, "running")
+	c.Assert(operation.Completed.IsZero(), jc.IsTrue)
+}
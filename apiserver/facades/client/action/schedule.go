@@ -0,0 +1,189 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// AddActionSchedules isn't on the V6 API.
+func (*APIv6) AddActionSchedules(_, _ struct{}) {}
+
+// ListActionSchedules isn't on the V6 API.
+func (*APIv6) ListActionSchedules(_, _ struct{}) {}
+
+// PauseActionSchedules isn't on the V6 API.
+func (*APIv6) PauseActionSchedules(_, _ struct{}) {}
+
+// ResumeActionSchedules isn't on the V6 API.
+func (*APIv6) ResumeActionSchedules(_, _ struct{}) {}
+
+// RemoveActionSchedules isn't on the V6 API.
+func (*APIv6) RemoveActionSchedules(_, _ struct{}) {}
+
+// AddActionSchedules adds schedules on which actions are run by the
+// controller. The receiver of each action is a unit, an application,
+// whose units all run the action, or an application leader.
+func (a *ActionAPI) AddActionSchedules(args params.AddActionScheduleArgs) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		schedule, err := a.addActionSchedule(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Schedule, err = actionScheduleToParams(schedule)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (a *ActionAPI) addActionSchedule(arg params.AddActionScheduleArg) (*state.ActionSchedule, error) {
+	appName := strings.TrimSuffix(arg.Receiver, "/leader")
+	if names.IsValidUnit(arg.Receiver) {
+		appName, _ = names.UnitApplication(arg.Receiver)
+	}
+	if !names.IsValidApplication(appName) {
+		return nil, errors.NotValidf("action receiver %q", arg.Receiver)
+	}
+	app, err := a.state.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec, ok := ch.Actions().ActionSpecs[arg.Name]
+	if !ok {
+		return nil, errors.NotFoundf("action %q on application %q", arg.Name, appName)
+	}
+	if err := spec.ValidateParams(arg.Parameters); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.model.AddActionSchedule(state.AddActionScheduleArgs{
+		Receiver:   arg.Receiver,
+		ActionName: arg.Name,
+		Parameters: arg.Parameters,
+		Schedule:   arg.Schedule,
+		Overlap:    state.ActionScheduleOverlap(arg.Overlap),
+		Owner:      a.authorizer.GetAuthTag().Id(),
+	})
+}
+
+// ListActionSchedules returns the action schedules of the model,
+// with the history of the runs of their actions.
+func (a *ActionAPI) ListActionSchedules() (params.ActionScheduleResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	schedules, err := a.model.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	results := params.ActionScheduleResults{
+		Results: make([]params.ActionScheduleResult, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		results.Results[i].Schedule, err = actionScheduleToParams(schedule)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// PauseActionSchedules stops the actions of the specified
+// schedules from being run until they are resumed.
+func (a *ActionAPI) PauseActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, func(schedule *state.ActionSchedule) error {
+		return schedule.SetPaused(true)
+	})
+}
+
+// ResumeActionSchedules resumes running the actions of the specified
+// schedules. The runs due while they were paused are not made up.
+func (a *ActionAPI) ResumeActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, func(schedule *state.ActionSchedule) error {
+		return schedule.SetPaused(false)
+	})
+}
+
+// RemoveActionSchedules removes the specified schedules.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	return a.updateActionSchedules(args, func(schedule *state.ActionSchedule) error {
+		return schedule.Remove()
+	})
+}
+
+func (a *ActionAPI) updateActionSchedules(
+	args params.ActionScheduleIds, update func(*state.ActionSchedule) error,
+) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		schedule, err := a.model.ActionSchedule(id)
+		if err == nil {
+			err = update(schedule)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func actionScheduleToParams(schedule *state.ActionSchedule) (*params.ActionSchedule, error) {
+	result := &params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receiver:   schedule.Receiver(),
+		Name:       schedule.ActionName(),
+		Parameters: schedule.Parameters(),
+		Schedule:   schedule.Schedule(),
+		Overlap:    string(schedule.Overlap()),
+		Owner:      schedule.Owner(),
+		Created:    schedule.Created(),
+		Paused:     schedule.Paused(),
+	}
+	if lastRun := schedule.LastRun(); !lastRun.IsZero() {
+		result.LastRun = &lastRun
+	}
+	if !schedule.Paused() {
+		nextRun, err := schedule.NextRun()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result.NextRun = &nextRun
+	}
+	for _, run := range schedule.History() {
+		paramsRun := params.ActionScheduleRun{
+			Time:    run.Time,
+			Skipped: run.Skipped,
+			Error:   run.Error,
+		}
+		if run.OperationId != "" {
+			paramsRun.OperationTag = names.NewOperationTag(run.OperationId).String()
+		}
+		result.History = append(result.History, paramsRun)
+	}
+	return result, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) addSchedule(c *gc.C, arg params.AddActionScheduleArg) params.ActionScheduleResult {
+	results, err := s.action.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{arg},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *scheduleSuite) TestAddActionSchedules(c *gc.C) {
+	result := s.addSchedule(c, params.AddActionScheduleArg{
+		Receiver: "wordpress",
+		Name:     "fakeaction",
+		Schedule: "0 3 * * *",
+		Overlap:  "queue",
+	})
+	c.Assert(result.Error, gc.IsNil)
+	schedule := result.Schedule
+	c.Assert(schedule.Id, gc.Equals, "1")
+	c.Assert(schedule.Receiver, gc.Equals, "wordpress")
+	c.Assert(schedule.Name, gc.Equals, "fakeaction")
+	c.Assert(schedule.Schedule, gc.Equals, "0 3 * * *")
+	c.Assert(schedule.Overlap, gc.Equals, "queue")
+	c.Assert(schedule.Owner, gc.Equals, "admin")
+	c.Assert(schedule.Paused, jc.IsFalse)
+	c.Assert(schedule.LastRun, gc.IsNil)
+	c.Assert(schedule.NextRun, gc.NotNil)
+	c.Assert(schedule.NextRun.Hour(), gc.Equals, 3)
+}
+
+func (s *scheduleSuite) TestAddActionSchedulesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		arg params.AddActionScheduleArg
+		err string
+	}{{
+		arg: params.AddActionScheduleArg{Receiver: "wordpress/0", Name: "missing", Schedule: "@daily"},
+		err: `action "missing" on application "wordpress" not found`,
+	}, {
+		arg: params.AddActionScheduleArg{Receiver: "dummy/leader", Name: "snapshot", Schedule: "@daily",
+			Parameters: map[string]interface{}{"outfile": 42}},
+		err: `validation failed: .*`,
+	}, {
+		arg: params.AddActionScheduleArg{Receiver: "wordpress", Name: "fakeaction", Schedule: "every day"},
+		err: `schedule "every day" with 2 fields, expected 5 not valid`,
+	}, {
+		arg: params.AddActionScheduleArg{Receiver: "unit-wordpress-0", Name: "fakeaction", Schedule: "@daily"},
+		err: `action receiver "unit-wordpress-0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		result := s.addSchedule(c, test.arg)
+		c.Check(result.Error, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *scheduleSuite) TestAddActionSchedulesBlocked(c *gc.C) {
+	s.BlockAllChanges(c, "TestAddActionSchedulesBlocked")
+	_, err := s.action.AddActionSchedules(params.AddActionScheduleArgs{
+		Schedules: []params.AddActionScheduleArg{{Receiver: "wordpress", Name: "fakeaction", Schedule: "@daily"}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+func (s *scheduleSuite) TestListActionSchedules(c *gc.C) {
+	s.toSupportNewActionID(c)
+	s.addSchedule(c, params.AddActionScheduleArg{Receiver: "wordpress", Name: "fakeaction", Schedule: "@hourly"})
+	s.addSchedule(c, params.AddActionScheduleArg{Receiver: "mysql/0", Name: "fakeaction", Schedule: "@daily"})
+
+	schedule, err := s.Model.ActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+	due := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	run, err := schedule.Trigger(due)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	first := results.Results[0].Schedule
+	c.Assert(first.Id, gc.Equals, "1")
+	c.Assert(first.LastRun, gc.NotNil)
+	c.Assert(first.LastRun.Equal(due), jc.IsTrue)
+	c.Assert(first.History, gc.HasLen, 1)
+	c.Assert(first.History[0].OperationTag, gc.Equals, "operation-"+run.OperationId)
+	c.Assert(results.Results[1].Schedule.Id, gc.Equals, "2")
+	c.Assert(results.Results[1].Schedule.History, gc.HasLen, 0)
+}
+
+func (s *scheduleSuite) TestPauseResumeRemoveActionSchedules(c *gc.C) {
+	s.addSchedule(c, params.AddActionScheduleArg{Receiver: "wordpress", Name: "fakeaction", Schedule: "@hourly"})
+	ids := params.ActionScheduleIds{Ids: []string{"1", "42"}}
+
+	errResults, err := s.action.PauseActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.Results, gc.HasLen, 2)
+	c.Assert(errResults.Results[0].Error, gc.IsNil)
+	c.Assert(errResults.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	schedule, err := s.Model.ActionSchedule("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsTrue)
+
+	results, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Schedule.Paused, jc.IsTrue)
+	c.Assert(results.Results[0].Schedule.NextRun, gc.IsNil)
+
+	errResults, err = s.action.ResumeActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.Results[0].Error, gc.IsNil)
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsFalse)
+
+	errResults, err = s.action.RemoveActionSchedules(ids)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errResults.Results[0].Error, gc.IsNil)
+	results, err = s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+}
@@ -7,10 +7,12 @@
 package application
 
 import (
+	"crypto/tls"
 	"fmt"
 	"math"
 	"net"
 	"reflect"
+	"time"
 
 	"github.com/juju/charm/v7"
 	csparams "github.com/juju/charmrepo/v5/csclient/params"
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/names/v4"
 	"github.com/juju/schema"
+	"github.com/juju/utils"
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/macaroon.v2"
@@ -99,6 +102,24 @@ type APIv11 struct {
 // APIv12 provides the Application API facade for version 12.
 // It adds the UnitsInfo method.
 type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds the ImportK8sWorkload method.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds the BackupApplications and RestoreApplications methods.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds the SetIngressTLSCertificates method.
+type APIv15 struct {
 	*APIBase
 }
 
@@ -206,13 +227,37 @@ func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
 }
 
 func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV13(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv12{api}, nil
 }
 
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -448,9 +493,300 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 	return result, nil
 }
 
+// ImportK8sWorkload isn't on the v12 API.
+func (u *APIv12) ImportK8sWorkload(_, _ struct{}) {}
+
+// ImportK8sWorkload deploys applications which manage workloads already
+// running in the cluster of a container model. Each workload is labelled
+// and annotated as the resources of the application with the same name,
+// which is deployed with the specified charm and as many units as the
+// workload runs.
+func (api *APIBase) ImportK8sWorkload(args params.ImportK8sWorkloadArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.ErrorResults{}, errors.NotSupportedf("importing workloads on a non-container model")
+	}
+	importer, ok := api.caasBroker.(caas.WorkloadImporter)
+	if !ok {
+		return params.ErrorResults{}, errors.NotSupportedf("importing workloads into this cluster")
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Workloads)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Workloads {
+		err := api.importK8sWorkload(importer, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) importK8sWorkload(importer caas.WorkloadImporter, arg params.ImportK8sWorkloadArg) error {
+	if !names.IsValidApplication(arg.ApplicationName) {
+		return errors.NotValidf("application name %q", arg.ApplicationName)
+	}
+	if _, err := api.backend.Application(arg.ApplicationName); err == nil {
+		return errors.AlreadyExistsf("application %q", arg.ApplicationName)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	numUnits, err := importer.ImportWorkload(arg.ApplicationName, caas.DeploymentType(arg.DeploymentType))
+	if err != nil {
+		return errors.Annotatef(err, "importing workload %q", arg.ApplicationName)
+	}
+	return deployApplication(
+		api.backend,
+		api.model,
+		api.stateCharm,
+		params.ApplicationDeploy{
+			ApplicationName: arg.ApplicationName,
+			Series:          "kubernetes",
+			CharmURL:        arg.CharmURL,
+			NumUnits:        numUnits,
+		},
+		api.deployApplicationFunc,
+		api.storagePoolManager,
+		api.registry,
+		api.caasBroker,
+	)
+}
+
+// backupIdFormat is the layout of the time at which a backup is made,
+// used with a random suffix to identify the backup and its snapshots.
+const backupIdFormat = "20060102-150405"
+
+// newBackupId returns the id of a backup made at the specified time.
+// The suffix keeps backups made in the same second apart.
+func newBackupId(created time.Time) string {
+	suffix := utils.RandomString(4, append(utils.LowerAlpha, utils.Digits...))
+	return created.Format(backupIdFormat) + "-" + suffix
+}
+
+// BackupApplications isn't on the v13 API.
+func (u *APIv13) BackupApplications(_, _ struct{}) {}
+
+// RestoreApplications isn't on the v13 API.
+func (u *APIv13) RestoreApplications(_, _ struct{}) {}
+
+// BackupApplications takes a snapshot of each volume claim owned by the
+// storage of the applications of a container model, and records them
+// as a backup from which the storage can be restored.
+func (api *APIBase) BackupApplications(args params.Entities) (params.ApplicationBackupResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ApplicationBackupResults{}, errors.Trace(err)
+	}
+	snapshotter, err := api.storageSnapshotter("backing up")
+	if err != nil {
+		return params.ApplicationBackupResults{}, errors.Trace(err)
+	}
+	result := params.ApplicationBackupResults{
+		Results: make([]params.ApplicationBackupResult, len(args.Entities)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		backup, err := api.backupApplication(snapshotter, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = backup
+	}
+	return result, nil
+}
+
+func (api *APIBase) backupApplication(snapshotter caas.StorageSnapshotter, tag string) (*params.ApplicationBackup, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	created := time.Now().UTC()
+	id := newBackupId(created)
+	snapshots, err := snapshotter.SnapshotStorage(appTag.Id(), id)
+	if err != nil {
+		return nil, errors.Annotatef(err, "snapshotting storage of application %q", appTag.Id())
+	}
+	backup := state.ApplicationBackup{
+		Id:          id,
+		Application: appTag.Id(),
+		Created:     created,
+		Snapshots:   make([]state.VolumeSnapshot, len(snapshots)),
+	}
+	for i, s := range snapshots {
+		backup.Snapshots[i] = state.VolumeSnapshot{
+			StorageName:  s.StorageName,
+			ClaimName:    s.ClaimName,
+			SnapshotName: s.SnapshotName,
+			Size:         s.Size,
+		}
+	}
+	if err := app.AddBackup(backup.Id, backup.Created, backup.Snapshots); err != nil {
+		// The snapshots of a backup which is not recorded
+		// could not be restored, so they are not kept.
+		if deleteErr := snapshotter.DeleteSnapshots(snapshots); deleteErr != nil {
+			logger.Warningf("cannot delete snapshots of unrecorded backup %q of %q: %v", id, appTag.Id(), deleteErr)
+		}
+		return nil, errors.Trace(err)
+	}
+	return applicationBackupToParams(backup), nil
+}
+
+// RestoreApplications replaces the volume claims owned by the storage
+// of the applications of a container model with claims for volumes
+// restored from the snapshots of one of their backups. The applications
+// must be scaled to 0 first.
+func (api *APIBase) RestoreApplications(args params.RestoreApplicationArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	snapshotter, err := api.storageSnapshotter("restoring")
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := api.restoreApplication(snapshotter, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *APIBase) restoreApplication(snapshotter caas.StorageSnapshotter, arg params.RestoreApplicationArg) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var backup state.ApplicationBackup
+	if arg.BackupId != "" {
+		if backup, err = app.Backup(arg.BackupId); err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		backups, err := app.Backups()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(backups) == 0 {
+			return errors.NotFoundf("backups of application %q", appTag.Id())
+		}
+		backup = backups[len(backups)-1]
+	}
+	snapshots := make([]caas.VolumeSnapshot, len(backup.Snapshots))
+	for i, s := range backup.Snapshots {
+		snapshots[i] = caas.VolumeSnapshot{
+			StorageName:  s.StorageName,
+			ClaimName:    s.ClaimName,
+			SnapshotName: s.SnapshotName,
+			Size:         s.Size,
+		}
+	}
+	err = snapshotter.RestoreStorage(appTag.Id(), snapshots)
+	return errors.Annotatef(err, "restoring storage of application %q from backup %q", appTag.Id(), backup.Id)
+}
+
+// storageSnapshotter returns the broker of a container model as a
+// caas.StorageSnapshotter, describing the operation in any error.
+func (api *APIBase) storageSnapshotter(operation string) (caas.StorageSnapshotter, error) {
+	if api.modelType != state.ModelTypeCAAS {
+		return nil, errors.NotSupportedf("%s applications on a non-container model", operation)
+	}
+	snapshotter, ok := api.caasBroker.(caas.StorageSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("%s application storage in this cluster", operation)
+	}
+	return snapshotter, nil
+}
+
+// SetIngressTLSCertificates isn't on the v14 API.
+func (u *APIv14) SetIngressTLSCertificates(_, _ struct{}) {}
+
+// SetIngressTLSCertificates stores the TLS certificate and key for the
+// ingress of each application of a container model in a k8s secret, and
+// returns the name of the secret for the application config to refer to.
+// The key pairs are not kept in the model.
+func (api *APIBase) SetIngressTLSCertificates(args params.IngressTLSCertificates) (params.StringResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.StringResults{}, errors.Trace(err)
+	}
+	if api.modelType != state.ModelTypeCAAS {
+		return params.StringResults{}, errors.NotSupportedf("ingress TLS certificates on a non-container model")
+	}
+	setter, ok := api.caasBroker.(caas.IngressTLSSecretSetter)
+	if !ok {
+		return params.StringResults{}, errors.NotSupportedf("ingress TLS certificates in this cluster")
+	}
+	result := params.StringResults{
+		Results: make([]params.StringResult, len(args.Certificates)),
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Certificates {
+		secretName, err := api.setIngressTLSCertificate(setter, arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = secretName
+	}
+	return result, nil
+}
+
+func (api *APIBase) setIngressTLSCertificate(setter caas.IngressTLSSecretSetter, arg params.IngressTLSCertificate) (string, error) {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if _, err := api.backend.Application(appTag.Id()); err != nil {
+		return "", errors.Trace(err)
+	}
+	certificate, key := []byte(arg.Certificate), []byte(arg.Key)
+	if _, err := tls.X509KeyPair(certificate, key); err != nil {
+		return "", errors.NewNotValid(err, "TLS certificate and key")
+	}
+	secretName, err := setter.SetIngressTLSSecret(appTag.Id(), certificate, key)
+	return secretName, errors.Annotatef(err, "storing ingress TLS certificate of %q", appTag.Id())
+}
+
+func applicationBackupToParams(backup state.ApplicationBackup) *params.ApplicationBackup {
+	result := &params.ApplicationBackup{
+		Id:             backup.Id,
+		ApplicationTag: names.NewApplicationTag(backup.Application).String(),
+		Created:        backup.Created,
+		Snapshots:      make([]params.VolumeSnapshot, len(backup.Snapshots)),
+	}
+	for i, s := range backup.Snapshots {
+		result.Snapshots[i] = params.VolumeSnapshot{
+			StorageName:  s.StorageName,
+			ClaimName:    s.ClaimName,
+			SnapshotName: s.SnapshotName,
+			Size:         s.Size,
+		}
+	}
+	return result
+}
+
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return iaasConfigSchema()
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -458,9 +794,40 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	configSchema, defaults = addUpdateStatusSchemaAndDefaults(configSchema, defaults)
 	return AddTrustSchemaAndDefaults(configSchema, defaults)
 }
 
+// validateOperatorPodConfig returns an error if the operator pod resources
+// or placement overriding the model config in the given application config
+// are not valid.
+func validateOperatorPodConfig(cfg application.ConfigAttributes) error {
+	_, err := k8s.OperatorPodConfig(nil, cfg)
+	return errors.Trace(err)
+}
+
+// validateIngressTLSConfig returns an error if applying the given
+// changes to the current application config results in invalid
+// ingress TLS settings.
+func validateIngressTLSConfig(current, changes application.ConfigAttributes) error {
+	cfg := make(application.ConfigAttributes)
+	for k, v := range current {
+		cfg[k] = v
+	}
+	for k, v := range changes {
+		cfg[k] = v
+	}
+	return errors.Trace(k8s.ValidateIngressTLSConfig(cfg))
+}
+
+// changesIngressTLS returns whether the given application
+// config changes affect the ingress TLS settings.
+func changesIngressTLS(changes application.ConfigAttributes) bool {
+	_, issuer := changes[k8s.IngressTLSIssuerKey]
+	_, secret := changes[k8s.IngressTLSSecretKey]
+	return issuer || secret
+}
+
 func splitApplicationAndCharmConfig(modelType state.ModelType, inConfig map[string]string) (
 	appCfg map[string]interface{},
 	charmCfg map[string]string,
@@ -692,6 +1059,21 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateResourceTags(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateUpdateStatusHookInterval(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateOperatorPodConfig(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateIngressTLSConfig(nil, applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
+	if err := validateAutoscalingPolicy(nil, applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -1784,6 +2166,14 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 			}
 		}
 
+		newScale := arg.Scale
+		if arg.ScaleChange != 0 {
+			newScale = app.GetScale() + arg.ScaleChange
+		}
+		if err := checkAutoscaledScale(app, newScale); err != nil {
+			return nil, errors.Trace(err)
+		}
+
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -2316,6 +2706,37 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		changes, err := application.NewConfig(appConfigAttrs, configSchema, defaults)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateResourceTags(changes.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateUpdateStatusHookInterval(changes.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+		if err := validateOperatorPodConfig(changes.Attributes()); err != nil {
+			return errors.Trace(err)
+		}
+		if changesAutoscaling(changes.Attributes()) {
+			current, err := app.ApplicationConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := validateAutoscalingPolicy(current, changes.Attributes()); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if changesIngressTLS(changes.Attributes()) {
+			current, err := app.ApplicationConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := validateIngressTLSConfig(current, changes.Attributes()); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, configSchema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2726,6 +3147,23 @@ func (api *APIBase) UnitsInfo(in params.Entities) (params.UnitInfoResults, error
 			out[i].Error = common.ServerError(err)
 			continue
 		}
+		history, err := unit.HookHistory()
+		if err != nil {
+			out[i].Error = common.ServerError(err)
+			continue
+		}
+		for _, e := range history {
+			result.HookHistory = append(result.HookHistory, params.HookExecution{
+				Kind:     e.Kind,
+				Name:     e.Name,
+				Trigger:  e.Trigger,
+				Started:  e.Started,
+				Finished: e.Finished,
+				ExitCode: e.ExitCode,
+				Error:    e.Error,
+				Skipped:  e.Skipped,
+			})
+		}
 
 		out[i].Result = result
 	}
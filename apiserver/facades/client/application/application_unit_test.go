@@ -57,7 +57,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv12
+	api          *application.APIv15
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -88,7 +88,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv12{api}
+	s.api = &application.APIv15{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -832,6 +832,228 @@ func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	c.Assert(s.deployParams["foobar"].CharmConfig, jc.DeepEquals, charm.Settings{"intOption": int64(2)})
 }
 
+func (s *ApplicationSuite) TestImportK8sWorkload(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	s.backend.charm = &mockCharm{
+		meta: &charm.Meta{MinJujuVersion: version.MustParse("2.8.0")},
+	}
+	s.caasBroker.SetErrors(nil, errors.NotFoundf(`deployment "gone"`))
+
+	results, err := s.api.ImportK8sWorkload(params.ImportK8sWorkloadArgs{
+		Workloads: []params.ImportK8sWorkloadArg{{
+			ApplicationName: "web",
+			DeploymentType:  "stateless",
+			CharmURL:        "local:kubernetes/web-0",
+		}, {
+			ApplicationName: "gone",
+			DeploymentType:  "stateless",
+			CharmURL:        "local:kubernetes/gone-0",
+		}, {
+			ApplicationName: "postgresql",
+			DeploymentType:  "stateful",
+			CharmURL:        "local:kubernetes/postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `importing workload "gone": deployment "gone" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "postgresql" already exists`)
+
+	s.caasBroker.CheckCallNames(c, "ImportWorkload", "Version", "ImportWorkload")
+	s.caasBroker.CheckCall(c, 0, "ImportWorkload", "web", caas.DeploymentStateless)
+	c.Assert(s.deployParams["web"].NumUnits, gc.Equals, 3)
+	c.Assert(s.deployParams["web"].Series, gc.Equals, "kubernetes")
+	c.Assert(s.deployParams, gc.HasLen, 1)
+}
+
+func (s *ApplicationSuite) TestImportK8sWorkloadIAASModel(c *gc.C) {
+	_, err := s.api.ImportK8sWorkload(params.ImportK8sWorkloadArgs{
+		Workloads: []params.ImportK8sWorkloadArg{{
+			ApplicationName: "web",
+			DeploymentType:  "stateless",
+			CharmURL:        "local:kubernetes/web-0",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "importing workloads on a non-container model not supported")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestBackupApplications(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	s.caasBroker.SetErrors(nil, errors.NotFoundf(`persistent storage for application "postgresql"`))
+
+	results, err := s.api.BackupApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-postgresql"},
+			{Tag: "application-gone"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches,
+		`snapshotting storage of application "postgresql": persistent storage for application "postgresql" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "gone" not found`)
+
+	backup := results.Results[0].Result
+	c.Assert(backup.Id, gc.Matches, `\d{8}-\d{6}-[a-z0-9]{4}`)
+	c.Assert(backup.ApplicationTag, gc.Equals, "application-postgresql")
+	c.Assert(backup.Snapshots, jc.DeepEquals, []params.VolumeSnapshot{{
+		StorageName:  "database",
+		ClaimName:    "database-postgresql-0",
+		SnapshotName: "database-postgresql-0-" + backup.Id,
+		Size:         1024,
+	}})
+	s.caasBroker.CheckCallNames(c, "SnapshotStorage", "SnapshotStorage")
+	s.caasBroker.CheckCall(c, 0, "SnapshotStorage", "postgresql", backup.Id)
+
+	app := s.backend.applications["postgresql"]
+	c.Assert(app.backups, gc.HasLen, 1)
+	c.Assert(app.backups[0].Id, gc.Equals, backup.Id)
+	c.Assert(app.backups[0].Created, gc.Equals, backup.Created)
+}
+
+func (s *ApplicationSuite) TestBackupApplicationsNotRecorded(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	s.backend.applications["postgresql"].SetErrors(errors.New("boom"))
+
+	results, err := s.api.BackupApplications(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+
+	// The snapshots of the backup which could not be recorded are deleted.
+	s.caasBroker.CheckCallNames(c, "SnapshotStorage", "DeleteSnapshots")
+	id := s.caasBroker.Calls()[0].Args[1].(string)
+	s.caasBroker.CheckCall(c, 1, "DeleteSnapshots", []caas.VolumeSnapshot{{
+		StorageName:  "database",
+		ClaimName:    "database-postgresql-0",
+		SnapshotName: "database-postgresql-0-" + id,
+		Size:         1024,
+	}})
+}
+
+func (s *ApplicationSuite) TestBackupApplicationsIAASModel(c *gc.C) {
+	_, err := s.api.BackupApplications(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "backing up applications on a non-container model not supported")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetIngressTLSCertificates(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+
+	results, err := s.api.SetIngressTLSCertificates(params.IngressTLSCertificates{
+		Certificates: []params.IngressTLSCertificate{{
+			ApplicationTag: "application-postgresql",
+			Certificate:    coretesting.ServerCert,
+			Key:            coretesting.ServerKey,
+		}, {
+			ApplicationTag: "application-postgresql",
+			Certificate:    coretesting.ServerCert,
+			Key:            coretesting.CAKey,
+		}, {
+			ApplicationTag: "application-gone",
+			Certificate:    coretesting.ServerCert,
+			Key:            coretesting.ServerKey,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.StringResult{Result: "postgresql-tls"})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `TLS certificate and key: .*`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "gone" not found`)
+	s.caasBroker.CheckCallNames(c, "SetIngressTLSSecret")
+	s.caasBroker.CheckCall(c, 0, "SetIngressTLSSecret",
+		"postgresql", []byte(coretesting.ServerCert), []byte(coretesting.ServerKey))
+}
+
+func (s *ApplicationSuite) TestSetIngressTLSCertificatesIAASModel(c *gc.C) {
+	_, err := s.api.SetIngressTLSCertificates(params.IngressTLSCertificates{
+		Certificates: []params.IngressTLSCertificate{{ApplicationTag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "ingress TLS certificates on a non-container model not supported")
+	s.caasBroker.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRestoreApplications(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	snapshots := func(id string) []state.VolumeSnapshot {
+		return []state.VolumeSnapshot{{
+			StorageName:  "database",
+			ClaimName:    "database-postgresql-0",
+			SnapshotName: "database-postgresql-0-" + id,
+			Size:         1024,
+		}}
+	}
+	s.backend.applications["postgresql"].backups = []state.ApplicationBackup{{
+		Id:          "20200102-030405",
+		Application: "postgresql",
+		Snapshots:   snapshots("20200102-030405"),
+	}, {
+		Id:          "20200103-030405",
+		Application: "postgresql",
+		Snapshots:   snapshots("20200103-030405"),
+	}}
+
+	results, err := s.api.RestoreApplications(params.RestoreApplicationArgs{
+		Args: []params.RestoreApplicationArg{
+			{ApplicationTag: "application-postgresql", BackupId: "20200102-030405"},
+			{ApplicationTag: "application-postgresql"},
+			{ApplicationTag: "application-postgresql", BackupId: "20200104-030405"},
+			{ApplicationTag: "application-redis"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `backup "20200104-030405" of application "postgresql" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `backups of application "redis" not found`)
+
+	s.caasBroker.CheckCallNames(c, "RestoreStorage", "RestoreStorage")
+	s.caasBroker.CheckCall(c, 0, "RestoreStorage", "postgresql", []caas.VolumeSnapshot{{
+		StorageName:  "database",
+		ClaimName:    "database-postgresql-0",
+		SnapshotName: "database-postgresql-0-20200102-030405",
+		Size:         1024,
+	}})
+	// The most recent backup is restored by default.
+	s.caasBroker.CheckCall(c, 1, "RestoreStorage", "postgresql", []caas.VolumeSnapshot{{
+		StorageName:  "database",
+		ClaimName:    "database-postgresql-0",
+		SnapshotName: "database-postgresql-0-20200103-030405",
+		Size:         1024,
+	}})
+}
+
+func (s *ApplicationSuite) TestRestoreApplicationsRunningPods(c *gc.C) {
+	s.model.modelType = state.ModelTypeCAAS
+	s.setAPIUser(c, names.NewUserTag("admin"))
+	s.backend.applications["postgresql"].backups = []state.ApplicationBackup{{
+		Id:          "20200102-030405",
+		Application: "postgresql",
+	}}
+	s.caasBroker.SetErrors(errors.NotValidf("running pods"))
+
+	results, err := s.api.RestoreApplications(params.RestoreApplicationArgs{
+		Args: []params.RestoreApplicationArg{{ApplicationTag: "application-postgresql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches,
+		`restoring storage of application "postgresql" from backup "20200102-030405": running pods not valid`)
+}
+
 func (s *ApplicationSuite) TestDeployCAASBlockStorageRejected(c *gc.C) {
 	s.model.modelType = state.ModelTypeCAAS
 	s.backend.charm = &mockCharm{
@@ -1019,7 +1241,33 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "Scale", 5)
+	app.CheckCallNames(c, "Charm", "ApplicationConfig", "Scale")
+	app.CheckCall(c, 2, "Scale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"autoscale-min-units":  2,
+		"autoscale-max-units":  4,
+		"autoscale-cpu-target": 80,
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          3,
+		}, {
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ScaleApplicationResult{
+		Info: &params.ScaleApplicationInfo{Scale: 3},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `scale 5 outside autoscaling range 2-4 not valid`)
+	app.CheckCallNames(c, "Charm", "ApplicationConfig", "Scale", "Charm", "ApplicationConfig")
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsNotAllowedForOperator(c *gc.C) {
@@ -1098,7 +1346,8 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 1, "ChangeScale", 5)
+	app.CheckCallNames(c, "Charm", "GetScale", "ApplicationConfig", "ChangeScale")
+	app.CheckCall(c, 3, "ChangeScale", 5)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleArgCheck(c *gc.C) {
@@ -1663,6 +1912,7 @@ func (s *ApplicationSuite) testSetApplicationConfig(c *gc.C, branchName string)
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults = application.AddUpdateStatusSchemaAndDefaults(schema, defaults)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1695,6 +1945,7 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults = application.AddUpdateStatusSchemaAndDefaults(schema, defaults)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1706,6 +1957,137 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	s.backend.generation.CheckCall(c, 0, "AssignApplication", "postgresql")
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigResourceTags(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeIAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"resource-tags": "team=finance unit={unit}",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"resource-tags": "team=finance unit={unit}",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigResourceTagsReservedPrefix(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeIAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"resource-tags": "juju-model=foo",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `resource tag "juju-model" using reserved prefix "juju-" not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigUpdateStatusHookInterval(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeIAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"update-status-hook-interval": "30m",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"update-status-hook-interval": "30m",
+	})
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigUpdateStatusHookIntervalInvalid(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"update-status-hook-interval": "10s",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `update status hook interval 10s less than 1m0s not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigOperatorPodConfigInvalid(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-operator-cpu-limit": "lots",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `operator-cpu-limit "lots" not valid`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscaling(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"autoscale-cpu-target": 80,
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"autoscale-min-units": "2",
+				"autoscale-max-units": "5",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app.CheckCallNames(c, "ApplicationConfig", "UpdateApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigAutoscalingInvalid(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"autoscale-min-units":  "4",
+				"autoscale-max-units":  "2",
+				"autoscale-cpu-target": "80",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `invalid autoscaling policy: maximum units 2 less than minimum units 4`)
+	s.backend.applications["postgresql"].CheckCallNames(c, "ApplicationConfig")
+}
+
+func (s *ApplicationSuite) TestSetApplicationConfigIngressTLSInvalid(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.config = coreapplication.ConfigAttributes{
+		"kubernetes-ingress-tls-secret": "postgresql-tls",
+	}
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config: map[string]string{
+				"kubernetes-ingress-tls-issuer": "letsencrypt",
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `both "kubernetes-ingress-tls-issuer" and "kubernetes-ingress-tls-secret" not valid`)
+	app.CheckCallNames(c, "ApplicationConfig")
+}
+
 func (s *ApplicationSuite) TestBlockSetApplicationConfig(c *gc.C) {
 	s.blockChecker.SetErrors(errors.New("blocked"))
 	_, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{})
@@ -1742,6 +2124,7 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, defaults = application.AddUpdateStatusSchemaAndDefaults(schema, defaults)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1964,6 +2347,13 @@ func (s *ApplicationSuite) TestUnitsInfo(c *gc.C) {
 				},
 			},
 		}},
+		HookHistory: []params.HookExecution{{
+			Kind:     "hook",
+			Name:     "config-changed",
+			Trigger:  "config changed",
+			Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			Finished: time.Date(2020, 6, 1, 12, 0, 3, 0, time.UTC),
+		}},
 		ProviderId: "provider-id",
 		Address:    "192.168.1.1",
 	})
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+var autoscalingConfigKeys = []string{
+	caas.AutoscaleMinUnitsKey,
+	caas.AutoscaleMaxUnitsKey,
+	caas.AutoscaleCPUTargetKey,
+	caas.AutoscaleMemoryTargetKey,
+	caas.AutoscaleMetricTargetsKey,
+}
+
+// changesAutoscaling reports whether the given application config
+// changes include any autoscaling policy settings.
+func changesAutoscaling(changes application.ConfigAttributes) bool {
+	for _, key := range autoscalingConfigKeys {
+		if _, ok := changes[key]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// validateAutoscalingPolicy returns an error if applying the given
+// changes to the current application config results in an invalid
+// autoscaling policy.
+func validateAutoscalingPolicy(current, changes application.ConfigAttributes) error {
+	cfg := make(application.ConfigAttributes)
+	for k, v := range current {
+		cfg[k] = v
+	}
+	for k, v := range changes {
+		cfg[k] = v
+	}
+	_, err := caas.AutoscalingPolicyFromConfig(cfg)
+	return errors.Annotate(err, "invalid autoscaling policy")
+}
+
+// checkAutoscaledScale returns an error if the application is
+// autoscaled and the requested scale is outside the bounds of
+// its autoscaling policy.
+func checkAutoscaledScale(app Application, scale int) error {
+	cfg, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy, err := caas.AutoscalingPolicyFromConfig(cfg)
+	if err != nil || policy == nil {
+		return errors.Trace(err)
+	}
+	if scale < policy.MinUnits || scale > policy.MaxUnits {
+		return errors.NotValidf(
+			"scale %d outside autoscaling range %d-%d", scale, policy.MinUnits, policy.MaxUnits)
+	}
+	return nil
+}
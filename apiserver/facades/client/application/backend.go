@@ -69,6 +69,7 @@ type BlockChecker interface {
 // the same names.
 type Application interface {
 	Name() string
+	AddBackup(string, time.Time, []state.VolumeSnapshot) error
 	AddUnit(state.AddUnitParams) (Unit, error)
 	AllUnits() ([]Unit, error)
 	ApplicationConfig() (application.ConfigAttributes, error)
@@ -94,9 +95,12 @@ type Application interface {
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	GetScale() int
 	SetScale(int, int64, bool) error
 	ChangeScale(int) (int, error)
 	AgentTools() (*tools.Tools, error)
+	Backup(string) (state.ApplicationBackup, error)
+	Backups() ([]state.ApplicationBackup, error)
 	MergeBindings(*state.Bindings, bool) error
 	Relations() ([]Relation, error)
 }
@@ -175,6 +179,7 @@ type Unit interface {
 	AssignWithPolicy(state.AssignmentPolicy) error
 	AssignWithPlacement(*instance.Placement) error
 	ContainerInfo() (state.CloudContainer, error)
+	HookHistory() ([]state.HookExecution, error)
 }
 
 // Model defines a subset of the functionality provided by the
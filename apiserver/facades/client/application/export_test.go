@@ -9,6 +9,8 @@ var (
 	ParseSettingsCompatible = parseSettingsCompatible
 	NewStateStorage         = &newStateStorage
 	GetStorageState         = getStorageState
+
+	AddUpdateStatusSchemaAndDefaults = addUpdateStatusSchemaAndDefaults
 )
 
 func GetState(st *state.State) Backend {
@@ -19,6 +21,6 @@ func GetModel(m *state.Model) Model {
 	return modelShim{m}
 }
 
-func SetModelType(api *APIv12, modelType state.ModelType) {
+func SetModelType(api *APIv15, modelType state.ModelType) {
 	api.modelType = modelType
 }
@@ -104,6 +104,7 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+	backups     []state.ApplicationBackup
 }
 
 func (m *mockApplication) Name() string {
@@ -281,6 +282,38 @@ func (m *mockApplication) MergeBindings(bindings *state.Bindings, force bool) er
 	return m.NextErr()
 }
 
+func (m *mockApplication) AddBackup(id string, created time.Time, snapshots []state.VolumeSnapshot) error {
+	m.MethodCall(m, "AddBackup", id, created, snapshots)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.backups = append(m.backups, state.ApplicationBackup{
+		Id:          id,
+		Application: m.name,
+		Created:     created,
+		Snapshots:   snapshots,
+	})
+	return nil
+}
+
+func (m *mockApplication) Backup(id string) (state.ApplicationBackup, error) {
+	m.MethodCall(m, "Backup", id)
+	if err := m.NextErr(); err != nil {
+		return state.ApplicationBackup{}, err
+	}
+	for _, backup := range m.backups {
+		if backup.Id == id {
+			return backup, nil
+		}
+	}
+	return state.ApplicationBackup{}, errors.NotFoundf("backup %q of application %q", id, m.name)
+}
+
+func (m *mockApplication) Backups() ([]state.ApplicationBackup, error) {
+	m.MethodCall(m, "Backups")
+	return m.backups, m.NextErr()
+}
+
 type mockNotifyWatcher struct {
 	state.NotifyWatcher
 	jtesting.Stub
@@ -947,6 +980,17 @@ func (u *mockUnit) ContainerInfo() (state.CloudContainer, error) {
 	return mockCloudContainer{}, nil
 }
 
+func (u *mockUnit) HookHistory() ([]state.HookExecution, error) {
+	u.MethodCall(u, "HookHistory")
+	return []state.HookExecution{{
+		Kind:     "hook",
+		Name:     "config-changed",
+		Trigger:  "config changed",
+		Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		Finished: time.Date(2020, 6, 1, 12, 0, 3, 0, time.UTC),
+	}}, u.NextErr()
+}
+
 func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	u.MethodCall(u, "AgentTools")
 	return u.agentTools, u.NextErr()
@@ -1117,6 +1161,41 @@ func (m *mockCaasBroker) Version() (*version.Number, error) {
 	return &ver, nil
 }
 
+func (m *mockCaasBroker) ImportWorkload(name string, deploymentType caas.DeploymentType) (int, error) {
+	m.MethodCall(m, "ImportWorkload", name, deploymentType)
+	return 3, m.NextErr()
+}
+
+func (m *mockCaasBroker) ScaleImportedService(appName string, scale int) error {
+	m.MethodCall(m, "ScaleImportedService", appName, scale)
+	return m.NextErr()
+}
+
+func (m *mockCaasBroker) SnapshotStorage(appName, suffix string) ([]caas.VolumeSnapshot, error) {
+	m.MethodCall(m, "SnapshotStorage", appName, suffix)
+	return []caas.VolumeSnapshot{{
+		StorageName:  "database",
+		ClaimName:    "database-" + appName + "-0",
+		SnapshotName: "database-" + appName + "-0-" + suffix,
+		Size:         1024,
+	}}, m.NextErr()
+}
+
+func (m *mockCaasBroker) SetIngressTLSSecret(appName string, certificate, key []byte) (string, error) {
+	m.MethodCall(m, "SetIngressTLSSecret", appName, certificate, key)
+	return appName + "-tls", m.NextErr()
+}
+
+func (m *mockCaasBroker) RestoreStorage(appName string, snapshots []caas.VolumeSnapshot) error {
+	m.MethodCall(m, "RestoreStorage", appName, snapshots)
+	return m.NextErr()
+}
+
+func (m *mockCaasBroker) DeleteSnapshots(snapshots []caas.VolumeSnapshot) error {
+	m.MethodCall(m, "DeleteSnapshots", snapshots)
+	return m.NextErr()
+}
+
 type mockGeneration struct {
 	jtesting.Stub
 }
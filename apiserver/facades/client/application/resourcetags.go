@@ -0,0 +1,49 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/tags"
+)
+
+var resourceTagsFields = environschema.Fields{
+	application.ResourceTagsConfigOptionName: {
+		Description: "a space separated set of key=value tags to set on the application's cloud resources",
+		Type:        environschema.Tattrs,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var resourceTagsDefaults = schema.Defaults{
+	application.ResourceTagsConfigOptionName: schema.Omit,
+}
+
+// iaasConfigSchema returns the schema fields and defaults of the
+// application config of applications in IAAS models.
+func iaasConfigSchema() (environschema.Fields, schema.Defaults, error) {
+	fields, defaults := addUpdateStatusSchemaAndDefaults(resourceTagsFields, resourceTagsDefaults)
+	return AddTrustSchemaAndDefaults(fields, defaults)
+}
+
+// validateResourceTags returns an error if the resource tags in the
+// given application config use the prefix reserved for Juju's tags.
+func validateResourceTags(cfg application.ConfigAttributes) error {
+	resourceTags, err := cfg.GetStringMap(application.ResourceTagsConfigOptionName, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for k := range resourceTags {
+		if strings.HasPrefix(k, tags.JujuTagPrefix) {
+			return errors.NotValidf("resource tag %q using reserved prefix %q", k, tags.JujuTagPrefix)
+		}
+	}
+	return nil
+}
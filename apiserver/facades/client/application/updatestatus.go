@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+var updateStatusFields = environschema.Fields{
+	application.UpdateStatusHookIntervalConfigOptionName: {
+		Description: "How often to run the charm update-status hook, overriding the model's update-status-hook-interval",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+var updateStatusDefaults = schema.Defaults{
+	application.UpdateStatusHookIntervalConfigOptionName: schema.Omit,
+}
+
+// addUpdateStatusSchemaAndDefaults adds the update-status hook interval
+// schema fields and defaults to an existing set of schema fields and
+// defaults.
+func addUpdateStatusSchemaAndDefaults(fields environschema.Fields, defaults schema.Defaults) (environschema.Fields, schema.Defaults) {
+	newFields := make(environschema.Fields)
+	for name, field := range fields {
+		newFields[name] = field
+	}
+	for name, field := range updateStatusFields {
+		newFields[name] = field
+	}
+	newDefaults := make(schema.Defaults)
+	for key, value := range defaults {
+		newDefaults[key] = value
+	}
+	for key, value := range updateStatusDefaults {
+		newDefaults[key] = value
+	}
+	return newFields, newDefaults
+}
+
+// validateUpdateStatusHookInterval returns an error if the update-status
+// hook interval in the given application config is not valid.
+func validateUpdateStatusHookInterval(cfg application.ConfigAttributes) error {
+	_, _, err := cfg.UpdateStatusHookInterval()
+	return errors.Trace(err)
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/crossmodel"
@@ -1264,6 +1265,13 @@ func (context *statusContext) processApplication(application *state.Application)
 			logger.Debugf("no service details for %v: %v", application.Name(), err)
 		}
 		processedStatus.Scale = application.GetScale()
+		if processedStatus.Exposed {
+			appConfig, err := application.ApplicationConfig()
+			if err != nil {
+				return params.ApplicationStatus{Err: common.ServerError(err)}
+			}
+			processedStatus.ExternalURL = k8sprovider.ExternalURL(application.Name(), appConfig)
+		}
 	}
 	processedStatus.EndpointBindings = context.allAppsUnitsCharmBindings.endpointBindings[application.Name()]
 	return processedStatus
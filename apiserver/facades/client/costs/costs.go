@@ -0,0 +1,372 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/permission"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// defaultVolumeSizeMiB is the size of a volume that is assumed when
+// the storage constraints do not specify one.
+const defaultVolumeSizeMiB = 1024
+
+// NewEstimatorFunc returns the CostEstimator of the model's environ.
+type NewEstimatorFunc func() (environs.CostEstimator, error)
+
+// CostsAPI provides access to the Costs API facade, which estimates
+// the running costs of applications.
+type CostsAPI struct {
+	backend      Backend
+	authorizer   facade.Authorizer
+	newEstimator NewEstimatorFunc
+	registry     storage.ProviderRegistry
+	poolManager  poolmanager.PoolManager
+
+	modelTag    names.ModelTag
+	callContext context.ProviderCallContext
+}
+
+// NewFacade creates a new server-side Costs API facade. This is used
+// for facade registration.
+func NewFacade(ctx facade.Context) (*CostsAPI, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() != state.ModelTypeIAAS {
+		return nil, errors.NotSupportedf("cost estimation for %s models", model.Type())
+	}
+	registry, err := stateenvirons.NewStorageProviderRegistryForModel(
+		model,
+		stateenvirons.GetNewEnvironFunc(environs.New),
+		stateenvirons.GetNewCAASBrokerFunc(caas.New))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	poolManager := poolmanager.New(state.NewStateSettings(st), registry)
+	storageBackend, err := state.NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	newEstimator := func() (environs.CostEstimator, error) {
+		env, err := environs.GetEnviron(common.EnvironConfigGetterFuncs{
+			CloudSpecFunc: func() (environs.CloudSpec, error) {
+				return stateenvirons.CloudSpecForModel(model)
+			},
+			ModelConfigFunc: model.Config,
+		}, environs.New)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		estimator, ok := env.(environs.CostEstimator)
+		if !ok {
+			return nil, errors.NotSupportedf("cost estimation for %q clouds", model.CloudName())
+		}
+		return estimator, nil
+	}
+
+	return NewCostsAPI(
+		stateShim{State: st, model: model, storage: storageBackend},
+		ctx.Auth(),
+		newEstimator,
+		registry,
+		poolManager,
+		model.ModelTag(),
+		context.CallContext(st),
+	)
+}
+
+// NewCostsAPI creates a new server-side Costs API facade.
+func NewCostsAPI(
+	backend Backend,
+	auth facade.Authorizer,
+	newEstimator NewEstimatorFunc,
+	registry storage.ProviderRegistry,
+	poolManager poolmanager.PoolManager,
+	modelTag names.ModelTag,
+	callCtx context.ProviderCallContext,
+) (*CostsAPI, error) {
+	if !auth.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &CostsAPI{
+		backend:      backend,
+		authorizer:   auth,
+		newEstimator: newEstimator,
+		registry:     registry,
+		poolManager:  poolManager,
+		modelTag:     modelTag,
+		callContext:  callCtx,
+	}, nil
+}
+
+func (api *CostsAPI) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// EstimateCosts estimates the running costs of deploying the specified
+// applications to the model, e.g. when deploying a bundle. Each unit is
+// assumed to be deployed to a new machine, of the cheapest instance
+// type that satisfies the application's constraints combined with the
+// model's constraints.
+func (api *CostsAPI) EstimateCosts(args params.EstimateCostsArgs) (params.ApplicationCostResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationCostResults{}, errors.Trace(err)
+	}
+	estimator, err := api.newEstimator()
+	if err != nil {
+		return params.ApplicationCostResults{}, errors.Trace(err)
+	}
+	results := make([]params.ApplicationCostResult, len(args.Applications))
+	for i, arg := range args.Applications {
+		result, err := api.estimateApplicationCost(estimator, arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.ApplicationName = arg.ApplicationName
+		results[i] = result
+	}
+	return params.ApplicationCostResults{Results: results}, nil
+}
+
+func (api *CostsAPI) estimateApplicationCost(
+	estimator environs.CostEstimator,
+	arg params.ApplicationCostParams,
+) (params.ApplicationCostResult, error) {
+	if arg.NumUnits <= 0 {
+		return params.ApplicationCostResult{}, nil
+	}
+	cons, err := api.backend.ResolveConstraints(arg.Constraints)
+	if err != nil {
+		return params.ApplicationCostResult{}, errors.Trace(err)
+	}
+	var volumes []storage.VolumeParams
+	for name, sc := range arg.Storage {
+		pool := sc.Pool
+		if pool == "" {
+			cfg, err := api.backend.ModelConfig()
+			if err != nil {
+				return params.ApplicationCostResult{}, errors.Trace(err)
+			}
+			if pool, _ = cfg.StorageDefaultBlockSource(); pool == "" {
+				// The storage will not be backed by a volume.
+				continue
+			}
+		}
+		volume, err := api.volumeParams(pool, sc.Size)
+		if err != nil {
+			return params.ApplicationCostResult{}, errors.Annotatef(err, "storage %q", name)
+		}
+		count := sc.Count
+		if count == 0 {
+			count = 1
+		}
+		for j := uint64(0); j < count; j++ {
+			volumes = append(volumes, volume)
+		}
+	}
+	estimate, err := environs.EstimateInstanceCost(api.callContext, estimator, cons, volumes)
+	if err != nil {
+		return params.ApplicationCostResult{}, errors.Trace(err)
+	}
+	units := float64(arg.NumUnits)
+	return params.ApplicationCostResult{
+		Units:           arg.NumUnits,
+		InstanceType:    estimate.InstanceType,
+		Currency:        estimate.Currency,
+		InstancesHourly: estimate.InstanceHourly * units,
+		VolumesMonthly:  estimate.VolumesMonthly * units,
+		Monthly:         estimate.Monthly() * units,
+	}, nil
+}
+
+// ModelCosts estimates the running costs of the model's applications.
+// The cost of a machine is shared equally between the principal units
+// assigned to it; units in containers are not attributed any share of
+// their host machine's cost. The cost of the volumes backing a unit's
+// storage is attributed to the unit's application.
+func (api *CostsAPI) ModelCosts() (params.ApplicationCostResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ApplicationCostResults{}, errors.Trace(err)
+	}
+	estimator, err := api.newEstimator()
+	if err != nil {
+		return params.ApplicationCostResults{}, errors.Trace(err)
+	}
+	applications, err := api.backend.AllApplications()
+	if err != nil {
+		return params.ApplicationCostResults{}, errors.Trace(err)
+	}
+	machineCosts := make(map[string]*machineCost)
+	var results []params.ApplicationCostResult
+	for _, application := range applications {
+		if !application.IsPrincipal() {
+			continue
+		}
+		result, err := api.applicationCost(estimator, application, machineCosts)
+		if err != nil {
+			result = params.ApplicationCostResult{Error: common.ServerError(err)}
+		}
+		result.ApplicationName = application.Name()
+		results = append(results, result)
+	}
+	return params.ApplicationCostResults{Results: results}, nil
+}
+
+// machineCost records the share of a machine's hourly cost borne by
+// each principal unit assigned to it.
+type machineCost struct {
+	currency    string
+	hourlyShare float64
+}
+
+func (api *CostsAPI) applicationCost(
+	estimator environs.CostEstimator,
+	application Application,
+	machineCosts map[string]*machineCost,
+) (params.ApplicationCostResult, error) {
+	var result params.ApplicationCostResult
+	addCurrency := func(currency string) error {
+		if result.Currency == "" {
+			result.Currency = currency
+		} else if currency != "" && currency != result.Currency {
+			return errors.Errorf("costs priced in both %s and %s", result.Currency, currency)
+		}
+		return nil
+	}
+
+	units, err := application.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		cost, ok := machineCosts[machineId]
+		if !ok {
+			if cost, err = api.machineCost(estimator, machineId); err != nil {
+				return result, errors.Annotatef(err, "machine %q", machineId)
+			}
+			machineCosts[machineId] = cost
+		}
+		if err := addCurrency(cost.currency); err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Units++
+		result.InstancesHourly += cost.hourlyShare
+
+		volumes, err := api.backend.UnitVolumes(unit.UnitTag())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, volume := range volumes {
+			volumeParams, err := api.volumeParams(volume.Pool, volume.Size)
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			price, err := estimator.VolumeCost(api.callContext, volumeParams)
+			if err != nil {
+				return result, errors.Trace(err)
+			}
+			if price.Amount == 0 {
+				continue
+			}
+			if err := addCurrency(price.Currency); err != nil {
+				return result, errors.Trace(err)
+			}
+			result.VolumesMonthly += price.Amount
+		}
+	}
+	result.Monthly = result.InstancesHourly*environs.HoursPerMonth + result.VolumesMonthly
+	return result, nil
+}
+
+// machineCost returns the share of the machine's hourly cost borne by
+// each principal unit assigned to it. Machines that are containers or
+// that are not yet provisioned have no cost.
+func (api *CostsAPI) machineCost(estimator environs.CostEstimator, machineId string) (*machineCost, error) {
+	m, err := api.backend.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if m.ContainerType() != "" {
+		return &machineCost{}, nil
+	}
+	hc, err := m.HardwareCharacteristics()
+	if errors.IsNotFound(err) {
+		return &machineCost{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := m.Constraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !cons.HasInstanceType() {
+		// Without the instance type, price the cheapest instance
+		// type with the machine's hardware.
+		cons = constraints.Value{
+			Arch:     hc.Arch,
+			CpuCores: hc.CpuCores,
+			Mem:      hc.Mem,
+		}
+	}
+	estimate, err := environs.EstimateInstanceCost(api.callContext, estimator, cons, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	principals := len(m.Principals())
+	if principals == 0 {
+		principals = 1
+	}
+	return &machineCost{
+		currency:    estimate.Currency,
+		hourlyShare: estimate.InstanceHourly / float64(principals),
+	}, nil
+}
+
+// volumeParams returns the parameters of a volume of the specified
+// size, in MiB, in the named storage pool.
+func (api *CostsAPI) volumeParams(pool string, size uint64) (storage.VolumeParams, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(pool, api.poolManager, api.registry)
+	if err != nil {
+		return storage.VolumeParams{}, errors.Trace(err)
+	}
+	if size == 0 {
+		size = defaultVolumeSizeMiB
+	}
+	return storage.VolumeParams{
+		Provider:   providerType,
+		Size:       size,
+		Attributes: cfg.Attrs(),
+	}, nil
+}
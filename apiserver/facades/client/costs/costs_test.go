@@ -0,0 +1,265 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/costs"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type costsSuite struct {
+	testing.IsolationSuite
+
+	backend     *mockBackend
+	estimator   *mockEstimator
+	authorizer  apiservertesting.FakeAuthorizer
+	registry    storage.ProviderRegistry
+	poolManager poolmanager.PoolManager
+}
+
+var _ = gc.Suite(&costsSuite{})
+
+func (s *costsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"storage-default-block-source": "ebs",
+		}),
+		modelCons: constraints.MustParse("arch=amd64"),
+	}
+	s.estimator = &mockEstimator{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      names.NewUserTag("admin"),
+		AdminTag: names.NewUserTag("admin"),
+	}
+	s.registry = storage.StaticProviderRegistry{
+		Providers: map[storage.ProviderType]storage.Provider{
+			"ebs":  &dummystorage.StorageProvider{},
+			"loop": &dummystorage.StorageProvider{},
+		},
+	}
+	s.poolManager = poolmanager.New(poolmanager.MemSettings{
+		Settings: make(map[string]map[string]interface{}),
+	}, s.registry)
+	_, err := s.poolManager.Create("fast", "ebs", map[string]interface{}{"fast": true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *costsSuite) newAPI(c *gc.C) *costs.CostsAPI {
+	api, err := costs.NewCostsAPI(
+		s.backend,
+		s.authorizer,
+		func() (environs.CostEstimator, error) {
+			return s.estimator, nil
+		},
+		s.registry,
+		s.poolManager,
+		coretesting.ModelTag,
+		context.NewCloudCallContext(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *costsSuite) TestNewCostsAPINotClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := costs.NewCostsAPI(
+		s.backend, s.authorizer, nil, s.registry, s.poolManager,
+		coretesting.ModelTag, context.NewCloudCallContext(),
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *costsSuite) TestEstimateCosts(c *gc.C) {
+	results, err := s.newAPI(c).EstimateCosts(params.EstimateCostsArgs{
+		Applications: []params.ApplicationCostParams{{
+			ApplicationName: "web",
+			NumUnits:        2,
+			Constraints:     constraints.MustParse("cores=2"),
+			Storage: map[string]storage.Constraints{
+				"data": {Size: 10240},
+			},
+		}, {
+			ApplicationName: "db",
+			NumUnits:        1,
+			Constraints:     constraints.MustParse("instance-type=small"),
+			Storage: map[string]storage.Constraints{
+				"logs":    {Pool: "fast", Size: 20480, Count: 2},
+				"scratch": {Pool: "loop", Size: 1024, Count: 1},
+			},
+		}, {
+			ApplicationName: "sub",
+		}, {
+			ApplicationName: "huge",
+			NumUnits:        1,
+			Constraints:     constraints.MustParse("instance-type=huge"),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ApplicationCostResults{
+		Results: []params.ApplicationCostResult{{
+			ApplicationName: "web",
+			Units:           2,
+			InstanceType:    "large",
+			Currency:        "USD",
+			InstancesHourly: 4,
+			VolumesMonthly:  2.5,
+			Monthly:         2922.5,
+		}, {
+			ApplicationName: "db",
+			Units:           1,
+			InstanceType:    "small",
+			Currency:        "USD",
+			InstancesHourly: 0.5,
+			VolumesMonthly:  10,
+			Monthly:         375,
+		}, {
+			ApplicationName: "sub",
+		}, {
+			ApplicationName: "huge",
+			Error: &params.Error{
+				Message: `price of instance type "huge" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}},
+	})
+	s.backend.CheckCall(c, 0, "ResolveConstraints", constraints.MustParse("cores=2"))
+	s.estimator.CheckCall(c, 0, "InstanceTypes", constraints.MustParse("arch=amd64 cores=2"))
+}
+
+func (s *costsSuite) TestEstimateCostsUnknownPool(c *gc.C) {
+	results, err := s.newAPI(c).EstimateCosts(params.EstimateCostsArgs{
+		Applications: []params.ApplicationCostParams{{
+			ApplicationName: "web",
+			NumUnits:        1,
+			Storage: map[string]storage.Constraints{
+				"data": {Pool: "missing"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `storage "data": .*missing.* not found`)
+}
+
+func (s *costsSuite) TestEstimateCostsNotSupported(c *gc.C) {
+	api, err := costs.NewCostsAPI(
+		s.backend,
+		s.authorizer,
+		func() (environs.CostEstimator, error) {
+			return nil, errors.NotSupportedf("cost estimation for \"lxd\" clouds")
+		},
+		s.registry,
+		s.poolManager,
+		coretesting.ModelTag,
+		context.NewCloudCallContext(),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.EstimateCosts(params.EstimateCostsArgs{})
+	c.Assert(err, gc.ErrorMatches, `cost estimation for "lxd" clouds not supported`)
+}
+
+func (s *costsSuite) TestEstimateCostsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	_, err := s.newAPI(c).EstimateCosts(params.EstimateCostsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *costsSuite) TestModelCosts(c *gc.C) {
+	s.backend.applications = []costs.Application{
+		&mockApplication{name: "web", principal: true, units: []costs.Unit{
+			&mockUnit{name: "web/0", machineId: "0"},
+			&mockUnit{name: "web/1", machineId: "1"},
+		}},
+		&mockApplication{name: "db", principal: true, units: []costs.Unit{
+			&mockUnit{name: "db/0", machineId: "1"},
+			&mockUnit{name: "db/1"},
+		}},
+		&mockApplication{name: "logging", units: []costs.Unit{
+			&mockUnit{name: "logging/0", machineId: "0"},
+		}},
+		&mockApplication{name: "cache", principal: true, units: []costs.Unit{
+			&mockUnit{name: "cache/0", machineId: "0/lxd/0"},
+		}},
+	}
+	arch := "amd64"
+	cores := uint64(4)
+	mem := uint64(8192)
+	s.backend.machines = map[string]*mockMachine{
+		"0": {
+			id:         "0",
+			hc:         &instance.HardwareCharacteristics{Arch: &arch, CpuCores: &cores, Mem: &mem},
+			principals: []string{"web/0"},
+		},
+		"1": {
+			id:         "1",
+			cons:       constraints.MustParse("instance-type=small"),
+			hc:         &instance.HardwareCharacteristics{Arch: &arch},
+			principals: []string{"web/1", "db/0"},
+		},
+		"0/lxd/0": {
+			id:            "0/lxd/0",
+			containerType: instance.LXD,
+			principals:    []string{"cache/0"},
+		},
+	}
+	s.backend.unitVolumes = map[string][]state.VolumeParams{
+		"web/0": {{Pool: "ebs", Size: 8192}},
+		"db/0":  {{Pool: "fast", Size: 4096}},
+	}
+
+	results, err := s.newAPI(c).ModelCosts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ApplicationCostResults{
+		Results: []params.ApplicationCostResult{{
+			ApplicationName: "web",
+			Units:           2,
+			Currency:        "USD",
+			InstancesHourly: 2.25,
+			VolumesMonthly:  1,
+			Monthly:         1643.5,
+		}, {
+			ApplicationName: "db",
+			Units:           1,
+			Currency:        "USD",
+			InstancesHourly: 0.25,
+			VolumesMonthly:  1,
+			Monthly:         183.5,
+		}, {
+			ApplicationName: "cache",
+			Units:           1,
+		}},
+	})
+	s.backend.CheckCallNames(c,
+		"AllApplications",
+		"Machine", "UnitVolumes", "Machine", "UnitVolumes",
+		// Machine 1's cost is shared with web/1.
+		"UnitVolumes",
+		"Machine", "UnitVolumes",
+	)
+	s.estimator.CheckCall(c, 0, "InstanceTypes", constraints.MustParse("arch=amd64 cores=4 mem=8192M"))
+}
+
+func (s *costsSuite) TestModelCostsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someone")
+	_, err := s.newAPI(c).ModelCosts()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
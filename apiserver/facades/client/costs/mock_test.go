@@ -0,0 +1,182 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/client/costs"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+type mockBackend struct {
+	testing.Stub
+
+	config       *config.Config
+	modelCons    constraints.Value
+	applications []costs.Application
+	machines     map[string]*mockMachine
+	unitVolumes  map[string][]state.VolumeParams
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return b.config, b.NextErr()
+}
+
+func (b *mockBackend) ResolveConstraints(cons constraints.Value) (constraints.Value, error) {
+	b.MethodCall(b, "ResolveConstraints", cons)
+	if err := b.NextErr(); err != nil {
+		return constraints.Value{}, err
+	}
+	return constraints.Merge(b.modelCons, cons)
+}
+
+func (b *mockBackend) AllApplications() ([]costs.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	return b.applications, b.NextErr()
+}
+
+func (b *mockBackend) Machine(id string) (costs.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+func (b *mockBackend) UnitVolumes(tag names.UnitTag) ([]state.VolumeParams, error) {
+	b.MethodCall(b, "UnitVolumes", tag)
+	return b.unitVolumes[tag.Id()], b.NextErr()
+}
+
+type mockApplication struct {
+	name      string
+	principal bool
+	units     []costs.Unit
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) IsPrincipal() bool {
+	return a.principal
+}
+
+func (a *mockApplication) AllUnits() ([]costs.Unit, error) {
+	return a.units, nil
+}
+
+type mockUnit struct {
+	name      string
+	machineId string
+}
+
+func (u *mockUnit) UnitTag() names.UnitTag {
+	return names.NewUnitTag(u.name)
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
+
+type mockMachine struct {
+	id            string
+	containerType instance.ContainerType
+	cons          constraints.Value
+	hc            *instance.HardwareCharacteristics
+	principals    []string
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) ContainerType() instance.ContainerType {
+	return m.containerType
+}
+
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	return m.cons, nil
+}
+
+func (m *mockMachine) HardwareCharacteristics() (*instance.HardwareCharacteristics, error) {
+	if m.hc == nil {
+		return nil, errors.NotFoundf("instance data for machine %v", m.id)
+	}
+	return m.hc, nil
+}
+
+func (m *mockMachine) Principals() []string {
+	return m.principals
+}
+
+// mockEstimator prices two instance types, "small" with 1 core and
+// "large" with 4 cores, and volumes of the "ebs" provider.
+type mockEstimator struct {
+	testing.Stub
+}
+
+var instanceTypes = []instances.InstanceType{
+	{Name: "small", Arches: []string{"amd64"}, CpuCores: 1, Mem: 2048, Cost: 1},
+	{Name: "large", Arches: []string{"amd64"}, CpuCores: 4, Mem: 8192, Cost: 4},
+}
+
+func (e *mockEstimator) InstanceTypes(
+	ctx context.ProviderCallContext, cons constraints.Value,
+) (instances.InstanceTypesWithCostMetadata, error) {
+	e.MethodCall(e, "InstanceTypes", cons)
+	if err := e.NextErr(); err != nil {
+		return instances.InstanceTypesWithCostMetadata{}, err
+	}
+	itypes, err := instances.MatchingInstanceTypes(instanceTypes, "", cons)
+	return instances.InstanceTypesWithCostMetadata{InstanceTypes: itypes}, err
+}
+
+func (e *mockEstimator) InstanceTypeCost(ctx context.ProviderCallContext, instanceType string) (environs.Price, error) {
+	e.MethodCall(e, "InstanceTypeCost", instanceType)
+	if err := e.NextErr(); err != nil {
+		return environs.Price{}, err
+	}
+	switch instanceType {
+	case "small":
+		return environs.Price{Amount: 0.5, Currency: "USD"}, nil
+	case "large":
+		return environs.Price{Amount: 2, Currency: "USD"}, nil
+	}
+	return environs.Price{}, errors.NotFoundf("price of instance type %q", instanceType)
+}
+
+func (e *mockEstimator) VolumeCost(ctx context.ProviderCallContext, volume storage.VolumeParams) (environs.Price, error) {
+	e.MethodCall(e, "VolumeCost", volume)
+	if err := e.NextErr(); err != nil {
+		return environs.Price{}, err
+	}
+	if volume.Provider != "ebs" {
+		return environs.Price{}, nil
+	}
+	// $0.125 per GiB-month, or $0.25 for "fast" volumes.
+	price := 0.125
+	if volume.Attributes["fast"] == true {
+		price = 0.25
+	}
+	return environs.Price{Amount: price * float64(volume.Size/1024), Currency: "USD"}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package costs
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods used by the Costs facade.
+type Backend interface {
+	ModelConfig() (*config.Config, error)
+	ResolveConstraints(constraints.Value) (constraints.Value, error)
+	AllApplications() ([]Application, error)
+	Machine(id string) (Machine, error)
+
+	// UnitVolumes returns the parameters of the volumes backing the
+	// storage attached to the unit.
+	UnitVolumes(names.UnitTag) ([]state.VolumeParams, error)
+}
+
+// Application defines the application methods used by the Costs facade.
+type Application interface {
+	Name() string
+	IsPrincipal() bool
+	AllUnits() ([]Unit, error)
+}
+
+// Unit defines the unit methods used by the Costs facade.
+type Unit interface {
+	UnitTag() names.UnitTag
+	AssignedMachineId() (string, error)
+}
+
+// Machine defines the machine methods used by the Costs facade.
+type Machine interface {
+	Id() string
+	ContainerType() instance.ContainerType
+	Constraints() (constraints.Value, error)
+	HardwareCharacteristics() (*instance.HardwareCharacteristics, error)
+	Principals() []string
+}
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb. If you were
+// to change any part of it so that it were no longer *obviously* and
+// *trivially* correct, you would be Doing It Wrong.
+
+type storageAccess interface {
+	UnitStorageAttachments(names.UnitTag) ([]state.StorageAttachment, error)
+	StorageInstanceVolume(names.StorageTag) (state.Volume, error)
+}
+
+type stateShim struct {
+	*state.State
+	model   *state.Model
+	storage storageAccess
+}
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	return s.model.ModelConfig()
+}
+
+func (s stateShim) AllApplications() ([]Application, error) {
+	applications, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(applications))
+	for i, application := range applications {
+		result[i] = applicationShim{application}
+	}
+	return result, nil
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+func (s stateShim) UnitVolumes(tag names.UnitTag) ([]state.VolumeParams, error) {
+	attachments, err := s.storage.UnitStorageAttachments(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []state.VolumeParams
+	for _, attachment := range attachments {
+		volume, err := s.storage.StorageInstanceVolume(attachment.StorageInstance())
+		if errors.IsNotFound(err) {
+			// The storage is not backed by a volume.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info, err := volume.Info(); err == nil {
+			result = append(result, state.VolumeParams{Pool: info.Pool, Size: info.Size})
+		} else if params, ok := volume.Params(); ok {
+			result = append(result, params)
+		}
+	}
+	return result, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes functionality required by Facade.
+type Backend interface {
+
+	// WatchActionSchedules returns a watcher that notifies
+	// of changes to the action schedules of the model.
+	WatchActionSchedules() state.NotifyWatcher
+
+	// AllActionSchedules returns the action schedules of the model.
+	AllActionSchedules() ([]ActionSchedule, error)
+
+	// ActionSchedule returns the action schedule with the specified id.
+	ActionSchedule(id string) (ActionSchedule, error)
+}
+
+// ActionSchedule exposes the functionality of an
+// action schedule required by Facade.
+type ActionSchedule interface {
+	Id() string
+	Schedule() string
+	Paused() bool
+	NextRun() (time.Time, error)
+	Trigger(due time.Time) (state.ActionScheduleRun, error)
+}
+
+// Facade allows controller workers to run the
+// actions of the model on their schedules.
+type Facade struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewFacade creates a new authorized Facade.
+func NewFacade(backend Backend, res facade.Resources, auth facade.Authorizer) (*Facade, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:   backend,
+		resources: res,
+	}, nil
+}
+
+// WatchActionSchedules returns a watcher that notifies
+// of changes to the action schedules of the model.
+func (facade *Facade) WatchActionSchedules() (params.NotifyWatchResult, error) {
+	watch := facade.backend.WatchActionSchedules()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: facade.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// ActionScheduleTimes returns the time at which the
+// action of each schedule of the model is next due.
+func (facade *Facade) ActionScheduleTimes() (params.ActionScheduleTimes, error) {
+	schedules, err := facade.backend.AllActionSchedules()
+	if err != nil {
+		return params.ActionScheduleTimes{}, errors.Trace(err)
+	}
+	result := params.ActionScheduleTimes{
+		Schedules: make([]params.ActionScheduleTime, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		nextRun, err := schedule.NextRun()
+		if err != nil {
+			return params.ActionScheduleTimes{}, errors.Trace(err)
+		}
+		result.Schedules[i] = params.ActionScheduleTime{
+			Id:       schedule.Id(),
+			Schedule: schedule.Schedule(),
+			Paused:   schedule.Paused(),
+			NextRun:  nextRun,
+		}
+	}
+	return result, nil
+}
+
+// TriggerActionSchedules enqueues the actions of the specified
+// schedules, which were due at the specified times.
+func (facade *Facade) TriggerActionSchedules(args params.ActionScheduleTriggers) params.ErrorResults {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Triggers)),
+	}
+	for i, trigger := range args.Triggers {
+		err := facade.triggerOne(trigger.Id, trigger.Due)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result
+}
+
+func (facade *Facade) triggerOne(id string, due time.Time) error {
+	schedule, err := facade.backend.ActionSchedule(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if schedule.Paused() {
+		// The schedule was paused since the worker read it.
+		return nil
+	}
+	_, err = schedule.Trigger(due)
+	return errors.Trace(err)
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type FacadeSuite struct {
+	coretesting.BaseSuite
+
+	backend   *mockBackend
+	resources *common.Resources
+	facade    *actionscheduler.Facade
+}
+
+var _ = gc.Suite(&FacadeSuite{})
+
+func (s *FacadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		Stub: &testing.Stub{},
+		schedules: []*mockSchedule{{
+			Stub:     &testing.Stub{},
+			id:       "1",
+			schedule: "0 3 * * *",
+			nextRun:  time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+		}, {
+			Stub:     &testing.Stub{},
+			id:       "2",
+			schedule: "@hourly",
+			paused:   true,
+			nextRun:  time.Date(2020, 1, 2, 4, 0, 0, 0, time.UTC),
+		}},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+
+	var err error
+	s.facade, err = actionscheduler.NewFacade(s.backend, s.resources, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *FacadeSuite) TestRequiresController(c *gc.C) {
+	facade, err := actionscheduler.NewFacade(s.backend, s.resources, apiservertesting.FakeAuthorizer{})
+	c.Assert(facade, gc.IsNil)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *FacadeSuite) TestWatchActionSchedules(c *gc.C) {
+	result, err := s.facade.WatchActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *FacadeSuite) TestWatchActionSchedulesError(c *gc.C) {
+	s.backend.watcherFails = true
+	_, err := s.facade.WatchActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "watcher died")
+}
+
+func (s *FacadeSuite) TestActionScheduleTimes(c *gc.C) {
+	result, err := s.facade.ActionScheduleTimes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionScheduleTimes{
+		Schedules: []params.ActionScheduleTime{{
+			Id:       "1",
+			Schedule: "0 3 * * *",
+			NextRun:  time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC),
+		}, {
+			Id:       "2",
+			Schedule: "@hourly",
+			Paused:   true,
+			NextRun:  time.Date(2020, 1, 2, 4, 0, 0, 0, time.UTC),
+		}},
+	})
+}
+
+func (s *FacadeSuite) TestActionScheduleTimesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.facade.ActionScheduleTimes()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *FacadeSuite) TestTriggerActionSchedules(c *gc.C) {
+	due := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	s.backend.schedules[0].SetErrors(errors.AlreadyExistsf("run"))
+	result := s.facade.TriggerActionSchedules(params.ActionScheduleTriggers{
+		Triggers: []params.ActionScheduleTrigger{
+			{Id: "1", Due: due},
+			{Id: "2", Due: due},
+			{Id: "3", Due: due},
+		},
+	})
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeAlreadyExists)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	s.backend.CheckCallNames(c, "ActionSchedule", "ActionSchedule", "ActionSchedule")
+	s.backend.CheckCall(c, 2, "ActionSchedule", "3")
+	s.backend.schedules[0].CheckCall(c, 0, "Trigger", due)
+	// The second schedule is paused, so its action is not run.
+	s.backend.schedules[1].CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	*testing.Stub
+	schedules    []*mockSchedule
+	watcherFails bool
+}
+
+func (b *mockBackend) WatchActionSchedules() state.NotifyWatcher {
+	b.MethodCall(b, "WatchActionSchedules")
+	return &mockWatcher{fails: b.watcherFails}
+}
+
+func (b *mockBackend) AllActionSchedules() ([]actionscheduler.ActionSchedule, error) {
+	b.MethodCall(b, "AllActionSchedules")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	result := make([]actionscheduler.ActionSchedule, len(b.schedules))
+	for i, schedule := range b.schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+func (b *mockBackend) ActionSchedule(id string) (actionscheduler.ActionSchedule, error) {
+	b.MethodCall(b, "ActionSchedule", id)
+	for _, schedule := range b.schedules {
+		if schedule.id == id {
+			return schedule, nil
+		}
+	}
+	return nil, errors.NotFoundf("action schedule %q", id)
+}
+
+type mockSchedule struct {
+	*testing.Stub
+	id       string
+	schedule string
+	paused   bool
+	nextRun  time.Time
+}
+
+func (s *mockSchedule) Id() string                  { return s.id }
+func (s *mockSchedule) Schedule() string            { return s.schedule }
+func (s *mockSchedule) Paused() bool                { return s.paused }
+func (s *mockSchedule) NextRun() (time.Time, error) { return s.nextRun, nil }
+
+func (s *mockSchedule) Trigger(due time.Time) (state.ActionScheduleRun, error) {
+	s.MethodCall(s, "Trigger", due)
+	return state.ActionScheduleRun{Time: due}, s.NextErr()
+}
+
+type mockWatcher struct {
+	state.NotifyWatcher
+	fails bool
+}
+
+func (w *mockWatcher) Changes() <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	if w.fails {
+		close(ch)
+	} else {
+		ch <- struct{}{}
+	}
+	return ch
+}
+
+func (w *mockWatcher) Err() error {
+	return errors.New("watcher died")
+}
+
+func (w *mockWatcher) Stop() error {
+	return nil
+}
+
+func (w *mockWatcher) Kill() {}
+
+func (w *mockWatcher) Wait() error {
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewAPI provides the required signature for facade registration.
+func NewAPI(ctx facade.Context) (*Facade, error) {
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewFacade(backendShim{model}, ctx.Resources(), ctx.Auth())
+}
+
+// backendShim wraps a *Model to implement Backend.
+type backendShim struct {
+	model *state.Model
+}
+
+// WatchActionSchedules is part of the Backend interface.
+func (shim backendShim) WatchActionSchedules() state.NotifyWatcher {
+	return shim.model.WatchActionSchedules()
+}
+
+// AllActionSchedules is part of the Backend interface.
+func (shim backendShim) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := shim.model.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
+
+// ActionSchedule is part of the Backend interface.
+func (shim backendShim) ActionSchedule(id string) (ActionSchedule, error) {
+	schedule, err := shim.model.ActionSchedule(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return schedule, nil
+}
@@ -13,21 +13,43 @@ import (
 	"github.com/juju/juju/state/watcher"
 )
 
+// FacadeV1 is the V1 CAAS firewaller API, without WatchApplicationsConfig,
+// WatchApplicationsRelations, RelatedApplications, ModelConfig and
+// WatchForModelConfigChanges.
+type FacadeV1 struct {
+	*Facade
+}
+
+// Facade is the V2 CAAS firewaller API.
 type Facade struct {
 	*common.LifeGetter
 	*common.AgentEntityWatcher
+	*common.ModelWatcher
 	resources facade.Resources
 	state     CAASFirewallerState
 }
 
+// NewStateFacadeV1 provides the signature required for V1 facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
 	resources := ctx.Resources()
+	model, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return NewFacade(
 		resources,
 		authorizer,
-		stateShim{ctx.State()},
+		stateShim{State: ctx.State(), model: model},
 	)
 }
 
@@ -53,6 +75,11 @@ func NewFacade(
 			resources,
 			accessApplication,
 		),
+		ModelWatcher: common.NewModelWatcher(
+			st,
+			resources,
+			authorizer,
+		),
 		resources: resources,
 		state:     st,
 	}, nil
@@ -123,3 +150,114 @@ func (f *Facade) getApplicationConfig(tagString string) (map[string]interface{},
 	}
 	return app.ApplicationConfig()
 }
+
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the applications' application config.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+// WatchApplicationsConfig isn't on the V1 API.
+func (*FacadeV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+// WatchApplicationsRelations isn't on the V1 API.
+func (*FacadeV1) WatchApplicationsRelations(_, _ struct{}) {}
+
+// RelatedApplications isn't on the V1 API.
+func (*FacadeV1) RelatedApplications(_, _ struct{}) {}
+
+// ModelConfig isn't on the V1 API.
+func (*FacadeV1) ModelConfig(_, _ struct{}) {}
+
+// WatchForModelConfigChanges isn't on the V1 API.
+func (*FacadeV1) WatchForModelConfigChanges(_, _ struct{}) {}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchApplicationsRelations starts a StringsWatcher for each specified
+// application, which notifies of changes to the application's relations.
+func (f *Facade) WatchApplicationsRelations(args params.Entities) (params.StringsWatchResults, error) {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, changes, err := f.watchApplicationRelations(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].StringsWatcherId = id
+		results.Results[i].Changes = changes
+	}
+	return results, nil
+}
+
+func (f *Facade) watchApplicationRelations(tagString string) (string, []string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	w := app.WatchRelations()
+	if changes, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), changes, nil
+	}
+	return "", nil, watcher.EnsureErr(w)
+}
+
+// RelatedApplications returns the names of the applications related
+// to each of the specified applications.
+func (f *Facade) RelatedApplications(args params.Entities) (params.StringsResults, error) {
+	results := params.StringsResults{
+		Results: make([]params.StringsResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		related, err := f.relatedApplications(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = related
+	}
+	return results, nil
+}
+
+func (f *Facade) relatedApplications(tagString string) ([]string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.RelatedApplications()
+}
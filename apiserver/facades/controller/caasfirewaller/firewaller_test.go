@@ -27,6 +27,9 @@ type CAASFirewallerSuite struct {
 	st                  *mockState
 	applicationsChanges chan []string
 	appExposedChanges   chan struct{}
+	relationsChanges    chan []string
+	appConfigChanges    chan struct{}
+	modelConfigChanges  chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -38,17 +41,28 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
+	s.relationsChanges = make(chan []string, 1)
+	s.appConfigChanges = make(chan struct{}, 1)
+	s.modelConfigChanges = make(chan struct{}, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
+	relationsWatcher := statetesting.NewMockStringsWatcher(s.relationsChanges)
+	appConfigWatcher := statetesting.NewMockNotifyWatcher(s.appConfigChanges)
 	s.st = &mockState{
 		application: mockApplication{
-			life:    state.Alive,
-			watcher: appExposedWatcher,
+			life:             state.Alive,
+			watcher:          appExposedWatcher,
+			relationsWatcher: relationsWatcher,
+			configWatcher:    appConfigWatcher,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		appExposedWatcher:   appExposedWatcher,
+		modelConfigWatcher:  statetesting.NewMockNotifyWatcher(s.modelConfigChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, relationsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, appConfigWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.modelConfigWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -159,3 +173,75 @@ func (s *CAASFirewallerSuite) TestApplicationConfig(c *gc.C) {
 	})
 	c.Assert(results.Results[0].Config, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 }
+
+func (s *CAASFirewallerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	s.appConfigChanges <- struct{}{}
+
+	results, err := s.facade.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.configWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestWatchApplicationsRelations(c *gc.C) {
+	s.relationsChanges <- []string{"gitlab:db mysql:server"}
+
+	results, err := s.facade.WatchApplicationsRelations(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, jc.DeepEquals, []string{"gitlab:db mysql:server"})
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.application.relationsWatcher)
+}
+
+func (s *CAASFirewallerSuite) TestRelatedApplications(c *gc.C) {
+	s.st.application.related = []string{"mysql", "redis"}
+	results, err := s.facade.RelatedApplications(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StringsResults{
+		Results: []params.StringsResult{{
+			Result: []string{"mysql", "redis"},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+}
+
+func (s *CAASFirewallerSuite) TestWatchForModelConfigChanges(c *gc.C) {
+	s.modelConfigChanges <- struct{}{}
+
+	result, err := s.facade.WatchForModelConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.modelConfigWatcher)
+}
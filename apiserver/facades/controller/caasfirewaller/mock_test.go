@@ -9,8 +9,10 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type mockState struct {
@@ -18,6 +20,20 @@ type mockState struct {
 	application         mockApplication
 	applicationsWatcher *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	modelConfigWatcher  *statetesting.MockNotifyWatcher
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (st *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchForModelConfigChanges")
+	return st.modelConfigWatcher
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -43,9 +59,12 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	watcher          state.NotifyWatcher
+	relationsWatcher state.StringsWatcher
+	configWatcher    state.NotifyWatcher
+	related          []string
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -70,3 +89,18 @@ func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, err
 func (a *mockApplication) Watch() state.NotifyWatcher {
 	return a.watcher
 }
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
+func (a *mockApplication) WatchRelations() state.StringsWatcher {
+	a.MethodCall(a, "WatchRelations")
+	return a.relationsWatcher
+}
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	return a.related, a.NextErr()
+}
@@ -4,15 +4,22 @@
 package caasfirewaller
 
 import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
 // CAASUnitProvisionerState provides the subset of global state
 // required by the CAAS operator facade.
 type CAASFirewallerState interface {
+	state.ModelAccessor
+
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Application(string) (Application, error)
 	WatchApplications() state.StringsWatcher
@@ -24,12 +31,56 @@ type Application interface {
 	IsExposed() bool
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
+	WatchApplicationConfig() state.NotifyWatcher
+	WatchRelations() state.StringsWatcher
+	RelatedApplications() ([]string, error)
 }
 
 type stateShim struct {
 	*state.State
+	model *state.Model
+}
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	return s.model.ModelConfig()
+}
+
+func (s stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return s.model.WatchForModelConfigChanges()
 }
 
 func (s stateShim) Application(id string) (Application, error) {
-	return s.State.Application(id)
+	app, err := s.State.Application(id)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// RelatedApplications returns the sorted names of the applications,
+// other than the application itself, which are related to it.
+func (a applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		eps, err := rel.RelatedEndpoints(a.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			if ep.ApplicationName != a.Name() {
+				related.Add(ep.ApplicationName)
+			}
+		}
+	}
+	names := related.Values()
+	sort.Strings(names)
+	return names, nil
 }
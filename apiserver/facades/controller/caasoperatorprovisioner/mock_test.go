@@ -14,9 +14,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	coretesting "github.com/juju/juju/testing"
@@ -29,6 +31,7 @@ type mockState struct {
 	applicationWatcher *mockStringsWatcher
 	app                *mockApplication
 	operatorRepo       string
+	modelConfigWatcher *statetesting.MockNotifyWatcher
 }
 
 func newMockState() *mockState {
@@ -43,6 +46,16 @@ func (st *mockState) WatchApplications() state.StringsWatcher {
 	return st.applicationWatcher
 }
 
+func (st *mockState) WatchForModelConfigChanges() state.NotifyWatcher {
+	st.MethodCall(st, "WatchForModelConfigChanges")
+	return st.modelConfigWatcher
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	st.MethodCall(st, "ModelConfig")
+	return st.model.ModelConfig()
+}
+
 func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	if st.app.tag == tag {
 		return st.app, nil
@@ -109,6 +122,7 @@ func (m *mockStoragePoolManager) Get(name string) (*storage.Config, error) {
 
 type mockModel struct {
 	testing.Stub
+	attrs coretesting.Attrs
 }
 
 func (m *mockModel) UUID() string {
@@ -121,7 +135,7 @@ func (m *mockModel) ModelConfig() (*config.Config, error) {
 	attrs := coretesting.FakeConfig()
 	attrs["operator-storage"] = "k8s-storage"
 	attrs["agent-version"] = "2.6-beta3"
-	return config.New(config.UseDefaults, attrs)
+	return config.New(config.UseDefaults, attrs.Merge(m.attrs))
 }
 
 type mockApplication struct {
@@ -129,6 +143,9 @@ type mockApplication struct {
 	tag      names.Tag
 	password string
 	charm    caasoperatorprovisioner.Charm
+	config   application.ConfigAttributes
+
+	configWatcher state.NotifyWatcher
 }
 
 func (m *mockApplication) Tag() names.Tag {
@@ -148,6 +165,14 @@ func (a *mockApplication) Charm() (caasoperatorprovisioner.Charm, bool, error) {
 	return a.charm, false, nil
 }
 
+func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
+	return a.config, nil
+}
+
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	return a.configWatcher
+}
+
 type mockCharm struct {
 	meta *charm.Meta
 }
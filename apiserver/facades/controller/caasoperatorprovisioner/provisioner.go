@@ -5,6 +5,7 @@ package caasoperatorprovisioner
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -29,10 +30,17 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.caasoperatorprovisioner")
 
+// APIv1 provides the CAASOperatorProvisioner API facade for version 1.
+type APIv1 struct {
+	*API
+}
+
+// API provides the CAASOperatorProvisioner API facade for version 2.
 type API struct {
 	*common.PasswordChanger
 	*common.LifeGetter
 	*common.APIAddresser
+	*common.ModelWatcher
 
 	auth      facade.Authorizer
 	resources facade.Resources
@@ -58,7 +66,17 @@ func NewStateCAASOperatorProvisionerAPI(ctx facade.Context) (*API, error) {
 	registry := stateenvirons.NewStorageProviderRegistry(broker)
 	pm := poolmanager.New(state.NewStateSettings(ctx.State()), registry)
 
-	return NewCAASOperatorProvisionerAPI(resources, authorizer, stateShim{ctx.State()}, pm, registry)
+	return NewCAASOperatorProvisionerAPI(resources, authorizer, stateShim{State: ctx.State(), model: model}, pm, registry)
+}
+
+// NewStateCAASOperatorProvisionerAPIV1 provides the signature required
+// for version 1 facade registration.
+func NewStateCAASOperatorProvisionerAPIV1(ctx facade.Context) (*APIv1, error) {
+	api, err := NewStateCAASOperatorProvisionerAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
 }
 
 // NewCAASOperatorProvisionerAPI returns a new CAAS operator provisioner API facade.
@@ -76,6 +94,7 @@ func NewCAASOperatorProvisionerAPI(
 		PasswordChanger:    common.NewPasswordChanger(st, common.AuthFuncForTagKind(names.ApplicationTagKind)),
 		LifeGetter:         common.NewLifeGetter(st, common.AuthFuncForTagKind(names.ApplicationTagKind)),
 		APIAddresser:       common.NewAPIAddresser(st, resources),
+		ModelWatcher:       common.NewModelWatcher(st, resources, authorizer),
 		auth:               authorizer,
 		resources:          resources,
 		state:              st,
@@ -98,6 +117,48 @@ func (a *API) WatchApplications() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
 }
 
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the applications' application config.
+func (a *API) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := a.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (a *API) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := a.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return a.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// WatchForModelConfigChanges isn't on the V1 API.
+func (*APIv1) WatchForModelConfigChanges(_, _ struct{}) {}
+
+// ModelConfig isn't on the V1 API.
+func (*APIv1) ModelConfig(_, _ struct{}) {}
+
+// WatchApplicationsConfig isn't on the V1 API.
+func (*APIv1) WatchApplicationsConfig(_, _ struct{}) {}
+
 // OperatorProvisioningInfo returns the info needed to provision an operator.
 func (a *API) OperatorProvisioningInfo(args params.Entities) (params.OperatorProvisioningInfoResults, error) {
 	var result params.OperatorProvisioningInfoResults
@@ -183,11 +244,45 @@ func (a *API) OperatorProvisioningInfo(args params.Entities) (params.OperatorPro
 		needStorage := provider.RequireOperatorStorage(ch.Meta().MinJujuVersion)
 		logger.Debugf("application %s has min-juju-version=%v, so charm storage is %v",
 			appName.String(), ch.Meta().MinJujuVersion, needStorage)
-		result.Results[i] = oneProvisioningInfo(needStorage)
+		info := oneProvisioningInfo(needStorage)
+		if info.Error == nil {
+			if info.PodConfig, err = operatorPodConfig(modelConfig, app); err != nil {
+				info = params.OperatorProvisioningInfo{
+					Error: common.ServerError(errors.Annotatef(err, "getting operator pod config")),
+				}
+			}
+		}
+		result.Results[i] = info
 	}
 	return result, nil
 }
 
+// operatorPodConfig returns the resources and placement of the operator
+// pod of the application, as specified in the model config and overridden
+// in the application config, or nil if none are specified.
+func operatorPodConfig(modelConfig *config.Config, app Application) (*params.OperatorPodConfig, error) {
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := provider.OperatorPodConfig(modelConfig.AllAttrs(), appConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if reflect.DeepEqual(*cfg, caas.OperatorPodConfig{}) {
+		return nil, nil
+	}
+	return &params.OperatorPodConfig{
+		CPURequest:        cfg.CPURequest,
+		CPULimit:          cfg.CPULimit,
+		MemoryRequest:     cfg.MemoryRequest,
+		MemoryLimit:       cfg.MemoryLimit,
+		NodeSelector:      cfg.NodeSelector,
+		Tolerations:       cfg.Tolerations,
+		PriorityClassName: cfg.PriorityClassName,
+	}, nil
+}
+
 // IssueOperatorCertificate issues an x509 certificate for use by the specified application operator.
 func (a *API) IssueOperatorCertificate(args params.Entities) (params.IssueOperatorCertificateResults, error) {
 	cfg, err := a.state.ControllerConfig()
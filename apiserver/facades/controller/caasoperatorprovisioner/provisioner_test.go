@@ -11,15 +11,19 @@ import (
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
+	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/pki"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -79,6 +83,45 @@ func (s *CAASProvisionerSuite) TestWatchApplications(c *gc.C) {
 	c.Assert(resource, gc.Implements, new(state.StringsWatcher))
 }
 
+func (s *CAASProvisionerSuite) TestWatchApplicationsConfig(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.st.app = &mockApplication{
+		configWatcher: statetesting.NewMockNotifyWatcher(changes),
+	}
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.app.configWatcher) })
+
+	results, err := s.api.WatchApplicationsConfig(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[1].Error, jc.DeepEquals, &params.Error{
+		Message: `"unit-gitlab-0" is not a valid application tag`,
+	})
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.app.configWatcher)
+}
+
+func (s *CAASProvisionerSuite) TestWatchForModelConfigChanges(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.st.modelConfigWatcher = statetesting.NewMockNotifyWatcher(changes)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.modelConfigWatcher) })
+
+	result, err := s.api.WatchForModelConfigChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.modelConfigWatcher)
+}
+
 func (s *CAASProvisionerSuite) TestSetPasswords(c *gc.C) {
 	s.st.app = &mockApplication{
 		tag: names.NewApplicationTag("app"),
@@ -187,6 +230,44 @@ func (s *CAASProvisionerSuite) TestOperatorProvisioningInfo(c *gc.C) {
 	})
 }
 
+func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoPodConfig(c *gc.C) {
+	s.st.model.attrs = coretesting.Attrs{
+		provider.OperatorMemoryLimitKey:  "256Mi",
+		provider.OperatorNodeSelectorKey: "pool=system",
+		provider.OperatorTolerationsKey:  "dedicated=juju:NoSchedule",
+	}
+	s.st.app = &mockApplication{
+		charm: &mockCharm{meta: &charm.Meta{MinJujuVersion: version.MustParse("2.8.0")}},
+		config: application.ConfigAttributes{
+			"kubernetes-operator-memory-limit":   "1Gi",
+			"kubernetes-operator-priority-class": "juju-apps",
+		},
+	}
+	result, err := s.api.OperatorProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].PodConfig, jc.DeepEquals, &params.OperatorPodConfig{
+		MemoryLimit:       "1Gi",
+		NodeSelector:      map[string]string{"pool": "system"},
+		Tolerations:       []string{"dedicated=juju:NoSchedule"},
+		PriorityClassName: "juju-apps",
+	})
+}
+
+func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoInvalidPodConfig(c *gc.C) {
+	s.st.app = &mockApplication{
+		charm: &mockCharm{meta: &charm.Meta{MinJujuVersion: version.MustParse("2.8.0")}},
+		config: application.ConfigAttributes{
+			"kubernetes-operator-cpu-limit": "lots",
+		},
+	}
+	result, err := s.api.OperatorProvisioningInfo(params.Entities{Entities: []params.Entity{{"application-gitlab"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `getting operator pod config: operator-cpu-limit "lots" not valid`)
+}
+
 func (s *CAASProvisionerSuite) TestOperatorProvisioningInfoNoStorage(c *gc.C) {
 	s.st.operatorRepo = "somerepo"
 	s.st.app = &mockApplication{
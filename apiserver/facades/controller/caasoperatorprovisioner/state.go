@@ -8,6 +8,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
@@ -16,6 +17,8 @@ import (
 // CAASOperatorProvisionerState provides the subset of global state
 // required by the CAAS operator provisioner facade.
 type CAASOperatorProvisionerState interface {
+	state.ModelAccessor
+
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (controller.StateServingInfo, error)
 	WatchApplications() state.StringsWatcher
@@ -35,6 +38,8 @@ type Model interface {
 
 type Application interface {
 	Charm() (ch Charm, force bool, err error)
+	ApplicationConfig() (application.ConfigAttributes, error)
+	WatchApplicationConfig() state.NotifyWatcher
 }
 
 type Charm interface {
@@ -43,6 +48,15 @@ type Charm interface {
 
 type stateShim struct {
 	*state.State
+	model *state.Model
+}
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	return s.model.ModelConfig()
+}
+
+func (s stateShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return s.model.WatchForModelConfigChanges()
 }
 
 func (s stateShim) Model() (Model, error) {
@@ -142,8 +142,9 @@ func (m *mockModel) Containers(providerIds ...string) ([]state.CloudContainer, e
 
 type mockApplication struct {
 	testing.Stub
-	life         state.Life
-	scaleWatcher *statetesting.MockNotifyWatcher
+	life          state.Life
+	scaleWatcher  *statetesting.MockNotifyWatcher
+	configWatcher *statetesting.MockNotifyWatcher
 
 	tag        names.Tag
 	scale      int
@@ -173,6 +174,11 @@ func (a *mockApplication) WatchScale() state.NotifyWatcher {
 	return a.scaleWatcher
 }
 
+func (a *mockApplication) WatchApplicationConfig() state.NotifyWatcher {
+	a.MethodCall(a, "WatchApplicationConfig")
+	return a.configWatcher
+}
+
 func (a *mockApplication) GetScale() int {
 	a.MethodCall(a, "GetScale")
 	return a.scale
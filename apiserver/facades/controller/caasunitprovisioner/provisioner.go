@@ -37,6 +37,12 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.controller.caasunitprovisioner")
 
+// FacadeV1 is the V1 CAAS unit provisioner API, without WatchApplicationsConfig.
+type FacadeV1 struct {
+	*Facade
+}
+
+// Facade is the V2 CAAS unit provisioner API.
 type Facade struct {
 	*common.LifeGetter
 
@@ -49,6 +55,15 @@ type Facade struct {
 	clock              clock.Clock
 }
 
+// NewStateFacadeV1 provides the signature required for V1 facade registration.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	f, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -162,6 +177,42 @@ func (f *Facade) watchApplicationScale(tagString string) (string, error) {
 	return "", watcher.EnsureErr(w)
 }
 
+// WatchApplicationsConfig starts a NotifyWatcher to watch changes
+// to the applications' application config.
+func (f *Facade) WatchApplicationsConfig(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := f.watchApplicationConfig(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+// WatchApplicationsConfig isn't on the V1 API.
+func (*FacadeV1) WatchApplicationsConfig(_, _ struct{}) {}
+
+func (f *Facade) watchApplicationConfig(tagString string) (string, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	w := app.WatchApplicationConfig()
+	if _, ok := <-w.Changes(); ok {
+		return f.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
 // WatchPodSpec starts a NotifyWatcher to watch changes to the
 // pod spec for specified units in this model.
 func (f *Facade) WatchPodSpec(args params.Entities) (params.NotifyWatchResults, error) {
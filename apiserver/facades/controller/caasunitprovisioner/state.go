@@ -76,6 +76,7 @@ type Application interface {
 	SetScale(int, int64, bool) error
 	WatchScale() state.NotifyWatcher
 	ApplicationConfig() (application.ConfigAttributes, error)
+	WatchApplicationConfig() state.NotifyWatcher
 	AllUnits() (units []Unit, err error)
 	AddOperation(state.UnitUpdateProperties) *state.AddUnitOperation
 	UpdateUnits(*state.UpdateUnitsOperation) error
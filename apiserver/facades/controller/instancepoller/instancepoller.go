@@ -394,10 +394,66 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	return result, nil
 }
 
+// MarkInstancesReclaimed records that the instances of each given entity
+// were reclaimed by the cloud. Only machine tags are accepted, and only
+// machines constrained to spot instances may be marked. If the model is
+// configured to replace reclaimed instances, the provisioner will start
+// a replacement instance for each machine.
+func (a *InstancePollerAPI) MarkInstancesReclaimed(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	cfg, err := a.st.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			err = markInstanceReclaimed(machine, cfg.ReplaceReclaimedInstances())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func markInstanceReclaimed(m StateMachine, replace bool) error {
+	cons, err := m.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cons.IsSpotInstance() {
+		return errors.NotSupportedf("reclaiming on-demand instance of machine %q", m.Id())
+	}
+	return errors.Trace(m.MarkInstanceReclaimed(replace))
+}
+
+// InstancePollerAPIV4 implements the V4 API used by the instance poller
+// worker. Compared to V5, it lacks the MarkInstancesReclaimed method.
+type InstancePollerAPIV4 struct {
+	*InstancePollerAPI
+}
+
+// NewFacadeV4 creates a new instance of the V4 InstancePoller API.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*InstancePollerAPIV4, error) {
+	api, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &InstancePollerAPIV4{api}, nil
+}
+
+// MarkInstancesReclaimed is not available in V4.
+func (*InstancePollerAPIV4) MarkInstancesReclaimed(_, _ struct{}) {}
+
 // InstancePollerAPIV3 implements the V3 API used by the instance poller
 // worker. Compared to V4, it lacks the SetProviderNetworkConfig method.
 type InstancePollerAPIV3 struct {
-	*InstancePollerAPI
+	*InstancePollerAPIV4
 }
 
 // NewFacadeV3 creates a new instance of the V3 InstancePoller API.
@@ -412,7 +468,7 @@ func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.
 		return nil, err
 	}
 
-	return &InstancePollerAPIV3{api}, nil
+	return &InstancePollerAPIV3{&InstancePollerAPIV4{api}}, nil
 }
 
 // SetProviderNetworkConfig is not available in V3.
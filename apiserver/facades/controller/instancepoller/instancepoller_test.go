@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -744,6 +745,45 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 	s.st.CheckMachineCall(c, 3, "3")
 }
 
+func (s *InstancePollerSuite) TestMarkInstancesReclaimed(c *gc.C) {
+	modelConfig, err := jujutesting.ModelConfig(c).Apply(map[string]interface{}{
+		"replace-reclaimed-instances": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.SetConfig(c, modelConfig)
+	s.st.SetMachineInfo(c, machineInfo{
+		id: "1", instanceId: "i-1", constraints: constraints.MustParse("instance-lifecycle=spot"),
+	})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", instanceId: "i-2"})
+
+	result, err := s.api.MarkInstancesReclaimed(s.mixedEntities)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Message: `reclaiming on-demand instance of machine "2" not supported`,
+				Code:    params.CodeNotSupported,
+			}},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ServerError(`"application-unknown" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"invalid-tag" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"unit-missing-1" is not a valid machine tag`)},
+			{Error: apiservertesting.ServerError(`"" is not a valid tag`)},
+			{Error: apiservertesting.ServerError(`"42" is not a valid tag`)},
+		}},
+	)
+
+	s.st.CheckCall(c, 0, "ModelConfig")
+	s.st.CheckMachineCall(c, 1, "1")
+	s.st.CheckCall(c, 2, "Constraints")
+	s.st.CheckCall(c, 3, "MarkInstanceReclaimed", true)
+	s.st.CheckMachineCall(c, 4, "2")
+	s.st.CheckCall(c, 5, "Constraints")
+	s.st.CheckCall(c, 6, "Id")
+	s.st.CheckMachineCall(c, 7, "42")
+}
+
 func (s *InstancePollerSuite) TestSetProviderNetworkConfigSuccess(c *gc.C) {
 	s.setDefaultSpaceInfo()
 
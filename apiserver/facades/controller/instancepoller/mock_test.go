@@ -20,6 +20,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -271,6 +272,7 @@ type machineInfo struct {
 	providerAddresses []network.SpaceAddress
 	life              state.Life
 	isManual          bool
+	constraints       constraints.Value
 
 	// See package_mock_test.go for these mocks.
 	linkLayerDevices []networkingcommon.LinkLayerDevice
@@ -413,6 +415,28 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	return m.status, m.NextErr()
 }
 
+// Constraints implements StateMachine.
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Constraints")
+	return m.constraints, m.NextErr()
+}
+
+// MarkInstanceReclaimed implements StateMachine.
+func (m *mockMachine) MarkInstanceReclaimed(replace bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "MarkInstanceReclaimed", replace)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.instanceId = ""
+	return nil
+}
+
 // AssertAliveOp implements StateMachine.
 func (m *mockMachine) AssertAliveOp() txn.Op {
 	m.mu.Lock()
@@ -5,6 +5,7 @@ package instancepoller
 
 import (
 	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -28,6 +29,8 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	Constraints() (constraints.Value, error)
+	MarkInstanceReclaimed(replace bool) error
 }
 
 type StateInterface interface {
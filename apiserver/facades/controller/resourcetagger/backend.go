@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the methods the resource tagger facade needs from
+// state.
+type Backend interface {
+	// ApplicationConfig returns the application config of the
+	// named application.
+	ApplicationConfig(appName string) (application.ConfigAttributes, error)
+
+	// ControllerConfig returns the controller's config.
+	ControllerConfig() (controller.Config, error)
+
+	// ModelConfig returns the model's config.
+	ModelConfig() (*config.Config, error)
+
+	// ModelOwner returns the tag of the model's owner.
+	ModelOwner() names.UserTag
+
+	// AllMachines returns all of the model's machines.
+	AllMachines() ([]Machine, error)
+
+	// AllVolumes returns all of the model's volumes.
+	AllVolumes() ([]state.Volume, error)
+
+	// StorageInstance returns the storage instance with the
+	// given tag.
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+
+	// WatchModelConfig returns a NotifyWatcher that triggers
+	// when the model config changes.
+	WatchModelConfig() state.NotifyWatcher
+
+	// WatchApplicationConfigs returns a NotifyWatcher that triggers
+	// when the application config of any application changes.
+	WatchApplicationConfigs() state.NotifyWatcher
+
+	// WatchModelMachineChanges returns a NotifyWatcher that triggers
+	// when any of the model's machines changes.
+	WatchModelMachineChanges() state.NotifyWatcher
+}
+
+// Machine defines the methods the resource tagger facade needs from
+// state.Machine.
+type Machine interface {
+	Id() string
+	Life() state.Life
+	IsContainer() bool
+	IsManual() (bool, error)
+	InstanceId() (instance.Id, error)
+	Jobs() []state.MachineJob
+	Principals() []string
+}
+
+// storageBackend defines the methods the backend shim needs from the
+// state storage backend.
+type storageBackend interface {
+	AllVolumes() ([]state.Volume, error)
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+	sb    storageBackend
+}
+
+// ApplicationConfig implements Backend.
+func (b *backendShim) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	app, err := b.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ApplicationConfig()
+}
+
+// ControllerConfig implements Backend.
+func (b *backendShim) ControllerConfig() (controller.Config, error) {
+	return b.st.ControllerConfig()
+}
+
+// ModelConfig implements Backend.
+func (b *backendShim) ModelConfig() (*config.Config, error) {
+	return b.model.ModelConfig()
+}
+
+// ModelOwner implements Backend.
+func (b *backendShim) ModelOwner() names.UserTag {
+	return b.model.Owner()
+}
+
+// AllMachines implements Backend.
+func (b *backendShim) AllMachines() ([]Machine, error) {
+	machines, err := b.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
+
+// AllVolumes implements Backend.
+func (b *backendShim) AllVolumes() ([]state.Volume, error) {
+	return b.sb.AllVolumes()
+}
+
+// StorageInstance implements Backend.
+func (b *backendShim) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
+	return b.sb.StorageInstance(tag)
+}
+
+// WatchModelConfig implements Backend.
+func (b *backendShim) WatchModelConfig() state.NotifyWatcher {
+	return b.model.WatchForModelConfigChanges()
+}
+
+// WatchApplicationConfigs implements Backend.
+func (b *backendShim) WatchApplicationConfigs() state.NotifyWatcher {
+	return b.st.WatchApplicationConfigs()
+}
+
+// WatchModelMachineChanges implements Backend.
+func (b *backendShim) WatchModelMachineChanges() state.NotifyWatcher {
+	return b.st.WatchModelMachineChanges()
+}
@@ -0,0 +1,207 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+type fakeBackend struct {
+	testing.Stub
+
+	modelConfig      *config.Config
+	controllerConfig controller.Config
+	appConfig        map[string]application.ConfigAttributes
+	machines         []resourcetagger.Machine
+	volumes          []state.Volume
+	storageInstances map[names.StorageTag]state.StorageInstance
+
+	modelConfigWatcher state.NotifyWatcher
+	appConfigWatcher   state.NotifyWatcher
+	machineTagsWatcher state.NotifyWatcher
+}
+
+func (b *fakeBackend) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	b.MethodCall(b, "ApplicationConfig", appName)
+	cfg, ok := b.appConfig[appName]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", appName)
+	}
+	return cfg, nil
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	return b.controllerConfig, b.NextErr()
+}
+
+func (b *fakeBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return b.modelConfig, b.NextErr()
+}
+
+func (b *fakeBackend) ModelOwner() names.UserTag {
+	b.MethodCall(b, "ModelOwner")
+	return names.NewUserTag("fred")
+}
+
+func (b *fakeBackend) AllMachines() ([]resourcetagger.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	return b.machines, b.NextErr()
+}
+
+func (b *fakeBackend) AllVolumes() ([]state.Volume, error) {
+	b.MethodCall(b, "AllVolumes")
+	return b.volumes, b.NextErr()
+}
+
+func (b *fakeBackend) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
+	b.MethodCall(b, "StorageInstance", tag)
+	return b.storageInstances[tag], b.NextErr()
+}
+
+func (b *fakeBackend) WatchModelConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchModelConfig")
+	return b.modelConfigWatcher
+}
+
+func (b *fakeBackend) WatchApplicationConfigs() state.NotifyWatcher {
+	b.MethodCall(b, "WatchApplicationConfigs")
+	return b.appConfigWatcher
+}
+
+func (b *fakeBackend) WatchModelMachineChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchModelMachineChanges")
+	return b.machineTagsWatcher
+}
+
+type fakeMachine struct {
+	id         string
+	life       state.Life
+	container  bool
+	manual     bool
+	instanceId instance.Id
+	principals []string
+}
+
+func (m *fakeMachine) Id() string {
+	return m.id
+}
+
+func (m *fakeMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *fakeMachine) IsContainer() bool {
+	return m.container
+}
+
+func (m *fakeMachine) IsManual() (bool, error) {
+	return m.manual, nil
+}
+
+func (m *fakeMachine) InstanceId() (instance.Id, error) {
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine %v", m.id)
+	}
+	return m.instanceId, nil
+}
+
+func (m *fakeMachine) Jobs() []state.MachineJob {
+	return []state.MachineJob{state.JobHostUnits}
+}
+
+func (m *fakeMachine) Principals() []string {
+	return m.principals
+}
+
+type fakeVolume struct {
+	state.Volume
+	tag        names.VolumeTag
+	life       state.Life
+	info       *state.VolumeInfo
+	storageTag names.StorageTag
+}
+
+func (v *fakeVolume) VolumeTag() names.VolumeTag {
+	return v.tag
+}
+
+func (v *fakeVolume) Tag() names.Tag {
+	return v.tag
+}
+
+func (v *fakeVolume) Life() state.Life {
+	return v.life
+}
+
+func (v *fakeVolume) Params() (state.VolumeParams, bool) {
+	return state.VolumeParams{}, false
+}
+
+func (v *fakeVolume) Info() (state.VolumeInfo, error) {
+	if v.info == nil {
+		return state.VolumeInfo{}, errors.NotProvisionedf("%v", names.ReadableString(v.tag))
+	}
+	return *v.info, nil
+}
+
+func (v *fakeVolume) StorageInstance() (names.StorageTag, error) {
+	if v.storageTag == (names.StorageTag{}) {
+		return names.StorageTag{}, errors.NewNotAssigned(nil, "volume not assigned")
+	}
+	return v.storageTag, nil
+}
+
+type fakeStorageInstance struct {
+	state.StorageInstance
+	tag   names.StorageTag
+	owner names.Tag
+}
+
+func (i *fakeStorageInstance) Tag() names.Tag {
+	return i.tag
+}
+
+func (i *fakeStorageInstance) StorageTag() names.StorageTag {
+	return i.tag
+}
+
+func (i *fakeStorageInstance) Owner() (names.Tag, bool) {
+	return i.owner, i.owner != nil
+}
+
+type fakePoolManager struct {
+	storage.ProviderRegistry
+}
+
+func (*fakePoolManager) Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
+	return nil, errors.NotSupportedf("Create")
+}
+
+func (*fakePoolManager) Get(name string) (*storage.Config, error) {
+	return nil, errors.NotFoundf("pool %q", name)
+}
+
+func (*fakePoolManager) Delete(name string) error {
+	return errors.NotSupportedf("Delete")
+}
+
+func (*fakePoolManager) List() ([]*storage.Config, error) {
+	return nil, nil
+}
+
+func (*fakePoolManager) Replace(name, provider string, attrs map[string]interface{}) error {
+	return errors.NotSupportedf("Replace")
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides the API used by the resource tagger
+// worker, which keeps the tags of a model's cloud resources up to date
+// as the model's resource tags, the applications' resource tags and the
+// units deployed to the model's machines change.
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// API implements the API facade used by the resource tagger worker.
+type API struct {
+	backend     Backend
+	resources   facade.Resources
+	registry    storage.ProviderRegistry
+	poolManager poolmanager.PoolManager
+}
+
+// NewFacade provides the signature required for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if m.Type() != state.ModelTypeIAAS {
+		return nil, errors.NotSupportedf("resource tagging for %s models", m.Type())
+	}
+	registry, err := stateenvirons.NewStorageProviderRegistryForModel(
+		m,
+		stateenvirons.GetNewEnvironFunc(environs.New),
+		stateenvirons.GetNewCAASBrokerFunc(caas.New),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	poolManager := poolmanager.New(state.NewStateSettings(st), registry)
+	sb, err := state.NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		&backendShim{st: st, model: m, sb: sb},
+		ctx.Resources(),
+		ctx.Auth(),
+		registry,
+		poolManager,
+	)
+}
+
+// NewAPI returns a new resource tagger API facade.
+func NewAPI(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	registry storage.ProviderRegistry,
+	poolManager poolmanager.PoolManager,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:     backend,
+		resources:   resources,
+		registry:    registry,
+		poolManager: poolManager,
+	}, nil
+}
+
+// WatchResourceTags returns a NotifyWatcher that triggers when the
+// tags of the model's cloud resources may have changed: when the model
+// config or any application's config changes, or when units are
+// assigned to or removed from the model's machines.
+func (api *API) WatchResourceTags() (params.NotifyWatchResult, error) {
+	watch := common.NewMultiNotifyWatcher(
+		api.backend.WatchModelConfig(),
+		api.backend.WatchApplicationConfigs(),
+		api.backend.WatchModelMachineChanges(),
+	)
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// InstanceTags returns the tags to set on the cloud instances of the
+// model's provisioned machines. Containers and manually provisioned
+// machines are omitted.
+func (api *API) InstanceTags() (params.InstanceTagsResults, error) {
+	tagger, _, controllerUUID, err := api.resourceTagger()
+	if err != nil {
+		return params.InstanceTagsResults{}, errors.Trace(err)
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.InstanceTagsResults{}, errors.Trace(err)
+	}
+	var results []params.InstanceTagsResult
+	for _, m := range machines {
+		if m.Life() == state.Dead || m.IsContainer() {
+			continue
+		}
+		instanceId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		}
+		result := params.InstanceTagsResult{
+			MachineTag: names.NewMachineTag(m.Id()).String(),
+			InstanceId: string(instanceId),
+		}
+		if err == nil {
+			var manual bool
+			if manual, err = m.IsManual(); err == nil && manual {
+				continue
+			}
+		}
+		if err == nil {
+			result.Tags, err = tagger.InstanceTags(controllerUUID, m.Id(), machineJobs(m), m.Principals())
+		}
+		result.Error = common.ServerError(err)
+		results = append(results, result)
+	}
+	return params.InstanceTagsResults{Results: results}, nil
+}
+
+// VolumeTags returns the tags to set on the model's provisioned
+// volumes, along with the storage provider and configuration of
+// each volume.
+func (api *API) VolumeTags() (params.VolumeTagsResults, error) {
+	tagger, modelUUID, controllerUUID, err := api.resourceTagger()
+	if err != nil {
+		return params.VolumeTagsResults{}, errors.Trace(err)
+	}
+	volumes, err := api.backend.AllVolumes()
+	if err != nil {
+		return params.VolumeTagsResults{}, errors.Trace(err)
+	}
+	var results []params.VolumeTagsResult
+	for _, v := range volumes {
+		if v.Life() == state.Dead {
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		}
+		result := params.VolumeTagsResult{
+			VolumeTag: v.VolumeTag().String(),
+			VolumeId:  info.VolumeId,
+		}
+		if err == nil {
+			var volumeParams params.VolumeParams
+			volumeParams, err = api.volumeParams(v, tagger, modelUUID, controllerUUID)
+			result.Provider = volumeParams.Provider
+			result.Attributes = volumeParams.Attributes
+			result.Tags = volumeParams.Tags
+		}
+		result.Error = common.ServerError(err)
+		results = append(results, result)
+	}
+	return params.VolumeTagsResults{Results: results}, nil
+}
+
+func (api *API) volumeParams(
+	v state.Volume,
+	tagger *common.ResourceTagger,
+	modelUUID, controllerUUID string,
+) (params.VolumeParams, error) {
+	storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+		v.StorageInstance, api.backend.StorageInstance,
+	)
+	if err != nil {
+		return params.VolumeParams{}, errors.Trace(err)
+	}
+	return storagecommon.VolumeParams(
+		v, storageInstance, modelUUID, controllerUUID,
+		tagger, api.poolManager, api.registry,
+	)
+}
+
+// resourceTagger returns a ResourceTagger for computing the tags of the
+// model's cloud resources, and the UUIDs of the model and controller.
+func (api *API) resourceTagger() (_ *common.ResourceTagger, modelUUID, controllerUUID string, _ error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return nil, "", "", errors.Trace(err)
+	}
+	controllerCfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return nil, "", "", errors.Trace(err)
+	}
+	tagger := common.NewResourceTagger(cfg, api.backend.ModelOwner(), api.backend)
+	return tagger, cfg.UUID(), controllerCfg.ControllerUUID(), nil
+}
+
+func machineJobs(m Machine) []model.MachineJob {
+	jobs := m.Jobs()
+	result := make([]model.MachineJob, len(jobs))
+	for i, job := range jobs {
+		result[i] = job.ToParams()
+	}
+	return result
+}
@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/resourcetagger"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/provider"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type resourceTaggerSuite struct {
+	coretesting.BaseSuite
+
+	backend    *fakeBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *resourcetagger.API
+}
+
+var _ = gc.Suite(&resourceTaggerSuite{})
+
+func (s *resourceTaggerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	storageTag := names.NewStorageTag("data/0")
+	s.backend = &fakeBackend{
+		modelConfig: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"resource-tags": "team=finance",
+		}),
+		controllerConfig: coretesting.FakeControllerConfig(),
+		appConfig: map[string]application.ConfigAttributes{
+			"mysql": {
+				"resource-tags": map[string]interface{}{
+					"app": "{application}",
+				},
+			},
+		},
+		machines: []resourcetagger.Machine{
+			&fakeMachine{id: "0", life: state.Alive, instanceId: "i-0", principals: []string{"mysql/0"}},
+			&fakeMachine{id: "1", life: state.Dead, instanceId: "i-1"},
+			&fakeMachine{id: "0/lxd/0", life: state.Alive, container: true, instanceId: "juju-lxd-0"},
+			&fakeMachine{id: "2", life: state.Alive},
+			&fakeMachine{id: "3", life: state.Alive, manual: true, instanceId: "manual:10.0.0.1"},
+		},
+		volumes: []state.Volume{
+			&fakeVolume{
+				tag:        names.NewVolumeTag("0"),
+				life:       state.Alive,
+				info:       &state.VolumeInfo{VolumeId: "vol-0", Pool: "loop"},
+				storageTag: storageTag,
+			},
+			&fakeVolume{
+				tag:  names.NewVolumeTag("1"),
+				life: state.Alive,
+			},
+			&fakeVolume{
+				tag:  names.NewVolumeTag("2"),
+				life: state.Dead,
+				info: &state.VolumeInfo{VolumeId: "vol-2", Pool: "loop"},
+			},
+		},
+		storageInstances: map[names.StorageTag]state.StorageInstance{
+			storageTag: &fakeStorageInstance{tag: storageTag, owner: names.NewUnitTag("mysql/0")},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{Controller: true}
+
+	api, err := resourcetagger.NewAPI(
+		s.backend, s.resources, s.authorizer,
+		provider.CommonStorageProviders(), &fakePoolManager{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *resourceTaggerSuite) TestNewAPIRequiresController(c *gc.C) {
+	_, err := resourcetagger.NewAPI(
+		s.backend, s.resources, apiservertesting.FakeAuthorizer{},
+		provider.CommonStorageProviders(), &fakePoolManager{},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *resourceTaggerSuite) TestInstanceTags(c *gc.C) {
+	results, err := s.api.InstanceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0], jc.DeepEquals, params.InstanceTagsResult{
+		MachineTag: "machine-0",
+		InstanceId: "i-0",
+		Tags: map[string]string{
+			tags.JujuModel:         coretesting.ModelTag.Id(),
+			tags.JujuController:    coretesting.ControllerTag.Id(),
+			tags.JujuMachine:       "testmodel-machine-0",
+			tags.JujuUnitsDeployed: "mysql/0",
+			"team":                 "finance",
+			"app":                  "mysql",
+		},
+	})
+}
+
+func (s *resourceTaggerSuite) TestVolumeTags(c *gc.C) {
+	results, err := s.api.VolumeTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeTagsResult{{
+		VolumeTag: "volume-0",
+		VolumeId:  "vol-0",
+		Provider:  "loop",
+		Tags: map[string]string{
+			tags.JujuModel:           coretesting.ModelTag.Id(),
+			tags.JujuController:      coretesting.ControllerTag.Id(),
+			tags.JujuStorageInstance: "data/0",
+			tags.JujuStorageOwner:    "mysql/0",
+			"team":                   "finance",
+			"app":                    "mysql",
+		},
+	}})
+}
+
+func (s *resourceTaggerSuite) TestWatchResourceTags(c *gc.C) {
+	s.backend.modelConfigWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.backend.appConfigWatcher = apiservertesting.NewFakeNotifyWatcher()
+	s.backend.machineTagsWatcher = apiservertesting.NewFakeNotifyWatcher()
+
+	result, err := s.api.WatchResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	s.backend.CheckCallNames(c, "WatchModelConfig", "WatchApplicationConfigs", "WatchModelMachineChanges")
+}
@@ -42,10 +42,11 @@ type Actions struct {
 
 // Action describes an Action that will be or has been queued up.
 type Action struct {
-	Tag        string                 `json:"tag"`
-	Receiver   string                 `json:"receiver"`
-	Name       string                 `json:"name"`
-	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Tag              string                 `json:"tag"`
+	Receiver         string                 `json:"receiver"`
+	Name             string                 `json:"name"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	ExecutionTimeout time.Duration          `json:"execution-timeout,omitempty"`
 }
 
 // EnqueuedActions represents the result of enqueuing actions to run.
@@ -150,6 +151,7 @@ type OperationResult struct {
 	Completed    time.Time      `json:"completed,omitempty"`
 	Status       string         `json:"status,omitempty"`
 	Actions      []ActionResult `json:"actions,omitempty"`
+	Steps        []RunbookStep  `json:"steps,omitempty"`
 	Error        *Error         `json:"error,omitempty"`
 }
 
@@ -201,3 +203,153 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// ActionOutputParams holds the arguments for
+// logging chunks of output streamed by some actions.
+type ActionOutputParams struct {
+	Output []ActionOutputChunk `json:"output"`
+}
+
+// ActionOutputChunk holds a chunk of output written by
+// an action to its stdout or stderr.
+type ActionOutputChunk struct {
+	Tag    string `json:"tag"`
+	Stream string `json:"stream"`
+	Data   string `json:"data"`
+}
+
+// AddActionScheduleArgs holds the arguments for adding action schedules.
+type AddActionScheduleArgs struct {
+	Schedules []AddActionScheduleArg `json:"schedules"`
+}
+
+// AddActionScheduleArg holds the arguments for adding a schedule on
+// which an action is run. The receiver is a unit, an application, or
+// an application leader.
+type AddActionScheduleArg struct {
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule"`
+	Overlap    string                 `json:"overlap,omitempty"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
+
+// ActionScheduleResults holds a slice of ActionScheduleResult.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results,omitempty"`
+}
+
+// ActionScheduleResult holds an action schedule or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionSchedule describes a schedule on which an action is run.
+type ActionSchedule struct {
+	Id         string                 `json:"id"`
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Schedule   string                 `json:"schedule"`
+	Overlap    string                 `json:"overlap"`
+	Owner      string                 `json:"owner,omitempty"`
+	Created    time.Time              `json:"created"`
+	Paused     bool                   `json:"paused"`
+	LastRun    *time.Time             `json:"last-run,omitempty"`
+	NextRun    *time.Time             `json:"next-run,omitempty"`
+	History    []ActionScheduleRun    `json:"history,omitempty"`
+}
+
+// ActionScheduleRun records a time a scheduled action was due.
+type ActionScheduleRun struct {
+	Time         time.Time `json:"time"`
+	OperationTag string    `json:"operation,omitempty"`
+	Skipped      bool      `json:"skipped,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// ActionScheduleTimes holds the times at which
+// scheduled actions are next due.
+type ActionScheduleTimes struct {
+	Schedules []ActionScheduleTime `json:"schedules"`
+}
+
+// ActionScheduleTime holds the time at which
+// a scheduled action is next due.
+type ActionScheduleTime struct {
+	Id       string    `json:"id"`
+	Schedule string    `json:"schedule"`
+	Paused   bool      `json:"paused"`
+	NextRun  time.Time `json:"next-run"`
+}
+
+// ActionScheduleTriggers holds the arguments for triggering
+// the runs of scheduled actions.
+type ActionScheduleTriggers struct {
+	Triggers []ActionScheduleTrigger `json:"triggers"`
+}
+
+// ActionScheduleTrigger holds the id of an action
+// schedule, and the time its action was due.
+type ActionScheduleTrigger struct {
+	Id  string    `json:"id"`
+	Due time.Time `json:"due"`
+}
+
+// RunbookStep records the progress of a step of
+// a runbook run as an operation.
+type RunbookStep struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// StartRunbookArg holds the arguments for starting
+// a runbook with the named steps as an operation.
+type StartRunbookArg struct {
+	Summary string   `json:"summary"`
+	Steps   []string `json:"steps"`
+}
+
+// RunRunbookStepArg holds the arguments for running a step of a
+// runbook: an action, or commands, run on units of the model. The
+// units are those named, those of the applications, or only the
+// leaders of the applications if Leader is set.
+type RunRunbookStepArg struct {
+	OperationTag string                 `json:"operation"`
+	Step         int                    `json:"step"`
+	Action       string                 `json:"action,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Commands     string                 `json:"commands,omitempty"`
+	Timeout      time.Duration          `json:"timeout,omitempty"`
+	Applications []string               `json:"applications,omitempty"`
+	Units        []string               `json:"units,omitempty"`
+	Leader       bool                   `json:"leader,omitempty"`
+}
+
+// RunbookStepStatusArg holds the status of a step of a runbook.
+type RunbookStepStatusArg struct {
+	OperationTag string `json:"operation"`
+	Step         int    `json:"step"`
+	Status       string `json:"status"`
+	Message      string `json:"message,omitempty"`
+}
+
+// RunbookHeartbeatArg identifies the operation of a
+// runbook whose client is still running it.
+type RunbookHeartbeatArg struct {
+	OperationTag string `json:"operation"`
+}
+
+// FinishRunbookArg holds the arguments for finishing
+// a runbook, with the status of its operation.
+type FinishRunbookArg struct {
+	OperationTag string `json:"operation"`
+	Status       string `json:"status"`
+}
@@ -36,6 +36,80 @@ type ApplicationDeploy struct {
 	Resources        map[string]string              `json:"resources,omitempty"`
 }
 
+// ImportK8sWorkloadArgs holds the parameters for importing workloads
+// running in a k8s cluster into a model as applications.
+type ImportK8sWorkloadArgs struct {
+	Workloads []ImportK8sWorkloadArg `json:"workloads"`
+}
+
+// ImportK8sWorkloadArg holds the parameters for importing the
+// workload with the application's name, which is managed with
+// the specified charm.
+type ImportK8sWorkloadArg struct {
+	ApplicationName string `json:"application"`
+	DeploymentType  string `json:"deployment-type"`
+	CharmURL        string `json:"charm-url"`
+}
+
+// IngressTLSCertificates holds the TLS certificates for
+// the ingresses of exposed applications.
+type IngressTLSCertificates struct {
+	Certificates []IngressTLSCertificate `json:"certificates"`
+}
+
+// IngressTLSCertificate holds a PEM encoded TLS certificate
+// and key for the ingress of an exposed application.
+type IngressTLSCertificate struct {
+	ApplicationTag string `json:"application-tag"`
+	Certificate    string `json:"certificate"`
+	Key            string `json:"key"`
+}
+
+// ApplicationBackupResults holds the backups made of the
+// storage of applications.
+type ApplicationBackupResults struct {
+	Results []ApplicationBackupResult `json:"results"`
+}
+
+// ApplicationBackupResult holds a backup made of the storage
+// of an application, or an error.
+type ApplicationBackupResult struct {
+	Result *ApplicationBackup `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// ApplicationBackup holds the volume snapshots taken at the same
+// time of the storage of an application.
+type ApplicationBackup struct {
+	Id             string           `json:"id"`
+	ApplicationTag string           `json:"application-tag"`
+	Created        time.Time        `json:"created"`
+	Snapshots      []VolumeSnapshot `json:"snapshots"`
+}
+
+// VolumeSnapshot holds a snapshot of a volume claim
+// owned by the storage of an application.
+type VolumeSnapshot struct {
+	StorageName  string `json:"storage-name"`
+	ClaimName    string `json:"claim-name"`
+	SnapshotName string `json:"snapshot-name"`
+	Size         uint64 `json:"size"`
+}
+
+// RestoreApplicationArgs holds the parameters for restoring
+// the storage of applications from backups.
+type RestoreApplicationArgs struct {
+	Args []RestoreApplicationArg `json:"args"`
+}
+
+// RestoreApplicationArg holds the parameters for restoring the
+// storage of an application from one of its backups. The most
+// recent backup is restored if no backup id is specified.
+type RestoreApplicationArg struct {
+	ApplicationTag string `json:"application-tag"`
+	BackupId       string `json:"backup-id,omitempty"`
+}
+
 // ApplicationsDeployV5 holds the parameters for deploying one or more applications.
 type ApplicationsDeployV5 struct {
 	Applications []ApplicationDeployV5 `json:"applications"`
@@ -472,6 +546,7 @@ type UnitResult struct {
 	Charm           string                 `json:"charm"`
 	Leader          bool                   `json:"leader,omitempty"`
 	RelationData    []EndpointRelationData `json:"relation-data,omitempty"`
+	HookHistory     []HookExecution        `json:"hook-history,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
@@ -0,0 +1,71 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/storage"
+)
+
+// EstimateCostsArgs holds the applications whose running costs
+// should be estimated, e.g. the applications of a bundle.
+type EstimateCostsArgs struct {
+	Applications []ApplicationCostParams `json:"applications"`
+}
+
+// ApplicationCostParams describes an application whose running costs
+// should be estimated. Each unit is assumed to be deployed to its own
+// machine.
+type ApplicationCostParams struct {
+	// ApplicationName is the name of the application.
+	ApplicationName string `json:"application"`
+
+	// NumUnits is the number of units of the application.
+	NumUnits int `json:"num-units"`
+
+	// Constraints holds the application's constraints. They are
+	// combined with the model's constraints.
+	Constraints constraints.Value `json:"constraints"`
+
+	// Storage holds the application's storage constraints, keyed
+	// by storage name.
+	Storage map[string]storage.Constraints `json:"storage,omitempty"`
+}
+
+// ApplicationCostResults holds the estimated running costs of
+// applications.
+type ApplicationCostResults struct {
+	Results []ApplicationCostResult `json:"results"`
+}
+
+// ApplicationCostResult holds the estimated running cost of an
+// application.
+type ApplicationCostResult struct {
+	// ApplicationName is the name of the application.
+	ApplicationName string `json:"application"`
+
+	// Units is the number of units that were priced.
+	Units int `json:"units"`
+
+	// InstanceType is the instance type that was priced, if the
+	// cost was estimated for units that are yet to be deployed.
+	InstanceType string `json:"instance-type,omitempty"`
+
+	// Currency is the ISO 4217 code of the currency that the costs
+	// are expressed in.
+	Currency string `json:"currency,omitempty"`
+
+	// InstancesHourly is the hourly cost of the application's
+	// instances.
+	InstancesHourly float64 `json:"instances-hourly"`
+
+	// VolumesMonthly is the monthly cost of the application's
+	// volumes.
+	VolumesMonthly float64 `json:"volumes-monthly"`
+
+	// Monthly is the total monthly cost of the application.
+	Monthly float64 `json:"monthly"`
+
+	Error *Error `json:"error,omitempty"`
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// DebugHooksMessage is sent on the websockets which attach a
+// "juju debug-hooks" client, and a unit agent, to a debug-hooks
+// session. Type is one of the message types defined in
+// github.com/juju/juju/pubsub/debughooks.
+type DebugHooksMessage struct {
+	Type  string `json:"type"`
+	Data  []byte `json:"data,omitempty"`
+	Hook  string `json:"hook,omitempty"`
+	Code  int    `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// DebugHooksClientConfig holds the parameters for the websocket
+// of a debug-hooks client.
+type DebugHooksClientConfig struct {
+	// Hooks holds the names of the hooks and actions to debug;
+	// if empty, all of them are debugged.
+	Hooks []string `schema:"hook" url:"hook,omitempty"`
+}
+
+// DebugHooksAgentConfig holds the parameters for the websocket of
+// a unit agent attaching a hook to a debug-hooks session.
+type DebugHooksAgentConfig struct {
+	// Session is the ID of the session to attach to.
+	Session string `schema:"session" url:"session"`
+
+	// Hook is the name of the hook or action to debug.
+	Hook string `schema:"hook" url:"hook"`
+}
+
+// DebugHooksSession describes a debug-hooks session waiting
+// for a unit's hooks.
+type DebugHooksSession struct {
+	ID    string   `json:"id"`
+	Hooks []string `json:"hooks,omitempty"`
+}
+
+// DebugHooksSessionResults holds the debug-hooks sessions of
+// a set of units.
+type DebugHooksSessionResults struct {
+	Results []DebugHooksSessionResult `json:"results"`
+}
+
+// DebugHooksSessionResult holds the debug-hooks session of a
+// unit, which is nil if it has none, or an error.
+type DebugHooksSessionResult struct {
+	Result *DebugHooksSession `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
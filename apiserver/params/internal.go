@@ -293,6 +293,44 @@ type SetUnitStateArg struct {
 	MeterStatusState *string            `json:"meter-status-state,omitempty"`
 }
 
+// HookExecution records a hook, action or command run by a unit.
+type HookExecution struct {
+	Kind     string    `json:"kind"`
+	Name     string    `json:"name"`
+	Trigger  string    `json:"trigger,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	ExitCode int       `json:"exit-code"`
+	Error    string    `json:"error,omitempty"`
+	Skipped  bool      `json:"skipped,omitempty"`
+}
+
+// RecordHookExecutionsArgs holds the executions to record for
+// multiple units.
+type RecordHookExecutionsArgs struct {
+	Args []RecordHookExecutionsArg `json:"args"`
+}
+
+// RecordHookExecutionsArg holds a unit tag and the executions
+// to add to the unit's hook history.
+type RecordHookExecutionsArg struct {
+	Tag        string          `json:"tag"`
+	Executions []HookExecution `json:"executions"`
+}
+
+// UpdateStatusHookIntervalResults holds the update-status hook
+// intervals of a set of units.
+type UpdateStatusHookIntervalResults struct {
+	Results []UpdateStatusHookIntervalResult `json:"results"`
+}
+
+// UpdateStatusHookIntervalResult holds the interval at which a unit
+// runs its update-status hook, or an error.
+type UpdateStatusHookIntervalResult struct {
+	Interval time.Duration `json:"interval"`
+	Error    *Error        `json:"error,omitempty"`
+}
+
 // CommitHookChangesArgs serves as a container for CommitHookChangesArg objects
 // to be processed by the controller.
 type CommitHookChangesArgs struct {
@@ -329,9 +329,21 @@ type OperatorProvisioningInfo struct {
 	APIAddresses []string                    `json:"api-addresses"`
 	Tags         map[string]string           `json:"tags,omitempty"`
 	CharmStorage *KubernetesFilesystemParams `json:"charm-storage,omitempty"`
+	PodConfig    *OperatorPodConfig          `json:"pod-config,omitempty"`
 	Error        *Error                      `json:"error,omitempty"`
 }
 
+// OperatorPodConfig holds the resources and placement of an operator pod.
+type OperatorPodConfig struct {
+	CPURequest        string            `json:"cpu-request,omitempty"`
+	CPULimit          string            `json:"cpu-limit,omitempty"`
+	MemoryRequest     string            `json:"memory-request,omitempty"`
+	MemoryLimit       string            `json:"memory-limit,omitempty"`
+	NodeSelector      map[string]string `json:"node-selector,omitempty"`
+	Tolerations       []string          `json:"tolerations,omitempty"`
+	PriorityClassName string            `json:"priority-class-name,omitempty"`
+}
+
 // IssueOperatorCertificateResult contains an x509 certificate
 // for a CAAS Operator.
 type IssueOperatorCertificateResult struct {
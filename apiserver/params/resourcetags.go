@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// InstanceTagsResults holds the tags to set on the cloud instances
+// of a model's machines.
+type InstanceTagsResults struct {
+	Results []InstanceTagsResult `json:"results"`
+}
+
+// InstanceTagsResult holds the tags to set on the cloud instance
+// of a machine.
+type InstanceTagsResult struct {
+	MachineTag string            `json:"machine-tag"`
+	InstanceId string            `json:"instance-id"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Error      *Error            `json:"error,omitempty"`
+}
+
+// VolumeTagsResults holds the tags to set on a model's volumes.
+type VolumeTagsResults struct {
+	Results []VolumeTagsResult `json:"results"`
+}
+
+// VolumeTagsResult holds the tags to set on a volume, and the
+// storage provider and configuration that manage the volume.
+type VolumeTagsResult struct {
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+	Error      *Error                 `json:"error,omitempty"`
+}
@@ -160,6 +160,7 @@ type ApplicationStatus struct {
 	Scale         int    `json:"int,omitempty"`
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
+	ExternalURL   string `json:"external-url,omitempty"`
 }
 
 // TODO(wallyworld) - remove in Juju 3
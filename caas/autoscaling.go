@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// AutoscaleMinUnitsKey specifies the minimum number of units
+	// an autoscaled application is scaled down to.
+	AutoscaleMinUnitsKey = "autoscale-min-units"
+
+	// AutoscaleMaxUnitsKey specifies the maximum number of units
+	// an autoscaled application is scaled up to. Autoscaling is
+	// enabled for an application when this is set.
+	AutoscaleMaxUnitsKey = "autoscale-max-units"
+
+	// AutoscaleCPUTargetKey specifies the target average CPU
+	// utilisation of an application's units, as a percentage of
+	// the CPU they request.
+	AutoscaleCPUTargetKey = "autoscale-cpu-target"
+
+	// AutoscaleMemoryTargetKey specifies the target average memory
+	// utilisation of an application's units, as a percentage of
+	// the memory they request.
+	AutoscaleMemoryTargetKey = "autoscale-memory-target"
+
+	// AutoscaleMetricTargetsKey specifies the target average values
+	// of custom metrics reported for an application's units.
+	AutoscaleMetricTargetsKey = "autoscale-metric-targets"
+)
+
+// AutoscalingPolicy describes how the number of units of an
+// application is scaled automatically by the cloud.
+type AutoscalingPolicy struct {
+	// MinUnits is the minimum number of units.
+	MinUnits int
+
+	// MaxUnits is the maximum number of units.
+	MaxUnits int
+
+	// CPUTargetPercent, if non-zero, is the target average CPU
+	// utilisation of the units, as a percentage of the CPU requested.
+	CPUTargetPercent int
+
+	// MemoryTargetPercent, if non-zero, is the target average
+	// memory utilisation of the units, as a percentage of the
+	// memory requested.
+	MemoryTargetPercent int
+
+	// MetricTargets holds the target average values of custom
+	// metrics of the units, keyed by metric name.
+	MetricTargets map[string]string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AutoscalingPolicy) Validate() error {
+	if p.MinUnits < 1 {
+		return errors.NotValidf("minimum units %d", p.MinUnits)
+	}
+	if p.MaxUnits < p.MinUnits {
+		return errors.NewNotValid(nil, fmt.Sprintf("maximum units %d less than minimum units %d", p.MaxUnits, p.MinUnits))
+	}
+	if p.CPUTargetPercent < 0 {
+		return errors.NotValidf("CPU target %d%%", p.CPUTargetPercent)
+	}
+	if p.MemoryTargetPercent < 0 {
+		return errors.NotValidf("memory target %d%%", p.MemoryTargetPercent)
+	}
+	if p.CPUTargetPercent == 0 && p.MemoryTargetPercent == 0 && len(p.MetricTargets) == 0 {
+		return errors.NewNotValid(nil, "autoscaling policy requires a CPU, memory or metric target")
+	}
+	for name, value := range p.MetricTargets {
+		if name == "" || value == "" {
+			return errors.NotValidf("metric target %q=%q", name, value)
+		}
+	}
+	return nil
+}
+
+// AutoscalingPolicyFromConfig returns the autoscaling policy held in
+// the given application config, or nil if autoscaling is not enabled
+// for the application. The minimum number of units defaults to 1.
+func AutoscalingPolicyFromConfig(cfg application.ConfigAttributes) (*AutoscalingPolicy, error) {
+	maxUnits, err := configInt(cfg, AutoscaleMaxUnitsKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if maxUnits == 0 {
+		return nil, nil
+	}
+	policy := AutoscalingPolicy{MinUnits: 1, MaxUnits: maxUnits}
+	if _, ok := cfg[AutoscaleMinUnitsKey]; ok {
+		if policy.MinUnits, err = configInt(cfg, AutoscaleMinUnitsKey); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if policy.CPUTargetPercent, err = configInt(cfg, AutoscaleCPUTargetKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.MemoryTargetPercent, err = configInt(cfg, AutoscaleMemoryTargetKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if policy.MetricTargets, err = cfg.GetStringMap(AutoscaleMetricTargetsKey, nil); err != nil {
+		return nil, errors.Annotatef(err, "%s", AutoscaleMetricTargetsKey)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &policy, nil
+}
+
+func configInt(cfg application.ConfigAttributes, key string) (int, error) {
+	value, ok := cfg[key]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	v, err := schema.ForceInt().Coerce(value, []string{key})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return v.(int), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/testing"
+)
+
+type AutoscalingSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscalingSuite{})
+
+func (s *AutoscalingSuite) TestPolicyFromConfigNotEnabled(c *gc.C) {
+	policy, err := caas.AutoscalingPolicyFromConfig(application.ConfigAttributes{
+		caas.AutoscaleCPUTargetKey: 70,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.IsNil)
+}
+
+func (s *AutoscalingSuite) TestPolicyFromConfig(c *gc.C) {
+	policy, err := caas.AutoscalingPolicyFromConfig(application.ConfigAttributes{
+		caas.AutoscaleMinUnitsKey:      2,
+		caas.AutoscaleMaxUnitsKey:      float64(10),
+		caas.AutoscaleCPUTargetKey:     70,
+		caas.AutoscaleMemoryTargetKey:  int64(80),
+		caas.AutoscaleMetricTargetsKey: map[string]interface{}{"requests-per-second": "100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &caas.AutoscalingPolicy{
+		MinUnits:            2,
+		MaxUnits:            10,
+		CPUTargetPercent:    70,
+		MemoryTargetPercent: 80,
+		MetricTargets:       map[string]string{"requests-per-second": "100"},
+	})
+}
+
+func (s *AutoscalingSuite) TestPolicyFromConfigDefaultMinUnits(c *gc.C) {
+	policy, err := caas.AutoscalingPolicyFromConfig(application.ConfigAttributes{
+		caas.AutoscaleMaxUnitsKey:  3,
+		caas.AutoscaleCPUTargetKey: 50,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, &caas.AutoscalingPolicy{
+		MinUnits:         1,
+		MaxUnits:         3,
+		CPUTargetPercent: 50,
+	})
+}
+
+func (s *AutoscalingSuite) TestPolicyFromConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs application.ConfigAttributes
+		err   string
+	}{{
+		attrs: application.ConfigAttributes{
+			caas.AutoscaleMaxUnitsKey: 3,
+		},
+		err: "autoscaling policy requires a CPU, memory or metric target",
+	}, {
+		attrs: application.ConfigAttributes{
+			caas.AutoscaleMinUnitsKey:  4,
+			caas.AutoscaleMaxUnitsKey:  3,
+			caas.AutoscaleCPUTargetKey: 50,
+		},
+		err: "maximum units 3 less than minimum units 4",
+	}, {
+		attrs: application.ConfigAttributes{
+			caas.AutoscaleMinUnitsKey:  0,
+			caas.AutoscaleMaxUnitsKey:  3,
+			caas.AutoscaleCPUTargetKey: 50,
+		},
+		err: "minimum units 0 not valid",
+	}, {
+		attrs: application.ConfigAttributes{
+			caas.AutoscaleMaxUnitsKey:  3,
+			caas.AutoscaleCPUTargetKey: "lots",
+		},
+		err: `autoscale-cpu-target: expected number, got string\("lots"\)`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := caas.AutoscalingPolicyFromConfig(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
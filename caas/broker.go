@@ -194,6 +194,11 @@ type Broker interface {
 	// are changes to units of the specified application.
 	WatchUnits(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error)
 
+	// WatchEvents returns a watcher which notifies when there are
+	// warning events for the units, volumes or services of the
+	// specified application.
+	WatchEvents(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error)
+
 	// Units returns all units and any associated filesystems
 	// of the specified application. Filesystems are mounted
 	// via volumes bound to the unit.
@@ -283,10 +288,80 @@ type ServiceGetterSetter interface {
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
 
+	// EnsureAutoscaler creates or updates the autoscaler which scales the
+	// specified service according to the given policy. If the policy is
+	// nil, any existing autoscaler is removed.
+	EnsureAutoscaler(appName string, policy *AutoscalingPolicy) error
+
+	// EnsureNetworkPolicy creates or updates the network policy which
+	// restricts ingress to the specified service. If the policy is nil,
+	// any existing network policy is removed.
+	EnsureNetworkPolicy(appName string, policy *NetworkPolicy) error
+
 	// GetService returns the service for the specified application.
 	GetService(appName string, mode DeploymentMode, includeClusterIP bool) (*Service, error)
 }
 
+// WorkloadImporter provides the API to bring workloads already
+// running in the cluster under the management of the model.
+type WorkloadImporter interface {
+	// ImportWorkload labels and annotates the named workload, and
+	// any service of the same name, so that they are managed as the
+	// resources of the application with that name. It returns the
+	// number of units the workload runs.
+	ImportWorkload(name string, deploymentType DeploymentType) (int, error)
+
+	// ScaleImportedService sets the number of units run by the
+	// workload of an imported application, which has no pod spec.
+	ScaleImportedService(appName string, scale int) error
+}
+
+// IngressTLSSecretSetter provides the API to store the TLS
+// certificates used by the ingresses of exposed applications.
+type IngressTLSSecretSetter interface {
+	// SetIngressTLSSecret stores the PEM encoded TLS certificate and
+	// key for the ingress of the application in a secret, replacing
+	// any stored before, and returns the name of the secret.
+	SetIngressTLSSecret(appName string, certificate, key []byte) (string, error)
+}
+
+// VolumeSnapshot describes a snapshot of a volume claim
+// owned by the storage of an application.
+type VolumeSnapshot struct {
+	// StorageName is the name of the charm storage
+	// which owns the claim.
+	StorageName string
+
+	// ClaimName is the name of the snapshotted claim.
+	ClaimName string
+
+	// SnapshotName is the name of the snapshot.
+	SnapshotName string
+
+	// Size is the size of the claim, in MiB.
+	Size uint64
+}
+
+// StorageSnapshotter provides the API to back up and restore
+// the persistent storage of applications.
+type StorageSnapshotter interface {
+	// SnapshotStorage takes a snapshot of each volume claim owned by
+	// the storage of the application. Each snapshot is named after
+	// its claim, with the specified suffix. If any snapshot cannot be
+	// taken, those already taken are deleted.
+	SnapshotStorage(appName, suffix string) ([]VolumeSnapshot, error)
+
+	// RestoreStorage replaces the volume claims of the application
+	// with claims for new volumes populated from the snapshots. The
+	// application must not be running any pods, and the snapshots
+	// must all be ready to use.
+	RestoreStorage(appName string, snapshots []VolumeSnapshot) error
+
+	// DeleteSnapshots deletes the snapshots, such as those
+	// taken for a backup which could not be recorded.
+	DeleteSnapshots(snapshots []VolumeSnapshot) error
+}
+
 // NamespaceGetterSetter provides the API to get/set namespace.
 type NamespaceGetterSetter interface {
 	// Namespaces returns name names of the namespaces on the cluster.
@@ -396,6 +471,35 @@ type ModelOperatorConfig struct {
 
 	// Port is the socket port that the operator model will be listening on
 	Port int32
+
+	// PodConfig holds the resources and placement of the operator pod.
+	// If nil, they are taken from the model config.
+	PodConfig *OperatorPodConfig
+}
+
+// OperatorPodConfig holds the resources and placement of an operator pod.
+type OperatorPodConfig struct {
+	// CPURequest and CPULimit are the CPU requested by and
+	// available to the operator, as k8s quantities, eg "100m".
+	CPURequest string `json:"cpu-request,omitempty"`
+	CPULimit   string `json:"cpu-limit,omitempty"`
+
+	// MemoryRequest and MemoryLimit are the memory requested by and
+	// available to the operator, as k8s quantities, eg "256Mi".
+	MemoryRequest string `json:"memory-request,omitempty"`
+	MemoryLimit   string `json:"memory-limit,omitempty"`
+
+	// NodeSelector holds the labels of the nodes
+	// on which the operator may be scheduled.
+	NodeSelector map[string]string `json:"node-selector,omitempty"`
+
+	// Tolerations holds the node taints tolerated by
+	// the operator, each as key[=value][:effect].
+	Tolerations []string `json:"tolerations,omitempty"`
+
+	// PriorityClassName is the name of the
+	// priority class of the operator pod.
+	PriorityClassName string `json:"priority-class-name,omitempty"`
 }
 
 // OperatorConfig is the config to use when creating an operator.
@@ -423,4 +527,8 @@ type OperatorConfig struct {
 	// map for consistency in Read after Write and Write after Write.
 	// A value of 0 is ignored.
 	ConfigMapGeneration int64
+
+	// PodConfig holds the resources and placement of the operator pod.
+	// If nil, they are taken from the model config.
+	PodConfig *OperatorPodConfig
 }
@@ -31,6 +31,31 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	AutoscaleMinUnitsKey: {
+		Description: "the minimum number of units of an autoscaled application",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	AutoscaleMaxUnitsKey: {
+		Description: "the maximum number of units of an autoscaled application; setting this enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	AutoscaleCPUTargetKey: {
+		Description: "the target average CPU utilisation of an autoscaled application's units, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	AutoscaleMemoryTargetKey: {
+		Description: "the target average memory utilisation of an autoscaled application's units, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	AutoscaleMetricTargetsKey: {
+		Description: "a space separated set of metric=value target average values of custom metrics of an autoscaled application's units",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 // ConfigSchema returns the valid fields for a CAAS application config.
@@ -24,6 +24,31 @@ var baseFields = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	caas.AutoscaleMinUnitsKey: {
+		Description: "the minimum number of units of an autoscaled application",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.AutoscaleMaxUnitsKey: {
+		Description: "the maximum number of units of an autoscaled application; setting this enables autoscaling",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.AutoscaleCPUTargetKey: {
+		Description: "the target average CPU utilisation of an autoscaled application's units, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.AutoscaleMemoryTargetKey: {
+		Description: "the target average memory utilisation of an autoscaled application's units, as a percentage",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	caas.AutoscaleMetricTargetsKey: {
+		Description: "a space separated set of metric=value target average values of custom metrics of an autoscaled application's units",
+		Type:        environschema.Tattrs,
+		Group:       environschema.EnvironGroup,
+	},
 }
 
 var baseDefaults = schema.Defaults{
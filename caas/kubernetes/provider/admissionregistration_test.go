@@ -106,6 +106,8 @@ func (s *K8sBrokerSuite) assertMutatingWebhookConfigurations(c *gc.C, cfgs []k8s
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Update(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
@@ -395,6 +397,8 @@ func (s *K8sBrokerSuite) assertValidatingWebhookConfigurations(c *gc.C, cfgs []k
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(metav1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
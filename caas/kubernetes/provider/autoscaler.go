@@ -0,0 +1,153 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	autoscaling "k8s.io/api/autoscaling/v2beta2"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+// EnsureAutoscaler is part of the caas.ServiceGetterSetter interface.
+// It creates or updates a horizontal pod autoscaler which scales the
+// application's deployment or stateful set according to the policy.
+// The autoscaler is owned by the workload it scales, so it is garbage
+// collected by the cluster when the application is removed.
+func (k *kubernetesClient) EnsureAutoscaler(appName string, policy *caas.AutoscalingPolicy) error {
+	deploymentName := k.deploymentName(appName, true)
+	if policy == nil {
+		return errors.Trace(k.deleteHorizontalPodAutoscaler(deploymentName))
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	owner, err := k.scalableWorkload(deploymentName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	metrics, err := autoscalingMetrics(policy)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	minReplicas := int32(policy.MinUnits)
+	spec := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:            deploymentName,
+			Labels:          LabelsForApp(appName),
+			Annotations:     k.annotations.Copy(),
+			OwnerReferences: []v1.OwnerReference{*owner},
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: owner.APIVersion,
+				Kind:       owner.Kind,
+				Name:       owner.Name,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: int32(policy.MaxUnits),
+			Metrics:     metrics,
+		},
+	}
+	logger.Debugf("ensuring autoscaler for %q: %+v", appName, policy)
+	return errors.Trace(k.ensureHorizontalPodAutoscaler(spec))
+}
+
+// scalableWorkload returns a reference to the deployment or stateful
+// set with the given name.
+func (k *kubernetesClient) scalableWorkload(name string) (*v1.OwnerReference, error) {
+	ss, err := k.getStatefulSet(name)
+	if err == nil {
+		return &v1.OwnerReference{
+			APIVersion: "apps/v1",
+			Kind:       "StatefulSet",
+			Name:       ss.GetName(),
+			UID:        ss.GetUID(),
+		}, nil
+	} else if !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	deployment, err := k.getDeployment(name)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("deployment or stateful set %q to autoscale", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &v1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Name:       deployment.GetName(),
+		UID:        deployment.GetUID(),
+	}, nil
+}
+
+func autoscalingMetrics(policy *caas.AutoscalingPolicy) ([]autoscaling.MetricSpec, error) {
+	var metrics []autoscaling.MetricSpec
+	resourceMetric := func(name core.ResourceName, percent int) autoscaling.MetricSpec {
+		utilization := int32(percent)
+		return autoscaling.MetricSpec{
+			Type: autoscaling.ResourceMetricSourceType,
+			Resource: &autoscaling.ResourceMetricSource{
+				Name: name,
+				Target: autoscaling.MetricTarget{
+					Type:               autoscaling.UtilizationMetricType,
+					AverageUtilization: &utilization,
+				},
+			},
+		}
+	}
+	if policy.CPUTargetPercent > 0 {
+		metrics = append(metrics, resourceMetric(core.ResourceCPU, policy.CPUTargetPercent))
+	}
+	if policy.MemoryTargetPercent > 0 {
+		metrics = append(metrics, resourceMetric(core.ResourceMemory, policy.MemoryTargetPercent))
+	}
+	names := make([]string, 0, len(policy.MetricTargets))
+	for name := range policy.MetricTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		value, err := resource.ParseQuantity(policy.MetricTargets[name])
+		if err != nil {
+			return nil, errors.NotValidf("target %q for metric %q", policy.MetricTargets[name], name)
+		}
+		metrics = append(metrics, autoscaling.MetricSpec{
+			Type: autoscaling.PodsMetricSourceType,
+			Pods: &autoscaling.PodsMetricSource{
+				Metric: autoscaling.MetricIdentifier{Name: name},
+				Target: autoscaling.MetricTarget{
+					Type:         autoscaling.AverageValueMetricType,
+					AverageValue: &value,
+				},
+			},
+		})
+	}
+	return metrics, nil
+}
+
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(spec *autoscaling.HorizontalPodAutoscaler) error {
+	api := k.client().AutoscalingV2beta2().HorizontalPodAutoscalers(k.namespace)
+	_, err := api.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(name string) error {
+	err := k.client().AutoscalingV2beta2().HorizontalPodAutoscalers(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
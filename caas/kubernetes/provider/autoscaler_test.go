@@ -0,0 +1,188 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v2beta2"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) autoscalerArg(kind string, metrics ...autoscaling.MetricSpec) *autoscaling.HorizontalPodAutoscaler {
+	return &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: s.broker.GetAnnotations().ToMap(),
+			OwnerReferences: []v1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       "app-name",
+				UID:        "uid-xxxxx",
+			}},
+		},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       "app-name",
+			},
+			MinReplicas: int32Ptr(2),
+			MaxReplicas: 10,
+			Metrics:     metrics,
+		},
+	}
+}
+
+func cpuMetric(percent int32) autoscaling.MetricSpec {
+	return autoscaling.MetricSpec{
+		Type: autoscaling.ResourceMetricSourceType,
+		Resource: &autoscaling.ResourceMetricSource{
+			Name: core.ResourceCPU,
+			Target: autoscaling.MetricTarget{
+				Type:               autoscaling.UtilizationMetricType,
+				AverageUtilization: &percent,
+			},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureAutoscalerDeployment(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deployment := &apps.Deployment{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name", UID: "uid-xxxxx"},
+	}
+	rps := resource.MustParse("100")
+	hpa := s.autoscalerArg("Deployment", cpuMetric(70), autoscaling.MetricSpec{
+		Type: autoscaling.PodsMetricSourceType,
+		Pods: &autoscaling.PodsMetricSource{
+			Metric: autoscaling.MetricIdentifier{Name: "requests-per-second"},
+			Target: autoscaling.MetricTarget{
+				Type:         autoscaling.AverageValueMetricType,
+				AverageValue: &rps,
+			},
+		},
+	})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(deployment, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Update(hpa).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockHorizontalPodAutoscalers.EXPECT().Create(hpa).
+			Return(hpa, nil),
+	)
+
+	err := s.broker.EnsureAutoscaler("app-name", &caas.AutoscalingPolicy{
+		MinUnits:         2,
+		MaxUnits:         10,
+		CPUTargetPercent: 70,
+		MetricTargets:    map[string]string{"requests-per-second": "100"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureAutoscalerStatefulSet(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	statefulSet := &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name", UID: "uid-xxxxx"},
+	}
+	memory := int32(80)
+	hpa := s.autoscalerArg("StatefulSet", autoscaling.MetricSpec{
+		Type: autoscaling.ResourceMetricSourceType,
+		Resource: &autoscaling.ResourceMetricSource{
+			Name: core.ResourceMemory,
+			Target: autoscaling.MetricTarget{
+				Type:               autoscaling.UtilizationMetricType,
+				AverageUtilization: &memory,
+			},
+		},
+	})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(statefulSet, nil),
+		s.mockHorizontalPodAutoscalers.EXPECT().Update(hpa).
+			Return(hpa, nil),
+	)
+
+	err := s.broker.EnsureAutoscaler("app-name", &caas.AutoscalingPolicy{
+		MinUnits:            2,
+		MaxUnits:            10,
+		MemoryTargetPercent: 80,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureAutoscalerNoWorkload(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureAutoscaler("app-name", &caas.AutoscalingPolicy{
+		MinUnits:         2,
+		MaxUnits:         10,
+		CPUTargetPercent: 70,
+	})
+	c.Assert(err, gc.ErrorMatches, `deployment or stateful set "app-name" to autoscale not found`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureAutoscalerInvalidMetricTarget(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(&apps.Deployment{ObjectMeta: v1.ObjectMeta{Name: "app-name"}}, nil),
+	)
+
+	err := s.broker.EnsureAutoscaler("app-name", &caas.AutoscalingPolicy{
+		MinUnits:      1,
+		MaxUnits:      10,
+		MetricTargets: map[string]string{"requests-per-second": "lots"},
+	})
+	c.Assert(err, gc.ErrorMatches, `target "lots" for metric "requests-per-second" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureAutoscalerRemove(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockHorizontalPodAutoscalers.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureAutoscaler("app-name", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
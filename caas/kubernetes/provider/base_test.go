@@ -66,6 +66,10 @@ type BaseSuite struct {
 	mockNodes                  *mocks.MockNodeInterface
 	mockEvents                 *mocks.MockEventInterface
 
+	mockHorizontalPodAutoscalers *mocks.MockHorizontalPodAutoscalerInterface
+	mockNetworkPolicies          *mocks.MockNetworkPolicyInterface
+	mockPodDisruptionBudgets     *mocks.MockPodDisruptionBudgetInterface
+
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
 	mockCustomResourceDefinition *mocks.MockCustomResourceDefinitionInterface
@@ -319,6 +323,21 @@ func (s *BaseSuite) setupK8sRestClient(
 	s.mockClusterRoleBindings = mocks.NewMockClusterRoleBindingInterface(ctrl)
 	mockRbacV1.EXPECT().ClusterRoleBindings().AnyTimes().Return(s.mockClusterRoleBindings)
 
+	mockAutoscaling := mocks.NewMockAutoscalingV2beta2Interface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta2().AnyTimes().Return(mockAutoscaling)
+	s.mockHorizontalPodAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	mockAutoscaling.EXPECT().HorizontalPodAutoscalers(namespace).AnyTimes().Return(s.mockHorizontalPodAutoscalers)
+
+	mockNetworking := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworking)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	mockNetworking.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
+
+	mockPolicy := mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(mockPolicy)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	mockPolicy.EXPECT().PodDisruptionBudgets(namespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
 	s.mockDiscovery = mocks.NewMockDiscoveryInterface(ctrl)
 	s.k8sClient.EXPECT().Discovery().AnyTimes().Return(s.mockDiscovery)
 
@@ -28,6 +28,16 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	// IngressTLSIssuerKey is the application config key holding the
+	// name of the cert-manager cluster issuer used to obtain a TLS
+	// certificate for the ingress of an exposed application.
+	IngressTLSIssuerKey = "kubernetes-ingress-tls-issuer"
+	// IngressTLSSecretKey is the application config key holding the
+	// name of the k8s TLS secret with the certificate and key for the
+	// ingress of an exposed application. The key pair itself is never
+	// kept in config.
+	IngressTLSSecretKey = "kubernetes-ingress-tls-secret"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +96,51 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	IngressTLSIssuerKey: {
+		Description: "the cert-manager cluster issuer for the ingress TLS certificate",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	IngressTLSSecretKey: {
+		Description: "the name of the k8s TLS secret holding the ingress certificate and key",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorCPURequestKey): {
+		Description: "the CPU requested by the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorCPULimitKey): {
+		Description: "the CPU limit of the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorMemoryRequestKey): {
+		Description: "the memory requested by the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorMemoryLimitKey): {
+		Description: "the memory limit of the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorNodeSelectorKey): {
+		Description: "comma separated key=value labels of the nodes on which the operator pod may be scheduled, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorTolerationsKey): {
+		Description: "comma separated node taints tolerated by the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	applicationOperatorConfigKey(OperatorPriorityClassKey): {
+		Description: "the priority class of the operator pod, overriding the model config",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +150,16 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	IngressTLSIssuerKey:      schema.Omit,
+	IngressTLSSecretKey:      schema.Omit,
+
+	applicationOperatorConfigKey(OperatorCPURequestKey):    schema.Omit,
+	applicationOperatorConfigKey(OperatorCPULimitKey):      schema.Omit,
+	applicationOperatorConfigKey(OperatorMemoryRequestKey): schema.Omit,
+	applicationOperatorConfigKey(OperatorMemoryLimitKey):   schema.Omit,
+	applicationOperatorConfigKey(OperatorNodeSelectorKey):  schema.Omit,
+	applicationOperatorConfigKey(OperatorTolerationsKey):   schema.Omit,
+	applicationOperatorConfigKey(OperatorPriorityClassKey): schema.Omit,
 }
 
 // ConfigSchema returns the configuration schema for
@@ -17,6 +17,7 @@ var unsupportedConstraints = []string{
 	constraints.Arch,
 	constraints.InstanceType,
 	constraints.Spaces,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -36,7 +36,7 @@ func (u *upgradeCAASControllerBridge) Namespace() string {
 }
 
 func controllerUpgrade(appName string, vers version.Number, broker UpgradeCAASControllerBroker) error {
-	return upgradeStatefulSet(appName, "", vers, broker.Client().AppsV1().StatefulSets(broker.Namespace()))
+	return upgradeStatefulSet(appName, "", vers, nil, broker.Client().AppsV1().StatefulSets(broker.Namespace()))
 }
 
 func (k *kubernetesClient) upgradeController(agentTag names.Tag, vers version.Number) error {
@@ -102,6 +102,8 @@ func (s *K8sBrokerSuite) assertCustomerResourceDefinitions(c *gc.C, crds []k8ssp
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
@@ -424,6 +426,8 @@ func (s *K8sBrokerSuite) assertCustomerResources(c *gc.C, crs map[string][]unstr
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	}...)
 	gomock.InOrder(assertCalls...)
 
@@ -4,13 +4,19 @@
 package provider
 
 import (
-	"github.com/juju/errors"
+	"fmt"
+	"time"
 
+	"github.com/juju/errors"
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -70,3 +76,118 @@ func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.
 	)
 	return k.newWatcher(factory.Core().V1().Events().Informer(), objName, k.clock)
 }
+
+// WatchEvents returns a watcher which notifies when there are
+// warning events for the pods, persistent volume claims, services
+// or workload resource of the specified application.
+func (k *kubernetesClient) WatchEvents(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error) {
+	selector, err := k8slabels.Parse(applicationSelector(appName, mode))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	factory := informers.NewSharedInformerFactoryWithOptions(k.client(), 0,
+		informers.WithNamespace(k.namespace),
+		informers.WithTweakListOptions(func(o *v1.ListOptions) {
+			o.FieldSelector = fields.OneTermEqualSelector("type", core.EventTypeWarning).String()
+		}),
+	)
+	informer := &filteredInformer{
+		SharedIndexInformer: factory.Core().V1().Events().Informer(),
+		filter: func(obj interface{}) bool {
+			evt, ok := obj.(*core.Event)
+			return ok && k.isApplicationObject(selector, evt.InvolvedObject)
+		},
+	}
+	return k.newWatcher(informer, appName, k.clock)
+}
+
+// filteredInformer only passes the objects accepted
+// by the filter on to its event handlers.
+type filteredInformer struct {
+	cache.SharedIndexInformer
+	filter func(obj interface{}) bool
+}
+
+// AddEventHandler is part of the cache.SharedInformer interface.
+func (i *filteredInformer) AddEventHandler(handler cache.ResourceEventHandler) {
+	i.SharedIndexInformer.AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: i.filter,
+		Handler:    handler,
+	})
+}
+
+// isApplicationObject returns true if the object is one which is
+// created for an application, ie it has the application's labels.
+// Pods, services and workload resources are labelled when they are
+// created, as are persistent volume claims, either by the stateful
+// set which claims them or when they are created for a deployment.
+func (k *kubernetesClient) isApplicationObject(selector k8slabels.Selector, obj core.ObjectReference) bool {
+	labels, err := k.objectLabels(obj)
+	if k8serrors.IsNotFound(err) {
+		return false
+	} else if err != nil {
+		logger.Debugf("getting labels of %s %q: %v", obj.Kind, obj.Name, err)
+		return false
+	}
+	return selector.Matches(k8slabels.Set(labels))
+}
+
+// objectLabels returns the labels of the object of one of the kinds
+// created for an application, or nil for objects of any other kind.
+func (k *kubernetesClient) objectLabels(obj core.ObjectReference) (map[string]string, error) {
+	var (
+		meta v1.Object
+		err  error
+	)
+	switch obj.Kind {
+	case "Pod":
+		meta, err = k.client().CoreV1().Pods(k.namespace).Get(obj.Name, v1.GetOptions{})
+	case "PersistentVolumeClaim":
+		meta, err = k.client().CoreV1().PersistentVolumeClaims(k.namespace).Get(obj.Name, v1.GetOptions{})
+	case "Service":
+		meta, err = k.client().CoreV1().Services(k.namespace).Get(obj.Name, v1.GetOptions{})
+	case "StatefulSet":
+		meta, err = k.client().AppsV1().StatefulSets(k.namespace).Get(obj.Name, v1.GetOptions{})
+	case "Deployment":
+		meta, err = k.client().AppsV1().Deployments(k.namespace).Get(obj.Name, v1.GetOptions{})
+	case "DaemonSet":
+		meta, err = k.client().AppsV1().DaemonSets(k.namespace).Get(obj.Name, v1.GetOptions{})
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return meta.GetLabels(), nil
+}
+
+// mostRecentWarning returns the most recent warning event,
+// or nil if there are no warning events.
+func mostRecentWarning(events []core.Event) *core.Event {
+	var result *core.Event
+	for i, evt := range events {
+		if evt.Type != core.EventTypeWarning {
+			continue
+		}
+		if result == nil || !eventTime(evt).Before(eventTime(*result)) {
+			result = &events[i]
+		}
+	}
+	return result
+}
+
+// eventTime returns the time when the event was last observed.
+func eventTime(evt core.Event) time.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp.Time
+	}
+	if !evt.EventTime.IsZero() {
+		return evt.EventTime.Time
+	}
+	return evt.FirstTimestamp.Time
+}
+
+// warningMessage formats a warning event as a status message.
+func warningMessage(evt *core.Event) string {
+	return fmt.Sprintf("%s: %s", evt.Reason, evt.Message)
+}
@@ -0,0 +1,216 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	jujuclock "github.com/juju/clock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) TestWatchEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.k8sWatcherFn = func(_ cache.SharedIndexInformer, name string, _ jujuclock.Clock) (provider.KubernetesNotifyWatcher, error) {
+		c.Check(name, gc.Equals, "app-name")
+		w, _ := newKubernetesTestWatcher()
+		return w, nil
+	}
+	w, err := s.broker.WatchEvents("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case _, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for event")
+	}
+}
+
+func (s *K8sBrokerSuite) TestIsApplicationObject(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	appLabels := map[string]string{"juju-app": "app-name"}
+	otherLabels := map[string]string{"juju-app": "app-name-other"}
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get("app-name-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Labels: appLabels}}, nil),
+		s.mockPods.EXPECT().Get("app-name-other-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Labels: otherLabels}}, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get("database-app-name-other-0", v1.GetOptions{}).
+			Return(&core.PersistentVolumeClaim{ObjectMeta: v1.ObjectMeta{Labels: otherLabels}}, nil),
+		s.mockServices.EXPECT().Get("app-name-endpoints", v1.GetOptions{}).
+			Return(&core.Service{ObjectMeta: v1.ObjectMeta{Labels: appLabels}}, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().Get("app-name-operator-0", v1.GetOptions{}).
+			Return(&core.Pod{ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-operator": "app-name"}}}, nil),
+	)
+
+	for i, t := range []struct {
+		mode     caas.DeploymentMode
+		obj      core.ObjectReference
+		expected bool
+	}{
+		{caas.ModeWorkload, core.ObjectReference{Kind: "Pod", Name: "app-name-0"}, true},
+		{caas.ModeWorkload, core.ObjectReference{Kind: "Pod", Name: "app-name-other-0"}, false},
+		{caas.ModeWorkload, core.ObjectReference{Kind: "PersistentVolumeClaim", Name: "database-app-name-other-0"}, false},
+		{caas.ModeWorkload, core.ObjectReference{Kind: "Service", Name: "app-name-endpoints"}, true},
+		{caas.ModeWorkload, core.ObjectReference{Kind: "StatefulSet", Name: "app-name"}, false},
+		{caas.ModeWorkload, core.ObjectReference{Kind: "Node", Name: "app-name"}, false},
+		{caas.ModeOperator, core.ObjectReference{Kind: "Pod", Name: "app-name-operator-0"}, true},
+	} {
+		c.Logf("test %d: %s %s", i, t.obj.Kind, t.obj.Name)
+		c.Check(s.broker.IsApplicationObject("app-name", t.mode, t.obj), gc.Equals, t.expected)
+	}
+}
+
+func warningEvent(reason, message string, when time.Time) core.Event {
+	return core.Event{
+		Type:          core.EventTypeWarning,
+		Reason:        reason,
+		Message:       message,
+		LastTimestamp: v1.NewTime(when),
+	}
+}
+
+func (s *K8sBrokerSuite) TestUnitsPendingPodReportsWarningEvent(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podList := &core.PodList{
+		Items: []core.Pod{{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "app-name-0",
+				UID:  types.UID("uuid"),
+			},
+			Status: core.PodStatus{
+				Phase: core.PodPending,
+				Conditions: []core.PodCondition{{
+					Type:    core.PodScheduled,
+					Status:  core.ConditionFalse,
+					Message: "unschedulable",
+				}},
+			},
+			Spec: core.PodSpec{
+				Containers: []core.Container{{}},
+			},
+		}},
+	}
+	warningTime := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	events := &core.EventList{
+		Items: []core.Event{
+			warningEvent("FailedScheduling", "0/1 nodes are available", warningTime.Add(-time.Minute)),
+			warningEvent("FailedScheduling", "0/3 nodes are available", warningTime),
+			{Type: core.EventTypeNormal, Reason: "Scheduled", Message: "later normal event"},
+		},
+	}
+	s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app=app-name"}).Return(podList, nil)
+	s.mockEvents.EXPECT().List(
+		listOptionsFieldSelectorMatcher("involvedObject.name=app-name-0,involvedObject.kind=Pod"),
+	).Return(events, nil)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Status.Status, gc.Equals, status.Allocating)
+	c.Assert(units[0].Status.Message, gc.Equals, "FailedScheduling: 0/3 nodes are available")
+	c.Assert(units[0].Status.Since, gc.NotNil)
+	c.Assert(*units[0].Status.Since, jc.DeepEquals, warningTime)
+}
+
+func (s *K8sBrokerSuite) TestGetServiceWaitingReportsWorkloadWarningEvent(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	workload := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:      2,
+			ReadyReplicas: 1,
+		},
+	}
+	events := &core.EventList{
+		Items: []core.Event{
+			warningEvent("FailedCreate", `create Pod app-name-1 failed: exceeded quota`, time.Now()),
+		},
+	}
+
+	s.assertGetService(c,
+		caas.ModeWorkload,
+		&caas.Service{
+			Id: "uid-xxxxx",
+			Addresses: network.ProviderAddresses{
+				network.NewScopedProviderAddress("10.0.0.1", network.ScopePublic),
+			},
+			Generation: int64Ptr(0),
+			Status: status.StatusInfo{
+				Status:  status.Blocked,
+				Message: "FailedCreate: create Pod app-name-1 failed: exceeded quota",
+			},
+		},
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(workload, nil),
+		s.mockEvents.EXPECT().List(
+			listOptionsFieldSelectorMatcher("involvedObject.name=app-name,involvedObject.kind=StatefulSet"),
+		).Return(events, nil),
+	)
+}
+
+func (s *K8sBrokerSuite) TestGetServiceWaitingReportsServiceWarningEvent(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	workload := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Status: appsv1.StatefulSetStatus{
+			Replicas:      2,
+			ReadyReplicas: 1,
+		},
+	}
+	events := &core.EventList{
+		Items: []core.Event{
+			warningEvent("SyncLoadBalancerFailed", "no available IPs", time.Now()),
+		},
+	}
+
+	s.assertGetService(c,
+		caas.ModeWorkload,
+		&caas.Service{
+			Id: "uid-xxxxx",
+			Addresses: network.ProviderAddresses{
+				network.NewScopedProviderAddress("10.0.0.1", network.ScopePublic),
+			},
+			Generation: int64Ptr(0),
+			Status: status.StatusInfo{
+				Status:  status.Waiting,
+				Message: "SyncLoadBalancerFailed: no available IPs",
+			},
+		},
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(workload, nil),
+		s.mockEvents.EXPECT().List(
+			listOptionsFieldSelectorMatcher("involvedObject.name=app-name,involvedObject.kind=StatefulSet"),
+		).Return(&core.EventList{}, nil),
+		s.mockEvents.EXPECT().List(
+			listOptionsFieldSelectorMatcher("involvedObject.name=app-name,involvedObject.kind=Service"),
+		).Return(events, nil),
+	)
+}
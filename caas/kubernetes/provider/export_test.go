@@ -13,6 +13,7 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/juju/juju/caas"
@@ -152,6 +153,14 @@ func (k *kubernetesClient) DeleteNamespaceModelTeardown(ctx context.Context, wg
 	k.deleteNamespaceModelTeardown(ctx, wg, errChan)
 }
 
+func (k *kubernetesClient) IsApplicationObject(appName string, mode caas.DeploymentMode, obj core.ObjectReference) bool {
+	selector, err := k8slabels.Parse(applicationSelector(appName, mode))
+	if err != nil {
+		panic(err)
+	}
+	return k.isApplicationObject(selector, obj)
+}
+
 func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.Provider {
 	return &storageProvider{&kubernetesClient{clientUnlocked: k8sClient, namespace: namespace}}
 }
@@ -0,0 +1,439 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	providertesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+// fakeClusterSuite runs the broker end to end against an in-process
+// fake cluster, rather than checking the calls it makes to mock clients.
+type fakeClusterSuite struct {
+	testing.BaseSuite
+
+	cluster *providertesting.FakeCluster
+	broker  caas.Broker
+}
+
+var _ = gc.Suite(&fakeClusterSuite{})
+
+func (s *fakeClusterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		config.NameKey:              "test",
+		provider.OperatorStorageKey: "",
+		provider.WorkloadStorageKey: "",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.cluster = providertesting.NewFakeCluster("test", &storagev1.StorageClass{
+		ObjectMeta: v1.ObjectMeta{Name: "workload-storage"},
+	})
+	c.Assert(s.cluster.AddNode("node-1", nil), jc.ErrorIsNil)
+	c.Assert(s.cluster.AddNode("node-2", nil), jc.ErrorIsNil)
+	s.broker, err = s.cluster.NewBroker(testing.ControllerTag.Id(), cfg, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *fakeClusterSuite) ensureService(c *gc.C, numUnits int) {
+	params := &caas.ServiceParams{
+		PodSpec:           getBasicPodspec(),
+		OperatorImagePath: "operator/image-path",
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attributes:  map[string]interface{}{"storage-class": "workload-storage"},
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+		}},
+	}
+	statusSetter := func(string, status.Status, string, map[string]interface{}) error { return nil }
+	err := s.broker.EnsureService("app-name", statusSetter, params, numUnits, application.ConfigAttributes{
+		"kubernetes-service-type": "ClusterIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.cluster.SyncWorkloads(), jc.ErrorIsNil)
+}
+
+func (s *fakeClusterSuite) TestEnsureServiceStartsPendingUnits(c *gc.C) {
+	s.ensureService(c, 2)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	for _, unit := range units {
+		c.Check(unit.Status.Status, gc.Equals, status.Allocating)
+		// The claims are not bound yet, so the storage is not attached.
+		c.Check(storageFilesystems(unit, "database"), gc.HasLen, 0)
+	}
+
+	svc, err := s.broker.GetService("app-name", caas.ModeWorkload, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc.Scale, gc.NotNil)
+	c.Assert(*svc.Scale, gc.Equals, 2)
+}
+
+func (s *fakeClusterSuite) TestRunningUnitWithBoundStorage(c *gc.C) {
+	s.ensureService(c, 1)
+
+	claims, err := s.cluster.Clientset.CoreV1().PersistentVolumeClaims("test").List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Items, gc.HasLen, 1)
+	c.Assert(s.cluster.BindClaim(claims.Items[0].Name), jc.ErrorIsNil)
+	c.Assert(s.cluster.SetPodPhase("app-name-0", core.PodRunning), jc.ErrorIsNil)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	unit := units[0]
+	c.Assert(unit.Id, gc.Equals, "app-name-0")
+	c.Assert(unit.Address, gc.Not(gc.Equals), "")
+	c.Assert(unit.Status.Status, gc.Equals, status.Running)
+	filesystems := storageFilesystems(unit, "database")
+	c.Assert(filesystems, gc.HasLen, 1)
+	c.Assert(filesystems[0].MountPoint, gc.Equals, "path/to/here")
+	c.Assert(filesystems[0].Volume.VolumeId, gc.Equals, "pvc-"+string(claims.Items[0].UID))
+	c.Assert(filesystems[0].Volume.Status.Status, gc.Equals, status.Attached)
+}
+
+var volumeSnapshotResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1beta1",
+	Resource: "volumesnapshots",
+}
+
+// storageFilesystems returns the filesystems of
+// the unit for the storage with the specified name.
+func storageFilesystems(unit caas.Unit, storageName string) []caas.FilesystemInfo {
+	var result []caas.FilesystemInfo
+	for _, fs := range unit.FilesystemInfo {
+		if fs.StorageName == storageName {
+			result = append(result, fs)
+		}
+	}
+	return result
+}
+
+func (s *fakeClusterSuite) TestScaleRemovesUnits(c *gc.C) {
+	s.ensureService(c, 3)
+	s.ensureService(c, 1)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Id, gc.Equals, "app-name-0")
+}
+
+func (s *fakeClusterSuite) TestFailNodeLosesUnits(c *gc.C) {
+	s.ensureService(c, 2)
+	c.Assert(s.cluster.SetPodPhase("app-name-0", core.PodRunning), jc.ErrorIsNil)
+	c.Assert(s.cluster.SetPodPhase("app-name-1", core.PodRunning), jc.ErrorIsNil)
+	c.Assert(s.cluster.FailNode("node-2"), jc.ErrorIsNil)
+
+	units, err := s.broker.Units("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	statuses := make(map[string]status.Status)
+	for _, unit := range units {
+		statuses[unit.Id] = unit.Status.Status
+	}
+	c.Assert(statuses, jc.DeepEquals, map[string]status.Status{
+		"app-name-0": status.Running,
+		"app-name-1": status.Unknown,
+	})
+}
+
+func (s *fakeClusterSuite) TestWatchUnits(c *gc.C) {
+	s.ensureService(c, 1)
+	w, err := s.broker.WatchUnits("app-name", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		w.Kill()
+		c.Assert(w.Wait(), jc.ErrorIsNil)
+	}()
+
+	waitChange := func() {
+		select {
+		case _, ok := <-w.Changes():
+			c.Assert(ok, jc.IsTrue)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for units change")
+		}
+	}
+	waitChange()
+
+	c.Assert(s.cluster.SetPodPhase("app-name-0", core.PodRunning), jc.ErrorIsNil)
+	waitChange()
+}
+
+func (s *fakeClusterSuite) TestEnsureOperator(c *gc.C) {
+	err := s.broker.EnsureOperator("app-name", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		OperatorInfo:      []byte("operator-info-data"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.cluster.SyncWorkloads(), jc.ErrorIsNil)
+
+	op, err := s.broker.Operator("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status.Status, gc.Equals, status.Allocating)
+	c.Assert(op.Config.OperatorImagePath, gc.Equals, "/path/to/image")
+	c.Assert(string(op.Config.AgentConf), gc.Equals, "agent-conf-data")
+
+	c.Assert(s.cluster.SetPodPhase("app-name-operator-0", core.PodRunning), jc.ErrorIsNil)
+	op, err = s.broker.Operator("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status.Status, gc.Equals, status.Running)
+}
+
+func (s *fakeClusterSuite) TestEnsureOperatorPodConfig(c *gc.C) {
+	err := s.broker.EnsureOperator("app-name", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		OperatorInfo:      []byte("operator-info-data"),
+		PodConfig: &caas.OperatorPodConfig{
+			CPURequest:        "100m",
+			MemoryLimit:       "256Mi",
+			NodeSelector:      map[string]string{"pool": "system"},
+			Tolerations:       []string{"dedicated=juju:NoSchedule"},
+			PriorityClassName: "juju-system",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ss, err := s.cluster.Clientset.AppsV1().StatefulSets("test").Get("app-name-operator", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	spec := ss.Spec.Template.Spec
+	resources := spec.Containers[0].Resources
+	c.Assert(resources.Requests.Cpu().String(), gc.Equals, "100m")
+	c.Assert(resources.Limits.Memory().String(), gc.Equals, "256Mi")
+	c.Assert(spec.NodeSelector, jc.DeepEquals, map[string]string{"pool": "system"})
+	c.Assert(spec.Tolerations, jc.DeepEquals, []core.Toleration{{
+		Key: "dedicated", Operator: core.TolerationOpEqual, Value: "juju", Effect: core.TaintEffectNoSchedule,
+	}})
+	c.Assert(spec.PriorityClassName, gc.Equals, "juju-system")
+
+	// Updating the operator applies the new config.
+	err = s.broker.EnsureOperator("app-name", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		AgentConf:         []byte("agent-conf-data"),
+		OperatorInfo:      []byte("operator-info-data"),
+		PodConfig:         &caas.OperatorPodConfig{CPURequest: "200m"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ss, err = s.cluster.Clientset.AppsV1().StatefulSets("test").Get("app-name-operator", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	spec = ss.Spec.Template.Spec
+	c.Assert(spec.Containers[0].Resources.Requests.Cpu().String(), gc.Equals, "200m")
+	c.Assert(spec.Containers[0].Resources.Limits, gc.HasLen, 0)
+	c.Assert(spec.NodeSelector, gc.HasLen, 0)
+	c.Assert(spec.Tolerations, gc.HasLen, 0)
+	c.Assert(spec.PriorityClassName, gc.Equals, "")
+}
+
+func (s *fakeClusterSuite) TestEnsureModelOperatorPodConfigFromModelConfig(c *gc.C) {
+	cfg, err := s.broker.Config().Apply(map[string]interface{}{
+		provider.OperatorMemoryRequestKey: "64Mi",
+		provider.OperatorNodeSelectorKey:  "pool=system",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.broker.SetConfig(cfg), jc.ErrorIsNil)
+
+	err = s.broker.EnsureModelOperator(testing.ModelTag.Id(), "path/to/agent", &caas.ModelOperatorConfig{
+		AgentConf:         []byte("agent-conf-data"),
+		OperatorImagePath: "/path/to/image",
+		Port:              17071,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	deployment, err := s.cluster.Clientset.AppsV1().Deployments("test").Get("modeloperator", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	spec := deployment.Spec.Template.Spec
+	c.Assert(spec.Containers[0].Resources.Requests.Memory().String(), gc.Equals, "64Mi")
+	c.Assert(spec.NodeSelector, jc.DeepEquals, map[string]string{"pool": "system"})
+}
+
+func (s *fakeClusterSuite) TestSnapshotStorage(c *gc.C) {
+	s.ensureService(c, 2)
+
+	claims, err := s.cluster.Clientset.CoreV1().PersistentVolumeClaims("test").List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Items, gc.HasLen, 2)
+	var claimNames []string
+	for _, claim := range claims.Items {
+		claimNames = append(claimNames, claim.Name)
+	}
+	sort.Strings(claimNames)
+
+	snapshots, err := s.broker.(caas.StorageSnapshotter).SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	for i, snapshot := range snapshots {
+		c.Check(snapshot.StorageName, gc.Equals, "database")
+		c.Check(snapshot.ClaimName, gc.Equals, claimNames[i])
+		c.Check(snapshot.SnapshotName, gc.Equals, snapshot.ClaimName+"-20200102-030405")
+		c.Check(snapshot.Size, gc.Equals, uint64(100))
+
+		obj, err := s.cluster.Dynamic.Resource(volumeSnapshotResource).Namespace("test").Get(snapshot.SnapshotName, v1.GetOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+		claimName, _, err := unstructured.NestedString(obj.Object, "spec", "source", "persistentVolumeClaimName")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(claimName, gc.Equals, snapshot.ClaimName)
+		c.Check(obj.GetLabels(), jc.DeepEquals, map[string]string{"juju-app": "app-name"})
+	}
+}
+
+func (s *fakeClusterSuite) TestSnapshotStorageNoStatefulSet(c *gc.C) {
+	_, err := s.broker.(caas.StorageSnapshotter).SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, gc.ErrorMatches, `persistent storage for application "app-name" not found`)
+}
+
+func (s *fakeClusterSuite) TestRestoreStorage(c *gc.C) {
+	s.ensureService(c, 1)
+	claims := s.cluster.Clientset.CoreV1().PersistentVolumeClaims("test")
+	list, err := claims.List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Items, gc.HasLen, 1)
+	original := list.Items[0]
+	c.Assert(s.cluster.BindClaim(original.Name), jc.ErrorIsNil)
+
+	snapshotter := s.broker.(caas.StorageSnapshotter)
+	snapshots, err := snapshotter.SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.cluster.SetSnapshotReady(snapshots[0].SnapshotName, true), jc.ErrorIsNil)
+
+	// The claims are kept when the application is scaled down.
+	s.ensureService(c, 0)
+	err = snapshotter.RestoreStorage("app-name", snapshots)
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored, err := claims.Get(original.Name, v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored.UID, gc.Not(gc.Equals), original.UID)
+	c.Assert(restored.Spec.VolumeName, gc.Equals, "")
+	c.Assert(restored.Spec.DataSource, gc.NotNil)
+	c.Assert(*restored.Spec.DataSource.APIGroup, gc.Equals, "snapshot.storage.k8s.io")
+	c.Assert(restored.Spec.DataSource.Kind, gc.Equals, "VolumeSnapshot")
+	c.Assert(restored.Spec.DataSource.Name, gc.Equals, snapshots[0].SnapshotName)
+	c.Assert(restored.Spec.Resources, jc.DeepEquals, original.Spec.Resources)
+	c.Assert(restored.Labels["juju-app"], gc.Equals, "app-name")
+}
+
+func (s *fakeClusterSuite) TestRestoreStorageWithPods(c *gc.C) {
+	s.ensureService(c, 1)
+	snapshotter := s.broker.(caas.StorageSnapshotter)
+	snapshots, err := snapshotter.SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = snapshotter.RestoreStorage("app-name", snapshots)
+	c.Assert(err, gc.ErrorMatches, `application "app-name" is running 1 pod\(s\), scale it to 0 before restoring its storage`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *fakeClusterSuite) TestRestoreStorageSnapshotNotFound(c *gc.C) {
+	s.ensureService(c, 1)
+	snapshotter := s.broker.(caas.StorageSnapshotter)
+	snapshots, err := snapshotter.SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	s.ensureService(c, 0)
+	err = s.cluster.Dynamic.Resource(volumeSnapshotResource).Namespace("test").Delete(snapshots[0].SnapshotName, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = snapshotter.RestoreStorage("app-name", snapshots)
+	c.Assert(err, gc.ErrorMatches, `volume snapshot ".*-20200102-030405" not found`)
+	s.checkClaimsKept(c, snapshots)
+}
+
+func (s *fakeClusterSuite) TestRestoreStorageSnapshotNotReady(c *gc.C) {
+	s.ensureService(c, 2)
+	snapshotter := s.broker.(caas.StorageSnapshotter)
+	snapshots, err := snapshotter.SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 2)
+	c.Assert(s.cluster.SetSnapshotReady(snapshots[0].SnapshotName, true), jc.ErrorIsNil)
+	s.ensureService(c, 0)
+
+	// No claim is replaced unless all the snapshots are ready.
+	err = snapshotter.RestoreStorage("app-name", snapshots)
+	c.Assert(err, gc.ErrorMatches, `volume snapshot ".*-20200102-030405" is not ready to use`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	s.checkClaimsKept(c, snapshots)
+}
+
+// checkClaimsKept checks that the claims from which
+// the snapshots were taken have not been replaced.
+func (s *fakeClusterSuite) checkClaimsKept(c *gc.C, snapshots []caas.VolumeSnapshot) {
+	for _, snapshot := range snapshots {
+		claim, err := s.cluster.Clientset.CoreV1().PersistentVolumeClaims("test").Get(snapshot.ClaimName, v1.GetOptions{})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(claim.Spec.DataSource, gc.IsNil)
+	}
+}
+
+func (s *fakeClusterSuite) TestSnapshotStorageDeletesPartialBackup(c *gc.C) {
+	s.ensureService(c, 2)
+	claims, err := s.cluster.Clientset.CoreV1().PersistentVolumeClaims("test").List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Items, gc.HasLen, 2)
+
+	// A snapshot of the second claim already exists, so the backup fails.
+	existing := &unstructured.Unstructured{}
+	existing.SetAPIVersion("snapshot.storage.k8s.io/v1beta1")
+	existing.SetKind("VolumeSnapshot")
+	existing.SetName(claims.Items[1].Name + "-20200102-030405")
+	snapshots := s.cluster.Dynamic.Resource(volumeSnapshotResource).Namespace("test")
+	_, err = snapshots.Create(existing, v1.CreateOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.broker.(caas.StorageSnapshotter).SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, gc.ErrorMatches, `snapshotting volume claim ".*": volume snapshot ".*" already exists`)
+
+	// The snapshots taken for the backup are deleted.
+	list, err := snapshots.List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Items, gc.HasLen, 1)
+	c.Assert(list.Items[0].GetName(), gc.Equals, existing.GetName())
+}
+
+func (s *fakeClusterSuite) TestDeleteSnapshots(c *gc.C) {
+	s.ensureService(c, 1)
+	snapshotter := s.broker.(caas.StorageSnapshotter)
+	snapshots, err := snapshotter.SnapshotStorage("app-name", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+
+	err = snapshotter.DeleteSnapshots(append(snapshots, caas.VolumeSnapshot{SnapshotName: "gone"}))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.cluster.Dynamic.Resource(volumeSnapshotResource).Namespace("test").Get(snapshots[0].SnapshotName, v1.GetOptions{})
+	c.Assert(err, gc.ErrorMatches, `.* not found`)
+}
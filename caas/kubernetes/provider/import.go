@@ -0,0 +1,131 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// annotationImported marks the resources of workloads which
+// were imported into a model rather than created by Juju.
+var annotationImported = jujuAnnotationKey("imported")
+
+// ImportWorkload is part of the caas.WorkloadImporter interface.
+// Juju finds the resources of an application by their labels, so the
+// deployment or stateful set, its pod template and any service of the
+// same name are labelled with the application name. Changing the pod
+// template rolls out new pods, which are then reported as the units of
+// the application.
+func (k *kubernetesClient) ImportWorkload(name string, deploymentType caas.DeploymentType) (int, error) {
+	var replicas *int32
+	switch deploymentType {
+	case caas.DeploymentStateful:
+		ss, err := k.getStatefulSet(name)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if err := k.importObjectMeta(name, &ss.ObjectMeta); err != nil {
+			return 0, errors.Annotatef(err, "stateful set %q", name)
+		}
+		ss.Spec.Template.Labels = AppendLabels(ss.Spec.Template.Labels, LabelsForApp(name))
+		if _, err := k.client().AppsV1().StatefulSets(k.namespace).Update(ss); err != nil {
+			return 0, errors.Annotatef(err, "updating stateful set %q", name)
+		}
+		replicas = ss.Spec.Replicas
+	case caas.DeploymentStateless:
+		deployment, err := k.getDeployment(name)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if err := k.importObjectMeta(name, &deployment.ObjectMeta); err != nil {
+			return 0, errors.Annotatef(err, "deployment %q", name)
+		}
+		deployment.Spec.Template.Labels = AppendLabels(deployment.Spec.Template.Labels, LabelsForApp(name))
+		if _, err := k.client().AppsV1().Deployments(k.namespace).Update(deployment); err != nil {
+			return 0, errors.Annotatef(err, "updating deployment %q", name)
+		}
+		replicas = deployment.Spec.Replicas
+	default:
+		return 0, errors.NotSupportedf("importing %q workloads", deploymentType)
+	}
+	if err := k.importService(name); err != nil {
+		return 0, errors.Trace(err)
+	}
+	if replicas == nil {
+		// The cluster runs a single replica by default.
+		return 1, nil
+	}
+	return int(*replicas), nil
+}
+
+// importService labels and annotates the service with the
+// specified name, if there is one.
+func (k *kubernetesClient) importService(name string) error {
+	services := k.client().CoreV1().Services(k.namespace)
+	svc, err := services.Get(name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.importObjectMeta(name, &svc.ObjectMeta); err != nil {
+		return errors.Annotatef(err, "service %q", name)
+	}
+	_, err = services.Update(svc)
+	return errors.Annotatef(err, "updating service %q", name)
+}
+
+// importObjectMeta adds the labels and annotations of the resources of
+// the named application. Resources managed by another application can
+// not be imported, but importing a resource again is allowed so that a
+// failed import can be retried.
+func (k *kubernetesClient) importObjectMeta(appName string, meta *v1.ObjectMeta) error {
+	if current, ok := meta.Labels[labelApplication]; ok {
+		if current != appName || meta.Annotations[annotationImported] != "true" {
+			return errors.AlreadyExistsf("resource managed by application %q", current)
+		}
+	}
+	meta.Labels = AppendLabels(meta.Labels, LabelsForApp(appName))
+	meta.Annotations = k8sannotations.New(meta.Annotations).
+		Merge(k.annotations).
+		Add(annotationImported, "true").
+		ToMap()
+	return nil
+}
+
+// ScaleImportedService is part of the caas.WorkloadImporter interface.
+// An imported workload keeps the name it was imported with, which is
+// the application's name, rather than the name Juju would give it.
+func (k *kubernetesClient) ScaleImportedService(appName string, scale int) error {
+	replicas := int32(scale)
+	ss, err := k.getStatefulSet(appName)
+	if err == nil {
+		if !isImported(ss.ObjectMeta) {
+			return errors.NotFoundf("imported workload %q", appName)
+		}
+		ss.Spec.Replicas = &replicas
+		_, err = k.client().AppsV1().StatefulSets(k.namespace).Update(ss)
+		return errors.Trace(err)
+	} else if !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	deployment, err := k.getDeployment(appName)
+	if errors.IsNotFound(err) || (err == nil && !isImported(deployment.ObjectMeta)) {
+		return errors.NotFoundf("imported workload %q", appName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	deployment.Spec.Replicas = &replicas
+	_, err = k.client().AppsV1().Deployments(k.namespace).Update(deployment)
+	return errors.Trace(err)
+}
+
+func isImported(meta v1.ObjectMeta) bool {
+	return meta.Annotations[annotationImported] == "true"
+}
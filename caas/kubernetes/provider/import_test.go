@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) importedAnnotations() map[string]string {
+	annotations := s.broker.GetAnnotations().Copy()
+	annotations["juju.io/imported"] = "true"
+	return annotations.ToMap()
+}
+
+func (s *K8sBrokerSuite) TestImportWorkloadDeployment(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	replicas := int32(3)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"app": "web"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"app": "web"}},
+			},
+		},
+	}
+	imported := *deployment
+	imported.ObjectMeta = v1.ObjectMeta{
+		Name:        "app-name",
+		Labels:      map[string]string{"app": "web", "juju-app": "app-name"},
+		Annotations: s.importedAnnotations(),
+	}
+	imported.Spec.Template.ObjectMeta = v1.ObjectMeta{
+		Labels: map[string]string{"app": "web", "juju-app": "app-name"},
+	}
+	svc := &core.Service{ObjectMeta: v1.ObjectMeta{Name: "app-name"}}
+	importedSvc := &core.Service{ObjectMeta: v1.ObjectMeta{
+		Name:        "app-name",
+		Labels:      map[string]string{"juju-app": "app-name"},
+		Annotations: s.importedAnnotations(),
+	}}
+	gomock.InOrder(
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).Return(deployment, nil),
+		s.mockDeployments.EXPECT().Update(&imported).Return(&imported, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Return(svc, nil),
+		s.mockServices.EXPECT().Update(importedSvc).Return(importedSvc, nil),
+	)
+
+	units, err := s.broker.ImportWorkload("app-name", caas.DeploymentStateless)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 3)
+}
+
+func (s *K8sBrokerSuite) TestImportWorkloadStatefulSetWithoutService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ss := &appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Name: "app-name"}}
+	imported := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: s.importedAnnotations(),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{Labels: map[string]string{"juju-app": "app-name"}},
+			},
+		},
+	}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).Return(ss, nil),
+		s.mockStatefulSets.EXPECT().Update(imported).Return(imported, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).Return(nil, s.k8sNotFoundError()),
+	)
+
+	units, err := s.broker.ImportWorkload("app-name", caas.DeploymentStateful)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.Equals, 1)
+}
+
+func (s *K8sBrokerSuite) TestImportWorkloadManagedByJuju(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ss := &appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{
+		Name:   "app-name",
+		Labels: map[string]string{"juju-app": "app-name"},
+	}}
+	s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).Return(ss, nil)
+
+	_, err := s.broker.ImportWorkload("app-name", caas.DeploymentStateful)
+	c.Assert(err, gc.ErrorMatches, `stateful set "app-name": resource managed by application "app-name" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *K8sBrokerSuite) TestImportWorkloadNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).Return(nil, s.k8sNotFoundError())
+
+	_, err := s.broker.ImportWorkload("app-name", caas.DeploymentStateless)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *K8sBrokerSuite) TestImportWorkloadDaemonNotSupported(c *gc.C) {
+	_, err := s.broker.ImportWorkload("app-name", caas.DeploymentDaemon)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *K8sBrokerSuite) TestScaleImportedService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	deployment := &appsv1.Deployment{ObjectMeta: v1.ObjectMeta{
+		Name:        "app-name",
+		Annotations: s.importedAnnotations(),
+	}}
+	replicas := int32(2)
+	scaled := *deployment
+	scaled.Spec.Replicas = &replicas
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).Return(deployment, nil),
+		s.mockDeployments.EXPECT().Update(&scaled).Return(&scaled, nil),
+	)
+
+	err := s.broker.ScaleImportedService("app-name", 2)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestScaleImportedServiceNotImported(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ss := &appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Name: "app-name"}}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).Return(ss, nil),
+	)
+
+	err := s.broker.ScaleImportedService("app-name", 2)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -98,6 +98,8 @@ func (s *K8sBrokerSuite) assertIngressResources(c *gc.C, IngressResources []k8ss
 				Return(statefulSetArg, nil),
 			s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 				Return(nil, nil),
+			s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+				Return(s.k8sNotFoundError()),
 		}...)
 	}
 	gomock.InOrder(assertCalls...)
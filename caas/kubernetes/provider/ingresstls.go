@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// certManagerIssuerAnnotation tells cert-manager which cluster
+	// issuer to use for the certificates of an ingress.
+	certManagerIssuerAnnotation = "cert-manager.io/cluster-issuer"
+)
+
+// ingressTLS holds the TLS settings for the ingress
+// created when an application is exposed.
+type ingressTLS struct {
+	issuer string
+	secret string
+}
+
+// ingressTLSConfig returns the TLS settings in the application config,
+// or nil if TLS is not configured.
+func ingressTLSConfig(config application.ConfigAttributes) (*ingressTLS, error) {
+	result := &ingressTLS{
+		issuer: config.GetString(IngressTLSIssuerKey, ""),
+		secret: config.GetString(IngressTLSSecretKey, ""),
+	}
+	if result.issuer == "" && result.secret == "" {
+		return nil, nil
+	}
+	if result.issuer != "" && result.secret != "" {
+		return nil, errors.NotValidf("both %q and %q", IngressTLSIssuerKey, IngressTLSSecretKey)
+	}
+	return result, nil
+}
+
+// ValidateIngressTLSConfig returns an error if the
+// application config holds invalid ingress TLS settings.
+func ValidateIngressTLSConfig(config application.ConfigAttributes) error {
+	_, err := ingressTLSConfig(config)
+	return errors.Trace(err)
+}
+
+// secretName returns the name of the secret holding the certificate. When
+// an issuer is used, the secret is managed by cert-manager rather than Juju.
+func (t *ingressTLS) secretName(deploymentName string) string {
+	if t.issuer != "" {
+		return deploymentName + "-issuer-tls"
+	}
+	return t.secret
+}
+
+// ingressTLSSecretName returns the name of the secret Juju
+// creates to hold a TLS certificate provided by the user.
+func ingressTLSSecretName(deploymentName string) string {
+	return deploymentName + "-tls"
+}
+
+// ingressPath returns the http path at which an exposed application is served.
+func ingressPath(appName string, config application.ConfigAttributes) string {
+	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
+	if httpPath == "$appname" {
+		httpPath = appName
+	}
+	if !strings.HasPrefix(httpPath, "/") {
+		httpPath = "/" + httpPath
+	}
+	return httpPath
+}
+
+// ExternalURL returns the URL at which the specified exposed application
+// can be reached, or "" if the application has no external hostname.
+func ExternalURL(appName string, config application.ConfigAttributes) string {
+	host := config.GetString(caas.JujuExternalHostNameKey, "")
+	if host == "" {
+		return ""
+	}
+	scheme := "http"
+	if tlsConfig, err := ingressTLSConfig(config); err == nil && tlsConfig != nil {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s%s", scheme, host, ingressPath(appName, config))
+}
+
+// SetIngressTLSSecret is part of the caas.IngressTLSSecretSetter interface.
+// The secret is updated when the certificate is rotated, and is removed with
+// the application or once its config refers to another secret or an issuer.
+func (k *kubernetesClient) SetIngressTLSSecret(appName string, certificate, key []byte) (string, error) {
+	if _, err := tls.X509KeyPair(certificate, key); err != nil {
+		return "", errors.NewNotValid(err, "TLS certificate and key")
+	}
+	secret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        ingressTLSSecretName(k.deploymentName(appName, true)),
+			Namespace:   k.namespace,
+			Labels:      k.getSecretLabels(appName),
+			Annotations: k.annotations.ToMap(),
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			core.TLSCertKey:       certificate,
+			core.TLSPrivateKeyKey: key,
+		},
+	}
+	if _, err := k.ensureSecret(secret); err != nil {
+		return "", errors.Annotate(err, "ensuring ingress TLS secret")
+	}
+	return secret.Name, nil
+}
+
+// ensureIngressTLS configures the ingress to terminate TLS for the host,
+// and removes the secret Juju created for a certificate provided by the
+// user once the application config no longer refers to it.
+func (k *kubernetesClient) ensureIngressTLS(
+	deploymentName, host string, tlsConfig *ingressTLS, ingress *v1beta1.Ingress,
+) error {
+	jujuSecretName := ingressTLSSecretName(deploymentName)
+	if tlsConfig == nil || tlsConfig.secretName(deploymentName) != jujuSecretName {
+		if err := k.deleteSecret(jujuSecretName, ""); err != nil {
+			return errors.Annotate(err, "deleting ingress TLS secret")
+		}
+	}
+	if tlsConfig == nil {
+		return nil
+	}
+	ingress.Spec.TLS = []v1beta1.IngressTLS{{
+		Hosts:      []string{host},
+		SecretName: tlsConfig.secretName(deploymentName),
+	}}
+	if tlsConfig.issuer != "" {
+		ingress.Annotations[certManagerIssuerAnnotation] = tlsConfig.issuer
+	}
+	return nil
+}
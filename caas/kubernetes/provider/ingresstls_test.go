@@ -0,0 +1,277 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/testing"
+)
+
+func (s *K8sBrokerSuite) exposedService() *core.Service {
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) exposeIngress(tls []extensionsv1beta1.IngressTLS, extraAnnotations map[string]string) *extensionsv1beta1.Ingress {
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":  "",
+				"ingress.kubernetes.io/ssl-redirect":    "false",
+				"kubernetes.io/ingress.class":           "nginx",
+				"kubernetes.io/ingress.allow-http":      "false",
+				"ingress.kubernetes.io/ssl-passthrough": "false",
+			},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{{
+							Path: "/",
+							Backend: extensionsv1beta1.IngressBackend{
+								ServiceName: "app-name", ServicePort: intstr.FromInt(8080)},
+						}}},
+				}}},
+			TLS: tls,
+		},
+	}
+	for k, v := range extraAnnotations {
+		ingress.Annotations[k] = v
+	}
+	return ingress
+}
+
+func (s *K8sBrokerSuite) tlsSecret(cert, key string) *core.Secret {
+	return &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name-tls",
+			Namespace:   "test",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: s.broker.GetAnnotations().ToMap(),
+		},
+		Type: core.SecretTypeTLS,
+		Data: map[string][]byte{
+			"tls.crt": []byte(cert),
+			"tls.key": []byte(key),
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithoutTLS(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := s.exposeIngress(nil, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(s.exposedService(), nil),
+		s.mockSecrets.EXPECT().Delete("app-name-tls", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingress).Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname": "example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := s.exposeIngress([]extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "app-name-tls",
+	}}, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(s.exposedService(), nil),
+		s.mockIngressInterface.EXPECT().Create(ingress).Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-tls-secret": "app-name-tls",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithOwnSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := s.exposeIngress([]extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "my-cert",
+	}}, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(s.exposedService(), nil),
+		s.mockSecrets.EXPECT().Delete("app-name-tls", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingress).Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-tls-secret": "my-cert",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestSetIngressTLSSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	secret := s.tlsSecret(testing.ServerCert, testing.ServerKey)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secret).Return(secret, nil),
+	)
+
+	name, err := s.broker.SetIngressTLSSecret("app-name", []byte(testing.ServerCert), []byte(testing.ServerKey))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "app-name-tls")
+}
+
+func (s *K8sBrokerSuite) TestSetIngressTLSSecretRotatesCertificate(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	secret := s.tlsSecret(testing.ServerCert, testing.ServerKey)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secret).Return(nil, s.k8sAlreadyExistsError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app=app-name"}).
+			Return(&core.SecretList{Items: []core.Secret{*secret}}, nil),
+		s.mockSecrets.EXPECT().Update(secret).Return(secret, nil),
+	)
+
+	name, err := s.broker.SetIngressTLSSecret("app-name", []byte(testing.ServerCert), []byte(testing.ServerKey))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(name, gc.Equals, "app-name-tls")
+}
+
+func (s *K8sBrokerSuite) TestSetIngressTLSSecretInvalidKeyPair(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	_, err := s.broker.SetIngressTLSSecret("app-name", []byte(testing.ServerCert), []byte(testing.CAKey))
+	c.Assert(err, gc.ErrorMatches, `TLS certificate and key: .*`)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceWithIssuer(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ingress := s.exposeIngress([]extensionsv1beta1.IngressTLS{{
+		Hosts:      []string{"example.com"},
+		SecretName: "app-name-issuer-tls",
+	}}, map[string]string{
+		"cert-manager.io/cluster-issuer": "letsencrypt",
+	})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(s.exposedService(), nil),
+		s.mockSecrets.EXPECT().Delete("app-name-tls", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+		s.mockIngressInterface.EXPECT().Create(ingress).Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-tls-issuer": "letsencrypt",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestExposeServiceInvalidTLSConfig(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	err := s.broker.ExposeService("app-name", nil, application.ConfigAttributes{
+		"juju-external-hostname":        "example.com",
+		"kubernetes-ingress-tls-issuer": "letsencrypt",
+		"kubernetes-ingress-tls-secret": "app-name-tls",
+	})
+	c.Assert(err, gc.ErrorMatches, `both "kubernetes-ingress-tls-issuer" and "kubernetes-ingress-tls-secret" not valid`)
+}
+
+func (s *K8sBrokerSuite) TestUnexposeServiceKeepsTLSSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(nil),
+	)
+
+	err := s.broker.UnexposeService("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+type ingressTLSSuite struct{}
+
+var _ = gc.Suite(&ingressTLSSuite{})
+
+func (*ingressTLSSuite) TestExternalURL(c *gc.C) {
+	for i, test := range []struct {
+		config application.ConfigAttributes
+		url    string
+	}{{
+		config: application.ConfigAttributes{},
+		url:    "",
+	}, {
+		config: application.ConfigAttributes{
+			"juju-external-hostname": "example.com",
+		},
+		url: "http://example.com/",
+	}, {
+		config: application.ConfigAttributes{
+			"juju-external-hostname":        "example.com",
+			"juju-application-path":         "$appname",
+			"kubernetes-ingress-tls-issuer": "letsencrypt",
+		},
+		url: "https://example.com/gitlab",
+	}, {
+		config: application.ConfigAttributes{
+			"juju-external-hostname":        "example.com",
+			"kubernetes-ingress-tls-secret": "gitlab-tls",
+		},
+		url: "https://example.com/",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(provider.ExternalURL("gitlab", test.config), gc.Equals, test.url)
+	}
+}
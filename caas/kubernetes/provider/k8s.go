@@ -177,6 +177,9 @@ type kubernetesClient struct {
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/apiextensions_mock.go k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1beta1 ApiextensionsV1beta1Interface,CustomResourceDefinitionInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/apiextensionsclientset_mock.go -mock_names=Interface=MockApiExtensionsClientInterface k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset Interface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/discovery_mock.go k8s.io/client-go/discovery DiscoveryInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/autoscalingv2beta2_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta2 AutoscalingV2beta2Interface,HorizontalPodAutoscalerInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
+//go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/dynamic_mock.go -mock_names=Interface=MockDynamicInterface k8s.io/client-go/dynamic Interface,ResourceInterface,NamespaceableResourceInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/admissionregistration_mock.go k8s.io/client-go/kubernetes/typed/admissionregistration/v1beta1  AdmissionregistrationV1beta1Interface,MutatingWebhookConfigurationInterface,ValidatingWebhookConfigurationInterface
 //go:generate go run github.com/golang/mock/mockgen -package mocks -destination mocks/serviceaccountinformer_mock.go k8s.io/client-go/informers/core/v1 ServiceAccountInformer
@@ -653,9 +656,11 @@ func (k *kubernetesClient) GetService(appName string, mode caas.DeploymentMode,
 		return nil, errors.Trace(err)
 	}
 	var result caas.Service
+	var serviceName string
 	// We may have the stateful set or deployment but service not done yet.
 	if len(servicesList.Items) > 0 {
 		service := servicesList.Items[0]
+		serviceName = service.Name
 		result.Id = string(service.GetUID())
 		result.Addresses = getSvcAddresses(&service, includeClusterIP)
 	}
@@ -684,6 +689,9 @@ func (k *kubernetesClient) GetService(appName string, mode caas.DeploymentMode,
 			Status:  ssStatus,
 			Message: message,
 		}
+		if err := k.addServiceWarning(&result, serviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return &result, nil
 	}
 
@@ -707,6 +715,9 @@ func (k *kubernetesClient) GetService(appName string, mode caas.DeploymentMode,
 			Status:  deployStatus,
 			Message: message,
 		}
+		if err := k.addServiceWarning(&result, serviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return &result, nil
 	}
 
@@ -730,10 +741,30 @@ func (k *kubernetesClient) GetService(appName string, mode caas.DeploymentMode,
 			Status:  dsStatus,
 			Message: message,
 		}
+		if err := k.addServiceWarning(&result, serviceName); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	return &result, nil
 }
 
+// addServiceWarning reports the most recent warning event for the
+// named service, eg a load balancer which could not be provisioned,
+// if the application is still waiting and has no better message.
+func (k *kubernetesClient) addServiceWarning(result *caas.Service, serviceName string) error {
+	if serviceName == "" || result.Status.Status != status.Waiting || result.Status.Message != "" {
+		return nil
+	}
+	events, err := k.getEvents(serviceName, "Service")
+	if err != nil {
+		return errors.Annotatef(err, "getting events for service %s", serviceName)
+	}
+	if warning := mostRecentWarning(events); warning != nil {
+		result.Status.Message = warningMessage(warning)
+	}
+	return nil
+}
+
 // DeleteService deletes the specified service with all related resources.
 func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	logger.Debugf("deleting application %s", appName)
@@ -794,6 +825,14 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 		return errors.Trace(err)
 	}
 
+	if err := k.deleteNetworkPolicies(appName); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := k.deletePodDisruptionBudgets(appName); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := k.deleteDaemonSets(appName); err != nil {
 		return errors.Trace(err)
 	}
@@ -875,38 +914,42 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 		var nodeSelectorTerm core.NodeSelectorTerm
 		updateSelectorTerms(&nodeSelectorTerm, affinityTags, core.NodeSelectorOpIn)
 		updateSelectorTerms(&nodeSelectorTerm, antiAffinityTags, core.NodeSelectorOpNotIn)
-		pod.Affinity = &core.Affinity{
-			NodeAffinity: &core.NodeAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-					NodeSelectorTerms: []core.NodeSelectorTerm{nodeSelectorTerm},
-				},
-			},
-		}
+		addNodeSelectorRequirements(pod, nodeSelectorTerm.MatchExpressions...)
 	}
 	if cons.Zones != nil {
-		zones := *cons.Zones
-		affinity := pod.Affinity
-		if affinity == nil {
-			affinity = &core.Affinity{
-				NodeAffinity: &core.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-						NodeSelectorTerms: []core.NodeSelectorTerm{{}},
-					},
-				},
-			}
-			pod.Affinity = affinity
-		}
-		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
-		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
-			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
-				Operator: core.NodeSelectorOpIn,
-				Values:   zones,
-			})
+		addNodeSelectorRequirements(pod, core.NodeSelectorRequirement{
+			Key:      "failure-domain.beta.kubernetes.io/zone",
+			Operator: core.NodeSelectorOpIn,
+			Values:   *cons.Zones,
+		})
 	}
 	return nil
 }
 
+// addNodeSelectorRequirements adds the requirements to every required node
+// selector term of the pod's node affinity, so that they also apply to any
+// node affinity specified by the charm.
+func addNodeSelectorRequirements(pod *core.PodSpec, reqs ...core.NodeSelectorRequirement) {
+	if pod.Affinity == nil {
+		pod.Affinity = &core.Affinity{}
+	}
+	if pod.Affinity.NodeAffinity == nil {
+		pod.Affinity.NodeAffinity = &core.NodeAffinity{}
+	}
+	nodeAffinity := pod.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &core.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []core.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		term := &selector.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, reqs...)
+	}
+}
+
 const applyRawSpecTimeoutSeconds = 20
 
 func (k *kubernetesClient) applyRawK8sSpec(
@@ -1198,6 +1241,16 @@ func (k *kubernetesClient) ensureService(
 		// This should never happened because we have validated both in this method and in `charm.v6`.
 		return errors.NotSupportedf("deployment type %q", params.Deployment.DeploymentType)
 	}
+
+	// Any pod disruption budget which has been removed
+	// from the spec is deleted.
+	if workloadSpec.PodDisruptionBudget != nil {
+		if err := k.ensurePodDisruptionBudget(appName, deploymentName, annotations.Copy(), workloadSpec.PodDisruptionBudget); err != nil {
+			return errors.Annotate(err, "creating or updating pod disruption budget")
+		}
+	} else if err := k.deletePodDisruptionBudget(deploymentName); err != nil {
+		return errors.Annotate(err, "deleting pod disruption budget")
+	}
 	return nil
 }
 
@@ -1494,7 +1547,6 @@ func (k *kubernetesClient) configureDaemonSet(
 				Add(annotationKeyApplicationUUID, storageUniqueID).ToMap(),
 		},
 		Spec: apps.DaemonSetSpec{
-			// TODO(caas): DaemonSetUpdateStrategy support.
 			Selector: &v1.LabelSelector{
 				MatchLabels: k.getDaemonSetLabels(appName),
 			},
@@ -1509,6 +1561,11 @@ func (k *kubernetesClient) configureDaemonSet(
 			},
 		},
 	}
+	if workloadSpec.UpdateStrategy != nil {
+		if daemonSet.Spec.UpdateStrategy, err = workloadSpec.UpdateStrategy.ToDaemonSetUpdateStrategy(); err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+	}
 	handlePVC := func(pvc core.PersistentVolumeClaim, mountPath string, readOnly bool) error {
 		cs, err := k.configurePVCForStatelessResource(pvc, mountPath, readOnly, &daemonSet.Spec.Template.Spec)
 		cleanUps = append(cleanUps, cs...)
@@ -1558,7 +1615,6 @@ func (k *kubernetesClient) configureDeployment(
 				Add(annotationKeyApplicationUUID, storageUniqueID).ToMap(),
 		},
 		Spec: apps.DeploymentSpec{
-			// TODO(caas): DeploymentStrategy support.
 			Replicas:             replicas,
 			RevisionHistoryLimit: int32Ptr(deploymentRevisionHistoryLimit),
 			Selector: &v1.LabelSelector{
@@ -1574,7 +1630,15 @@ func (k *kubernetesClient) configureDeployment(
 			},
 		},
 	}
+	if workloadSpec.UpdateStrategy != nil {
+		if deployment.Spec.Strategy, err = workloadSpec.UpdateStrategy.ToDeploymentStrategy(); err != nil {
+			return cleanUps, errors.Trace(err)
+		}
+	}
 	handlePVC := func(pvc core.PersistentVolumeClaim, mountPath string, readOnly bool) error {
+		// Label the claim as the stateful set controller does
+		// for the claims it creates, so it can be selected.
+		pvc.Labels = AppendLabels(pvc.Labels, LabelsForApp(appName))
 		cs, err := k.configurePVCForStatelessResource(pvc, mountPath, readOnly, &deployment.Spec.Template.Spec)
 		cleanUps = append(cleanUps, cs...)
 		return errors.Trace(err)
@@ -1862,12 +1926,10 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 	ingressSSLRedirect := config.GetBool(ingressSSLRedirectKey, defaultIngressSSLRedirect)
 	ingressSSLPassthrough := config.GetBool(ingressSSLPassthroughKey, defaultIngressSSLPassthrough)
 	ingressAllowHTTP := config.GetBool(ingressAllowHTTPKey, defaultIngressAllowHTTPKey)
-	httpPath := config.GetString(caas.JujuApplicationPath, caas.JujuDefaultApplicationPath)
-	if httpPath == "$appname" {
-		httpPath = appName
-	}
-	if !strings.HasPrefix(httpPath, "/") {
-		httpPath = "/" + httpPath
+	httpPath := ingressPath(appName, config)
+	tlsConfig, err := ingressTLSConfig(config)
+	if err != nil {
+		return errors.Trace(err)
 	}
 
 	deploymentName := k.deploymentName(appName, true)
@@ -1903,6 +1965,9 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 				}}},
 		},
 	}
+	if err := k.ensureIngressTLS(deploymentName, host, tlsConfig, spec); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(caas): refactor juju expose to solve potential conflict with ingress definition in podspec.
 	// https://bugs.launchpad.net/juju/+bug/1854123
 	_, err = k.ensureIngress(appName, spec, true)
@@ -1913,6 +1978,8 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
 	deploymentName := k.deploymentName(appName, true)
+	// Any ingress TLS secret is kept, to be used again
+	// if the application is exposed again.
 	return errors.Trace(k.deleteIngress(deploymentName, ""))
 }
 
@@ -2221,15 +2288,24 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 		}
 	}
 
-	if statusMessage == "" {
+	// A pod which is not running may be waiting to be scheduled or
+	// unable to pull its images; any warning events explain why, so
+	// are more relevant than the pod conditions.
+	pending := !terminated && pod.Status.Phase != core.PodRunning && pod.Status.Message == ""
+	if statusMessage == "" || pending {
 		// If there are any events for this pod we can use the
 		// most recent to set the status.
 		eventList, err := k.getEvents(pod.Name, "Pod")
 		if err != nil {
 			return "", "", time.Time{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		if warning := mostRecentWarning(eventList); pending && warning != nil {
+			statusMessage = warningMessage(warning)
+			if t := eventTime(*warning); !t.IsZero() {
+				since = t
+			}
+		} else if count := len(eventList); statusMessage == "" && count > 0 {
+			// Take the most recent event.
 			statusMessage = eventList[count-1].Message
 		}
 	}
@@ -2278,14 +2354,14 @@ func (k *kubernetesClient) getStatusFromEvents(name, kind string, jujuStatus sta
 		return "", "", errors.Trace(err)
 	}
 	var statusMessage string
-	// Take the most recent event.
-	if count := len(events); count > 0 {
-		evt := events[count-1]
-		if jujuStatus == "" {
-			if evt.Type == core.EventTypeWarning && evt.Reason == "FailedCreate" {
-				jujuStatus = status.Blocked
-				statusMessage = evt.Message
-			}
+	if jujuStatus != status.Waiting {
+		return statusMessage, jujuStatus, nil
+	}
+	// The workload is not ready yet, so report why if we can.
+	if warning := mostRecentWarning(events); warning != nil {
+		statusMessage = warningMessage(warning)
+		if warning.Reason == "FailedCreate" {
+			jujuStatus = status.Blocked
 		}
 	}
 	return statusMessage, jujuStatus, nil
@@ -2365,6 +2441,8 @@ type workloadSpec struct {
 	MutatingWebhookConfigurations   []k8sspecs.K8sMutatingWebhookSpec
 	ValidatingWebhookConfigurations []k8sspecs.K8sValidatingWebhookSpec
 	IngressResources                []k8sspecs.K8sIngressSpec
+	PodDisruptionBudget             *k8sspecs.PodDisruptionBudgetSpec
+	UpdateStrategy                  *k8sspecs.UpdateStrategy
 }
 
 func processContainers(deploymentName string, podSpec *specs.PodSpec, spec *core.PodSpec) error {
@@ -2443,6 +2521,8 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 			spec.MutatingWebhookConfigurations = k8sResources.MutatingWebhookConfigurations
 			spec.ValidatingWebhookConfigurations = k8sResources.ValidatingWebhookConfigurations
 			spec.IngressResources = k8sResources.IngressResources
+			spec.PodDisruptionBudget = k8sResources.PodDisruptionBudget
+			spec.UpdateStrategy = k8sResources.UpdateStrategy
 			if k8sResources.Pod != nil {
 				spec.Pod.RestartPolicy = k8sResources.Pod.RestartPolicy
 				spec.Pod.ActiveDeadlineSeconds = k8sResources.Pod.ActiveDeadlineSeconds
@@ -2452,6 +2532,10 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 				spec.Pod.DNSPolicy = k8sResources.Pod.DNSPolicy
 				spec.Pod.HostNetwork = k8sResources.Pod.HostNetwork
 				spec.Pod.HostPID = k8sResources.Pod.HostPID
+				spec.Pod.Affinity = k8sResources.Pod.Affinity.DeepCopy()
+				spec.Pod.Tolerations = k8sResources.Pod.Tolerations
+				spec.Pod.TopologySpreadConstraints = k8sResources.Pod.TopologySpreadConstraints
+				spec.Pod.PriorityClassName = k8sResources.Pod.PriorityClassName
 			}
 			spec.ServiceAccounts = append(spec.ServiceAccounts, &k8sResources.K8sRBACResources)
 		}
@@ -21,6 +21,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -1926,6 +1927,18 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			v1.ListOptions{LabelSelector: "juju-app=test"},
 		).Return(nil),
 
+		// delete all network policies.
+		s.mockNetworkPolicies.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
+
+		// delete all pod disruption budgets.
+		s.mockPodDisruptionBudgets.EXPECT().DeleteCollection(
+			s.deleteOptions(v1.DeletePropagationForeground, ""),
+			v1.ListOptions{LabelSelector: "juju-app=test"},
+		).Return(nil),
+
 		// delete all daemon set resources.
 		s.mockDaemonSets.EXPECT().DeleteCollection(
 			s.deleteOptions(v1.DeletePropagationForeground, ""),
@@ -2047,6 +2060,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2285,6 +2300,8 @@ password: shhhh`[1:],
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2846,6 +2863,8 @@ password: shhhh`[1:],
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2933,6 +2952,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -2950,6 +2971,166 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceStatefulWithSchedulingAndDisruptionBudget(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	minAvailable := intstr.FromInt(1)
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Pod: &k8sspecs.PodSpec{
+				PriorityClassName: "high-priority",
+				Affinity: &core.Affinity{
+					NodeAffinity: &core.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+							NodeSelectorTerms: []core.NodeSelectorTerm{{
+								MatchExpressions: []core.NodeSelectorRequirement{{
+									Key:      "disktype",
+									Operator: core.NodeSelectorOpIn,
+									Values:   []string{"ssd"},
+								}},
+							}},
+						},
+					},
+				},
+				Tolerations: []core.Toleration{{
+					Key:      "dedicated",
+					Operator: core.TolerationOpEqual,
+					Value:    "app-name",
+					Effect:   core.TaintEffectNoSchedule,
+				}},
+				TopologySpreadConstraints: []core.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       "kubernetes.io/hostname",
+					WhenUnsatisfiable: core.DoNotSchedule,
+				}},
+			},
+			PodDisruptionBudget: &k8sspecs.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+			},
+			UpdateStrategy: &k8sspecs.UpdateStrategy{
+				Type: "RollingUpdate",
+				RollingUpdate: &k8sspecs.RollingUpdateSpec{
+					Partition: int32Ptr(1),
+				},
+			},
+		},
+	}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(workloadSpec)
+	// The zones constraint is added to the node affinity from the charm.
+	podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions = []core.NodeSelectorRequirement{{
+		Key:      "disktype",
+		Operator: core.NodeSelectorOpIn,
+		Values:   []string{"ssd"},
+	}, {
+		Key:      "failure-domain.beta.kubernetes.io/zone",
+		Operator: core.NodeSelectorOpIn,
+		Values:   []string{"a", "b"},
+	}}
+	c.Assert(podSpec.PriorityClassName, gc.Equals, "high-priority")
+	c.Assert(podSpec.Tolerations, gc.HasLen, 1)
+	c.Assert(podSpec.TopologySpreadConstraints, gc.HasLen, 1)
+
+	numUnits := int32(2)
+	statefulSetArg := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju-app-uuid":      "appuuid",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			RevisionHistoryLimit: int32Ptr(0),
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"juju.io/controller":                       testing.ControllerTag.Id(),
+					},
+				},
+				Spec: podSpec,
+			},
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+					Partition: int32Ptr(1),
+				},
+			},
+			PodManagementPolicy: apps.PodManagementPolicyType("Parallel"),
+			ServiceName:         "app-name-endpoints",
+		},
+	}
+	pdbArg := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"juju.io/controller": testing.ControllerTag.Id()},
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+		},
+	}
+
+	serviceArg := *basicServiceArg
+	serviceArg.Spec.Type = core.ServiceTypeClusterIP
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(&serviceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(&serviceArg).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name-endpoints", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicHeadlessServiceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicHeadlessServiceArg).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
+			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Update(pdbArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockPodDisruptionBudgets.EXPECT().Create(pdbArg).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodSpec,
+		Deployment: caas.DeploymentParams{
+			DeploymentType: caas.DeploymentStateful,
+		},
+		OperatorImagePath: "operator/image-path",
+		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
+		Constraints:       constraints.MustParse("zones=a,b"),
+	}
+	err = s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 2, application.ConfigAttributes{
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceCustomType(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -3017,6 +3198,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceCustomType(c *gc.C) {
 			Return(statefulSetArg, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3220,6 +3403,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleCreate(c *gc.
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3390,6 +3575,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewRoleUpdate(c *gc.
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3578,6 +3765,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleCreate
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -3764,6 +3953,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountNewClusterRoleUpdate
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4025,6 +4216,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4295,6 +4488,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4617,6 +4812,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithServiceAccountAndK8sServiceAccount
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4687,6 +4884,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4791,6 +4990,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(deploymentArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4834,7 +5035,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageCreate(c *gc.C
 
 	pvc := &core.PersistentVolumeClaim{
 		ObjectMeta: v1.ObjectMeta{
-			Name: "database-appuuid",
+			Name:   "database-appuuid",
+			Labels: map[string]string{"juju-app": "app-name"},
 			Annotations: map[string]string{
 				"foo":          "bar",
 				"juju-storage": "database",
@@ -4922,6 +5124,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageCreate(c *gc.C
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Create(deploymentArg).
 			Return(deploymentArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -4979,7 +5183,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageUpdate(c *gc.C
 
 	pvc := &core.PersistentVolumeClaim{
 		ObjectMeta: v1.ObjectMeta{
-			Name: "database-appuuid",
+			Name:   "database-appuuid",
+			Labels: map[string]string{"juju-app": "app-name"},
 			Annotations: map[string]string{
 				"foo":          "bar",
 				"juju-storage": "database",
@@ -5069,6 +5274,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithStorageUpdate(c *gc.C
 			Return(pvc, nil),
 		s.mockDeployments.EXPECT().Update(deploymentArg).
 			Return(deploymentArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5232,6 +5439,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageCreate(c *gc.C)
 			Return(pvc, nil),
 		s.mockDaemonSets.EXPECT().Create(daemonSetArg).
 			Return(daemonSetArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5405,6 +5614,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithStorageUpdate(c *gc.C)
 		}).Return(&appsv1.DaemonSetList{Items: []appsv1.DaemonSet{*daemonSetArg}}, nil),
 		s.mockDaemonSets.EXPECT().Update(daemonSetArg).
 			Return(daemonSetArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5531,6 +5742,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsC
 			Return(nil, s.k8sNotFoundError()),
 		s.mockDaemonSets.EXPECT().Create(daemonSetArg).
 			Return(daemonSetArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5651,6 +5864,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDaemonSetWithDevicesAndConstraintsU
 		}).Return(&appsv1.DaemonSetList{Items: []appsv1.DaemonSet{*daemonSetArg}}, nil),
 		s.mockDaemonSets.EXPECT().Update(daemonSetArg).
 			Return(daemonSetArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5729,6 +5944,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(statefulSetArg, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5810,6 +6027,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithConstraints(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5898,6 +6117,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithNodeAffinity(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
@@ -5978,6 +6199,8 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
 		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(nil, nil),
+		s.mockPodDisruptionBudgets.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 	)
 
 	params := &caas.ServiceParams{
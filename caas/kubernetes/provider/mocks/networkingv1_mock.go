@@ -0,0 +1,213 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: k8s.io/client-go/kubernetes/typed/networking/v1 (interfaces: NetworkingV1Interface,NetworkPolicyInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	v1 "k8s.io/api/networking/v1"
+	v10 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v11 "k8s.io/client-go/kubernetes/typed/networking/v1"
+	rest "k8s.io/client-go/rest"
+	reflect "reflect"
+)
+
+// MockNetworkingV1Interface is a mock of NetworkingV1Interface interface
+type MockNetworkingV1Interface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkingV1InterfaceMockRecorder
+}
+
+// MockNetworkingV1InterfaceMockRecorder is the mock recorder for MockNetworkingV1Interface
+type MockNetworkingV1InterfaceMockRecorder struct {
+	mock *MockNetworkingV1Interface
+}
+
+// NewMockNetworkingV1Interface creates a new mock instance
+func NewMockNetworkingV1Interface(ctrl *gomock.Controller) *MockNetworkingV1Interface {
+	mock := &MockNetworkingV1Interface{ctrl: ctrl}
+	mock.recorder = &MockNetworkingV1InterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNetworkingV1Interface) EXPECT() *MockNetworkingV1InterfaceMockRecorder {
+	return m.recorder
+}
+
+// NetworkPolicies mocks base method
+func (m *MockNetworkingV1Interface) NetworkPolicies(arg0 string) v11.NetworkPolicyInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NetworkPolicies", arg0)
+	ret0, _ := ret[0].(v11.NetworkPolicyInterface)
+	return ret0
+}
+
+// NetworkPolicies indicates an expected call of NetworkPolicies
+func (mr *MockNetworkingV1InterfaceMockRecorder) NetworkPolicies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkPolicies", reflect.TypeOf((*MockNetworkingV1Interface)(nil).NetworkPolicies), arg0)
+}
+
+// RESTClient mocks base method
+func (m *MockNetworkingV1Interface) RESTClient() rest.Interface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RESTClient")
+	ret0, _ := ret[0].(rest.Interface)
+	return ret0
+}
+
+// RESTClient indicates an expected call of RESTClient
+func (mr *MockNetworkingV1InterfaceMockRecorder) RESTClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RESTClient", reflect.TypeOf((*MockNetworkingV1Interface)(nil).RESTClient))
+}
+
+// MockNetworkPolicyInterface is a mock of NetworkPolicyInterface interface
+type MockNetworkPolicyInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockNetworkPolicyInterfaceMockRecorder
+}
+
+// MockNetworkPolicyInterfaceMockRecorder is the mock recorder for MockNetworkPolicyInterface
+type MockNetworkPolicyInterfaceMockRecorder struct {
+	mock *MockNetworkPolicyInterface
+}
+
+// NewMockNetworkPolicyInterface creates a new mock instance
+func NewMockNetworkPolicyInterface(ctrl *gomock.Controller) *MockNetworkPolicyInterface {
+	mock := &MockNetworkPolicyInterface{ctrl: ctrl}
+	mock.recorder = &MockNetworkPolicyInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockNetworkPolicyInterface) EXPECT() *MockNetworkPolicyInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockNetworkPolicyInterface) Create(arg0 *v1.NetworkPolicy) (*v1.NetworkPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*v1.NetworkPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Create), arg0)
+}
+
+// Delete mocks base method
+func (m *MockNetworkPolicyInterface) Delete(arg0 string, arg1 *v10.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method
+func (m *MockNetworkPolicyInterface) DeleteCollection(arg0 *v10.DeleteOptions, arg1 v10.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockNetworkPolicyInterfaceMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Get mocks base method
+func (m *MockNetworkPolicyInterface) Get(arg0 string, arg1 v10.GetOptions) (*v1.NetworkPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*v1.NetworkPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method
+func (m *MockNetworkPolicyInterface) List(arg0 v10.ListOptions) (*v1.NetworkPolicyList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1.NetworkPolicyList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockNetworkPolicyInterfaceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).List), arg0)
+}
+
+// Patch mocks base method
+func (m *MockNetworkPolicyInterface) Patch(arg0 string, arg1 types.PatchType, arg2 []byte, arg3 ...string) (*v1.NetworkPolicy, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1.NetworkPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockNetworkPolicyInterface) Update(arg0 *v1.NetworkPolicy) (*v1.NetworkPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(*v1.NetworkPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Update), arg0)
+}
+
+// Watch mocks base method
+func (m *MockNetworkPolicyInterface) Watch(arg0 v10.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockNetworkPolicyInterfaceMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockNetworkPolicyInterface)(nil).Watch), arg0)
+}
@@ -0,0 +1,256 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: k8s.io/client-go/kubernetes/typed/policy/v1beta1 (interfaces: PolicyV1beta1Interface,PodDisruptionBudgetInterface)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	gomock "github.com/golang/mock/gomock"
+	v1beta1 "k8s.io/api/policy/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	v1beta10 "k8s.io/client-go/kubernetes/typed/policy/v1beta1"
+	rest "k8s.io/client-go/rest"
+	reflect "reflect"
+)
+
+// MockPolicyV1beta1Interface is a mock of PolicyV1beta1Interface interface
+type MockPolicyV1beta1Interface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPolicyV1beta1InterfaceMockRecorder
+}
+
+// MockPolicyV1beta1InterfaceMockRecorder is the mock recorder for MockPolicyV1beta1Interface
+type MockPolicyV1beta1InterfaceMockRecorder struct {
+	mock *MockPolicyV1beta1Interface
+}
+
+// NewMockPolicyV1beta1Interface creates a new mock instance
+func NewMockPolicyV1beta1Interface(ctrl *gomock.Controller) *MockPolicyV1beta1Interface {
+	mock := &MockPolicyV1beta1Interface{ctrl: ctrl}
+	mock.recorder = &MockPolicyV1beta1InterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPolicyV1beta1Interface) EXPECT() *MockPolicyV1beta1InterfaceMockRecorder {
+	return m.recorder
+}
+
+// Evictions mocks base method
+func (m *MockPolicyV1beta1Interface) Evictions(arg0 string) v1beta10.EvictionInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Evictions", arg0)
+	ret0, _ := ret[0].(v1beta10.EvictionInterface)
+	return ret0
+}
+
+// Evictions indicates an expected call of Evictions
+func (mr *MockPolicyV1beta1InterfaceMockRecorder) Evictions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Evictions", reflect.TypeOf((*MockPolicyV1beta1Interface)(nil).Evictions), arg0)
+}
+
+// PodDisruptionBudgets mocks base method
+func (m *MockPolicyV1beta1Interface) PodDisruptionBudgets(arg0 string) v1beta10.PodDisruptionBudgetInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PodDisruptionBudgets", arg0)
+	ret0, _ := ret[0].(v1beta10.PodDisruptionBudgetInterface)
+	return ret0
+}
+
+// PodDisruptionBudgets indicates an expected call of PodDisruptionBudgets
+func (mr *MockPolicyV1beta1InterfaceMockRecorder) PodDisruptionBudgets(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodDisruptionBudgets", reflect.TypeOf((*MockPolicyV1beta1Interface)(nil).PodDisruptionBudgets), arg0)
+}
+
+// PodSecurityPolicies mocks base method
+func (m *MockPolicyV1beta1Interface) PodSecurityPolicies() v1beta10.PodSecurityPolicyInterface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PodSecurityPolicies")
+	ret0, _ := ret[0].(v1beta10.PodSecurityPolicyInterface)
+	return ret0
+}
+
+// PodSecurityPolicies indicates an expected call of PodSecurityPolicies
+func (mr *MockPolicyV1beta1InterfaceMockRecorder) PodSecurityPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodSecurityPolicies", reflect.TypeOf((*MockPolicyV1beta1Interface)(nil).PodSecurityPolicies))
+}
+
+// RESTClient mocks base method
+func (m *MockPolicyV1beta1Interface) RESTClient() rest.Interface {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RESTClient")
+	ret0, _ := ret[0].(rest.Interface)
+	return ret0
+}
+
+// RESTClient indicates an expected call of RESTClient
+func (mr *MockPolicyV1beta1InterfaceMockRecorder) RESTClient() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RESTClient", reflect.TypeOf((*MockPolicyV1beta1Interface)(nil).RESTClient))
+}
+
+// MockPodDisruptionBudgetInterface is a mock of PodDisruptionBudgetInterface interface
+type MockPodDisruptionBudgetInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockPodDisruptionBudgetInterfaceMockRecorder
+}
+
+// MockPodDisruptionBudgetInterfaceMockRecorder is the mock recorder for MockPodDisruptionBudgetInterface
+type MockPodDisruptionBudgetInterfaceMockRecorder struct {
+	mock *MockPodDisruptionBudgetInterface
+}
+
+// NewMockPodDisruptionBudgetInterface creates a new mock instance
+func NewMockPodDisruptionBudgetInterface(ctrl *gomock.Controller) *MockPodDisruptionBudgetInterface {
+	mock := &MockPodDisruptionBudgetInterface{ctrl: ctrl}
+	mock.recorder = &MockPodDisruptionBudgetInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPodDisruptionBudgetInterface) EXPECT() *MockPodDisruptionBudgetInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method
+func (m *MockPodDisruptionBudgetInterface) Create(arg0 *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Create(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Create), arg0)
+}
+
+// Delete mocks base method
+func (m *MockPodDisruptionBudgetInterface) Delete(arg0 string, arg1 *v1.DeleteOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Delete), arg0, arg1)
+}
+
+// DeleteCollection mocks base method
+func (m *MockPodDisruptionBudgetInterface) DeleteCollection(arg0 *v1.DeleteOptions, arg1 v1.ListOptions) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) DeleteCollection(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).DeleteCollection), arg0, arg1)
+}
+
+// Get mocks base method
+func (m *MockPodDisruptionBudgetInterface) Get(arg0 string, arg1 v1.GetOptions) (*v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Get), arg0, arg1)
+}
+
+// List mocks base method
+func (m *MockPodDisruptionBudgetInterface) List(arg0 v1.ListOptions) (*v1beta1.PodDisruptionBudgetList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudgetList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) List(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).List), arg0)
+}
+
+// Patch mocks base method
+func (m *MockPodDisruptionBudgetInterface) Patch(arg0 string, arg1 types.PatchType, arg2 []byte, arg3 ...string) (*v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2}
+	for _, a := range arg3 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Patch", varargs...)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Patch(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Patch), varargs...)
+}
+
+// Update mocks base method
+func (m *MockPodDisruptionBudgetInterface) Update(arg0 *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Update(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Update), arg0)
+}
+
+// UpdateStatus mocks base method
+func (m *MockPodDisruptionBudgetInterface) UpdateStatus(arg0 *v1beta1.PodDisruptionBudget) (*v1beta1.PodDisruptionBudget, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", arg0)
+	ret0, _ := ret[0].(*v1beta1.PodDisruptionBudget)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) UpdateStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).UpdateStatus), arg0)
+}
+
+// Watch mocks base method
+func (m *MockPodDisruptionBudgetInterface) Watch(arg0 v1.ListOptions) (watch.Interface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Watch", arg0)
+	ret0, _ := ret[0].(watch.Interface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Watch indicates an expected call of Watch
+func (mr *MockPodDisruptionBudgetInterfaceMockRecorder) Watch(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Watch", reflect.TypeOf((*MockPodDisruptionBudgetInterface)(nil).Watch), arg0)
+}
@@ -161,6 +161,9 @@ func ensureModelOperator(
 	if err != nil {
 		return errors.Annotate(err, "building juju model operator deployment")
 	}
+	if err := applyOperatorPodConfig(&deployment.Spec.Template.Spec, config.PodConfig); err != nil {
+		return errors.Annotate(err, "configuring model operator pod")
+	}
 
 	return broker.EnsureDeployment(deployment)
 }
@@ -182,6 +185,15 @@ func (k *kubernetesClient) EnsureModelOperator(
 		namespace:        func() string { return k.namespace },
 	}
 
+	if config.PodConfig == nil {
+		podConfig, err := k.operatorPodConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		withPodConfig := *config
+		withPodConfig.PodConfig = podConfig
+		config = &withPodConfig
+	}
 	return ensureModelOperator(modelUUID, agentPath, config, bridge)
 }
 
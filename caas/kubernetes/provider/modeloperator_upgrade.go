@@ -4,14 +4,18 @@
 package provider
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/version"
 	"k8s.io/client-go/kubernetes"
+
+	"github.com/juju/juju/caas"
 )
 
 type upgradeCAASModelOperatorBridge struct {
 	clientFn    func() kubernetes.Interface
 	namespaceFn func() string
+	podConfigFn func() (*caas.OperatorPodConfig, error)
 }
 
 type UpgradeCAASModelOperatorBroker interface {
@@ -21,6 +25,10 @@ type UpgradeCAASModelOperatorBroker interface {
 
 	// Namespace returns the targeted Kubernetes namespace for this broker
 	Namespace() string
+
+	// OperatorPodConfig returns the resources and placement
+	// of operator pods as specified in the model config.
+	OperatorPodConfig() (*caas.OperatorPodConfig, error)
 }
 
 func (u *upgradeCAASModelOperatorBridge) Client() kubernetes.Interface {
@@ -31,7 +39,11 @@ func modelOperatorUpgrade(
 	operatorName string,
 	vers version.Number,
 	broker UpgradeCAASModelOperatorBroker) error {
-	return upgradeDeployment(operatorName, "", vers,
+	podConfig, err := broker.OperatorPodConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return upgradeDeployment(operatorName, "", vers, podConfig,
 		broker.Client().AppsV1().Deployments(broker.Namespace()))
 }
 
@@ -39,10 +51,15 @@ func (u *upgradeCAASModelOperatorBridge) Namespace() string {
 	return u.namespaceFn()
 }
 
+func (u *upgradeCAASModelOperatorBridge) OperatorPodConfig() (*caas.OperatorPodConfig, error) {
+	return u.podConfigFn()
+}
+
 func (k *kubernetesClient) upgradeModelOperator(agentTag names.Tag, vers version.Number) error {
 	broker := &upgradeCAASModelOperatorBridge{
 		clientFn:    k.client,
 		namespaceFn: k.GetCurrentNamespace,
+		podConfigFn: k.operatorPodConfig,
 	}
 	return modelOperatorUpgrade(modelOperatorName, vers, broker)
 }
@@ -15,11 +15,13 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloudconfig/podcfg"
 )
 
 type dummyUpgradeCAASModel struct {
-	client *fake.Clientset
+	client    *fake.Clientset
+	podConfig *caas.OperatorPodConfig
 }
 
 type modelUpgraderSuite struct {
@@ -36,6 +38,10 @@ func (d *dummyUpgradeCAASModel) Namespace() string {
 	return "test"
 }
 
+func (d *dummyUpgradeCAASModel) OperatorPodConfig() (*caas.OperatorPodConfig, error) {
+	return d.podConfig, nil
+}
+
 func (s *modelUpgraderSuite) SetUpTest(c *gc.C) {
 	s.broker = &dummyUpgradeCAASModel{
 		client: fake.NewSimpleClientset(),
@@ -83,3 +89,38 @@ func (s *modelUpgraderSuite) TestModelOperatorUpgrade(c *gc.C) {
 	c.Assert(de.Annotations[labelVersion], gc.Equals, version.MustParse("9.9.9").String())
 	c.Assert(de.Spec.Template.Annotations[labelVersion], gc.Equals, version.MustParse("9.9.9").String())
 }
+
+func (s *modelUpgraderSuite) TestModelOperatorUpgradeAppliesPodConfig(c *gc.C) {
+	operatorName := modelOperatorName
+	_, err := s.broker.Client().AppsV1().Deployments(s.broker.Namespace()).Create(
+		&apps.Deployment{
+			ObjectMeta: meta.ObjectMeta{
+				Name: operatorName,
+			},
+			Spec: apps.DeploymentSpec{
+				Template: core.PodTemplateSpec{
+					Spec: core.PodSpec{
+						Containers: []core.Container{{
+							Name:  "jujud",
+							Image: fmt.Sprintf("%s/%s:9.9.8", podcfg.JujudOCINamespace, podcfg.JujudOCIName),
+						}},
+					},
+				},
+			},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.broker.podConfig = &caas.OperatorPodConfig{
+		MemoryLimit:       "512Mi",
+		NodeSelector:      map[string]string{"pool": "system"},
+		PriorityClassName: "juju-system",
+	}
+	c.Assert(modelOperatorUpgrade(operatorName, version.MustParse("9.9.9"), s.broker), jc.ErrorIsNil)
+	de, err := s.broker.Client().AppsV1().Deployments(s.broker.Namespace()).
+		Get(operatorName, meta.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	spec := de.Spec.Template.Spec
+	c.Assert(spec.Containers[0].Resources.Limits.Memory().String(), gc.Equals, "512Mi")
+	c.Assert(spec.NodeSelector, jc.DeepEquals, map[string]string{"pool": "system"})
+	c.Assert(spec.PriorityClassName, gc.Equals, "juju-system")
+}
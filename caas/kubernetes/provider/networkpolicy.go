@@ -0,0 +1,132 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+// EnsureNetworkPolicy is part of the caas.ServiceGetterSetter interface.
+// It creates or updates a network policy which only admits ingress to
+// the application's pods from the pods and operators of the application
+// itself and of the applications allowed by the policy, or from any
+// source if the application is exposed. Ingress is limited to the ports
+// of the application's service, if it has one.
+//
+// The ports are not those opened with open-port: units of a Kubernetes
+// application have no machine, so opened ports are not recorded for
+// them. The service ports are those of the containers in the
+// application's pod spec, which are the ports the application's pods
+// actually accept connections on.
+func (k *kubernetesClient) EnsureNetworkPolicy(appName string, policy *caas.NetworkPolicy) error {
+	deploymentName := k.deploymentName(appName, true)
+	if policy == nil {
+		return errors.Trace(k.deleteNetworkPolicy(deploymentName))
+	}
+	ports, err := k.networkPolicyPorts(deploymentName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rule := networkingv1.NetworkPolicyIngressRule{Ports: ports}
+	if !policy.Exposed {
+		apps := append([]string{appName}, policy.AllowedApplications...)
+		sort.Strings(apps[1:])
+		rule.From = []networkingv1.NetworkPolicyPeer{
+			{PodSelector: appsSelector(labelApplication, apps)},
+			{PodSelector: appsSelector(labelOperator, apps)},
+		}
+	}
+	spec := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      LabelsForApp(appName),
+			Annotations: k.annotations.Copy(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{MatchLabels: LabelsForApp(appName)},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{rule},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	logger.Debugf("ensuring network policy for %q: %+v", appName, policy)
+	return errors.Trace(k.ensureNetworkPolicy(spec))
+}
+
+func appsSelector(label string, apps []string) *v1.LabelSelector {
+	return &v1.LabelSelector{
+		MatchExpressions: []v1.LabelSelectorRequirement{{
+			Key:      label,
+			Operator: v1.LabelSelectorOpIn,
+			Values:   apps,
+		}},
+	}
+}
+
+// networkPolicyPorts returns the pod ports targeted by the service with
+// the specified name. If there is no such service, no ports are returned
+// so that ingress is not limited to particular ports.
+func (k *kubernetesClient) networkPolicyPorts(serviceName string) ([]networkingv1.NetworkPolicyPort, error) {
+	svc, err := k.client().CoreV1().Services(k.namespace).Get(serviceName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ports []networkingv1.NetworkPolicyPort
+	for _, sp := range svc.Spec.Ports {
+		port := sp.TargetPort
+		if port.IntValue() == 0 && port.Type == intstr.Int {
+			port = intstr.FromInt(int(sp.Port))
+		}
+		protocol := sp.Protocol
+		if protocol == "" {
+			protocol = core.ProtocolTCP
+		}
+		ports = append(ports, networkingv1.NetworkPolicyPort{
+			Protocol: &protocol,
+			Port:     &port,
+		})
+	}
+	return ports, nil
+}
+
+func (k *kubernetesClient) ensureNetworkPolicy(spec *networkingv1.NetworkPolicy) error {
+	api := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := api.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(name string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicies(appName string) error {
+	err := k.client().NetworkingV1().NetworkPolicies(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: labelSetToSelector(LabelsForApp(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+)
+
+func (s *K8sBrokerSuite) networkPolicyArg(rule networkingv1.NetworkPolicyIngressRule) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: s.broker.GetAnnotations().ToMap(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{MatchLabels: map[string]string{"juju-app": "app-name"}},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{rule},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicy(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{
+				Port:       80,
+				TargetPort: intstr.FromInt(8080),
+				Protocol:   core.ProtocolTCP,
+			}, {
+				Port:     53,
+				Protocol: core.ProtocolUDP,
+			}},
+		},
+	}
+	tcp, udp := core.ProtocolTCP, core.ProtocolUDP
+	port8080, port53 := intstr.FromInt(8080), intstr.FromInt(53)
+	apps := []string{"app-name", "mariadb", "redis"}
+	policy := s.networkPolicyArg(networkingv1.NetworkPolicyIngressRule{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &tcp, Port: &port8080},
+			{Protocol: &udp, Port: &port53},
+		},
+		From: []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key: "juju-app", Operator: v1.LabelSelectorOpIn, Values: apps,
+				}},
+			},
+		}, {
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key: "juju-operator", Operator: v1.LabelSelectorOpIn, Values: apps,
+				}},
+			},
+		}},
+	})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(svc, nil),
+		s.mockNetworkPolicies.EXPECT().Update(policy).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policy).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", &caas.NetworkPolicy{
+		AllowedApplications: []string{"redis", "mariadb"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyExposedNoService(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	policy := s.networkPolicyArg(networkingv1.NetworkPolicyIngressRule{})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Update(policy).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", &caas.NetworkPolicy{
+		AllowedApplications: []string{"mariadb"},
+		Exposed:             true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyRemove(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -188,8 +189,11 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 			return errors.Annotatef(err, "config map for %q should already exist", appName)
 		}
 	} else {
-		cmCleanUp, err := k.ensureConfigMapLegacy(
-			operatorConfigMap(appName, cmName, k.getConfigMapLabels(appName), annotations, config))
+		cm, err := operatorConfigMap(appName, cmName, k.getConfigMapLabels(appName), annotations, config)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		cmCleanUp, err := k.ensureConfigMapLegacy(cm)
 		cleanups = append(cleanups, cmCleanUp)
 		if err != nil {
 			return errors.Annotate(err, "creating or updating ConfigMap")
@@ -210,6 +214,15 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 	if err != nil {
 		return errors.Annotate(err, "generating operator podspec")
 	}
+	podConfig := config.PodConfig
+	if podConfig == nil {
+		if podConfig, err = k.operatorPodConfig(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := applyOperatorPodConfig(&pod.Spec, podConfig); err != nil {
+		return errors.Annotate(err, "configuring operator pod")
+	}
 	// Take a copy for use with statefulset.
 	podWithoutStorage := pod
 
@@ -618,6 +631,11 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 		if operatorInfo, ok := configMap.Data[caas.OperatorInfoFile]; ok {
 			cfg.OperatorInfo = []byte(operatorInfo)
 		}
+		if podConfig, ok := configMap.Data[operatorConfigMapPodConfigKey(appName)]; ok {
+			if err := json.Unmarshal([]byte(podConfig), &cfg.PodConfig); err != nil {
+				return nil, errors.Annotatef(err, "unmarshalling pod config of operator %q", appName)
+			}
+		}
 	}
 
 	return &caas.Operator{
@@ -740,10 +758,17 @@ func operatorConfigMapAgentConfKey(appName string) string {
 	return appName + "-agent.conf"
 }
 
+// operatorConfigMapPodConfigKey returns the key of the operator config
+// map entry holding the resources and placement of the operator pod, so
+// that they are kept when the operator is upgraded.
+func operatorConfigMapPodConfigKey(appName string) string {
+	return appName + "-pod-config"
+}
+
 // operatorConfigMap returns a *core.ConfigMap for the operator pod
 // of the specified application, with the specified configuration.
-func operatorConfigMap(appName, name string, labels, annotations map[string]string, config *caas.OperatorConfig) *core.ConfigMap {
-	return &core.ConfigMap{
+func operatorConfigMap(appName, name string, labels, annotations map[string]string, config *caas.OperatorConfig) (*core.ConfigMap, error) {
+	cm := &core.ConfigMap{
 		ObjectMeta: v1.ObjectMeta{
 			Name:        name,
 			Labels:      labels,
@@ -755,4 +780,12 @@ func operatorConfigMap(appName, name string, labels, annotations map[string]stri
 			caas.OperatorInfoFile:                  string(config.OperatorInfo),
 		},
 	}
+	if config.PodConfig != nil {
+		podConfig, err := json.Marshal(config.PodConfig)
+		if err != nil {
+			return nil, errors.Annotate(err, "marshalling operator pod config")
+		}
+		cm.Data[operatorConfigMapPodConfigKey(appName)] = string(podConfig)
+	}
+	return cm, nil
 }
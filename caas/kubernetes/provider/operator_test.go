@@ -1019,6 +1019,7 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 		Data: map[string]string{
 			"test-agent.conf": "agent-conf-data",
 			"operator.yaml":   "operator-info-data",
+			"test-pod-config": `{"memory-limit":"256Mi"}`,
 		},
 	}
 	gomock.InOrder(
@@ -1041,6 +1042,7 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	c.Assert(operator.Config.OperatorImagePath, gc.Equals, "test-image")
 	c.Assert(operator.Config.AgentConf, gc.DeepEquals, []byte("agent-conf-data"))
 	c.Assert(operator.Config.OperatorInfo, gc.DeepEquals, []byte("operator-info-data"))
+	c.Assert(operator.Config.PodConfig, jc.DeepEquals, &caas.OperatorPodConfig{MemoryLimit: "256Mi"})
 }
 
 func (s *K8sBrokerSuite) TestOperatorNoPodFound(c *gc.C) {
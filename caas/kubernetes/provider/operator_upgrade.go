@@ -30,6 +30,7 @@ type upgradeCAASOperatorBridge struct {
 	namespaceFn      func() string
 	operatorFn       func(string) (*caas.Operator, error)
 	operatorNameFn   func(string) string
+	podConfigFn      func() (*caas.OperatorPodConfig, error)
 }
 
 type UpgradeCAASOperatorBroker interface {
@@ -52,6 +53,10 @@ type UpgradeCAASOperatorBroker interface {
 	// OperatorName returns the operator name used for the operator deployment
 	// for the supplied application.
 	OperatorName(string) string
+
+	// OperatorPodConfig returns the resources and placement
+	// of operator pods as specified in the model config.
+	OperatorPodConfig() (*caas.OperatorPodConfig, error)
 }
 
 const applicationUpgradeTimeoutSeconds = time.Second * 30
@@ -80,6 +85,10 @@ func (u *upgradeCAASOperatorBridge) Namespace() string {
 	return u.namespaceFn()
 }
 
+func (u *upgradeCAASOperatorBridge) OperatorPodConfig() (*caas.OperatorPodConfig, error) {
+	return u.podConfigFn()
+}
+
 func operatorInitUpgrade(appName, imagePath string, broker UpgradeCAASOperatorBroker) (func() (bool, error), error) {
 	deploymentName := broker.DeploymentName(appName, true)
 
@@ -183,6 +192,15 @@ func operatorUpgrade(appName string, vers version.Number, broker UpgradeCAASOper
 		return errors.NotValidf("no resource is upgradable for application %q", appName)
 	}
 
+	// Operators with resources and placement overridden in the application
+	// config keep them, others take those currently in the model config.
+	podConfig := operator.Config.PodConfig
+	if podConfig == nil {
+		if podConfig, err = broker.OperatorPodConfig(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	podChecker, err := operatorInitUpgrade(appName, operatorImagePath, broker)
 	if err != nil {
 		return errors.Trace(err)
@@ -205,6 +223,7 @@ func operatorUpgrade(appName string, vers version.Number, broker UpgradeCAASOper
 					broker.OperatorName(appName),
 					operatorImagePath,
 					vers,
+					podConfig,
 					broker.Client().AppsV1().StatefulSets(broker.Namespace())))
 			}
 		}
@@ -219,6 +238,7 @@ func (k *kubernetesClient) upgradeOperator(agentTag names.Tag, vers version.Numb
 		namespaceFn:      k.GetCurrentNamespace,
 		operatorFn:       k.Operator,
 		operatorNameFn:   k.operatorName,
+		podConfigFn:      k.operatorPodConfig,
 	}
 	return operatorUpgrade(agentTag.Id(), vers, broker)
 }
@@ -11,9 +11,11 @@ import (
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
@@ -60,6 +62,10 @@ func (d *DummyUpgradeCAASOperator) OperatorName(n string) string {
 	return n
 }
 
+func (d *DummyUpgradeCAASOperator) OperatorPodConfig() (*caas.OperatorPodConfig, error) {
+	return &caas.OperatorPodConfig{}, nil
+}
+
 func (o *OperatorUpgraderSuite) SetUpTest(c *gc.C) {
 	o.broker = &DummyUpgradeCAASOperator{
 		client: fake.NewSimpleClientset(),
@@ -338,3 +344,45 @@ func (o *OperatorUpgraderSuite) TestDeploymentInitUpgrade(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ready, jc.IsTrue)
 }
+
+func (o *OperatorUpgraderSuite) TestUpgradeStatefulSetAppliesPodConfig(c *gc.C) {
+	var (
+		appName      = "testss"
+		oldImagePath = fmt.Sprintf("%s/%s:9.9.8", podcfg.JujudOCINamespace, podcfg.JujudOCIName)
+		newImagePath = fmt.Sprintf("%s/%s:9.9.9", podcfg.JujudOCINamespace, podcfg.JujudOCIName)
+	)
+
+	statefulSets := o.broker.Client().AppsV1().StatefulSets(o.broker.Namespace())
+	_, err := statefulSets.Create(&apps.StatefulSet{
+		ObjectMeta: meta.ObjectMeta{
+			Name: appName,
+		},
+		Spec: apps.StatefulSetSpec{
+			Template: core.PodTemplateSpec{
+				Spec: core.PodSpec{
+					Containers: []core.Container{{
+						Name:  operatorContainerName,
+						Image: oldImagePath,
+					}},
+				},
+			},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	podConfig := &caas.OperatorPodConfig{
+		MemoryLimit:  "256Mi",
+		NodeSelector: map[string]string{"pool": "operators"},
+	}
+	err = upgradeStatefulSet(appName, newImagePath, version.MustParse("9.9.9"), podConfig, statefulSets)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ss, err := statefulSets.Get(appName, meta.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	spec := ss.Spec.Template.Spec
+	c.Assert(spec.Containers[0].Image, gc.Equals, newImagePath)
+	c.Assert(spec.Containers[0].Resources.Limits, jc.DeepEquals, core.ResourceList{
+		core.ResourceMemory: resource.MustParse("256Mi"),
+	})
+	c.Assert(spec.NodeSelector, jc.DeepEquals, map[string]string{"pool": "operators"})
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// OperatorCPURequestKey, OperatorCPULimitKey, OperatorMemoryRequestKey
+	// and OperatorMemoryLimitKey are the model config attributes used to
+	// specify the resources of operator pods, as k8s quantities.
+	OperatorCPURequestKey    = "operator-cpu-request"
+	OperatorCPULimitKey      = "operator-cpu-limit"
+	OperatorMemoryRequestKey = "operator-memory-request"
+	OperatorMemoryLimitKey   = "operator-memory-limit"
+
+	// OperatorNodeSelectorKey is the model config attribute used to
+	// specify the labels of the nodes on which operator pods may be
+	// scheduled, as a comma separated list of key=value.
+	OperatorNodeSelectorKey = "operator-node-selector"
+
+	// OperatorTolerationsKey is the model config attribute used to
+	// specify the node taints tolerated by operator pods, as a comma
+	// separated list of key[=value][:effect].
+	OperatorTolerationsKey = "operator-tolerations"
+
+	// OperatorPriorityClassKey is the model config attribute used to
+	// specify the priority class of operator pods.
+	OperatorPriorityClassKey = "operator-priority-class"
+)
+
+// operatorPodConfigKeys holds the model config attributes for
+// operator pods. Each may be overridden for the operator of an
+// application by the application config attribute of the same
+// name, prefixed with "kubernetes-".
+var operatorPodConfigKeys = []string{
+	OperatorCPURequestKey,
+	OperatorCPULimitKey,
+	OperatorMemoryRequestKey,
+	OperatorMemoryLimitKey,
+	OperatorNodeSelectorKey,
+	OperatorTolerationsKey,
+	OperatorPriorityClassKey,
+}
+
+// applicationOperatorConfigKey returns the application config attribute
+// overriding the specified model config attribute for operator pods.
+func applicationOperatorConfigKey(key string) string {
+	return "kubernetes-" + key
+}
+
+// OperatorPodConfig returns the resources and placement of an operator
+// pod as specified in the model config attributes, overridden by any
+// specified in the application config, which may be nil.
+func OperatorPodConfig(modelAttrs map[string]interface{}, appConfig application.ConfigAttributes) (*caas.OperatorPodConfig, error) {
+	values := make(map[string]string)
+	for _, key := range operatorPodConfigKeys {
+		value, _ := modelAttrs[key].(string)
+		if appValue, _ := appConfig[applicationOperatorConfigKey(key)].(string); appValue != "" {
+			value = appValue
+		}
+		values[key] = strings.TrimSpace(value)
+	}
+	cfg := &caas.OperatorPodConfig{
+		CPURequest:        values[OperatorCPURequestKey],
+		CPULimit:          values[OperatorCPULimitKey],
+		MemoryRequest:     values[OperatorMemoryRequestKey],
+		MemoryLimit:       values[OperatorMemoryLimitKey],
+		PriorityClassName: values[OperatorPriorityClassKey],
+	}
+	for _, key := range []string{
+		OperatorCPURequestKey, OperatorCPULimitKey, OperatorMemoryRequestKey, OperatorMemoryLimitKey,
+	} {
+		if values[key] == "" {
+			continue
+		}
+		if _, err := resource.ParseQuantity(values[key]); err != nil {
+			return nil, errors.NotValidf("%s %q", key, values[key])
+		}
+	}
+	for _, item := range splitList(values[OperatorNodeSelectorKey]) {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.NotValidf("%s %q, expected key=value", OperatorNodeSelectorKey, item)
+		}
+		if cfg.NodeSelector == nil {
+			cfg.NodeSelector = make(map[string]string)
+		}
+		cfg.NodeSelector[parts[0]] = parts[1]
+	}
+	for _, item := range splitList(values[OperatorTolerationsKey]) {
+		if _, err := parseToleration(item); err != nil {
+			return nil, errors.Annotatef(err, "%s", OperatorTolerationsKey)
+		}
+		cfg.Tolerations = append(cfg.Tolerations, item)
+	}
+	return cfg, nil
+}
+
+// splitList returns the non-empty items of a comma separated list.
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// parseToleration returns the toleration specified
+// as key[=value][:effect]. A toleration without a
+// value tolerates taints with any value.
+func parseToleration(s string) (core.Toleration, error) {
+	toleration := core.Toleration{Operator: core.TolerationOpExists}
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		toleration.Effect = core.TaintEffect(s[i+1:])
+		s = s[:i]
+		switch toleration.Effect {
+		case core.TaintEffectNoSchedule, core.TaintEffectPreferNoSchedule, core.TaintEffectNoExecute:
+		default:
+			return core.Toleration{}, errors.NotValidf("taint effect %q", toleration.Effect)
+		}
+	}
+	if i := strings.Index(s, "="); i >= 0 {
+		toleration.Operator = core.TolerationOpEqual
+		toleration.Value = s[i+1:]
+		s = s[:i]
+	}
+	if s == "" {
+		return core.Toleration{}, errors.NotValidf("toleration without key")
+	}
+	toleration.Key = s
+	return toleration, nil
+}
+
+// applyOperatorPodConfig sets the resources of the first container of
+// the specified operator pod spec, and its placement, from the config.
+func applyOperatorPodConfig(spec *core.PodSpec, cfg *caas.OperatorPodConfig) error {
+	if cfg == nil || len(spec.Containers) == 0 {
+		return nil
+	}
+	resources := &spec.Containers[0].Resources
+	for _, r := range []struct {
+		list  *core.ResourceList
+		name  core.ResourceName
+		value string
+	}{
+		{&resources.Requests, core.ResourceCPU, cfg.CPURequest},
+		{&resources.Limits, core.ResourceCPU, cfg.CPULimit},
+		{&resources.Requests, core.ResourceMemory, cfg.MemoryRequest},
+		{&resources.Limits, core.ResourceMemory, cfg.MemoryLimit},
+	} {
+		if r.value == "" {
+			delete(*r.list, r.name)
+			continue
+		}
+		quantity, err := resource.ParseQuantity(r.value)
+		if err != nil {
+			return errors.NotValidf("%s %q", r.name, r.value)
+		}
+		if *r.list == nil {
+			*r.list = make(core.ResourceList)
+		}
+		(*r.list)[r.name] = quantity
+	}
+	spec.NodeSelector = cfg.NodeSelector
+	spec.Tolerations = nil
+	for _, s := range cfg.Tolerations {
+		toleration, err := parseToleration(s)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		spec.Tolerations = append(spec.Tolerations, toleration)
+	}
+	spec.PriorityClassName = cfg.PriorityClassName
+	return nil
+}
+
+// operatorPodConfig returns the resources and placement
+// of operator pods as specified in the model config.
+func (k *kubernetesClient) operatorPodConfig() (*caas.OperatorPodConfig, error) {
+	return OperatorPodConfig(k.Config().AllAttrs(), nil)
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+type operatorPodConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&operatorPodConfigSuite{})
+
+func (s *operatorPodConfigSuite) TestOperatorPodConfig(c *gc.C) {
+	cfg, err := provider.OperatorPodConfig(map[string]interface{}{
+		provider.OperatorCPURequestKey:    "100m",
+		provider.OperatorMemoryRequestKey: "128Mi",
+		provider.OperatorMemoryLimitKey:   "256Mi",
+		provider.OperatorNodeSelectorKey:  "pool=system, disk=ssd",
+		provider.OperatorTolerationsKey:   "dedicated=juju:NoSchedule,spot",
+		provider.OperatorPriorityClassKey: "juju-system",
+	}, application.ConfigAttributes{
+		"kubernetes-operator-memory-limit":  "1Gi",
+		"kubernetes-operator-node-selector": "pool=apps",
+		"kubernetes-operator-cpu-limit":     "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &caas.OperatorPodConfig{
+		CPURequest:        "100m",
+		MemoryRequest:     "128Mi",
+		MemoryLimit:       "1Gi",
+		NodeSelector:      map[string]string{"pool": "apps"},
+		Tolerations:       []string{"dedicated=juju:NoSchedule", "spot"},
+		PriorityClassName: "juju-system",
+	})
+}
+
+func (s *operatorPodConfigSuite) TestOperatorPodConfigEmpty(c *gc.C) {
+	cfg, err := provider.OperatorPodConfig(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg, jc.DeepEquals, &caas.OperatorPodConfig{})
+}
+
+func (s *operatorPodConfigSuite) TestOperatorPodConfigInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{provider.OperatorCPULimitKey: "lots"},
+		err:   `operator-cpu-limit "lots" not valid`,
+	}, {
+		attrs: map[string]interface{}{provider.OperatorNodeSelectorKey: "pool"},
+		err:   `operator-node-selector "pool", expected key=value not valid`,
+	}, {
+		attrs: map[string]interface{}{provider.OperatorTolerationsKey: "spot:Never"},
+		err:   `operator-tolerations: taint effect "Never" not valid`,
+	}, {
+		attrs: map[string]interface{}{provider.OperatorTolerationsKey: "=true"},
+		err:   `operator-tolerations: toleration without key not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := provider.OperatorPodConfig(test.attrs, nil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
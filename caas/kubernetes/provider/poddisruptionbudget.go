@@ -0,0 +1,66 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// ensurePodDisruptionBudget creates or updates a pod disruption budget
+// covering all the pods of the application.
+func (k *kubernetesClient) ensurePodDisruptionBudget(
+	appName, deploymentName string,
+	annotations k8sannotations.Annotation,
+	spec *k8sspecs.PodDisruptionBudgetSpec,
+) error {
+	pdb := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      LabelsForApp(appName),
+			Annotations: annotations.ToMap(),
+		},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable:   spec.MinAvailable,
+			MaxUnavailable: spec.MaxUnavailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: LabelsForApp(appName),
+			},
+		},
+	}
+	logger.Debugf("ensuring pod disruption budget for %q: %+v", appName, pdb.Spec)
+	api := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace)
+	_, err := api.Update(pdb)
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(pdb)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudget(name string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudgets(appName string) error {
+	err := k.client().PolicyV1beta1().PodDisruptionBudgets(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: labelSetToSelector(LabelsForApp(appName)).String(),
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/client-go/rest"
 
 	"github.com/juju/juju/caas"
+	k8sexec "github.com/juju/juju/caas/kubernetes/provider/exec"
 	"github.com/juju/juju/cloud"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
@@ -106,6 +107,20 @@ func CloudSpecToK8sRestConfig(cloudSpec environs.CloudSpec) (*rest.Config, error
 	}, nil
 }
 
+// NewExecClient returns an exec client for the specified
+// namespace of the cluster of the specified cloud.
+func NewExecClient(namespace string, cloudSpec environs.CloudSpec) (k8sexec.Executor, error) {
+	k8sRestConfig, err := CloudSpecToK8sRestConfig(cloudSpec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	c, err := kubernetes.NewForConfig(k8sRestConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return k8sexec.New(namespace, c, k8sRestConfig), nil
+}
+
 func newRestClient(cfg *rest.Config) (rest.Interface, error) {
 	return rest.RESTClientFor(cfg)
 }
@@ -149,6 +164,30 @@ func (p kubernetesEnvironProvider) Open(args environs.OpenParams) (caas.Broker,
 		randomPrefix, jujuclock.WallClock)
 }
 
+// NewBrokerForClients returns a broker for the model with the specified
+// config, which manages the given namespace using the specified clients
+// rather than clients for a rest config. It is used to run the broker
+// against an in-process cluster, such as a fake cluster in tests.
+func NewBrokerForClients(
+	controllerUUID string,
+	cfg *config.Config,
+	namespace string,
+	k8sClient kubernetes.Interface,
+	apiextensionsClient apiextensionsclientset.Interface,
+	dynamicClient dynamic.Interface,
+	clock jujuclock.Clock,
+) (caas.Broker, error) {
+	newClient := func(*rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
+		return k8sClient, apiextensionsClient, dynamicClient, nil
+	}
+	newRestClient := func(*rest.Config) (rest.Interface, error) {
+		return nil, errors.NotSupportedf("rest client for an in-process cluster")
+	}
+	return newK8sBroker(
+		controllerUUID, &rest.Config{}, cfg, namespace, newClient, newRestClient,
+		newKubernetesNotifyWatcher, newKubernetesStringsWatcher, randomPrefix, clock)
+}
+
 // CloudSchema returns the schema for adding new clouds of this type.
 func (p kubernetesEnvironProvider) CloudSchema() *jsonschema.Schema {
 	return nil
@@ -127,3 +127,16 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestValidateNetworkPolicies(c *gc.C) {
+	validCfg, err := s.provider.Validate(fakeConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.NetworkPoliciesEnabled(validCfg), jc.IsFalse)
+
+	validCfg, err = s.provider.Validate(fakeConfig(c, coretesting.Attrs{"network-policies": "true"}), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.NetworkPoliciesEnabled(validCfg), jc.IsTrue)
+
+	_, err = s.provider.Validate(fakeConfig(c, coretesting.Attrs{"network-policies": "maybe"}), nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: network-policies: expected bool, got string\("maybe"\)`)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/environs/config"
 )
 
@@ -21,6 +22,11 @@ const (
 	// OperatorStorageKey is the model config attribute used to specify
 	// the storage class for provisioning operator storage.
 	OperatorStorageKey = "operator-storage"
+
+	// NetworkPoliciesKey is the model config attribute used to specify
+	// whether ingress to each application is restricted to the
+	// applications related to it, using network policies.
+	NetworkPoliciesKey = "network-policies"
 )
 
 var (
@@ -47,6 +53,51 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	NetworkPoliciesKey: {
+		Description: "Whether ingress to each application is restricted to its related applications and, if exposed, external clients.",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+	},
+	caas.ClusterZonesKey: {
+		Description: "A comma separated list of <cloud>:<credential> entries naming the other k8s clouds the model spans, and the model owner's credential used for each. Each cloud is a placement zone for the units of applications with a zones constraint.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorCPURequestKey: {
+		Description: "The CPU requested by operator pods, as a k8s quantity, eg 100m.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorCPULimitKey: {
+		Description: "The CPU limit of operator pods, as a k8s quantity, eg 500m.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorMemoryRequestKey: {
+		Description: "The memory requested by operator pods, as a k8s quantity, eg 128Mi.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorMemoryLimitKey: {
+		Description: "The memory limit of operator pods, as a k8s quantity, eg 512Mi.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorNodeSelectorKey: {
+		Description: "A comma separated list of key=value node labels selecting the nodes on which operator pods may be scheduled.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorTolerationsKey: {
+		Description: "A comma separated list of node taints tolerated by operator pods, each as key[=value][:effect].",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorPriorityClassKey: {
+		Description: "The priority class of operator pods.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -58,8 +109,18 @@ var providerConfigFields = func() schema.Fields {
 }()
 
 var providerConfigDefaults = schema.Defaults{
-	WorkloadStorageKey: "",
-	OperatorStorageKey: "",
+	WorkloadStorageKey:   "",
+	OperatorStorageKey:   "",
+	NetworkPoliciesKey:   schema.Omit,
+	caas.ClusterZonesKey: schema.Omit,
+
+	OperatorCPURequestKey:    schema.Omit,
+	OperatorCPULimitKey:      schema.Omit,
+	OperatorMemoryRequestKey: schema.Omit,
+	OperatorMemoryLimitKey:   schema.Omit,
+	OperatorNodeSelectorKey:  schema.Omit,
+	OperatorTolerationsKey:   schema.Omit,
+	OperatorPriorityClassKey: schema.Omit,
 }
 
 type brokerConfig struct {
@@ -75,6 +136,13 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[OperatorStorageKey].(string)
 }
 
+// NetworkPoliciesEnabled returns true if the specified model config
+// enables network policies for the model's applications.
+func NetworkPoliciesEnabled(cfg *config.Config) bool {
+	enabled, _ := cfg.AllAttrs()[NetworkPoliciesKey].(bool)
+	return enabled
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -122,6 +190,10 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 		return nil, err
 	}
 
+	if _, err := OperatorPodConfig(validated, nil); err != nil {
+		return nil, err
+	}
+
 	bcfg := &brokerConfig{cfg, validated}
 	return bcfg, nil
 }
@@ -0,0 +1,274 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/juju/juju/caas"
+)
+
+// volumeSnapshotGroup is the API group of the CSI volume snapshot
+// resources, which must be installed in the cluster to snapshot
+// application storage.
+const volumeSnapshotGroup = "snapshot.storage.k8s.io"
+
+var volumeSnapshotResource = schema.GroupVersionResource{
+	Group:    volumeSnapshotGroup,
+	Version:  "v1beta1",
+	Resource: "volumesnapshots",
+}
+
+func (k *kubernetesClient) volumeSnapshots() dynamic.ResourceInterface {
+	return k.dynamicClient().Resource(volumeSnapshotResource).Namespace(k.namespace)
+}
+
+// SnapshotStorage is part of the caas.StorageSnapshotter interface.
+// Only the claims made from the volume claim templates of the
+// application's stateful set are snapshotted, including those of
+// pods removed when the application was scaled down.
+func (k *kubernetesClient) SnapshotStorage(appName, suffix string) ([]caas.VolumeSnapshot, error) {
+	ss, err := k.getStatefulSet(k.deploymentName(appName, true))
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("persistent storage for application %q", appName)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	claims, err := k.client().CoreV1().PersistentVolumeClaims(k.namespace).List(v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []caas.VolumeSnapshot
+	for _, claim := range claims.Items {
+		template, ok := claimTemplateFor(ss, claim.Name)
+		if !ok {
+			continue
+		}
+		storageName := claim.Annotations[labelStorage]
+		if storageName == "" {
+			storageName = template.Annotations[labelStorage]
+		}
+		var size uint64
+		if q, ok := claim.Spec.Resources.Requests[core.ResourceStorage]; ok {
+			size = uint64(q.Value() / (1024 * 1024))
+		}
+		snapshot := caas.VolumeSnapshot{
+			StorageName:  storageName,
+			ClaimName:    claim.Name,
+			SnapshotName: fmt.Sprintf("%s-%s", claim.Name, suffix),
+			Size:         size,
+		}
+		if err := k.createVolumeSnapshot(appName, snapshot); err != nil {
+			if deleteErr := k.DeleteSnapshots(result); deleteErr != nil {
+				logger.Warningf("cannot delete snapshots of partial backup of %q: %v", appName, deleteErr)
+			}
+			return nil, errors.Annotatef(err, "snapshotting volume claim %q", claim.Name)
+		}
+		result = append(result, snapshot)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ClaimName < result[j].ClaimName
+	})
+	return result, nil
+}
+
+// claimTemplateFor returns the volume claim template of the stateful
+// set from which the named claim was made. The claims of a stateful set
+// are named <template>-<stateful set>-<ordinal>.
+func claimTemplateFor(ss *apps.StatefulSet, claimName string) (core.PersistentVolumeClaim, bool) {
+	for _, template := range ss.Spec.VolumeClaimTemplates {
+		prefix := fmt.Sprintf("%s-%s-", template.Name, ss.Name)
+		if !strings.HasPrefix(claimName, prefix) {
+			continue
+		}
+		ordinal := strings.TrimPrefix(claimName, prefix)
+		if ordinal != "" && strings.Trim(ordinal, "0123456789") == "" {
+			return template, true
+		}
+	}
+	return core.PersistentVolumeClaim{}, false
+}
+
+func (k *kubernetesClient) createVolumeSnapshot(appName string, snapshot caas.VolumeSnapshot) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(volumeSnapshotResource.GroupVersion().String())
+	obj.SetKind("VolumeSnapshot")
+	obj.SetName(snapshot.SnapshotName)
+	obj.SetLabels(LabelsForApp(appName))
+	obj.SetAnnotations(k.annotations.Copy().Add(labelStorage, snapshot.StorageName).ToMap())
+	if err := unstructured.SetNestedField(
+		obj.Object, snapshot.ClaimName, "spec", "source", "persistentVolumeClaimName",
+	); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := k.volumeSnapshots().Create(obj, v1.CreateOptions{})
+	if k8serrors.IsAlreadyExists(err) {
+		return errors.AlreadyExistsf("volume snapshot %q", snapshot.SnapshotName)
+	} else if k8serrors.IsNotFound(err) {
+		return errors.NotSupportedf("volume snapshots in this cluster")
+	}
+	return errors.Trace(err)
+}
+
+// DeleteSnapshots is part of the caas.StorageSnapshotter interface.
+func (k *kubernetesClient) DeleteSnapshots(snapshots []caas.VolumeSnapshot) error {
+	for _, snapshot := range snapshots {
+		err := k.volumeSnapshots().Delete(snapshot.SnapshotName, &v1.DeleteOptions{
+			PropagationPolicy: &defaultPropagationPolicy,
+		})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting volume snapshot %q", snapshot.SnapshotName)
+		}
+	}
+	return nil
+}
+
+// RestoreStorage is part of the caas.StorageSnapshotter interface.
+// Each claim is deleted and created again from the claim template of
+// the application's stateful set, with the snapshot as its data source,
+// so that the claim is bound to a new volume when the application is
+// scaled up. Nothing is deleted unless every snapshot is ready to use,
+// and as the data is then held by the snapshots, a restore which fails
+// part way can be run again.
+func (k *kubernetesClient) RestoreStorage(appName string, snapshots []caas.VolumeSnapshot) error {
+	ss, err := k.getStatefulSet(k.deploymentName(appName, true))
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("persistent storage for application %q", appName)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	pods, err := k.client().CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector: labelSetToSelector(LabelsForApp(appName)).String(),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(pods.Items); n > 0 {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"application %q is running %d pod(s), scale it to 0 before restoring its storage", appName, n,
+		))
+	}
+
+	claims := make([]*core.PersistentVolumeClaim, len(snapshots))
+	for i, snapshot := range snapshots {
+		template, ok := claimTemplateFor(ss, snapshot.ClaimName)
+		if !ok {
+			return errors.NotFoundf("volume claim template for %q", snapshot.ClaimName)
+		}
+		if err := k.checkSnapshotReady(snapshot.SnapshotName); err != nil {
+			return errors.Trace(err)
+		}
+		claim := &core.PersistentVolumeClaim{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        snapshot.ClaimName,
+				Labels:      AppendLabels(nil, template.Labels, ss.Spec.Template.Labels),
+				Annotations: template.Annotations,
+			},
+			Spec: *template.Spec.DeepCopy(),
+		}
+		apiGroup := volumeSnapshotGroup
+		claim.Spec.DataSource = &core.TypedLocalObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     "VolumeSnapshot",
+			Name:     snapshot.SnapshotName,
+		}
+		claims[i] = claim
+	}
+
+	// The claims are deleted together, so that restoring
+	// waits once for them all to be removed.
+	claimNames := make([]string, len(claims))
+	for i, claim := range claims {
+		claimNames[i] = claim.Name
+	}
+	if err := k.deleteClaimsAndWait(claimNames); err != nil {
+		return errors.Trace(err)
+	}
+	for _, claim := range claims {
+		if _, err := k.createPVC(claim); err != nil {
+			return errors.Annotatef(err, "restoring volume claim %q", claim.Name)
+		}
+	}
+	return nil
+}
+
+// checkSnapshotReady returns an error unless the named
+// volume snapshot exists and is ready to use.
+func (k *kubernetesClient) checkSnapshotReady(name string) error {
+	obj, err := k.volumeSnapshots().Get(name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return errors.NotFoundf("volume snapshot %q", name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ready, _, err := unstructured.NestedBool(obj.Object, "status", "readyToUse")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ready {
+		return errors.NewNotValid(nil, fmt.Sprintf("volume snapshot %q is not ready to use", name))
+	}
+	return nil
+}
+
+// deleteClaimsTimeout is the time for which restoring storage
+// waits for the volume claims being replaced to be removed.
+const deleteClaimsTimeout = 30 * time.Second
+
+// deleteClaimsAndWait deletes the named volume claims, where they
+// exist, and waits for them to be removed so that they can be
+// created again.
+func (k *kubernetesClient) deleteClaimsAndWait(names []string) error {
+	claims := k.client().CoreV1().PersistentVolumeClaims(k.namespace)
+	for _, name := range names {
+		err := claims.Delete(name, &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy})
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting volume claim %q", name)
+		}
+	}
+	errClaimExists := errors.New("volume claims still exist")
+	remaining := names
+	err := retry.Call(retry.CallArgs{
+		MaxDuration: deleteClaimsTimeout,
+		Delay:       time.Second,
+		Clock:       k.clock,
+		Func: func() error {
+			var exist []string
+			for _, name := range remaining {
+				_, err := claims.Get(name, v1.GetOptions{})
+				if k8serrors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+				exist = append(exist, name)
+			}
+			remaining = exist
+			if len(remaining) > 0 {
+				return errClaimExists
+			}
+			return nil
+		},
+		IsFatalError: func(err error) bool {
+			return err != errClaimExists
+		},
+	})
+	if retry.IsDurationExceeded(err) {
+		return errors.Errorf("volume claims %s still exist after %v", strings.Join(remaining, ", "), deleteClaimsTimeout)
+	}
+	return errors.Trace(err)
+}
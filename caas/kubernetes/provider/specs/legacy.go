@@ -76,7 +76,7 @@ func (cs *k8sContainersLegacy) Validate() error {
 // k8sPodSpecLegacy is a subset of v1.PodSpec which defines
 // attributes we expose for charms to set.
 type k8sPodSpecLegacy struct {
-	PodSpec                      `json:",inline" yaml:",inline"`
+	PodSpecV3                    `json:",inline" yaml:",inline"`
 	ServiceAccountName           string `json:"serviceAccountName,omitempty" yaml:"serviceAccountName,omitempty"`
 	AutomountServiceAccountToken *bool  `json:"automountServiceAccountToken,omitempty" yaml:"automountServiceAccountToken,omitempty"`
 
@@ -19,7 +19,7 @@ var logger = loggo.GetLogger("juju.kubernetes.provider.specs")
 
 type (
 	// K8sPodSpec is the current k8s pod spec.
-	K8sPodSpec = K8sPodSpecV3
+	K8sPodSpec = K8sPodSpecV4
 )
 
 type k8sContainer struct {
@@ -78,9 +78,9 @@ func (*K8sContainerSpec) Validate() error {
 	return nil
 }
 
-// PodSpec is a subset of v1.PodSpec which defines
-// attributes we expose for charms to set.
-type PodSpec struct {
+// PodSpecV3 is a subset of v1.PodSpec which defines
+// attributes we expose for charms to set in pod spec versions up to 3.
+type PodSpecV3 struct {
 	RestartPolicy                 core.RestartPolicy       `json:"restartPolicy,omitempty" yaml:"restartPolicy,omitempty"`
 	ActiveDeadlineSeconds         *int64                   `json:"activeDeadlineSeconds,omitempty" yaml:"activeDeadlineSeconds,omitempty"`
 	TerminationGracePeriodSeconds *int64                   `json:"terminationGracePeriodSeconds,omitempty" yaml:"terminationGracePeriodSeconds,omitempty"`
@@ -91,14 +91,20 @@ type PodSpec struct {
 	HostPID                       bool                     `json:"hostPID,omitempty" yaml:"hostPID,omitempty"`
 }
 
-// IsEmpty checks if PodSpec is empty or not.
-func (ps PodSpec) IsEmpty() bool {
-	return ps.RestartPolicy == "" &&
-		ps.ActiveDeadlineSeconds == nil &&
-		ps.TerminationGracePeriodSeconds == nil &&
-		ps.SecurityContext == nil &&
-		len(ps.ReadinessGates) == 0 &&
-		ps.DNSPolicy == ""
+func (ps *PodSpecV3) toLatest() *PodSpec {
+	if ps == nil {
+		return nil
+	}
+	return &PodSpec{
+		RestartPolicy:                 ps.RestartPolicy,
+		ActiveDeadlineSeconds:         ps.ActiveDeadlineSeconds,
+		TerminationGracePeriodSeconds: ps.TerminationGracePeriodSeconds,
+		SecurityContext:               ps.SecurityContext,
+		ReadinessGates:                ps.ReadinessGates,
+		DNSPolicy:                     ps.DNSPolicy,
+		HostNetwork:                   ps.HostNetwork,
+		HostPID:                       ps.HostPID,
+	}
 }
 
 type k8sContainers struct {
@@ -194,6 +200,8 @@ type PodSpecConverter interface {
 
 func getParser(specVersion specs.Version) (parserType, error) {
 	switch specVersion {
+	case specs.Version4:
+		return parsePodSpecV4, nil
 	case specs.Version3:
 		return parsePodSpecV3, nil
 	case specs.Version2:
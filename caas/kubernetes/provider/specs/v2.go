@@ -197,7 +197,7 @@ func (ing K8sIngressSpec) Validate() error {
 
 // KubernetesResourcesV2 is the k8s related resources for version 2.
 type KubernetesResourcesV2 struct {
-	Pod *PodSpec `json:"pod,omitempty" yaml:"pod,omitempty"`
+	Pod *PodSpecV3 `json:"pod,omitempty" yaml:"pod,omitempty"`
 
 	Secrets                   []Secret                                                     `json:"secrets" yaml:"secrets"`
 	CustomResourceDefinitions map[string]apiextensionsv1beta1.CustomResourceDefinitionSpec `json:"customResourceDefinitions,omitempty" yaml:"customResourceDefinitions,omitempty"`
@@ -222,7 +222,7 @@ func validateCustomResourceDefinitionV2(name string, crd apiextensionsv1beta1.Cu
 
 func (krs *KubernetesResourcesV2) toLatest() *KubernetesResources {
 	out := &KubernetesResources{
-		Pod:                       krs.Pod,
+		Pod:                       krs.Pod.toLatest(),
 		Secrets:                   krs.Secrets,
 		CustomResourceDefinitions: customResourceDefinitionsToLatest(krs.CustomResourceDefinitions),
 		CustomResources:           krs.CustomResources,
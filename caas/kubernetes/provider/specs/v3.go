@@ -47,7 +47,11 @@ func (p podSpecV3) ToLatest() *specs.PodSpec {
 	pSpec.Service = p.caaSSpecV3.Service
 	pSpec.ConfigMaps = p.caaSSpecV3.ConfigMaps
 	pSpec.ServiceAccount = p.caaSSpecV3.ServiceAccount
-	pSpec.ProviderPod = &p.K8sPodSpecV3
+	iPodSpec := &K8sPodSpec{}
+	if p.K8sPodSpecV3.KubernetesResources != nil {
+		iPodSpec.KubernetesResources = p.K8sPodSpecV3.KubernetesResources.toLatest()
+	}
+	pSpec.ProviderPod = iPodSpec
 	return pSpec
 }
 
@@ -296,9 +300,9 @@ func (w K8sValidatingWebhookSpec) Validate() error {
 	return nil
 }
 
-// KubernetesResources is the k8s related resources.
-type KubernetesResources struct {
-	Pod *PodSpec `json:"pod,omitempty" yaml:"pod,omitempty"`
+// KubernetesResourcesV3 is the k8s related resources for version 3.
+type KubernetesResourcesV3 struct {
+	Pod *PodSpecV3 `json:"pod,omitempty" yaml:"pod,omitempty"`
 
 	Secrets                   []Secret                               `json:"secrets" yaml:"secrets"`
 	CustomResourceDefinitions []K8sCustomResourceDefinitionSpec      `json:"customResourceDefinitions" yaml:"customResourceDefinitions"`
@@ -312,8 +316,21 @@ type KubernetesResources struct {
 	IngressResources []K8sIngressSpec `json:"ingressResources,omitempty" yaml:"ingressResources,omitempty"`
 }
 
+func (krs *KubernetesResourcesV3) toLatest() *KubernetesResources {
+	return &KubernetesResources{
+		Pod:                             krs.Pod.toLatest(),
+		Secrets:                         krs.Secrets,
+		CustomResourceDefinitions:       krs.CustomResourceDefinitions,
+		CustomResources:                 krs.CustomResources,
+		MutatingWebhookConfigurations:   krs.MutatingWebhookConfigurations,
+		ValidatingWebhookConfigurations: krs.ValidatingWebhookConfigurations,
+		K8sRBACResources:                krs.K8sRBACResources,
+		IngressResources:                krs.IngressResources,
+	}
+}
+
 // Validate is defined on ProviderPod.
-func (krs *KubernetesResources) Validate() error {
+func (krs *KubernetesResourcesV3) Validate() error {
 	for _, crd := range krs.CustomResourceDefinitions {
 		if err := crd.Validate(); err != nil {
 			return errors.Trace(err)
@@ -352,7 +369,7 @@ func (krs *KubernetesResources) Validate() error {
 // attributes we expose for charms to set.
 type K8sPodSpecV3 struct {
 	// k8s resources.
-	KubernetesResources *KubernetesResources `json:"kubernetesResources,omitempty" yaml:"kubernetesResources,omitempty"`
+	KubernetesResources *KubernetesResourcesV3 `json:"kubernetesResources,omitempty" yaml:"kubernetesResources,omitempty"`
 }
 
 // Validate is defined on ProviderPod.
@@ -0,0 +1,376 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/juju/juju/caas/specs"
+)
+
+type caaSSpecV4 = specs.PodSpecV4
+
+type podSpecV4 struct {
+	caaSSpecV4    `json:",inline" yaml:",inline"`
+	K8sPodSpecV4  `json:",inline" yaml:",inline"`
+	k8sContainers `json:",inline" yaml:",inline"`
+}
+
+// Validate is defined on ProviderPod.
+func (p podSpecV4) Validate() error {
+	if err := p.K8sPodSpecV4.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.k8sContainers.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func (p podSpecV4) ToLatest() *specs.PodSpec {
+	pSpec := &specs.PodSpec{}
+	pSpec.Version = specs.CurrentVersion
+	for _, c := range p.Containers {
+		pSpec.Containers = append(pSpec.Containers, c.ToContainerSpec())
+	}
+	pSpec.Service = p.caaSSpecV4.Service
+	pSpec.ConfigMaps = p.caaSSpecV4.ConfigMaps
+	pSpec.ServiceAccount = p.caaSSpecV4.ServiceAccount
+	pSpec.ProviderPod = &p.K8sPodSpecV4
+	return pSpec
+}
+
+// PodSpec is a subset of v1.PodSpec which defines
+// attributes we expose for charms to set.
+type PodSpec struct {
+	RestartPolicy                 core.RestartPolicy       `json:"restartPolicy,omitempty" yaml:"restartPolicy,omitempty"`
+	ActiveDeadlineSeconds         *int64                   `json:"activeDeadlineSeconds,omitempty" yaml:"activeDeadlineSeconds,omitempty"`
+	TerminationGracePeriodSeconds *int64                   `json:"terminationGracePeriodSeconds,omitempty" yaml:"terminationGracePeriodSeconds,omitempty"`
+	SecurityContext               *core.PodSecurityContext `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
+	ReadinessGates                []core.PodReadinessGate  `json:"readinessGates,omitempty" yaml:"readinessGates,omitempty"`
+	DNSPolicy                     core.DNSPolicy           `json:"dnsPolicy,omitempty" yaml:"dnsPolicy,omitempty"`
+	HostNetwork                   bool                     `json:"hostNetwork,omitempty" yaml:"hostNetwork,omitempty"`
+	HostPID                       bool                     `json:"hostPID,omitempty" yaml:"hostPID,omitempty"`
+
+	Affinity                  *core.Affinity                  `json:"affinity,omitempty" yaml:"affinity,omitempty"`
+	Tolerations               []core.Toleration               `json:"tolerations,omitempty" yaml:"tolerations,omitempty"`
+	TopologySpreadConstraints []core.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty" yaml:"topologySpreadConstraints,omitempty"`
+	PriorityClassName         string                          `json:"priorityClassName,omitempty" yaml:"priorityClassName,omitempty"`
+}
+
+// IsEmpty checks if PodSpec is empty or not.
+func (ps PodSpec) IsEmpty() bool {
+	return ps.RestartPolicy == "" &&
+		ps.ActiveDeadlineSeconds == nil &&
+		ps.TerminationGracePeriodSeconds == nil &&
+		ps.SecurityContext == nil &&
+		len(ps.ReadinessGates) == 0 &&
+		ps.DNSPolicy == "" &&
+		ps.Affinity == nil &&
+		len(ps.Tolerations) == 0 &&
+		len(ps.TopologySpreadConstraints) == 0 &&
+		ps.PriorityClassName == ""
+}
+
+// Validate returns an error if the spec is not valid.
+func (ps PodSpec) Validate() error {
+	for _, t := range ps.Tolerations {
+		if err := validateToleration(t); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, tsc := range ps.TopologySpreadConstraints {
+		if tsc.MaxSkew <= 0 {
+			return errors.NotValidf("topology spread constraint maxSkew %d", tsc.MaxSkew)
+		}
+		if tsc.TopologyKey == "" {
+			return errors.New("topology spread constraint topologyKey is missing")
+		}
+		if tsc.WhenUnsatisfiable != core.DoNotSchedule && tsc.WhenUnsatisfiable != core.ScheduleAnyway {
+			return errors.NotSupportedf("topology spread constraint whenUnsatisfiable %q", tsc.WhenUnsatisfiable)
+		}
+	}
+	if ps.PriorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(ps.PriorityClassName); len(errs) != 0 {
+			return errors.NotValidf("priority class name %q: %s", ps.PriorityClassName, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+func validateToleration(t core.Toleration) error {
+	switch t.Operator {
+	case core.TolerationOpExists:
+		if t.Value != "" {
+			return errors.NotValidf("toleration %q with operator %q and value %q", t.Key, t.Operator, t.Value)
+		}
+	case core.TolerationOpEqual, "":
+		if t.Key == "" {
+			return errors.NotValidf("toleration with operator %q and empty key", core.TolerationOpEqual)
+		}
+	default:
+		return errors.NotSupportedf("toleration %q operator %q", t.Key, t.Operator)
+	}
+	switch t.Effect {
+	case "", core.TaintEffectNoSchedule, core.TaintEffectPreferNoSchedule, core.TaintEffectNoExecute:
+	default:
+		return errors.NotSupportedf("toleration %q effect %q", t.Key, t.Effect)
+	}
+	if t.TolerationSeconds != nil && t.Effect != core.TaintEffectNoExecute {
+		return errors.NotValidf("toleration %q tolerationSeconds with effect %q", t.Key, t.Effect)
+	}
+	return nil
+}
+
+// validateIntOrPercent returns an error if the value is
+// neither a non-negative integer nor a percentage.
+func validateIntOrPercent(name string, v *intstr.IntOrString) error {
+	if v == nil {
+		return nil
+	}
+	if v.Type == intstr.Int {
+		if v.IntVal < 0 {
+			return errors.NotValidf("negative %s %d", name, v.IntVal)
+		}
+		return nil
+	}
+	if errs := validation.IsValidPercent(v.StrVal); len(errs) != 0 {
+		return errors.NotValidf("%s %q: %s", name, v.StrVal, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// PodDisruptionBudgetSpec defines the spec for creating a pod disruption
+// budget covering all the pods of an application.
+type PodDisruptionBudgetSpec struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+}
+
+// Validate returns an error if the spec is not valid.
+func (pdb PodDisruptionBudgetSpec) Validate() error {
+	if pdb.MinAvailable == nil && pdb.MaxUnavailable == nil {
+		return errors.New("pod disruption budget requires either minAvailable or maxUnavailable")
+	}
+	if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		return errors.New("pod disruption budget cannot specify both minAvailable and maxUnavailable")
+	}
+	if err := validateIntOrPercent("minAvailable", pdb.MinAvailable); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validateIntOrPercent("maxUnavailable", pdb.MaxUnavailable))
+}
+
+// RollingUpdateSpec defines the parameters of a rolling update.
+// Which of them apply depends on the workload type of the application.
+type RollingUpdateSpec struct {
+	Partition      *int32              `json:"partition,omitempty" yaml:"partition,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty" yaml:"maxSurge,omitempty"`
+}
+
+// UpdateStrategy defines how the pods of an application are replaced
+// when the pod spec changes.
+type UpdateStrategy struct {
+	Type          string             `json:"type" yaml:"type"`
+	RollingUpdate *RollingUpdateSpec `json:"rollingUpdate,omitempty" yaml:"rollingUpdate,omitempty"`
+}
+
+const (
+	rollingUpdateStrategy = "RollingUpdate"
+	recreateStrategy      = "Recreate"
+	onDeleteStrategy      = "OnDelete"
+)
+
+// Validate returns an error if the spec is not valid.
+func (us UpdateStrategy) Validate() error {
+	switch us.Type {
+	case rollingUpdateStrategy:
+	case recreateStrategy, onDeleteStrategy:
+		if us.RollingUpdate != nil {
+			return errors.NotValidf("rollingUpdate for update strategy %q", us.Type)
+		}
+		return nil
+	case "":
+		return errors.New("update strategy type is missing")
+	default:
+		return errors.NotSupportedf("update strategy %q", us.Type)
+	}
+	if us.RollingUpdate == nil {
+		return nil
+	}
+	if p := us.RollingUpdate.Partition; p != nil && *p < 0 {
+		return errors.NotValidf("negative rolling update partition %d", *p)
+	}
+	if err := validateIntOrPercent("maxUnavailable", us.RollingUpdate.MaxUnavailable); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(validateIntOrPercent("maxSurge", us.RollingUpdate.MaxSurge))
+}
+
+// ToDeploymentStrategy converts the update strategy to a deployment strategy.
+func (us UpdateStrategy) ToDeploymentStrategy() (apps.DeploymentStrategy, error) {
+	switch us.Type {
+	case recreateStrategy:
+		return apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType}, nil
+	case rollingUpdateStrategy:
+		out := apps.DeploymentStrategy{Type: apps.RollingUpdateDeploymentStrategyType}
+		if ru := us.RollingUpdate; ru != nil {
+			if ru.Partition != nil {
+				return apps.DeploymentStrategy{}, errors.NotSupportedf("rolling update partition for deployment")
+			}
+			out.RollingUpdate = &apps.RollingUpdateDeployment{
+				MaxUnavailable: ru.MaxUnavailable,
+				MaxSurge:       ru.MaxSurge,
+			}
+		}
+		return out, nil
+	}
+	return apps.DeploymentStrategy{}, errors.NotSupportedf("update strategy %q for deployment", us.Type)
+}
+
+// ToStatefulSetUpdateStrategy converts the update strategy to a stateful set update strategy.
+func (us UpdateStrategy) ToStatefulSetUpdateStrategy() (apps.StatefulSetUpdateStrategy, error) {
+	switch us.Type {
+	case onDeleteStrategy:
+		return apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType}, nil
+	case rollingUpdateStrategy:
+		out := apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType}
+		if ru := us.RollingUpdate; ru != nil {
+			if ru.MaxUnavailable != nil || ru.MaxSurge != nil {
+				return apps.StatefulSetUpdateStrategy{}, errors.NotSupportedf("rolling update maxUnavailable or maxSurge for stateful set")
+			}
+			out.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
+				Partition: ru.Partition,
+			}
+		}
+		return out, nil
+	}
+	return apps.StatefulSetUpdateStrategy{}, errors.NotSupportedf("update strategy %q for stateful set", us.Type)
+}
+
+// ToDaemonSetUpdateStrategy converts the update strategy to a daemon set update strategy.
+func (us UpdateStrategy) ToDaemonSetUpdateStrategy() (apps.DaemonSetUpdateStrategy, error) {
+	switch us.Type {
+	case onDeleteStrategy:
+		return apps.DaemonSetUpdateStrategy{Type: apps.OnDeleteDaemonSetStrategyType}, nil
+	case rollingUpdateStrategy:
+		out := apps.DaemonSetUpdateStrategy{Type: apps.RollingUpdateDaemonSetStrategyType}
+		if ru := us.RollingUpdate; ru != nil {
+			if ru.Partition != nil || ru.MaxSurge != nil {
+				return apps.DaemonSetUpdateStrategy{}, errors.NotSupportedf("rolling update partition or maxSurge for daemon set")
+			}
+			out.RollingUpdate = &apps.RollingUpdateDaemonSet{
+				MaxUnavailable: ru.MaxUnavailable,
+			}
+		}
+		return out, nil
+	}
+	return apps.DaemonSetUpdateStrategy{}, errors.NotSupportedf("update strategy %q for daemon set", us.Type)
+}
+
+// KubernetesResources is the k8s related resources.
+type KubernetesResources struct {
+	Pod *PodSpec `json:"pod,omitempty" yaml:"pod,omitempty"`
+
+	Secrets                   []Secret                               `json:"secrets" yaml:"secrets"`
+	CustomResourceDefinitions []K8sCustomResourceDefinitionSpec      `json:"customResourceDefinitions" yaml:"customResourceDefinitions"`
+	CustomResources           map[string][]unstructured.Unstructured `json:"customResources,omitempty" yaml:"customResources,omitempty"`
+
+	MutatingWebhookConfigurations   []K8sMutatingWebhookSpec   `json:"mutatingWebhookConfigurations,omitempty" yaml:"mutatingWebhookConfigurations,omitempty"`
+	ValidatingWebhookConfigurations []K8sValidatingWebhookSpec `json:"validatingWebhookConfigurations,omitempty" yaml:"validatingWebhookConfigurations,omitempty"`
+
+	K8sRBACResources `json:",inline" yaml:",inline"`
+
+	IngressResources []K8sIngressSpec `json:"ingressResources,omitempty" yaml:"ingressResources,omitempty"`
+
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty" yaml:"podDisruptionBudget,omitempty"`
+	UpdateStrategy      *UpdateStrategy          `json:"updateStrategy,omitempty" yaml:"updateStrategy,omitempty"`
+}
+
+// Validate is defined on ProviderPod.
+func (krs *KubernetesResources) Validate() error {
+	if krs.Pod != nil {
+		if err := krs.Pod.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for _, crd := range krs.CustomResourceDefinitions {
+		if err := crd.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for k, crs := range krs.CustomResources {
+		if len(crs) == 0 {
+			return errors.NotValidf("empty custom resources %q", k)
+		}
+	}
+
+	for _, webhook := range krs.MutatingWebhookConfigurations {
+		if err := webhook.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for _, webhook := range krs.ValidatingWebhookConfigurations {
+		if err := webhook.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if err := krs.K8sRBACResources.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, ing := range krs.IngressResources {
+		if err := ing.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	if krs.PodDisruptionBudget != nil {
+		if err := krs.PodDisruptionBudget.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if krs.UpdateStrategy != nil {
+		if err := krs.UpdateStrategy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// K8sPodSpecV4 is a subset of v1.PodSpec which defines
+// attributes we expose for charms to set.
+type K8sPodSpecV4 struct {
+	// k8s resources.
+	KubernetesResources *KubernetesResources `json:"kubernetesResources,omitempty" yaml:"kubernetesResources,omitempty"`
+}
+
+// Validate is defined on ProviderPod.
+func (p *K8sPodSpecV4) Validate() error {
+	if p.KubernetesResources != nil {
+		if err := p.KubernetesResources.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func parsePodSpecV4(in string) (_ PodSpecConverter, err error) {
+	var spec podSpecV4
+	decoder := newStrictYAMLOrJSONDecoder(strings.NewReader(in), len(in))
+	if err = decoder.Decode(&spec); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &spec, nil
+}
@@ -0,0 +1,360 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package specs_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/caas/specs"
+	"github.com/juju/juju/testing"
+)
+
+type v4SpecsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&v4SpecsSuite{})
+
+var version4Header = `
+version: 4
+`[1:]
+
+var version4Containers = `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:]
+
+func (s *v4SpecsSuite) TestParse(c *gc.C) {
+	specStr := version4Header + version4Containers + `
+kubernetesResources:
+  pod:
+    restartPolicy: OnFailure
+    priorityClassName: high-priority
+    affinity:
+      podAntiAffinity:
+        requiredDuringSchedulingIgnoredDuringExecution:
+          - topologyKey: kubernetes.io/hostname
+            labelSelector:
+              matchLabels:
+                juju-app: gitlab
+    tolerations:
+      - key: dedicated
+        operator: Equal
+        value: gitlab
+        effect: NoSchedule
+    topologySpreadConstraints:
+      - maxSkew: 1
+        topologyKey: failure-domain.beta.kubernetes.io/zone
+        whenUnsatisfiable: ScheduleAnyway
+  podDisruptionBudget:
+    minAvailable: 2
+  updateStrategy:
+    type: RollingUpdate
+    rollingUpdate:
+      partition: 1
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Version, gc.Equals, specs.Version4)
+	c.Assert(spec.Containers, gc.HasLen, 1)
+
+	minAvailable := intstr.FromInt(2)
+	c.Assert(spec.ProviderPod, jc.DeepEquals, &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Pod: &k8sspecs.PodSpec{
+				RestartPolicy:     core.RestartPolicyOnFailure,
+				PriorityClassName: "high-priority",
+				Affinity: &core.Affinity{
+					PodAntiAffinity: &core.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{{
+							TopologyKey: "kubernetes.io/hostname",
+							LabelSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"juju-app": "gitlab"},
+							},
+						}},
+					},
+				},
+				Tolerations: []core.Toleration{{
+					Key:      "dedicated",
+					Operator: core.TolerationOpEqual,
+					Value:    "gitlab",
+					Effect:   core.TaintEffectNoSchedule,
+				}},
+				TopologySpreadConstraints: []core.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       "failure-domain.beta.kubernetes.io/zone",
+					WhenUnsatisfiable: core.ScheduleAnyway,
+				}},
+			},
+			PodDisruptionBudget: &k8sspecs.PodDisruptionBudgetSpec{
+				MinAvailable: &minAvailable,
+			},
+			UpdateStrategy: &k8sspecs.UpdateStrategy{
+				Type: "RollingUpdate",
+				RollingUpdate: &k8sspecs.RollingUpdateSpec{
+					Partition: int32Ptr(1),
+				},
+			},
+		},
+	})
+}
+
+func (s *v4SpecsSuite) TestParseV3ToLatest(c *gc.C) {
+	specStr := version3Header + version4Containers + `
+kubernetesResources:
+  pod:
+    restartPolicy: OnFailure
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Version, gc.Equals, specs.CurrentVersion)
+	c.Assert(spec.ProviderPod, jc.DeepEquals, &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Pod: &k8sspecs.PodSpec{
+				RestartPolicy: core.RestartPolicyOnFailure,
+			},
+		},
+	})
+}
+
+func (s *v4SpecsSuite) TestVersion3RejectsVersion4Fields(c *gc.C) {
+	for _, field := range []string{
+		`
+  pod:
+    priorityClassName: high-priority
+`[1:],
+		`
+  podDisruptionBudget:
+    minAvailable: 1
+`[1:],
+		`
+  updateStrategy:
+    type: OnDelete
+`[1:],
+	} {
+		specStr := version3Header + version4Containers + "kubernetesResources:\n" + field
+		_, err := k8sspecs.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, `json: unknown field .*`)
+	}
+}
+
+func (s *v4SpecsSuite) TestValidatePodSpec(c *gc.C) {
+	for i, t := range []struct {
+		pod string
+		err string
+	}{{
+		pod: `
+tolerations:
+  - key: dedicated
+    operator: Exists
+    value: gitlab
+`[1:],
+		err: `toleration "dedicated" with operator "Exists" and value "gitlab" not valid`,
+	}, {
+		pod: `
+tolerations:
+  - operator: Equal
+    value: gitlab
+`[1:],
+		err: `toleration with operator "Equal" and empty key not valid`,
+	}, {
+		pod: `
+tolerations:
+  - key: dedicated
+    operator: Exists
+    effect: NoSchedule
+    tolerationSeconds: 10
+`[1:],
+		err: `toleration "dedicated" tolerationSeconds with effect "NoSchedule" not valid`,
+	}, {
+		pod: `
+tolerations:
+  - key: dedicated
+    operator: Exists
+    effect: Sometimes
+`[1:],
+		err: `toleration "dedicated" effect "Sometimes" not supported`,
+	}, {
+		pod: `
+topologySpreadConstraints:
+  - maxSkew: 0
+    topologyKey: kubernetes.io/hostname
+    whenUnsatisfiable: DoNotSchedule
+`[1:],
+		err: `topology spread constraint maxSkew 0 not valid`,
+	}, {
+		pod: `
+topologySpreadConstraints:
+  - maxSkew: 1
+    whenUnsatisfiable: DoNotSchedule
+`[1:],
+		err: `topology spread constraint topologyKey is missing`,
+	}, {
+		pod: `
+topologySpreadConstraints:
+  - maxSkew: 1
+    topologyKey: kubernetes.io/hostname
+`[1:],
+		err: `topology spread constraint whenUnsatisfiable "" not supported`,
+	}, {
+		pod: `
+priorityClassName: High_Priority
+`[1:],
+		err: `priority class name "High_Priority": .* not valid`,
+	}} {
+		c.Logf("#%d: %s", i, t.pod)
+		specStr := version4Header + version4Containers + "kubernetesResources:\n  pod:\n" + indent(t.pod, "    ")
+		_, err := k8sspecs.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *v4SpecsSuite) TestValidatePodDisruptionBudget(c *gc.C) {
+	for i, t := range []struct {
+		pdb string
+		err string
+	}{{
+		pdb: "{}\n",
+		err: `pod disruption budget requires either minAvailable or maxUnavailable`,
+	}, {
+		pdb: "minAvailable: 1\nmaxUnavailable: 1\n",
+		err: `pod disruption budget cannot specify both minAvailable and maxUnavailable`,
+	}, {
+		pdb: "minAvailable: -1\n",
+		err: `negative minAvailable -1 not valid`,
+	}, {
+		pdb: "maxUnavailable: half\n",
+		err: `maxUnavailable "half": .* not valid`,
+	}} {
+		c.Logf("#%d: %s", i, t.pdb)
+		specStr := version4Header + version4Containers + "kubernetesResources:\n  podDisruptionBudget:\n" + indent(t.pdb, "    ")
+		_, err := k8sspecs.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+
+	specStr := version4Header + version4Containers + `
+kubernetesResources:
+  podDisruptionBudget:
+    maxUnavailable: 25%
+`[1:]
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *v4SpecsSuite) TestValidateUpdateStrategy(c *gc.C) {
+	for i, t := range []struct {
+		strategy string
+		err      string
+	}{{
+		strategy: "rollingUpdate:\n  partition: 1\n",
+		err:      `update strategy type is missing`,
+	}, {
+		strategy: "type: Sometimes\n",
+		err:      `update strategy "Sometimes" not supported`,
+	}, {
+		strategy: "type: OnDelete\nrollingUpdate:\n  partition: 1\n",
+		err:      `rollingUpdate for update strategy "OnDelete" not valid`,
+	}, {
+		strategy: "type: RollingUpdate\nrollingUpdate:\n  partition: -1\n",
+		err:      `negative rolling update partition -1 not valid`,
+	}, {
+		strategy: "type: RollingUpdate\nrollingUpdate:\n  maxSurge: lots\n",
+		err:      `maxSurge "lots": .* not valid`,
+	}} {
+		c.Logf("#%d: %s", i, t.strategy)
+		specStr := version4Header + version4Containers + "kubernetesResources:\n  updateStrategy:\n" + indent(t.strategy, "    ")
+		_, err := k8sspecs.ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *v4SpecsSuite) TestUpdateStrategyToDeploymentStrategy(c *gc.C) {
+	maxSurge := intstr.FromString("50%")
+	out, err := k8sspecs.UpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: &k8sspecs.RollingUpdateSpec{MaxSurge: &maxSurge},
+	}.ToDeploymentStrategy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, apps.DeploymentStrategy{
+		Type:          apps.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: &apps.RollingUpdateDeployment{MaxSurge: &maxSurge},
+	})
+
+	out, err = k8sspecs.UpdateStrategy{Type: "Recreate"}.ToDeploymentStrategy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType})
+
+	_, err = k8sspecs.UpdateStrategy{Type: "OnDelete"}.ToDeploymentStrategy()
+	c.Assert(err, gc.ErrorMatches, `update strategy "OnDelete" for deployment not supported`)
+
+	_, err = k8sspecs.UpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: &k8sspecs.RollingUpdateSpec{Partition: int32Ptr(1)},
+	}.ToDeploymentStrategy()
+	c.Assert(err, gc.ErrorMatches, `rolling update partition for deployment not supported`)
+}
+
+func (s *v4SpecsSuite) TestUpdateStrategyToStatefulSetUpdateStrategy(c *gc.C) {
+	out, err := k8sspecs.UpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: &k8sspecs.RollingUpdateSpec{Partition: int32Ptr(2)},
+	}.ToStatefulSetUpdateStrategy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, apps.StatefulSetUpdateStrategy{
+		Type:          apps.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(2)},
+	})
+
+	out, err = k8sspecs.UpdateStrategy{Type: "OnDelete"}.ToStatefulSetUpdateStrategy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType})
+
+	_, err = k8sspecs.UpdateStrategy{Type: "Recreate"}.ToStatefulSetUpdateStrategy()
+	c.Assert(err, gc.ErrorMatches, `update strategy "Recreate" for stateful set not supported`)
+
+	maxSurge := intstr.FromInt(1)
+	_, err = k8sspecs.UpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: &k8sspecs.RollingUpdateSpec{MaxSurge: &maxSurge},
+	}.ToStatefulSetUpdateStrategy()
+	c.Assert(err, gc.ErrorMatches, `rolling update maxUnavailable or maxSurge for stateful set not supported`)
+}
+
+func (s *v4SpecsSuite) TestUpdateStrategyToDaemonSetUpdateStrategy(c *gc.C) {
+	maxUnavailable := intstr.FromInt(1)
+	out, err := k8sspecs.UpdateStrategy{
+		Type:          "RollingUpdate",
+		RollingUpdate: &k8sspecs.RollingUpdateSpec{MaxUnavailable: &maxUnavailable},
+	}.ToDaemonSetUpdateStrategy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, jc.DeepEquals, apps.DaemonSetUpdateStrategy{
+		Type:          apps.RollingUpdateDaemonSetStrategyType,
+		RollingUpdate: &apps.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+	})
+
+	_, err = k8sspecs.UpdateStrategy{Type: "Recreate"}.ToDaemonSetUpdateStrategy()
+	c.Assert(err, gc.ErrorMatches, `update strategy "Recreate" for daemon set not supported`)
+}
+
+func indent(s, prefix string) string {
+	var out string
+	for _, line := range strings.SplitAfter(s, "\n") {
+		if line == "" {
+			continue
+		}
+		out += prefix + line
+	}
+	return out
+}
@@ -63,6 +63,11 @@ func (k *kubernetesClient) configureStatefulSet(
 			ServiceName:         headlessServiceName(deploymentName),
 		},
 	}
+	if workloadSpec.UpdateStrategy != nil {
+		if statefulSet.Spec.UpdateStrategy, err = workloadSpec.UpdateStrategy.ToStatefulSetUpdateStrategy(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := k.configurePodFiles(appName, annotations, workloadSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
 	}
@@ -112,6 +117,9 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
 	existing.Spec.Template.Spec.ServiceAccountName = existingPodSpec.ServiceAccountName
 	existing.Spec.Template.Spec.AutomountServiceAccountToken = existingPodSpec.AutomountServiceAccountToken
+	existing.Spec.Template.Spec.NodeSelector = existingPodSpec.NodeSelector
+	existing.Spec.Template.Spec.Tolerations = existingPodSpec.Tolerations
+	existing.Spec.Template.Spec.PriorityClassName = existingPodSpec.PriorityClassName
 	// NB: we can't update the Spec.ServiceName as it is immutable.
 	_, err = k.updateStatefulSet(existing)
 	return errors.Trace(err)
@@ -0,0 +1,428 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package testing provides an in-process fake k8s cluster, so that the
+// k8s broker and the workers using it can be exercised end to end in
+// unit tests without a real cluster.
+package testing
+
+import (
+	"fmt"
+	"sync"
+
+	jujuclock "github.com/juju/clock"
+	"github.com/juju/errors"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/environs/config"
+)
+
+// FakeCluster is an in-process k8s cluster. Its clients store objects in
+// memory and support watches, but no controllers run in the cluster, so
+// pods are not started and claims are not bound until the test asks for
+// it by calling SyncWorkloads, SetPodPhase and BindClaim.
+type FakeCluster struct {
+	// Namespace is the namespace managed by brokers
+	// created for the cluster.
+	Namespace string
+
+	// Clientset is the fake client for the core k8s API.
+	Clientset *fake.Clientset
+
+	// APIExtensions is the fake client for the
+	// custom resource definitions API.
+	APIExtensions *apiextensionsfake.Clientset
+
+	// Dynamic is the fake client for custom resources.
+	Dynamic *dynamicfake.FakeDynamicClient
+
+	mu      sync.Mutex
+	nextUID int
+	nextIP  int
+	nodes   []string
+}
+
+// NewFakeCluster returns a fake cluster with the specified namespace,
+// initially holding the given objects.
+func NewFakeCluster(namespace string, objects ...runtime.Object) *FakeCluster {
+	return &FakeCluster{
+		Namespace:     namespace,
+		Clientset:     fake.NewSimpleClientset(objects...),
+		APIExtensions: apiextensionsfake.NewSimpleClientset(),
+		Dynamic:       dynamicfake.NewSimpleDynamicClient(runtime.NewScheme()),
+	}
+}
+
+// NewBroker returns a k8s broker for the model with the
+// specified config, which manages the cluster's namespace.
+func (f *FakeCluster) NewBroker(controllerUUID string, cfg *config.Config, clock jujuclock.Clock) (caas.Broker, error) {
+	return provider.NewBrokerForClients(
+		controllerUUID, cfg, f.Namespace, f.Clientset, f.APIExtensions, f.Dynamic, clock,
+	)
+}
+
+// uid returns a new unique id for an object created by the cluster.
+func (f *FakeCluster) uid() k8stypes.UID {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextUID++
+	return k8stypes.UID(fmt.Sprintf("uid-%d", f.nextUID))
+}
+
+// podIP returns a new address for a pod.
+func (f *FakeCluster) podIP() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.nextIP++
+	return fmt.Sprintf("10.1.%d.%d", f.nextIP/256, f.nextIP%256)
+}
+
+// AddNode adds a ready node with the specified name and labels to
+// the cluster. Pods started by SyncWorkloads are spread across the
+// nodes, in the order they were added.
+func (f *FakeCluster) AddNode(name string, labels map[string]string) error {
+	node := &core.Node{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			UID:    f.uid(),
+			Labels: labels,
+		},
+		Status: core.NodeStatus{
+			Conditions: []core.NodeCondition{{
+				Type:   core.NodeReady,
+				Status: core.ConditionTrue,
+			}},
+		},
+	}
+	if _, err := f.Clientset.CoreV1().Nodes().Create(node); err != nil {
+		return errors.Trace(err)
+	}
+	f.mu.Lock()
+	f.nodes = append(f.nodes, name)
+	f.mu.Unlock()
+	return nil
+}
+
+// FailNode marks the named node as not ready, and the
+// pods running on it as lost, as the cluster would when
+// it stops hearing from the node.
+func (f *FakeCluster) FailNode(name string) error {
+	nodes := f.Clientset.CoreV1().Nodes()
+	node, err := nodes.Get(name, v1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	node.Status.Conditions = []core.NodeCondition{{
+		Type:    core.NodeReady,
+		Status:  core.ConditionUnknown,
+		Reason:  "NodeStatusUnknown",
+		Message: "Kubelet stopped posting node status.",
+	}}
+	if _, err := nodes.UpdateStatus(node); err != nil {
+		return errors.Trace(err)
+	}
+	pods, err := f.Clientset.CoreV1().Pods(f.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != name {
+			continue
+		}
+		pod.Status.Phase = core.PodUnknown
+		pod.Status.Reason = "NodeLost"
+		pod.Status.Message = fmt.Sprintf("Node %s which was running pod %s is unresponsive", name, pod.Name)
+		if err := f.updatePodStatus(&pod); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SyncWorkloads does the job of the workload controllers of a real
+// cluster. Pods are created or removed so that each stateful set and
+// deployment in the namespace runs as many pods as it has replicas,
+// along with any claims for the volumes of stateful set pods. New pods
+// are pending until SetPodPhase is called, and new claims are pending
+// until BindClaim is called.
+func (f *FakeCluster) SyncWorkloads() error {
+	statefulSets, err := f.Clientset.AppsV1().StatefulSets(f.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, ss := range statefulSets.Items {
+		owner := v1.OwnerReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: ss.Name, UID: ss.UID}
+		if err := f.syncPods(owner, ss.Spec.Replicas, ss.Spec.Template, ss.Spec.VolumeClaimTemplates); err != nil {
+			return errors.Annotatef(err, "stateful set %q", ss.Name)
+		}
+	}
+	deployments, err := f.Clientset.AppsV1().Deployments(f.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, deployment := range deployments.Items {
+		owner := v1.OwnerReference{APIVersion: "apps/v1", Kind: "Deployment", Name: deployment.Name, UID: deployment.UID}
+		if err := f.syncPods(owner, deployment.Spec.Replicas, deployment.Spec.Template, nil); err != nil {
+			return errors.Annotatef(err, "deployment %q", deployment.Name)
+		}
+	}
+	return nil
+}
+
+// syncPods creates or removes the pods of the workload
+// with the specified owner, so that there are as many as
+// the workload's replicas.
+func (f *FakeCluster) syncPods(
+	owner v1.OwnerReference, replicas *int32, template core.PodTemplateSpec, claimTemplates []core.PersistentVolumeClaim,
+) error {
+	want := 1
+	if replicas != nil {
+		want = int(*replicas)
+	}
+	pods := f.Clientset.CoreV1().Pods(f.Namespace)
+	existing, err := f.ownedPods(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := 0; i < want; i++ {
+		name := fmt.Sprintf("%s-%d", owner.Name, i)
+		if _, ok := existing[name]; ok {
+			delete(existing, name)
+			continue
+		}
+		pod := &core.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:            name,
+				Namespace:       f.Namespace,
+				UID:             f.uid(),
+				Labels:          template.Labels,
+				Annotations:     template.Annotations,
+				OwnerReferences: []v1.OwnerReference{owner},
+			},
+			Spec: *template.Spec.DeepCopy(),
+			Status: core.PodStatus{
+				Phase: core.PodPending,
+			},
+		}
+		pod.Spec.NodeName = f.nodeFor(i)
+		for _, claimTemplate := range claimTemplates {
+			claimName := fmt.Sprintf("%s-%s", claimTemplate.Name, name)
+			if err := f.ensureClaim(claimName, claimTemplate); err != nil {
+				return errors.Trace(err)
+			}
+			pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+				Name: claimTemplate.Name,
+				VolumeSource: core.VolumeSource{
+					PersistentVolumeClaim: &core.PersistentVolumeClaimVolumeSource{ClaimName: claimName},
+				},
+			})
+		}
+		if _, err := pods.Create(pod); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// Any pods left over are beyond the workload's replicas.
+	for name := range existing {
+		if err := pods.Delete(name, &v1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(f.updateWorkloadStatus(owner))
+}
+
+// ownedPods returns the pods of the workload
+// with the specified owner, keyed by name.
+func (f *FakeCluster) ownedPods(owner v1.OwnerReference) (map[string]core.Pod, error) {
+	pods, err := f.Clientset.CoreV1().Pods(f.Namespace).List(v1.ListOptions{})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]core.Pod)
+	for _, pod := range pods.Items {
+		for _, ref := range pod.OwnerReferences {
+			if ref.Kind == owner.Kind && ref.Name == owner.Name {
+				result[pod.Name] = pod
+			}
+		}
+	}
+	return result, nil
+}
+
+// nodeFor returns the node on which to run the
+// pod with the specified ordinal, if there are nodes.
+func (f *FakeCluster) nodeFor(ordinal int) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.nodes) == 0 {
+		return ""
+	}
+	return f.nodes[ordinal%len(f.nodes)]
+}
+
+// ensureClaim creates a pending claim from
+// the template, if it does not already exist.
+func (f *FakeCluster) ensureClaim(name string, template core.PersistentVolumeClaim) error {
+	claim := template.DeepCopy()
+	claim.Name = name
+	claim.Namespace = f.Namespace
+	claim.UID = f.uid()
+	claim.Status = core.PersistentVolumeClaimStatus{Phase: core.ClaimPending}
+	_, err := f.Clientset.CoreV1().PersistentVolumeClaims(f.Namespace).Create(claim)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// BindClaim provisions a volume for the named
+// claim, and binds the claim to it.
+func (f *FakeCluster) BindClaim(name string) error {
+	claims := f.Clientset.CoreV1().PersistentVolumeClaims(f.Namespace)
+	claim, err := claims.Get(name, v1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volume := &core.PersistentVolume{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "pvc-" + string(claim.UID),
+			UID:  f.uid(),
+		},
+		Spec: core.PersistentVolumeSpec{
+			Capacity:         claim.Spec.Resources.Requests,
+			AccessModes:      claim.Spec.AccessModes,
+			StorageClassName: stringValue(claim.Spec.StorageClassName),
+			ClaimRef: &core.ObjectReference{
+				Kind:      "PersistentVolumeClaim",
+				Namespace: f.Namespace,
+				Name:      claim.Name,
+				UID:       claim.UID,
+			},
+		},
+		Status: core.PersistentVolumeStatus{Phase: core.VolumeBound},
+	}
+	if _, err := f.Clientset.CoreV1().PersistentVolumes().Create(volume); err != nil {
+		return errors.Trace(err)
+	}
+	claim.Spec.VolumeName = volume.Name
+	claim.Status.Phase = core.ClaimBound
+	claim.Status.Capacity = claim.Spec.Resources.Requests
+	_, err = claims.Update(claim)
+	return errors.Trace(err)
+}
+
+// volumeSnapshotResource is the resource of the
+// CSI volume snapshots in the cluster.
+var volumeSnapshotResource = schema.GroupVersionResource{
+	Group:    "snapshot.storage.k8s.io",
+	Version:  "v1beta1",
+	Resource: "volumesnapshots",
+}
+
+// SetSnapshotReady reports the named volume
+// snapshot as ready to use, or not.
+func (f *FakeCluster) SetSnapshotReady(name string, ready bool) error {
+	snapshots := f.Dynamic.Resource(volumeSnapshotResource).Namespace(f.Namespace)
+	obj, err := snapshots.Get(name, v1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := unstructured.SetNestedField(obj.Object, ready, "status", "readyToUse"); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = snapshots.Update(obj, v1.UpdateOptions{})
+	return errors.Trace(err)
+}
+
+// SetPodPhase sets the phase of the named pod. A running pod is
+// given an address and reported as ready, and the ready replicas
+// of the workload it belongs to are updated.
+func (f *FakeCluster) SetPodPhase(name string, phase core.PodPhase) error {
+	pod, err := f.Clientset.CoreV1().Pods(f.Namespace).Get(name, v1.GetOptions{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pod.Status.Phase = phase
+	pod.Status.Reason = ""
+	pod.Status.Message = ""
+	ready := core.ConditionFalse
+	if phase == core.PodRunning {
+		ready = core.ConditionTrue
+		if pod.Status.PodIP == "" {
+			pod.Status.PodIP = f.podIP()
+		}
+	}
+	pod.Status.Conditions = []core.PodCondition{{Type: core.PodReady, Status: ready}}
+	if err := f.updatePodStatus(pod); err != nil {
+		return errors.Trace(err)
+	}
+	for _, owner := range pod.OwnerReferences {
+		if err := f.updateWorkloadStatus(owner); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (f *FakeCluster) updatePodStatus(pod *core.Pod) error {
+	_, err := f.Clientset.CoreV1().Pods(f.Namespace).UpdateStatus(pod)
+	return errors.Trace(err)
+}
+
+// updateWorkloadStatus sets the replica counts in the status of the
+// workload with the specified owner from the phases of its pods.
+func (f *FakeCluster) updateWorkloadStatus(owner v1.OwnerReference) error {
+	pods, err := f.ownedPods(owner)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	replicas, ready := int32(len(pods)), int32(0)
+	for _, pod := range pods {
+		if pod.Status.Phase == core.PodRunning {
+			ready++
+		}
+	}
+	switch owner.Kind {
+	case "StatefulSet":
+		statefulSets := f.Clientset.AppsV1().StatefulSets(f.Namespace)
+		ss, err := statefulSets.Get(owner.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		ss.Status = apps.StatefulSetStatus{Replicas: replicas, ReadyReplicas: ready, CurrentReplicas: replicas}
+		_, err = statefulSets.UpdateStatus(ss)
+		return errors.Trace(err)
+	case "Deployment":
+		deployments := f.Clientset.AppsV1().Deployments(f.Namespace)
+		deployment, err := deployments.Get(owner.Name, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		deployment.Status = apps.DeploymentStatus{Replicas: replicas, ReadyReplicas: ready, AvailableReplicas: ready}
+		_, err = deployments.UpdateStatus(deployment)
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -12,6 +12,7 @@ import (
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	appstyped "k8s.io/client-go/kubernetes/typed/apps/v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/cloudconfig/podcfg"
 	k8sannotations "github.com/juju/juju/core/annotations"
 )
@@ -36,7 +37,9 @@ func (k *kubernetesClient) Upgrade(agentTag string, vers version.Number) error {
 	return errors.NotImplementedf("k8s upgrade for agent tag %q", agentTag)
 }
 
-func upgradeDeployment(name, imagePath string, vers version.Number, broker appstyped.DeploymentInterface) error {
+func upgradeDeployment(
+	name, imagePath string, vers version.Number, podConfig *caas.OperatorPodConfig, broker appstyped.DeploymentInterface,
+) error {
 	de, err := broker.Get(name, meta.GetOptions{})
 	if k8serrors.IsNotFound(err) {
 		return errors.NotFoundf(
@@ -51,6 +54,9 @@ func upgradeDeployment(name, imagePath string, vers version.Number, broker appst
 		return errors.Annotatef(err, "deployment %q", name)
 	}
 	de.Spec.Template = *updatedTemplateSpec
+	if err := applyOperatorPodConfig(&de.Spec.Template.Spec, podConfig); err != nil {
+		return errors.Annotatef(err, "deployment %q", name)
+	}
 
 	// update juju-version annotation.
 	// TODO(caas): consider how to upgrade to current annotations format safely.
@@ -71,7 +77,9 @@ func upgradeDeployment(name, imagePath string, vers version.Number, broker appst
 	return nil
 }
 
-func upgradeStatefulSet(name, imagePath string, vers version.Number, broker appstyped.StatefulSetInterface) error {
+func upgradeStatefulSet(
+	name, imagePath string, vers version.Number, podConfig *caas.OperatorPodConfig, broker appstyped.StatefulSetInterface,
+) error {
 	ss, err := broker.Get(name, meta.GetOptions{})
 	if k8serrors.IsNotFound(err) {
 		return errors.NotFoundf(
@@ -86,6 +94,9 @@ func upgradeStatefulSet(name, imagePath string, vers version.Number, broker apps
 		return errors.Annotatef(err, "statefulset %q", name)
 	}
 	ss.Spec.Template = *updatedTemplateSpec
+	if err := applyOperatorPodConfig(&ss.Spec.Template.Spec, podConfig); err != nil {
+		return errors.Annotatef(err, "statefulset %q", name)
+	}
 
 	// update juju-version annotation.
 	// TODO(caas): consider how to upgrade to current annotations format safely.
@@ -382,8 +382,11 @@ func (k *kubernetesClient) volumeInfoForPVC(vol core.Volume, volMount core.Volum
 		if err != nil {
 			return nil, errors.Annotate(err, "unable to get events for PVC")
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		// Prefer the most recent warning, eg a provisioning failure,
+		// otherwise take the most recent event.
+		if warning := mostRecentWarning(eventList); warning != nil && pvc.Status.Phase != core.ClaimBound {
+			statusMessage = warningMessage(warning)
+		} else if count := len(eventList); count > 0 {
 			statusMessage = eventList[count-1].Message
 		}
 	}
@@ -0,0 +1,20 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+// NetworkPolicy describes which sources may connect to
+// the units of an application. The ports on which they may
+// connect are those of the application's pod spec, which
+// the broker reads from the application's service.
+type NetworkPolicy struct {
+	// AllowedApplications holds the names of the applications,
+	// other than the application itself, whose units may connect
+	// to the application's units. These are the applications
+	// related to the application.
+	AllowedApplications []string
+
+	// Exposed is true if the application's units accept
+	// connections from any source.
+	Exposed bool
+}
@@ -12,10 +12,10 @@ import (
 )
 
 // CurrentVersion is the latest version of pod spec.
-const CurrentVersion Version = Version3
+const CurrentVersion Version = Version4
 
 // PodSpec is the current version of pod spec.
-type PodSpec = PodSpecV3
+type PodSpec = PodSpecV4
 
 // ContainerPort defines a port on a container.
 type ContainerPort struct {
@@ -56,6 +56,12 @@ version: 3
 `[1:],
 			version: specs.Version(3),
 		},
+		{
+			strSpec: `
+version: 4
+`[1:],
+			version: specs.Version(4),
+		},
 	} {
 		c.Logf("#%d: testing GetVersion: %d", i, tc.version)
 		v, err := specs.GetVersion(tc.strSpec)
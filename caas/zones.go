@@ -0,0 +1,359 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+// ClusterZonesKey is the model config attribute used to specify the
+// additional clouds a model spans, as a comma separated list of
+// <cloud>:<credential> entries. Each cloud is a cluster which is
+// treated as a placement zone, named after the cloud, and accessed
+// with the named credential of the model owner.
+const ClusterZonesKey = "cluster-zones"
+
+// ClusterZones returns the names of the additional clouds
+// the model with the specified config spans.
+func ClusterZones(cfg *config.Config) []string {
+	var zones []string
+	for _, entry := range clusterZoneEntries(cfg) {
+		zones = append(zones, entry[0])
+	}
+	return zones
+}
+
+// ClusterZoneCredential returns the name of the model owner's
+// credential used to access the cluster of the specified zone.
+func ClusterZoneCredential(cfg *config.Config, zone string) (string, error) {
+	for _, entry := range clusterZoneEntries(cfg) {
+		if entry[0] != zone {
+			continue
+		}
+		if entry[1] == "" {
+			return "", errors.NotValidf("cluster zone %q without a credential", zone)
+		}
+		return entry[1], nil
+	}
+	return "", errors.NotFoundf("cluster zone %q", zone)
+}
+
+// clusterZoneEntries returns the cloud and credential
+// of each entry of the cluster zones in the config.
+func clusterZoneEntries(cfg *config.Config) [][2]string {
+	value, _ := cfg.AllAttrs()[ClusterZonesKey].(string)
+	var entries [][2]string
+	for _, entry := range strings.Split(value, ",") {
+		zone, credential := entry, ""
+		if i := strings.Index(entry, ":"); i >= 0 {
+			zone, credential = entry[:i], entry[i+1:]
+		}
+		if zone = strings.TrimSpace(zone); zone != "" {
+			entries = append(entries, [2]string{zone, strings.TrimSpace(credential)})
+		}
+	}
+	return entries
+}
+
+// ZonedBroker is implemented by brokers of models spanning
+// several clusters. Each cluster is a placement zone with its
+// own broker.
+//
+// Operators and model level resources are managed in the model's
+// own cluster, the primary zone, but the model's namespace is created
+// and destroyed in every zone. The units of an application are
+// placed in the zones chosen by the unit provisioner, and are
+// reported with provider ids qualified by zone.
+type ZonedBroker interface {
+	Broker
+
+	// Zones returns the names of the zones, starting
+	// with the primary zone.
+	Zones() []string
+
+	// ZoneBroker returns the broker for the specified zone.
+	ZoneBroker(zone string) (Broker, error)
+}
+
+// NewZonedBroker returns a broker which spans the clusters of the
+// specified brokers, keyed by zone. The primary broker manages the
+// model's own cluster.
+func NewZonedBroker(primaryZone string, primary Broker, zones map[string]Broker) ZonedBroker {
+	names := []string{primaryZone}
+	brokers := map[string]Broker{primaryZone: primary}
+	for zone, broker := range zones {
+		if zone == primaryZone {
+			continue
+		}
+		names = append(names, zone)
+		brokers[zone] = broker
+	}
+	sort.Strings(names[1:])
+	return &zonedBroker{
+		Broker:  primary,
+		zones:   names,
+		brokers: brokers,
+	}
+}
+
+type zonedBroker struct {
+	// Broker is the broker of the primary zone.
+	Broker
+
+	zones   []string
+	brokers map[string]Broker
+}
+
+// Zones is part of the ZonedBroker interface.
+func (z *zonedBroker) Zones() []string {
+	return append([]string(nil), z.zones...)
+}
+
+// ZoneBroker is part of the ZonedBroker interface.
+func (z *zonedBroker) ZoneBroker(zone string) (Broker, error) {
+	broker, ok := z.brokers[zone]
+	if !ok {
+		return nil, errors.NotFoundf("cluster zone %q", zone)
+	}
+	return broker, nil
+}
+
+// forEachZone calls f with the broker of each zone, in order.
+func (z *zonedBroker) forEachZone(f func(zone string, broker Broker) error) error {
+	for _, zone := range z.zones {
+		if err := f(zone, z.brokers[zone]); err != nil {
+			return errors.Annotatef(err, "cluster zone %q", zone)
+		}
+	}
+	return nil
+}
+
+// SetConfig is part of the environs.Configer interface.
+func (z *zonedBroker) SetConfig(cfg *config.Config) error {
+	return z.forEachZone(func(_ string, broker Broker) error {
+		return broker.SetConfig(cfg)
+	})
+}
+
+// SetCloudSpec is part of the environs.CloudSpecSetter interface.
+// Only the cloud of the primary zone is tracked with the model.
+func (z *zonedBroker) SetCloudSpec(spec environs.CloudSpec) error {
+	setter, ok := z.Broker.(environs.CloudSpecSetter)
+	if !ok {
+		return errors.NotSupportedf("changing the cloud spec of %q", z.zones[0])
+	}
+	return setter.SetCloudSpec(spec)
+}
+
+// Create is part of the environs.BootstrapEnviron interface.
+// The model's namespace is created in every zone; only the
+// primary zone reports that it already exists.
+func (z *zonedBroker) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	if err := z.Broker.Create(ctx, args); err != nil {
+		return errors.Trace(err)
+	}
+	return z.forEachZone(func(zone string, broker Broker) error {
+		if zone == z.zones[0] {
+			return nil
+		}
+		if err := broker.Create(ctx, args); err != nil && !errors.IsAlreadyExists(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	})
+}
+
+// Destroy is part of the environs.BootstrapEnviron interface.
+// The model is destroyed in the other zones first so that, if
+// one of them fails, the model is still intact in the primary
+// zone when the destroy is retried.
+func (z *zonedBroker) Destroy(ctx context.ProviderCallContext) error {
+	err := z.forEachZone(func(zone string, broker Broker) error {
+		if zone == z.zones[0] {
+			return nil
+		}
+		return broker.Destroy(ctx)
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return z.Broker.Destroy(ctx)
+}
+
+// WatchUnits is part of the Broker interface.
+func (z *zonedBroker) WatchUnits(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error) {
+	return z.watchZones(func(broker Broker) (watcher.NotifyWatcher, error) {
+		return broker.WatchUnits(appName, mode)
+	})
+}
+
+// WatchEvents is part of the Broker interface.
+func (z *zonedBroker) WatchEvents(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error) {
+	return z.watchZones(func(broker Broker) (watcher.NotifyWatcher, error) {
+		return broker.WatchEvents(appName, mode)
+	})
+}
+
+// WatchService is part of the Broker interface.
+func (z *zonedBroker) WatchService(appName string, mode DeploymentMode) (watcher.NotifyWatcher, error) {
+	return z.watchZones(func(broker Broker) (watcher.NotifyWatcher, error) {
+		return broker.WatchService(appName, mode)
+	})
+}
+
+// watchZones returns a watcher combining the watchers
+// started in each zone.
+func (z *zonedBroker) watchZones(watch func(Broker) (watcher.NotifyWatcher, error)) (watcher.NotifyWatcher, error) {
+	var watchers []watcher.NotifyWatcher
+	err := z.forEachZone(func(_ string, broker Broker) error {
+		w, err := watch(broker)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		watchers = append(watchers, w)
+		return nil
+	})
+	if err != nil {
+		for _, w := range watchers {
+			w.Kill()
+		}
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewMultiNotifyWatcher(watchers...), nil
+}
+
+// Units is part of the Broker interface. The ids of units
+// outside the primary zone are qualified by their zone.
+func (z *zonedBroker) Units(appName string, mode DeploymentMode) ([]Unit, error) {
+	var result []Unit
+	err := z.forEachZone(func(zone string, broker Broker) error {
+		units, err := broker.Units(appName, mode)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, u := range units {
+			u.Id = z.zoneProviderId(zone, u.Id)
+			result = append(result, u)
+		}
+		return nil
+	})
+	return result, errors.Trace(err)
+}
+
+// AnnotateUnit is part of the Broker interface.
+func (z *zonedBroker) AnnotateUnit(appName string, mode DeploymentMode, podName string, unit names.UnitTag) error {
+	zone, podName := ParseZoneProviderId(podName)
+	if zone == "" {
+		zone = z.zones[0]
+	}
+	broker, err := z.ZoneBroker(zone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return broker.AnnotateUnit(appName, mode, podName, unit)
+}
+
+// GetService is part of the Broker interface. The service of the
+// primary zone is returned, or that of the first zone running the
+// application if it has no units in the primary zone, with the scale
+// of the application across all zones.
+func (z *zonedBroker) GetService(appName string, mode DeploymentMode, includeClusterIP bool) (*Service, error) {
+	var result *Service
+	scale, scaled := 0, false
+	err := z.forEachZone(func(_ string, broker Broker) error {
+		svc, err := broker.GetService(appName, mode, includeClusterIP)
+		if errors.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if svc.Scale != nil {
+			scale += *svc.Scale
+			scaled = true
+		}
+		if result == nil || (result.Id == "" && svc.Id != "") {
+			result = svc
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result == nil {
+		return nil, errors.NotFoundf("service for %q", appName)
+	}
+	if scaled {
+		result.Scale = &scale
+	}
+	return result, nil
+}
+
+// ExposeService is part of the Broker interface.
+func (z *zonedBroker) ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error {
+	return z.forEachZone(func(_ string, broker Broker) error {
+		return broker.ExposeService(appName, resourceTags, config)
+	})
+}
+
+// UnexposeService is part of the Broker interface.
+func (z *zonedBroker) UnexposeService(appName string) error {
+	return z.forEachZone(func(_ string, broker Broker) error {
+		return broker.UnexposeService(appName)
+	})
+}
+
+// DeleteService is part of the Broker interface.
+func (z *zonedBroker) DeleteService(appName string) error {
+	return z.forEachZone(func(_ string, broker Broker) error {
+		return broker.DeleteService(appName)
+	})
+}
+
+// zoneProviderId returns the provider id of a unit in the specified
+// zone. Units in the primary zone keep their ids so that they are
+// unchanged when a model starts spanning other clusters.
+func (z *zonedBroker) zoneProviderId(zone, id string) string {
+	if zone == z.zones[0] {
+		return id
+	}
+	return zone + "/" + id
+}
+
+// ParseZoneProviderId splits a unit provider id reported by a zoned
+// broker into the unit's zone and its id within the zone's cluster.
+// The zone is empty for units in the primary zone.
+func ParseZoneProviderId(providerId string) (zone, id string) {
+	if i := strings.Index(providerId, "/"); i >= 0 {
+		return providerId[:i], providerId[i+1:]
+	}
+	return "", providerId
+}
+
+// SpreadUnits returns the number of units to place in each
+// of the specified zones, spreading them as evenly as
+// possible. Earlier zones receive any extra units.
+func SpreadUnits(numUnits int, zones []string) map[string]int {
+	result := make(map[string]int)
+	if len(zones) == 0 {
+		return result
+	}
+	for i, zone := range zones {
+		n := numUnits / len(zones)
+		if i < numUnits%len(zones) {
+			n++
+		}
+		result[zone] = n
+	}
+	return result
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caas_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/testing"
+)
+
+type zonesSuite struct {
+	testing.BaseSuite
+
+	primary *fakeZoneBroker
+	other   *fakeZoneBroker
+	broker  caas.ZonedBroker
+}
+
+var _ = gc.Suite(&zonesSuite{})
+
+// fakeZoneBroker implements the Broker methods
+// overridden by a zoned broker.
+type fakeZoneBroker struct {
+	caas.Broker
+	*jujutesting.Stub
+
+	units   []caas.Unit
+	service *caas.Service
+}
+
+func (b *fakeZoneBroker) Units(appName string, mode caas.DeploymentMode) ([]caas.Unit, error) {
+	b.MethodCall(b, "Units", appName, mode)
+	return b.units, b.NextErr()
+}
+
+func (b *fakeZoneBroker) AnnotateUnit(appName string, mode caas.DeploymentMode, podName string, unit names.UnitTag) error {
+	b.MethodCall(b, "AnnotateUnit", appName, mode, podName, unit)
+	return b.NextErr()
+}
+
+func (b *fakeZoneBroker) GetService(appName string, mode caas.DeploymentMode, includeClusterIP bool) (*caas.Service, error) {
+	b.MethodCall(b, "GetService", appName, mode, includeClusterIP)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	if b.service == nil {
+		return nil, errors.NotFoundf("service")
+	}
+	svc := *b.service
+	return &svc, nil
+}
+
+func (b *fakeZoneBroker) DeleteService(appName string) error {
+	b.MethodCall(b, "DeleteService", appName)
+	return b.NextErr()
+}
+
+func (b *fakeZoneBroker) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	b.MethodCall(b, "Create", ctx, args)
+	return b.NextErr()
+}
+
+func (b *fakeZoneBroker) Destroy(ctx context.ProviderCallContext) error {
+	b.MethodCall(b, "Destroy", ctx)
+	return b.NextErr()
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func (s *zonesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.primary = &fakeZoneBroker{Stub: &jujutesting.Stub{}}
+	s.other = &fakeZoneBroker{Stub: &jujutesting.Stub{}}
+	s.broker = caas.NewZonedBroker("east", s.primary, map[string]caas.Broker{
+		"west": s.other,
+	})
+}
+
+func (s *zonesSuite) TestClusterZones(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		caas.ClusterZonesKey: " west:west-cred, north,,",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caas.ClusterZones(cfg), jc.DeepEquals, []string{"west", "north"})
+
+	credential, err := caas.ClusterZoneCredential(cfg, "west")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(credential, gc.Equals, "west-cred")
+	_, err = caas.ClusterZoneCredential(cfg, "north")
+	c.Assert(err, gc.ErrorMatches, `cluster zone "north" without a credential not valid`)
+	_, err = caas.ClusterZoneCredential(cfg, "south")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	cfg, err = config.New(config.UseDefaults, testing.FakeConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(caas.ClusterZones(cfg), gc.HasLen, 0)
+}
+
+func (s *zonesSuite) TestZones(c *gc.C) {
+	c.Assert(s.broker.Zones(), jc.DeepEquals, []string{"east", "west"})
+
+	broker, err := s.broker.ZoneBroker("west")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broker, gc.Equals, s.other)
+
+	_, err = s.broker.ZoneBroker("north")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *zonesSuite) TestUnits(c *gc.C) {
+	s.primary.units = []caas.Unit{{Id: "app-0"}}
+	s.other.units = []caas.Unit{{Id: "app-0"}, {Id: "app-1"}}
+
+	units, err := s.broker.Units("app", caas.ModeWorkload)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, jc.DeepEquals, []caas.Unit{
+		{Id: "app-0"}, {Id: "west/app-0"}, {Id: "west/app-1"},
+	})
+}
+
+func (s *zonesSuite) TestAnnotateUnit(c *gc.C) {
+	tag := names.NewUnitTag("app/1")
+	err := s.broker.AnnotateUnit("app", caas.ModeWorkload, "west/app-0", tag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.AnnotateUnit("app", caas.ModeWorkload, "app-0", tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.other.CheckCall(c, 0, "AnnotateUnit", "app", caas.ModeWorkload, "app-0", tag)
+	s.primary.CheckCall(c, 0, "AnnotateUnit", "app", caas.ModeWorkload, "app-0", tag)
+}
+
+func (s *zonesSuite) TestGetServiceSumsScale(c *gc.C) {
+	s.primary.service = &caas.Service{Id: "east-id", Scale: intPtr(2)}
+	s.other.service = &caas.Service{Id: "west-id", Scale: intPtr(1)}
+
+	svc, err := s.broker.GetService("app", caas.ModeWorkload, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc, jc.DeepEquals, &caas.Service{Id: "east-id", Scale: intPtr(3)})
+}
+
+func (s *zonesSuite) TestGetServiceNotInPrimaryZone(c *gc.C) {
+	s.other.service = &caas.Service{Id: "west-id", Scale: intPtr(1)}
+
+	svc, err := s.broker.GetService("app", caas.ModeWorkload, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(svc, jc.DeepEquals, &caas.Service{Id: "west-id", Scale: intPtr(1)})
+}
+
+func (s *zonesSuite) TestGetServiceNotFound(c *gc.C) {
+	_, err := s.broker.GetService("app", caas.ModeWorkload, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *zonesSuite) TestDeleteService(c *gc.C) {
+	s.other.SetErrors(errors.New("boom"))
+
+	err := s.broker.DeleteService("app")
+	c.Assert(err, gc.ErrorMatches, `cluster zone "west": boom`)
+	s.primary.CheckCall(c, 0, "DeleteService", "app")
+	s.other.CheckCall(c, 0, "DeleteService", "app")
+}
+
+func (s *zonesSuite) TestCreate(c *gc.C) {
+	s.other.SetErrors(errors.AlreadyExistsf("namespace"))
+	ctx := context.NewCloudCallContext()
+	args := environs.CreateParams{ControllerUUID: "deadbeef"}
+
+	err := s.broker.Create(ctx, args)
+	c.Assert(err, jc.ErrorIsNil)
+	s.primary.CheckCall(c, 0, "Create", ctx, args)
+	s.other.CheckCall(c, 0, "Create", ctx, args)
+}
+
+func (s *zonesSuite) TestCreateAlreadyExistsInPrimaryZone(c *gc.C) {
+	s.primary.SetErrors(errors.AlreadyExistsf("namespace"))
+
+	err := s.broker.Create(context.NewCloudCallContext(), environs.CreateParams{})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	s.other.CheckNoCalls(c)
+}
+
+func (s *zonesSuite) TestDestroy(c *gc.C) {
+	ctx := context.NewCloudCallContext()
+
+	err := s.broker.Destroy(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.other.CheckCall(c, 0, "Destroy", ctx)
+	s.primary.CheckCall(c, 0, "Destroy", ctx)
+}
+
+func (s *zonesSuite) TestDestroyKeepsPrimaryZoneOnError(c *gc.C) {
+	s.other.SetErrors(errors.New("boom"))
+
+	err := s.broker.Destroy(context.NewCloudCallContext())
+	c.Assert(err, gc.ErrorMatches, `cluster zone "west": boom`)
+	s.primary.CheckNoCalls(c)
+}
+
+func (s *zonesSuite) TestParseZoneProviderId(c *gc.C) {
+	zone, id := caas.ParseZoneProviderId("west/app-0")
+	c.Assert(zone, gc.Equals, "west")
+	c.Assert(id, gc.Equals, "app-0")
+
+	zone, id = caas.ParseZoneProviderId("app-0")
+	c.Assert(zone, gc.Equals, "")
+	c.Assert(id, gc.Equals, "app-0")
+}
+
+func (s *zonesSuite) TestSpreadUnits(c *gc.C) {
+	c.Assert(caas.SpreadUnits(5, []string{"east", "west"}), jc.DeepEquals, map[string]int{
+		"east": 3, "west": 2,
+	})
+	c.Assert(caas.SpreadUnits(1, []string{"east", "west", "north"}), jc.DeepEquals, map[string]int{
+		"east": 1, "west": 0, "north": 0,
+	})
+	c.Assert(caas.SpreadUnits(3, nil), gc.HasLen, 0)
+}
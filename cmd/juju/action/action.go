@@ -61,6 +61,44 @@ type APIClient interface {
 
 	// WatchActionProgress reports on logged action progress messages.
 	WatchActionProgress(actionId string) (watcher.StringsWatcher, error)
+
+	// WatchActionOutput reports on the output streamed by a running action.
+	WatchActionOutput(actionId string) (watcher.StringsWatcher, error)
+
+	// AddActionSchedule adds a schedule on which an action is run
+	// by the controller, and returns it.
+	AddActionSchedule(params.AddActionScheduleArg) (params.ActionSchedule, error)
+
+	// ListActionSchedules returns the action schedules of the model.
+	ListActionSchedules() ([]params.ActionSchedule, error)
+
+	// PauseActionSchedule stops the action of the specified
+	// schedule from being run until it is resumed.
+	PauseActionSchedule(id string) error
+
+	// ResumeActionSchedule resumes running the action of the specified schedule.
+	ResumeActionSchedule(id string) error
+
+	// RemoveActionSchedule removes the specified schedule.
+	RemoveActionSchedule(id string) error
+
+	// StartRunbook starts a runbook with the named steps as an
+	// operation, and returns the tag of the operation.
+	StartRunbook(summary string, steps []string) (string, error)
+
+	// RunRunbookStep runs a step of a runbook, adding a task
+	// to the runbook's operation on each of the step's units.
+	RunRunbookStep(params.RunRunbookStepArg) (params.EnqueuedActions, error)
+
+	// SetRunbookStepStatus records the result of a step of a runbook.
+	SetRunbookStepStatus(params.RunbookStepStatusArg) error
+
+	// RunbookHeartbeat records that this client is still
+	// running the runbook of the operation.
+	RunbookHeartbeat(operationTag string) error
+
+	// FinishRunbook completes the operation of a runbook.
+	FinishRunbook(params.FinishRunbookArg) error
 }
 
 // ActionCommandBase is the base type for action sub-commands.
@@ -4,8 +4,10 @@
 package action
 
 import (
+	"io"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/names/v4"
 
@@ -59,10 +61,22 @@ func (c *RunCommand) MaxWait() time.Duration {
 	return c.maxWait
 }
 
+func (c *RunCommand) ExecutionTimeout() time.Duration {
+	return c.executionTimeout
+}
+
 func (c *RunCommand) Args() [][]string {
 	return c.args
 }
 
+func (c *RunCommand) ParamsJSON() cmd.FileVar {
+	return c.paramsJSON
+}
+
+func (c *RunCommand) SetIsTerminal(isTerminal bool) {
+	c.isTerminal = func(io.Reader) bool { return isTerminal }
+}
+
 type RunActionCommand struct {
 	*runActionCommand
 }
@@ -177,3 +191,39 @@ func NewListOperationsCommandForTest(store jujuclient.ClientStore) (cmd.Command,
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &ListOperationsCommand{c}
 }
+
+func NewScheduleActionCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &scheduleActionCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRunRunbookCommandForTest(store jujuclient.ClientStore, clock clock.Clock) cmd.Command {
+	c := &runRunbookCommand{clock: clock}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewPauseScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &updateScheduleCommand{scheduleUpdate: pauseScheduleUpdate}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewResumeScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &updateScheduleCommand{scheduleUpdate: resumeScheduleUpdate}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &updateScheduleCommand{scheduleUpdate: removeScheduleUpdate}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -265,9 +265,17 @@ type operationInfo struct {
 	Error   string              `yaml:"error,omitempty" json:"error,omitempty"`
 	Action  *actionSummary      `yaml:"action,omitempty" json:"action,omitempty"`
 	Timing  timingInfo          `yaml:"timing,omitempty" json:"timing,omitempty"`
+	Steps   []stepInfo          `yaml:"steps,omitempty" json:"steps,omitempty"`
 	Tasks   map[string]taskInfo `yaml:"tasks,omitempty" json:"tasks,omitempty"`
 }
 
+// stepInfo holds the progress of a step of a runbook.
+type stepInfo struct {
+	Name    string `yaml:"name" json:"name"`
+	Status  string `yaml:"status" json:"status"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
 type timingInfo struct {
 	Enqueued  string `yaml:"enqueued,omitempty" json:"enqueued,omitempty"`
 	Started   string `yaml:"started,omitempty" json:"started,omitempty"`
@@ -305,6 +313,13 @@ func formatOperationResult(operation params.OperationResult, utc bool) operation
 	if err := operation.Error; err != nil {
 		result.Error = err.Error()
 	}
+	for _, step := range operation.Steps {
+		result.Steps = append(result.Steps, stepInfo{
+			Name:    step.Name,
+			Status:  step.Status,
+			Message: step.Message,
+		})
+	}
 	var singleAction actionSummary
 	haveSingleAction := true
 	for i, task := range operation.Actions {
@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules of a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+	utc bool
+}
+
+const listSchedulesDoc = `
+List the schedules on which the controller runs actions, added with
+schedule-action. The yaml and json formats include the most recent runs
+of each action, with the operations enqueued to run it.
+
+Examples:
+
+    juju action-schedules
+    juju action-schedules --format yaml
+
+See also:
+    schedule-action
+    show-operation
+`
+
+// SetFlags implements Command.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "plain", map[string]cmd.Formatter{
+		"yaml":  cmd.FormatYaml,
+		"json":  cmd.FormatJson,
+		"plain": c.formatTabular,
+	})
+	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
+}
+
+// Info implements Command.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "action-schedules",
+		Purpose: "Lists the schedules on which actions are run.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-action-schedules"},
+	})
+}
+
+// Init implements Command.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+type scheduleRunInfo struct {
+	Time      string `yaml:"time" json:"time"`
+	Operation string `yaml:"operation,omitempty" json:"operation,omitempty"`
+	Skipped   bool   `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+	Error     string `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+type scheduleInfo struct {
+	Receiver   string                 `yaml:"receiver" json:"receiver"`
+	Action     string                 `yaml:"action" json:"action"`
+	Parameters map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Schedule   string                 `yaml:"schedule" json:"schedule"`
+	Overlap    string                 `yaml:"overlap" json:"overlap"`
+	Owner      string                 `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Paused     bool                   `yaml:"paused,omitempty" json:"paused,omitempty"`
+	LastRun    string                 `yaml:"last-run,omitempty" json:"last-run,omitempty"`
+	NextRun    string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	History    []scheduleRunInfo      `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+// Run implements Command.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	schedules, err := api.ListActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 {
+		ctx.Infof("No action schedules have been added.")
+		return nil
+	}
+	if c.out.Name() == "plain" {
+		return c.out.Write(ctx, schedules)
+	}
+	out := make(map[string]scheduleInfo, len(schedules))
+	for _, schedule := range schedules {
+		out[schedule.Id] = c.formatSchedule(schedule)
+	}
+	return c.out.Write(ctx, out)
+}
+
+func (c *listSchedulesCommand) formatSchedule(schedule params.ActionSchedule) scheduleInfo {
+	info := scheduleInfo{
+		Receiver:   schedule.Receiver,
+		Action:     schedule.Name,
+		Parameters: schedule.Parameters,
+		Schedule:   schedule.Schedule,
+		Overlap:    schedule.Overlap,
+		Owner:      schedule.Owner,
+		Paused:     schedule.Paused,
+		LastRun:    c.formatTime(schedule.LastRun, false),
+		NextRun:    c.formatTime(schedule.NextRun, false),
+	}
+	for _, run := range schedule.History {
+		runInfo := scheduleRunInfo{
+			Time:    c.formatTime(&run.Time, false),
+			Skipped: run.Skipped,
+			Error:   run.Error,
+		}
+		if tag, err := names.ParseOperationTag(run.OperationTag); err == nil {
+			runInfo.Operation = tag.Id()
+		}
+		info.History = append(info.History, runInfo)
+	}
+	return info
+}
+
+func (c *listSchedulesCommand) formatTime(t *time.Time, plain bool) string {
+	if t == nil {
+		return ""
+	}
+	return formatTimestamp(*t, false, c.utc, plain)
+}
+
+func (c *listSchedulesCommand) formatTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]params.ActionSchedule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.SetColumnAlignRight(0)
+	w.Println("Id", "Receiver", "Action", "Schedule", "Overlap", "Status", "Last run", "Next run")
+	for _, schedule := range schedules {
+		status := "active"
+		if schedule.Paused {
+			status = "paused"
+		}
+		w.Print(schedule.Id, schedule.Receiver, schedule.Name, schedule.Schedule, schedule.Overlap, status)
+		w.Print(c.formatTime(schedule.LastRun, true))
+		w.Println(c.formatTime(schedule.NextRun, true))
+	}
+	return tw.Flush()
+}
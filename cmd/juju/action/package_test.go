@@ -4,6 +4,7 @@
 package action_test
 
 import (
+	"fmt"
 	"io/ioutil"
 	"testing"
 	"time"
@@ -151,7 +152,16 @@ type fakeAPIClient struct {
 	apiVersion         int
 	apiErr             error
 	logMessageCh       chan []string
+	outputChs          map[string]chan []string
 	waitForResults     chan bool
+	addedSchedule      params.AddActionScheduleArg
+	schedules          []params.ActionSchedule
+	scheduleCalls      []string
+	runbookSteps       [][]params.ActionResult
+	runbookCalls       []string
+	runbookHeartbeats  int
+	runbookStepArgs    []params.RunRunbookStepArg
+	cancelledTags      []string
 }
 
 var _ action.APIClient = (*fakeAPIClient)(nil)
@@ -192,9 +202,18 @@ func (c *fakeAPIClient) EnqueueOperation(args params.Actions) (params.EnqueuedAc
 }
 
 func (c *fakeAPIClient) Cancel(args params.Entities) (params.ActionResults, error) {
-	return params.ActionResults{
-		Results: c.actionResults,
-	}, c.apiErr
+	results := params.ActionResults{
+		Results: append([]params.ActionResult(nil), c.actionResults...),
+	}
+	for _, e := range args.Entities {
+		c.cancelledTags = append(c.cancelledTags, e.Tag)
+		for i, a := range c.actionResults {
+			if a.Action != nil && a.Action.Tag == e.Tag {
+				c.actionResults[i].Status = params.ActionAborted
+			}
+		}
+	}
+	return results, c.apiErr
 }
 
 func (c *fakeAPIClient) ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error) {
@@ -267,6 +286,14 @@ func (c *fakeAPIClient) WatchActionProgress(actionId string) (watcher.StringsWat
 	return watchertest.NewMockStringsWatcher(c.logMessageCh), nil
 }
 
+func (c *fakeAPIClient) WatchActionOutput(actionId string) (watcher.StringsWatcher, error) {
+	ch, ok := c.outputChs[actionId]
+	if !ok {
+		ch = make(chan []string)
+	}
+	return watchertest.NewMockStringsWatcher(ch), nil
+}
+
 func (c *fakeAPIClient) ListOperations(args params.OperationQueryArgs) (params.OperationResults, error) {
 	c.operationQueryArgs = args
 	return params.OperationResults{
@@ -320,3 +347,74 @@ func (c *fakeAPIClient) getOperation(id string) (params.OperationResult, error)
 	}
 	return c.operationResults[0], nil
 }
+
+func (c *fakeAPIClient) AddActionSchedule(arg params.AddActionScheduleArg) (params.ActionSchedule, error) {
+	c.addedSchedule = arg
+	if c.apiErr != nil || len(c.schedules) == 0 {
+		return params.ActionSchedule{}, c.apiErr
+	}
+	return c.schedules[0], nil
+}
+
+func (c *fakeAPIClient) ListActionSchedules() ([]params.ActionSchedule, error) {
+	return c.schedules, c.apiErr
+}
+
+func (c *fakeAPIClient) PauseActionSchedule(id string) error {
+	c.scheduleCalls = append(c.scheduleCalls, "pause "+id)
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) ResumeActionSchedule(id string) error {
+	c.scheduleCalls = append(c.scheduleCalls, "resume "+id)
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedule(id string) error {
+	c.scheduleCalls = append(c.scheduleCalls, "remove "+id)
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) StartRunbook(summary string, steps []string) (string, error) {
+	c.runbookCalls = append(c.runbookCalls, fmt.Sprintf("start %q %v", summary, steps))
+	return "operation-1", c.apiErr
+}
+
+// RunRunbookStep enqueues the tasks of the next
+// of the runbook steps given by the test.
+func (c *fakeAPIClient) RunRunbookStep(arg params.RunRunbookStepArg) (params.EnqueuedActions, error) {
+	c.runbookStepArgs = append(c.runbookStepArgs, arg)
+	if c.apiErr != nil {
+		return params.EnqueuedActions{}, c.apiErr
+	}
+	if len(c.runbookSteps) == 0 {
+		return params.EnqueuedActions{}, errors.New("no more steps")
+	}
+	tasks := c.runbookSteps[0]
+	c.runbookSteps = c.runbookSteps[1:]
+	c.actionResults = append(c.actionResults, tasks...)
+	enqueued := params.EnqueuedActions{OperationTag: arg.OperationTag}
+	for _, task := range tasks {
+		enqueued.Actions = append(enqueued.Actions, params.StringResult{Result: task.Action.Tag})
+	}
+	return enqueued, nil
+}
+
+func (c *fakeAPIClient) SetRunbookStepStatus(arg params.RunbookStepStatusArg) error {
+	call := fmt.Sprintf("step %d %s", arg.Step, arg.Status)
+	if arg.Message != "" {
+		call += ": " + arg.Message
+	}
+	c.runbookCalls = append(c.runbookCalls, call)
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) RunbookHeartbeat(operationTag string) error {
+	c.runbookHeartbeats++
+	return c.apiErr
+}
+
+func (c *fakeAPIClient) FinishRunbook(arg params.FinishRunbookArg) error {
+	c.runbookCalls = append(c.runbookCalls, "finish "+arg.Status)
+	return c.apiErr
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	gjs "github.com/juju/gojsonschema"
+	"github.com/juju/jsonschema"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/interact"
+)
+
+// paramsError describes the parameters given to an action
+// which are not valid according to the action's schema.
+type paramsError struct {
+	actionName  string
+	application string
+	problems    []string
+}
+
+// Error is part of the error interface.
+func (e *paramsError) Error() string {
+	return fmt.Sprintf("invalid parameters for action %q of application %q:\n  - %s",
+		e.actionName, e.application, strings.Join(e.problems, "\n  - "))
+}
+
+// validateParams validates the parameters of the named action of an
+// application against the action's schema, as the controller does. If
+// they are not valid, the error reports each problem with the
+// parameters, naming the parameter concerned.
+func validateParams(actionName, application string, spec params.ActionSpec, actionParams map[string]interface{}) error {
+	schema, err := gjs.NewSchema(gjs.NewGoLoader(spec.Params))
+	if err != nil {
+		return errors.Annotatef(err, "invalid schema for action %q", actionName)
+	}
+	if actionParams == nil {
+		actionParams = map[string]interface{}{}
+	}
+	result, err := schema.Validate(gjs.NewGoLoader(actionParams))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Valid() {
+		return nil
+	}
+	var problems []string
+	for _, resultErr := range result.Errors() {
+		field := strings.TrimPrefix(strings.TrimPrefix(resultErr.Context.String(), "(root)"), ".")
+		if field == "" {
+			problems = append(problems, resultErr.Description)
+		} else {
+			problems = append(problems, field+": "+resultErr.Description)
+		}
+	}
+	sort.Strings(problems)
+	return &paramsError{
+		actionName:  actionName,
+		application: application,
+		problems:    problems,
+	}
+}
+
+// missingParams returns the names of the required parameters
+// of an action which are not in actionParams.
+func missingParams(spec params.ActionSpec, actionParams map[string]interface{}) []string {
+	required, _ := spec.Params["required"].([]interface{})
+	var missing []string
+	for _, name := range required {
+		name, ok := name.(string)
+		if !ok {
+			continue
+		}
+		if _, ok := actionParams[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// queryParams asks the user for the values of the named parameters of
+// an action, and adds them to actionParams. Parameters which cannot be
+// entered as a single value, such as objects and arrays, are left out,
+// to be reported when the parameters are validated.
+func queryParams(pollster *interact.Pollster, spec params.ActionSpec, names []string, actionParams map[string]interface{}) error {
+	properties, _ := spec.Params["properties"].(map[string]interface{})
+	for _, name := range names {
+		property, _ := properties[name].(map[string]interface{})
+		schema, ok := paramSchema(name, property)
+		if !ok {
+			continue
+		}
+		value, err := pollster.QuerySchema(schema)
+		if err != nil {
+			return errors.Annotatef(err, "reading parameter %q", name)
+		}
+		actionParams[name] = value
+	}
+	return nil
+}
+
+// paramTypes holds the parameter types which can be entered by the user.
+var paramTypes = map[string]jsonschema.Type{
+	"string":  jsonschema.StringType,
+	"integer": jsonschema.IntegerType,
+	"number":  jsonschema.NumberType,
+	"boolean": jsonschema.BooleanType,
+}
+
+// paramSchema returns the schema used to query for the value of the named
+// parameter, and whether the user can enter it. A parameter of no
+// particular type is queried as a string.
+func paramSchema(name string, property map[string]interface{}) (*jsonschema.Schema, bool) {
+	schema := &jsonschema.Schema{
+		Singular: name,
+		Type:     []jsonschema.Type{jsonschema.StringType},
+	}
+	if typeName, ok := property["type"].(string); ok {
+		t, ok := paramTypes[typeName]
+		if !ok {
+			return nil, false
+		}
+		schema.Type = []jsonschema.Type{t}
+	} else if _, ok := property["type"]; ok {
+		// A parameter of several types can't be queried.
+		return nil, false
+	}
+	schema.Description, _ = property["description"].(string)
+	schema.Default = property["default"]
+	schema.Enum, _ = property["enum"].([]interface{})
+	return schema, true
+}
@@ -4,8 +4,10 @@
 package action
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -16,11 +18,13 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
+	"golang.org/x/crypto/ssh/terminal"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/juju/interact"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/watcher"
 )
@@ -51,13 +55,17 @@ type runCommand struct {
 	leaders           map[string]string
 	actionName        string
 	paramsYAML        cmd.FileVar
+	paramsJSON        cmd.FileVar
 	parseStrings      bool
 	background        bool
 	maxWait           time.Duration
+	executionTimeout  time.Duration
+	stream            bool
 	out               cmd.Output
 	args              [][]string
 	utc               bool
 	logMessageHandler func(*cmd.Context, string)
+	isTerminal        func(io.Reader) bool
 }
 
 const runDoc = `
@@ -74,6 +82,15 @@ To queue a action to be run in the background without waiting for it to finish,
 use the --background option.
 
 To set the maximum time to wait for a action to complete, use the --max-wait option.
+This only limits how long the client waits; the action keeps running on the unit.
+
+To limit how long the action may run for on the unit, use the --execution-timeout
+option. An action still running when the timeout expires is cancelled, along with
+any processes it started, and marked as failed.
+
+To follow the output of the action(s) as it is produced, use the --stream option.
+The output is written as it arrives from the units; when running on more than
+one unit, each line is prefixed with the name of the unit that produced it.
 
 By default, the output of a single action will just be that action's stdout.
 For multiple actions, each action stdout is printed with the action id.
@@ -88,20 +105,27 @@ resolved before the action is enqueued.
 
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
-Params may be in a yaml file which is passed with the --params option, or they
-may be specified by a key.key.key...=value format (see examples below.)
+Params may be in a yaml file which is passed with the --params option, or a
+json file passed with the --params-from-json option, or they may be specified
+by a key.key.key...=value format (see examples below.)
+
+Params are checked against the action's schema before the action is run, and
+each problem with them is reported. When run in a terminal, the values of any
+required params which are not given are asked for.
 
 Params given in the CLI invocation will be parsed as YAML unless the
 --string-args option is set.  This can be helpful for values such as 'y', which
 is a boolean true in YAML.
 
-If --params is passed, along with key.key...=value explicit arguments, the
-explicit arguments will override the parameter file.
+If --params or --params-from-json is passed, along with key.key...=value
+explicit arguments, the explicit arguments will override the parameter file.
 
 Examples:
 
     juju run mysql/3 backup --background
     juju run mysql/3 backup --max-wait=2m
+    juju run mysql/3 backup --execution-timeout=30m
+    juju run mysql/3 mysql/4 backup --stream
     juju run mysql/3 backup --format yaml
     juju run mysql/3 backup --utc
     juju run mysql/3 backup
@@ -110,6 +134,7 @@ Examples:
     juju run mysql/3 backup --params parameters.yml
     juju run mysql/3 backup out=out.tar.bz2 file.kind=xz file.quality=high
     juju run mysql/3 backup --params p.yml file.kind=xz file.quality=high
+    juju run mysql/3 backup --params-from-json parameters.json
     juju run sleeper/0 pause time=1000
     juju run sleeper/0 pause --string-args time=1000
 
@@ -130,9 +155,12 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.Var(&c.paramsJSON, "params-from-json", "Path to json-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.BoolVar(&c.background, "background", false, "Run the action in the background")
 	f.DurationVar(&c.maxWait, "max-wait", 0, "Maximum wait time for a action to complete")
+	f.DurationVar(&c.executionTimeout, "execution-timeout", 0, "Maximum time the action may run for before it is cancelled and failed")
+	f.BoolVar(&c.stream, "stream", false, "Write the output of the action(s) as it is produced")
 	f.BoolVar(&c.utc, "utc", false, "Show times in UTC")
 }
 
@@ -164,12 +192,21 @@ func (c *runCommand) Init(args []string) (err error) {
 		return errors.New("no action specified")
 	}
 
+	if c.paramsYAML.Path != "" && c.paramsJSON.Path != "" {
+		return errors.New("cannot specify both --params and --params-from-json")
+	}
 	if c.background && c.maxWait > 0 {
 		return errors.New("cannot specify both --max-wait and --background")
 	}
+	if c.background && c.stream {
+		return errors.New("cannot specify both --stream and --background")
+	}
 	if !c.background && c.maxWait == 0 {
 		c.maxWait = 60 * time.Second
 	}
+	if c.executionTimeout < 0 {
+		return errors.New("--execution-timeout must not be negative")
+	}
 
 	// Parse CLI key-value args if they exist.
 	c.args = make([][]string, 0)
@@ -288,6 +325,24 @@ func (c *runCommand) waitForTasks(ctx *cmd.Context, tasks []enqueuedAction, info
 		}
 	}
 
+	var streamer *OutputStreamer
+	if c.stream {
+		if c.api.BestAPIVersion() < 7 {
+			return errors.New("--stream is not supported by this controller\nupgrade your controller to use it")
+		}
+		streamer = NewOutputStreamer(ctx.Stdout, ctx.Stderr, len(tasks) > 1)
+		defer streamer.Stop()
+		for _, result := range tasks {
+			tag, err := names.ParseActionTag(result.task)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := streamer.Follow(c.api, tag.Id(), result.receiver); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	for i, result := range tasks {
 		tag, err := names.ParseActionTag(result.task)
 		if err != nil {
@@ -306,8 +361,23 @@ func (c *runCommand) waitForTasks(ctx *cmd.Context, tasks []enqueuedAction, info
 		if err != nil {
 			return errors.Trace(err)
 		}
+		if streamer != nil {
+			streamer.Finish(tag.Id(), actionResult)
+		}
 		d := FormatActionResult(tag.Id(), actionResult, c.utc, false)
 		d["id"] = tag.Id() // Action ID is required in case we timed out.
+		if streamer != nil && c.out.Name() == "plain" {
+			// The output has already been written as it was streamed.
+			if results, ok := d["results"].(map[string]interface{}); ok {
+				filtered := make(map[string]interface{})
+				for k, v := range results {
+					if k != "stdout" && k != "stderr" {
+						filtered[k] = v
+					}
+				}
+				d["results"] = filtered
+			}
+		}
 		info[result.receiver] = d
 	}
 
@@ -345,6 +415,15 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (string, []enqueuedAction,
 
 		actionParams = betterParams
 	}
+	if c.paramsJSON.Path != "" {
+		b, err := c.paramsJSON.Read(ctx)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if err := json.Unmarshal(b, &actionParams); err != nil {
+			return "", nil, errors.Annotate(err, "params must contain a JSON object")
+		}
+	}
 	// If we had explicit args {..., [key, key, key, key, value], ...}
 	// then iterate and set params ..., key.key.key.key=value, ...
 	for _, argSlice := range c.args {
@@ -369,6 +448,18 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (string, []enqueuedAction,
 	if !ok {
 		return "", nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
+	if c.executionTimeout > 0 && c.api.BestAPIVersion() < 7 {
+		return "", nil, errors.Errorf("--execution-timeout is not supported by this controller" +
+			"\nupgrade your controller to use it")
+	}
+	answers, err := c.checkParams(ctx, typedConformantParams)
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	for name, value := range answers {
+		actionParams[name] = value
+	}
+
 	actions := make([]params.Action, len(c.unitReceivers))
 	for i, unitReceiver := range c.unitReceivers {
 		if strings.HasSuffix(unitReceiver, "leader") {
@@ -384,6 +475,7 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (string, []enqueuedAction,
 		}
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
+		actions[i].ExecutionTimeout = c.executionTimeout
 	}
 	results, err := c.api.EnqueueOperation(params.Actions{Actions: actions})
 	if err != nil {
@@ -409,6 +501,59 @@ func (c *runCommand) enqueueActions(ctx *cmd.Context) (string, []enqueuedAction,
 	return operationTag.Id(), tasks, nil
 }
 
+// checkParams checks the params of the action against its schema for
+// the application of each unit, before the action is enqueued, so that
+// every problem with them can be reported. When running in a terminal,
+// the user is asked for any required params which are missing; their
+// values are added to actionParams and returned.
+func (c *runCommand) checkParams(ctx *cmd.Context, actionParams map[string]interface{}) (map[string]interface{}, error) {
+	isTerminal := c.isTerminal
+	if isTerminal == nil {
+		isTerminal = isTerminalReader
+	}
+	answers := make(map[string]interface{})
+	seen := set.NewStrings()
+	for _, unitReceiver := range c.unitReceivers {
+		application := strings.Split(unitReceiver, "/")[0]
+		if seen.Contains(application) {
+			continue
+		}
+		seen.Add(application)
+		specs, err := c.api.ApplicationCharmActions(params.Entity{
+			Tag: names.NewApplicationTag(application).String(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec, ok := specs[c.actionName]
+		if !ok {
+			// The controller reports actions which aren't defined.
+			continue
+		}
+		if missing := missingParams(spec, actionParams); len(missing) > 0 && isTerminal(ctx.Stdin) {
+			query := make(map[string]interface{})
+			pollster := interact.New(ctx.Stdin, ctx.Stderr, ctx.Stderr)
+			if err := queryParams(pollster, spec, missing, query); err != nil {
+				return nil, errors.Trace(err)
+			}
+			for name, value := range query {
+				actionParams[name] = value
+				answers[name] = value
+			}
+		}
+		if err := validateParams(c.actionName, application, spec, actionParams); err != nil {
+			return nil, err
+		}
+	}
+	return answers, nil
+}
+
+// isTerminalReader returns whether r is a terminal.
+func isTerminalReader(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
+
 // filteredOutputKeys are those we don't want to display as part of the
 // results map for plain output.
 var filteredOutputKeys = set.NewStrings("return-code", "stdout", "stderr", "stdout-encoding", "stderr-encoding")
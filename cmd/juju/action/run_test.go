@@ -66,9 +66,11 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectMaxWait        time.Duration
+		expectTimeout        time.Duration
 		expectUnits          []string
 		expectAction         string
 		expectParamsYamlPath string
+		expectParamsJSONPath string
 		expectParseStrings   bool
 		expectKVArgs         [][]string
 		expectOutput         string
@@ -81,6 +83,10 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		should:      "fail with both --background and --max-wait",
 		args:        []string{"--background", "--max-wait=60s", validUnitId, "action"},
 		expectError: "cannot specify both --max-wait and --background",
+	}, {
+		should:      "fail with both --stream and --background",
+		args:        []string{"--background", "--stream", validUnitId, "action"},
+		expectError: "cannot specify both --stream and --background",
 	}, {
 		should:      "fail with no action specified",
 		args:        []string{validUnitId},
@@ -129,6 +135,20 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		expectUnits:   []string{validUnitId},
 		expectAction:  "action",
 		expectMaxWait: 20 * time.Second,
+	}, {
+		should:        "use execution-timeout if specified",
+		args:          []string{validUnitId, "action", "--execution-timeout", "5m"},
+		expectUnits:   []string{validUnitId},
+		expectAction:  "action",
+		expectTimeout: 5 * time.Minute,
+	}, {
+		should:      "fail with both --params and --params-from-json",
+		args:        []string{validUnitId, "action", "--params=foo.yml", "--params-from-json=foo.json"},
+		expectError: "cannot specify both --params and --params-from-json",
+	}, {
+		should:      "fail with negative execution-timeout",
+		args:        []string{validUnitId, "action", "--execution-timeout=-1s"},
+		expectError: "--execution-timeout must not be negative",
 	}, {
 		should:       "work with action name ending in numeric values",
 		args:         []string{validUnitId, "action-01"},
@@ -174,6 +194,12 @@ func (s *CallSuite) TestInit(c *gc.C) {
 		expectUnits:          []string{validUnitId},
 		expectAction:         "valid-action-name",
 		expectParamsYamlPath: "foo.yml",
+	}, {
+		should:               "handle --params-from-json properly",
+		args:                 []string{validUnitId, "valid-action-name", "--params-from-json=foo.json"},
+		expectUnits:          []string{validUnitId},
+		expectAction:         "valid-action-name",
+		expectParamsJSONPath: "foo.json",
 	}, {
 		should: "handle --params and key-value args",
 		args: []string{
@@ -227,6 +253,7 @@ func (s *CallSuite) TestInit(c *gc.C) {
 				c.Check(command.UnitNames(), gc.DeepEquals, t.expectUnits)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
+				c.Check(command.ParamsJSON().Path, gc.Equals, t.expectParamsJSONPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
 				c.Check(command.ParseStrings(), gc.Equals, t.expectParseStrings)
 				if t.expectMaxWait != 0 {
@@ -234,6 +261,7 @@ func (s *CallSuite) TestInit(c *gc.C) {
 				} else {
 					c.Check(command.MaxWait(), gc.Equals, 60*time.Second)
 				}
+				c.Check(command.ExecutionTimeout(), gc.Equals, t.expectTimeout)
 			} else {
 				c.Check(err, gc.ErrorMatches, t.expectError)
 			}
@@ -320,6 +348,88 @@ func (s *CallSuite) TestRun(c *gc.C) {
 			Parameters: map[string]interface{}{},
 			Receiver:   names.NewUnitTag(validUnitId).String(),
 		}},
+	}, {
+		should:   "fail to enqueue an action with an execution timeout on an old controller",
+		withArgs: []string{validUnitId, "some-action", "--background", "--execution-timeout=10m"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+		expectedErr: "--execution-timeout is not supported by this controller\nupgrade your controller to use it",
+	}, {
+		should: "enqueue an action with an execution timeout",
+		clientSetup: func(client *fakeAPIClient) {
+			client.apiVersion = 7
+		},
+		withArgs: []string{validUnitId, "some-action", "--background", "--execution-timeout=10m"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+		expectedActionEnqueued: []params.Action{{
+			Name:             "some-action",
+			Parameters:       map[string]interface{}{},
+			Receiver:         names.NewUnitTag(validUnitId).String(),
+			ExecutionTimeout: 10 * time.Minute,
+		}},
+	}, {
+		should:   "fail to stream action output from an old controller",
+		withArgs: []string{validUnitId, "some-action", "--stream"},
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+		expectedErr: "--stream is not supported by this controller\nupgrade your controller to use it",
+	}, {
+		should: "stream the output of an action",
+		clientSetup: func(client *fakeAPIClient) {
+			client.apiVersion = 7
+			chunk, err := json.Marshal(actions.ActionOutput{
+				Stream: actions.StdoutStream,
+				Data:   "hello\nwo",
+			})
+			if err != nil {
+				panic(err)
+			}
+			client.outputChs = map[string]chan []string{
+				validActionId: make(chan []string, 1),
+			}
+			client.outputChs[validActionId] <- []string{string(chunk)}
+		},
+		withArgs: []string{validUnitId, "some-action", "--stream"},
+		withTags: tagsForIdPrefix(validActionId, validActionTagString),
+		withActionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+				Name:     "some-action",
+			},
+			Status: "completed",
+			Output: map[string]interface{}{
+				"return-code":     "0",
+				"stdout":          "hello\nworld\n",
+				"stdout-encoding": "utf-8",
+				"outcome":         "success",
+			},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Started:   time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 17, 0, 0, time.UTC),
+		}},
+		expectedActionEnqueued: []params.Action{{
+			Name:       "some-action",
+			Parameters: map[string]interface{}{},
+			Receiver:   names.NewUnitTag(validUnitId).String(),
+		}},
+		expectedOutput: `
+hello
+world
+outcome: success`[1:],
 	}, {
 		should:   "run a basic action with no params with output set to action-set data",
 		withArgs: []string{validUnitId, "some-action"},
@@ -894,3 +1004,85 @@ Check task status with 'juju show-task %s'`[1:],
 		}
 	}
 }
+
+// backupSchema is the schema of the backup action, as
+// returned by the API.
+var backupSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"outfile": map[string]interface{}{"type": "string"},
+		"level":   map[string]interface{}{"type": "integer"},
+	},
+	"required":             []interface{}{"outfile"},
+	"additionalProperties": false,
+}
+
+func (s *CallSuite) runBackup(c *gc.C, isTerminal bool, stdin string, args ...string) (*fakeAPIClient, *cmd.Context, error) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{Tag: validActionTagString},
+		}},
+		charmActions: map[string]params.ActionSpec{
+			"backup": {Params: backupSchema},
+		},
+		apiVersion: 6,
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, command := action.NewRunCommandForTest(s.store, nil)
+	command.SetIsTerminal(isTerminal)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	args = append([]string{"-m", "admin", "--background", validUnitId, "backup"}, args...)
+	if err := cmdtesting.InitCommand(wrappedCommand, args); err != nil {
+		return nil, nil, err
+	}
+	err := wrappedCommand.Run(ctx)
+	return fakeClient, ctx, err
+}
+
+func (s *CallSuite) TestRunInvalidParams(c *gc.C) {
+	fakeClient, _, err := s.runBackup(c, false, "", "level=high", "extra=1")
+	c.Assert(err, gc.ErrorMatches, `
+invalid parameters for action "backup" of application "mysql":
+  - "outfile" property is missing and required
+  - additional property "extra" is not allowed
+  - level: must be of type integer`[1:])
+	c.Assert(fakeClient.EnqueuedActions().Actions, gc.HasLen, 0)
+}
+
+func (s *CallSuite) TestRunParamsFromJSON(c *gc.C) {
+	path := setupValueFile(c, s.dir, "params.json", `{"outfile": "out.tar", "level": 3}`)
+	fakeClient, _, err := s.runBackup(c, false, "", "--params-from-json", path)
+	c.Assert(err, jc.ErrorIsNil)
+	enqueued := fakeClient.EnqueuedActions().Actions
+	c.Assert(enqueued, gc.HasLen, 1)
+	c.Assert(enqueued[0].Parameters, jc.DeepEquals, map[string]interface{}{
+		"outfile": "out.tar",
+		"level":   float64(3),
+	})
+}
+
+func (s *CallSuite) TestRunParamsFromInvalidJSON(c *gc.C) {
+	path := setupValueFile(c, s.dir, "params.json", `["out.tar"]`)
+	_, _, err := s.runBackup(c, false, "", "--params-from-json", path)
+	c.Assert(err, gc.ErrorMatches, "params must contain a JSON object: .*")
+}
+
+func (s *CallSuite) TestRunPromptsForRequiredParams(c *gc.C) {
+	fakeClient, ctx, err := s.runBackup(c, true, "out.tar\n", "level=2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Enter outfile: ")
+	enqueued := fakeClient.EnqueuedActions().Actions
+	c.Assert(enqueued, gc.HasLen, 1)
+	c.Assert(enqueued[0].Parameters, jc.DeepEquals, map[string]interface{}{
+		"outfile": "out.tar",
+		"level":   2,
+	})
+}
+
+func (s *CallSuite) TestRunDoesNotPromptWithoutTerminal(c *gc.C) {
+	_, _, err := s.runBackup(c, false, "out.tar\n", "level=2")
+	c.Assert(err, gc.ErrorMatches, `(?s)invalid parameters .*"outfile" property is missing and required`)
+}
@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+)
+
+// runbook is a procedure of ordered steps, each running an action or
+// commands on units of the model. If a step fails, or its results do
+// not allow the runbook to continue, the rollback steps are run.
+type runbook struct {
+	Description string        `yaml:"description"`
+	Steps       []runbookStep `yaml:"steps"`
+	Rollback    []runbookStep `yaml:"rollback"`
+}
+
+// runbookStep is a step of a runbook.
+type runbookStep struct {
+	// Name identifies the step in the runbook's operation.
+	Name string `yaml:"name"`
+
+	// Action is the action run by the step, with Params.
+	Action string                 `yaml:"action"`
+	Params map[string]interface{} `yaml:"params"`
+
+	// Exec holds the commands run by the step instead of an
+	// action, with Timeout, as for juju exec.
+	Exec    string        `yaml:"exec"`
+	Timeout time.Duration `yaml:"timeout"`
+
+	// The step runs on the Units, and on all the units of the
+	// Applications, or on their leaders only if Leader is set.
+	Applications []string `yaml:"applications"`
+	Units        []string `yaml:"units"`
+	Leader       bool     `yaml:"leader"`
+
+	// ContinueIf holds the results, keyed by their dotted paths,
+	// which each task of the step must return for the runbook to
+	// continue.
+	ContinueIf map[string]interface{} `yaml:"continue-if"`
+
+	// IgnoreFailure allows the runbook to
+	// continue when the tasks of the step fail.
+	IgnoreFailure bool `yaml:"ignore-failure"`
+}
+
+// parseRunbook parses and validates a runbook in YAML.
+func parseRunbook(data []byte) (*runbook, error) {
+	var rb runbook
+	if err := yaml.UnmarshalStrict(data, &rb); err != nil {
+		return nil, errors.Annotate(err, "parsing runbook")
+	}
+	if len(rb.Steps) == 0 {
+		return nil, errors.New("runbook has no steps")
+	}
+	if err := validateRunbookSteps(rb.Steps, "step"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := validateRunbookSteps(rb.Rollback, "rollback step"); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &rb, nil
+}
+
+func validateRunbookSteps(steps []runbookStep, kind string) error {
+	seen := set.NewStrings()
+	for i := range steps {
+		step := &steps[i]
+		if step.Name == "" {
+			return errors.Errorf("%s %d has no name", kind, i+1)
+		}
+		if seen.Contains(step.Name) {
+			return errors.Errorf("%s %q is not unique", kind, step.Name)
+		}
+		seen.Add(step.Name)
+		if err := step.validate(); err != nil {
+			return errors.Annotatef(err, "%s %q", kind, step.Name)
+		}
+	}
+	return nil
+}
+
+func (step *runbookStep) validate() error {
+	switch {
+	case step.Action == "" && step.Exec == "":
+		return errors.New("no action or exec specified")
+	case step.Action != "" && step.Exec != "":
+		return errors.New("cannot specify both action and exec")
+	case step.Action != "" && !nameRule.MatchString(step.Action):
+		return errors.Errorf("invalid action name %q", step.Action)
+	case step.Action != "" && step.Timeout != 0:
+		return errors.New("timeout is only valid with exec")
+	case step.Exec != "" && len(step.Params) > 0:
+		return errors.New("params are only valid with an action")
+	case step.Timeout < 0:
+		return errors.New("timeout must not be negative")
+	}
+	if len(step.Applications) == 0 && len(step.Units) == 0 {
+		return errors.New("no applications or units specified")
+	}
+	if step.Leader && len(step.Applications) == 0 {
+		return errors.New("leader is only valid with applications")
+	}
+	for _, application := range step.Applications {
+		if !names.IsValidApplication(application) {
+			return errors.Errorf("invalid application name %q", application)
+		}
+	}
+	for _, unit := range step.Units {
+		if !names.IsValidUnit(unit) && !validLeader.MatchString(unit) {
+			return errors.Errorf("invalid unit name %q", unit)
+		}
+	}
+	if step.Params != nil {
+		conformantParams, err := common.ConformYAML(step.Params)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		step.Params = conformantParams.(map[string]interface{})
+	}
+	for key := range step.ContinueIf {
+		for _, part := range strings.Split(key, ".") {
+			if part == "" {
+				return errors.Errorf("invalid result key %q in continue-if", key)
+			}
+		}
+	}
+	return nil
+}
+
+// runbookStepArg returns the arguments for
+// running the step of the runbook's operation.
+func (step *runbookStep) runbookStepArg(operationTag string, index int) params.RunRunbookStepArg {
+	return params.RunRunbookStepArg{
+		OperationTag: operationTag,
+		Step:         index,
+		Action:       step.Action,
+		Parameters:   step.Params,
+		Commands:     step.Exec,
+		Timeout:      step.Timeout,
+		Applications: step.Applications,
+		Units:        step.Units,
+		Leader:       step.Leader,
+	}
+}
+
+// checkContinue returns an error describing the first of the
+// results of the step's continue-if conditions which the results
+// of a task do not match.
+func (step *runbookStep) checkContinue(results map[string]interface{}) error {
+	keys := make([]string, 0, len(step.ContinueIf))
+	for key := range step.ContinueIf {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		expected := fmt.Sprint(step.ContinueIf[key])
+		value, ok := resultValue(results, strings.Split(key, "."))
+		if !ok {
+			return errors.Errorf("result %q is missing, expected %q", key, expected)
+		}
+		if actual := fmt.Sprint(value); actual != expected {
+			return errors.Errorf("result %q is %q, expected %q", key, actual, expected)
+		}
+	}
+	return nil
+}
+
+// resultValue returns the value in results at the path of keys.
+func resultValue(results map[string]interface{}, keys []string) (interface{}, bool) {
+	value, ok := results[keys[0]]
+	if !ok || len(keys) == 1 {
+		return value, ok
+	}
+	nested, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return resultValue(nested, keys[1:])
+}
@@ -0,0 +1,452 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// runbookPollInterval is the interval at which
+// the tasks of a runbook step are checked.
+const runbookPollInterval = 2 * time.Second
+
+// runbookHeartbeatInterval is the interval at which the controller is
+// told that the runbook is still being run while waiting for tasks.
+// The controller fails runbooks which go without a heartbeat for much
+// longer than this.
+const runbookHeartbeatInterval = time.Minute
+
+// runbookCancelWait is the maximum time waited for cancelled
+// tasks to stop, before the runbook carries on without them.
+const runbookCancelWait = time.Minute
+
+// errRunbookInterrupted is returned when the runbook is
+// interrupted while it is running.
+var errRunbookInterrupted = errors.New("runbook interrupted")
+
+func NewRunRunbookCommand() cmd.Command {
+	return modelcmd.Wrap(&runRunbookCommand{clock: clock.WallClock})
+}
+
+// runRunbookCommand runs the steps of a runbook as one operation.
+type runRunbookCommand struct {
+	ActionCommandBase
+	api         APIClient
+	clock       clock.Clock
+	file        cmd.FileVar
+	maxWait     time.Duration
+	interrupted chan os.Signal
+}
+
+const runRunbookDoc = `
+Run the steps of a runbook in order, as a single operation. Each step
+runs an action, or commands as with juju exec, on the units of the
+model it targets, and the next step is run once all of its tasks have
+finished. The progress of the runbook can be seen with
+"juju show-operation".
+
+A runbook is a YAML file with a list of steps, and optionally a list of
+rollback steps. If a step fails, or the results of its tasks do not
+match those required to continue, the runbook stops, and the rollback
+steps are run.
+
+    description: Upgrade mysql
+    steps:
+      - name: pause
+        action: pause
+        applications: [mysql]
+      - name: backup
+        action: backup
+        applications: [mysql]
+        leader: true
+        params:
+          outfile: backup.tar.gz
+        continue-if:
+          backup.status: ok
+      - name: upgrade
+        exec: apt-get install -y mysql-server
+        timeout: 10m
+        applications: [mysql]
+      - name: resume
+        action: resume
+        applications: [mysql]
+    rollback:
+      - name: resume
+        action: resume
+        applications: [mysql]
+
+Each step has a name, unique among the steps, and either an action with
+its params, or the commands to exec with an optional timeout. A step
+runs on the specified units, which may be given as <application>/leader,
+and on all the units of the specified applications, or on just their
+leaders if leader is true.
+
+The continue-if results are keyed by their dotted paths in the results
+of each task of the step, and must all match for the runbook to continue.
+If ignore-failure is true, the runbook continues even if tasks of the
+step fail.
+
+Use --max-wait to limit the time waited for the tasks of each step; a
+step whose tasks are not all finished in time fails, and its unfinished
+tasks are cancelled before any rollback steps are run.
+
+The runbook is driven by this command rather than by the controller:
+each step is only started once run-runbook has seen the tasks of the
+previous step finish, so the runbook makes progress only while the
+command is running. Interrupting it with ctrl+c cancels the tasks of
+the current step and marks the operation as aborted; the remaining
+steps and the rollback steps are not run. If the command is killed or
+loses its connection, the controller fails the operation once it has
+heard nothing from the command for ten minutes; tasks already started
+are left to finish.
+
+Examples:
+
+    juju run-runbook upgrade-mysql.yaml
+    juju run-runbook upgrade-mysql.yaml --max-wait 30m
+
+See also:
+    run
+    exec
+    show-operation
+`
+
+// SetFlags implements Command.
+func (c *runRunbookCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.DurationVar(&c.maxWait, "max-wait", 0, "Maximum wait time for the tasks of each step to finish")
+}
+
+// Info implements Command.
+func (c *runRunbookCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "run-runbook",
+		Args:    "<runbook file>",
+		Purpose: "Run the steps of a runbook as an operation.",
+		Doc:     runRunbookDoc,
+	})
+}
+
+// Init implements Command.
+func (c *runRunbookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no runbook file specified")
+	}
+	c.file.Path = args[0]
+	if c.maxWait < 0 {
+		return errors.New("--max-wait must not be negative")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.
+func (c *runRunbookCommand) Run(ctx *cmd.Context) error {
+	data, err := c.file.Read(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rb, err := parseRunbook(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := c.ensureAPI(); err != nil {
+		return errors.Trace(err)
+	}
+	defer c.api.Close()
+	if c.api.BestAPIVersion() < 7 {
+		return errors.New("run-runbook is not supported by this controller\nupgrade your controller to use it")
+	}
+
+	summary := rb.Description
+	if summary == "" {
+		summary = "runbook " + filepath.Base(c.file.Path)
+	}
+	var stepNames []string
+	for _, step := range rb.Steps {
+		stepNames = append(stepNames, step.Name)
+	}
+	for _, step := range rb.Rollback {
+		stepNames = append(stepNames, "rollback: "+step.Name)
+	}
+	operationTag, err := c.api.StartRunbook(summary, stepNames)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	tag, err := names.ParseOperationTag(operationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Running runbook as operation %s", tag.Id())
+
+	c.interrupted = make(chan os.Signal, 1)
+	ctx.InterruptNotify(c.interrupted)
+	defer ctx.StopInterruptNotify(c.interrupted)
+
+	var failure error
+	for i, step := range rb.Steps {
+		if c.isInterrupted() {
+			failure = errRunbookInterrupted
+			break
+		}
+		ctx.Infof("Step %d of %d: %s", i+1, len(rb.Steps), step.Name)
+		if err := c.runStep(ctx, operationTag, i, step); err != nil {
+			failure = errors.Annotatef(err, "step %q failed", step.Name)
+			break
+		}
+	}
+	status := params.ActionCompleted
+	if errors.Cause(failure) == errRunbookInterrupted {
+		ctx.Infof("ctrl+c detected, aborting runbook")
+		status = params.ActionAborted
+	} else if failure != nil {
+		status = params.ActionFailed
+		if len(rb.Rollback) > 0 {
+			ctx.Infof("Step failed, rolling back")
+		}
+		for i, step := range rb.Rollback {
+			ctx.Infof("Rollback step %d of %d: %s", i+1, len(rb.Rollback), step.Name)
+			if err := c.runStep(ctx, operationTag, len(rb.Steps)+i, step); err != nil {
+				ctx.Infof("rollback step %q failed: %v", step.Name, err)
+			}
+		}
+	}
+	if err := c.api.FinishRunbook(params.FinishRunbookArg{
+		OperationTag: operationTag,
+		Status:       status,
+	}); err != nil {
+		return errors.Annotate(err, "finishing runbook")
+	}
+	ctx.Infof("Check operation status with 'juju show-operation %s'", tag.Id())
+	return failure
+}
+
+// runStep runs a step of the runbook, and records its result in the
+// runbook's operation. An error is returned if the step fails, or the
+// results of its tasks do not allow the runbook to continue.
+func (c *runRunbookCommand) runStep(ctx *cmd.Context, operationTag string, index int, step runbookStep) error {
+	stepErr := c.runStepTasks(ctx, operationTag, index, step)
+	status := params.RunbookStepStatusArg{
+		OperationTag: operationTag,
+		Step:         index,
+		Status:       params.ActionCompleted,
+	}
+	if stepErr != nil {
+		status.Status = params.ActionFailed
+		status.Message = stepErr.Error()
+	}
+	if err := c.api.SetRunbookStepStatus(status); err != nil {
+		if stepErr != nil {
+			return stepErr
+		}
+		return errors.Annotate(err, "recording step status")
+	}
+	return stepErr
+}
+
+func (c *runRunbookCommand) runStepTasks(ctx *cmd.Context, operationTag string, index int, step runbookStep) error {
+	enqueued, err := c.api.RunRunbookStep(step.runbookStepArg(operationTag, index))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var enqueueErr error
+	var tasks []names.ActionTag
+	for _, task := range enqueued.Actions {
+		if task.Error != nil {
+			enqueueErr = task.Error
+			continue
+		}
+		tag, err := names.ParseActionTag(task.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tasks = append(tasks, tag)
+	}
+	results, err := c.waitForTasks(operationTag, tasks)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if enqueueErr != nil {
+		return errors.Annotate(enqueueErr, "enqueuing task")
+	}
+	for _, result := range results {
+		id, receiver := taskDescription(result)
+		ctx.Infof("  - task %s on %s %s", id, receiver, result.Status)
+		if result.Status != params.ActionCompleted {
+			if step.IgnoreFailure {
+				continue
+			}
+			if result.Message != "" {
+				return errors.Errorf("task %s on %s %s: %s", id, receiver, result.Status, result.Message)
+			}
+			return errors.Errorf("task %s on %s %s", id, receiver, result.Status)
+		}
+		if err := step.checkContinue(result.Output); err != nil {
+			return errors.Annotatef(err, "task %s on %s", id, receiver)
+		}
+	}
+	return nil
+}
+
+// isInterrupted reports whether the runbook has been interrupted.
+func (c *runRunbookCommand) isInterrupted() bool {
+	select {
+	case <-c.interrupted:
+		return true
+	default:
+		return false
+	}
+}
+
+// waitForTasks waits for the tasks to finish, for no longer than
+// maxWait, and returns their results. If the wait times out or the
+// runbook is interrupted, the unfinished tasks are cancelled. While
+// waiting, heartbeats are recorded for the runbook's operation.
+func (c *runRunbookCommand) waitForTasks(operationTag string, tasks []names.ActionTag) ([]params.ActionResult, error) {
+	if len(tasks) == 0 {
+		return nil, nil
+	}
+	entities := make([]params.Entity, len(tasks))
+	for i, tag := range tasks {
+		entities[i] = params.Entity{Tag: tag.String()}
+	}
+	var timeout <-chan time.Time
+	if c.maxWait > 0 {
+		timeout = c.clock.After(c.maxWait)
+	}
+	lastHeartbeat := c.clock.Now()
+	for {
+		results, finished, err := c.taskResults(entities)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if finished {
+			return results, nil
+		}
+		if now := c.clock.Now(); now.Sub(lastHeartbeat) >= runbookHeartbeatInterval {
+			if err := c.api.RunbookHeartbeat(operationTag); err != nil {
+				logger.Warningf("recording runbook heartbeat: %v", err)
+			}
+			lastHeartbeat = now
+		}
+		select {
+		case <-c.clock.After(runbookPollInterval):
+		case <-timeout:
+			c.cancelTasks(results)
+			return nil, errors.Errorf("timed out after %v waiting for tasks", c.maxWait)
+		case <-c.interrupted:
+			c.cancelTasks(results)
+			return nil, errRunbookInterrupted
+		}
+	}
+}
+
+// taskResults returns the results of the tasks, and whether
+// they have all finished.
+func (c *runRunbookCommand) taskResults(entities []params.Entity) ([]params.ActionResult, bool, error) {
+	results, err := c.api.Actions(params.Entities{Entities: entities})
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != len(entities) {
+		return nil, false, errors.Errorf("expected %d results, got %d", len(entities), len(results.Results))
+	}
+	finished := true
+	for _, result := range results.Results {
+		if result.Error != nil {
+			return nil, false, result.Error
+		}
+		if !taskFinished(result) {
+			finished = false
+		}
+	}
+	return results.Results, finished, nil
+}
+
+// cancelTasks cancels the unfinished tasks of the results, and waits
+// for them to stop for no longer than runbookCancelWait, so that they
+// do not run alongside any later rollback steps.
+func (c *runRunbookCommand) cancelTasks(results []params.ActionResult) {
+	var entities []params.Entity
+	for _, result := range results {
+		if !taskFinished(result) && result.Action != nil {
+			entities = append(entities, params.Entity{Tag: result.Action.Tag})
+		}
+	}
+	if len(entities) == 0 {
+		return
+	}
+	cancelled, err := c.api.Cancel(params.Entities{Entities: entities})
+	if err != nil {
+		logger.Warningf("cancelling runbook tasks: %v", err)
+		return
+	}
+	for _, result := range cancelled.Results {
+		if result.Error != nil {
+			logger.Warningf("cancelling runbook task: %v", result.Error)
+		}
+	}
+	timeout := c.clock.After(runbookCancelWait)
+	for {
+		_, finished, err := c.taskResults(entities)
+		if err != nil {
+			logger.Warningf("waiting for cancelled runbook tasks: %v", err)
+			return
+		}
+		if finished {
+			return
+		}
+		select {
+		case <-c.clock.After(runbookPollInterval):
+		case <-timeout:
+			logger.Warningf("cancelled runbook tasks still running after %v", runbookCancelWait)
+			return
+		}
+	}
+}
+
+// taskFinished reports whether the task of the result has finished.
+func taskFinished(result params.ActionResult) bool {
+	switch result.Status {
+	case params.ActionPending, params.ActionRunning, params.ActionAborting:
+		return false
+	}
+	return true
+}
+
+// taskDescription returns the id and receiver of the task of a result.
+func taskDescription(result params.ActionResult) (string, string) {
+	if result.Action == nil {
+		return "", ""
+	}
+	id, receiver := result.Action.Tag, result.Action.Receiver
+	if tag, err := names.ParseActionTag(id); err == nil {
+		id = tag.Id()
+	}
+	if tag, err := names.ParseUnitTag(receiver); err == nil {
+		receiver = tag.Id()
+	}
+	return id, receiver
+}
+
+func (c *runRunbookCommand) ensureAPI() (err error) {
+	if c.api != nil {
+		return nil
+	}
+	c.api, err = c.NewActionAPIClient()
+	return errors.Trace(err)
+}
@@ -0,0 +1,405 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RunRunbookSuite struct {
+	scheduleSuite
+	dir string
+}
+
+var _ = gc.Suite(&RunRunbookSuite{})
+
+func (s *RunRunbookSuite) SetUpTest(c *gc.C) {
+	s.scheduleSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+}
+
+const upgradeRunbook = `
+description: Upgrade mysql
+steps:
+  - name: backup
+    action: backup
+    applications: [mysql]
+    leader: true
+    params:
+      outfile: backup.tar.gz
+    continue-if:
+      backup.status: ok
+  - name: upgrade
+    exec: apt-get install -y mysql-server
+    timeout: 10m
+    units: [mysql/0]
+rollback:
+  - name: resume
+    action: resume
+    applications: [mysql]
+`
+
+func task(id, unit, status string, output map[string]interface{}) params.ActionResult {
+	return params.ActionResult{
+		Action: &params.Action{
+			Tag:      "action-" + id,
+			Receiver: "unit-" + unit,
+		},
+		Status: status,
+		Output: output,
+	}
+}
+
+func (s *RunRunbookSuite) run(c *gc.C, client *fakeAPIClient, runbook string, args ...string) (string, error) {
+	restore := s.patchAPIClient(client)
+	defer restore()
+	path := setupValueFile(c, s.dir, "runbook.yaml", runbook)
+	ctx, err := cmdtesting.RunCommand(c, action.NewRunRunbookCommandForTest(s.store, clock.WallClock),
+		append([]string{path}, args...)...)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *RunRunbookSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no runbook file specified",
+	}, {
+		args: []string{"a.yaml", "b.yaml"},
+		err:  `unrecognized args: \["b.yaml"\]`,
+	}, {
+		args: []string{"a.yaml", "--max-wait", "-1s"},
+		err:  "--max-wait must not be negative",
+	}, {
+		args: []string{"a.yaml", "--max-wait", "30m"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewRunRunbookCommandForTest(s.store, clock.WallClock)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *RunRunbookSuite) TestInvalidRunbook(c *gc.C) {
+	for i, test := range []struct {
+		runbook string
+		err     string
+	}{{
+		runbook: "steps: [",
+		err:     "parsing runbook: .*",
+	}, {
+		runbook: "steps:\n  - name: a\n    action: a\n    units: [a/0]\n    unknown: x\n",
+		err:     "(?s)parsing runbook: .*field unknown not found.*",
+	}, {
+		runbook: "description: nothing\n",
+		err:     "runbook has no steps",
+	}, {
+		runbook: "steps:\n  - action: a\n    units: [a/0]\n",
+		err:     "step 1 has no name",
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, units: [a/0]}\n  - {name: a, action: b, units: [a/0]}\n",
+		err:     `step "a" is not unique`,
+	}, {
+		runbook: "steps:\n  - {name: a, units: [a/0]}\n",
+		err:     `step "a": no action or exec specified`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, exec: ls, units: [a/0]}\n",
+		err:     `step "a": cannot specify both action and exec`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: A!, units: [a/0]}\n",
+		err:     `step "a": invalid action name "A!"`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, timeout: 1m, units: [a/0]}\n",
+		err:     `step "a": timeout is only valid with exec`,
+	}, {
+		runbook: "steps:\n  - {name: a, exec: ls, params: {x: 1}, units: [a/0]}\n",
+		err:     `step "a": params are only valid with an action`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a}\n",
+		err:     `step "a": no applications or units specified`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, units: [a/0], leader: true}\n",
+		err:     `step "a": leader is only valid with applications`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, applications: [A]}\n",
+		err:     `step "a": invalid application name "A"`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, units: [a]}\n",
+		err:     `step "a": invalid unit name "a"`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, units: [a/0], continue-if: {a..b: ok}}\n",
+		err:     `step "a": invalid result key "a..b" in continue-if`,
+	}, {
+		runbook: "steps:\n  - {name: a, action: a, units: [a/0]}\nrollback:\n  - {name: b, action: b}\n",
+		err:     `rollback step "b": no applications or units specified`,
+	}} {
+		c.Logf("test %d: %q", i, test.runbook)
+		client := &fakeAPIClient{apiVersion: 7}
+		_, err := s.run(c, client, test.runbook)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(client.runbookCalls, gc.HasLen, 0)
+	}
+}
+
+func (s *RunRunbookSuite) TestRun(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{{
+			task("1", "mysql-0", params.ActionCompleted, map[string]interface{}{
+				"backup": map[string]interface{}{"status": "ok"},
+			}),
+		}, {
+			task("2", "mysql-0", params.ActionCompleted, nil),
+		}},
+	}
+	stderr, err := s.run(c, client, upgradeRunbook)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "Upgrade mysql" [backup upgrade rollback: resume]`,
+		"step 0 completed",
+		"step 1 completed",
+		"finish completed",
+	})
+	c.Assert(client.runbookStepArgs, jc.DeepEquals, []params.RunRunbookStepArg{{
+		OperationTag: "operation-1",
+		Step:         0,
+		Action:       "backup",
+		Parameters:   map[string]interface{}{"outfile": "backup.tar.gz"},
+		Applications: []string{"mysql"},
+		Leader:       true,
+	}, {
+		OperationTag: "operation-1",
+		Step:         1,
+		Commands:     "apt-get install -y mysql-server",
+		Timeout:      10 * time.Minute,
+		Units:        []string{"mysql/0"},
+	}})
+	c.Assert(stderr, gc.Equals, `
+Running runbook as operation 1
+Step 1 of 2: backup
+  - task 1 on mysql/0 completed
+Step 2 of 2: upgrade
+  - task 2 on mysql/0 completed
+Check operation status with 'juju show-operation 1'
+`[1:])
+}
+
+func (s *RunRunbookSuite) TestRunDefaultSummary(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion:   7,
+		runbookSteps: [][]params.ActionResult{{task("1", "mysql-0", params.ActionCompleted, nil)}},
+	}
+	_, err := s.run(c, client, "steps:\n  - {name: pause, action: pause, units: [mysql/0]}\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.runbookCalls[0], gc.Equals, `start "runbook runbook.yaml" [pause]`)
+}
+
+func (s *RunRunbookSuite) TestRunContinueIfNotMatched(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{{
+			task("1", "mysql-0", params.ActionCompleted, map[string]interface{}{
+				"backup": map[string]interface{}{"status": "partial"},
+			}),
+		}, {
+			task("2", "mysql-0", params.ActionCompleted, nil),
+		}},
+	}
+	stderr, err := s.run(c, client, upgradeRunbook)
+	c.Assert(err, gc.ErrorMatches, `step "backup" failed: task 1 on mysql/0: result "backup.status" is "partial", expected "ok"`)
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "Upgrade mysql" [backup upgrade rollback: resume]`,
+		`step 0 failed: task 1 on mysql/0: result "backup.status" is "partial", expected "ok"`,
+		"step 2 completed",
+		"finish failed",
+	})
+	c.Assert(client.runbookStepArgs, gc.HasLen, 2)
+	c.Assert(client.runbookStepArgs[1].Action, gc.Equals, "resume")
+	c.Assert(stderr, gc.Equals, `
+Running runbook as operation 1
+Step 1 of 2: backup
+  - task 1 on mysql/0 completed
+Step failed, rolling back
+Rollback step 1 of 1: resume
+  - task 2 on mysql/0 completed
+Check operation status with 'juju show-operation 1'
+`[1:])
+}
+
+func (s *RunRunbookSuite) TestRunContinueIfMissing(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{{
+			task("1", "mysql-0", params.ActionCompleted, nil),
+		}, {
+			task("2", "mysql-0", params.ActionCompleted, nil),
+		}},
+	}
+	_, err := s.run(c, client, upgradeRunbook)
+	c.Assert(err, gc.ErrorMatches, `step "backup" failed: task 1 on mysql/0: result "backup.status" is missing, expected "ok"`)
+}
+
+func (s *RunRunbookSuite) TestRunTaskFailed(c *gc.C) {
+	failed := task("1", "mysql-0", params.ActionFailed, nil)
+	failed.Message = "disk full"
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{
+			{failed},
+			{task("2", "mysql-0", params.ActionFailed, nil)},
+		},
+	}
+	stderr, err := s.run(c, client, upgradeRunbook)
+	c.Assert(err, gc.ErrorMatches, `step "backup" failed: task 1 on mysql/0 failed: disk full`)
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "Upgrade mysql" [backup upgrade rollback: resume]`,
+		"step 0 failed: task 1 on mysql/0 failed: disk full",
+		"step 2 failed: task 2 on mysql/0 failed",
+		"finish failed",
+	})
+	c.Assert(stderr, jc.Contains, `rollback step "resume" failed: task 2 on mysql/0 failed`)
+}
+
+func (s *RunRunbookSuite) TestRunIgnoreFailure(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{
+			{task("1", "mysql-0", params.ActionFailed, nil)},
+			{task("2", "mysql-0", params.ActionCompleted, nil)},
+		},
+	}
+	_, err := s.run(c, client, `
+steps:
+  - {name: pause, action: pause, units: [mysql/0], ignore-failure: true}
+  - {name: resume, action: resume, units: [mysql/0]}
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "runbook runbook.yaml" [pause resume]`,
+		"step 0 completed",
+		"step 1 completed",
+		"finish completed",
+	})
+}
+
+func (s *RunRunbookSuite) TestRunOldController(c *gc.C) {
+	client := &fakeAPIClient{apiVersion: 6}
+	_, err := s.run(c, client, upgradeRunbook)
+	c.Assert(err, gc.ErrorMatches, "run-runbook is not supported by this controller\nupgrade your controller to use it")
+	c.Assert(client.runbookCalls, gc.HasLen, 0)
+}
+
+func (s *RunRunbookSuite) TestRunMaxWait(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{
+			{task("1", "mysql-0", params.ActionRunning, nil)},
+		},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+	path := setupValueFile(c, s.dir, "runbook.yaml", "steps:\n  - {name: pause, action: pause, units: [mysql/0]}\n")
+
+	clock := testclock.NewClock(time.Now())
+	done := make(chan error)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, action.NewRunRunbookCommandForTest(s.store, clock), path, "--max-wait", "1m")
+		done <- err
+	}()
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `step "pause" failed: timed out after 1m0s waiting for tasks`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for run-runbook")
+	}
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "runbook runbook.yaml" [pause]`,
+		"step 0 failed: timed out after 1m0s waiting for tasks",
+		"finish failed",
+	})
+}
+
+func (s *RunRunbookSuite) TestRunHeartbeat(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{
+			{task("1", "mysql-0", params.ActionRunning, nil)},
+		},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+	path := setupValueFile(c, s.dir, "runbook.yaml", "steps:\n  - {name: pause, action: pause, units: [mysql/0]}\n")
+
+	clock := testclock.NewClock(time.Now())
+	done := make(chan error)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, action.NewRunRunbookCommandForTest(s.store, clock), path, "--max-wait", "90s")
+		done <- err
+	}()
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	err = clock.WaitAdvance(30*time.Second, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `step "pause" failed: timed out after 1m30s waiting for tasks`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for run-runbook")
+	}
+	c.Assert(client.runbookHeartbeats, gc.Equals, 1)
+}
+
+func (s *RunRunbookSuite) TestRunMaxWaitCancelsTasksBeforeRollback(c *gc.C) {
+	client := &fakeAPIClient{
+		apiVersion: 7,
+		runbookSteps: [][]params.ActionResult{
+			{task("1", "mysql-0", params.ActionCompleted, nil), task("2", "mysql-1", params.ActionRunning, nil)},
+			{task("3", "mysql-0", params.ActionCompleted, nil)},
+		},
+	}
+	restore := s.patchAPIClient(client)
+	defer restore()
+	path := setupValueFile(c, s.dir, "runbook.yaml",
+		"steps:\n  - {name: pause, action: pause, applications: [mysql]}\nrollback:\n  - {name: resume, action: resume, units: [mysql/0]}\n")
+
+	clock := testclock.NewClock(time.Now())
+	done := make(chan error)
+	go func() {
+		_, err := cmdtesting.RunCommand(c, action.NewRunRunbookCommandForTest(s.store, clock), path, "--max-wait", "1m")
+		done <- err
+	}()
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, gc.ErrorMatches, `step "pause" failed: timed out after 1m0s waiting for tasks`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for run-runbook")
+	}
+	c.Assert(client.cancelledTags, jc.DeepEquals, []string{"action-2"})
+	c.Assert(client.runbookCalls, jc.DeepEquals, []string{
+		`start "runbook runbook.yaml" [pause rollback: resume]`,
+		"step 0 failed: timed out after 1m0s waiting for tasks",
+		"step 1 completed",
+		"finish failed",
+	})
+}
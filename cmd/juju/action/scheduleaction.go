@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewScheduleActionCommand() cmd.Command {
+	return modelcmd.Wrap(&scheduleActionCommand{})
+}
+
+// scheduleActionCommand adds a schedule on which the
+// controller runs an action on a unit or application.
+type scheduleActionCommand struct {
+	ActionCommandBase
+	receiver     string
+	actionName   string
+	cron         string
+	overlap      string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+}
+
+const scheduleActionDoc = `
+Add a schedule on which the controller runs an action. Each time the
+action is due, an operation is enqueued to run it on the specified unit,
+on all the units of the specified application, or on the leader of the
+application, specified as <application>/leader.
+
+The schedule is given with --cron, in the standard five field format
+(minute, hour, day of month, month, day of week), or as one of the
+descriptors @hourly, @daily, @weekly, @monthly and @yearly. Schedules
+are in UTC, unless prefixed with a time zone, as in "TZ=Europe/London".
+
+If the operation of the previous run of the action is still pending or
+running when the action is next due, the run is skipped, unless
+--overlap queue is specified. When the controller is unavailable at the
+time an action is due, the missed runs are made up by a single run.
+
+Params are validated according to the charm of the application, and are
+specified in the same way as for run-action.
+
+Examples:
+
+    juju schedule-action mysql/leader backup --cron '0 3 * * *'
+    juju schedule-action mysql backup --cron @daily --overlap queue
+    juju schedule-action mysql/0 backup --cron 'TZ=Europe/London 30 2 * * 1' out=out.tar.bz2
+
+See also:
+    action-schedules
+    pause-action-schedule
+    resume-action-schedule
+    remove-action-schedule
+`
+
+// SetFlags implements Command.
+func (c *scheduleActionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.cron, "cron", "", "The cron schedule on which the action is run")
+	f.StringVar(&c.overlap, "overlap", "skip", "Whether to skip or queue runs while the previous run is incomplete")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements Command.
+func (c *scheduleActionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "schedule-action",
+		Args:    "<unit>|<application>|<application>/leader <action> --cron <schedule> [<key>=<value> [<key>[.<key> ...]=<value>]]",
+		Purpose: "Run an action on a schedule.",
+		Doc:     scheduleActionDoc,
+	})
+}
+
+// Init implements Command.
+func (c *scheduleActionCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no unit or application specified")
+	}
+	c.receiver = args[0]
+	if !names.IsValidUnit(c.receiver) && !validLeader.MatchString(c.receiver) && !names.IsValidApplication(c.receiver) {
+		return errors.Errorf("invalid unit or application name %q", c.receiver)
+	}
+	if len(args) == 1 {
+		return errors.New("no action specified")
+	}
+	c.actionName = args[1]
+	if !nameRule.MatchString(c.actionName) {
+		return errors.Errorf("invalid action name %q", c.actionName)
+	}
+	if c.cron == "" {
+		return errors.New("no schedule specified, use --cron")
+	}
+	if _, err := actions.ParseSchedule(c.cron); err != nil {
+		return errors.Trace(err)
+	}
+	switch c.overlap {
+	case "skip", "queue":
+	default:
+		return errors.Errorf(`--overlap must be "skip" or "queue", got %q`, c.overlap)
+	}
+
+	// Parse CLI key-value args if they exist.
+	for _, arg := range args[2:] {
+		thisArg := strings.SplitN(arg, "=", 2)
+		if len(thisArg) != 2 {
+			return errors.Errorf("argument %q must be of the form key...=value", arg)
+		}
+		keySlice := strings.Split(thisArg[0], ".")
+		for _, key := range keySlice {
+			if valid := nameRule.MatchString(key); !valid {
+				return errors.Errorf("key %q must start and end with lowercase alphanumeric, "+
+					"and contain only lowercase alphanumeric and hyphens", key)
+			}
+		}
+		c.args = append(c.args, append(keySlice, thisArg[1]))
+	}
+	return nil
+}
+
+// Run implements Command.
+func (c *scheduleActionCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	actionParams := map[string]interface{}{}
+	if c.paramsYAML.Path != "" {
+		b, err := c.paramsYAML.Read(ctx)
+		if err != nil {
+			return err
+		}
+		if err := yaml.Unmarshal(b, &actionParams); err != nil {
+			return err
+		}
+	}
+	for _, argSlice := range c.args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !c.parseStrings {
+			if err := yaml.Unmarshal([]byte(value), &cleansedValue); err != nil {
+				return err
+			}
+		}
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return err
+	}
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return errors.New("params must contain a YAML map with string keys")
+	}
+
+	schedule, err := api.AddActionSchedule(params.AddActionScheduleArg{
+		Receiver:   c.receiver,
+		Name:       c.actionName,
+		Parameters: typedConformantParams,
+		Schedule:   c.cron,
+		Overlap:    c.overlap,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	message := fmt.Sprintf("Action schedule %s added", schedule.Id)
+	if schedule.NextRun != nil {
+		message += fmt.Sprintf(", %s is next run at %s", schedule.Name, formatTimestamp(*schedule.NextRun, false, false, true))
+	}
+	ctx.Infof("%s.", message)
+	return nil
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+// scheduleSuite is the base suite for the action schedule commands,
+// which run against the current model.
+type scheduleSuite struct {
+	BaseActionSuite
+}
+
+func (s *scheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.store.Models["ctrl"].CurrentModel = "admin/admin"
+}
+
+type ScheduleActionSuite struct {
+	scheduleSuite
+}
+
+var _ = gc.Suite(&ScheduleActionSuite{})
+
+func (s *ScheduleActionSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no unit or application specified",
+	}, {
+		args: []string{invalidUnitId, "backup", "--cron", "@daily"},
+		err:  `invalid unit or application name "something-strange-"`,
+	}, {
+		args: []string{"mysql"},
+		err:  "no action specified",
+	}, {
+		args: []string{"mysql", "Backup", "--cron", "@daily"},
+		err:  `invalid action name "Backup"`,
+	}, {
+		args: []string{"mysql", "backup"},
+		err:  "no schedule specified, use --cron",
+	}, {
+		args: []string{"mysql", "backup", "--cron", "0 3 * *"},
+		err:  `schedule "0 3 \* \*" with 4 fields, expected 5 not valid`,
+	}, {
+		args: []string{"mysql", "backup", "--cron", "@daily", "--overlap", "cancel"},
+		err:  `--overlap must be "skip" or "queue", got "cancel"`,
+	}, {
+		args: []string{"mysql", "backup", "--cron", "@daily", "out"},
+		err:  `argument "out" must be of the form key...=value`,
+	}, {
+		args: []string{"mysql/leader", "backup", "--cron", "0 3 * * *", "out=a.tgz"},
+	}, {
+		args: []string{"mysql/0", "backup", "--cron", "TZ=Europe/London 0 3 * * *", "--overlap", "queue"},
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		cmd := action.NewScheduleActionCommandForTest(s.store)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *ScheduleActionSuite) TestRun(c *gc.C) {
+	nextRun := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		schedules: []params.ActionSchedule{{Id: "1", Name: "backup", NextRun: &nextRun}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewScheduleActionCommandForTest(s.store),
+		"mysql/leader", "backup", "--cron", "0 3 * * *", "out=a.tgz", "file.kind=xz", "count=3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeClient.addedSchedule, jc.DeepEquals, params.AddActionScheduleArg{
+		Receiver: "mysql/leader",
+		Name:     "backup",
+		Parameters: map[string]interface{}{
+			"out":   "a.tgz",
+			"file":  map[string]interface{}{"kind": "xz"},
+			"count": 3,
+		},
+		Schedule: "0 3 * * *",
+		Overlap:  "skip",
+	})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Matches, "Action schedule 1 added, backup is next run at .*\\.\n")
+}
+
+func (s *ScheduleActionSuite) TestRunError(c *gc.C) {
+	fakeClient := &fakeAPIClient{apiErr: errors.New("boom")}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewScheduleActionCommandForTest(s.store),
+		"mysql", "backup", "--cron", "@daily", "--overlap", "queue")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(fakeClient.addedSchedule.Overlap, gc.Equals, "queue")
+}
+
+type ListSchedulesSuite struct {
+	scheduleSuite
+}
+
+var _ = gc.Suite(&ListSchedulesSuite{})
+
+var listedSchedules = func() []params.ActionSchedule {
+	lastRun := time.Date(2020, 1, 1, 3, 0, 0, 0, time.UTC)
+	nextRun := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	return []params.ActionSchedule{{
+		Id:       "1",
+		Receiver: "mysql/leader",
+		Name:     "backup",
+		Schedule: "0 3 * * *",
+		Overlap:  "skip",
+		Owner:    "admin",
+		LastRun:  &lastRun,
+		NextRun:  &nextRun,
+		History: []params.ActionScheduleRun{{
+			Time:         lastRun,
+			OperationTag: "operation-7",
+		}},
+	}, {
+		Id:       "2",
+		Receiver: "mysql",
+		Name:     "vacuum",
+		Schedule: "@weekly",
+		Overlap:  "queue",
+		Paused:   true,
+	}}
+}()
+
+func (s *ListSchedulesSuite) TestRunPlain(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: listedSchedules})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Id  Receiver      Action  Schedule   Overlap  Status  Last run             Next run\n"+
+		" 1  mysql/leader  backup  0 3 * * *  skip     active  2020-01-01T03:00:00  2020-01-02T03:00:00\n"+
+		" 2  mysql         vacuum  @weekly    queue    paused                       \n"+
+		"\n")
+}
+
+func (s *ListSchedulesSuite) TestRunYAML(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{schedules: listedSchedules})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+"1":
+  receiver: mysql/leader
+  action: backup
+  schedule: 0 3 * * *
+  overlap: skip
+  owner: admin
+  last-run: 2020-01-01 03:00:00 +0000 UTC
+  next-run: 2020-01-02 03:00:00 +0000 UTC
+  history:
+  - time: 2020-01-01 03:00:00 +0000 UTC
+    operation: "7"
+"2":
+  receiver: mysql
+  action: vacuum
+  schedule: '@weekly'
+  overlap: queue
+  paused: true
+`[1:])
+}
+
+func (s *ListSchedulesSuite) TestRunNone(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules have been added.\n")
+}
+
+type UpdateScheduleSuite struct {
+	scheduleSuite
+}
+
+var _ = gc.Suite(&UpdateScheduleSuite{})
+
+func (s *UpdateScheduleSuite) TestInit(c *gc.C) {
+	cmd := action.NewPauseScheduleCommandForTest(s.store)
+	err := cmdtesting.InitCommand(cmd, nil)
+	c.Assert(err, gc.ErrorMatches, "no action schedule specified")
+
+	cmd = action.NewResumeScheduleCommandForTest(s.store)
+	err = cmdtesting.InitCommand(cmd, []string{"backup"})
+	c.Assert(err, gc.ErrorMatches, `invalid action schedule id "backup"`)
+
+	cmd = action.NewRemoveScheduleCommandForTest(s.store)
+	err = cmdtesting.InitCommand(cmd, []string{"1", "2"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["2"\]`)
+}
+
+func (s *UpdateScheduleSuite) TestRun(c *gc.C) {
+	fakeClient := &fakeAPIClient{}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, action.NewPauseScheduleCommandForTest(s.store), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Action schedule 1 paused.\n")
+
+	ctx, err = cmdtesting.RunCommand(c, action.NewResumeScheduleCommandForTest(s.store), "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Action schedule 1 resumed.\n")
+
+	ctx, err = cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Action schedule 2 removed.\n")
+
+	c.Assert(fakeClient.scheduleCalls, jc.DeepEquals, []string{"pause 1", "resume 1", "remove 2"})
+}
+
+func (s *UpdateScheduleSuite) TestRunError(c *gc.C) {
+	restore := s.patchAPIClient(&fakeAPIClient{apiErr: errors.New(`action schedule "3" not found`)})
+	defer restore()
+
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "3")
+	c.Assert(err, gc.ErrorMatches, `action schedule "3" not found`)
+}
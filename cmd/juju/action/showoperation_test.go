@@ -279,6 +279,52 @@ tasks:
     results:
       foo:
         bar: baz
+`[1:],
+	}, {
+		should:            "show the steps of a runbook",
+		withClientQueryID: operationId,
+		withAPITimeout:    1 * time.Second,
+		withAPIResponse: []params.OperationResult{{
+			OperationTag: names.NewOperationTag(operationId).String(),
+			Summary:      "Upgrade mysql",
+			Status:       "failed",
+			Steps: []params.RunbookStep{
+				{Name: "pause", Status: "completed"},
+				{Name: "backup", Status: "failed", Message: "task 69 on foo/0 failed"},
+				{Name: "upgrade", Status: "cancelled"},
+			},
+			Actions: []params.ActionResult{{
+				Action: &params.Action{
+					Tag:      names.NewActionTag("69").String(),
+					Name:     "backup",
+					Receiver: "foo/0",
+				},
+				Status: "failed",
+			}},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		expectedOutput: `
+summary: Upgrade mysql
+status: failed
+action:
+  name: backup
+  parameters: {}
+timing:
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+  completed: 2015-02-14 08:15:30 +0000 UTC
+steps:
+- name: pause
+  status: completed
+- name: backup
+  status: failed
+  message: task 69 on foo/0 failed
+- name: upgrade
+  status: cancelled
+tasks:
+  "69":
+    host: foo/0
+    status: failed
 `[1:],
 	}, {
 		should:            "watch, wait, get a result",
@@ -0,0 +1,212 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	coreactions "github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/watcher"
+)
+
+// OutputWatcher is the API used to follow the output of running tasks.
+type OutputWatcher interface {
+	// WatchActionOutput reports on the output streamed by a running action.
+	WatchActionOutput(actionId string) (watcher.StringsWatcher, error)
+}
+
+// OutputStreamer writes the output of running tasks to stdout and
+// stderr as it is produced. When following more than one task, each
+// line is prefixed with the name of the unit running the task.
+type OutputStreamer struct {
+	stdout io.Writer
+	stderr io.Writer
+	prefix bool
+
+	// mu is held while writing a line, so that
+	// lines from different tasks never interleave.
+	mu    sync.Mutex
+	tasks map[string]*taskOutput
+}
+
+// taskOutput holds the output streamed so far by a single task.
+type taskOutput struct {
+	watcher  watcher.StringsWatcher
+	stop     chan struct{}
+	done     chan struct{}
+	writers  map[string]*lineWriter
+	streamed map[string]*strings.Builder
+}
+
+// NewOutputStreamer returns an OutputStreamer which writes to the
+// given stdout and stderr, prefixing each line with the receiver's
+// name if prefix is true.
+func NewOutputStreamer(stdout, stderr io.Writer, prefix bool) *OutputStreamer {
+	return &OutputStreamer{
+		stdout: stdout,
+		stderr: stderr,
+		prefix: prefix,
+		tasks:  make(map[string]*taskOutput),
+	}
+}
+
+// Follow starts writing the output of the specified task, which is
+// run by the named receiver.
+func (s *OutputStreamer) Follow(api OutputWatcher, taskId, receiver string) error {
+	w, err := api.WatchActionOutput(taskId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var prefix string
+	if s.prefix {
+		prefix = receiver + ": "
+	}
+	t := &taskOutput{
+		watcher: w,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		writers: map[string]*lineWriter{
+			coreactions.StdoutStream: {mu: &s.mu, out: s.stdout, prefix: prefix},
+			coreactions.StderrStream: {mu: &s.mu, out: s.stderr, prefix: prefix},
+		},
+		streamed: map[string]*strings.Builder{
+			coreactions.StdoutStream: {},
+			coreactions.StderrStream: {},
+		},
+	}
+	s.tasks[taskId] = t
+	go func() {
+		defer close(t.done)
+		for {
+			var chunks []string
+			select {
+			case <-t.stop:
+				return
+			case changes, ok := <-w.Changes():
+				if !ok {
+					return
+				}
+				chunks = changes
+			}
+			for _, chunk := range chunks {
+				var output coreactions.ActionOutput
+				if err := json.Unmarshal([]byte(chunk), &output); err != nil {
+					logger.Warningf("badly formatted action output: %v\n%v", err, chunk)
+					continue
+				}
+				writer, ok := t.writers[output.Stream]
+				if !ok {
+					continue
+				}
+				t.streamed[output.Stream].WriteString(output.Data)
+				_, _ = writer.Write([]byte(output.Data))
+			}
+		}
+	}()
+	return nil
+}
+
+// Finish stops following the output of the specified task, which
+// has finished with the given result. Any of the task's stdout or
+// stderr that was not streamed while it ran is written now.
+func (s *OutputStreamer) Finish(taskId string, result params.ActionResult) {
+	t, ok := s.tasks[taskId]
+	if !ok {
+		return
+	}
+	s.stopTask(taskId, t)
+	for stream, writer := range t.writers {
+		final, ok := streamResult(result.Output, stream)
+		// The streamed output has its line endings
+		// normalised in the results.
+		streamed := strings.Replace(t.streamed[stream].String(), "\r\n", "\n", -1)
+		if ok && len(final) > len(streamed) && strings.HasPrefix(final, streamed) {
+			_, _ = writer.Write([]byte(final[len(streamed):]))
+		}
+		writer.Flush()
+	}
+}
+
+// Stop stops following the output of all tasks.
+func (s *OutputStreamer) Stop() {
+	for taskId, t := range s.tasks {
+		s.stopTask(taskId, t)
+		for _, writer := range t.writers {
+			writer.Flush()
+		}
+	}
+}
+
+func (s *OutputStreamer) stopTask(taskId string, t *taskOutput) {
+	close(t.stop)
+	<-t.done
+	t.watcher.Kill()
+	if err := t.watcher.Wait(); err != nil {
+		logger.Debugf("watching output of task %s: %v", taskId, err)
+	}
+	delete(s.tasks, taskId)
+}
+
+// streamResult returns the named stream's output from the results
+// of an action, if it is present and was not encoded.
+func streamResult(output map[string]interface{}, stream string) (string, bool) {
+	for _, key := range []string{stream, strings.ToUpper(stream[:1]) + stream[1:]} {
+		if value, ok := output[key].(string); ok {
+			if encoding, _ := output[key+"-encoding"].(string); encoding == "base64" {
+				return "", false
+			}
+			if encoding, _ := output[key+"Encoding"].(string); encoding == "base64" {
+				return "", false
+			}
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// lineWriter writes complete lines to an underlying writer,
+// each preceded by a prefix.
+type lineWriter struct {
+	mu      *sync.Mutex
+	out     io.Writer
+	prefix  string
+	partial []byte
+}
+
+// Write is part of io.Writer.
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(w.partial[:i+1])
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush writes any incomplete line.
+func (w *lineWriter) Flush() {
+	if len(w.partial) == 0 {
+		return
+	}
+	w.writeLine(append(w.partial, '\n'))
+	w.partial = nil
+}
+
+func (w *lineWriter) writeLine(line []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, _ = io.WriteString(w.out, w.prefix)
+	_, _ = w.out.Write(line)
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+	"github.com/juju/juju/core/actions"
+)
+
+type OutputStreamerSuite struct{}
+
+var _ = gc.Suite(&OutputStreamerSuite{})
+
+func (s *OutputStreamerSuite) outputCh(c *gc.C, chunks ...actions.ActionOutput) chan []string {
+	encoded := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		data, err := json.Marshal(chunk)
+		c.Assert(err, jc.ErrorIsNil)
+		encoded[i] = string(data)
+	}
+	ch := make(chan []string, 1)
+	ch <- encoded
+	return ch
+}
+
+func (s *OutputStreamerSuite) TestWritesRemainingOutputOnFinish(c *gc.C) {
+	client := &fakeAPIClient{
+		outputChs: map[string]chan []string{
+			"1": s.outputCh(c,
+				actions.ActionOutput{Stream: actions.StdoutStream, Data: "one\ntw"},
+				actions.ActionOutput{Stream: actions.StderrStream, Data: "oops"},
+			),
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	streamer := action.NewOutputStreamer(&stdout, &stderr, false)
+	err := streamer.Follow(client, "1", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	streamer.Finish("1", params.ActionResult{
+		Output: map[string]interface{}{
+			"stdout": "one\ntwo\nthree\n",
+			"stderr": "oops\n",
+		},
+	})
+	c.Check(stdout.String(), gc.Equals, "one\ntwo\nthree\n")
+	c.Check(stderr.String(), gc.Equals, "oops\n")
+}
+
+func (s *OutputStreamerSuite) TestPrefixesLines(c *gc.C) {
+	client := &fakeAPIClient{
+		outputChs: map[string]chan []string{
+			"1": s.outputCh(c, actions.ActionOutput{Stream: actions.StdoutStream, Data: "one\n"}),
+			"2": s.outputCh(c, actions.ActionOutput{Stream: actions.StdoutStream, Data: "two\n"}),
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	streamer := action.NewOutputStreamer(&stdout, &stderr, true)
+	err := streamer.Follow(client, "1", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = streamer.Follow(client, "2", "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	streamer.Finish("1", params.ActionResult{
+		Output: map[string]interface{}{"stdout": "one\n"},
+	})
+	streamer.Finish("2", params.ActionResult{
+		Output: map[string]interface{}{"stdout": "two\nmore\n"},
+	})
+	lines := strings.Split(strings.TrimSuffix(stdout.String(), "\n"), "\n")
+	sort.Strings(lines)
+	c.Check(lines, jc.DeepEquals, []string{"mysql/0: one", "mysql/1: more", "mysql/1: two"})
+	c.Check(stderr.String(), gc.Equals, "")
+}
+
+func (s *OutputStreamerSuite) TestSkipsEncodedResults(c *gc.C) {
+	client := &fakeAPIClient{
+		outputChs: map[string]chan []string{
+			"1": s.outputCh(c, actions.ActionOutput{Stream: actions.StdoutStream, Data: "partial"}),
+		},
+	}
+	var stdout, stderr bytes.Buffer
+	streamer := action.NewOutputStreamer(&stdout, &stderr, true)
+	err := streamer.Follow(client, "1", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	streamer.Finish("1", params.ActionResult{
+		Output: map[string]interface{}{
+			"stdout":          "cGFydGlhbA==",
+			"stdout-encoding": "base64",
+		},
+	})
+	// Whether or not the chunk was received, nothing is
+	// written from the encoded results.
+	c.Check(stdout.String(), gc.Matches, "(mysql/0: partial\n)?")
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// scheduleUpdate describes a change made to an action schedule
+// by one of the pause, resume and remove commands.
+type scheduleUpdate struct {
+	name    string
+	purpose string
+	doc     string
+	done    string
+	update  func(APIClient, string) error
+}
+
+var (
+	pauseScheduleUpdate = scheduleUpdate{
+		name:    "pause-action-schedule",
+		purpose: "Stop running an action on its schedule.",
+		doc: `
+Stop the controller from running the action of a schedule, until the
+schedule is resumed with resume-action-schedule. The operations already
+enqueued by the schedule are not affected.
+
+Examples:
+
+    juju pause-action-schedule 3
+
+See also:
+    action-schedules
+    resume-action-schedule
+`,
+		done:   "paused",
+		update: APIClient.PauseActionSchedule,
+	}
+
+	resumeScheduleUpdate = scheduleUpdate{
+		name:    "resume-action-schedule",
+		purpose: "Resume running an action on its schedule.",
+		doc: `
+Resume running the action of a schedule paused with pause-action-schedule.
+The runs that were due while the schedule was paused are not made up.
+
+Examples:
+
+    juju resume-action-schedule 3
+
+See also:
+    action-schedules
+    pause-action-schedule
+`,
+		done:   "resumed",
+		update: APIClient.ResumeActionSchedule,
+	}
+
+	removeScheduleUpdate = scheduleUpdate{
+		name:    "remove-action-schedule",
+		purpose: "Remove the schedule on which an action is run.",
+		doc: `
+Remove a schedule added with schedule-action, along with the history of
+its runs. The operations already enqueued by the schedule are not affected.
+
+Examples:
+
+    juju remove-action-schedule 3
+
+See also:
+    action-schedules
+    schedule-action
+`,
+		done:   "removed",
+		update: APIClient.RemoveActionSchedule,
+	}
+)
+
+func NewPauseScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&updateScheduleCommand{scheduleUpdate: pauseScheduleUpdate})
+}
+
+func NewResumeScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&updateScheduleCommand{scheduleUpdate: resumeScheduleUpdate})
+}
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&updateScheduleCommand{scheduleUpdate: removeScheduleUpdate})
+}
+
+// updateScheduleCommand pauses, resumes or removes an action schedule.
+type updateScheduleCommand struct {
+	ActionCommandBase
+	scheduleUpdate
+	id string
+}
+
+// Info implements Command.
+func (c *updateScheduleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    c.name,
+		Args:    "<schedule id>",
+		Purpose: c.purpose,
+		Doc:     c.doc,
+	})
+}
+
+// Init implements Command.
+func (c *updateScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no action schedule specified")
+	}
+	c.id = args[0]
+	if _, err := strconv.ParseUint(c.id, 10, 64); err != nil {
+		return errors.Errorf("invalid action schedule id %q", c.id)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements Command.
+func (c *updateScheduleCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := c.update(api, c.id); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Action schedule %s %s.", c.id, c.done)
+	return nil
+}
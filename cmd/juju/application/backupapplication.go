@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewBackupApplicationCommand returns a command which backs
+// up the persistent storage of a k8s application.
+func NewBackupApplicationCommand() modelcmd.ModelCommand {
+	cmd := &backupApplicationCommand{}
+	cmd.newAPIFunc = func() (backupApplicationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// backupApplicationCommand is responsible for
+// backing up the storage of applications.
+type backupApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (backupApplicationAPI, error)
+	applicationName string
+}
+
+const backupApplicationDoc = `
+Take a snapshot of every persistent volume claim owned by the storage of
+an application, using the CSI volume snapshot API of the cluster.
+
+The snapshots are recorded as a backup of the application, identified by
+the time at which it was made. The application's storage can be restored
+from the backup with restore-application.
+
+The cluster must have the volume snapshot resources installed, and a
+default volume snapshot class for the storage of the application.
+
+Examples:
+
+    juju backup-application mariadb
+
+See also:
+    restore-application
+`
+
+// Info implements cmd.Command.
+func (c *backupApplicationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "backup-application",
+		Args:    "<application>",
+		Purpose: "Snapshot the persistent storage of an application.",
+		Doc:     backupApplicationDoc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *backupApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+type backupApplicationAPI interface {
+	Close() error
+	BackupApplication(applicationName string) (params.ApplicationBackup, error)
+}
+
+// Run implements cmd.Command.
+func (c *backupApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	backup, err := client.BackupApplication(c.applicationName)
+	if err != nil {
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "could not back up application %q", c.applicationName), block.BlockChange,
+		)
+	}
+	for _, snapshot := range backup.Snapshots {
+		ctx.Verbosef("snapshot %s of %s (%s storage)", snapshot.SnapshotName, snapshot.ClaimName, snapshot.StorageName)
+	}
+	ctx.Infof("Backup %s of application %q made with %d volume snapshot(s).",
+		backup.Id, c.applicationName, len(backup.Snapshots))
+	return nil
+}
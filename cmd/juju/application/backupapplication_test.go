@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type BackupApplicationSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockApplicationBackupAPI
+}
+
+var _ = gc.Suite(&BackupApplicationSuite{})
+
+type mockApplicationBackupAPI struct {
+	*testing.Stub
+}
+
+func (s *mockApplicationBackupAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockApplicationBackupAPI) BackupApplication(applicationName string) (params.ApplicationBackup, error) {
+	s.MethodCall(s, "BackupApplication", applicationName)
+	return params.ApplicationBackup{
+		Id:             "20200102-030405",
+		ApplicationTag: "application-" + applicationName,
+		Snapshots: []params.VolumeSnapshot{{
+			StorageName:  "database",
+			ClaimName:    "database-" + applicationName + "-0",
+			SnapshotName: "database-" + applicationName + "-0-20200102-030405",
+			Size:         1024,
+		}},
+	}, s.NextErr()
+}
+
+func (s *mockApplicationBackupAPI) RestoreApplication(applicationName, backupId string) error {
+	s.MethodCall(s, "RestoreApplication", applicationName, backupId)
+	return s.NextErr()
+}
+
+func (s *BackupApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockApplicationBackupAPI{Stub: &testing.Stub{}}
+}
+
+// caasStore returns a client store whose current model is a k8s model.
+func caasStore() jujuclient.ClientStore {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return store
+}
+
+func (s *BackupApplicationSuite) runBackup(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewBackupApplicationCommandForTest(s.mockAPI, caasStore()), args...)
+}
+
+func (s *BackupApplicationSuite) TestBackup(c *gc.C) {
+	ctx, err := s.runBackup(c, "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Backup 20200102-030405 of application \"mariadb\" made with 1 volume snapshot(s).\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"BackupApplication", []interface{}{"mariadb"}},
+		{"Close", nil},
+	})
+}
+
+func (s *BackupApplicationSuite) TestBackupFails(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runBackup(c, "mariadb")
+	c.Assert(err, gc.ErrorMatches, `could not back up application "mariadb": boom`)
+}
+
+func (s *BackupApplicationSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"MariaDB"},
+		err:  `invalid application name "MariaDB"`,
+	}, {
+		args: []string{"mariadb", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runBackup(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
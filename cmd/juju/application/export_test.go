@@ -309,3 +309,34 @@ func (s *RepoSuiteBaseSuite) SetUpTest(c *gc.C) {
 		return defaultSupportedJujuSeries, nil
 	})
 }
+
+// NewExposeCommandForTest returns an ExposeCommand with the api provided as specified.
+func NewExposeCommandForTest(api applicationExposeAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &exposeCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewImportK8sWorkloadCommandForTest(api importK8sWorkloadAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &importK8sWorkloadCommand{newAPIFunc: func() (importK8sWorkloadAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewBackupApplicationCommandForTest(api backupApplicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &backupApplicationCommand{newAPIFunc: func() (backupApplicationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRestoreApplicationCommandForTest(api restoreApplicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &restoreApplicationCommand{newAPIFunc: func() (restoreApplicationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
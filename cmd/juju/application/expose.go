@@ -4,13 +4,20 @@
 package application
 
 import (
+	"crypto/tls"
+	"io/ioutil"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 )
 
 var usageExposeSummary = `
@@ -20,8 +27,20 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+On k8s models, the application is exposed through an ingress. The
+--hostname option sets the host name the ingress serves. TLS can be
+terminated at the ingress either by naming a cert-manager cluster issuer
+with --tls-issuer, or by supplying a PEM encoded certificate and key
+with --tls-cert and --tls-key, which Juju stores in a k8s secret. Only
+the name of the secret is kept in the application config, where the
+kubernetes-ingress-tls-secret setting can also name a TLS secret already
+in the cluster. Running expose again with a new certificate and key
+rotates the secret.
+
 Examples:
     juju expose wordpress
+    juju expose gitlab --hostname gitlab.example.com --tls-issuer letsencrypt
+    juju expose gitlab --hostname gitlab.example.com --tls-cert cert.pem --tls-key key.pem
 
 See also: 
     unexpose`[1:]
@@ -35,6 +54,13 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	hostname  string
+	tlsIssuer string
+	tlsCert   string
+	tlsKey    string
+
+	api applicationExposeAPI
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,11 +72,26 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.hostname, "hostname", "", "The host name of the ingress (k8s models only)")
+	f.StringVar(&c.tlsIssuer, "tls-issuer", "", "The cert-manager cluster issuer of the ingress TLS certificate (k8s models only)")
+	f.StringVar(&c.tlsCert, "tls-cert", "", "Path to the PEM encoded ingress TLS certificate (k8s models only)")
+	f.StringVar(&c.tlsKey, "tls-key", "", "Path to the PEM encoded private key of the ingress TLS certificate (k8s models only)")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	if c.tlsIssuer != "" && (c.tlsCert != "" || c.tlsKey != "") {
+		return errors.New("cannot specify --tls-issuer with --tls-cert or --tls-key")
+	}
+	if (c.tlsCert == "") != (c.tlsKey == "") {
+		return errors.New("--tls-cert and --tls-key must be specified together")
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -58,9 +99,15 @@ type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
 	Unexpose(applicationName string) error
+	SetApplicationConfig(branchName, application string, config map[string]string) error
+	UnsetApplicationConfig(branchName, application string, options []string) error
+	SetIngressTLSCertificate(application string, certificate, key []byte) (string, error)
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
 	root, err := c.NewAPIRoot()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -70,11 +117,81 @@ func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
 
 // Run changes the juju-managed firewall to expose any
 // ports that were also explicitly marked by units as open.
-func (c *exposeCommand) Run(_ *cmd.Context) error {
+func (c *exposeCommand) Run(ctx *cmd.Context) error {
+	ingress, err := c.ingressOptions(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	client, err := c.getAPI()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
+
+	if ingress.certificate != nil {
+		secretName, err := client.SetIngressTLSCertificate(c.ApplicationName, ingress.certificate, ingress.key)
+		if err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ingress.config[k8sprovider.IngressTLSSecretKey] = secretName
+	}
+	// The keys being replaced are unset first, so that the TLS issuer
+	// and secret are never both set.
+	if len(ingress.unset) > 0 {
+		if err := client.UnsetApplicationConfig(model.GenerationMaster, c.ApplicationName, ingress.unset); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
+	if len(ingress.config) > 0 {
+		if err := client.SetApplicationConfig(model.GenerationMaster, c.ApplicationName, ingress.config); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+	}
 	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
 }
+
+// ingressOptions holds the application config to set and the config
+// keys to unset for the ingress options passed to the command, and any
+// TLS certificate and key to store for the ingress.
+type ingressOptions struct {
+	config      map[string]string
+	unset       []string
+	certificate []byte
+	key         []byte
+}
+
+// ingressOptions returns the ingress options passed to the command.
+func (c *exposeCommand) ingressOptions(ctx *cmd.Context) (ingressOptions, error) {
+	result := ingressOptions{config: make(map[string]string)}
+	if c.hostname == "" && c.tlsIssuer == "" && c.tlsCert == "" {
+		return result, nil
+	}
+	modelType, err := c.ModelType()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if modelType != model.CAAS {
+		return result, errors.NotSupportedf("ingress options on a non-container model")
+	}
+
+	if c.hostname != "" {
+		result.config[caas.JujuExternalHostNameKey] = c.hostname
+	}
+	switch {
+	case c.tlsIssuer != "":
+		result.config[k8sprovider.IngressTLSIssuerKey] = c.tlsIssuer
+		result.unset = []string{k8sprovider.IngressTLSSecretKey}
+	case c.tlsCert != "":
+		if result.certificate, err = ioutil.ReadFile(ctx.AbsPath(c.tlsCert)); err != nil {
+			return result, errors.Annotate(err, "reading TLS certificate")
+		}
+		if result.key, err = ioutil.ReadFile(ctx.AbsPath(c.tlsKey)); err != nil {
+			return result, errors.Annotate(err, "reading TLS key")
+		}
+		if _, err := tls.X509KeyPair(result.certificate, result.key); err != nil {
+			return result, errors.Annotate(err, "invalid TLS certificate and key")
+		}
+		result.unset = []string{k8sprovider.IngressTLSIssuerKey}
+	}
+	return result, nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ExposeTLSSuite struct {
+	testing.IsolationSuite
+
+	mockAPI   *mockExposeAPI
+	modelType model.ModelType
+}
+
+var _ = gc.Suite(&ExposeTLSSuite{})
+
+type mockExposeAPI struct {
+	*testing.Stub
+}
+
+func (s mockExposeAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockExposeAPI) Expose(application string) error {
+	s.MethodCall(s, "Expose", application)
+	return s.NextErr()
+}
+
+func (s mockExposeAPI) Unexpose(application string) error {
+	s.MethodCall(s, "Unexpose", application)
+	return s.NextErr()
+}
+
+func (s mockExposeAPI) SetApplicationConfig(branchName, application string, config map[string]string) error {
+	s.MethodCall(s, "SetApplicationConfig", branchName, application, config)
+	return s.NextErr()
+}
+
+func (s mockExposeAPI) UnsetApplicationConfig(branchName, application string, options []string) error {
+	s.MethodCall(s, "UnsetApplicationConfig", branchName, application, options)
+	return s.NextErr()
+}
+
+func (s mockExposeAPI) SetIngressTLSCertificate(application string, certificate, key []byte) (string, error) {
+	s.MethodCall(s, "SetIngressTLSCertificate", application, certificate, key)
+	return application + "-tls", s.NextErr()
+}
+
+func (s *ExposeTLSSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockExposeAPI{Stub: &testing.Stub{}}
+	s.modelType = model.CAAS
+}
+
+func (s *ExposeTLSSuite) runExpose(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: s.modelType,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewExposeCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ExposeTLSSuite) TestExposeWithoutOptions(c *gc.C) {
+	_, err := s.runExpose(c, "gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "Expose", "Close")
+}
+
+func (s *ExposeTLSSuite) TestExposeWithIssuer(c *gc.C) {
+	_, err := s.runExpose(c, "gitlab", "--hostname", "gitlab.example.com", "--tls-issuer", "letsencrypt")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UnsetApplicationConfig", []interface{}{model.GenerationMaster, "gitlab", []string{
+			"kubernetes-ingress-tls-secret",
+		}}},
+		{"SetApplicationConfig", []interface{}{model.GenerationMaster, "gitlab", map[string]string{
+			"juju-external-hostname":        "gitlab.example.com",
+			"kubernetes-ingress-tls-issuer": "letsencrypt",
+		}}},
+		{"Expose", []interface{}{"gitlab"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ExposeTLSSuite) writeKeyPair(c *gc.C, cert, key string) (string, string) {
+	dir := c.MkDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	c.Assert(ioutil.WriteFile(certPath, []byte(cert), 0600), jc.ErrorIsNil)
+	c.Assert(ioutil.WriteFile(keyPath, []byte(key), 0600), jc.ErrorIsNil)
+	return certPath, keyPath
+}
+
+func (s *ExposeTLSSuite) TestExposeWithCertificate(c *gc.C) {
+	certPath, keyPath := s.writeKeyPair(c, coretesting.ServerCert, coretesting.ServerKey)
+
+	_, err := s.runExpose(c, "gitlab", "--tls-cert", certPath, "--tls-key", keyPath)
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"SetIngressTLSCertificate", []interface{}{
+			"gitlab", []byte(coretesting.ServerCert), []byte(coretesting.ServerKey),
+		}},
+		{"UnsetApplicationConfig", []interface{}{model.GenerationMaster, "gitlab", []string{
+			"kubernetes-ingress-tls-issuer",
+		}}},
+		{"SetApplicationConfig", []interface{}{model.GenerationMaster, "gitlab", map[string]string{
+			"kubernetes-ingress-tls-secret": "gitlab-tls",
+		}}},
+		{"Expose", []interface{}{"gitlab"}},
+		{"Close", nil},
+	})
+}
+
+func (s *ExposeTLSSuite) TestExposeWithInvalidCertificate(c *gc.C) {
+	certPath, keyPath := s.writeKeyPair(c, coretesting.ServerCert, coretesting.CAKey)
+
+	_, err := s.runExpose(c, "gitlab", "--tls-cert", certPath, "--tls-key", keyPath)
+	c.Assert(err, gc.ErrorMatches, "invalid TLS certificate and key: .*")
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ExposeTLSSuite) TestExposeMissingCertificateFile(c *gc.C) {
+	_, err := s.runExpose(c, "gitlab", "--tls-cert", "missing.pem", "--tls-key", "missing.pem")
+	c.Assert(err, gc.ErrorMatches, "reading TLS certificate: .*")
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ExposeTLSSuite) TestExposeOptionsIAASModel(c *gc.C) {
+	s.modelType = model.IAAS
+	_, err := s.runExpose(c, "mysql", "--hostname", "mysql.example.com")
+	c.Assert(err, gc.ErrorMatches, "ingress options on a non-container model not supported")
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *ExposeTLSSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"gitlab", "--tls-issuer", "letsencrypt", "--tls-cert", "cert.pem", "--tls-key", "key.pem"},
+		err:  "cannot specify --tls-issuer with --tls-cert or --tls-key",
+	}, {
+		args: []string{"gitlab", "--tls-cert", "cert.pem"},
+		err:  "--tls-cert and --tls-key must be specified together",
+	}, {
+		args: []string{"gitlab", "--tls-key", "key.pem"},
+		err:  "--tls-cert and --tls-key must be specified together",
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runExpose(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/caas"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewImportK8sWorkloadCommand returns a command which imports
+// a workload running in a k8s cluster as an application.
+func NewImportK8sWorkloadCommand() modelcmd.ModelCommand {
+	cmd := &importK8sWorkloadCommand{}
+	cmd.newAPIFunc = func() (importK8sWorkloadAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &importK8sWorkloadAPIAdapter{
+			Client:     application.NewClient(root),
+			charmAdder: root.Client(),
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// importK8sWorkloadCommand is responsible for importing
+// k8s workloads into a model.
+type importK8sWorkloadCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc     func() (importK8sWorkloadAPI, error)
+	name           string
+	deploymentType caas.DeploymentType
+	provides       []string
+	requires       []string
+}
+
+const importK8sWorkloadDoc = `
+Import a deployment or stateful set already running in the model's
+namespace, so that it is managed as a Juju application.
+
+The application takes the name of the workload, and is deployed with a
+generic charm which has no hooks. The workload, its pod template and any
+service with the same name are given the labels and annotations Juju uses
+for the resources of the application. Changing the pod template rolls out
+new pods, which become the units of the application.
+
+Once imported, the application can be scaled, and its status is reported,
+like any other application. Endpoints for the generic charm may be
+specified as name:interface, so that the application can be related to
+others; both options may be given more than once.
+
+Examples:
+
+    juju import-k8s-workload deployment/web
+    juju import-k8s-workload statefulset/db --provides db:pgsql
+    juju import-k8s-workload deploy/api --provides api:http --requires db:pgsql
+
+See also:
+    scale-application
+    relate
+`
+
+// Info implements cmd.Command.
+func (c *importK8sWorkloadCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-k8s-workload",
+		Args:    "<deployment|statefulset>/<name>",
+		Purpose: "Import a workload running in the cluster as an application.",
+		Doc:     importK8sWorkloadDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *importK8sWorkloadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.provides), "provides", "An endpoint provided by the application, as name:interface")
+	f.Var(cmd.NewAppendStringsValue(&c.requires), "requires", "An endpoint required by the application, as name:interface")
+}
+
+// Init implements cmd.Command.
+func (c *importK8sWorkloadCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no workload specified")
+	}
+	parts := strings.SplitN(args[0], "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return errors.Errorf("invalid workload %q, expected <deployment|statefulset>/<name>", args[0])
+	}
+	switch strings.ToLower(parts[0]) {
+	case "deployment", "deployments", "deploy":
+		c.deploymentType = caas.DeploymentStateless
+	case "statefulset", "statefulsets", "sts":
+		c.deploymentType = caas.DeploymentStateful
+	default:
+		return errors.Errorf("cannot import %q workloads, expected a deployment or statefulset", parts[0])
+	}
+	c.name = parts[1]
+	if !names.IsValidApplication(c.name) {
+		return errors.Errorf("cannot import workload %q: not a valid application name", c.name)
+	}
+	for _, endpoint := range append(c.provides, c.requires...) {
+		if _, _, err := parseEndpoint(endpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// parseEndpoint splits an endpoint specified as name:interface.
+func parseEndpoint(endpoint string) (name, iface string, _ error) {
+	parts := strings.SplitN(endpoint, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Errorf("invalid endpoint %q, expected name:interface", endpoint)
+	}
+	return parts[0], parts[1], nil
+}
+
+type importK8sWorkloadAPI interface {
+	Close() error
+	AddLocalCharm(*charm.URL, charm.Charm, bool) (*charm.URL, error)
+	ImportK8sWorkload(applicationName string, deploymentType caas.DeploymentType, charmURL *charm.URL) error
+}
+
+// importK8sWorkloadAPIAdapter adds the local charm upload
+// of the client API to the application API.
+type importK8sWorkloadAPIAdapter struct {
+	*application.Client
+	charmAdder *api.Client
+}
+
+// AddLocalCharm is part of the importK8sWorkloadAPI interface.
+func (a *importK8sWorkloadAPIAdapter) AddLocalCharm(curl *charm.URL, ch charm.Charm, force bool) (*charm.URL, error) {
+	return a.charmAdder.AddLocalCharm(curl, ch, force)
+}
+
+// Run implements cmd.Command.
+func (c *importK8sWorkloadCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	dir, err := ioutil.TempDir("", "import-k8s-workload")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	ch, err := c.writeGenericCharm(dir)
+	if err != nil {
+		return errors.Annotate(err, "creating charm")
+	}
+	curl := &charm.URL{
+		Schema:   "local",
+		Name:     ch.Meta().Name,
+		Series:   "kubernetes",
+		Revision: ch.Revision(),
+	}
+	if curl, err = client.AddLocalCharm(curl, ch, false); err != nil {
+		return errors.Annotate(err, "adding charm")
+	}
+	if err := client.ImportK8sWorkload(c.name, c.deploymentType, curl); err != nil {
+		return block.ProcessBlockedError(errors.Annotatef(err, "could not import workload %q", c.name), block.BlockChange)
+	}
+	ctx.Infof("Imported %s as application %q with charm %q.", c.workloadKind(), c.name, curl.String())
+	return nil
+}
+
+func (c *importK8sWorkloadCommand) workloadKind() string {
+	if c.deploymentType == caas.DeploymentStateful {
+		return "stateful set"
+	}
+	return "deployment"
+}
+
+// genericCharmMeta holds the metadata of the generic charm
+// used to manage an imported workload.
+type genericCharmMeta struct {
+	Name           string                       `yaml:"name"`
+	Summary        string                       `yaml:"summary"`
+	Description    string                       `yaml:"description"`
+	Series         []string                     `yaml:"series"`
+	MinJujuVersion string                       `yaml:"min-juju-version"`
+	Provides       map[string]map[string]string `yaml:"provides,omitempty"`
+	Requires       map[string]map[string]string `yaml:"requires,omitempty"`
+}
+
+// genericDispatch is the dispatch script of the generic charm,
+// which handles every hook by doing nothing. The workload is
+// managed in the cluster, not by the charm.
+const genericDispatch = "#!/bin/sh\nexit 0\n"
+
+// writeGenericCharm writes the generic charm for the workload to
+// the specified directory, and returns it.
+func (c *importK8sWorkloadCommand) writeGenericCharm(dir string) (*charm.CharmDir, error) {
+	meta := genericCharmMeta{
+		Name:        c.name,
+		Summary:     "Workload imported from Kubernetes",
+		Description: "Manages the " + c.workloadKind() + " " + c.name + " imported from the cluster.",
+		Series:      []string{"kubernetes"},
+		// Charms for earlier versions require operator storage.
+		MinJujuVersion: "2.8.0",
+	}
+	endpoints := func(specs []string) map[string]map[string]string {
+		if len(specs) == 0 {
+			return nil
+		}
+		result := make(map[string]map[string]string)
+		for _, spec := range specs {
+			name, iface, _ := parseEndpoint(spec)
+			result[name] = map[string]string{"interface": iface}
+		}
+		return result
+	}
+	meta.Provides = endpoints(c.provides)
+	meta.Requires = endpoints(c.requires)
+	data, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), data, 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "dispatch"), []byte(genericDispatch), 0755); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := charm.ReadCharmDir(dir)
+	return ch, errors.Trace(err)
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ImportK8sWorkloadSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockImportK8sWorkloadAPI
+}
+
+var _ = gc.Suite(&ImportK8sWorkloadSuite{})
+
+type mockImportK8sWorkloadAPI struct {
+	*testing.Stub
+	charmMeta *charm.Meta
+}
+
+func (s *mockImportK8sWorkloadAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s *mockImportK8sWorkloadAPI) AddLocalCharm(curl *charm.URL, ch charm.Charm, force bool) (*charm.URL, error) {
+	s.MethodCall(s, "AddLocalCharm", curl, force)
+	s.charmMeta = ch.Meta()
+	added := *curl
+	added.Revision = 1
+	return &added, s.NextErr()
+}
+
+func (s *mockImportK8sWorkloadAPI) ImportK8sWorkload(applicationName string, deploymentType caas.DeploymentType, charmURL *charm.URL) error {
+	s.MethodCall(s, "ImportK8sWorkload", applicationName, deploymentType, charmURL)
+	return s.NextErr()
+}
+
+func (s *ImportK8sWorkloadSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockImportK8sWorkloadAPI{Stub: &testing.Stub{}}
+}
+
+func (s *ImportK8sWorkloadSuite) runImport(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewImportK8sWorkloadCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ImportK8sWorkloadSuite) TestImportDeployment(c *gc.C) {
+	ctx, err := s.runImport(c, "deployment/web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Imported deployment as application \"web\" with charm \"local:kubernetes/web-1\".\n")
+
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"AddLocalCharm", []interface{}{charm.MustParseURL("local:kubernetes/web-0"), false}},
+		{"ImportK8sWorkload", []interface{}{"web", caas.DeploymentStateless, charm.MustParseURL("local:kubernetes/web-1")}},
+		{"Close", nil},
+	})
+	meta := s.mockAPI.charmMeta
+	c.Assert(meta.Name, gc.Equals, "web")
+	c.Assert(meta.Series, jc.DeepEquals, []string{"kubernetes"})
+	c.Assert(meta.MinJujuVersion.String(), gc.Equals, "2.8.0")
+	c.Assert(meta.Provides, gc.HasLen, 0)
+	c.Assert(meta.Requires, gc.HasLen, 0)
+}
+
+func (s *ImportK8sWorkloadSuite) TestImportStatefulSetWithEndpoints(c *gc.C) {
+	_, err := s.runImport(c, "sts/db", "--provides", "db:pgsql", "--requires", "backup:s3", "--provides", "metrics:prometheus")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mockAPI.CheckCall(c, 1, "ImportK8sWorkload", "db", caas.DeploymentStateful, charm.MustParseURL("local:kubernetes/db-1"))
+	meta := s.mockAPI.charmMeta
+	c.Assert(meta.Provides, gc.HasLen, 2)
+	c.Assert(meta.Provides["db"].Interface, gc.Equals, "pgsql")
+	c.Assert(meta.Provides["metrics"].Interface, gc.Equals, "prometheus")
+	c.Assert(meta.Requires, gc.HasLen, 1)
+	c.Assert(meta.Requires["backup"].Interface, gc.Equals, "s3")
+}
+
+func (s *ImportK8sWorkloadSuite) TestImportFails(c *gc.C) {
+	s.mockAPI.SetErrors(nil, errors.New("boom"))
+	_, err := s.runImport(c, "deployment/web")
+	c.Assert(err, gc.ErrorMatches, `could not import workload "web": boom`)
+}
+
+func (s *ImportK8sWorkloadSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no workload specified",
+	}, {
+		args: []string{"web"},
+		err:  `invalid workload "web", expected <deployment\|statefulset>/<name>`,
+	}, {
+		args: []string{"daemonset/web"},
+		err:  `cannot import "daemonset" workloads, expected a deployment or statefulset`,
+	}, {
+		args: []string{"deployment/Web"},
+		err:  `cannot import workload "Web": not a valid application name`,
+	}, {
+		args: []string{"deployment/web", "--provides", "db"},
+		err:  `invalid endpoint "db", expected name:interface`,
+	}, {
+		args: []string{"deployment/web", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runImport(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
@@ -0,0 +1,111 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRestoreApplicationCommand returns a command which restores the
+// persistent storage of a k8s application from one of its backups.
+func NewRestoreApplicationCommand() modelcmd.ModelCommand {
+	cmd := &restoreApplicationCommand{}
+	cmd.newAPIFunc = func() (restoreApplicationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// restoreApplicationCommand is responsible for
+// restoring the storage of applications.
+type restoreApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (restoreApplicationAPI, error)
+	applicationName string
+	backupId        string
+}
+
+const restoreApplicationDoc = `
+Restore the persistent storage of an application from the volume
+snapshots of a backup made with backup-application. The most recent
+backup is restored if no backup id is specified.
+
+Each persistent volume claim in the backup is deleted, and created again
+for a new volume populated from its snapshot. The data written since the
+backup was made is lost.
+
+The application must be scaled to 0 before its storage is restored, and
+may be scaled up again once the restore is complete.
+
+Examples:
+
+    juju scale-application mariadb 0
+    juju restore-application mariadb 20200102-030405-x7k2
+    juju scale-application mariadb 3
+
+See also:
+    backup-application
+    scale-application
+`
+
+// Info implements cmd.Command.
+func (c *restoreApplicationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "restore-application",
+		Args:    "<application> [<backup id>]",
+		Purpose: "Restore the persistent storage of an application from a backup.",
+		Doc:     restoreApplicationDoc,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *restoreApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if len(args) > 1 {
+		c.backupId = args[1]
+		args = args[1:]
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+type restoreApplicationAPI interface {
+	Close() error
+	RestoreApplication(applicationName, backupId string) error
+}
+
+// Run implements cmd.Command.
+func (c *restoreApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.RestoreApplication(c.applicationName, c.backupId); err != nil {
+		return block.ProcessBlockedError(
+			errors.Annotatef(err, "could not restore application %q", c.applicationName), block.BlockChange,
+		)
+	}
+	ctx.Infof("Storage of application %q restored.", c.applicationName)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/application"
+)
+
+type RestoreApplicationSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockApplicationBackupAPI
+}
+
+var _ = gc.Suite(&RestoreApplicationSuite{})
+
+func (s *RestoreApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockApplicationBackupAPI{Stub: &testing.Stub{}}
+}
+
+func (s *RestoreApplicationSuite) runRestore(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, application.NewRestoreApplicationCommandForTest(s.mockAPI, caasStore()), args...)
+}
+
+func (s *RestoreApplicationSuite) TestRestore(c *gc.C) {
+	ctx, err := s.runRestore(c, "mariadb", "20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Storage of application \"mariadb\" restored.\n")
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RestoreApplication", []interface{}{"mariadb", "20200102-030405"}},
+		{"Close", nil},
+	})
+}
+
+func (s *RestoreApplicationSuite) TestRestoreMostRecent(c *gc.C) {
+	_, err := s.runRestore(c, "mariadb")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "RestoreApplication", "mariadb", "")
+}
+
+func (s *RestoreApplicationSuite) TestRestoreFails(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runRestore(c, "mariadb")
+	c.Assert(err, gc.ErrorMatches, `could not restore application "mariadb": boom`)
+}
+
+func (s *RestoreApplicationSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"MariaDB"},
+		err:  `invalid application name "MariaDB"`,
+	}, {
+		args: []string{"mariadb", "20200102-030405", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runRestore(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
@@ -4,17 +4,23 @@
 package application
 
 import (
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 )
 
 // NewScaleApplicationCommand returns a command which scales an application's units.
@@ -38,6 +44,14 @@ type scaleApplicationCommand struct {
 	newAPIFunc      func() (scaleApplicationAPI, error)
 	applicationName string
 	scale           int
+
+	autoscale     bool
+	noAutoscale   bool
+	minUnits      int
+	maxUnits      int
+	cpuTarget     int
+	memoryTarget  int
+	metricTargets map[string]string
 }
 
 const scaleApplicationDoc = `
@@ -45,21 +59,44 @@ Scale a k8s application by specifying how many units there should be.
 The new number of units can be greater or less than the current number, thus
 allowing both scale up and scale down.
 
+Alternatively, the application can be scaled automatically by the cluster
+between a minimum and maximum number of units, so that the average resource
+usage of its units stays close to the specified targets. CPU and memory
+targets are percentages of the resources requested by each unit; custom
+metric targets are average values per unit, and may be specified more than
+once. While an application is autoscaled, any number of units specified
+explicitly must be within the autoscaling range. Use --no-autoscale to stop
+autoscaling an application; it keeps its current number of units.
+
 Examples:
 
     juju scale-application mariadb 2
+    juju scale-application mariadb --max 10 --cpu 70
+    juju scale-application mariadb --min 2 --max 10 --memory 80 --metric requests-per-second=100
+    juju scale-application mariadb --no-autoscale
 `
 
 // Info implements cmd.Command.
 func (c *scaleApplicationCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "scale-application",
-		Args:    "<application> <scale>",
+		Args:    "<application> [<scale>]",
 		Purpose: "Set the desired number of application units.",
 		Doc:     scaleApplicationDoc,
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *scaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.minUnits, "min", 1, "Minimum number of units when autoscaling")
+	f.IntVar(&c.maxUnits, "max", 0, "Maximum number of units when autoscaling")
+	f.IntVar(&c.cpuTarget, "cpu", 0, "Target average CPU utilisation percentage when autoscaling")
+	f.IntVar(&c.memoryTarget, "memory", 0, "Target average memory utilisation percentage when autoscaling")
+	f.Var(cmd.StringMap{Mapping: &c.metricTargets}, "metric", "Target average value of a custom metric when autoscaling, as name=value")
+	f.BoolVar(&c.noAutoscale, "no-autoscale", false, "Stop autoscaling the application")
+}
+
 func (c *scaleApplicationCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.Errorf("no application specified")
@@ -68,6 +105,15 @@ func (c *scaleApplicationCommand) Init(args []string) error {
 	if !names.IsValidApplication(c.applicationName) {
 		return errors.Errorf("invalid application name %q", c.applicationName)
 	}
+	if err := c.initAutoscale(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.autoscale || c.noAutoscale {
+		if len(args) > 1 {
+			return errors.New("cannot specify both a scale and autoscaling options")
+		}
+		return nil
+	}
 	if len(args) == 1 {
 		return errors.Errorf("no scale specified")
 	}
@@ -82,10 +128,61 @@ func (c *scaleApplicationCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args[2:])
 }
 
+func (c *scaleApplicationCommand) initAutoscale() error {
+	c.autoscale = c.maxUnits != 0 || c.cpuTarget != 0 || c.memoryTarget != 0 || len(c.metricTargets) > 0
+	if c.noAutoscale {
+		if c.autoscale || c.minUnits != 1 {
+			return errors.New("cannot specify both --no-autoscale and autoscaling options")
+		}
+		return nil
+	}
+	if !c.autoscale {
+		if c.minUnits != 1 {
+			return errors.New("--min requires --max")
+		}
+		return nil
+	}
+	if c.maxUnits == 0 {
+		return errors.New("autoscaling requires --max")
+	}
+	policy := caas.AutoscalingPolicy{
+		MinUnits:            c.minUnits,
+		MaxUnits:            c.maxUnits,
+		CPUTargetPercent:    c.cpuTarget,
+		MemoryTargetPercent: c.memoryTarget,
+		MetricTargets:       c.metricTargets,
+	}
+	if err := policy.Validate(); err != nil {
+		return errors.Annotate(err, "invalid autoscaling policy")
+	}
+	return nil
+}
+
+// autoscaleConfig returns the application config which holds
+// the autoscaling policy specified on the command line. All of
+// the policy's settings are included so that any previous policy
+// is replaced in its entirety.
+func (c *scaleApplicationCommand) autoscaleConfig() map[string]string {
+	metrics := make([]string, 0, len(c.metricTargets))
+	for name, value := range c.metricTargets {
+		metrics = append(metrics, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(metrics)
+	return map[string]string{
+		caas.AutoscaleMinUnitsKey:      strconv.Itoa(c.minUnits),
+		caas.AutoscaleMaxUnitsKey:      strconv.Itoa(c.maxUnits),
+		caas.AutoscaleCPUTargetKey:     strconv.Itoa(c.cpuTarget),
+		caas.AutoscaleMemoryTargetKey:  strconv.Itoa(c.memoryTarget),
+		caas.AutoscaleMetricTargetsKey: strings.Join(metrics, " "),
+	}
+}
+
 type scaleApplicationAPI interface {
 	Close() error
 	BestAPIVersion() int
 	ScaleApplication(application.ScaleApplicationParams) (params.ScaleApplicationResult, error)
+	SetApplicationConfig(branchName, application string, config map[string]string) error
+	UnsetApplicationConfig(branchName, application string, options []string) error
 }
 
 // Run implements cmd.Command.
@@ -100,6 +197,28 @@ func (c *scaleApplicationCommand) Run(ctx *cmd.Context) error {
 		return errors.New("scaling applications is not supported by this controller")
 	}
 
+	if c.noAutoscale {
+		err := client.UnsetApplicationConfig(model.GenerationMaster, c.applicationName, []string{
+			caas.AutoscaleMinUnitsKey,
+			caas.AutoscaleMaxUnitsKey,
+			caas.AutoscaleCPUTargetKey,
+			caas.AutoscaleMemoryTargetKey,
+			caas.AutoscaleMetricTargetsKey,
+		})
+		if err != nil {
+			return block.ProcessBlockedError(errors.Annotatef(err, "could not stop autoscaling application %q", c.applicationName), block.BlockChange)
+		}
+		ctx.Infof("%v is no longer autoscaled", c.applicationName)
+		return nil
+	}
+	if c.autoscale {
+		if err := client.SetApplicationConfig(model.GenerationMaster, c.applicationName, c.autoscaleConfig()); err != nil {
+			return block.ProcessBlockedError(errors.Annotatef(err, "could not autoscale application %q", c.applicationName), block.BlockChange)
+		}
+		ctx.Infof("%v autoscaled between %d and %d units", c.applicationName, c.minUnits, c.maxUnits)
+		return nil
+	}
+
 	result, err := client.ScaleApplication(application.ScaleApplicationParams{
 		ApplicationName: c.applicationName,
 		Scale:           c.scale,
@@ -43,6 +43,16 @@ func (s mockScaleApplicationAPI) ScaleApplication(args application.ScaleApplicat
 	return params.ScaleApplicationResult{Info: &params.ScaleApplicationInfo{Scale: args.Scale}}, s.NextErr()
 }
 
+func (s mockScaleApplicationAPI) SetApplicationConfig(branchName, application string, config map[string]string) error {
+	s.MethodCall(s, "SetApplicationConfig", branchName, application, config)
+	return s.NextErr()
+}
+
+func (s mockScaleApplicationAPI) UnsetApplicationConfig(branchName, application string, options []string) error {
+	s.MethodCall(s, "UnsetApplicationConfig", branchName, application, options)
+	return s.NextErr()
+}
+
 func (s mockScaleApplicationAPI) BestAPIVersion() int {
 	return s.version
 }
@@ -102,3 +112,54 @@ func (s *ScaleApplicationSuite) TestOldServer(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "scaling applications is not supported by this controller")
 	s.mockAPI.CheckCall(c, 0, "Close")
 }
+
+func (s *ScaleApplicationSuite) TestAutoscaleApplication(c *gc.C) {
+	ctx, err := s.runScaleApplication(c, "foo", "--min", "2", "--max", "10", "--cpu", "70",
+		"--metric", "requests=100", "--metric", "latency=200m")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stderr := cmdtesting.Stderr(ctx)
+	out := strings.Replace(stderr, "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo autoscaled between 2 and 10 units`)
+	s.mockAPI.CheckCallNames(c, "SetApplicationConfig", "Close")
+	s.mockAPI.CheckCall(c, 0, "SetApplicationConfig", model.GenerationMaster, "foo", map[string]string{
+		"autoscale-min-units":      "2",
+		"autoscale-max-units":      "10",
+		"autoscale-cpu-target":     "70",
+		"autoscale-memory-target":  "0",
+		"autoscale-metric-targets": "latency=200m requests=100",
+	})
+}
+
+func (s *ScaleApplicationSuite) TestNoAutoscaleApplication(c *gc.C) {
+	ctx, err := s.runScaleApplication(c, "foo", "--no-autoscale")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stderr := cmdtesting.Stderr(ctx)
+	out := strings.Replace(stderr, "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo is no longer autoscaled`)
+	s.mockAPI.CheckCallNames(c, "UnsetApplicationConfig", "Close")
+	s.mockAPI.CheckCall(c, 0, "UnsetApplicationConfig", model.GenerationMaster, "foo", []string{
+		"autoscale-min-units",
+		"autoscale-max-units",
+		"autoscale-cpu-target",
+		"autoscale-memory-target",
+		"autoscale-metric-targets",
+	})
+}
+
+func (s *ScaleApplicationSuite) TestInvalidAutoscaleArgs(c *gc.C) {
+	_, err := s.runScaleApplication(c, "foo", "2", "--max", "3", "--cpu", "50")
+	c.Assert(err, gc.ErrorMatches, `cannot specify both a scale and autoscaling options`)
+	_, err = s.runScaleApplication(c, "foo", "--no-autoscale", "--max", "3")
+	c.Assert(err, gc.ErrorMatches, `cannot specify both --no-autoscale and autoscaling options`)
+	_, err = s.runScaleApplication(c, "foo", "--min", "2")
+	c.Assert(err, gc.ErrorMatches, `--min requires --max`)
+	_, err = s.runScaleApplication(c, "foo", "--cpu", "50")
+	c.Assert(err, gc.ErrorMatches, `autoscaling requires --max`)
+	_, err = s.runScaleApplication(c, "foo", "--max", "3")
+	c.Assert(err, gc.ErrorMatches, `invalid autoscaling policy: autoscaling policy requires a CPU, memory or metric target`)
+	_, err = s.runScaleApplication(c, "foo", "--min", "4", "--max", "3", "--memory", "50")
+	c.Assert(err, gc.ErrorMatches, `invalid autoscaling policy: maximum units 3 less than minimum units 4`)
+	s.mockAPI.CheckNoCalls(c)
+}
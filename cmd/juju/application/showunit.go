@@ -5,6 +5,7 @@ package application
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -23,12 +24,17 @@ The command takes deployed unit names as an argument.
 Optionally, relation data for only a specified endpoint
 or related unit may be shown, or just the application data. 
 
+The --hooks option shows the most recent hooks, actions and
+commands run by the unit, with how long each took to run, its
+exit code and the change which triggered it.
+
 Examples:
     juju show-unit mysql/0
     juju show-unit mysql/0 wordpress/1
     juju show-unit mysql/0 --app
     juju show-unit mysql/0 --endpoint db
     juju show-unit mysql/0 --related-unit wordpress/2
+    juju show-unit mysql/0 --hooks
 `
 
 // NewShowUnitCommand returns a command that displays unit info.
@@ -48,6 +54,7 @@ type showUnitCommand struct {
 	endpoint    string
 	relatedUnit string
 	appOnly     bool
+	hooks       bool
 
 	newAPIFunc func() (UnitsInfoAPI, error)
 }
@@ -95,6 +102,7 @@ func (c *showUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.endpoint, "endpoint", "", "only show relation data for the specified endpoint")
 	f.StringVar(&c.relatedUnit, "related-unit", "", "only show relation data for the specified unit")
 	f.BoolVar(&c.appOnly, "app", false, "only show application relation data")
+	f.BoolVar(&c.hooks, "hooks", false, "show the unit's recent hook executions")
 }
 
 // UnitsInfoAPI defines the API methods that show-unit command uses.
@@ -193,15 +201,30 @@ type RelationData struct {
 	Data                    map[string]UnitRelationData `yaml:"related-units,omitempty" json:"related-units,omitempty"`
 }
 
+// HookExecution defines the serialization behaviour of a hook, action
+// or command run by a unit.
+type HookExecution struct {
+	Kind     string    `yaml:"kind" json:"kind"`
+	Name     string    `yaml:"name" json:"name"`
+	Trigger  string    `yaml:"trigger,omitempty" json:"trigger,omitempty"`
+	Started  time.Time `yaml:"started" json:"started"`
+	Finished time.Time `yaml:"finished" json:"finished"`
+	Duration string    `yaml:"duration" json:"duration"`
+	ExitCode int       `yaml:"exit-code" json:"exit-code"`
+	Error    string    `yaml:"error,omitempty" json:"error,omitempty"`
+	Skipped  bool      `yaml:"skipped,omitempty" json:"skipped,omitempty"`
+}
+
 // ApplicationInfo defines the serialization behaviour of the application information.
 type UnitInfo struct {
-	WorkloadVersion string         `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
-	Machine         string         `yaml:"machine,omitempty" json:"machine,omitempty"`
-	OpenedPorts     []string       `yaml:"opened-ports" json:"opened-ports"`
-	PublicAddress   string         `yaml:"public-address,omitempty" json:"public-address,omitempty"`
-	Charm           string         `yaml:"charm" json:"charm"`
-	Leader          bool           `yaml:"leader" json:"leader"`
-	RelationData    []RelationData `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	WorkloadVersion string          `yaml:"workload-version,omitempty" json:"workload-version,omitempty"`
+	Machine         string          `yaml:"machine,omitempty" json:"machine,omitempty"`
+	OpenedPorts     []string        `yaml:"opened-ports" json:"opened-ports"`
+	PublicAddress   string          `yaml:"public-address,omitempty" json:"public-address,omitempty"`
+	Charm           string          `yaml:"charm" json:"charm"`
+	Leader          bool            `yaml:"leader" json:"leader"`
+	RelationData    []RelationData  `yaml:"relation-info,omitempty" json:"relation-info,omitempty"`
+	Hooks           []HookExecution `yaml:"hooks,omitempty" json:"hooks,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `yaml:"provider-id,omitempty" json:"provider-id,omitempty"`
@@ -224,6 +247,21 @@ func (c *showUnitCommand) createUnitInfo(details application.UnitInfo) (names.Un
 		ProviderId:      details.ProviderId,
 		Address:         details.Address,
 	}
+	if c.hooks {
+		for _, e := range details.HookHistory {
+			info.Hooks = append(info.Hooks, HookExecution{
+				Kind:     e.Kind,
+				Name:     e.Name,
+				Trigger:  e.Trigger,
+				Started:  e.Started,
+				Finished: e.Finished,
+				Duration: e.Finished.Sub(e.Started).String(),
+				ExitCode: e.ExitCode,
+				Error:    e.Error,
+				Skipped:  e.Skipped,
+			})
+		}
+	}
 	for _, rdparams := range details.RelationData {
 		if c.endpoint != "" && rdparams.Endpoint != c.endpoint {
 			continue
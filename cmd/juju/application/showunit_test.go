@@ -6,6 +6,7 @@ package application_test
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -159,6 +160,28 @@ func (s *ShowUnitSuite) createTestUnitInfo(app string, otherEndpoint string) api
 				},
 			},
 		}},
+		HookHistory: []apiapplication.HookExecution{{
+			Kind:     "hook",
+			Name:     "db-relation-changed",
+			Trigger:  "unit mariadb/2 changed in relation 1",
+			Started:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			Finished: time.Date(2020, 6, 1, 12, 0, 3, 0, time.UTC),
+		}, {
+			Kind:     "action",
+			Name:     "backup",
+			Trigger:  "action 2 queued",
+			Started:  time.Date(2020, 6, 1, 12, 1, 0, 0, time.UTC),
+			Finished: time.Date(2020, 6, 1, 12, 2, 30, 0, time.UTC),
+			ExitCode: 1,
+			Error:    "exit status 1",
+		}, {
+			Kind:     "hook",
+			Name:     "leader-elected",
+			Trigger:  "unit became leader",
+			Started:  time.Date(2020, 6, 1, 12, 3, 0, 0, time.UTC),
+			Finished: time.Date(2020, 6, 1, 12, 3, 0, 0, time.UTC),
+			Skipped:  true,
+		}},
 		ProviderId: "provider-id",
 		Address:    "192.168.1.1",
 	}
@@ -238,6 +261,59 @@ wordpress/0:
 	})
 }
 
+func (s *ShowUnitSuite) TestShowHooks(c *gc.C) {
+	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
+		return []apiapplication.UnitInfo{
+			s.createTestUnitInfo("wordpress", ""),
+		}, nil
+	}
+	s.assertRunShow(c, showUnitTest{
+		args: []string{"wordpress/0", "--app", "--hooks"},
+		stdout: `
+wordpress/0:
+  workload-version: "666"
+  machine: "0"
+  opened-ports:
+  - 100-102/ip
+  public-address: 10.0.0.1
+  charm: charm-wordpress
+  leader: true
+  relation-info:
+  - endpoint: db
+    cross-model: true
+    related-endpoint: server
+    application-data:
+      wordpress: setting
+  hooks:
+  - kind: hook
+    name: db-relation-changed
+    trigger: unit mariadb/2 changed in relation 1
+    started: 2020-06-01T12:00:00Z
+    finished: 2020-06-01T12:00:03Z
+    duration: 3s
+    exit-code: 0
+  - kind: action
+    name: backup
+    trigger: action 2 queued
+    started: 2020-06-01T12:01:00Z
+    finished: 2020-06-01T12:02:30Z
+    duration: 1m30s
+    exit-code: 1
+    error: exit status 1
+  - kind: hook
+    name: leader-elected
+    trigger: unit became leader
+    started: 2020-06-01T12:03:00Z
+    finished: 2020-06-01T12:03:00Z
+    duration: 0s
+    exit-code: 0
+    skipped: true
+  provider-id: provider-id
+  address: 192.168.1.1
+`[1:],
+	})
+}
+
 func (s *ShowUnitSuite) TestShowEndpoint(c *gc.C) {
 	s.mockAPI.unitsInfoFunc = func([]names.UnitTag) ([]apiapplication.UnitInfo, error) {
 		return []apiapplication.UnitInfo{
@@ -0,0 +1,214 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/names/v4"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/paths"
+	"github.com/juju/juju/network/ssh"
+	"github.com/juju/juju/worker/uniter/runner/capture"
+)
+
+func newDebugCaptureCommand(hostChecker ssh.ReachableChecker) cmd.Command {
+	c := new(debugCaptureCommand)
+	c.getActionAPI = c.debugHooksCommand.newActionsAPI
+	c.setHostChecker(hostChecker)
+	return modelcmd.Wrap(c)
+}
+
+// debugCaptureCommand connects via SSH to a unit to request that its hooks
+// are captured, and to list and download the captures made.
+type debugCaptureCommand struct {
+	debugHooksCommand
+	enable  bool
+	disable bool
+	capture string
+	out     string
+}
+
+const debugCaptureDoc = `
+Capture the hook tool calls made by a unit's hooks, along with the
+environment and charm revision each hook ran with, so that a failing
+hook can be replayed away from the unit with "jujud replay-hook".
+
+With --enable, the named hooks, or all hooks if none are named, are
+captured each time they run until capture is stopped with --disable.
+The most recent captures of each unit are kept on the unit's machine.
+
+Without --enable or --disable, the unit's captures are listed, oldest
+first, or the named capture is written to standard output or to the
+file given with --output.
+
+Captures hold the config, relation data and leadership settings the
+hook read, so should be handled as carefully as the unit itself.
+
+See the "juju help ssh" for information about SSH related options
+accepted by the debug-capture command.
+
+Examples:
+
+Capture the config-changed hook of mysql/0 each time it runs:
+
+    juju debug-capture mysql/0 --enable config-changed
+
+List the captures made:
+
+    juju debug-capture mysql/0
+
+Download a capture, and stop capturing:
+
+    juju debug-capture mysql/0 config-changed-20200601T120000.000000000Z -o capture.json
+    juju debug-capture mysql/0 --disable
+
+See also:
+    debug-hooks
+`
+
+func (c *debugCaptureCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "debug-capture",
+		Args:    "<unit name> [--enable [hook names] | --disable | capture name]",
+		Purpose: "Capture the hooks run by a unit, for replay.",
+		Doc:     debugCaptureDoc,
+	})
+}
+
+func (c *debugCaptureCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.debugHooksCommand.SetFlags(f)
+	f.BoolVar(&c.enable, "enable", false, "Capture the named hooks, or all hooks, each time they run")
+	f.BoolVar(&c.disable, "disable", false, "Stop capturing hooks")
+	f.StringVar(&c.out, "o", "", "Write the capture to the named file")
+	f.StringVar(&c.out, "output", "", "")
+}
+
+// IncompatibleModel is part of the modelcmd.modelSpecificCommand
+// interface. Captures are always made over SSH, so Kubernetes models
+// are not supported, whatever the flags.
+func (c *debugCaptureCommand) IncompatibleModel(err error) error {
+	return err
+}
+
+// AllowInterspersedFlags is true for debug-capture, as any arguments
+// after the unit name are hooks or captures rather than ssh options.
+func (c *debugCaptureCommand) AllowInterspersedFlags() bool {
+	return true
+}
+
+func (c *debugCaptureCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.Errorf("no unit name specified")
+	}
+	c.Target, args = args[0], args[1:]
+	if !names.IsValidUnit(c.Target) {
+		return errors.Errorf("%q is not a valid unit name", c.Target)
+	}
+	switch {
+	case c.enable && c.disable:
+		return errors.New("cannot specify both --enable and --disable")
+	case c.enable:
+		// If any of the hooks is "*", then capture all hooks.
+		c.hooks = append([]string{}, args...)
+		for _, h := range c.hooks {
+			if h == "*" {
+				c.hooks = nil
+				break
+			}
+		}
+	case c.disable:
+		if err := cmd.CheckEmpty(args); err != nil {
+			return errors.Trace(err)
+		}
+	case len(args) > 0:
+		c.capture, args = args[0], args[1:]
+		if err := cmd.CheckEmpty(args); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if c.out != "" && c.capture == "" {
+		return errors.New("--output is only valid when downloading a capture")
+	}
+	return nil
+}
+
+// validateHooks returns an error if any of the hooks
+// to be captured is not a hook of the unit's charm.
+func (c *debugCaptureCommand) validateHooks() error {
+	if len(c.hooks) == 0 {
+		return nil
+	}
+	appName, err := names.UnitApplication(c.Target)
+	if err != nil {
+		return err
+	}
+	validHooks, err := c.getValidHooks(appName)
+	if err != nil {
+		return err
+	}
+	for _, hook := range c.hooks {
+		if !validHooks.Contains(hook) {
+			return errors.Errorf("unit %q has no hook %q, valid hooks are %v",
+				c.Target, hook, validHooks.SortedValues())
+		}
+	}
+	return nil
+}
+
+// script returns the bash script to run on the unit's machine.
+func (c *debugCaptureCommand) script() (string, error) {
+	dir := capture.UnitDir(paths.NixDataDir, names.NewUnitTag(c.Target))
+	switch {
+	case c.enable:
+		return capture.EnableScript(dir, c.hooks), nil
+	case c.disable:
+		return capture.DisableScript(dir), nil
+	case c.capture != "":
+		return capture.FetchScript(dir, c.capture)
+	}
+	return capture.ListScript(dir), nil
+}
+
+// Run ensures c.Target is a unit, and resolves its address,
+// and connects to it via SSH to manage the unit's captures.
+func (c *debugCaptureCommand) Run(ctx *cmd.Context) error {
+	err := c.initRun()
+	if err != nil {
+		return err
+	}
+	defer c.cleanupRun()
+	if err := c.validateHooks(); err != nil {
+		return err
+	}
+	script, err := c.script()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.Args = []string{fmt.Sprintf("sudo /bin/bash -c '%s'", script)}
+	// A pty would mangle a downloaded capture.
+	pty := false
+	c.pty.b = &pty
+
+	if c.out == "" {
+		return c.sshCommand.Run(ctx)
+	}
+	f, err := os.OpenFile(ctx.AbsPath(c.out), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = f.Close() }()
+	outCtx := *ctx
+	outCtx.Stdout = f
+	if err := c.sshCommand.Run(&outCtx); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DebugCaptureSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&DebugCaptureSuite{})
+
+const unitCaptureDir = "/var/lib/juju/agents/unit-mysql-0/captures"
+
+func (s *DebugCaptureSuite) initCommand(c *gc.C, args ...string) (*debugCaptureCommand, error) {
+	command := &debugCaptureCommand{}
+	return command, cmdtesting.InitCommand(command, args)
+}
+
+func (s *DebugCaptureSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{
+		{nil, "no unit name specified"},
+		{[]string{"mysql"}, `"mysql" is not a valid unit name`},
+		{[]string{"mysql/0", "--enable", "--disable"}, "cannot specify both --enable and --disable"},
+		{[]string{"mysql/0", "--disable", "install"}, `unrecognized args: \["install"\]`},
+		{[]string{"mysql/0", "one", "two"}, `unrecognized args: \["two"\]`},
+		{[]string{"mysql/0", "-o", "out.json"}, "--output is only valid when downloading a capture"},
+	} {
+		_, err := s.initCommand(c, t.args...)
+		c.Check(err, gc.ErrorMatches, t.err, gc.Commentf("args %v", t.args))
+	}
+}
+
+func (s *DebugCaptureSuite) TestEnable(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0", "--enable", "install", "start")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.hooks, jc.DeepEquals, []string{"install", "start"})
+	script, err := command.script()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Matches, "mkdir -p -m 0700 "+unitCaptureDir+" && echo \\S+ \\| base64 -d > "+unitCaptureDir+"/request")
+}
+
+func (s *DebugCaptureSuite) TestEnableAll(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0", "--enable", "install", "*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.hooks, gc.HasLen, 0)
+	c.Assert(command.validateHooks(), jc.ErrorIsNil)
+}
+
+func (s *DebugCaptureSuite) TestDisable(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0", "--disable")
+	c.Assert(err, jc.ErrorIsNil)
+	script, err := command.script()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "rm -f "+unitCaptureDir+"/request")
+}
+
+func (s *DebugCaptureSuite) TestList(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	script, err := command.script()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "cd "+unitCaptureDir+" 2>/dev/null && ls -1rt -- *.json 2>/dev/null; true")
+}
+
+func (s *DebugCaptureSuite) TestFetch(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0", "install-20200601T120000.000000000Z", "-o", "out.json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.out, gc.Equals, "out.json")
+	script, err := command.script()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "cat '"+unitCaptureDir+"/install-20200601T120000.000000000Z.json'")
+}
+
+func (s *DebugCaptureSuite) TestFetchInvalidName(c *gc.C) {
+	command, err := s.initCommand(c, "mysql/0", "../../unit-mysql-0/agent.conf")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = command.script()
+	c.Assert(err, gc.ErrorMatches, `capture name ".*" not valid`)
+}
@@ -70,7 +70,7 @@ func (c *debugCodeCommand) Init(args []string) error {
 	return nil
 }
 func (c *debugCodeCommand) SetFlags(f *gnuflag.FlagSet) {
-	c.debugHooksCommand.SetFlags(f)
+	c.sshCommand.SetFlags(f)
 	f.StringVar(&c.debugAt, "at", "all",
 		"interpreted by the charm for where you want to stop, defaults to 'all'")
 }
@@ -6,19 +6,26 @@ package commands
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/juju/charm/v7/hooks"
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/network/ssh"
+	"github.com/juju/juju/pubsub/debughooks"
 	unitdebug "github.com/juju/juju/worker/uniter/runner/debug"
 )
 
@@ -32,7 +39,8 @@ func newDebugHooksCommand(hostChecker ssh.ReachableChecker) cmd.Command {
 // debugHooksCommand is responsible for launching a ssh shell on a given unit or machine.
 type debugHooksCommand struct {
 	sshCommand
-	hooks []string
+	hooks  []string
+	noTmux bool
 
 	getActionAPI func() (ActionsAPI, error)
 }
@@ -42,8 +50,34 @@ Interactively debug hooks or actions remotely on an application unit.
 
 See the "juju help ssh" for information about SSH related options
 accepted by the debug-hooks command.
+
+With --no-tmux, SSH and tmux are not used. Instead the unit agent pauses
+before each matching hook or action and attaches it to the session over
+the API, and a shell with the hook's environment is run in place of the
+hook; its input and output are relayed to this terminal. Exit the shell
+to let the unit continue; its exit status is the result of the hook.
+This works for units without SSH access, such as those of Kubernetes
+applications, and for non-interactive use, in which case the command
+exits with the status of the first hook to finish once its input ends.
+The actions of Kubernetes units run in the workload pod, and cannot be
+debugged this way.
+
+Examples:
+
+Debug the install hook of a unit without tmux:
+
+    juju debug-hooks --no-tmux mysql/0 install
+
+Run a script in place of the next start hook:
+
+    juju debug-hooks --no-tmux mysql/0 start < script.sh
 `
 
+func (c *debugHooksCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.sshCommand.SetFlags(f)
+	f.BoolVar(&c.noTmux, "no-tmux", false, "Attach to the unit's hooks over the API, without SSH or tmux")
+}
+
 func (c *debugHooksCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "debug-hooks",
@@ -177,6 +211,16 @@ func (c *debugHooksCommand) getValidHooks(appName string) (set.Strings, error) {
 	return validHooks, nil
 }
 
+// IncompatibleModel is part of the modelcmd.modelSpecificCommand
+// interface. With --no-tmux, no SSH access is needed, so the hooks of
+// Kubernetes units may be debugged.
+func (c *debugHooksCommand) IncompatibleModel(err error) error {
+	if c.noTmux {
+		return nil
+	}
+	return err
+}
+
 // commonRun is shared between debugHooks and debugCode
 func (c *debugHooksCommand) commonRun(
 	ctx *cmd.Context,
@@ -207,5 +251,189 @@ func (c *debugHooksCommand) commonRun(
 // and connects to it via SSH to execute the debug-hooks
 // script.
 func (c *debugHooksCommand) Run(ctx *cmd.Context) error {
+	if c.noTmux {
+		return c.runAttached(ctx)
+	}
 	return c.commonRun(ctx, c.Target, c.hooks, "")
 }
+
+// runAttached starts a debug-hooks session over the API, and relays
+// the shells of the unit's hooks to and from the terminal.
+func (c *debugHooksCommand) runAttached(ctx *cmd.Context) error {
+	if err := c.checkAttachableActions(ctx); err != nil {
+		return err
+	}
+	if err := c.validateHooksOrActions(); err != nil {
+		return err
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := "/units/" + names.NewUnitTag(c.Target).String() + "/debug-hooks"
+	conn, err := stream.Open(root, path, params.DebugHooksClientConfig{Hooks: c.hooks})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = conn.Close() }()
+	ctx.Infof("Waiting for a hook or action of %s to debug; interrupt to stop.", c.Target)
+	return attachDebugHooks(ctx, conn)
+}
+
+// checkAttachableActions rejects the actions of Kubernetes units, which
+// run in the workload pod where there is no shell to attach to.
+func (c *debugHooksCommand) checkAttachableActions(ctx *cmd.Context) error {
+	modelType, err := c.ModelType()
+	if err != nil {
+		return errors.Annotatef(err, "unable to get model type")
+	}
+	if modelType != model.CAAS {
+		return nil
+	}
+	if len(c.hooks) == 0 {
+		ctx.Infof("Actions of %s run in the workload pod and will not be debugged.", c.Target)
+		return nil
+	}
+	appName, err := names.UnitApplication(c.Target)
+	if err != nil {
+		return err
+	}
+	validActions, err := c.getValidActions(appName)
+	if err != nil {
+		return err
+	}
+	for _, hook := range c.hooks {
+		if validActions.Contains(hook) {
+			return errors.Errorf("cannot debug action %q of %s with --no-tmux: actions of Kubernetes units run in the workload pod", hook, c.Target)
+		}
+	}
+	return nil
+}
+
+// attachDebugHooks relays the terminal to the shells of the hooks
+// attached to the debug-hooks session on conn, until the session is
+// interrupted, or a hook finishes after input has ended. Input
+// received while no hook is attached is held for the next one.
+func attachDebugHooks(ctx *cmd.Context, conn base.Stream) error {
+	// done stops the goroutines below from blocking
+	// once nothing is receiving from them.
+	done := make(chan struct{})
+	defer close(done)
+
+	input := make(chan []byte)
+	go func() {
+		defer close(input)
+		for {
+			buf := make([]byte, 4096)
+			n, err := ctx.Stdin.Read(buf)
+			if n > 0 {
+				select {
+				case input <- buf[:n]:
+				case <-done:
+					return
+				}
+			}
+			if err != nil {
+				// Whatever the error, there is no more input.
+				return
+			}
+		}
+	}()
+	messages := make(chan params.DebugHooksMessage)
+	readErr := make(chan error, 1)
+	go func() {
+		for {
+			var m params.DebugHooksMessage
+			if err := conn.ReadJSON(&m); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case messages <- m:
+			case <-done:
+				return
+			}
+		}
+	}()
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	var (
+		attached  bool
+		inputDone bool
+		pending   [][]byte
+	)
+	send := func(m params.DebugHooksMessage) error {
+		return errors.Annotate(conn.WriteJSON(m), "sending to debug-hooks session")
+	}
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case data, ok := <-input:
+			if !ok {
+				input = nil
+				inputDone = true
+				if !attached {
+					// The next hook gets the end of input.
+					continue
+				}
+				if err := send(params.DebugHooksMessage{Type: debughooks.EOF}); err != nil {
+					return err
+				}
+				continue
+			}
+			if !attached {
+				pending = append(pending, data)
+				continue
+			}
+			if err := send(params.DebugHooksMessage{Type: debughooks.Input, Data: data}); err != nil {
+				return err
+			}
+		case m := <-messages:
+			switch m.Type {
+			case debughooks.Attached:
+				attached = true
+				ctx.Infof("Debugging the %s hook of the unit.", m.Hook)
+				for _, data := range pending {
+					if err := send(params.DebugHooksMessage{Type: debughooks.Input, Data: data}); err != nil {
+						return err
+					}
+				}
+				pending = nil
+				if inputDone {
+					if err := send(params.DebugHooksMessage{Type: debughooks.EOF}); err != nil {
+						return err
+					}
+				}
+			case debughooks.Output:
+				if _, err := ctx.Stdout.Write(m.Data); err != nil {
+					return errors.Trace(err)
+				}
+			case debughooks.Finished:
+				attached = false
+				if m.Error != "" {
+					ctx.Infof("The %s hook did not finish: %s", m.Hook, m.Error)
+				} else {
+					ctx.Infof("The %s hook finished with exit status %d.", m.Hook, m.Code)
+				}
+				if !inputDone {
+					continue
+				}
+				if m.Error != "" {
+					return errors.New(m.Error)
+				}
+				if m.Code != 0 {
+					return cmd.NewRcPassthroughError(m.Code)
+				}
+				return nil
+			}
+		case err := <-readErr:
+			if err == io.EOF {
+				return errors.New("debug-hooks session closed")
+			}
+			return errors.Annotate(err, "debug-hooks session closed")
+		}
+	}
+}
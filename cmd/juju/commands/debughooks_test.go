@@ -5,16 +5,26 @@ package commands
 
 import (
 	"encoding/base64"
+	"io"
 	"regexp"
 	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	goyaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
 	jujussh "github.com/juju/juju/network/ssh"
+	"github.com/juju/juju/pubsub/debughooks"
+	coretesting "github.com/juju/juju/testing"
 )
 
 var _ = gc.Suite(&DebugHooksSuite{})
@@ -190,3 +200,158 @@ func (s *DebugHooksSuite) TestDebugHooksArgFormatting(c *gc.C) {
 		"hooks": []interface{}{"install", "start"},
 	})
 }
+
+type DebugHooksAttachSuite struct {
+	coretesting.BaseSuite
+	stream *fakeDebugHooksStream
+}
+
+var _ = gc.Suite(&DebugHooksAttachSuite{})
+
+func (s *DebugHooksAttachSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stream = &fakeDebugHooksStream{in: make(chan params.DebugHooksMessage, 10)}
+}
+
+func (s *DebugHooksAttachSuite) TestFlagInit(c *gc.C) {
+	command := &debugHooksCommand{}
+	err := cmdtesting.InitCommand(command, []string{"--no-tmux", "mysql/0", "install"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(command.noTmux, jc.IsTrue)
+	c.Assert(command.hooks, jc.DeepEquals, []string{"install"})
+}
+
+func (s *DebugHooksAttachSuite) newAttachCommand(modelType model.ModelType) cmd.Command {
+	command := &debugHooksCommand{}
+	command.getActionAPI = func() (ActionsAPI, error) {
+		return fakeDebugHooksActionsAPI{"backup": {}}, nil
+	}
+	command.SetClientStore(minimalStore(modelType))
+	return modelcmd.Wrap(command)
+}
+
+func (s *DebugHooksAttachSuite) TestRejectsWorkloadActions(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newAttachCommand(model.CAAS), "--no-tmux", "mysql/0", "install", "backup")
+	c.Assert(err, gc.ErrorMatches, `cannot debug action "backup" of mysql/0 with --no-tmux: actions of Kubernetes units run in the workload pod`)
+}
+
+func (s *DebugHooksAttachSuite) TestKubernetesNeedsNoTmux(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newAttachCommand(model.CAAS), "mysql/0", "install")
+	c.Assert(err, gc.ErrorMatches, `Juju command "debug-hooks" not supported on kubernetes models`)
+}
+
+func (s *DebugHooksAttachSuite) TestChecksActionsOnlyForKubernetes(c *gc.C) {
+	command := &debugHooksCommand{}
+	command.getActionAPI = func() (ActionsAPI, error) {
+		c.Fatalf("unexpected actions API")
+		return nil, nil
+	}
+	command.SetClientStore(minimalStore(model.IAAS))
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--no-tmux", "mysql/0", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.checkAttachableActions(cmdtesting.Context(c))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DebugHooksAttachSuite) TestAttach(c *gc.C) {
+	s.stream.send(params.DebugHooksMessage{Type: debughooks.Attached, Hook: "install"})
+	s.stream.onWrite = func(m params.DebugHooksMessage) {
+		if m.Type == debughooks.EOF {
+			s.stream.send(params.DebugHooksMessage{Type: debughooks.Output, Data: []byte("bye\n")})
+			s.stream.send(params.DebugHooksMessage{Type: debughooks.Finished, Hook: "install", Code: 3})
+		}
+	}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("echo bye; exit 3\n")
+
+	err := attachDebugHooks(ctx, s.stream)
+	c.Assert(err, gc.FitsTypeOf, cmd.NewRcPassthroughError(3))
+	c.Assert(err.(*cmd.RcPassthroughError).Code, gc.Equals, 3)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "bye\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "Debugging the install hook of the unit.")
+	c.Assert(s.stream.written(), jc.DeepEquals, []params.DebugHooksMessage{
+		{Type: debughooks.Input, Data: []byte("echo bye; exit 3\n")},
+		{Type: debughooks.EOF},
+	})
+}
+
+func (s *DebugHooksAttachSuite) TestAttachAgentDisconnected(c *gc.C) {
+	s.stream.send(params.DebugHooksMessage{Type: debughooks.Attached, Hook: "install"})
+	s.stream.onWrite = func(m params.DebugHooksMessage) {
+		if m.Type == debughooks.EOF {
+			s.stream.send(params.DebugHooksMessage{
+				Type:  debughooks.Finished,
+				Hook:  "install",
+				Error: "unit agent disconnected",
+			})
+		}
+	}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("")
+
+	err := attachDebugHooks(ctx, s.stream)
+	c.Assert(err, gc.ErrorMatches, "unit agent disconnected")
+}
+
+func (s *DebugHooksAttachSuite) TestSessionClosed(c *gc.C) {
+	s.stream.readErr = errors.New("boom")
+	close(s.stream.in)
+	ctx := cmdtesting.Context(c)
+	// Input never ends, as on a terminal.
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	ctx.Stdin = reader
+
+	err := attachDebugHooks(ctx, s.stream)
+	c.Assert(err, gc.ErrorMatches, "debug-hooks session closed: boom")
+}
+
+// fakeDebugHooksActionsAPI is an ActionsAPI which returns the
+// actions of the charm.
+type fakeDebugHooksActionsAPI map[string]params.ActionSpec
+
+func (a fakeDebugHooksActionsAPI) ApplicationCharmActions(params.Entity) (map[string]params.ActionSpec, error) {
+	return a, nil
+}
+
+// fakeDebugHooksStream is a base.Stream which gives the messages sent
+// to it to the reader, and records the messages written to it.
+type fakeDebugHooksStream struct {
+	base.Stream
+	in      chan params.DebugHooksMessage
+	readErr error
+	onWrite func(params.DebugHooksMessage)
+
+	mu  sync.Mutex
+	out []params.DebugHooksMessage
+}
+
+func (s *fakeDebugHooksStream) send(m params.DebugHooksMessage) {
+	s.in <- m
+}
+
+func (s *fakeDebugHooksStream) written() []params.DebugHooksMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out
+}
+
+func (s *fakeDebugHooksStream) ReadJSON(v interface{}) error {
+	m, ok := <-s.in
+	if !ok {
+		return s.readErr
+	}
+	*(v.(*params.DebugHooksMessage)) = m
+	return nil
+}
+
+func (s *fakeDebugHooksStream) WriteJSON(v interface{}) error {
+	m := v.(params.DebugHooksMessage)
+	s.mu.Lock()
+	s.out = append(s.out, m)
+	s.mu.Unlock()
+	if s.onWrite != nil {
+		s.onWrite(m)
+	}
+	return nil
+}
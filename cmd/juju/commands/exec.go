@@ -56,6 +56,7 @@ type execCommand struct {
 	compat       bool
 	all          bool
 	operator     bool
+	stream       bool
 	timeout      time.Duration
 	machines     []string
 	applications []string
@@ -98,6 +99,10 @@ instead of the workload. On IAAS models, --operator has no effect.
 Commands run for applications or units are executed in a 'hook context' for
 the unit.
 
+If --stream is provided, the output of the commands is written as it is
+produced, rather than once they have finished. When running on more than
+one target, each line of output is prefixed with the name of the target.
+
 --all is provided as a simple way to run the command on all the machines
 in the model.  If you specify --all you cannot provide additional
 targets.
@@ -137,6 +142,7 @@ func (c *execCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 	f.BoolVar(&c.all, "all", false, "Run the commands on all the machines")
 	f.BoolVar(&c.operator, "operator", false, "Run the commands on the operator (k8s-only)")
+	f.BoolVar(&c.stream, "stream", false, "Write the output of the commands as it is produced")
 	f.DurationVar(&c.timeout, "timeout", 5*time.Minute, "How long to wait before the remote command is considered to have failed")
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.applications), "a", "One or more application names")
@@ -342,6 +348,21 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 		return errors.New("no actions were successfully enqueued, aborting")
 	}
 
+	var streamer *action.OutputStreamer
+	if c.stream {
+		if client.BestAPIVersion() < 7 {
+			return errors.New("--stream is not supported by this controller\nupgrade your controller to use it")
+		}
+		streamer = action.NewOutputStreamer(ctx.Stdout, ctx.Stderr, len(actionsToQuery) > 1)
+		defer streamer.Stop()
+		for _, actionToQuery := range actionsToQuery {
+			receiver := names.ReadableString(actionToQuery.receiver.tag)
+			if err := streamer.Follow(client, actionToQuery.actionTag.Id(), receiver); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	timeout := c.timeAfter(c.timeout)
 	values := []interface{}{}
 	for len(actionsToQuery) > 0 {
@@ -360,6 +381,9 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
+			if streamer != nil {
+				streamer.Finish(actionsToQuery[i].actionTag.Id(), result)
+			}
 			values = append(values, ConvertActionResults(result, actionsToQuery[i], c.compat))
 		}
 		actionsToQuery = newActionsToQuery
@@ -403,8 +427,10 @@ func (c *execCommand) Run(ctx *cmd.Context) error {
 		if c.compat {
 			codeKey = "ReturnCode"
 		}
-		ctx.Stdout.Write(formatOutput(result, stdoutKey, c.compat))
-		ctx.Stderr.Write(formatOutput(result, stderrKey, c.compat))
+		if streamer == nil {
+			ctx.Stdout.Write(formatOutput(result, stdoutKey, c.compat))
+			ctx.Stderr.Write(formatOutput(result, stderrKey, c.compat))
+		}
 		if code, ok := result[codeKey].(int); ok && code != 0 {
 			return cmd.NewRcPassthroughError(code)
 		}
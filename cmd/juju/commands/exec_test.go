@@ -5,6 +5,7 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
 	"time"
@@ -23,7 +24,10 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
 )
@@ -701,6 +705,55 @@ func (s *ExecSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *ExecSuite) TestStreamWithUnsupportedAPIVersion(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.setMachinesAlive("0")
+	mock.setResponse("0", mockResponse{machineTag: "machine-0"})
+
+	_, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--stream", "--all", "hostname")
+	c.Assert(err, gc.ErrorMatches, "--stream is not supported by this controller\nupgrade your controller to use it")
+}
+
+func (s *ExecSuite) TestStreamSingleResponse(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.bestAPIVersion = 7
+	mock.setMachinesAlive("0")
+	mock.setResponse("0", mockResponse{
+		stdout:     "line 1\nline 2\n",
+		stderr:     "oops\n",
+		code:       "42",
+		machineTag: "machine-0",
+	})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.execResponses["0"],
+	}
+	mock.setOutput(c, mock.receiverIdMap["0"], actions.ActionOutput{
+		Stream: actions.StdoutStream,
+		Data:   "line 1\nli",
+	})
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--stream", "--all", "ignored")
+	c.Check(err, gc.ErrorMatches, "subprocess encountered error code 42")
+	c.Check(cmdtesting.Stdout(context), gc.Equals, "line 1\nline 2\n")
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "oops\n")
+}
+
+func (s *ExecSuite) TestStreamPrefixesOutput(c *gc.C) {
+	mock := s.setupMockAPI()
+	mock.bestAPIVersion = 7
+	mock.setMachinesAlive("0", "1")
+	mock.setResponse("0", mockResponse{stdout: "zero\n", machineTag: "machine-0"})
+	mock.setResponse("1", mockResponse{stdout: "one\n", machineTag: "machine-1"})
+	mock.actionResponses = map[string]params.ActionResult{
+		mock.receiverIdMap["0"]: mock.execResponses["0"],
+		mock.receiverIdMap["1"]: mock.execResponses["1"],
+	}
+
+	context, err := cmdtesting.RunCommand(c, newTestExecCommand(&mockClock{}, model.IAAS), "--stream", "--format=json", "--all", "ignored")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(context), gc.Matches, "machine 0: zero\nmachine 1: one\n\\[.*\\]\n")
+}
+
 func (s *ExecSuite) setupMockAPI() *mockExecAPI {
 	mock := &mockExecAPI{
 		bestAPIVersion: 4,
@@ -721,6 +774,7 @@ type mockExecAPI struct {
 	execResponses   map[string]params.ActionResult
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
+	output          map[string][]string
 	block           bool
 	//
 	bestAPIVersion int
@@ -786,6 +840,17 @@ func makeActionResult(mock mockResponse, actionTag string) params.ActionResult {
 	}
 }
 
+func (m *mockExecAPI) setOutput(c *gc.C, actionId string, output ...actions.ActionOutput) {
+	if m.output == nil {
+		m.output = make(map[string][]string)
+	}
+	for _, chunk := range output {
+		data, err := json.Marshal(chunk)
+		c.Assert(err, jc.ErrorIsNil)
+		m.output[actionId] = append(m.output[actionId], string(data))
+	}
+}
+
 func (m *mockExecAPI) setResponse(id string, mock mockResponse) {
 	if m.execResponses == nil {
 		m.execResponses = make(map[string]params.ActionResult)
@@ -876,6 +941,14 @@ func (m *mockExecAPI) Actions(actionTags params.Entities) (params.ActionResults,
 	return results, nil
 }
 
+func (m *mockExecAPI) WatchActionOutput(actionId string) (watcher.StringsWatcher, error) {
+	changes := make(chan []string, 1)
+	if output, ok := m.output[actionId]; ok {
+		changes <- output
+	}
+	return watchertest.NewMockStringsWatcher(changes), nil
+}
+
 func (m *mockExecAPI) BestAPIVersion() int {
 	return m.bestAPIVersion
 }
@@ -315,6 +315,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 	r.Register(newDebugCodeCommand(nil))
+	r.Register(newDebugCaptureCommand(nil))
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -379,6 +380,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
 	r.Register(model.NewModelCredentialCommand())
+	r.Register(model.NewEstimateCostCommand())
 	if featureflag.Enabled(feature.Branches) || featureflag.Enabled(feature.Generations) {
 		r.Register(model.NewAddBranchCommand())
 		r.Register(model.NewCommitCommand())
@@ -402,11 +404,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewListCommand())
 	r.Register(action.NewShowCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewScheduleActionCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewPauseScheduleCommand())
+	r.Register(action.NewResumeScheduleCommand())
+	r.Register(action.NewRemoveScheduleCommand())
 	if featureflag.Enabled(feature.ActionsV2) {
 		r.Register(action.NewRunCommand())
 		r.Register(action.NewListOperationsCommand())
 		r.Register(action.NewShowOperationCommand())
 		r.Register(action.NewShowTaskCommand())
+		r.Register(action.NewRunRunbookCommand())
 	} else {
 		r.Register(action.NewRunActionCommand())
 		r.Register(action.NewShowActionOutputCommand())
@@ -500,6 +508,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(caas.NewUpdateCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewImportK8sWorkloadCommand())
+	r.Register(application.NewBackupApplicationCommand())
+	r.Register(application.NewRestoreApplicationCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
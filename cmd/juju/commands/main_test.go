@@ -464,6 +464,7 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"action-schedules",
 	"actions",
 	"add-cloud",
 	"add-credential",
@@ -483,6 +484,7 @@ var commandNames = []string{
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"backup-application",
 	"backups",
 	"bind",
 	"bootstrap",
@@ -503,6 +505,7 @@ var commandNames = []string{
 	"create-wallet",
 	"credentials",
 	"dashboard",
+	"debug-capture",
 	"debug-code",
 	"debug-hook",
 	"debug-hooks",
@@ -522,6 +525,7 @@ var commandNames = []string{
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"estimate-cost",
 	"exec",
 	"export-bundle",
 	"expose",
@@ -537,8 +541,10 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-k8s-workload",
 	"import-ssh-key",
 	"kill-controller",
+	"list-action-schedules",
 	"list-actions",
 	"list-agreements",
 	"list-backups",
@@ -575,12 +581,14 @@ var commandNames = []string{
 	"move-to-space",
 	"offer",
 	"offers",
+	"pause-action-schedule",
 	"payloads",
 	"plans",
 	"regions",
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
+	"remove-action-schedule",
 	"remove-application",
 	"remove-backup",
 	"remove-cached-images",
@@ -602,13 +610,16 @@ var commandNames = []string{
 	"resolved",
 	"resolve",
 	"resources",
+	"restore-application",
 	"restore-backup",
+	"resume-action-schedule",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
 	"run",
 	"scale-application",
+	"schedule-action",
 	"scp",
 	"set-credential",
 	"set-constraints",
@@ -680,7 +691,7 @@ var optionalFeatures = []string{
 
 // These are the commands that are behind the `devFeatures`.
 var commandNamesBehindFlags = set.NewStrings(
-	"run", "show-task", "operations", "list-operations", "show-operation",
+	"run", "show-task", "operations", "list-operations", "show-operation", "run-runbook",
 	"info",
 )
 
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// ApplicationCost holds the estimated running cost of an application.
+type ApplicationCost struct {
+	Units          int     `json:"units" yaml:"units"`
+	InstanceType   string  `json:"instance-type,omitempty" yaml:"instance-type,omitempty"`
+	Currency       string  `json:"currency,omitempty" yaml:"currency,omitempty"`
+	Hourly         float64 `json:"instances-hourly" yaml:"instances-hourly"`
+	VolumesMonthly float64 `json:"volumes-monthly" yaml:"volumes-monthly"`
+	Monthly        float64 `json:"monthly" yaml:"monthly"`
+	Error          string  `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// ApplicationCostsFromParams converts the results of estimating the
+// running costs of applications to ApplicationCosts, keyed by
+// application name. Hourly costs are rounded to 4 decimal places and
+// monthly costs to 2.
+func ApplicationCostsFromParams(results []params.ApplicationCostResult) map[string]ApplicationCost {
+	costs := make(map[string]ApplicationCost)
+	for _, result := range results {
+		if result.Error != nil {
+			costs[result.ApplicationName] = ApplicationCost{Error: result.Error.Error()}
+			continue
+		}
+		costs[result.ApplicationName] = ApplicationCost{
+			Units:          result.Units,
+			InstanceType:   result.InstanceType,
+			Currency:       result.Currency,
+			Hourly:         round(result.InstancesHourly, 4),
+			VolumesMonthly: round(result.VolumesMonthly, 2),
+			Monthly:        round(result.Monthly, 2),
+		}
+	}
+	return costs
+}
+
+func round(f float64, places int) float64 {
+	scale := math.Pow10(places)
+	return math.Round(f*scale) / scale
+}
+
+// FormatApplicationCostsTabular writes a table of the estimated running
+// costs of applications, with a final row recording the total monthly
+// cost.
+func FormatApplicationCostsTabular(writer io.Writer, value interface{}) error {
+	costs, ok := value.(map[string]ApplicationCost)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", costs, value)
+	}
+	names := make([]string, 0, len(costs))
+	for name := range costs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{TabWriter: tw}
+	w.Println("Application", "Units", "Instance type", "Instances/hour", "Volumes/month", "Monthly", "Currency", "Notes")
+
+	var (
+		total           float64
+		currency        string
+		mixedCurrencies bool
+	)
+	for _, name := range names {
+		cost := costs[name]
+		if cost.Error != "" {
+			w.Println(name, "", "", "", "", "", "", cost.Error)
+			continue
+		}
+		w.Println(
+			name,
+			cost.Units,
+			cost.InstanceType,
+			fmt.Sprintf("%.4f", cost.Hourly),
+			fmt.Sprintf("%.2f", cost.VolumesMonthly),
+			fmt.Sprintf("%.2f", cost.Monthly),
+			cost.Currency,
+			"",
+		)
+		total += cost.Monthly
+		if cost.Currency == "" {
+			continue
+		}
+		if currency == "" {
+			currency = cost.Currency
+		} else if currency != cost.Currency {
+			mixedCurrencies = true
+		}
+	}
+	if !mixedCurrencies {
+		w.Println("Total", "", "", "", "", fmt.Sprintf("%.2f", total), currency, "")
+	}
+	return errors.Trace(tw.Flush())
+}
@@ -40,6 +40,7 @@ type ModelInfo struct {
 	SLAOwner       string                      `json:"sla-owner,omitempty" yaml:"sla-owner,omitempty"`
 	AgentVersion   string                      `json:"agent-version,omitempty" yaml:"agent-version,omitempty"`
 	Credential     *ModelCredential            `json:"credential,omitempty" yaml:"credential,omitempty"`
+	Costs          map[string]ApplicationCost  `json:"costs,omitempty" yaml:"costs,omitempty"`
 }
 
 // ModelMachineInfo contains information about a machine in a model.
@@ -0,0 +1,196 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juju/charm/v7"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/costs"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/storage"
+)
+
+const estimateCostCommandDoc = `
+Estimates the running cost of deploying a bundle to the current or
+specified model, before the bundle is deployed.
+
+Each unit is assumed to be deployed to a new machine of the cheapest
+instance type that satisfies the application's constraints, combined
+with the model's constraints; placement directives are ignored. The
+cost of the volumes for the applications' storage is included; where
+the bundle does not specify the size of a volume, 1GiB is assumed.
+
+Costs are estimated from the on-demand prices of the cloud's instance
+types and volumes, and exclude root disks, network traffic and other
+cloud services. Cost estimation is supported on the ec2 and gce clouds.
+
+The prices are read from tables which are built into the controller.
+They are fixed when Juju is built, and are only refreshed by upgrading
+the controller, so they may not reflect the cloud's current prices.
+
+Examples:
+    juju estimate-cost ./bundle.yaml
+    juju estimate-cost -m mymodel ./bundle.yaml --format yaml
+
+See also:
+    deploy
+    show-model
+`
+
+// NewEstimateCostCommand returns a command that estimates the running
+// cost of a bundle.
+func NewEstimateCostCommand() cmd.Command {
+	return modelcmd.Wrap(&estimateCostCommand{})
+}
+
+// estimateCostCommand estimates the running cost of a bundle.
+type estimateCostCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api EstimateCostAPI
+
+	bundlePath string
+}
+
+// EstimateCostAPI defines the methods on the costs API that the
+// estimate-cost command calls.
+type EstimateCostAPI interface {
+	Close() error
+	EstimateCosts([]params.ApplicationCostParams) ([]params.ApplicationCostResult, error)
+}
+
+// Info implements Command.Info.
+func (c *estimateCostCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "estimate-cost",
+		Args:    "<bundle file or directory>",
+		Purpose: "Estimates the running cost of deploying a bundle.",
+		Doc:     estimateCostCommandDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *estimateCostCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": common.FormatApplicationCostsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *estimateCostCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundlePath, args = args[0], args[1:]
+	return c.ModelCommandBase.Init(args)
+}
+
+func (c *estimateCostCommand) getAPI() (EstimateCostAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return costs.NewClient(api), nil
+}
+
+// Run implements Command.Run.
+func (c *estimateCostCommand) Run(ctx *cmd.Context) error {
+	data, err := readBundleData(ctx.AbsPath(c.bundlePath))
+	if err != nil {
+		return errors.Annotatef(err, "cannot read bundle %q", c.bundlePath)
+	}
+	applications, err := bundleApplicationCostParams(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.EstimateCosts(applications)
+	if err != nil {
+		return errors.Annotate(err, "estimating costs")
+	}
+	return c.out.Write(ctx, common.ApplicationCostsFromParams(results))
+}
+
+// readBundleData reads the bundle data from a bundle YAML file, or from
+// a bundle directory or archive.
+func readBundleData(path string) (*charm.BundleData, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.IsDir() || filepath.Ext(path) == ".bundle" || filepath.Ext(path) == ".zip" {
+		bundle, err := charm.ReadBundle(path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return bundle.Data(), nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	data, err := charm.ReadBundleData(f)
+	return data, errors.Trace(err)
+}
+
+// bundleApplicationCostParams returns the parameters for estimating the
+// running cost of each of the bundle's applications.
+func bundleApplicationCostParams(data *charm.BundleData) ([]params.ApplicationCostParams, error) {
+	names := make([]string, 0, len(data.Applications))
+	for name := range data.Applications {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]params.ApplicationCostParams, len(names))
+	for i, name := range names {
+		spec := data.Applications[name]
+		cons, err := constraints.Parse(spec.Constraints)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q constraints", name)
+		}
+		var storageCons map[string]storage.Constraints
+		for storageName, s := range spec.Storage {
+			sc, err := storage.ParseConstraints(s)
+			if err != nil {
+				return nil, errors.Annotatef(err, "application %q storage %q", name, storageName)
+			}
+			if storageCons == nil {
+				storageCons = make(map[string]storage.Constraints)
+			}
+			storageCons[storageName] = sc
+		}
+		result[i] = params.ApplicationCostParams{
+			ApplicationName: name,
+			NumUnits:        spec.NumUnits,
+			Constraints:     cons,
+			Storage:         storageCons,
+		}
+	}
+	return result, nil
+}
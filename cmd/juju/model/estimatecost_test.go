@@ -0,0 +1,165 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+)
+
+type EstimateCostSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api        *fakeEstimateCostClient
+	bundlePath string
+}
+
+var _ = gc.Suite(&EstimateCostSuite{})
+
+const estimateCostBundle = `
+applications:
+  web:
+    charm: cs:web
+    num_units: 2
+    constraints: mem=4G
+    storage:
+      data: ebs,10G
+  db:
+    charm: cs:db
+    num_units: 1
+    constraints: instance-type=m5.large
+  logging:
+    charm: cs:logging
+relations:
+- [web, logging]
+`
+
+func (s *EstimateCostSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeEstimateCostClient{results: []params.ApplicationCostResult{{
+		ApplicationName: "db",
+		Units:           1,
+		InstanceType:    "m5.large",
+		Currency:        "USD",
+		InstancesHourly: 0.096,
+		Monthly:         70.08,
+	}, {
+		ApplicationName: "logging",
+	}, {
+		ApplicationName: "web",
+		Units:           2,
+		InstanceType:    "t3.medium",
+		Currency:        "USD",
+		InstancesHourly: 0.0832,
+		VolumesMonthly:  2,
+		Monthly:         62.736,
+	}}}
+	s.bundlePath = filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(s.bundlePath, []byte(estimateCostBundle), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *EstimateCostSuite) TestInitNoBundle(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewEstimateCostCommandForTest(s.api, jujuclienttesting.MinimalStore()))
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *EstimateCostSuite) TestEstimateCost(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewEstimateCostCommandForTest(s.api, jujuclienttesting.MinimalStore()), s.bundlePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Application  Units  Instance type  Instances/hour  Volumes/month  Monthly  Currency  Notes
+db           1      m5.large       0.0960          0.00           70.08    USD       
+logging      0                     0.0000          0.00           0.00               
+web          2      t3.medium      0.0832          2.00           62.74    USD       
+Total                                                             132.82   USD       
+
+`[1:])
+	s.api.CheckCallNames(c, "EstimateCosts", "Close")
+	s.api.CheckCall(c, 0, "EstimateCosts", []params.ApplicationCostParams{{
+		ApplicationName: "db",
+		NumUnits:        1,
+		Constraints:     constraints.MustParse("instance-type=m5.large"),
+	}, {
+		ApplicationName: "logging",
+	}, {
+		ApplicationName: "web",
+		NumUnits:        2,
+		Constraints:     constraints.MustParse("mem=4G"),
+		Storage: map[string]storage.Constraints{
+			"data": {Pool: "ebs", Size: 10240, Count: 1},
+		},
+	}})
+}
+
+func (s *EstimateCostSuite) TestEstimateCostYAML(c *gc.C) {
+	s.api.results[0].Error = &params.Error{Message: "boom"}
+	ctx, err := cmdtesting.RunCommand(c, model.NewEstimateCostCommandForTest(s.api, jujuclienttesting.MinimalStore()), s.bundlePath, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, map[string]interface{}{
+		"db": map[string]interface{}{
+			"units":            0,
+			"instances-hourly": 0,
+			"volumes-monthly":  0,
+			"monthly":          0,
+			"error":            "boom",
+		},
+		"logging": map[string]interface{}{
+			"units":            0,
+			"instances-hourly": 0,
+			"volumes-monthly":  0,
+			"monthly":          0,
+		},
+		"web": map[string]interface{}{
+			"units":            2,
+			"instance-type":    "t3.medium",
+			"currency":         "USD",
+			"instances-hourly": 0.0832,
+			"volumes-monthly":  2,
+			"monthly":          62.74,
+		},
+	})
+}
+
+func (s *EstimateCostSuite) TestEstimateCostInvalidBundle(c *gc.C) {
+	err := ioutil.WriteFile(s.bundlePath, []byte("applications: {web: {num_units: 1, constraints: bad}}"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, model.NewEstimateCostCommandForTest(s.api, jujuclienttesting.MinimalStore()), s.bundlePath)
+	c.Assert(err, gc.ErrorMatches, `application "web" constraints: .*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *EstimateCostSuite) TestEstimateCostError(c *gc.C) {
+	s.api.SetErrors(errors.NotSupportedf("cost estimation"))
+	_, err := cmdtesting.RunCommand(c, model.NewEstimateCostCommandForTest(s.api, jujuclienttesting.MinimalStore()), s.bundlePath)
+	c.Assert(err, gc.ErrorMatches, "estimating costs: cost estimation not supported")
+}
+
+type fakeEstimateCostClient struct {
+	gitjujutesting.Stub
+	results []params.ApplicationCostResult
+}
+
+func (f *fakeEstimateCostClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeEstimateCostClient) EstimateCosts(applications []params.ApplicationCostParams) ([]params.ApplicationCostResult, error) {
+	f.MethodCall(f, "EstimateCosts", applications)
+	return f.results, f.NextErr()
+}
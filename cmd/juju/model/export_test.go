@@ -52,6 +52,20 @@ func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientS
 	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
 }
 
+// NewShowCommandWithCostsForTest returns a ShowCommand with the apis
+// provided as specified.
+func NewShowCommandWithCostsForTest(
+	api ShowModelAPI,
+	costsAPI ModelCostsAPI,
+	refreshFunc func(jujuclient.ClientStore, string) error,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &showModelCommand{api: api, costsAPI: costsAPI}
+	cmd.SetClientStore(store)
+	cmd.SetModelRefresh(refreshFunc)
+	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
+}
+
 // NewDumpCommandForTest returns a DumpCommand with the api provided as specified.
 func NewDumpCommandForTest(api DumpModelAPI, store jujuclient.ClientStore) cmd.Command {
 	cmd := &dumpCommand{api: api}
@@ -244,3 +258,11 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewEstimateCostCommandForTest returns an EstimateCostCommand with the
+// api provided as specified.
+func NewEstimateCostCommandForTest(api EstimateCostAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &estimateCostCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
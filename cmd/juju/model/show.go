@@ -12,6 +12,7 @@ import (
 	"github.com/juju/names/v4"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/costs"
 	"github.com/juju/juju/api/modelmanager"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
@@ -20,7 +21,25 @@ import (
 	"github.com/juju/juju/cmd/output"
 )
 
-const showModelCommandDoc = `Show information about the current or specified model.`
+const showModelCommandDoc = `
+Show information about the current or specified model.
+
+With --costs, the estimated running cost of each of the model's
+applications is also shown. The cost of a machine is shared equally
+between the principal units deployed to it, and the cost of the volumes
+backing a unit's storage is attributed to the unit's application. Costs
+are estimated from the on-demand prices of the cloud's instance types
+and volumes, and exclude root disks, network traffic and other cloud
+services. Cost estimation is supported on the ec2 and gce clouds.
+
+The prices are read from tables which are built into the controller.
+They are fixed when Juju is built, and are only refreshed by upgrading
+the controller, so they may not reflect the cloud's current prices.
+
+Examples:
+    juju show-model
+    juju show-model mymodel --costs
+`
 
 func NewShowCommand() cmd.Command {
 	showCmd := &showModelCommand{}
@@ -30,8 +49,11 @@ func NewShowCommand() cmd.Command {
 // showModelCommand shows all the users with access to the current model.
 type showModelCommand struct {
 	modelcmd.ModelCommandBase
-	out cmd.Output
-	api ShowModelAPI
+	out      cmd.Output
+	api      ShowModelAPI
+	costsAPI ModelCostsAPI
+
+	costs bool
 }
 
 // ShowModelAPI defines the methods on the client API that the
@@ -41,6 +63,13 @@ type ShowModelAPI interface {
 	ModelInfo([]names.ModelTag) ([]params.ModelInfoResult, error)
 }
 
+// ModelCostsAPI defines the methods on the costs API that the
+// show-model command calls when showing costs.
+type ModelCostsAPI interface {
+	Close() error
+	ModelCosts() ([]params.ApplicationCostResult, error)
+}
+
 func (c *showModelCommand) getAPI() (ShowModelAPI, error) {
 	if c.api != nil {
 		return c.api, nil
@@ -52,6 +81,17 @@ func (c *showModelCommand) getAPI() (ShowModelAPI, error) {
 	return modelmanager.NewClient(api), nil
 }
 
+func (c *showModelCommand) getCostsAPI() (ModelCostsAPI, error) {
+	if c.costsAPI != nil {
+		return c.costsAPI, nil
+	}
+	api, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return costs.NewClient(api), nil
+}
+
 // Info implements Command.Info.
 func (c *showModelCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
@@ -66,6 +106,7 @@ func (c *showModelCommand) Info() *cmd.Info {
 func (c *showModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.costs, "costs", false, "Show the estimated running cost of each application")
 }
 
 // Init implements Command.Init.
@@ -113,9 +154,34 @@ func (c *showModelCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.costs {
+		if err := c.addCosts(infoMap); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.out.Write(ctx, infoMap)
 }
 
+// addCosts adds the estimated running costs of the model's applications
+// to the model information.
+func (c *showModelCommand) addCosts(infoMap map[string]common.ModelInfo) error {
+	api, err := c.getCostsAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	results, err := api.ModelCosts()
+	if err != nil {
+		return errors.Annotate(err, "estimating costs")
+	}
+	for name, info := range infoMap {
+		info.Costs = common.ApplicationCostsFromParams(results)
+		infoMap[name] = info
+	}
+	return nil
+}
+
 func (c *showModelCommand) apiModelInfoToModelInfoMap(modelInfo []params.ModelInfo, controllerName string) (map[string]common.ModelInfo, error) {
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	now := time.Now()
@@ -200,6 +200,51 @@ func (s *ShowCommandSuite) TestShowWithCredentialFormatJson(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.JSONEquals, s.expectedOutput)
 }
 
+func (s *ShowCommandSuite) TestShowCosts(c *gc.C) {
+	costs := &fakeModelCostsClient{results: []params.ApplicationCostResult{{
+		ApplicationName: "web",
+		Units:           2,
+		Currency:        "USD",
+		InstancesHourly: 0.19200000001,
+		VolumesMonthly:  2,
+		Monthly:         142.16,
+	}, {
+		ApplicationName: "db",
+		Error:           &params.Error{Message: `price of instance type "x1.32xlarge" not found`},
+	}}}
+	command := model.NewShowCommandWithCostsForTest(&s.fake, costs, noOpRefresh, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml", "--costs")
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelOutput := s.expectedOutput["mymodel"].(attrs)
+	modelOutput["costs"] = attrs{
+		"web": attrs{
+			"units":            2,
+			"currency":         "USD",
+			"instances-hourly": 0.192,
+			"volumes-monthly":  2,
+			"monthly":          142.16,
+		},
+		"db": attrs{
+			"units":            0,
+			"instances-hourly": 0,
+			"volumes-monthly":  0,
+			"monthly":          0,
+			"error":            `price of instance type "x1.32xlarge" not found`,
+		},
+	}
+	c.Assert(cmdtesting.Stdout(ctx), jc.YAMLEquals, s.expectedOutput)
+	costs.CheckCallNames(c, "ModelCosts", "Close")
+}
+
+func (s *ShowCommandSuite) TestShowCostsError(c *gc.C) {
+	costs := &fakeModelCostsClient{}
+	costs.SetErrors(errors.NotSupportedf("cost estimation"))
+	command := model.NewShowCommandWithCostsForTest(&s.fake, costs, noOpRefresh, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "--costs")
+	c.Assert(err, gc.ErrorMatches, "estimating costs: cost estimation not supported")
+}
+
 func (s *ShowCommandSuite) TestUnrecognizedArg(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.newShowCommand(), "admin", "whoops")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
@@ -751,3 +796,18 @@ func (f *fakeModelShowClient) ModelInfo(tags []names.ModelTag) ([]params.ModelIn
 	}
 	return []params.ModelInfoResult{{Result: &f.info, Error: f.err}}, f.NextErr()
 }
+
+type fakeModelCostsClient struct {
+	gitjujutesting.Stub
+	results []params.ApplicationCostResult
+}
+
+func (f *fakeModelCostsClient) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeModelCostsClient) ModelCosts() ([]params.ApplicationCostResult, error) {
+	f.MethodCall(f, "ModelCosts")
+	return f.results, f.NextErr()
+}
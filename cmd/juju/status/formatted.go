@@ -127,6 +127,7 @@ type applicationStatus struct {
 	Scale            int                   `json:"scale,omitempty" yaml:"scale,omitempty"`
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
+	ExternalURL      string                `json:"external-url,omitempty" yaml:"external-url,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
 	Life             string                `json:"life,omitempty" yaml:"life,omitempty"`
 	StatusInfo       statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
@@ -275,6 +275,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		Scale:            application.Scale,
 		ProviderId:       application.ProviderId,
 		Address:          application.PublicAddress,
+		ExternalURL:      application.ExternalURL,
 		Relations:        application.Relations,
 		CanUpgradeTo:     application.CanUpgradeTo,
 		SubordinateTo:    application.SubordinateTo,
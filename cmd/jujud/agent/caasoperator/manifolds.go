@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/utils/proxy"
@@ -100,6 +101,10 @@ type ManifoldsConfig struct {
 	// NewExecClient provides k8s execframework functionality for juju run commands or actions.
 	NewExecClient func(namespace string) (exec.Executor, error)
 
+	// NewZoneExecClient provides the same functionality for units
+	// placed in other cluster zones of the model.
+	NewZoneExecClient func(namespace string, cloudSpec environs.CloudSpec) (exec.Executor, error)
+
 	// NewContainerStartWatcherClient provides the container start watcher client.
 	NewContainerStartWatcherClient func(caasoperator.Client) caasoperator.ContainerStartWatcher
 
@@ -284,6 +289,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 				return api.NewCharmDownloader(caller)
 			},
 			NewExecClient:                  config.NewExecClient,
+			NewZoneExecClient:              config.NewZoneExecClient,
 			NewContainerStartWatcherClient: config.NewContainerStartWatcherClient,
 			RunListenerSocket:              config.RunListenerSocket,
 		})),
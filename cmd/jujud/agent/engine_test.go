@@ -33,6 +33,7 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
@@ -48,6 +49,7 @@ var (
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"resource-tagger",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
@@ -56,6 +58,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"application-scaler",
 		"charm-revision-updater",
 		"compute-provisioner",
@@ -71,6 +74,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"remote-relations",
+		"resource-tagger",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/pki"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -58,6 +59,7 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/resourcetagger"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -298,6 +300,11 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.ActionPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.action"),
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.actionscheduler"),
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
@@ -428,6 +435,14 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 			Logger:                       config.LoggingContext.GetLogger("juju.worker.machineundertaker"),
 		}))),
+		resourceTaggerName: ifNotMigrating(ifCredentialValid(resourcetagger.Manifold(resourcetagger.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			NewFacade:                    resourcetagger.NewFacade,
+			NewWorker:                    resourcetagger.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			Logger:                       config.LoggingContext.GetLogger("juju.worker.resourcetagger"),
+		}))),
 		modelUpgraderName: ifNotDead(ifCredentialValid(modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -655,11 +670,13 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 	loggingConfigUpdaterName = "logging-config-updater"
 	instanceMutaterName      = "instance-mutater"
+	resourceTaggerName       = "resource-tagger"
 
 	caasAdmissionName           = "caas-admission"
 	caasFirewallerName          = "caas-firewaller"
@@ -35,6 +35,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -60,6 +61,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"not-alive-flag",
 		"not-dead-flag",
 		"remote-relations",
+		"resource-tagger",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
@@ -82,6 +84,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 	// also fail. Search for 'ModelWorkers' to find affected vars.
 	c.Check(actual.SortedValues(), jc.DeepEquals, []string{
 		"action-pruner",
+		"action-scheduler",
 		"agent",
 		"api-caller",
 		"api-config-watcher",
@@ -210,6 +213,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"action-scheduler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"agent": {},
 
 	"api-caller": {"agent"},
@@ -393,6 +406,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"not-dead-flag",
 	},
 
+	"action-scheduler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"agent": {},
 
 	"api-caller": {"agent"},
@@ -577,6 +601,19 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"resource-tagger": {
+		"agent",
+		"api-caller",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"state-cleaner": {
 		"agent",
 		"api-caller",
@@ -24,6 +24,7 @@ import (
 	proxyutils "github.com/juju/proxy"
 	"github.com/juju/utils/exec"
 
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sexec "github.com/juju/juju/caas/kubernetes/provider/exec"
 	jujucmd "github.com/juju/juju/cmd"
 	agentcmd "github.com/juju/juju/cmd/jujud/agent"
@@ -32,6 +33,7 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/caasoperator"
 	"github.com/juju/juju/cmd/jujud/dumplogs"
 	"github.com/juju/juju/cmd/jujud/introspect"
+	"github.com/juju/juju/cmd/jujud/replayhook"
 	"github.com/juju/juju/cmd/jujud/run"
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	components "github.com/juju/juju/component/all"
@@ -246,6 +248,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 
 	caasOperatorAgent, err := agentcmd.NewCaasOperatorAgent(ctx, bufferedLogger, func(mc *caasoperator.ManifoldsConfig) error {
 		mc.NewExecClient = k8sexec.NewInCluster
+		mc.NewZoneExecClient = k8sprovider.NewExecClient
 		return nil
 	})
 	if err != nil {
@@ -254,6 +257,7 @@ func jujuDMain(args []string, ctx *cmd.Context) (code int, err error) {
 	jujud.Register(caasOperatorAgent)
 
 	jujud.Register(agentcmd.NewCheckConnectionCommand(agentConf, agentcmd.ConnectAsAgent))
+	jujud.Register(replayhook.NewCommand())
 
 	code = cmd.Main(jujud, ctx, args[1:])
 	return code, nil
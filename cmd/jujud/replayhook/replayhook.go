@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package replayhook provides a command which runs a charm hook against
+// the hook tool responses recorded by "juju debug-capture", so that a
+// hook which failed on a unit can be reproduced and debugged locally.
+package replayhook
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/capture"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// NewCommand returns a new Command instance which implements the
+// "jujud replay-hook" command.
+func NewCommand() cmd.Command {
+	return &replayHookCommand{
+		executable: os.Executable,
+	}
+}
+
+type replayHookCommand struct {
+	cmd.CommandBase
+	capturePath string
+	charmDir    string
+
+	// executable returns the path of the jujud executable,
+	// which serves as the hook tools run by the hook.
+	executable func() (string, error)
+}
+
+const replayHookDoc = `
+Run a hook captured with "juju debug-capture" against the charm in the
+given directory, which defaults to the current directory.
+
+The hook runs with the environment it ran with on the unit, except that
+the charm directory and the hook tools are local. Each hook tool the hook
+runs is answered with the response recorded for the same command and
+arguments when the hook was captured, so the hook sees the same config,
+relation data and leadership settings it saw on the unit. Nothing is
+changed on the unit.
+
+Once the hook has finished, any hook tool calls without a recorded
+response, and any recorded calls which were not made, are reported.
+
+Examples:
+
+    juju debug-capture mysql/0 config-changed-20200601T120000.000000000Z -o capture.json
+    jujud replay-hook --charm-dir ./mysql capture.json
+`
+
+// Info implements cmd.Command.
+func (c *replayHookCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "replay-hook",
+		Args:    "<capture file>",
+		Purpose: "run a captured hook against its recorded hook tool responses",
+		Doc:     replayHookDoc,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *replayHookCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.charmDir, "charm-dir", ".", "directory holding the charm to run the hook from")
+}
+
+// Init implements cmd.Command.
+func (c *replayHookCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no capture file specified")
+	}
+	c.capturePath, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *replayHookCommand) Run(ctx *cmd.Context) error {
+	bundle, err := capture.Read(ctx.AbsPath(c.capturePath))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	charmDir := ctx.AbsPath(c.charmDir)
+	hookScript, err := findHookScript(charmDir, bundle)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	replayDir, err := ioutil.TempDir("", "juju-replay-hook-")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(replayDir) }()
+
+	toolsDir := filepath.Join(replayDir, "tools")
+	if err := c.writeHookTools(toolsDir); err != nil {
+		return errors.Trace(err)
+	}
+
+	replayer := capture.NewReplayer(bundle)
+	socket := sockets.Socket{
+		Network: "unix",
+		Address: filepath.Join(replayDir, "agent.socket"),
+	}
+	srv, err := jujuc.NewResponderServer(replayer.Respond, socket, "")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	go func() { _ = srv.Run() }()
+	defer srv.Close()
+
+	ctx.Infof("replaying %s hook of %s captured at %s", bundle.Hook, bundle.Unit, bundle.Started)
+	ps := exec.Command(hookScript)
+	ps.Env = replayEnv(bundle.Env, os.Getenv("PATH"), charmDir, toolsDir, socket)
+	ps.Dir = charmDir
+	ps.Stdout = ctx.Stdout
+	ps.Stderr = ctx.Stderr
+	hookErr := ps.Run()
+
+	for _, call := range replayer.Unexpected() {
+		fmt.Fprintf(ctx.Stderr, "hook tool call not captured: %s\n", call)
+	}
+	for _, call := range replayer.Unreplayed() {
+		fmt.Fprintf(ctx.Stderr, "captured hook tool call not made: %s\n", call)
+	}
+	if bundle.Error != "" {
+		ctx.Infof("the captured hook failed with: %s", bundle.Error)
+	}
+	if exitErr, ok := hookErr.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return cmd.NewRcPassthroughError(status.ExitStatus())
+		}
+	}
+	return errors.Trace(hookErr)
+}
+
+// writeHookTools creates dir, holding a link named for each hook
+// tool to the jujud executable.
+func (c *replayHookCommand) writeHookTools(dir string) error {
+	jujudPath, err := c.executable()
+	if err != nil {
+		return errors.Annotate(err, "finding jujud executable")
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range jujuc.CommandNames() {
+		if err := os.Symlink(jujudPath, filepath.Join(dir, name)); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// findHookScript returns the script in charmDir which handles
+// the captured hook.
+func findHookScript(charmDir string, bundle capture.Bundle) (string, error) {
+	candidates := []string{filepath.Join(charmDir, "dispatch")}
+	for _, v := range bundle.Env {
+		if strings.HasPrefix(v, "JUJU_DISPATCH_PATH=") {
+			dispatchPath := strings.TrimPrefix(v, "JUJU_DISPATCH_PATH=")
+			candidates = append(candidates, filepath.Join(charmDir, filepath.FromSlash(dispatchPath)))
+		}
+	}
+	candidates = append(candidates, filepath.Join(charmDir, "hooks", bundle.Hook))
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	return "", errors.NotFoundf("hook %q in charm directory %q", bundle.Hook, charmDir)
+}
+
+// replayEnv returns the captured environment, changed to run the hook
+// from charmDir, with the hook tools in toolsDir answered by the jujuc
+// server listening on socket.
+func replayEnv(env []string, path, charmDir, toolsDir string, socket sockets.Socket) []string {
+	var result []string
+	for _, v := range env {
+		// The CA certificate is only used to reach a
+		// remote agent, and is not on this machine.
+		if !strings.HasPrefix(v, "JUJU_AGENT_CA_CERT=") {
+			result = append(result, v)
+		}
+	}
+	for _, v := range []string{
+		"CHARM_DIR=" + charmDir,
+		"JUJU_CHARM_DIR=" + charmDir,
+		"JUJU_AGENT_SOCKET_ADDRESS=" + socket.Address,
+		"JUJU_AGENT_SOCKET_NETWORK=" + socket.Network,
+		"PATH=" + toolsDir + string(os.PathListSeparator) + path,
+	} {
+		result = utils.Setenv(result, v)
+	}
+	return result
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package replayhook
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/juju/sockets"
+	"github.com/juju/juju/worker/uniter/runner/capture"
+)
+
+type ReplayHookSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&ReplayHookSuite{})
+
+func (s *ReplayHookSuite) writeCapture(c *gc.C, env []string) string {
+	path, err := capture.Write(c.MkDir(), capture.Bundle{
+		Unit:    "mysql/0",
+		Hook:    "config-changed",
+		Started: time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		Env:     env,
+		Calls: []capture.Call{{
+			Command: "config-get",
+			Args:    []string{"foo"},
+			Stdout:  []byte("bar\n"),
+		}},
+		Error: "exit status 3",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *ReplayHookSuite) writeHook(c *gc.C, charmDir, name, script string) {
+	err := os.MkdirAll(filepath.Dir(filepath.Join(charmDir, name)), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(charmDir, name), []byte("#!/bin/bash\n"+script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ReplayHookSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(NewCommand(), nil)
+	c.Assert(err, gc.ErrorMatches, "no capture file specified")
+	err = cmdtesting.InitCommand(NewCommand(), []string{"a", "b"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *ReplayHookSuite) TestRun(c *gc.C) {
+	capturePath := s.writeCapture(c, []string{
+		"JUJU_UNIT_NAME=mysql/0",
+		"JUJU_CHARM_DIR=/var/lib/juju/agents/unit-mysql-0/charm",
+		"JUJU_DISPATCH_PATH=hooks/config-changed",
+	})
+	charmDir := c.MkDir()
+	s.writeHook(c, charmDir, "hooks/config-changed", `
+echo "$JUJU_UNIT_NAME in $JUJU_CHARM_DIR"
+test -L "$(command -v config-get)" && echo "config-get found"
+exit 3
+`)
+
+	command := &replayHookCommand{
+		executable: func() (string, error) { return "/bin/true", nil },
+	}
+	ctx, err := cmdtesting.RunCommand(c, command, "--charm-dir", charmDir, capturePath)
+	c.Assert(err, jc.DeepEquals, cmd.NewRcPassthroughError(3))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "mysql/0 in "+charmDir+"\nconfig-get found\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "captured hook tool call not made: config-get foo\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "the captured hook failed with: exit status 3\n")
+}
+
+func (s *ReplayHookSuite) TestRunDispatch(c *gc.C) {
+	capturePath := s.writeCapture(c, []string{"JUJU_DISPATCH_PATH=hooks/config-changed"})
+	charmDir := c.MkDir()
+	s.writeHook(c, charmDir, "dispatch", `echo "dispatching $JUJU_DISPATCH_PATH"`)
+
+	command := &replayHookCommand{
+		executable: func() (string, error) { return "/bin/true", nil },
+	}
+	ctx, err := cmdtesting.RunCommand(c, command, "--charm-dir", charmDir, capturePath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "dispatching hooks/config-changed\n")
+}
+
+func (s *ReplayHookSuite) TestRunHookMissing(c *gc.C) {
+	capturePath := s.writeCapture(c, nil)
+	charmDir := c.MkDir()
+	_, err := cmdtesting.RunCommand(c, NewCommand(), "--charm-dir", charmDir, capturePath)
+	c.Assert(err, gc.ErrorMatches, `hook "config-changed" in charm directory ".*" not found`)
+}
+
+func (s *ReplayHookSuite) TestReplayEnv(c *gc.C) {
+	env := replayEnv([]string{
+		"JUJU_UNIT_NAME=mysql/0",
+		"CHARM_DIR=/var/lib/juju/agents/unit-mysql-0/charm",
+		"JUJU_AGENT_CA_CERT=/var/lib/juju/agents/unit-mysql-0/ca.crt",
+		"JUJU_AGENT_SOCKET_ADDRESS=@/var/lib/juju/agents/unit-mysql-0/agent.socket",
+		"PATH=/var/lib/juju/tools/unit-mysql-0:/usr/bin",
+	}, "/usr/local/bin", "/charm", "/replay/tools", sockets.Socket{
+		Network: "unix",
+		Address: "/replay/agent.socket",
+	})
+	c.Assert(env, jc.DeepEquals, []string{
+		"JUJU_UNIT_NAME=mysql/0",
+		"CHARM_DIR=/charm",
+		"JUJU_AGENT_SOCKET_ADDRESS=/replay/agent.socket",
+		"PATH=/replay/tools:/usr/local/bin",
+		"JUJU_CHARM_DIR=/charm",
+		"JUJU_AGENT_SOCKET_NETWORK=unix",
+	})
+}
@@ -226,6 +226,7 @@ func Manifolds(config manifoldsConfig) dependency.Manifolds {
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
 			Logger:                loggo.GetLogger("juju.worker.uniter"),
+			PrometheusRegisterer:  config.PrometheusRegisterer,
 		})),
 	}
 }
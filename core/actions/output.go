@@ -0,0 +1,22 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import "time"
+
+const (
+	// StdoutStream names the standard output of a running action.
+	StdoutStream = "stdout"
+
+	// StderrStream names the standard error of a running action.
+	StderrStream = "stderr"
+)
+
+// ActionOutput is a timestamped chunk of the output written
+// to stdout or stderr by a running action.
+type ActionOutput struct {
+	Stream    string    `json:"stream"`
+	Data      string    `json:"data"`
+	Timestamp time.Time `json:"timestamp"`
+}
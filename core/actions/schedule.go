@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/robfig/cron.v2"
+)
+
+// Schedule determines when a scheduled action is run.
+type Schedule interface {
+	// Next returns the first time the action is
+	// to be run after the specified time.
+	Next(time.Time) time.Time
+}
+
+// ParseSchedule returns the schedule specified in cron format, as five
+// fields for the minute, hour, day of the month, month and day of the
+// week, or as a descriptor such as "@daily" or "@every 6h". The times
+// are in UTC unless the schedule is prefixed with "TZ=<location> ".
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	schedule := spec
+	if strings.HasPrefix(schedule, "TZ=") {
+		i := strings.Index(schedule, " ")
+		if i < 0 {
+			return nil, errors.NotValidf("schedule %q without fields", spec)
+		}
+		if _, err := time.LoadLocation(schedule[3:i]); err != nil {
+			return nil, errors.NotValidf("schedule %q location", spec)
+		}
+		schedule = strings.TrimSpace(schedule[i:])
+	} else {
+		spec = "TZ=UTC " + spec
+	}
+	if !strings.HasPrefix(schedule, "@") {
+		// The cron package also accepts a leading seconds field,
+		// but actions are not scheduled more often than a minute.
+		if n := len(strings.Fields(schedule)); n != 5 {
+			return nil, errors.NotValidf("schedule %q with %d fields, expected 5", schedule, n)
+		}
+	}
+	s, err := cron.Parse(spec)
+	if err != nil {
+		return nil, errors.NewNotValid(err, "schedule "+schedule)
+	}
+	return s, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestParseSchedule(c *gc.C) {
+	now := time.Date(2020, 5, 6, 7, 8, 9, 0, time.UTC)
+	for i, test := range []struct {
+		spec string
+		next time.Time
+	}{{
+		spec: "0 3 * * *",
+		next: time.Date(2020, 5, 7, 3, 0, 0, 0, time.UTC),
+	}, {
+		spec: "*/15 * * * *",
+		next: time.Date(2020, 5, 6, 7, 15, 0, 0, time.UTC),
+	}, {
+		spec: "30 8 * * 1-5",
+		next: time.Date(2020, 5, 6, 8, 30, 0, 0, time.UTC),
+	}, {
+		spec: "@daily",
+		next: time.Date(2020, 5, 7, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "@every 1h",
+		next: time.Date(2020, 5, 6, 8, 8, 9, 0, time.UTC),
+	}, {
+		spec: "TZ=Asia/Tokyo 0 3 * * *",
+		next: time.Date(2020, 5, 6, 18, 0, 0, 0, time.UTC),
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.Next(now).UTC(), gc.Equals, test.next)
+	}
+}
+
+func (s *scheduleSuite) TestParseScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "0 3 * *",
+		err:  `schedule "0 3 \* \*" with 4 fields, expected 5 not valid`,
+	}, {
+		spec: "0 0 3 * * *",
+		err:  `schedule "0 0 3 \* \* \*" with 6 fields, expected 5 not valid`,
+	}, {
+		spec: "TZ=Nowhere/Special 0 3 * * *",
+		err:  `schedule "TZ=Nowhere/Special 0 3 \* \* \*" location not valid`,
+	}, {
+		spec: "@sometimes",
+		err:  `schedule @sometimes: .*`,
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
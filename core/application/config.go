@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -12,6 +13,20 @@ import (
 	"gopkg.in/juju/environschema.v1"
 )
 
+// ResourceTagsConfigOptionName is the option name used to set the
+// resource tags of an application's cloud resources in its
+// application configuration.
+const ResourceTagsConfigOptionName = "resource-tags"
+
+// UpdateStatusHookIntervalConfigOptionName is the option name used to
+// override the model's update-status-hook-interval for an application.
+const UpdateStatusHookIntervalConfigOptionName = "update-status-hook-interval"
+
+const (
+	minUpdateStatusHookInterval = time.Minute
+	maxUpdateStatusHookInterval = 60 * time.Minute
+)
+
 // ConfigAttributes is the config for an application.
 type ConfigAttributes map[string]interface{}
 
@@ -141,3 +156,23 @@ func (c ConfigAttributes) GetStringMap(attrName string, defaultValue map[string]
 	}
 	return defaultValue, nil
 }
+
+// UpdateStatusHookInterval returns the update-status hook interval
+// set in the application config, and whether one is set at all.
+func (c ConfigAttributes) UpdateStatusHookInterval() (time.Duration, bool, error) {
+	raw := c.GetString(UpdateStatusHookIntervalConfigOptionName, "")
+	if raw == "" {
+		return 0, false, nil
+	}
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, false, errors.NotValidf("update status hook interval %q", raw)
+	}
+	if interval < minUpdateStatusHookInterval {
+		return 0, false, errors.NotValidf("update status hook interval %v less than %v", interval, minUpdateStatusHookInterval)
+	}
+	if interval > maxUpdateStatusHookInterval {
+		return 0, false, errors.NotValidf("update status hook interval %v greater than %v", interval, maxUpdateStatusHookInterval)
+	}
+	return interval, true, nil
+}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/schema"
 	jc "github.com/juju/testing/checkers"
@@ -127,3 +129,38 @@ func (s *ApplicationSuite) TestInvalidStringMap(c *gc.C) {
 	_, err := cfg.Attributes().GetStringMap("field1", nil)
 	c.Assert(err, gc.ErrorMatches, "string map value of type string not valid")
 }
+
+func (s *ApplicationSuite) TestUpdateStatusHookInterval(c *gc.C) {
+	attrs := application.ConfigAttributes{
+		application.UpdateStatusHookIntervalConfigOptionName: "30m",
+	}
+	interval, ok, err := attrs.UpdateStatusHookInterval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(interval, gc.Equals, 30*time.Minute)
+}
+
+func (s *ApplicationSuite) TestUpdateStatusHookIntervalNotSet(c *gc.C) {
+	for _, attrs := range []application.ConfigAttributes{
+		nil,
+		{application.UpdateStatusHookIntervalConfigOptionName: ""},
+	} {
+		_, ok, err := attrs.UpdateStatusHookInterval()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(ok, jc.IsFalse)
+	}
+}
+
+func (s *ApplicationSuite) TestUpdateStatusHookIntervalInvalid(c *gc.C) {
+	for value, expect := range map[string]string{
+		"soon": `update status hook interval "soon" not valid`,
+		"30s":  `update status hook interval 30s less than 1m0s not valid`,
+		"2h":   `update status hook interval 2h0m0s greater than 1h0m0s not valid`,
+	} {
+		attrs := application.ConfigAttributes{
+			application.UpdateStatusHookIntervalConfigOptionName: value,
+		}
+		_, _, err := attrs.UpdateStatusHookInterval()
+		c.Check(err, gc.ErrorMatches, expect)
+	}
+}
@@ -23,17 +23,31 @@ const (
 	Arch      = "arch"
 	Container = "container"
 	// cpuCores is an alias for Cores.
-	cpuCores       = "cpu-cores"
-	Cores          = "cores"
-	CpuPower       = "cpu-power"
-	Mem            = "mem"
-	RootDisk       = "root-disk"
-	RootDiskSource = "root-disk-source"
-	Tags           = "tags"
-	InstanceType   = "instance-type"
-	Spaces         = "spaces"
-	VirtType       = "virt-type"
-	Zones          = "zones"
+	cpuCores          = "cpu-cores"
+	Cores             = "cores"
+	CpuPower          = "cpu-power"
+	Mem               = "mem"
+	RootDisk          = "root-disk"
+	RootDiskSource    = "root-disk-source"
+	Tags              = "tags"
+	InstanceType      = "instance-type"
+	InstanceLifecycle = "instance-lifecycle"
+	Spaces            = "spaces"
+	VirtType          = "virt-type"
+	Zones             = "zones"
+)
+
+// The following constants list the supported values
+// of the instance-lifecycle constraint.
+const (
+	// InstanceLifecycleOnDemand indicates that the machine must run
+	// on regular, on-demand, capacity. This is the default.
+	InstanceLifecycleOnDemand = "on-demand"
+
+	// InstanceLifecycleSpot indicates that the machine may run on
+	// spot (or preemptible) capacity, which is cheaper but may be
+	// reclaimed by the cloud at any time.
+	InstanceLifecycleSpot = "spot"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -84,6 +98,11 @@ type Value struct {
 	// be used. Only valid for clouds which support instance types.
 	InstanceType *string `json:"instance-type,omitempty" yaml:"instance-type,omitempty"`
 
+	// InstanceLifecycle, if not nil or empty, indicates whether the machine
+	// must run on on-demand or spot capacity. Only valid for clouds which
+	// support spot (or preemptible) instances.
+	InstanceLifecycle *string `json:"instance-lifecycle,omitempty" yaml:"instance-lifecycle,omitempty"`
+
 	// Spaces, if not nil, holds a list of juju network spaces that
 	// should be available (or not) on the machine. Positive and
 	// negative values are accepted, and the difference is the latter
@@ -156,6 +175,18 @@ func (v *Value) HasInstanceType() bool {
 	return v.InstanceType != nil && *v.InstanceType != ""
 }
 
+// HasInstanceLifecycle returns true if the constraints.Value specifies
+// an instance lifecycle.
+func (v *Value) HasInstanceLifecycle() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle != ""
+}
+
+// IsSpotInstance returns true if the constraints.Value specifies that
+// the machine may run on spot (or preemptible) capacity.
+func (v *Value) IsSpotInstance() bool {
+	return v.InstanceLifecycle != nil && *v.InstanceLifecycle == InstanceLifecycleSpot
+}
+
 // extractItems returns the list of entries in the given field which
 // are either positive (included) or negative (!included; with prefix
 // "^").
@@ -226,6 +257,9 @@ func (v Value) String() string {
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		strs = append(strs, "instance-lifecycle="+(*v.InstanceLifecycle))
+	}
 	if v.Mem != nil {
 		s := uintStr(*v.Mem)
 		if s != "" {
@@ -290,6 +324,9 @@ func (v Value) GoString() string {
 	if v.InstanceType != nil {
 		values = append(values, fmt.Sprintf("InstanceType: %q", *v.InstanceType))
 	}
+	if v.InstanceLifecycle != nil {
+		values = append(values, fmt.Sprintf("InstanceLifecycle: %q", *v.InstanceLifecycle))
+	}
 	if v.Container != nil {
 		values = append(values, fmt.Sprintf("Container: %q", *v.Container))
 	}
@@ -470,6 +507,8 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setTags(str)
 	case InstanceType:
 		err = v.setInstanceType(str)
+	case InstanceLifecycle:
+		err = v.setInstanceLifecycle(str)
 	case Spaces:
 		err = v.setSpaces(str)
 	case VirtType:
@@ -517,6 +556,8 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.Container = &ctype
 		case InstanceType:
 			v.InstanceType = &vstr
+		case InstanceLifecycle:
+			err = v.setInstanceLifecycle(vstr)
 		case Cores:
 			v.CpuCores, err = parseUint64(vstr)
 		case CpuPower:
@@ -610,6 +651,20 @@ func (v *Value) setInstanceType(str string) error {
 	return nil
 }
 
+func (v *Value) setInstanceLifecycle(str string) error {
+	if v.InstanceLifecycle != nil {
+		return errors.Errorf("already set")
+	}
+	switch str {
+	case "", InstanceLifecycleOnDemand, InstanceLifecycleSpot:
+	default:
+		return errors.Errorf("%q not recognized; must be %q or %q",
+			str, InstanceLifecycleOnDemand, InstanceLifecycleSpot)
+	}
+	v.InstanceLifecycle = &str
+	return nil
+}
+
 func (v *Value) setMem(str string) (err error) {
 	if v.Mem != nil {
 		return errors.Errorf("already set")
@@ -272,6 +272,30 @@ var parseConstraintsTests = []struct {
 		err:     `bad "root-disk-source" constraint: already set`,
 	},
 
+	// instance-lifecycle in detail.
+	{
+		summary: "set instance-lifecycle empty",
+		args:    []string{"instance-lifecycle="},
+	}, {
+		summary: "set instance-lifecycle spot",
+		args:    []string{"instance-lifecycle=spot"},
+	}, {
+		summary: "set instance-lifecycle on-demand",
+		args:    []string{"instance-lifecycle=on-demand"},
+	}, {
+		summary: "set nonsense instance-lifecycle",
+		args:    []string{"instance-lifecycle=reserved"},
+		err:     `bad "instance-lifecycle" constraint: "reserved" not recognized; must be "on-demand" or "spot"`,
+	}, {
+		summary: "double set instance-lifecycle together",
+		args:    []string{"instance-lifecycle=spot instance-lifecycle=spot"},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	}, {
+		summary: "double set instance-lifecycle separately",
+		args:    []string{"instance-lifecycle=spot", "instance-lifecycle=on-demand"},
+		err:     `bad "instance-lifecycle" constraint: already set`,
+	},
+
 	// tags
 	{
 		summary: "single tag",
@@ -559,6 +583,18 @@ func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
 	c.Check(con.HasRootDiskSource(), jc.IsFalse)
 }
 
+func (s *ConstraintsSuite) TestIsSpotInstance(c *gc.C) {
+	con := constraints.MustParse("instance-lifecycle=spot")
+	c.Check(con.HasInstanceLifecycle(), jc.IsTrue)
+	c.Check(con.IsSpotInstance(), jc.IsTrue)
+	con = constraints.MustParse("instance-lifecycle=on-demand")
+	c.Check(con.HasInstanceLifecycle(), jc.IsTrue)
+	c.Check(con.IsSpotInstance(), jc.IsFalse)
+	con = constraints.MustParse("instance-lifecycle=")
+	c.Check(con.HasInstanceLifecycle(), jc.IsFalse)
+	c.Check(con.IsSpotInstance(), jc.IsFalse)
+}
+
 func (s *ConstraintsSuite) TestHasRootDisk(c *gc.C) {
 	con := constraints.MustParse("root-disk=32G")
 	c.Check(con.HasRootDisk(), jc.IsTrue)
@@ -644,21 +680,24 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"InstanceLifecycle1", constraints.Value{InstanceLifecycle: strp("")}},
+	{"InstanceLifecycle2", constraints.Value{InstanceLifecycle: strp("spot")}},
 	{"Zones1", constraints.Value{Zones: nil}},
 	{"Zones2", constraints.Value{Zones: &[]string{}}},
 	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
 	{"All", constraints.Value{
-		Arch:           strp("i386"),
-		Container:      ctypep("lxd"),
-		CpuCores:       uint64p(4096),
-		CpuPower:       uint64p(9001),
-		Mem:            uint64p(18000000000),
-		RootDisk:       uint64p(24000000000),
-		RootDiskSource: strp("cave"),
-		Tags:           &[]string{"foo", "bar"},
-		Spaces:         &[]string{"space1", "^space2"},
-		InstanceType:   strp("foo"),
-		Zones:          &[]string{"az1", "az2"},
+		Arch:              strp("i386"),
+		Container:         ctypep("lxd"),
+		CpuCores:          uint64p(4096),
+		CpuPower:          uint64p(9001),
+		Mem:               uint64p(18000000000),
+		RootDisk:          uint64p(24000000000),
+		RootDiskSource:    strp("cave"),
+		Tags:              &[]string{"foo", "bar"},
+		Spaces:            &[]string{"space1", "^space2"},
+		InstanceType:      strp("foo"),
+		InstanceLifecycle: strp("on-demand"),
+		Zones:             &[]string{"az1", "az2"},
 	}},
 }
 
@@ -197,6 +197,11 @@ const (
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
 
+	// ReplaceReclaimedInstancesKey determines whether a replacement
+	// instance is started for a machine whose spot instance was
+	// reclaimed by the cloud.
+	ReplaceReclaimedInstancesKey = "replace-reclaimed-instances"
+
 	// TransmitVendorMetricsKey is the key for whether the controller sends
 	// metrics collected in this model for anonymized aggregate analytics.
 	TransmitVendorMetricsKey = "transmit-vendor-metrics"
@@ -456,6 +461,7 @@ var defaultConfigValues = map[string]interface{}{
 	ResourceTagsKey:               "",
 	"logging-config":              "",
 	AutomaticallyRetryHooks:       true,
+	ReplaceReclaimedInstancesKey:  false,
 	"enable-os-refresh-update":    true,
 	"enable-os-upgrade":           true,
 	"development":                 false,
@@ -1212,6 +1218,13 @@ func (c *Config) AutomaticallyRetryHooks() bool {
 	}
 }
 
+// ReplaceReclaimedInstances returns whether a replacement instance should be
+// started for a machine whose spot instance was reclaimed by the cloud.
+func (c *Config) ReplaceReclaimedInstances() bool {
+	val, _ := c.defined[ReplaceReclaimedInstancesKey].(bool)
+	return val
+}
+
 // TransmitVendorMetrics returns whether the controller sends charm-collected metrics
 // in this model for anonymized aggregate analytics. By default this should be true.
 func (c *Config) TransmitVendorMetrics() bool {
@@ -1538,6 +1551,7 @@ var alwaysOptional = schema.Defaults{
 	"disable-network-management":  schema.Omit,
 	IgnoreMachineAddresses:        schema.Omit,
 	AutomaticallyRetryHooks:       schema.Omit,
+	ReplaceReclaimedInstancesKey:  schema.Omit,
 	"test-mode":                   schema.Omit,
 	TransmitVendorMetricsKey:      schema.Omit,
 	NetBondReconfigureDelayKey:    schema.Omit,
@@ -1988,6 +2002,11 @@ data of the store. (default false)`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
+	ReplaceReclaimedInstancesKey: {
+		Description: "Determines whether a replacement instance is started for a machine whose spot instance was reclaimed by the cloud",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	TransmitVendorMetricsKey: {
 		Description: "Determines whether metrics declared by charms deployed into this model are sent for anonymized aggregate analytics",
 		Type:        environschema.Tbool,
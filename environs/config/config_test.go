@@ -1126,6 +1126,17 @@ func (s *ConfigSuite) TestAutoHookRetryTrueEnv(c *gc.C) {
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
 }
 
+func (s *ConfigSuite) TestReplaceReclaimedInstancesDefault(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{})
+	c.Assert(config.ReplaceReclaimedInstances(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestReplaceReclaimedInstances(c *gc.C) {
+	config := newTestConfig(c, testing.Attrs{
+		"replace-reclaimed-instances": "true"})
+	c.Assert(config.ReplaceReclaimedInstances(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestNoBothProxy(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{
 		"http-proxy":  "http://user@10.0.0.1",
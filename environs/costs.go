@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+// HoursPerMonth is the number of hours used to convert hourly prices
+// into monthly prices. It is the average number of hours in a month,
+// which is also the convention used by the cloud providers' own price
+// calculators.
+const HoursPerMonth = 730
+
+// CostEstimator is an interface that an Environ may implement to price
+// the instance types and volumes that it knows about. Prices are
+// estimates, typically taken from price tables bundled with Juju, and
+// exclude charges such as network traffic and other cloud services.
+type CostEstimator interface {
+	InstanceTypesFetcher
+
+	// InstanceTypeCost returns the hourly on-demand price of running an
+	// instance of the named type in the environ's region. An error
+	// satisfying errors.IsNotFound is returned if the price of the
+	// instance type is not known.
+	InstanceTypeCost(ctx context.ProviderCallContext, instanceType string) (Price, error)
+
+	// VolumeCost returns the monthly price of a volume with the
+	// specified parameters in the environ's region. Volumes that are
+	// not billed separately, such as loop or tmpfs volumes, have a
+	// price of zero.
+	VolumeCost(ctx context.ProviderCallContext, volume storage.VolumeParams) (Price, error)
+}
+
+// Price records an amount of money in a currency.
+type Price struct {
+	// Amount is the amount of money, in units of Currency.
+	Amount float64
+
+	// Currency is the ISO 4217 code of the currency, e.g. "USD".
+	Currency string
+}
+
+// CostEstimate records the estimated cost of running an instance and
+// its volumes.
+type CostEstimate struct {
+	// InstanceType is the name of the instance type that was priced.
+	InstanceType string
+
+	// Currency is the ISO 4217 code of the currency that the costs are
+	// expressed in.
+	Currency string
+
+	// InstanceHourly is the hourly cost of running the instance.
+	InstanceHourly float64
+
+	// VolumesMonthly is the monthly cost of the instance's volumes.
+	VolumesMonthly float64
+}
+
+// Monthly returns the total monthly cost of the instance and its
+// volumes.
+func (e CostEstimate) Monthly() float64 {
+	return e.InstanceHourly*HoursPerMonth + e.VolumesMonthly
+}
+
+// EstimateInstanceCost estimates the cost of running an instance with
+// the specified constraints and volumes. If the constraints specify an
+// instance type then that instance type is priced; otherwise the
+// cheapest priced instance type that satisfies the constraints is
+// assumed, as that is what the provider would choose. If the environ
+// does not implement CostEstimator, then an error satisfying
+// errors.IsNotSupported is returned.
+func EstimateInstanceCost(
+	ctx context.ProviderCallContext,
+	env interface{},
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+) (CostEstimate, error) {
+	estimator, ok := env.(CostEstimator)
+	if !ok {
+		return CostEstimate{}, errors.NotSupportedf("cost estimation")
+	}
+
+	var (
+		result CostEstimate
+		price  Price
+		err    error
+	)
+	if cons.HasInstanceType() {
+		result.InstanceType = *cons.InstanceType
+		price, err = estimator.InstanceTypeCost(ctx, result.InstanceType)
+		if err != nil {
+			return CostEstimate{}, errors.Trace(err)
+		}
+	} else {
+		result.InstanceType, price, err = cheapestInstanceType(ctx, estimator, cons)
+		if err != nil {
+			return CostEstimate{}, errors.Trace(err)
+		}
+	}
+	result.Currency = price.Currency
+	result.InstanceHourly = price.Amount
+
+	for _, volume := range volumes {
+		price, err := estimator.VolumeCost(ctx, volume)
+		if err != nil {
+			return CostEstimate{}, errors.Annotatef(err, "pricing %q volume", volume.Provider)
+		}
+		if price.Amount == 0 {
+			continue
+		}
+		if price.Currency != result.Currency {
+			return CostEstimate{}, errors.Errorf(
+				"%q volume priced in %s, instance priced in %s",
+				volume.Provider, price.Currency, result.Currency,
+			)
+		}
+		result.VolumesMonthly += price.Amount
+	}
+	return result, nil
+}
+
+// cheapestInstanceType returns the name and price of the cheapest
+// priced instance type that satisfies the constraints.
+func cheapestInstanceType(
+	ctx context.ProviderCallContext,
+	estimator CostEstimator,
+	cons constraints.Value,
+) (string, Price, error) {
+	itypes, err := estimator.InstanceTypes(ctx, cons)
+	if err != nil {
+		return "", Price{}, errors.Annotate(err, "listing instance types")
+	}
+	var (
+		cheapest string
+		price    Price
+	)
+	for _, itype := range itypes.InstanceTypes {
+		p, err := estimator.InstanceTypeCost(ctx, itype.Name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return "", Price{}, errors.Trace(err)
+		}
+		if cheapest == "" || p.Amount < price.Amount {
+			cheapest, price = itype.Name, p
+		}
+	}
+	if cheapest == "" {
+		return "", Price{}, errors.NotFoundf("priced instance type matching constraints %q", cons)
+	}
+	return cheapest, price, nil
+}
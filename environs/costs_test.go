@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/storage"
+)
+
+type costsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&costsSuite{})
+
+func (s *costsSuite) TestCostEstimateMonthly(c *gc.C) {
+	estimate := environs.CostEstimate{InstanceHourly: 0.1, VolumesMonthly: 5}
+	c.Assert(estimate.Monthly(), jc.DeepEquals, 78.0)
+}
+
+func (s *costsSuite) TestEstimateInstanceCostNotCostEstimator(c *gc.C) {
+	_, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), struct{}{}, constraints.Value{}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *costsSuite) TestEstimateInstanceCostInstanceType(c *gc.C) {
+	estimator := newFakeCostEstimator()
+	cons := constraints.MustParse("instance-type=large")
+	volumes := []storage.VolumeParams{{Provider: "ebs", Size: 10240}, {Provider: "loop", Size: 1024}}
+	estimate, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), estimator, cons, volumes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(estimate, jc.DeepEquals, environs.CostEstimate{
+		InstanceType:   "large",
+		Currency:       "USD",
+		InstanceHourly: 0.2,
+		VolumesMonthly: 1,
+	})
+	estimator.CheckCallNames(c, "InstanceTypeCost", "VolumeCost", "VolumeCost")
+	estimator.CheckCall(c, 0, "InstanceTypeCost", "large")
+}
+
+func (s *costsSuite) TestEstimateInstanceCostCheapestMatch(c *gc.C) {
+	estimator := newFakeCostEstimator()
+	cons := constraints.MustParse("mem=1G")
+	estimate, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), estimator, cons, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(estimate, jc.DeepEquals, environs.CostEstimate{
+		InstanceType:   "small",
+		Currency:       "USD",
+		InstanceHourly: 0.1,
+	})
+	estimator.CheckCallNames(c, "InstanceTypes", "InstanceTypeCost", "InstanceTypeCost", "InstanceTypeCost")
+	estimator.CheckCall(c, 0, "InstanceTypes", cons)
+}
+
+func (s *costsSuite) TestEstimateInstanceCostNoPricedMatch(c *gc.C) {
+	estimator := newFakeCostEstimator()
+	estimator.prices = nil
+	_, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), estimator, constraints.Value{}, nil)
+	c.Assert(err, gc.ErrorMatches, `priced instance type matching constraints "" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *costsSuite) TestEstimateInstanceCostVolumeCurrencyMismatch(c *gc.C) {
+	estimator := newFakeCostEstimator()
+	estimator.volumeCurrency = "EUR"
+	cons := constraints.MustParse("instance-type=small")
+	volumes := []storage.VolumeParams{{Provider: "ebs", Size: 10240}}
+	_, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), estimator, cons, volumes)
+	c.Assert(err, gc.ErrorMatches, `"ebs" volume priced in EUR, instance priced in USD`)
+}
+
+func (s *costsSuite) TestEstimateInstanceCostError(c *gc.C) {
+	estimator := newFakeCostEstimator()
+	estimator.SetErrors(errors.New("boom"))
+	_, err := environs.EstimateInstanceCost(context.NewCloudCallContext(), estimator, constraints.Value{}, nil)
+	c.Assert(err, gc.ErrorMatches, "listing instance types: boom")
+}
+
+type fakeCostEstimator struct {
+	*testing.Stub
+
+	prices         map[string]float64
+	volumeCurrency string
+}
+
+func newFakeCostEstimator() *fakeCostEstimator {
+	return &fakeCostEstimator{
+		Stub: &testing.Stub{},
+		prices: map[string]float64{
+			"small": 0.1,
+			"large": 0.2,
+		},
+		volumeCurrency: "USD",
+	}
+}
+
+func (f *fakeCostEstimator) InstanceTypes(
+	ctx context.ProviderCallContext, cons constraints.Value,
+) (instances.InstanceTypesWithCostMetadata, error) {
+	f.MethodCall(f, "InstanceTypes", cons)
+	return instances.InstanceTypesWithCostMetadata{
+		InstanceTypes: []instances.InstanceType{
+			{Name: "large"}, {Name: "unpriced"}, {Name: "small"},
+		},
+	}, f.NextErr()
+}
+
+func (f *fakeCostEstimator) InstanceTypeCost(
+	ctx context.ProviderCallContext, instanceType string,
+) (environs.Price, error) {
+	f.MethodCall(f, "InstanceTypeCost", instanceType)
+	if err := f.NextErr(); err != nil {
+		return environs.Price{}, err
+	}
+	price, ok := f.prices[instanceType]
+	if !ok {
+		return environs.Price{}, errors.NotFoundf("price of instance type %q", instanceType)
+	}
+	return environs.Price{Amount: price, Currency: "USD"}, nil
+}
+
+func (f *fakeCostEstimator) VolumeCost(
+	ctx context.ProviderCallContext, volume storage.VolumeParams,
+) (environs.Price, error) {
+	f.MethodCall(f, "VolumeCost", volume)
+	if err := f.NextErr(); err != nil {
+		return environs.Price{}, err
+	}
+	if volume.Provider != "ebs" {
+		return environs.Price{}, nil
+	}
+	// $0.10 per GiB-month.
+	return environs.Price{Amount: 0.1 * float64(volume.Size/1024), Currency: f.volumeCurrency}, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package pricing provides the offline price tables used by providers
+// to implement environs.CostEstimator.
+//
+// Each provider that estimates costs bundles a YAML price table, which
+// is compiled into the provider as a constant. The tables are refreshed
+// from the clouds' public price lists with:
+//
+//     go run github.com/juju/juju/generate/pricetables <provider> <yamlfile>
+//     go generate ./provider/<provider>
+package pricing
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/environs"
+)
+
+// Table holds the on-demand prices of a cloud's instance types and
+// volume types, by region.
+type Table struct {
+	// Currency is the ISO 4217 code of the currency that the prices
+	// are expressed in.
+	Currency string `yaml:"currency"`
+
+	// Regions holds the prices for each region, keyed by region name.
+	Regions map[string]RegionPrices `yaml:"regions"`
+}
+
+// RegionPrices holds the prices of the instance types and volume types
+// available in a region.
+type RegionPrices struct {
+	// InstanceTypes holds the hourly price of each instance type,
+	// keyed by instance type name.
+	InstanceTypes map[string]float64 `yaml:"instance-types,omitempty"`
+
+	// VolumeTypes holds the price per GiB-month of each volume type,
+	// keyed by volume type name.
+	VolumeTypes map[string]float64 `yaml:"volume-types,omitempty"`
+}
+
+// Parse parses a YAML price table.
+func Parse(data []byte) (*Table, error) {
+	var table Table
+	if err := yaml.Unmarshal(data, &table); err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal price table")
+	}
+	if table.Currency == "" {
+		return nil, errors.NotValidf("price table without currency")
+	}
+	return &table, nil
+}
+
+// Marshal returns the YAML encoding of the price table.
+func (t *Table) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(t)
+	return data, errors.Trace(err)
+}
+
+// InstanceTypePrice returns the hourly price of the named instance type
+// in the specified region. An error satisfying errors.IsNotFound is
+// returned if the table does not record the price.
+func (t *Table) InstanceTypePrice(region, instanceType string) (environs.Price, error) {
+	price, ok := t.Regions[region].InstanceTypes[instanceType]
+	if !ok {
+		return environs.Price{}, errors.NotFoundf("price of instance type %q in region %q", instanceType, region)
+	}
+	return environs.Price{Amount: price, Currency: t.Currency}, nil
+}
+
+// VolumePrice returns the monthly price of a volume of the named type
+// and size, in GiB, in the specified region. An error satisfying
+// errors.IsNotFound is returned if the table does not record the price.
+func (t *Table) VolumePrice(region, volumeType string, sizeGiB uint64) (environs.Price, error) {
+	price, ok := t.Regions[region].VolumeTypes[volumeType]
+	if !ok {
+		return environs.Price{}, errors.NotFoundf("price of volume type %q in region %q", volumeType, region)
+	}
+	return environs.Price{Amount: price * float64(sizeGiB), Currency: t.Currency}, nil
+}
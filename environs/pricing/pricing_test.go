@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package pricing_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/pricing"
+)
+
+type pricingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pricingSuite{})
+
+const testTable = `
+currency: USD
+regions:
+  region-1:
+    instance-types:
+      small: 0.01
+      large: 0.1
+    volume-types:
+      ssd: 0.1
+`
+
+func (s *pricingSuite) TestParse(c *gc.C) {
+	table, err := pricing.Parse([]byte(testTable))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(table, jc.DeepEquals, &pricing.Table{
+		Currency: "USD",
+		Regions: map[string]pricing.RegionPrices{
+			"region-1": {
+				InstanceTypes: map[string]float64{"small": 0.01, "large": 0.1},
+				VolumeTypes:   map[string]float64{"ssd": 0.1},
+			},
+		},
+	})
+}
+
+func (s *pricingSuite) TestParseNoCurrency(c *gc.C) {
+	_, err := pricing.Parse([]byte("regions: {}"))
+	c.Assert(err, gc.ErrorMatches, "price table without currency not valid")
+}
+
+func (s *pricingSuite) TestParseInvalid(c *gc.C) {
+	_, err := pricing.Parse([]byte("currency: [USD]"))
+	c.Assert(err, gc.ErrorMatches, "(?s)cannot unmarshal price table: .*")
+}
+
+func (s *pricingSuite) TestMarshalRoundTrip(c *gc.C) {
+	table, err := pricing.Parse([]byte(testTable))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := table.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	roundTripped, err := pricing.Parse(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(roundTripped, jc.DeepEquals, table)
+}
+
+func (s *pricingSuite) TestInstanceTypePrice(c *gc.C) {
+	table, err := pricing.Parse([]byte(testTable))
+	c.Assert(err, jc.ErrorIsNil)
+	price, err := table.InstanceTypePrice("region-1", "large")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 0.1, Currency: "USD"})
+
+	_, err = table.InstanceTypePrice("region-1", "huge")
+	c.Assert(err, gc.ErrorMatches, `price of instance type "huge" in region "region-1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = table.InstanceTypePrice("region-2", "large")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *pricingSuite) TestVolumePrice(c *gc.C) {
+	table, err := pricing.Parse([]byte(testTable))
+	c.Assert(err, jc.ErrorIsNil)
+	price, err := table.VolumePrice("region-1", "ssd", 20)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 2, Currency: "USD"})
+
+	_, err = table.VolumePrice("region-1", "hdd", 20)
+	c.Assert(err, gc.ErrorMatches, `price of volume type "hdd" in region "region-1" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
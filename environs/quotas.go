@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+// QuotaChecker is an interface that an Environ may implement to report
+// the cloud resource quotas that remain available to the model's
+// credential. If an Environ implements this interface, the provisioner
+// and instance prechecks consult it before starting instances, so that
+// a deployment that would exceed the quotas is refused up front rather
+// than failing in StartInstance.
+type QuotaChecker interface {
+	// RemainingQuotas returns the amount of each cloud resource that
+	// may still be consumed before a quota is exceeded.
+	RemainingQuotas(ctx context.ProviderCallContext) (Quotas, error)
+
+	// InstanceQuotaUsage returns the amount of each cloud resource that
+	// would be consumed by starting an instance with the specified
+	// constraints and volumes.
+	InstanceQuotaUsage(
+		ctx context.ProviderCallContext,
+		cons constraints.Value,
+		volumes []storage.VolumeParams,
+	) (QuotaUsage, error)
+}
+
+// Quotas records the remaining amount of each cloud resource that is
+// subject to a quota. A nil field indicates that the resource is either
+// not limited, or that the cloud does not report its limit.
+type Quotas struct {
+	// Instances is the number of instances that may still be started.
+	Instances *uint64
+
+	// Cores is the number of virtual CPU cores that may still be
+	// consumed by instances.
+	Cores *uint64
+
+	// MemoryMiB is the amount of instance memory, in MiB, that may
+	// still be consumed.
+	MemoryMiB *uint64
+
+	// Volumes is the number of volumes that may still be created.
+	Volumes *uint64
+
+	// VolumeGiB is the amount of volume storage, in GiB, that may still
+	// be allocated.
+	VolumeGiB *uint64
+}
+
+// QuotaUsage records the amount of each cloud resource consumed by an
+// operation, such as starting an instance.
+type QuotaUsage struct {
+	Instances uint64
+	Cores     uint64
+	MemoryMiB uint64
+	Volumes   uint64
+	VolumeGiB uint64
+}
+
+// Check returns an error satisfying errors.IsQuotaLimitExceeded if the
+// specified usage would exceed any of the remaining quotas. The error
+// message describes every quota that would be exceeded.
+func (q Quotas) Check(usage QuotaUsage) error {
+	var exceeded []string
+	check := func(what string, remaining *uint64, required uint64) {
+		if remaining == nil || required <= *remaining {
+			return
+		}
+		exceeded = append(exceeded, fmt.Sprintf(
+			"%s (%d required, %d remaining)", what, required, *remaining,
+		))
+	}
+	check("instances", q.Instances, usage.Instances)
+	check("cores", q.Cores, usage.Cores)
+	check("memory MiB", q.MemoryMiB, usage.MemoryMiB)
+	check("volumes", q.Volumes, usage.Volumes)
+	check("volume GiB", q.VolumeGiB, usage.VolumeGiB)
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return errors.QuotaLimitExceededf(
+		"cloud quota exceeded for %s", strings.Join(exceeded, ", "),
+	)
+}
+
+// CheckInstanceQuotas checks whether starting an instance with the
+// specified constraints and volumes would exceed the cloud's remaining
+// quotas. If the environ does not implement QuotaChecker, then nil is
+// returned; otherwise an error satisfying errors.IsQuotaLimitExceeded
+// is returned if a quota would be exceeded.
+func CheckInstanceQuotas(
+	ctx context.ProviderCallContext,
+	env interface{},
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+) error {
+	checker, ok := env.(QuotaChecker)
+	if !ok {
+		return nil
+	}
+	quotas, err := checker.RemainingQuotas(ctx)
+	if err != nil {
+		return errors.Annotate(err, "querying cloud quotas")
+	}
+	if quotas == (Quotas{}) {
+		// The cloud reports no quotas, so there is nothing to check.
+		return nil
+	}
+	usage, err := checker.InstanceQuotaUsage(ctx, cons, volumes)
+	if err != nil {
+		return errors.Annotate(err, "computing instance quota usage")
+	}
+	return errors.Trace(quotas.Check(usage))
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+type quotasSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&quotasSuite{})
+
+func (s *quotasSuite) TestCheckWithinQuotas(c *gc.C) {
+	quotas := environs.Quotas{Instances: uint64p(1), Cores: uint64p(4)}
+	err := quotas.Check(environs.QuotaUsage{Instances: 1, Cores: 4, MemoryMiB: 1 << 20})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *quotasSuite) TestCheckQuotasExceeded(c *gc.C) {
+	quotas := environs.Quotas{Instances: uint64p(0), Cores: uint64p(4), VolumeGiB: uint64p(10)}
+	err := quotas.Check(environs.QuotaUsage{Instances: 1, Cores: 2, VolumeGiB: 20})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded for instances \(1 required, 0 remaining\), volume GiB \(20 required, 10 remaining\)`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *quotasSuite) TestCheckInstanceQuotasNotQuotaChecker(c *gc.C) {
+	err := environs.CheckInstanceQuotas(context.NewCloudCallContext(), struct{}{}, constraints.Value{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *quotasSuite) TestCheckInstanceQuotasNoQuotas(c *gc.C) {
+	checker := &fakeQuotaChecker{Stub: &testing.Stub{}}
+	err := environs.CheckInstanceQuotas(context.NewCloudCallContext(), checker, constraints.Value{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	checker.CheckCallNames(c, "RemainingQuotas")
+}
+
+func (s *quotasSuite) TestCheckInstanceQuotasExceeded(c *gc.C) {
+	checker := &fakeQuotaChecker{
+		Stub:   &testing.Stub{},
+		quotas: environs.Quotas{Volumes: uint64p(1)},
+		usage:  environs.QuotaUsage{Instances: 1, Volumes: 2},
+	}
+	cons := constraints.MustParse("cores=2")
+	volumes := []storage.VolumeParams{{Size: 1024}}
+	err := environs.CheckInstanceQuotas(context.NewCloudCallContext(), checker, cons, volumes)
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded for volumes \(2 required, 1 remaining\)`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+	checker.CheckCallNames(c, "RemainingQuotas", "InstanceQuotaUsage")
+	checker.CheckCall(c, 1, "InstanceQuotaUsage", cons, volumes)
+}
+
+func (s *quotasSuite) TestCheckInstanceQuotasError(c *gc.C) {
+	checker := &fakeQuotaChecker{Stub: &testing.Stub{}}
+	checker.SetErrors(errors.New("boom"))
+	err := environs.CheckInstanceQuotas(context.NewCloudCallContext(), checker, constraints.Value{}, nil)
+	c.Assert(err, gc.ErrorMatches, "querying cloud quotas: boom")
+}
+
+type fakeQuotaChecker struct {
+	*testing.Stub
+
+	quotas environs.Quotas
+	usage  environs.QuotaUsage
+}
+
+func (f *fakeQuotaChecker) RemainingQuotas(ctx context.ProviderCallContext) (environs.Quotas, error) {
+	f.MethodCall(f, "RemainingQuotas")
+	return f.quotas, f.NextErr()
+}
+
+func (f *fakeQuotaChecker) InstanceQuotaUsage(
+	ctx context.ProviderCallContext, cons constraints.Value, volumes []storage.VolumeParams,
+) (environs.QuotaUsage, error) {
+	f.MethodCall(f, "InstanceQuotaUsage", cons, volumes)
+	return f.usage, f.NextErr()
+}
+
+func uint64p(v uint64) *uint64 {
+	return &v
+}
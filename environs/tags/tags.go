@@ -40,6 +40,11 @@ const (
 	// the model and machine id corresponding to the
 	// provisioned machine instance.
 	JujuMachine = JujuTagPrefix + "machine-id"
+
+	// JujuUserTags is the tag name used for recording the names of
+	// the user-specified tags that Juju has set on a resource. The
+	// value is a space-separated list of the tag names.
+	JujuUserTags = JujuTagPrefix + "user-tags"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
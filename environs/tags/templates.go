@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tags
+
+import (
+	"sort"
+	"strings"
+)
+
+// The placeholders that may be used in the values of user-specified
+// resource tags. They are replaced with the details of the resource
+// being tagged when the tags are applied.
+const (
+	// ModelPlaceholder is replaced with the name of the model.
+	ModelPlaceholder = "{model}"
+
+	// ModelOwnerPlaceholder is replaced with the name of the
+	// model's owner.
+	ModelOwnerPlaceholder = "{model-owner}"
+
+	// ApplicationPlaceholder is replaced with the name of the
+	// application that the resource belongs to. For a machine that
+	// hosts units of several applications, it is replaced with the
+	// space-separated list of the applications' names.
+	ApplicationPlaceholder = "{application}"
+
+	// UnitPlaceholder is replaced with the name of the unit that
+	// the resource belongs to. For a machine that hosts several
+	// units, it is replaced with the space-separated list of the
+	// units' names.
+	UnitPlaceholder = "{unit}"
+
+	// MachinePlaceholder is replaced with the ID of the machine
+	// that the resource belongs to.
+	MachinePlaceholder = "{machine}"
+)
+
+// TemplateVars holds the values that are substituted for the
+// placeholders in templated resource tag values. Placeholders
+// whose values are empty, because they do not apply to the
+// resource being tagged, are replaced with the empty string.
+type TemplateVars struct {
+	Model       string
+	ModelOwner  string
+	Application string
+	Unit        string
+	Machine     string
+}
+
+// ApplicationResourceTagger is a ResourceTagger that can also provide
+// the resource tags of individual applications, and the template
+// values that describe the model.
+type ApplicationResourceTagger interface {
+	ResourceTagger
+
+	// ApplicationResourceTags returns the resource tags of the
+	// named application.
+	ApplicationResourceTags(appName string) (map[string]string, error)
+
+	// TemplateVars returns the template values that describe
+	// the model.
+	TemplateVars() TemplateVars
+}
+
+// ExpandTemplates returns a copy of the given tags, with the
+// placeholders in the tag values replaced by the given values.
+func ExpandTemplates(tags map[string]string, vars TemplateVars) map[string]string {
+	replacer := strings.NewReplacer(
+		ModelPlaceholder, vars.Model,
+		ModelOwnerPlaceholder, vars.ModelOwner,
+		ApplicationPlaceholder, vars.Application,
+		UnitPlaceholder, vars.Unit,
+		MachinePlaceholder, vars.Machine,
+	)
+	expanded := make(map[string]string, len(tags))
+	for k, v := range tags {
+		expanded[k] = replacer.Replace(v)
+	}
+	return expanded
+}
+
+// UserResourceTags returns the tags in the given set that were
+// specified by the user, omitting the tags managed by Juju.
+func UserResourceTags(tags map[string]string) map[string]string {
+	userTags := make(map[string]string)
+	for k, v := range tags {
+		if !strings.HasPrefix(k, JujuTagPrefix) {
+			userTags[k] = v
+		}
+	}
+	return userTags
+}
+
+// UpdateUserTags returns the tags to set on a resource, which has the
+// existing tags, so that its user-specified tags are those in the given
+// tags, and the names of the existing tags to remove from it. Only the
+// user-specified tags that Juju set previously are removed, as recorded
+// in the JujuUserTags tag; tags set on the resource by other means are
+// left alone. The returned tags record the names of the user-specified
+// tags being set, so that they can be removed in turn.
+func UpdateUserTags(existing, tags map[string]string) (map[string]string, []string) {
+	update := make(map[string]string, len(tags)+1)
+	var userTagNames []string
+	for k, v := range tags {
+		update[k] = v
+		if !strings.HasPrefix(k, JujuTagPrefix) {
+			userTagNames = append(userTagNames, k)
+		}
+	}
+	sort.Strings(userTagNames)
+	update[JujuUserTags] = strings.Join(userTagNames, " ")
+
+	var remove []string
+	for _, k := range strings.Fields(existing[JujuUserTags]) {
+		if _, ok := update[k]; !ok {
+			remove = append(remove, k)
+		}
+	}
+	return update, remove
+}
@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tags_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/testing"
+)
+
+type templatesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&templatesSuite{})
+
+func (*templatesSuite) TestExpandTemplates(c *gc.C) {
+	in := map[string]string{
+		"owner":       "{model-owner}",
+		"name":        "{model}/{application}/{unit}",
+		"machine":     "machine-{machine}",
+		"cost-center": "1234",
+		"unknown":     "{unknown}",
+	}
+	out := tags.ExpandTemplates(in, tags.TemplateVars{
+		Model:       "finance",
+		ModelOwner:  "fred",
+		Application: "mysql",
+		Unit:        "mysql/0",
+		Machine:     "42",
+	})
+	c.Assert(out, jc.DeepEquals, map[string]string{
+		"owner":       "fred",
+		"name":        "finance/mysql/mysql/0",
+		"machine":     "machine-42",
+		"cost-center": "1234",
+		"unknown":     "{unknown}",
+	})
+	// The input is not modified.
+	c.Assert(in["owner"], gc.Equals, "{model-owner}")
+}
+
+func (*templatesSuite) TestExpandTemplatesEmptyVars(c *gc.C) {
+	out := tags.ExpandTemplates(map[string]string{
+		"app":   "{application}",
+		"owner": "owned-by-{model-owner}",
+	}, tags.TemplateVars{})
+	c.Assert(out, jc.DeepEquals, map[string]string{
+		"app":   "",
+		"owner": "owned-by-",
+	})
+}
+
+func (*templatesSuite) TestUserResourceTags(c *gc.C) {
+	out := tags.UserResourceTags(map[string]string{
+		tags.JujuModel:      "model-uuid",
+		tags.JujuController: "controller-uuid",
+		"owner":             "fred",
+	})
+	c.Assert(out, jc.DeepEquals, map[string]string{"owner": "fred"})
+}
+
+func (*templatesSuite) TestUpdateUserTags(c *gc.C) {
+	existing := map[string]string{
+		tags.JujuModel:    "model-uuid",
+		tags.JujuUserTags: "cost-center owner",
+		"cost-center":     "1234",
+		"owner":           "fred",
+		"Name":            "juju-machine-0",
+	}
+	update, remove := tags.UpdateUserTags(existing, map[string]string{
+		tags.JujuModel: "model-uuid",
+		"owner":        "mary",
+		"team":         "finance",
+	})
+	c.Assert(update, jc.DeepEquals, map[string]string{
+		tags.JujuModel:    "model-uuid",
+		tags.JujuUserTags: "owner team",
+		"owner":           "mary",
+		"team":            "finance",
+	})
+	// Only the user-specified tags set by Juju are removed.
+	c.Assert(remove, jc.DeepEquals, []string{"cost-center"})
+}
+
+func (*templatesSuite) TestUpdateUserTagsNoneRecorded(c *gc.C) {
+	update, remove := tags.UpdateUserTags(map[string]string{"owner": "fred"}, nil)
+	c.Assert(update, jc.DeepEquals, map[string]string{tags.JujuUserTags: ""})
+	c.Assert(remove, gc.HasLen, 0)
+}
@@ -191,6 +191,45 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-issuer:
+    description: the cert-manager cluster issuer for the ingress TLS certificate
+    source: unset
+    type: string
+  kubernetes-ingress-tls-secret:
+    description: the name of the k8s TLS secret holding the ingress certificate
+      and key
+    source: unset
+    type: string
+  kubernetes-operator-cpu-limit:
+    description: the CPU limit of the operator pod, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-cpu-request:
+    description: the CPU requested by the operator pod, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-memory-limit:
+    description: the memory limit of the operator pod, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-memory-request:
+    description: the memory requested by the operator pod, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-node-selector:
+    description: comma separated key=value labels of the nodes on which the operator
+      pod may be scheduled, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-priority-class:
+    description: the priority class of the operator pod, overriding the model config
+    source: unset
+    type: string
+  kubernetes-operator-tolerations:
+    description: comma separated node taints tolerated by the operator pod, overriding
+      the model config
+    source: unset
+    type: string
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The pricetables command refreshes a provider's bundled price table
+// from the cloud's public price list. The regions recorded in the
+// existing table are refreshed, unless regions are specified with the
+// -regions flag. Once the table has been refreshed, run go generate in
+// the provider's package to update the compiled-in copy.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/pricing"
+)
+
+const (
+	awsOfferURL  = "https://pricing.us-east-1.amazonaws.com/offers/v1.0/aws/AmazonEC2/current/%s/index.json"
+	gcePricesURL = "https://cloudpricingcalculator.appspot.com/static/data/pricelist.json"
+)
+
+var fetchers = map[string]func(regions []string) (*pricing.Table, error){
+	"ec2": fetchEC2Prices,
+	"gce": fetchGCEPrices,
+}
+
+var headers = map[string]string{
+	"ec2": "On-demand Linux prices for EC2 instance types and EBS volume types.",
+	"gce": "On-demand Linux prices for GCE machine types and persistent disk types.",
+}
+
+func main() {
+	regionsFlag := flag.String("regions", "", "comma separated list of regions to fetch prices for")
+	flag.Parse()
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: pricetables [-regions r1,r2] <provider> <yamlfile>")
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), flag.Arg(1), *regionsFlag); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(provider, path, regionsFlag string) error {
+	fetch, ok := fetchers[provider]
+	if !ok {
+		return errors.NotSupportedf("price tables for provider %q", provider)
+	}
+	var regions []string
+	if regionsFlag != "" {
+		regions = strings.Split(regionsFlag, ",")
+	} else {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Annotate(err, "reading existing price table; specify -regions to create a new one")
+		}
+		existing, err := pricing.Parse(data)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for region := range existing.Regions {
+			regions = append(regions, region)
+		}
+		sort.Strings(regions)
+	}
+
+	table, err := fetch(regions)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := table.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	header := fmt.Sprintf(""+
+		"# %s\n"+
+		"# Instance type prices are per hour, volume type prices are per GiB-month.\n"+
+		"# Regenerate with: go run github.com/juju/juju/generate/pricetables %s prices.yaml\n",
+		headers[provider], provider,
+	)
+	return errors.Trace(ioutil.WriteFile(path, append([]byte(header), data...), 0644))
+}
+
+func getJSON(url string, v interface{}) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("fetching %s: %s", url, resp.Status)
+	}
+	return errors.Annotatef(json.NewDecoder(resp.Body).Decode(v), "decoding %s", url)
+}
+
+// awsOffer holds the parts of an AWS price list offer file that are
+// needed to price instance types and EBS volumes.
+type awsOffer struct {
+	Products map[string]struct {
+		ProductFamily string            `json:"productFamily"`
+		Attributes    map[string]string `json:"attributes"`
+	} `json:"products"`
+	Terms struct {
+		OnDemand map[string]map[string]struct {
+			PriceDimensions map[string]struct {
+				Unit         string            `json:"unit"`
+				PricePerUnit map[string]string `json:"pricePerUnit"`
+			} `json:"priceDimensions"`
+		} `json:"OnDemand"`
+	} `json:"terms"`
+}
+
+// onDemandPrice returns the on-demand USD price of the product with
+// the specified SKU, if it is charged in the specified unit.
+func (o *awsOffer) onDemandPrice(sku, unit string) (float64, bool) {
+	for _, term := range o.Terms.OnDemand[sku] {
+		for _, dimension := range term.PriceDimensions {
+			if dimension.Unit != unit {
+				continue
+			}
+			price, err := strconv.ParseFloat(dimension.PricePerUnit["USD"], 64)
+			if err != nil || price == 0 {
+				continue
+			}
+			return price, true
+		}
+	}
+	return 0, false
+}
+
+var ebsVolumeTypes = map[string]bool{
+	"standard": true,
+	"gp2":      true,
+	"io1":      true,
+	"st1":      true,
+	"sc1":      true,
+}
+
+func fetchEC2Prices(regions []string) (*pricing.Table, error) {
+	table := &pricing.Table{
+		Currency: "USD",
+		Regions:  make(map[string]pricing.RegionPrices),
+	}
+	for _, region := range regions {
+		var offer awsOffer
+		if err := getJSON(fmt.Sprintf(awsOfferURL, region), &offer); err != nil {
+			return nil, errors.Trace(err)
+		}
+		prices := pricing.RegionPrices{
+			InstanceTypes: make(map[string]float64),
+			VolumeTypes:   make(map[string]float64),
+		}
+		for sku, product := range offer.Products {
+			attrs := product.Attributes
+			switch product.ProductFamily {
+			case "Compute Instance":
+				if attrs["operatingSystem"] != "Linux" ||
+					attrs["tenancy"] != "Shared" ||
+					attrs["preInstalledSw"] != "NA" ||
+					attrs["capacitystatus"] != "Used" {
+					continue
+				}
+				if price, ok := offer.onDemandPrice(sku, "Hrs"); ok {
+					prices.InstanceTypes[attrs["instanceType"]] = price
+				}
+			case "Storage":
+				volumeType := attrs["volumeApiName"]
+				if !ebsVolumeTypes[volumeType] {
+					continue
+				}
+				if price, ok := offer.onDemandPrice(sku, "GB-Mo"); ok {
+					prices.VolumeTypes[volumeType] = price
+				}
+			}
+		}
+		table.Regions[region] = prices
+	}
+	return table, nil
+}
+
+const gceMachineTypePrefix = "CP-COMPUTEENGINE-VMIMAGE-"
+
+var gceDiskTypes = map[string]string{
+	"CP-COMPUTEENGINE-STORAGE-PD-CAPACITY": "pd-standard",
+	"CP-COMPUTEENGINE-STORAGE-PD-SSD":      "pd-ssd",
+	"CP-COMPUTEENGINE-LOCAL-SSD":           "local-ssd",
+}
+
+func fetchGCEPrices(regions []string) (*pricing.Table, error) {
+	var priceList struct {
+		Prices map[string]json.RawMessage `json:"gcp_price_list"`
+	}
+	if err := getJSON(gcePricesURL, &priceList); err != nil {
+		return nil, errors.Trace(err)
+	}
+	table := &pricing.Table{
+		Currency: "USD",
+		Regions:  make(map[string]pricing.RegionPrices),
+	}
+	for _, region := range regions {
+		table.Regions[region] = pricing.RegionPrices{
+			InstanceTypes: make(map[string]float64),
+			VolumeTypes:   make(map[string]float64),
+		}
+	}
+	for key, raw := range priceList.Prices {
+		var prices map[string]interface{}
+		if err := json.Unmarshal(raw, &prices); err != nil {
+			// Not all entries are prices keyed by region.
+			continue
+		}
+		machineType := ""
+		diskType := gceDiskTypes[key]
+		if strings.HasPrefix(key, gceMachineTypePrefix) && !strings.HasSuffix(key, "-PREEMPTIBLE") {
+			machineType = strings.ToLower(strings.TrimPrefix(key, gceMachineTypePrefix))
+		}
+		if machineType == "" && diskType == "" {
+			continue
+		}
+		for _, region := range regions {
+			price, ok := prices[region].(float64)
+			if !ok || price == 0 {
+				continue
+			}
+			if machineType != "" {
+				table.Regions[region].InstanceTypes[machineType] = price
+			} else {
+				table.Regions[region].VolumeTypes[diskType] = price
+			}
+		}
+	}
+	return table, nil
+}
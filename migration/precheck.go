@@ -53,6 +53,7 @@ type PrecheckModel interface {
 	Life() state.Life
 	MigrationMode() state.MigrationMode
 	CloudCredentialTag() (names.CloudCredentialTag, bool)
+	AllActionSchedules() ([]*state.ActionSchedule, error)
 }
 
 // PrecheckMachine describes the state interface for a machine needed
@@ -74,6 +75,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	Backups() ([]state.ApplicationBackup, error)
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -179,6 +181,15 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.New("model has revoked credentials")
 		}
 	}
+	// Action schedules are not exported with the model,
+	// so they would be lost.
+	schedules, err := model.AllActionSchedules()
+	if err != nil {
+		return errors.Annotate(err, "retrieving action schedules")
+	}
+	if len(schedules) > 0 {
+		return errors.Errorf("model has %d action schedules, which cannot be migrated", len(schedules))
+	}
 	return nil
 }
 
@@ -345,6 +356,15 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// The model description has no place for the records
+		// of application backups, so they would be lost.
+		backups, err := app.Backups()
+		if err != nil {
+			return nil, errors.Annotatef(err, "retrieving backups for %s", app.Name())
+		}
+		if len(backups) > 0 {
+			return nil, errors.Errorf("application %s has storage backups, which cannot be migrated", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
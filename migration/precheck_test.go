@@ -171,6 +171,26 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestModelWithActionSchedules(c *gc.C) {
+	backend := newHappyBackend()
+	backend.model.schedules = []*state.ActionSchedule{{}}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "model has 1 action schedules, which cannot be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationWithBackups(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:    "foo",
+				backups: []state.ApplicationBackup{{Id: "20200102-030405-x7k2", Application: "foo"}},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has storage backups, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -832,6 +852,7 @@ type fakeModel struct {
 	modelType     state.ModelType
 	migrationMode state.MigrationMode
 	credential    string
+	schedules     []*state.ActionSchedule
 }
 
 func (m *fakeModel) Type() state.ModelType {
@@ -865,6 +886,10 @@ func (m *fakeModel) CloudCredentialTag() (names.CloudCredentialTag, bool) {
 	return names.CloudCredentialTag{}, false
 }
 
+func (m *fakeModel) AllActionSchedules() ([]*state.ActionSchedule, error) {
+	return m.schedules, nil
+}
+
 type fakeMachine struct {
 	id             string
 	version        version.Binary
@@ -925,6 +950,7 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	backups  []state.ApplicationBackup
 }
 
 func (a *fakeApp) Name() string {
@@ -951,6 +977,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) Backups() ([]state.ApplicationBackup, error) {
+	return a.backups, nil
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
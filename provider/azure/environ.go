@@ -74,6 +74,9 @@ const (
 
 	computeAPIVersion = "2018-10-01"
 	networkAPIVersion = "2018-08-01"
+	// spotComputeAPIVersion is the compute API version used for spot
+	// virtual machines, which are not supported by computeAPIVersion.
+	spotComputeAPIVersion = "2019-07-01"
 	// Note: do not upgrade this storage API anymore because Juju uses managed storage since 2.3 and this API is only used
 	// for models upgraded from 2.2.
 	// Upgrading the storage API may break those upgraded old models.
@@ -258,11 +261,7 @@ func (env *azureEnviron) initResourceGroup(ctx context.ProviderCallContext, cont
 	resourceGroupsClient := resources.GroupsClient{env.resources}
 
 	env.mu.Lock()
-	tags := tags.ResourceTags(
-		names.NewModelTag(env.config.Config.UUID()),
-		names.NewControllerTag(controllerUUID),
-		env.config,
-	)
+	tags := env.modelResourceTagsLocked(controllerUUID)
 	env.mu.Unlock()
 
 	logger.Debugf("creating resource group %q", env.resourceGroup)
@@ -440,11 +439,7 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 	// required to create the instance. We take the lock just once, to
 	// ensure we obtain all information based on the same configuration.
 	env.mu.Lock()
-	envTags := tags.ResourceTags(
-		names.NewModelTag(env.config.Config.UUID()),
-		names.NewControllerTag(args.ControllerUUID),
-		env.config,
-	)
+	envTags := env.modelResourceTagsLocked(args.ControllerUUID)
 	storageAccountType := env.config.storageAccountType
 	imageStream := env.config.ImageStream()
 	instanceTypes, err := env.getInstanceTypesLocked(ctx)
@@ -537,7 +532,7 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 	if err := env.createVirtualMachine(
 		ctx, vmName, vmTags, envTags,
 		instanceSpec, args.InstanceConfig,
-		storageAccountType,
+		storageAccountType, args.Constraints.IsSpotInstance(),
 	); err != nil {
 		logger.Errorf("creating instance failed, destroying: %v", err)
 		if err := env.StopInstances(ctx, instance.Id(vmName)); err != nil {
@@ -574,6 +569,7 @@ func (env *azureEnviron) createVirtualMachine(
 	instanceSpec *instances.InstanceSpec,
 	instanceConfig *instancecfg.InstanceConfig,
 	storageAccountType string,
+	spot bool,
 ) error {
 	deploymentsClient := resources.DeploymentsClient{
 		BaseClient: env.resources,
@@ -761,26 +757,34 @@ func (env *azureEnviron) createVirtualMachine(
 		},
 	}}
 	vmDependsOn = append(vmDependsOn, nicId)
+	vmAPIVersion := computeAPIVersion
+	var vmProperties interface{} = &compute.VirtualMachineProperties{
+		HardwareProfile: &compute.HardwareProfile{
+			VMSize: compute.VirtualMachineSizeTypes(
+				instanceSpec.InstanceType.Name,
+			),
+		},
+		StorageProfile: storageProfile,
+		OsProfile:      osProfile,
+		NetworkProfile: &compute.NetworkProfile{
+			&nics,
+		},
+		AvailabilitySet: availabilitySetSubResource,
+	}
+	if spot {
+		vmAPIVersion = spotComputeAPIVersion
+		vmProperties = newSpotVirtualMachineProperties(
+			vmProperties.(*compute.VirtualMachineProperties),
+		)
+	}
 	resources = append(resources, armtemplates.Resource{
-		APIVersion: computeAPIVersion,
+		APIVersion: vmAPIVersion,
 		Type:       "Microsoft.Compute/virtualMachines",
 		Name:       vmName,
 		Location:   env.location,
 		Tags:       vmTags,
-		Properties: &compute.VirtualMachineProperties{
-			HardwareProfile: &compute.HardwareProfile{
-				VMSize: compute.VirtualMachineSizeTypes(
-					instanceSpec.InstanceType.Name,
-				),
-			},
-			StorageProfile: storageProfile,
-			OsProfile:      osProfile,
-			NetworkProfile: &compute.NetworkProfile{
-				&nics,
-			},
-			AvailabilitySet: availabilitySetSubResource,
-		},
-		DependsOn: vmDependsOn,
+		Properties: vmProperties,
+		DependsOn:  vmDependsOn,
 	})
 
 	// On Windows and CentOS, we must add the CustomScript VM
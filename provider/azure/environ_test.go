@@ -616,6 +616,24 @@ func (s *environSuite) assertStartInstance(c *gc.C, wantedRootDisk *int) {
 	})
 }
 
+func (s *environSuite) TestStartInstanceSpot(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = s.startInstanceSenders(false)
+	s.requests = nil
+	args := makeStartInstanceParams(c, s.controllerUUID, "bionic")
+	args.Constraints = constraints.MustParse("instance-lifecycle=spot")
+	_, err := env.StartInstance(s.callCtx, args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertStartInstanceRequests(c, s.requests, assertStartInstanceRequestsParams{
+		imageReference: &xenialImageReference,
+		diskSizeGB:     32,
+		osProfile:      &s.linuxOsProfile,
+		instanceType:   "Standard_A1",
+		spot:           true,
+	})
+}
+
 func (s *environSuite) TestStartInstanceNoAuthorizedKeys(c *gc.C) {
 	env := s.openEnviron(c)
 	cfg, err := env.Config().Remove([]string{"authorized-keys"})
@@ -889,6 +907,7 @@ type assertStartInstanceRequestsParams struct {
 	needsProviderInit   bool
 	unmanagedStorage    bool
 	instanceType        string
+	spot                bool
 }
 
 func (s *environSuite) assertStartInstanceRequests(
@@ -1144,6 +1163,19 @@ func (s *environSuite) assertStartInstanceRequests(
 		},
 		DependsOn: append(vmDependsOn, nicId),
 	}}...)
+	if args.spot {
+		vm := &templateResources[len(templateResources)-1]
+		vm.APIVersion = "2019-07-01"
+		vm.Properties = map[string]interface{}{
+			"hardwareProfile": vm.Properties.(*compute.VirtualMachineProperties).HardwareProfile,
+			"storageProfile":  vm.Properties.(*compute.VirtualMachineProperties).StorageProfile,
+			"osProfile":       vm.Properties.(*compute.VirtualMachineProperties).OsProfile,
+			"networkProfile":  vm.Properties.(*compute.VirtualMachineProperties).NetworkProfile,
+			"priority":        "Spot",
+			"evictionPolicy":  "Delete",
+			"billingProfile":  map[string]interface{}{"maxPrice": -1},
+		}
+	}
 	if args.vmExtension != nil {
 		templateResources = append(templateResources, armtemplates.Resource{
 			APIVersion: computeAPIVersion,
@@ -1950,6 +1982,79 @@ func makeResourceGroupResult() resources.Group {
 	}
 }
 
+func (s *environSuite) TestTagInstance(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.makeSender(".*/virtualMachines/juju-06f00d-0", &compute.VirtualMachine{
+			Tags: map[string]*string{
+				"juju-machine-name": to.StringPtr("juju-06f00d-0"),
+			},
+		}),
+		s.makeSender(".*/virtualMachines/juju-06f00d-0", &compute.VirtualMachine{}),
+	}
+	s.requests = nil
+	err := env.(environs.InstanceTagger).TagInstance(s.callCtx, "juju-06f00d-0", map[string]string{
+		"cost-center": "1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[1].Method, gc.Equals, "PATCH")
+	assertRequestBody(c, s.requests[1], &compute.VirtualMachineUpdate{
+		Tags: map[string]*string{
+			"juju-machine-name": to.StringPtr("juju-06f00d-0"),
+			"juju-user-tags":    to.StringPtr("cost-center"),
+			"cost-center":       to.StringPtr("1234"),
+		},
+	})
+}
+
+func (s *environSuite) TestTagInstanceRemovesDroppedTagsAndTagsSecurityGroup(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{
+		s.makeSender(".*/virtualMachines/juju-06f00d-0", &compute.VirtualMachine{
+			Tags: map[string]*string{
+				"juju-machine-name": to.StringPtr("juju-06f00d-0"),
+				"juju-user-tags":    to.StringPtr("cost-center owner"),
+				"cost-center":       to.StringPtr("1234"),
+				"owner":             to.StringPtr("fred"),
+				"other":             to.StringPtr("kept"),
+			},
+		}),
+		s.makeSender(".*/virtualMachines/juju-06f00d-0", &compute.VirtualMachine{}),
+		s.makeSender(".*/networkSecurityGroups/juju-internal-nsg", &network.SecurityGroup{}),
+		s.makeSender(".*/networkSecurityGroups/juju-internal-nsg", &network.SecurityGroup{}),
+	}
+	s.requests = nil
+	err := env.(environs.InstanceTagger).TagInstance(s.callCtx, "juju-06f00d-0", map[string]string{
+		tags.JujuController: testing.ControllerTag.Id(),
+		"owner":             "mary",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 4)
+	c.Assert(s.requests[1].Method, gc.Equals, "PATCH")
+	assertRequestBody(c, s.requests[1], &compute.VirtualMachineUpdate{
+		Tags: map[string]*string{
+			"juju-machine-name": to.StringPtr("juju-06f00d-0"),
+			"juju-user-tags":    to.StringPtr("owner"),
+			tags.JujuController: to.StringPtr(testing.ControllerTag.Id()),
+			"owner":             to.StringPtr("mary"),
+			"other":             to.StringPtr("kept"),
+		},
+	})
+	c.Assert(s.requests[2].Method, gc.Equals, "GET")
+	c.Assert(s.requests[3].Method, gc.Equals, "PATCH")
+	assertRequestBody(c, s.requests[3], &network.TagsObject{
+		Tags: map[string]*string{
+			"juju-user-tags":    to.StringPtr(""),
+			tags.JujuController: to.StringPtr(testing.ControllerTag.Id()),
+			tags.JujuModel:      to.StringPtr(testing.ModelTag.Id()),
+		},
+	})
+}
+
 func (s *environSuite) TestAdoptResourcesErrorGettingGroup(c *gc.C) {
 	env := s.openEnviron(c)
 	sender := s.makeErrorSender(
@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+)
+
+const (
+	// spotPriority is the virtual machine priority used to request
+	// Azure spot capacity.
+	spotPriority = "Spot"
+
+	// spotEvictionPolicyDelete causes evicted spot virtual machines to
+	// be deleted, so that Juju may start a replacement.
+	spotEvictionPolicyDelete = "Delete"
+
+	// spotMaxPriceOnDemand indicates that the spot virtual machine should
+	// not be evicted for price reasons, paying up to the on-demand price.
+	spotMaxPriceOnDemand = -1
+)
+
+// spotVirtualMachineProperties extends the virtual machine properties of
+// the compute API version used by the provider with those required to
+// request a spot virtual machine, which were added in spotComputeAPIVersion.
+type spotVirtualMachineProperties struct {
+	*compute.VirtualMachineProperties
+	Priority       string              `json:"priority"`
+	EvictionPolicy string              `json:"evictionPolicy"`
+	BillingProfile *spotBillingProfile `json:"billingProfile,omitempty"`
+}
+
+type spotBillingProfile struct {
+	MaxPrice float64 `json:"maxPrice"`
+}
+
+func newSpotVirtualMachineProperties(props *compute.VirtualMachineProperties) *spotVirtualMachineProperties {
+	return &spotVirtualMachineProperties{
+		VirtualMachineProperties: props,
+		Priority:                 spotPriority,
+		EvictionPolicy:           spotEvictionPolicyDelete,
+		BillingProfile:           &spotBillingProfile{MaxPrice: spotMaxPriceOnDemand},
+	}
+}
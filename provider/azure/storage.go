@@ -533,6 +533,28 @@ func (v *azureVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volu
 	return nil, errors.NotSupportedf("ReleaseVolumes")
 }
 
+// TagVolume is specified on the storage.VolumeTagger interface.
+// Only managed disks can be tagged; unmanaged disks are blobs in
+// the model's storage account, which do not support tags.
+func (v *azureVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, resourceTags map[string]string) error {
+	if v.maybeStorageClient != nil {
+		return errors.NotSupportedf("tagging unmanaged disks")
+	}
+	diskClient := compute.DisksClient{v.env.disk}
+	sdkCtx := stdcontext.Background()
+	disk, err := diskClient.Get(sdkCtx, v.env.resourceGroup, volumeId)
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting disk %q", volumeId), ctx)
+	}
+	future, err := diskClient.Update(sdkCtx, v.env.resourceGroup, volumeId, compute.DiskUpdate{
+		Tags: updateTags(disk.Tags, resourceTags),
+	})
+	if err == nil {
+		err = future.WaitForCompletionRef(sdkCtx, diskClient.Client)
+	}
+	return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging disk %q", volumeId), ctx)
+}
+
 // ValidateVolumeParams is specified on the storage.VolumeSource interface.
 func (v *azureVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	if mibToGib(params.Size) > volumeSizeMaxGiB {
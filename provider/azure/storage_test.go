@@ -764,6 +764,43 @@ func (s *storageSuite) TestDestroyVolumesLegacy(c *gc.C) {
 	blob1.CheckCallNames(c, "DeleteIfExists")
 }
 
+func (s *storageSuite) TestTagVolume(c *gc.C) {
+	volumeSource := s.volumeSource(c, false)
+	c.Assert(volumeSource, gc.Implements, new(storage.VolumeTagger))
+	getSender := azuretesting.NewSenderWithValue(&compute.Disk{
+		Tags: map[string]*string{
+			"foo": to.StringPtr("bar"),
+		},
+	})
+	getSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	updateSender := azuretesting.NewSenderWithValue(&compute.Disk{})
+	updateSender.PathPattern = `.*/Microsoft\.Compute/disks/volume-0`
+	s.sender = azuretesting.Senders{getSender, updateSender}
+	s.requests = nil
+
+	err := volumeSource.(storage.VolumeTagger).TagVolume(s.cloudCallCtx, "volume-0", map[string]string{
+		"cost-center": "1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 2)
+	c.Assert(s.requests[0].Method, gc.Equals, "GET")
+	c.Assert(s.requests[1].Method, gc.Equals, "PATCH")
+	assertRequestBody(c, s.requests[1], &compute.DiskUpdate{
+		Tags: map[string]*string{
+			"foo":            to.StringPtr("bar"),
+			"juju-user-tags": to.StringPtr("cost-center"),
+			"cost-center":    to.StringPtr("1234"),
+		},
+	})
+}
+
+func (s *storageSuite) TestTagVolumeLegacy(c *gc.C) {
+	volumeSource := s.volumeSource(c, true)
+	err := volumeSource.(storage.VolumeTagger).TagVolume(s.cloudCallCtx, "volume-0", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *storageSuite) TestAttachVolumes(c *gc.C) {
 	s.testAttachVolumes(c, false)
 }
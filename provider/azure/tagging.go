@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package azure
+
+import (
+	stdcontext "context"
+
+	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2018-08-01/network"
+	"github.com/Azure/go-autorest/autorest/to"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/provider/azure/internal/errorutils"
+)
+
+var _ environs.InstanceTagger = (*azureEnviron)(nil)
+
+// TagInstance is part of the environs.InstanceTagger interface.
+//
+// As well as the virtual machine, the model's network security group
+// is tagged, with the model's resource tags. User-specified tags that
+// were set previously but are no longer specified are removed.
+func (env *azureEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, instanceTags map[string]string) error {
+	vmClient := compute.VirtualMachinesClient{env.compute}
+	vmName := string(id)
+	sdkCtx := stdcontext.Background()
+	vm, err := vmClient.Get(sdkCtx, env.resourceGroup, vmName, "")
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "getting virtual machine %q", vmName), ctx)
+	}
+	future, err := vmClient.Update(sdkCtx, env.resourceGroup, vmName, compute.VirtualMachineUpdate{
+		Tags: updateTags(vm.Tags, instanceTags),
+	})
+	if err == nil {
+		err = future.WaitForCompletionRef(sdkCtx, vmClient.Client)
+	}
+	if err != nil {
+		return errorutils.HandleCredentialError(errors.Annotatef(err, "tagging virtual machine %q", vmName), ctx)
+	}
+
+	controllerUUID := instanceTags[tags.JujuController]
+	if controllerUUID == "" {
+		return nil
+	}
+	env.mu.Lock()
+	modelTags := env.modelResourceTagsLocked(controllerUUID)
+	env.mu.Unlock()
+	return errors.Trace(env.tagSecurityGroup(ctx, modelTags))
+}
+
+// tagSecurityGroup tags the model's network security group, which is
+// shared by all of the model's machines, with the given tags.
+func (env *azureEnviron) tagSecurityGroup(ctx context.ProviderCallContext, groupTags map[string]string) error {
+	nsgClient := network.SecurityGroupsClient{env.network}
+	sdkCtx := stdcontext.Background()
+	nsg, err := nsgClient.Get(sdkCtx, env.resourceGroup, internalSecurityGroupName, "")
+	if err != nil {
+		if isNotFoundResult(nsg.Response) {
+			return nil
+		}
+		return errorutils.HandleCredentialError(errors.Annotate(err, "getting network security group"), ctx)
+	}
+	future, err := nsgClient.UpdateTags(sdkCtx, env.resourceGroup, internalSecurityGroupName, network.TagsObject{
+		Tags: updateTags(nsg.Tags, groupTags),
+	})
+	if err == nil {
+		err = future.WaitForCompletionRef(sdkCtx, nsgClient.Client)
+	}
+	return errorutils.HandleCredentialError(errors.Annotate(err, "tagging network security group"), ctx)
+}
+
+// updateTags returns the given Azure resource tags, updated with the
+// specified tags. User-specified tags previously set by Juju that are
+// not in the specified tags are removed; other existing tags are kept.
+func updateTags(existing map[string]*string, tagsToSet map[string]string) map[string]*string {
+	existingTags := make(map[string]string, len(existing))
+	for k, v := range existing {
+		existingTags[k] = to.String(v)
+	}
+	update, remove := tags.UpdateUserTags(existingTags, tagsToSet)
+	updated := make(map[string]*string, len(existing)+len(update))
+	for k, v := range existing {
+		updated[k] = v
+	}
+	for _, k := range remove {
+		delete(updated, k)
+	}
+	for k, v := range update {
+		updated[k] = to.StringPtr(v)
+	}
+	return updated
+}
+
+// modelResourceTagsLocked returns the tags to set on resources that are
+// shared by the model's machines, with any templated values expanded.
+// The caller must hold env.mu.
+func (env *azureEnviron) modelResourceTagsLocked(controllerUUID string) map[string]string {
+	cfg := env.config.Config
+	return tags.ExpandTemplates(
+		tags.ResourceTags(
+			names.NewModelTag(cfg.UUID()),
+			names.NewControllerTag(controllerUUID),
+			env.config,
+		),
+		tags.TemplateVars{Model: cfg.Name()},
+	)
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/pricing"
+	"github.com/juju/juju/storage"
+)
+
+//go:generate go run github.com/juju/juju/generate/filetoconst priceTableYAML prices.yaml prices_table.go 2020 ec2
+
+var _ environs.CostEstimator = (*environ)(nil)
+
+// priceTable holds the EC2 prices bundled with Juju.
+var priceTable = mustParsePriceTable(priceTableYAML)
+
+func mustParsePriceTable(data string) *pricing.Table {
+	table, err := pricing.Parse([]byte(data))
+	if err != nil {
+		panic(errors.Annotate(err, "parsing EC2 price table"))
+	}
+	return table
+}
+
+// InstanceTypeCost is part of the environs.CostEstimator interface.
+//
+// The on-demand price of running Linux on the instance type is
+// returned; spot instances are typically cheaper.
+func (e *environ) InstanceTypeCost(ctx context.ProviderCallContext, instanceType string) (environs.Price, error) {
+	price, err := priceTable.InstanceTypePrice(e.cloud.Region, instanceType)
+	return price, errors.Trace(err)
+}
+
+// VolumeCost is part of the environs.CostEstimator interface.
+//
+// Only EBS volumes are billed. The price of the provisioned IOPS of
+// io1 volumes is not included.
+func (e *environ) VolumeCost(ctx context.ProviderCallContext, volume storage.VolumeParams) (environs.Price, error) {
+	if volume.Provider != EBS_ProviderType {
+		return environs.Price{}, nil
+	}
+	ebsConfig, err := newEbsConfig(volume.Attributes)
+	if err != nil {
+		return environs.Price{}, errors.Trace(err)
+	}
+	price, err := priceTable.VolumePrice(e.cloud.Region, ebsConfig.volumeType, mibToGib(volume.Size))
+	return price, errors.Trace(err)
+}
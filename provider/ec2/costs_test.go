@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+type costsSuite struct {
+	testing.IsolationSuite
+
+	env *environ
+}
+
+var _ = gc.Suite(&costsSuite{})
+
+func (s *costsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.env = &environ{cloud: environs.CloudSpec{Region: "us-east-1"}}
+}
+
+func (s *costsSuite) TestPriceTableRegions(c *gc.C) {
+	for region, prices := range priceTable.Regions {
+		c.Check(prices.InstanceTypes, gc.Not(gc.HasLen), 0, gc.Commentf("region %q", region))
+		for _, volumeType := range []string{volumeTypeStandard, volumeTypeGP2, volumeTypeIO1, volumeTypeST1, volumeTypeSC1} {
+			_, ok := prices.VolumeTypes[volumeType]
+			c.Check(ok, jc.IsTrue, gc.Commentf("region %q volume type %q", region, volumeType))
+		}
+	}
+}
+
+func (s *costsSuite) TestInstanceTypeCost(c *gc.C) {
+	price, err := s.env.InstanceTypeCost(context.NewCloudCallContext(), "m5.large")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 0.096, Currency: "USD"})
+}
+
+func (s *costsSuite) TestInstanceTypeCostUnknown(c *gc.C) {
+	_, err := s.env.InstanceTypeCost(context.NewCloudCallContext(), "x1.32xlarge")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *costsSuite) TestVolumeCost(c *gc.C) {
+	for _, t := range []struct {
+		attrs map[string]interface{}
+		price float64
+	}{
+		{nil, 10},
+		{map[string]interface{}{EBS_VolumeType: volumeAliasMagnetic}, 5},
+		{map[string]interface{}{EBS_VolumeType: volumeAliasColdStorage}, 2.5},
+		{map[string]interface{}{EBS_VolumeType: volumeTypeIO1, EBS_IOPS: 10}, 12.5},
+	} {
+		price, err := s.env.VolumeCost(context.NewCloudCallContext(), storage.VolumeParams{
+			Provider:   EBS_ProviderType,
+			Size:       100 * 1024,
+			Attributes: t.attrs,
+		})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(price, jc.DeepEquals, environs.Price{Amount: t.price, Currency: "USD"}, gc.Commentf("%v", t.attrs))
+	}
+}
+
+func (s *costsSuite) TestVolumeCostNotEBS(c *gc.C) {
+	price, err := s.env.VolumeCost(context.NewCloudCallContext(), storage.VolumeParams{
+		Provider: "loop",
+		Size:     100 * 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{})
+}
+
+func (s *costsSuite) TestVolumeCostInvalidConfig(c *gc.C) {
+	_, err := s.env.VolumeCost(context.NewCloudCallContext(), storage.VolumeParams{
+		Provider:   EBS_ProviderType,
+		Size:       1024,
+		Attributes: map[string]interface{}{EBS_VolumeType: "floppy"},
+	})
+	c.Assert(err, gc.ErrorMatches, "validating EBS storage config: .*")
+}
@@ -286,6 +286,7 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeTagger = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -980,6 +981,26 @@ func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId
 	}, nil
 }
 
+// TagVolume is specified on the storage.VolumeTagger interface.
+//
+// User-specified tags that are no longer specified are cleared by
+// setting their values to the empty string, as the EC2 API client
+// does not support deleting tags.
+func (v *ebsVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, resourceTags map[string]string) error {
+	resp, err := v.env.ec2.Volumes([]string{volumeId}, nil)
+	if err != nil {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "fetching volume %q", volumeId)
+	}
+	if len(resp.Volumes) == 0 {
+		return errors.NotFoundf("volume %q", volumeId)
+	}
+	update, remove := tags.UpdateUserTags(ec2TagsMap(resp.Volumes[0].Tags), resourceTags)
+	return errors.Annotate(
+		tagResources(v.env.ec2, ctx, clearTags(update, remove), volumeId),
+		"tagging volume",
+	)
+}
+
 var errTooManyVolumes = errors.New("too many EBS volumes to attach")
 
 // blockDeviceNamer returns a function that cycles through block device names.
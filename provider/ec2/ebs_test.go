@@ -1039,6 +1039,50 @@ func (s *ebsSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *ebsSuite) TestTagVolume(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	c.Assert(vs, gc.Implements, new(storage.VolumeTagger))
+
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.srv.client.CreateTags([]string{resp.Id}, []awsec2.Tag{{"foo", "bar"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = vs.(storage.VolumeTagger).TagVolume(s.cloudCallCtx, resp.Id, map[string]string{
+		"cost-center": "1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes, err := s.srv.client.Volumes([]string{resp.Id}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes.Volumes, gc.HasLen, 1)
+	c.Assert(volumes.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"foo", "bar"},
+		{"cost-center", "1234"},
+		{"juju-user-tags", "cost-center"},
+	})
+
+	// Tags that are no longer specified are cleared.
+	err = vs.(storage.VolumeTagger).TagVolume(s.cloudCallCtx, resp.Id, map[string]string{
+		"owner": "fred",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumes, err = s.srv.client.Volumes([]string{resp.Id}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes.Volumes, gc.HasLen, 1)
+	c.Assert(volumes.Volumes[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"foo", "bar"},
+		{"cost-center", ""},
+		{"owner", "fred"},
+		{"juju-user-tags", "owner"},
+	})
+}
+
 func (s *ebsSuite) TestImportVolumeCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
@@ -338,27 +338,43 @@ func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environ
 	); err != nil {
 		return errors.Trace(err)
 	}
-	if !args.Constraints.HasInstanceType() {
-		return nil
+	if args.Constraints.HasInstanceType() {
+		if err := e.precheckInstanceType(ctx, args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := environs.CheckInstanceQuotas(ctx, e, args.Constraints, nil); err != nil {
+		if errors.IsQuotaLimitExceeded(err) {
+			return errors.Trace(err)
+		}
+		// Not being able to query the quotas must not prevent
+		// the instance from being provisioned.
+		logger.Warningf("cannot check cloud quotas: %v", err)
 	}
-	// Constraint has an instance-type constraint so let's see if it is valid.
+	return nil
+}
+
+// precheckInstanceType checks that the instance-type constraint names
+// an instance type that is supported in the region, for the constrained
+// architecture if any.
+func (e *environ) precheckInstanceType(ctx context.ProviderCallContext, cons constraints.Value) error {
 	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
 	instanceTypes, err := e.supportedInstanceTypes(ec2Session, ctx)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	for _, itype := range instanceTypes {
-		if itype.Name != *args.Constraints.InstanceType {
+		if itype.Name != *cons.InstanceType {
 			continue
 		}
-		if archMatches(itype.Arches, args.Constraints.Arch) {
+		if archMatches(itype.Arches, cons.Arch) {
 			return nil
 		}
 	}
-	if args.Constraints.Arch == nil {
-		return fmt.Errorf("invalid AWS instance type %q specified", *args.Constraints.InstanceType)
+	if cons.Arch == nil {
+		return fmt.Errorf("invalid AWS instance type %q specified", *cons.InstanceType)
 	}
-	return fmt.Errorf("invalid AWS instance type %q and arch %q specified", *args.Constraints.InstanceType, *args.Constraints.Arch)
+	return fmt.Errorf("invalid AWS instance type %q and arch %q specified", *cons.InstanceType, *cons.Arch)
 }
 
 // MetadataLookupParams returns parameters which are used to query simplestreams metadata.
@@ -526,7 +542,10 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		apiPorts = append(apiPorts, args.InstanceConfig.APIInfo.Ports()[0])
 	}
 	callback(status.Allocating, "Setting up groups", nil)
-	groups, err := e.setUpGroups(ctx, args.ControllerUUID, args.InstanceConfig.MachineId, apiPorts)
+	groups, err := e.setUpGroups(
+		ctx, args.ControllerUUID, args.InstanceConfig.MachineId,
+		tags.UserResourceTags(args.InstanceConfig.Tags), apiPorts,
+	)
 	if err != nil {
 		return nil, annotateWrapError(err, "cannot set up groups")
 	}
@@ -573,7 +592,11 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	}
 
 	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	if args.Constraints.IsSpotInstance() {
+		instResp, err = runSpotInstances(e.ec2, ec2Session, ctx, runArgs, callback)
+	} else {
+		instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	}
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
 			err = annotateWrapError(err, "cannot run instances")
@@ -609,11 +632,17 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	// Tag the machine's root EBS volume, if it has one.
 	if inst.Instance.RootDeviceType == "ebs" {
 		cfg := e.Config()
+		// The instance's tags include the application tags and
+		// the expanded values of any templated tags.
+		userTags := tags.UserResourceTags(args.InstanceConfig.Tags)
 		tags := tags.ResourceTags(
 			names.NewModelTag(cfg.UUID()),
 			names.NewControllerTag(args.ControllerUUID),
 			cfg,
 		)
+		for k, v := range userTags {
+			tags[k] = v
+		}
 		tags[tagName] = instanceName + "-root"
 		if err := tagRootDisk(e.ec2, ctx, tags, inst.Instance); err != nil {
 			return nil, annotateWrapError(err, "tagging root disk")
@@ -895,6 +924,105 @@ func tagResources(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]s
 	return maybeConvertCredentialError(err, ctx)
 }
 
+// modelResourceTags returns the tags to set on resources that are
+// shared by the model's machines, with any templated values expanded.
+func (e *environ) modelResourceTags(controllerUUID string) map[string]string {
+	cfg := e.Config()
+	return tags.ExpandTemplates(
+		tags.ResourceTags(
+			names.NewModelTag(cfg.UUID()),
+			names.NewControllerTag(controllerUUID),
+			cfg,
+		),
+		tags.TemplateVars{Model: cfg.Name()},
+	)
+}
+
+// TagInstance is part of the environs.InstanceTagger interface.
+//
+// As well as the instance itself, the instance's root EBS volume and,
+// when the instance firewall mode is used, its machine security group
+// are tagged with the user-specified tags. The model's shared security
+// groups are tagged with the model's user-specified tags.
+//
+// The EC2 API client used does not support deleting tags, so tags that
+// are no longer specified are cleared by setting their values to the
+// empty string. The tags of security groups cannot be read with it
+// either, so tags that are no longer specified are cleared from the
+// shared security groups only if they were also set on the instance.
+func (e *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, instanceTags map[string]string) error {
+	resp, err := e.ec2.Instances([]string{string(id)}, nil)
+	if err != nil {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "fetching instance %q", id)
+	}
+	if len(resp.Reservations) == 0 || len(resp.Reservations[0].Instances) == 0 {
+		return errors.NotFoundf("instance %q", id)
+	}
+	inst := &resp.Reservations[0].Instances[0]
+	update, remove := tags.UpdateUserTags(ec2TagsMap(inst.Tags), instanceTags)
+	if err := tagResources(e.ec2, ctx, clearTags(update, remove), inst.InstanceId); err != nil {
+		return errors.Annotate(err, "tagging instance")
+	}
+
+	userTags := clearTags(tags.UserResourceTags(instanceTags), remove)
+	var resourceIds, sharedGroupIds []string
+	for _, m := range inst.BlockDeviceMappings {
+		if m.DeviceName == inst.RootDeviceName && m.VolumeId != "" {
+			resourceIds = append(resourceIds, m.VolumeId)
+			break
+		}
+	}
+	machineGroupPrefix := e.jujuGroupName() + "-"
+	for _, g := range inst.SecurityGroups {
+		switch {
+		case g.Name == e.jujuGroupName() || g.Name == e.globalGroupName():
+			sharedGroupIds = append(sharedGroupIds, g.Id)
+		case strings.HasPrefix(g.Name, machineGroupPrefix):
+			resourceIds = append(resourceIds, g.Id)
+		}
+	}
+	if len(resourceIds) > 0 {
+		if err := tagResources(e.ec2, ctx, userTags, resourceIds...); err != nil {
+			return errors.Annotate(err, "tagging instance resources")
+		}
+	}
+	if len(sharedGroupIds) == 0 {
+		return nil
+	}
+	modelTags := tags.UserResourceTags(e.modelResourceTags(instanceTags[tags.JujuController]))
+	for _, k := range remove {
+		if _, ok := modelTags[k]; !ok {
+			modelTags[k] = ""
+		}
+	}
+	return errors.Annotate(
+		tagResources(e.ec2, ctx, modelTags, sharedGroupIds...),
+		"tagging security groups",
+	)
+}
+
+// ec2TagsMap returns the given EC2 tags as a map.
+func ec2TagsMap(ec2Tags []ec2.Tag) map[string]string {
+	m := make(map[string]string, len(ec2Tags))
+	for _, t := range ec2Tags {
+		m[t.Key] = t.Value
+	}
+	return m
+}
+
+// clearTags returns a copy of the given tags, with the tags
+// to remove added with empty values.
+func clearTags(tags map[string]string, remove []string) map[string]string {
+	result := make(map[string]string, len(tags)+len(remove))
+	for k, v := range tags {
+		result[k] = v
+	}
+	for _, k := range remove {
+		result[k] = ""
+	}
+	return result
+}
+
 func tagRootDisk(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]string, inst *ec2.Instance) error {
 	if len(tags) == 0 {
 		return nil
@@ -1990,7 +2118,15 @@ func (e *environ) jujuGroupName() string {
 // other instances that might be running on the same EC2 account.  In
 // addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
-func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, machineId string, apiPorts []int) ([]ec2.SecurityGroup, error) {
+//
+// The machine security group is tagged with the given user-specified
+// resource tags of the machine, in addition to the model's tags.
+func (e *environ) setUpGroups(
+	ctx context.ProviderCallContext,
+	controllerUUID, machineId string,
+	machineTags map[string]string,
+	apiPorts []int,
+) ([]ec2.SecurityGroup, error) {
 	perms := []ec2.IPPerm{{
 		Protocol:  "tcp",
 		FromPort:  22,
@@ -2019,7 +2155,7 @@ func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, m
 		ToPort:   -1,
 	})
 	// Ensure there's a global group for Juju-related traffic.
-	jujuGroup, err := e.ensureGroup(ctx, controllerUUID, e.jujuGroupName(), perms)
+	jujuGroup, err := e.ensureGroup(ctx, controllerUUID, e.jujuGroupName(), nil, perms)
 	if err != nil {
 		return nil, err
 	}
@@ -2027,9 +2163,9 @@ func (e *environ) setUpGroups(ctx context.ProviderCallContext, controllerUUID, m
 	var machineGroup ec2.SecurityGroup
 	switch e.Config().FirewallMode() {
 	case config.FwInstance:
-		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.machineGroupName(machineId), nil)
+		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.machineGroupName(machineId), machineTags, nil)
 	case config.FwGlobal:
-		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.globalGroupName(), nil)
+		machineGroup, err = e.ensureGroup(ctx, controllerUUID, e.globalGroupName(), nil, nil)
 	}
 	if err != nil {
 		return nil, err
@@ -2063,8 +2199,14 @@ func (e *environ) securityGroupsByNameOrID(groupName string) (*ec2.SecurityGroup
 // If a group with name does not exist, one will be created.
 // If it exists, its permissions are set to perms.
 // Any entries in perms without SourceIPs will be granted for
-// the named group only.
-func (e *environ) ensureGroup(ctx context.ProviderCallContext, controllerUUID, name string, perms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
+// the named group only. A created group is tagged with the
+// model's resource tags and the given extra tags.
+func (e *environ) ensureGroup(
+	ctx context.ProviderCallContext,
+	controllerUUID, name string,
+	extraTags map[string]string,
+	perms []ec2.IPPerm,
+) (g ec2.SecurityGroup, err error) {
 	// Due to parallelization of the provisioner, it's possible that we try
 	// to create the model security group a second time before the first time
 	// is complete causing failures.
@@ -2089,12 +2231,10 @@ func (e *environ) ensureGroup(ctx context.ProviderCallContext, controllerUUID, n
 	if err == nil {
 		g = resp.SecurityGroup
 		// Tag the created group with the model and controller UUIDs.
-		cfg := e.Config()
-		tags := tags.ResourceTags(
-			names.NewModelTag(cfg.UUID()),
-			names.NewControllerTag(controllerUUID),
-			cfg,
-		)
+		tags := e.modelResourceTags(controllerUUID)
+		for k, v := range extraTags {
+			tags[k] = v
+		}
 		if err := tagResources(e.ec2, ctx, tags, g.Id); err != nil {
 			return g, errors.Annotate(err, "tagging security group")
 		}
@@ -10,6 +10,7 @@ import (
 	"os"
 
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 	"github.com/juju/os/series"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
@@ -95,6 +96,9 @@ func (t *LiveTests) SetUpSuite(c *gc.C) {
 			c.Assert(secretKey, gc.Equals, "x")
 			return mockEC2Session{}
 		})
+		t.BaseSuite.PatchValue(&ec2.ServiceQuotasSession, func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+			return mockServiceQuotasSession{}
+		})
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -247,6 +248,9 @@ func (t *localServerSuite) SetUpSuite(c *gc.C) {
 		c.Assert(secretKey, gc.Equals, "x")
 		return mockEC2Session{}
 	})
+	t.BaseSuite.PatchValue(&ec2.ServiceQuotasSession, func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+		return mockServiceQuotasSession{}
+	})
 	t.srv.createRootDisks = true
 	t.srv.startServer(c)
 	// TODO(jam) I don't understand why we shouldn't do this.
@@ -1468,6 +1472,79 @@ func (t *localServerSuite) TestPrecheckInstanceInvalidInstanceType(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "m1.invalid" specified`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceWithinQuota(c *gc.C) {
+	t.PatchValue(&ec2.ServiceQuotasSession, func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+		return mockServiceQuotasSession{quotas: map[string]float64{"L-1216C47A": 4}}
+	})
+	env := t.Prepare(c)
+	cons := constraints.MustParse("instance-type=t3a.medium")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceQuotaExceeded(c *gc.C) {
+	t.PatchValue(&ec2.ServiceQuotasSession, func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+		return mockServiceQuotasSession{quotas: map[string]float64{
+			"L-1216C47A": 3,
+			"L-D18FCD1D": 1,
+		}}
+	})
+	env := t.Prepare(c)
+	cons := constraints.MustParse("instance-type=t3a.medium root-disk=1020G")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded for cores \(2 required, 1 remaining\), volume GiB \(1020 required, 1016 remaining\)`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceQuotaError(c *gc.C) {
+	t.PatchValue(&ec2.ServiceQuotasSession, func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+		return mockServiceQuotasSession{quotas: map[string]float64{"L-1216C47A": 4}}
+	})
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return mockFailingInstancesEC2Session{}
+	})
+	env := t.Prepare(c)
+	cons := constraints.MustParse("instance-type=t3a.medium")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	// Failing to query the quotas does not fail the precheck.
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(c.GetTestLog(), jc.Contains, "cannot check cloud quotas")
+}
+
+func (t *localServerSuite) TestStartInstanceSpot(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	session := &mockSpotEC2Session{}
+	t.PatchValue(&ec2.EC2Session, func(region, accessKey, secretKey string) ec2iface.EC2API {
+		return session
+	})
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		return nil, errors.New("on-demand instance requested")
+	})
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		StatusCallback: fakeCallback,
+		Constraints:    constraints.MustParse("instance-type=t3a.medium instance-lifecycle=spot"),
+	}
+	_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, gc.ErrorMatches, `There is no Spot capacity available \(InsufficientInstanceCapacity\)`)
+
+	input := session.runInstancesInput
+	c.Assert(input, gc.NotNil)
+	c.Assert(*input.InstanceType, gc.Equals, "t3a.medium")
+	c.Assert(*input.InstanceMarketOptions.MarketType, gc.Equals, "spot")
+}
+
 func (t *localServerSuite) TestPrecheckInstanceUnsupportedArch(c *gc.C) {
 	env := t.Prepare(c)
 	cons := constraints.MustParse("instance-type=cc1.4xlarge arch=i386")
@@ -1886,6 +1963,79 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestTagInstance(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	instances, err := env.AllRunningInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances, gc.HasLen, 1)
+
+	err = env.(environs.InstanceTagger).TagInstance(t.callCtx, instances[0].Id(), map[string]string{
+		"juju-units-deployed": "mysql/0",
+		"cost-center":         "1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ec2conn := ec2.EnvironEC2(env)
+	resp, err := ec2conn.Instances([]string{string(instances[0].Id())}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Reservations[0].Instances[0].Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"juju-units-deployed", "mysql/0"},
+		{"juju-user-tags", "cost-center"},
+		{"cost-center", "1234"},
+	})
+
+	// The root disk only gets the user-specified tags.
+	rootDiskTags := func() []amzec2.Tag {
+		volumes, err := ec2conn.Volumes(nil, nil)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, vol := range volumes.Volumes {
+			if len(vol.Tags) != 0 {
+				return vol.Tags
+			}
+		}
+		c.Fatalf("root disk not found")
+		return nil
+	}
+	c.Assert(rootDiskTags(), jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0-root"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"cost-center", "1234"},
+	})
+
+	// Tags that are no longer specified are cleared.
+	err = env.(environs.InstanceTagger).TagInstance(t.callCtx, instances[0].Id(), map[string]string{
+		"juju-units-deployed": "mysql/0",
+		"owner":               "fred",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resp, err = ec2conn.Instances([]string{string(instances[0].Id())}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Reservations[0].Instances[0].Tags, jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"juju-is-controller", "true"},
+		{"juju-units-deployed", "mysql/0"},
+		{"juju-user-tags", "owner"},
+		{"cost-center", ""},
+		{"owner", "fred"},
+	})
+	c.Assert(rootDiskTags(), jc.SameContents, []amzec2.Tag{
+		{"Name", "juju-sample-machine-0-root"},
+		{"juju-model-uuid", coretesting.ModelTag.Id()},
+		{"juju-controller-uuid", t.ControllerUUID},
+		{"cost-center", ""},
+		{"owner", "fred"},
+	})
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllRunningInstances(s.callCtx)
@@ -5,8 +5,11 @@ package ec2_test
 
 import (
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
 )
 
 type mockEC2Session struct {
@@ -86,3 +89,68 @@ func (mockEC2Session) DescribeSpotPriceHistory(*ec2.DescribeSpotPriceHistoryInpu
 		SpotPriceHistory: nil,
 	}, nil
 }
+
+func (mockEC2Session) DescribeInstancesPages(_ *ec2.DescribeInstancesInput, fn func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	fn(&ec2.DescribeInstancesOutput{
+		Reservations: []*ec2.Reservation{{
+			Instances: []*ec2.Instance{{
+				InstanceType: aws.String("t3a.medium"),
+				CpuOptions: &ec2.CpuOptions{
+					CoreCount:      aws.Int64(1),
+					ThreadsPerCore: aws.Int64(2),
+				},
+			}},
+		}},
+	}, true)
+	return nil
+}
+
+func (mockEC2Session) DescribeVolumesPages(_ *ec2.DescribeVolumesInput, fn func(*ec2.DescribeVolumesOutput, bool) bool) error {
+	fn(&ec2.DescribeVolumesOutput{
+		Volumes: []*ec2.Volume{{Size: aws.Int64(8)}},
+	}, true)
+	return nil
+}
+
+// mockSpotEC2Session is a mockEC2Session which records
+// RunInstances requests, and fails them.
+type mockSpotEC2Session struct {
+	mockEC2Session
+
+	runInstancesInput *ec2.RunInstancesInput
+}
+
+func (s *mockSpotEC2Session) RunInstances(input *ec2.RunInstancesInput) (*ec2.Reservation, error) {
+	s.runInstancesInput = input
+	return nil, awserr.New("InsufficientInstanceCapacity", "There is no Spot capacity available", nil)
+}
+
+// mockServiceQuotasSession reports the quota values it holds, keyed
+// by quota code, and reports any other quota as not found.
+type mockServiceQuotasSession struct {
+	servicequotasiface.ServiceQuotasAPI
+	quotas map[string]float64
+}
+
+func (s mockServiceQuotasSession) GetServiceQuota(in *servicequotas.GetServiceQuotaInput) (*servicequotas.GetServiceQuotaOutput, error) {
+	value, ok := s.quotas[aws.StringValue(in.QuotaCode)]
+	if !ok {
+		return nil, awserr.New(servicequotas.ErrCodeNoSuchResourceException, "quota not found", nil)
+	}
+	return &servicequotas.GetServiceQuotaOutput{
+		Quota: &servicequotas.ServiceQuota{
+			QuotaCode: in.QuotaCode,
+			Value:     aws.Float64(value),
+		},
+	}, nil
+}
+
+// mockFailingInstancesEC2Session is a mockEC2Session which fails
+// to describe instances.
+type mockFailingInstancesEC2Session struct {
+	mockEC2Session
+}
+
+func (mockFailingInstancesEC2Session) DescribeInstancesPages(*ec2.DescribeInstancesInput, func(*ec2.DescribeInstancesOutput, bool) bool) error {
+	return awserr.New("InternalError", "boom", nil)
+}
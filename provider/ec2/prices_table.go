@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+// Generated code - do not edit.
+
+const priceTableYAML = `# On-demand Linux prices for EC2 instance types and EBS volume types.
+# Instance type prices are per hour, volume type prices are per GiB-month.
+# Regenerate with: go run github.com/juju/juju/generate/pricetables ec2 prices.yaml
+currency: USD
+regions:
+  eu-central-1:
+    instance-types:
+      c5.2xlarge: 0.388
+      c5.4xlarge: 0.776
+      c5.large: 0.097
+      c5.xlarge: 0.194
+      m5.2xlarge: 0.46
+      m5.4xlarge: 0.92
+      m5.large: 0.115
+      m5.xlarge: 0.23
+      r5.2xlarge: 0.608
+      r5.4xlarge: 1.216
+      r5.large: 0.152
+      r5.xlarge: 0.304
+      t3.2xlarge: 0.384
+      t3.large: 0.096
+      t3.medium: 0.048
+      t3.micro: 0.012
+      t3.nano: 0.006
+      t3.small: 0.024
+      t3.xlarge: 0.192
+    volume-types:
+      gp2: 0.119
+      io1: 0.149
+      sc1: 0.03
+      st1: 0.054
+      standard: 0.059
+  eu-west-1:
+    instance-types:
+      c5.2xlarge: 0.384
+      c5.4xlarge: 0.768
+      c5.large: 0.096
+      c5.xlarge: 0.192
+      m5.2xlarge: 0.428
+      m5.4xlarge: 0.856
+      m5.large: 0.107
+      m5.xlarge: 0.214
+      r5.2xlarge: 0.564
+      r5.4xlarge: 1.128
+      r5.large: 0.141
+      r5.xlarge: 0.282
+      t3.2xlarge: 0.3648
+      t3.large: 0.0912
+      t3.medium: 0.0456
+      t3.micro: 0.0114
+      t3.nano: 0.0057
+      t3.small: 0.0228
+      t3.xlarge: 0.1824
+    volume-types:
+      gp2: 0.11
+      io1: 0.138
+      sc1: 0.028
+      st1: 0.05
+      standard: 0.055
+  us-east-1:
+    instance-types:
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      c5.large: 0.085
+      c5.xlarge: 0.17
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.large: 0.096
+      m5.xlarge: 0.192
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r5.large: 0.126
+      r5.xlarge: 0.252
+      t3.2xlarge: 0.3328
+      t3.large: 0.0832
+      t3.medium: 0.0416
+      t3.micro: 0.0104
+      t3.nano: 0.0052
+      t3.small: 0.0208
+      t3.xlarge: 0.1664
+    volume-types:
+      gp2: 0.1
+      io1: 0.125
+      sc1: 0.025
+      st1: 0.045
+      standard: 0.05
+  us-east-2:
+    instance-types:
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      c5.large: 0.085
+      c5.xlarge: 0.17
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.large: 0.096
+      m5.xlarge: 0.192
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r5.large: 0.126
+      r5.xlarge: 0.252
+      t3.2xlarge: 0.3328
+      t3.large: 0.0832
+      t3.medium: 0.0416
+      t3.micro: 0.0104
+      t3.nano: 0.0052
+      t3.small: 0.0208
+      t3.xlarge: 0.1664
+    volume-types:
+      gp2: 0.1
+      io1: 0.125
+      sc1: 0.025
+      st1: 0.045
+      standard: 0.05
+  us-west-2:
+    instance-types:
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      c5.large: 0.085
+      c5.xlarge: 0.17
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.large: 0.096
+      m5.xlarge: 0.192
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r5.large: 0.126
+      r5.xlarge: 0.252
+      t3.2xlarge: 0.3328
+      t3.large: 0.0832
+      t3.medium: 0.0416
+      t3.micro: 0.0104
+      t3.nano: 0.0052
+      t3.small: 0.0208
+      t3.xlarge: 0.1664
+    volume-types:
+      gp2: 0.1
+      io1: 0.125
+      sc1: 0.025
+      st1: 0.045
+      standard: 0.05
+`
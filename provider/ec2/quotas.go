@@ -0,0 +1,204 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/aws/aws-sdk-go/service/servicequotas"
+	"github.com/aws/aws-sdk-go/service/servicequotas/servicequotasiface"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// onDemandStandardVCPUQuotaCode is the Service Quotas code for the
+	// "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances"
+	// quota, which limits the number of vCPUs of running instances.
+	onDemandStandardVCPUQuotaCode = "L-1216C47A"
+
+	// gp2StorageQuotaCode is the Service Quotas code for the "Storage
+	// for General Purpose SSD (gp2) volumes, in TiB" quota.
+	gp2StorageQuotaCode = "L-D18FCD1D"
+)
+
+var _ environs.QuotaChecker = (*environ)(nil)
+
+// ServiceQuotasSession returns a Service Quotas session with the
+// given credentials.
+var ServiceQuotasSession = func(region, accessKey, secretKey string) servicequotasiface.ServiceQuotasAPI {
+	sess := session.Must(session.NewSession())
+	return servicequotas.New(sess, &aws.Config{
+		Region: aws.String(region),
+		Credentials: credentials.NewStaticCredentialsFromCreds(credentials.Value{
+			AccessKeyID:     accessKey,
+			SecretAccessKey: secretKey,
+		}),
+	})
+}
+
+// RemainingQuotas is part of the environs.QuotaChecker interface.
+//
+// The vCPU quota for on-demand standard instances and the gp2 volume
+// storage quota are reported. Quotas which cannot be read, for example
+// because the credential is not permitted to query Service Quotas, are
+// omitted.
+func (e *environ) RemainingQuotas(ctx context.ProviderCallContext) (environs.Quotas, error) {
+	var result environs.Quotas
+	quotasSession := ServiceQuotasSession(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+
+	vcpuLimit, err := serviceQuotaValue(quotasSession, "ec2", onDemandStandardVCPUQuotaCode)
+	if err != nil {
+		logger.Debugf("cannot get vCPU quota: %v", err)
+	} else {
+		used, err := runningInstanceVCPUs(ec2Session)
+		if err != nil {
+			return environs.Quotas{}, maybeConvertCredentialError(err, ctx)
+		}
+		result.Cores = remaining(vcpuLimit, used)
+	}
+
+	gp2LimitTiB, err := serviceQuotaValue(quotasSession, "ebs", gp2StorageQuotaCode)
+	if err != nil {
+		logger.Debugf("cannot get gp2 storage quota: %v", err)
+	} else {
+		used, err := volumeGiB(ec2Session, volumeTypeGP2)
+		if err != nil {
+			return environs.Quotas{}, maybeConvertCredentialError(err, ctx)
+		}
+		result.VolumeGiB = remaining(gp2LimitTiB*1024, used)
+	}
+	return result, nil
+}
+
+// InstanceQuotaUsage is part of the environs.QuotaChecker interface.
+func (e *environ) InstanceQuotaUsage(
+	ctx context.ProviderCallContext,
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+) (environs.QuotaUsage, error) {
+	usage := environs.QuotaUsage{Instances: 1}
+
+	// Count the vCPUs of the cheapest instance type matching
+	// the constraints, as that is what StartInstance will pick.
+	ec2Session := EC2Session(e.cloud.Region, e.ec2.AccessKey, e.ec2.SecretKey)
+	instanceTypes, err := e.supportedInstanceTypes(ec2Session, ctx)
+	if err != nil {
+		return environs.QuotaUsage{}, errors.Trace(err)
+	}
+	matching, err := instances.MatchingInstanceTypes(instanceTypes, "", cons)
+	if err != nil {
+		return environs.QuotaUsage{}, errors.Trace(err)
+	}
+	if !cons.IsSpotInstance() {
+		// Spot instances count towards a separate vCPU quota.
+		usage.Cores = matching[0].CpuCores
+	}
+	usage.MemoryMiB = matching[0].Mem
+
+	rootDiskMiB := gibToMib(common.MinRootDiskSizeGiB(""))
+	if cons.RootDisk != nil && *cons.RootDisk > rootDiskMiB {
+		rootDiskMiB = *cons.RootDisk
+	}
+	usage.Volumes = 1
+	usage.VolumeGiB = mibToGib(rootDiskMiB)
+	for _, v := range volumes {
+		if v.Provider != EBS_ProviderType {
+			continue
+		}
+		if volumeType, ok := v.Attributes[EBS_VolumeType]; ok && volumeType != volumeTypeGP2 {
+			continue
+		}
+		usage.Volumes++
+		usage.VolumeGiB += mibToGib(v.Size)
+	}
+	return usage, nil
+}
+
+func serviceQuotaValue(client servicequotasiface.ServiceQuotasAPI, serviceCode, quotaCode string) (uint64, error) {
+	out, err := client.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String(serviceCode),
+		QuotaCode:   aws.String(quotaCode),
+	})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if out.Quota == nil || out.Quota.Value == nil {
+		return 0, errors.NotFoundf("quota %q for service %q", quotaCode, serviceCode)
+	}
+	return uint64(*out.Quota.Value), nil
+}
+
+// runningInstanceVCPUs returns the total number of vCPUs of the pending
+// and running instances in the region, which count towards the vCPU quota.
+func runningInstanceVCPUs(client ec2iface.EC2API) (uint64, error) {
+	var total uint64
+	err := client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: aws.StringSlice([]string{"pending", "running"}),
+		}},
+	}, func(page *ec2.DescribeInstancesOutput, lastPage bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, inst := range reservation.Instances {
+				if inst.InstanceLifecycle != nil && *inst.InstanceLifecycle == ec2.InstanceLifecycleTypeSpot {
+					// Spot instances count towards a separate quota.
+					continue
+				}
+				total += instanceVCPUs(inst)
+			}
+		}
+		return true
+	})
+	return total, errors.Trace(err)
+}
+
+func instanceVCPUs(inst *ec2.Instance) uint64 {
+	if inst.CpuOptions == nil || inst.CpuOptions.CoreCount == nil {
+		return 1
+	}
+	threads := int64(1)
+	if inst.CpuOptions.ThreadsPerCore != nil {
+		threads = *inst.CpuOptions.ThreadsPerCore
+	}
+	return uint64(*inst.CpuOptions.CoreCount * threads)
+}
+
+// volumeGiB returns the total size of the volumes of the
+// specified type in the region.
+func volumeGiB(client ec2iface.EC2API, volumeType string) (uint64, error) {
+	var total uint64
+	err := client.DescribeVolumesPages(&ec2.DescribeVolumesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("volume-type"),
+			Values: aws.StringSlice([]string{volumeType}),
+		}},
+	}, func(page *ec2.DescribeVolumesOutput, lastPage bool) bool {
+		for _, v := range page.Volumes {
+			if v.Size != nil {
+				total += uint64(*v.Size)
+			}
+		}
+		return true
+	})
+	return total, errors.Trace(err)
+}
+
+func remaining(limit, used uint64) *uint64 {
+	var result uint64
+	if used < limit {
+		result = limit - used
+	}
+	return &result
+}
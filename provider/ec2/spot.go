@@ -0,0 +1,202 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var runSpotInstances = _runSpotInstances
+
+// runSpotInstances requests spot instances with the given run arguments,
+// retrying for a fixed number of attempts as runInstances does. The amz
+// library cannot request spot capacity, so the instances are requested
+// with the AWS SDK, and then described with the amz library so that the
+// result can be used in the same way as that of runInstances.
+func _runSpotInstances(
+	e *ec2.EC2,
+	client ec2iface.EC2API,
+	ctx context.ProviderCallContext,
+	ri *ec2.RunInstances,
+	c environs.StatusCallbackFunc,
+) (*ec2.RunInstancesResp, error) {
+	input, err := spotRunInstancesInput(ri)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var out *awsec2.Reservation
+	try := 1
+	for a := shortAttempt.Start(); a.Next(); {
+		c(status.Allocating, fmt.Sprintf("Start spot instance attempt %d", try), nil)
+		out, err = client.RunInstances(input)
+		err = convertAWSError(err)
+		if err == nil || !isNotFoundError(err) {
+			break
+		}
+		try++
+	}
+	if err != nil {
+		return nil, maybeConvertCredentialError(err, ctx)
+	}
+
+	ids := make([]string, len(out.Instances))
+	for i, inst := range out.Instances {
+		ids[i] = aws.StringValue(inst.InstanceId)
+	}
+	resp := &ec2.RunInstancesResp{
+		ReservationId: aws.StringValue(out.ReservationId),
+		OwnerId:       aws.StringValue(out.OwnerId),
+	}
+	// The instances may not be visible immediately, due to
+	// eventual consistency.
+	for a := shortAttempt.Start(); a.Next(); {
+		var instResp *ec2.InstancesResp
+		instResp, err = e.Instances(ids, nil)
+		if err != nil {
+			continue
+		}
+		resp.Instances = resp.Instances[:0]
+		for _, r := range instResp.Reservations {
+			resp.Instances = append(resp.Instances, r.Instances...)
+		}
+		if len(resp.Instances) == len(ids) {
+			return resp, nil
+		}
+	}
+	if err == nil {
+		err = errors.NotFoundf("spot instances %v", ids)
+	}
+	return nil, maybeConvertCredentialError(err, ctx)
+}
+
+// spotRunInstancesInput converts amz RunInstances arguments into
+// the equivalent AWS SDK input, requesting spot capacity.
+func spotRunInstancesInput(ri *ec2.RunInstances) (*awsec2.RunInstancesInput, error) {
+	token, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	minCount, maxCount := ri.MinCount, ri.MaxCount
+	if minCount == 0 && maxCount == 0 {
+		minCount, maxCount = 1, 1
+	} else if maxCount == 0 {
+		maxCount = minCount
+	}
+	input := &awsec2.RunInstancesInput{
+		ClientToken:  aws.String(token.String()),
+		ImageId:      aws.String(ri.ImageId),
+		InstanceType: aws.String(ri.InstanceType),
+		MinCount:     aws.Int64(int64(minCount)),
+		MaxCount:     aws.Int64(int64(maxCount)),
+		InstanceMarketOptions: &awsec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String(awsec2.MarketTypeSpot),
+			SpotOptions: &awsec2.SpotMarketOptions{
+				// Juju starts a replacement instance itself if
+				// the spot instance is reclaimed.
+				SpotInstanceType:             aws.String(awsec2.SpotInstanceTypeOneTime),
+				InstanceInterruptionBehavior: aws.String(awsec2.InstanceInterruptionBehaviorTerminate),
+			},
+		},
+	}
+	for _, g := range ri.SecurityGroups {
+		if g.Id != "" {
+			input.SecurityGroupIds = append(input.SecurityGroupIds, aws.String(g.Id))
+		} else {
+			input.SecurityGroups = append(input.SecurityGroups, aws.String(g.Name))
+		}
+	}
+	for _, b := range ri.BlockDeviceMappings {
+		input.BlockDeviceMappings = append(input.BlockDeviceMappings, spotBlockDeviceMapping(b))
+	}
+	if ri.KeyName != "" {
+		input.KeyName = aws.String(ri.KeyName)
+	}
+	if ri.UserData != nil {
+		input.UserData = aws.String(base64.StdEncoding.EncodeToString(ri.UserData))
+	}
+	if ri.AvailZone != "" || ri.PlacementGroupName != "" {
+		input.Placement = &awsec2.Placement{}
+		if ri.AvailZone != "" {
+			input.Placement.AvailabilityZone = aws.String(ri.AvailZone)
+		}
+		if ri.PlacementGroupName != "" {
+			input.Placement.GroupName = aws.String(ri.PlacementGroupName)
+		}
+	}
+	if ri.SubnetId != "" {
+		input.SubnetId = aws.String(ri.SubnetId)
+	}
+	if ri.PrivateIPAddress != "" {
+		input.PrivateIpAddress = aws.String(ri.PrivateIPAddress)
+	}
+	if ri.IAMInstanceProfile != "" {
+		input.IamInstanceProfile = &awsec2.IamInstanceProfileSpecification{
+			Name: aws.String(ri.IAMInstanceProfile),
+		}
+	}
+	if ri.EBSOptimized {
+		input.EbsOptimized = aws.Bool(true)
+	}
+	return input, nil
+}
+
+func spotBlockDeviceMapping(b ec2.BlockDeviceMapping) *awsec2.BlockDeviceMapping {
+	result := &awsec2.BlockDeviceMapping{
+		DeviceName: aws.String(b.DeviceName),
+	}
+	if b.VirtualName != "" {
+		result.VirtualName = aws.String(b.VirtualName)
+		return result
+	}
+	result.Ebs = &awsec2.EbsBlockDevice{}
+	if b.SnapshotId != "" {
+		result.Ebs.SnapshotId = aws.String(b.SnapshotId)
+	}
+	if b.VolumeType != "" {
+		result.Ebs.VolumeType = aws.String(b.VolumeType)
+	}
+	if b.VolumeSize > 0 {
+		result.Ebs.VolumeSize = aws.Int64(b.VolumeSize)
+	}
+	if b.IOPS > 0 {
+		result.Ebs.Iops = aws.Int64(b.IOPS)
+	}
+	if b.DeleteOnTermination {
+		result.Ebs.DeleteOnTermination = aws.Bool(true)
+	}
+	return result
+}
+
+// convertAWSError converts an error returned by the AWS SDK into the
+// equivalent amz error, so that it can be classified in the same way
+// as the errors returned by the amz library.
+func convertAWSError(err error) error {
+	aerr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	result := &ec2.Error{
+		Code:    aerr.Code(),
+		Message: aerr.Message(),
+	}
+	if reqErr, ok := err.(awserr.RequestFailure); ok {
+		result.StatusCode = reqErr.StatusCode()
+		result.RequestId = reqErr.RequestID()
+	}
+	return result
+}
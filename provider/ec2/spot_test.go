@@ -0,0 +1,86 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/base64"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsec2 "github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+)
+
+type spotSuite struct{}
+
+var _ = gc.Suite(&spotSuite{})
+
+func (*spotSuite) TestSpotRunInstancesInput(c *gc.C) {
+	input, err := spotRunInstancesInput(&amzec2.RunInstances{
+		ImageId:      "ami-1",
+		InstanceType: "m5.large",
+		UserData:     []byte("#cloud-config"),
+		AvailZone:    "us-east-1a",
+		SubnetId:     "subnet-1",
+		SecurityGroups: []amzec2.SecurityGroup{
+			{Id: "sg-1"}, {Name: "juju-default"},
+		},
+		BlockDeviceMappings: []amzec2.BlockDeviceMapping{{
+			DeviceName: "/dev/sda1",
+			VolumeSize: 8,
+		}, {
+			DeviceName:  "/dev/sdb",
+			VirtualName: "ephemeral0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aws.StringValue(input.ClientToken), gc.Not(gc.Equals), "")
+	input.ClientToken = nil
+	c.Assert(input, jc.DeepEquals, &awsec2.RunInstancesInput{
+		ImageId:      aws.String("ami-1"),
+		InstanceType: aws.String("m5.large"),
+		MinCount:     aws.Int64(1),
+		MaxCount:     aws.Int64(1),
+		InstanceMarketOptions: &awsec2.InstanceMarketOptionsRequest{
+			MarketType: aws.String("spot"),
+			SpotOptions: &awsec2.SpotMarketOptions{
+				SpotInstanceType:             aws.String("one-time"),
+				InstanceInterruptionBehavior: aws.String("terminate"),
+			},
+		},
+		SecurityGroupIds: []*string{aws.String("sg-1")},
+		SecurityGroups:   []*string{aws.String("juju-default")},
+		BlockDeviceMappings: []*awsec2.BlockDeviceMapping{{
+			DeviceName: aws.String("/dev/sda1"),
+			Ebs:        &awsec2.EbsBlockDevice{VolumeSize: aws.Int64(8)},
+		}, {
+			DeviceName:  aws.String("/dev/sdb"),
+			VirtualName: aws.String("ephemeral0"),
+		}},
+		UserData:  aws.String(base64.StdEncoding.EncodeToString([]byte("#cloud-config"))),
+		Placement: &awsec2.Placement{AvailabilityZone: aws.String("us-east-1a")},
+		SubnetId:  aws.String("subnet-1"),
+	})
+}
+
+func (*spotSuite) TestConvertAWSError(c *gc.C) {
+	err := convertAWSError(awserr.NewRequestFailure(
+		awserr.New("InsufficientInstanceCapacity", "no spot capacity in this Availability Zone", nil),
+		500, "req-1",
+	))
+	c.Assert(err, jc.DeepEquals, &amzec2.Error{
+		StatusCode: 500,
+		Code:       "InsufficientInstanceCapacity",
+		Message:    "no spot capacity in this Availability Zone",
+		RequestId:  "req-1",
+	})
+	c.Assert(isZoneConstrainedError(err), jc.IsTrue)
+
+	plain := errors.New("boom")
+	c.Assert(convertAWSError(plain), gc.Equals, plain)
+	c.Assert(convertAWSError(nil), jc.ErrorIsNil)
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/pricing"
+	"github.com/juju/juju/provider/gce/google"
+	"github.com/juju/juju/storage"
+)
+
+//go:generate go run github.com/juju/juju/generate/filetoconst priceTableYAML prices.yaml prices_table.go 2020 gce
+
+var _ environs.CostEstimator = (*environ)(nil)
+
+// priceTable holds the GCE prices bundled with Juju.
+var priceTable = mustParsePriceTable(priceTableYAML)
+
+func mustParsePriceTable(data string) *pricing.Table {
+	table, err := pricing.Parse([]byte(data))
+	if err != nil {
+		panic(errors.Annotate(err, "parsing GCE price table"))
+	}
+	return table
+}
+
+// InstanceTypeCost is part of the environs.CostEstimator interface.
+//
+// The on-demand price of the machine type is returned, without any
+// sustained use discount; preemptible instances are typically cheaper.
+func (env *environ) InstanceTypeCost(ctx context.ProviderCallContext, instanceType string) (environs.Price, error) {
+	price, err := priceTable.InstanceTypePrice(env.cloud.Region, instanceType)
+	return price, errors.Trace(err)
+}
+
+// VolumeCost is part of the environs.CostEstimator interface.
+//
+// Only GCE persistent disks are billed.
+func (env *environ) VolumeCost(ctx context.ProviderCallContext, volume storage.VolumeParams) (environs.Price, error) {
+	if volume.Provider != storageProviderType {
+		return environs.Price{}, nil
+	}
+	// Price the same disk type as CreateVolumes would create.
+	diskType, ok := volume.Attributes["type"].(google.DiskType)
+	if !ok {
+		diskType = google.DiskPersistentStandard
+	}
+	price, err := priceTable.VolumePrice(env.cloud.Region, string(diskType), mibToGib(volume.Size))
+	return price, errors.Trace(err)
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
+	"github.com/juju/juju/storage"
+)
+
+type costsSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&costsSuite{})
+
+func (s *costsSuite) TestInstanceTypeCost(c *gc.C) {
+	price, err := s.Env.InstanceTypeCost(s.CallCtx, "n1-standard-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 0.0475, Currency: "USD"})
+}
+
+func (s *costsSuite) TestInstanceTypeCostUnknown(c *gc.C) {
+	_, err := s.Env.InstanceTypeCost(s.CallCtx, "m1-ultramem-160")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *costsSuite) TestVolumeCost(c *gc.C) {
+	price, err := s.Env.VolumeCost(s.CallCtx, storage.VolumeParams{
+		Provider: "gce",
+		Size:     100 * 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 4, Currency: "USD"})
+
+	price, err = s.Env.VolumeCost(s.CallCtx, storage.VolumeParams{
+		Provider:   "gce",
+		Size:       100 * 1024,
+		Attributes: map[string]interface{}{"type": google.DiskPersistentSSD},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{Amount: 17, Currency: "USD"})
+}
+
+func (s *costsSuite) TestVolumeCostNotPersistentDisk(c *gc.C) {
+	price, err := s.Env.VolumeCost(s.CallCtx, storage.VolumeParams{
+		Provider: "tmpfs",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(price, jc.DeepEquals, environs.Price{})
+}
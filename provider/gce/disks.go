@@ -76,6 +76,8 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var _ storage.VolumeTagger = (*volumeSource)(nil)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -389,6 +391,33 @@ func (v *volumeSource) ImportVolume(ctx context.ProviderCallContext, volName str
 	}, nil
 }
 
+// TagVolume is specified on the storage.VolumeTagger interface.
+//
+// GCE label values cannot record which labels were set by Juju, so
+// the disk's labels other than Juju's own are replaced by those for
+// the given tags; labels for tags which are no longer specified are
+// removed.
+func (v *volumeSource) TagVolume(ctx context.ProviderCallContext, volName string, resourceTags map[string]string) error {
+	zone, _, err := parseVolumeId(volName)
+	if err != nil {
+		return errors.Annotatef(err, "cannot get volume %q", volName)
+	}
+	disk, err := v.gce.Disk(zone, volName)
+	if err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", volName), ctx)
+	}
+	labels := resourceTagsToDiskLabels(resourceTags)
+	for k, v := range disk.Labels {
+		if _, ok := labels[k]; !ok && strings.HasPrefix(k, tags.JujuTagPrefix) {
+			labels[k] = v
+		}
+	}
+	if err := v.gce.SetDiskLabels(zone, volName, disk.LabelFingerprint, labels); err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "cannot update labels on volume %q", volName), ctx)
+	}
+	return nil
+}
+
 func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volNames []string) ([]storage.DescribeVolumesResult, error) {
 	results := make([]storage.DescribeVolumesResult, len(volNames))
 	for i, vol := range volNames {
@@ -510,13 +539,47 @@ func (v *volumeSource) detachOneVolume(ctx context.ProviderCallContext, attachPa
 func resourceTagsToDiskLabels(in map[string]string) map[string]string {
 	out := make(map[string]string)
 	for k, v := range in {
-		// Only the controller and model UUID tags are carried
-		// over, as they're known not to conflict with GCE's
+		// The controller and model UUID tags are carried over
+		// as is, as they're known not to conflict with GCE's
 		// rules regarding label values.
 		switch k {
 		case tags.JujuController, tags.JujuModel:
 			out[k] = v
+			continue
 		}
+		// Other Juju tags, such as the storage instance and owner
+		// tags, are not carried over. User-specified tags are,
+		// with their keys and values made to conform to GCE's
+		// rules; tags whose keys cannot be made to conform are
+		// dropped.
+		if strings.HasPrefix(k, tags.JujuTagPrefix) {
+			continue
+		}
+		label := diskLabel(k)
+		if label == "" || label[0] < 'a' || label[0] > 'z' {
+			continue
+		}
+		out[label] = diskLabel(v)
 	}
 	return out
 }
+
+// diskLabel returns the given string converted to a valid GCE label
+// key or value: at most 63 lowercase letters, digits, underscores and
+// dashes. Other characters are replaced with underscores.
+func diskLabel(s string) string {
+	const maxLabelLength = 63
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r - 'A' + 'a'
+		}
+		return '_'
+	}, s)
+	if len(s) > maxLabelLength {
+		s = s[:maxLabelLength]
+	}
+	return s
+}
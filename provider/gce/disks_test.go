@@ -266,6 +266,55 @@ func (s *volumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *volumeSourceSuite) TestTagVolume(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+
+	c.Assert(s.source, gc.Implements, new(storage.VolumeTagger))
+	err := s.source.(storage.VolumeTagger).TagVolume(
+		s.CallCtx,
+		s.BaseDisk.Name, map[string]string{
+			"cost-center": "1234",
+		},
+	)
+	c.Check(err, jc.ErrorIsNil)
+
+	called, calls := s.FakeConn.WasCalled("SetDiskLabels")
+	c.Check(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].ID, gc.Equals, s.BaseDisk.Name)
+	c.Assert(calls[0].Labels, jc.DeepEquals, map[string]string{
+		"juju-model-uuid":      s.BaseDisk.Labels["juju-model-uuid"],
+		"juju-controller-uuid": s.BaseDisk.Labels["juju-controller-uuid"],
+		"cost-center":          "1234",
+		// "yodel" is removed, as it is no longer specified.
+	})
+}
+
+func (s *volumeSourceSuite) TestTagVolumeSanitisesLabels(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+
+	err := s.source.(storage.VolumeTagger).TagVolume(
+		s.CallCtx,
+		s.BaseDisk.Name, map[string]string{
+			"Owner":              "Fred Smith",
+			"unit":               "mysql/0",
+			"9lives":             "cat",
+			"juju-storage-owner": "mysql/0",
+		},
+	)
+	c.Check(err, jc.ErrorIsNil)
+
+	_, calls := s.FakeConn.WasCalled("SetDiskLabels")
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].Labels["owner"], gc.Equals, "fred_smith")
+	c.Assert(calls[0].Labels["unit"], gc.Equals, "mysql_0")
+	_, ok := calls[0].Labels["9lives"]
+	c.Assert(ok, jc.IsFalse)
+	_, ok = calls[0].Labels["juju-storage-owner"]
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *volumeSourceSuite) TestImportVolumeNotReady(c *gc.C) {
 	s.FakeConn.GoogleDisk = s.BaseDisk
 	s.FakeConn.GoogleDisk.Status = "floop"
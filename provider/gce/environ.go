@@ -31,6 +31,10 @@ type gceConnection interface {
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 	UpdateMetadata(key, value string, ids ...string) error
+	// UpdateInstanceMetadata updates the metadata of the identified
+	// instance with a single request, setting and removing the items
+	// returned by the update function given the current metadata.
+	UpdateInstanceMetadata(id string, update func(map[string]string) (map[string]string, []string)) error
 
 	IngressRules(fwname string) ([]network.IngressRule, error)
 	OpenPorts(fwname string, rules ...network.IngressRule) error
@@ -204,6 +204,7 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
+		Preemptible:       args.Constraints.IsSpotInstance(),
 		// Network is omitted (left empty).
 	})
 	if err != nil {
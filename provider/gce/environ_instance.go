@@ -146,6 +146,21 @@ func (env *environ) AdoptResources(ctx context.ProviderCallContext, controllerUU
 	return nil
 }
 
+// TagInstance is part of the environs.InstanceTagger interface.
+// GCE instances are tagged by setting their metadata, all in a single
+// update. User-specified tags that were set previously but are no
+// longer specified are removed. GCE firewall rules do not support
+// labels or metadata, so the instance's firewall cannot be tagged.
+func (env *environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, instanceTags map[string]string) error {
+	err := env.gce.UpdateInstanceMetadata(string(id), func(current map[string]string) (map[string]string, []string) {
+		return tags.UpdateUserTags(current, instanceTags)
+	})
+	if err != nil {
+		return google.HandleCredentialError(errors.Annotatef(err, "tagging instance %q", id), ctx)
+	}
+	return nil
+}
+
 // TODO(ericsnow) Turn into an interface.
 type instPlacement struct {
 	Zone *google.AvailabilityZone
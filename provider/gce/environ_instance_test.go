@@ -231,6 +231,30 @@ func (s *environInstSuite) TestAdoptResources(c *gc.C) {
 	c.Check(call.Value, gc.Equals, "other-uuid")
 }
 
+func (s *environInstSuite) TestTagInstance(c *gc.C) {
+	s.FakeConn.Metadata = map[string]string{
+		"juju-user-tags": "cost-center team",
+		"cost-center":    "999",
+		"team":           "ops",
+		"juju-is-state":  "true",
+	}
+	err := s.Env.TagInstance(s.CallCtx, "john", map[string]string{
+		"owner":       "fred",
+		"cost-center": "1234",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	call := s.FakeConn.Calls[0]
+	c.Check(call.FuncName, gc.Equals, "UpdateInstanceMetadata")
+	c.Check(call.ID, gc.Equals, "john")
+	c.Check(call.Metadata, jc.DeepEquals, map[string]string{
+		"owner":          "fred",
+		"cost-center":    "1234",
+		"juju-user-tags": "cost-center owner",
+	})
+	c.Check(call.Remove, jc.DeepEquals, []string{"team"})
+}
+
 func (s *environInstSuite) TestAdoptResourcesInvalidCredentialError(c *gc.C) {
 	s.FakeConn.Err = gce.InvalidCredentialError
 	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
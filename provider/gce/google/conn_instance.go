@@ -6,6 +6,7 @@ package google
 import (
 	"path"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
 )
@@ -175,6 +176,78 @@ func (gce *Connection) UpdateMetadata(key, value string, ids ...string) error {
 
 }
 
+// UpdateInstanceMetadata updates the metadata of the identified
+// instance with a single request. The update function is given the
+// instance's current metadata, and returns the items to set and the
+// keys of the items to remove. The call blocks until the instance is
+// updated or the request fails.
+func (gce *Connection) UpdateInstanceMetadata(
+	id string, update func(map[string]string) (map[string]string, []string),
+) error {
+	instances, err := gce.service.ListInstances(gce.projectID, id)
+	if err != nil {
+		return errors.Annotatef(err, "updating metadata for instance %q", id)
+	}
+	var inst *compute.Instance
+	for _, i := range instances {
+		if i.Name == id {
+			inst = i
+			break
+		}
+	}
+	if inst == nil {
+		return errors.NotFoundf("instance %q", id)
+	}
+	metadata := inst.Metadata
+	if metadata == nil {
+		metadata = &compute.Metadata{}
+	}
+	current := make(map[string]string)
+	for _, item := range metadata.Items {
+		if item != nil && item.Value != nil {
+			current[item.Key] = *item.Value
+		}
+	}
+	setItems, remove := update(current)
+	removeKeys := set.NewStrings(remove...)
+	newKeys := set.NewStrings()
+	for k := range setItems {
+		newKeys.Add(k)
+	}
+
+	changed := false
+	var items []*compute.MetadataItems
+	for _, item := range metadata.Items {
+		if item == nil {
+			continue
+		}
+		if removeKeys.Contains(item.Key) {
+			changed = true
+			continue
+		}
+		if value, ok := setItems[item.Key]; ok {
+			if item.Value == nil || *item.Value != value {
+				item.Value = &value
+				changed = true
+			}
+			newKeys.Remove(item.Key)
+		}
+		items = append(items, item)
+	}
+	for _, k := range newKeys.SortedValues() {
+		value := setItems[k]
+		items = append(items, &compute.MetadataItems{Key: k, Value: &value})
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	metadata.Items = items
+	// The GCE API won't accept a full URL for the zone (lp:1667172).
+	zoneName := path.Base(inst.Zone)
+	return errors.Trace(gce.service.SetMetadata(gce.projectID, zoneName, inst.Name, metadata))
+}
+
 func (gce *Connection) updateInstanceMetadata(instance *compute.Instance, key, value string) error {
 	metadata := instance.Metadata
 	existingItem := findMetadataItem(metadata.Items, key)
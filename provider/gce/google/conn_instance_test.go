@@ -104,6 +104,23 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstancePreemptible(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+
+	spec := s.InstanceSpec
+	spec.Preemptible = true
+	_, err := s.Conn.AddInstance(spec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	automaticRestart := false
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
@@ -368,6 +385,65 @@ func (s *connSuite) TestUpdateMetadataExistingAttribute(c *gc.C) {
 	checkMetadataItems(c, md.Items[0], "eggs", "beans")
 }
 
+func (s *connSuite) TestUpdateInstanceMetadata(c *gc.C) {
+	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
+	s.RawInstanceFull.Metadata = &compute.Metadata{
+		Fingerprint: "heymumwatchthis",
+		Items: []*compute.MetadataItems{
+			makeMetadataItems("eggs", "steak"),
+			makeMetadataItems("rick", "moranis"),
+			makeMetadataItems("beans", "baked"),
+		},
+	}
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateInstanceMetadata(s.RawInstanceFull.Name, func(current map[string]string) (map[string]string, []string) {
+		c.Check(current, jc.DeepEquals, map[string]string{
+			"eggs":  "steak",
+			"rick":  "moranis",
+			"beans": "baked",
+		})
+		return map[string]string{"rick": "morty", "business": "time"}, []string{"beans"}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+
+	call := s.FakeConn.Calls[1]
+	c.Check(call.FuncName, gc.Equals, "SetMetadata")
+	c.Check(call.ZoneName, gc.Equals, "a-zone")
+	c.Check(call.InstanceId, gc.Equals, "spam")
+
+	md := call.Metadata
+	c.Check(md.Fingerprint, gc.Equals, "heymumwatchthis")
+	c.Assert(md.Items, gc.HasLen, 3)
+	checkMetadataItems(c, md.Items[0], "eggs", "steak")
+	checkMetadataItems(c, md.Items[1], "rick", "morty")
+	checkMetadataItems(c, md.Items[2], "business", "time")
+}
+
+func (s *connSuite) TestUpdateInstanceMetadataUnchanged(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateInstanceMetadata(s.RawInstanceFull.Name, func(map[string]string) (map[string]string, []string) {
+		return map[string]string{"eggs": "steak"}, []string{"beans"}
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListInstances")
+}
+
+func (s *connSuite) TestUpdateInstanceMetadataNotFound(c *gc.C) {
+	s.FakeConn.Instances = []*compute.Instance{&s.RawInstanceFull}
+
+	err := s.Conn.UpdateInstanceMetadata("spa", func(map[string]string) (map[string]string, []string) {
+		return nil, nil
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *connSuite) TestUpdateMetadataMultipleInstances(c *gc.C) {
 	// Ensure we extract the name from the URL we get on the raw instance.
 	s.RawInstanceFull.Zone = "http://eels/lone/wolf/a-zone"
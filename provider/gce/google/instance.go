@@ -52,6 +52,10 @@ type InstanceSpec struct {
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Preemptible indicates whether the instance should be created as
+	// a preemptible instance, which GCE may stop at any time.
+	Preemptible bool
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
@@ -61,10 +65,25 @@ func (is InstanceSpec) raw() *compute.Instance {
 		NetworkInterfaces: is.networkInterfaces(),
 		Metadata:          packMetadata(is.Metadata),
 		Tags:              &compute.Tags{Items: is.Tags},
+		Scheduling:        is.scheduling(),
 		// MachineType is set in the addInstance call.
 	}
 }
 
+func (is InstanceSpec) scheduling() *compute.Scheduling {
+	if !is.Preemptible {
+		return nil
+	}
+	// Preemptible instances can neither be restarted automatically,
+	// nor migrated for host maintenance.
+	automaticRestart := false
+	return &compute.Scheduling{
+		Preemptible:       true,
+		AutomaticRestart:  &automaticRestart,
+		OnHostMaintenance: "TERMINATE",
+	}
+}
+
 // Summary builds an InstanceSummary based on the spec and returns it.
 func (is InstanceSpec) Summary() InstanceSummary {
 	raw := is.raw()
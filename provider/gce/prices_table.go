@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+// Generated code - do not edit.
+
+const priceTableYAML = `# On-demand Linux prices for GCE machine types and persistent disk types.
+# Instance type prices are per hour, volume type prices are per GiB-month.
+# Regenerate with: go run github.com/juju/juju/generate/pricetables gce prices.yaml
+currency: USD
+regions:
+  europe-west1:
+    instance-types:
+      e2-medium: 0.036852
+      e2-micro: 0.009213
+      e2-small: 0.018426
+      e2-standard-2: 0.073706
+      e2-standard-4: 0.147412
+      e2-standard-8: 0.294824
+      f1-micro: 0.0086
+      g1-small: 0.0279
+      n1-highcpu-2: 0.078
+      n1-highcpu-4: 0.156
+      n1-highcpu-8: 0.312
+      n1-highmem-2: 0.1302
+      n1-highmem-4: 0.2604
+      n1-highmem-8: 0.5208
+      n1-standard-1: 0.0523
+      n1-standard-16: 0.8368
+      n1-standard-2: 0.1046
+      n1-standard-4: 0.2092
+      n1-standard-8: 0.4184
+    volume-types:
+      local-ssd: 0.08
+      pd-ssd: 0.17
+      pd-standard: 0.04
+  us-central1:
+    instance-types:
+      e2-medium: 0.033503
+      e2-micro: 0.008376
+      e2-small: 0.016751
+      e2-standard-2: 0.067006
+      e2-standard-4: 0.134012
+      e2-standard-8: 0.268024
+      f1-micro: 0.0076
+      g1-small: 0.0257
+      n1-highcpu-2: 0.0709
+      n1-highcpu-4: 0.1418
+      n1-highcpu-8: 0.2836
+      n1-highmem-2: 0.1184
+      n1-highmem-4: 0.2368
+      n1-highmem-8: 0.4736
+      n1-standard-1: 0.0475
+      n1-standard-16: 0.76
+      n1-standard-2: 0.095
+      n1-standard-4: 0.19
+      n1-standard-8: 0.38
+    volume-types:
+      local-ssd: 0.08
+      pd-ssd: 0.17
+      pd-standard: 0.04
+  us-east1:
+    instance-types:
+      e2-medium: 0.033503
+      e2-micro: 0.008376
+      e2-small: 0.016751
+      e2-standard-2: 0.067006
+      e2-standard-4: 0.134012
+      e2-standard-8: 0.268024
+      f1-micro: 0.0076
+      g1-small: 0.0257
+      n1-highcpu-2: 0.0709
+      n1-highcpu-4: 0.1418
+      n1-highcpu-8: 0.2836
+      n1-highmem-2: 0.1184
+      n1-highmem-4: 0.2368
+      n1-highmem-8: 0.4736
+      n1-standard-1: 0.0475
+      n1-standard-16: 0.76
+      n1-standard-2: 0.095
+      n1-standard-4: 0.19
+      n1-standard-8: 0.38
+    volume-types:
+      local-ssd: 0.08
+      pd-ssd: 0.17
+      pd-standard: 0.04
+  us-west1:
+    instance-types:
+      e2-medium: 0.033503
+      e2-micro: 0.008376
+      e2-small: 0.016751
+      e2-standard-2: 0.067006
+      e2-standard-4: 0.134012
+      e2-standard-8: 0.268024
+      f1-micro: 0.0076
+      g1-small: 0.0257
+      n1-highcpu-2: 0.0709
+      n1-highcpu-4: 0.1418
+      n1-highcpu-8: 0.2836
+      n1-highmem-2: 0.1184
+      n1-highmem-4: 0.2368
+      n1-highmem-8: 0.4736
+      n1-standard-1: 0.0475
+      n1-standard-16: 0.76
+      n1-standard-2: 0.095
+      n1-standard-4: 0.19
+      n1-standard-8: 0.38
+    volume-types:
+      local-ssd: 0.08
+      pd-ssd: 0.17
+      pd-standard: 0.04
+`
@@ -497,6 +497,8 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	Metadata         map[string]string
+	Remove           []string
 }
 
 type fakeConn struct {
@@ -514,6 +516,8 @@ type fakeConn struct {
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
+	Metadata map[string]string
+
 	Err        error
 	FailOnCall int
 }
@@ -577,6 +581,17 @@ func (fc *fakeConn) UpdateMetadata(key, value string, ids ...string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) UpdateInstanceMetadata(id string, update func(map[string]string) (map[string]string, []string)) error {
+	set, remove := update(fc.Metadata)
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "UpdateInstanceMetadata",
+		ID:       id,
+		Metadata: set,
+		Remove:   remove,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) IngressRules(fwname string) ([]network.IngressRule, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "Ports",
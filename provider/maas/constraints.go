@@ -21,6 +21,7 @@ var unsupportedConstraints = []string{
 	constraints.CpuPower,
 	constraints.InstanceType,
 	constraints.VirtType,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -331,6 +331,7 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
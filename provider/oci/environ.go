@@ -331,6 +331,7 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 		constraints.Container,
 		constraints.VirtType,
 		constraints.Tags,
+		constraints.InstanceLifecycle,
 	}
 
 	validator := constraints.NewValidator()
@@ -220,6 +220,7 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeTagger = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(
@@ -600,6 +601,25 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// TagVolume is part of the storage.VolumeTagger interface.
+//
+// The goose client does not support deleting volume metadata, so tags
+// that are no longer specified are cleared by setting their values to
+// the empty string.
+func (s *cinderVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, resourceTags map[string]string) error {
+	volume, err := s.storageAdapter.GetVolume(volumeId)
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Annotatef(err, "getting volume %q", volumeId)
+	}
+	update, remove := tags.UpdateUserTags(volume.Metadata, resourceTags)
+	if _, err := s.storageAdapter.SetVolumeMetadata(volumeId, clearTags(update, remove)); err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Annotatef(err, "tagging volume %q", volumeId)
+	}
+	return nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -807,6 +807,37 @@ func (s *cinderVolumeSourceSuite) TestImportVolume(c *gc.C) {
 	})
 }
 
+func (s *cinderVolumeSourceSuite) TestTagVolume(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID: volumeId,
+				Metadata: map[string]string{
+					"juju-user-tags": "cost-center owner",
+					"cost-center":    "999",
+					"owner":          "fred",
+					"other":          "value",
+				},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter, s.env)
+	c.Assert(volSource, gc.Implements, new(storage.VolumeTagger))
+
+	tags := map[string]string{"cost-center": "1234"}
+	err := volSource.(storage.VolumeTagger).TagVolume(s.callCtx, mockVolId, tags)
+	c.Assert(err, jc.ErrorIsNil)
+	// Tags that are no longer specified are cleared.
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"SetVolumeMetadata", []interface{}{mockVolId, map[string]string{
+			"juju-user-tags": "cost-center",
+			"cost-center":    "1234",
+			"owner":          "",
+		}}},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestImportVolumeInUse(c *gc.C) {
 	mockAdapter := &mockAdapter{
 		getVolume: func(volumeId string) (*cinder.Volume, error) {
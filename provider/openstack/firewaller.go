@@ -5,7 +5,9 @@ package openstack
 
 import (
 	"fmt"
+	"net/http"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -14,7 +16,9 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/retry"
+	"gopkg.in/goose.v2/client"
 	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/neutron"
 
 	"github.com/juju/juju/core/instance"
@@ -23,6 +27,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
 )
@@ -32,6 +37,9 @@ const (
 	GroupControllerPattern = `^(?P<prefix>juju-)(?P<controllerUUID>` + validUUID + `)(?P<suffix>-.*)$`
 )
 
+// neutronTagMaxLength is the maximum length of a Neutron resource tag.
+const neutronTagMaxLength = 60
+
 var extractControllerRe = regexp.MustCompile(GroupControllerPattern)
 
 // FirewallerFactory for obtaining firewaller object.
@@ -87,6 +95,11 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(ctx context.ProviderCallContext, inst instances.Instance, machineID string) ([]network.IngressRule, error)
+
+	// TagGroups sets the user-specified tags on the model's security
+	// groups: the machine tags on the specified machine's group, and
+	// the model tags on the groups shared by the model's machines.
+	TagGroups(ctx context.ProviderCallContext, controllerUUID, machineID string, machineTags, modelTags map[string]string) error
 }
 
 type firewallerFactory struct{}
@@ -670,6 +683,100 @@ func (c *neutronFirewaller) updateGroupControllerUUID(group *neutron.SecurityGro
 	return errors.Trace(err)
 }
 
+// TagGroups implements Firewaller interface.
+//
+// Security groups are tagged using Neutron's resource tags, each tag
+// being recorded as "key=value". Tags longer than Neutron allows are
+// skipped, and failures to tag are logged rather than returned, as not
+// all clouds support tagging security groups.
+func (c *neutronFirewaller) TagGroups(
+	ctx context.ProviderCallContext, controllerUUID, machineID string, machineTags, modelTags map[string]string,
+) error {
+	groupTags := []struct {
+		name string
+		tags map[string]string
+	}{
+		{c.machineGroupName(controllerUUID, machineID), machineTags},
+		{c.jujuGroupName(controllerUUID), modelTags},
+		{c.globalGroupName(controllerUUID), modelTags},
+	}
+	neutronClient := c.environ.neutron()
+	for _, g := range groupTags {
+		groups, err := neutronClient.SecurityGroupByNameV2(g.name)
+		if err != nil {
+			handleCredentialError(err, ctx)
+			return errors.Trace(err)
+		}
+		for _, group := range groups {
+			if err := c.tagGroup(group.Id, g.tags); err != nil {
+				if common.MaybeHandleCredentialError(IsAuthorisationFailure, err, ctx) {
+					return errors.Trace(err)
+				}
+				logger.Debugf("cannot tag security group %q: %v", g.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// tagGroup replaces the user-specified tags that Juju set on the
+// security group with the given ID with the given tags, leaving
+// tags set by other means alone.
+func (c *neutronFirewaller) tagGroup(groupID string, resourceTags map[string]string) error {
+	authClient := c.environ.client()
+	url := fmt.Sprintf("%s/%s/tags", neutron.ApiSecurityGroupsV2, groupID)
+	var current struct {
+		Tags []string `json:"tags"`
+	}
+	if err := authClient.SendRequest(
+		client.GET, "network", "v2.0", url, &goosehttp.RequestData{RespValue: &current},
+	); err != nil {
+		return errors.Trace(err)
+	}
+	newTags := updateNeutronTags(current.Tags, resourceTags)
+	req := struct {
+		Tags []string `json:"tags"`
+	}{newTags}
+	return errors.Trace(authClient.SendRequest(
+		client.PUT, "network", "v2.0", url, &goosehttp.RequestData{
+			ReqValue: req, ExpectedStatus: []int{http.StatusOK},
+		},
+	))
+}
+
+// updateNeutronTags returns the Neutron resource tags that replace the
+// user-specified tags recorded in the current tags with the given tags.
+// Tags longer than Neutron allows are omitted.
+func updateNeutronTags(current []string, resourceTags map[string]string) []string {
+	existing := make(map[string]string)
+	for _, tag := range current {
+		if kv := strings.SplitN(tag, "=", 2); len(kv) == 2 {
+			existing[kv[0]] = kv[1]
+		}
+	}
+	update, remove := tags.UpdateUserTags(existing, resourceTags)
+	removeKeys := set.NewStrings(remove...)
+
+	var newTags []string
+	for _, tag := range current {
+		key := strings.SplitN(tag, "=", 2)[0]
+		if _, ok := update[key]; ok || removeKeys.Contains(key) {
+			continue
+		}
+		newTags = append(newTags, tag)
+	}
+	for k, v := range update {
+		tag := k + "=" + v
+		if len(tag) > neutronTagMaxLength {
+			logger.Debugf("not setting tag %q on security group: too long", k)
+			continue
+		}
+		newTags = append(newTags, tag)
+	}
+	sort.Strings(newTags)
+	return newTags
+}
+
 // OpenPorts implements Firewaller interface.
 func (c *neutronFirewaller) OpenPorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
 	err := c.openPorts(ctx, c.openPortsInGroup, rules)
@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goosehttp "gopkg.in/goose.v2/http"
+)
+
+type firewallerInternalSuite struct {
+	testing.IsolationSuite
+
+	client *MockAuthenticatingClient
+}
+
+var _ = gc.Suite(&firewallerInternalSuite{})
+
+func (s *firewallerInternalSuite) setup(c *gc.C) (*neutronFirewaller, *gomock.Controller) {
+	ctrl := gomock.NewController(c)
+	s.client = NewMockAuthenticatingClient(ctrl)
+	env := &Environ{clientUnlocked: s.client}
+	return &neutronFirewaller{firewallerBase{environ: env}}, ctrl
+}
+
+func (s *firewallerInternalSuite) TestTagGroup(c *gc.C) {
+	fw, ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.client.EXPECT().SendRequest("GET", "network", "v2.0", "security-groups/group-id/tags", gomock.Any()).DoAndReturn(
+		func(_, _, _, _ string, requestData *goosehttp.RequestData) error {
+			resp := requestData.RespValue.(*struct {
+				Tags []string `json:"tags"`
+			})
+			resp.Tags = []string{"juju-user-tags=cost-center owner", "cost-center=999", "owner=fred", "other"}
+			return nil
+		},
+	)
+	var tags []string
+	s.client.EXPECT().SendRequest("PUT", "network", "v2.0", "security-groups/group-id/tags", gomock.Any()).DoAndReturn(
+		func(_, _, _, _ string, requestData *goosehttp.RequestData) error {
+			tags = requestData.ReqValue.(struct {
+				Tags []string `json:"tags"`
+			}).Tags
+			return nil
+		},
+	)
+
+	err := fw.tagGroup("group-id", map[string]string{
+		"cost-center": "1234",
+		"team":        "ops",
+		"long":        strings.Repeat("x", 60),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Tags no longer specified are removed, and those that are too
+	// long to set are skipped; tags set by other means are left alone.
+	c.Assert(tags, jc.DeepEquals, []string{
+		"cost-center=1234",
+		"juju-user-tags=cost-center long team",
+		"other",
+		"team=ops",
+	})
+}
@@ -2653,20 +2653,23 @@ func (s *localServerSuite) TestTagInstance(c *gc.C) {
 	err := bootstrapEnv(c, s.env)
 	c.Assert(err, jc.ErrorIsNil)
 
-	assertMetadata := func(extraKey, extraValue string) {
+	assertMetadata := func(extra map[string]string) {
 		// Refresh instance
 		allInstances, err := s.env.AllRunningInstances(s.callCtx)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(allInstances, gc.HasLen, 1)
+		expected := map[string]string{
+			"juju-model-uuid":      coretesting.ModelTag.Id(),
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-is-controller":   "true",
+		}
+		for k, v := range extra {
+			expected[k] = v
+		}
 		c.Assert(
 			openstack.InstanceServerDetail(allInstances[0]).Metadata,
 			jc.DeepEquals,
-			map[string]string{
-				"juju-model-uuid":      coretesting.ModelTag.Id(),
-				"juju-controller-uuid": coretesting.ControllerTag.Id(),
-				"juju-is-controller":   "true",
-				extraKey:               extraValue,
-			},
+			expected,
 		)
 	}
 
@@ -2674,25 +2677,41 @@ func (s *localServerSuite) TestTagInstance(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(allInstances, gc.HasLen, 1)
 
-	extraKey := "extra-k"
-	extraValue := "extra-v"
 	err = s.env.(environs.InstanceTagger).TagInstance(
 		s.callCtx,
 		allInstances[0].Id(),
-		map[string]string{extraKey: extraValue},
+		map[string]string{"extra-k": "extra-v"},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	assertMetadata(extraKey, extraValue)
+	assertMetadata(map[string]string{
+		"extra-k":        "extra-v",
+		"juju-user-tags": "extra-k",
+	})
 
 	// Ensure that a second call updates existing tags.
-	extraValue = "extra-v2"
 	err = s.env.(environs.InstanceTagger).TagInstance(
 		s.callCtx,
 		allInstances[0].Id(),
-		map[string]string{extraKey: extraValue},
+		map[string]string{"extra-k": "extra-v2"},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	assertMetadata(extraKey, extraValue)
+	assertMetadata(map[string]string{
+		"extra-k":        "extra-v2",
+		"juju-user-tags": "extra-k",
+	})
+
+	// Ensure that tags which are no longer specified are cleared.
+	err = s.env.(environs.InstanceTagger).TagInstance(
+		s.callCtx,
+		allInstances[0].Id(),
+		map[string]string{"other-k": "other-v"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	assertMetadata(map[string]string{
+		"extra-k":        "",
+		"other-k":        "other-v",
+		"juju-user-tags": "other-k",
+	})
 }
 
 func (s *localServerSuite) TestAdoptResources(c *gc.C) {
@@ -571,6 +571,7 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.InstanceLifecycle,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -721,6 +722,14 @@ func (e *Environ) PrecheckInstance(ctx context.ProviderCallContext, args environ
 		}
 	}
 
+	if err := environs.CheckInstanceQuotas(ctx, e, args.Constraints, nil); err != nil {
+		if errors.IsQuotaLimitExceeded(err) {
+			return errors.Trace(err)
+		}
+		// Not being able to query the quotas must not prevent
+		// the instance from being provisioned.
+		logger.Warningf("cannot check cloud quotas: %v", err)
+	}
 	return nil
 }
 
@@ -1792,7 +1801,7 @@ func (e *Environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID
 		return errors.Trace(err)
 	}
 	for _, instance := range instances {
-		err := e.TagInstance(ctx, instance.Id(), controllerTag)
+		err := e.nova().SetServerMetadata(string(instance.Id()), controllerTag)
 		if err != nil {
 			logger.Errorf("error updating controller tag for instance %s: %v", instance.Id(), err)
 			failed = append(failed, string(instance.Id()))
@@ -2186,12 +2195,59 @@ func (e *Environ) cloudSpec(region string) (simplestreams.CloudSpec, error) {
 }
 
 // TagInstance implements environs.InstanceTagger.
-func (e *Environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
-	if err := e.nova().SetServerMetadata(string(id), tags); err != nil {
+//
+// The instance's security groups are tagged too. The goose client does
+// not support deleting server metadata, so tags that are no longer
+// specified are cleared by setting their values to the empty string.
+func (e *Environ) TagInstance(ctx context.ProviderCallContext, id instance.Id, instanceTags map[string]string) error {
+	server, err := e.nova().GetServer(string(id))
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return errors.Annotate(err, "getting server")
+	}
+	update, remove := tags.UpdateUserTags(server.Metadata, instanceTags)
+	if err := e.nova().SetServerMetadata(string(id), clearTags(update, remove)); err != nil {
 		handleCredentialError(err, ctx)
 		return errors.Annotate(err, "setting server metadata")
 	}
-	return nil
+
+	controllerUUID := instanceTags[tags.JujuController]
+	machineID := instanceTags[tags.JujuMachine]
+	if controllerUUID == "" || machineID == "" {
+		return nil
+	}
+	return errors.Annotate(e.firewaller.TagGroups(
+		ctx, controllerUUID, machineID,
+		tags.UserResourceTags(instanceTags),
+		tags.UserResourceTags(e.modelResourceTags(controllerUUID)),
+	), "tagging security groups")
+}
+
+// modelResourceTags returns the tags to set on resources that are
+// shared by the model's machines, with any templated values expanded.
+func (e *Environ) modelResourceTags(controllerUUID string) map[string]string {
+	cfg := e.Config()
+	return tags.ExpandTemplates(
+		tags.ResourceTags(
+			names.NewModelTag(cfg.UUID()),
+			names.NewControllerTag(controllerUUID),
+			cfg,
+		),
+		tags.TemplateVars{Model: cfg.Name()},
+	)
+}
+
+// clearTags returns a copy of the given tags, with the tags
+// to remove added with empty values.
+func clearTags(tags map[string]string, remove []string) map[string]string {
+	result := make(map[string]string, len(tags)+len(remove))
+	for k, v := range tags {
+		result[k] = v
+	}
+	for _, k := range remove {
+		result[k] = ""
+	}
+	return result
 }
 
 func (e *Environ) SetClock(clock clock.Clock) {
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
+)
+
+var _ environs.QuotaChecker = (*Environ)(nil)
+
+// computeLimits holds the absolute limits reported by Nova. A negative
+// maximum means that the resource is not limited.
+type computeLimits struct {
+	MaxTotalInstances  int64 `json:"maxTotalInstances"`
+	TotalInstancesUsed int64 `json:"totalInstancesUsed"`
+	MaxTotalCores      int64 `json:"maxTotalCores"`
+	TotalCoresUsed     int64 `json:"totalCoresUsed"`
+	MaxTotalRAMSize    int64 `json:"maxTotalRAMSize"`
+	TotalRAMUsed       int64 `json:"totalRAMUsed"`
+}
+
+// volumeLimits holds the absolute limits reported by Cinder. A negative
+// maximum means that the resource is not limited.
+type volumeLimits struct {
+	MaxTotalVolumes         int64 `json:"maxTotalVolumes"`
+	TotalVolumesUsed        int64 `json:"totalVolumesUsed"`
+	MaxTotalVolumeGigabytes int64 `json:"maxTotalVolumeGigabytes"`
+	TotalGigabytesUsed      int64 `json:"totalGigabytesUsed"`
+}
+
+// RemainingQuotas is part of the environs.QuotaChecker interface.
+//
+// The compute quotas are read from Nova's limits, and the volume quotas
+// from Cinder's limits. Limits which cannot be read, for example because
+// the cloud has no volume endpoint, are omitted.
+func (e *Environ) RemainingQuotas(ctx context.ProviderCallContext) (environs.Quotas, error) {
+	var result environs.Quotas
+	authClient := e.client()
+
+	var compute struct {
+		Limits struct {
+			Absolute computeLimits `json:"absolute"`
+		} `json:"limits"`
+	}
+	if err := authClient.SendRequest(
+		client.GET, "compute", "v2", "limits", &goosehttp.RequestData{RespValue: &compute},
+	); err != nil {
+		handleCredentialError(err, ctx)
+		logger.Debugf("cannot get compute limits: %v", err)
+	} else {
+		limits := compute.Limits.Absolute
+		result.Instances = remaining(limits.MaxTotalInstances, limits.TotalInstancesUsed)
+		result.Cores = remaining(limits.MaxTotalCores, limits.TotalCoresUsed)
+		result.MemoryMiB = remaining(limits.MaxTotalRAMSize, limits.TotalRAMUsed)
+	}
+
+	serviceType, ok := volumeServiceType(authClient, e.cloud().Region)
+	if !ok {
+		return result, nil
+	}
+	var volume struct {
+		Limits struct {
+			Absolute volumeLimits `json:"absolute"`
+		} `json:"limits"`
+	}
+	if err := authClient.SendRequest(
+		client.GET, serviceType, "", "limits", &goosehttp.RequestData{RespValue: &volume},
+	); err != nil {
+		handleCredentialError(err, ctx)
+		logger.Debugf("cannot get volume limits: %v", err)
+	} else {
+		limits := volume.Limits.Absolute
+		result.Volumes = remaining(limits.MaxTotalVolumes, limits.TotalVolumesUsed)
+		result.VolumeGiB = remaining(limits.MaxTotalVolumeGigabytes, limits.TotalGigabytesUsed)
+	}
+	return result, nil
+}
+
+// InstanceQuotaUsage is part of the environs.QuotaChecker interface.
+func (e *Environ) InstanceQuotaUsage(
+	ctx context.ProviderCallContext,
+	cons constraints.Value,
+	volumes []storage.VolumeParams,
+) (environs.QuotaUsage, error) {
+	usage := environs.QuotaUsage{Instances: 1}
+
+	// Count the resources of the smallest flavor matching
+	// the constraints, as that is what StartInstance will pick.
+	flavors, err := e.nova().ListFlavorsDetail()
+	if err != nil {
+		handleCredentialError(err, ctx)
+		return environs.QuotaUsage{}, errors.Trace(err)
+	}
+	var allInstanceTypes []instances.InstanceType
+	for _, flavor := range flavors {
+		if !e.flavorFilter.AcceptFlavor(flavor) {
+			continue
+		}
+		allInstanceTypes = append(allInstanceTypes, instances.InstanceType{
+			Id:       flavor.Id,
+			Name:     flavor.Name,
+			Mem:      uint64(flavor.RAM),
+			CpuCores: uint64(flavor.VCPUs),
+			RootDisk: uint64(flavor.Disk * 1024),
+		})
+	}
+	matchCons := cons
+	matchCons.Arch = nil
+	matchCons.VirtType = nil
+	usingVolumeRootDisk := cons.HasRootDiskSource() && *cons.RootDiskSource == rootDiskSourceVolume
+	if usingVolumeRootDisk {
+		matchCons.RootDisk = nil
+	}
+	matching, err := instances.MatchingInstanceTypes(allInstanceTypes, "", matchCons)
+	if err != nil {
+		return environs.QuotaUsage{}, errors.Trace(err)
+	}
+	usage.Cores = matching[0].CpuCores
+	usage.MemoryMiB = matching[0].Mem
+
+	if usingVolumeRootDisk {
+		rootDiskGiB := common.MinRootDiskSizeGiB("")
+		if cons.HasRootDisk() && mibToGib(*cons.RootDisk) > rootDiskGiB {
+			rootDiskGiB = mibToGib(*cons.RootDisk)
+		}
+		usage.Volumes++
+		usage.VolumeGiB += rootDiskGiB
+	}
+	for _, v := range volumes {
+		if v.Provider != CinderProviderType {
+			continue
+		}
+		usage.Volumes++
+		usage.VolumeGiB += mibToGib(v.Size)
+	}
+	return usage, nil
+}
+
+// volumeServiceType returns the most recent block storage service type
+// in the service catalog for the region, or false if there is none.
+func volumeServiceType(client endpointResolver, region string) (string, bool) {
+	endpointMap := client.EndpointsForRegion(region)
+	for _, serviceType := range []string{"volumev3", "volumev2", "volume"} {
+		if _, ok := endpointMap[serviceType]; ok {
+			return serviceType, true
+		}
+	}
+	return "", false
+}
+
+func remaining(limit, used int64) *uint64 {
+	if limit < 0 {
+		return nil
+	}
+	var result uint64
+	if used < limit {
+		result = uint64(limit - used)
+	}
+	return &result
+}
+
+// mibToGib converts mebibytes to gibibytes, rounding up.
+func mibToGib(m uint64) uint64 {
+	return (m + 1023) / 1024
+}
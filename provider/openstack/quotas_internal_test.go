@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goosehttp "gopkg.in/goose.v2/http"
+	"gopkg.in/goose.v2/identity"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+type quotasInternalSuite struct {
+	testing.IsolationSuite
+
+	client *MockAuthenticatingClient
+}
+
+var _ = gc.Suite(&quotasInternalSuite{})
+
+func (s *quotasInternalSuite) setup(c *gc.C) (*Environ, *gomock.Controller) {
+	ctrl := gomock.NewController(c)
+	s.client = NewMockAuthenticatingClient(ctrl)
+	env := &Environ{
+		cloudUnlocked:  environs.CloudSpec{Region: "foo"},
+		clientUnlocked: s.client,
+	}
+	return env, ctrl
+}
+
+func (s *quotasInternalSuite) expectLimits(svcType, response string) {
+	s.client.EXPECT().SendRequest("GET", svcType, gomock.Any(), "limits", gomock.Any()).DoAndReturn(
+		func(_, _, _, _ string, requestData *goosehttp.RequestData) error {
+			return json.Unmarshal([]byte(response), requestData.RespValue)
+		},
+	)
+}
+
+func (s *quotasInternalSuite) TestRemainingQuotas(c *gc.C) {
+	env, ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.expectLimits("compute", `{"limits": {"absolute": {
+		"maxTotalInstances": 10, "totalInstancesUsed": 4,
+		"maxTotalCores": 20, "totalCoresUsed": 22,
+		"maxTotalRAMSize": -1, "totalRAMUsed": 2048
+	}}}`)
+	s.client.EXPECT().EndpointsForRegion("foo").Return(identity.ServiceURLs{
+		"volumev3": "https://cinder.invalid",
+	})
+	s.expectLimits("volumev3", `{"limits": {"absolute": {
+		"maxTotalVolumes": 10, "totalVolumesUsed": 1,
+		"maxTotalVolumeGigabytes": 1000, "totalGigabytesUsed": 250
+	}}}`)
+
+	quotas, err := env.RemainingQuotas(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, environs.Quotas{
+		Instances: uint64p(6),
+		Cores:     uint64p(0),
+		Volumes:   uint64p(9),
+		VolumeGiB: uint64p(750),
+	})
+
+	err = quotas.Check(environs.QuotaUsage{Instances: 1, Cores: 2, MemoryMiB: 4096})
+	c.Assert(err, gc.ErrorMatches, `cloud quota exceeded for cores \(2 required, 0 remaining\)`)
+	c.Assert(err, jc.Satisfies, errors.IsQuotaLimitExceeded)
+}
+
+func (s *quotasInternalSuite) TestRemainingQuotasNoVolumeEndpoint(c *gc.C) {
+	env, ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.client.EXPECT().SendRequest("GET", "compute", "v2", "limits", gomock.Any()).Return(errors.New("boom"))
+	s.client.EXPECT().EndpointsForRegion("foo").Return(identity.ServiceURLs{})
+
+	quotas, err := env.RemainingQuotas(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, environs.Quotas{})
+}
+
+func uint64p(v uint64) *uint64 {
+	return &v
+}
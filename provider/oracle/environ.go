@@ -661,6 +661,7 @@ func (o *OracleEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (c
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.InstanceLifecycle,
 	}
 
 	// we choose to use the default validator implementation
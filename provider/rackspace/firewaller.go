@@ -76,6 +76,11 @@ func (c *rackspaceFirewaller) SetUpGroups(ctx context.ProviderCallContext, contr
 	return nil, nil
 }
 
+// TagGroups implements OpenstackFirewaller interface.
+func (c *rackspaceFirewaller) TagGroups(ctx context.ProviderCallContext, controllerUUID, machineId string, machineTags, modelTags map[string]string) error {
+	return nil
+}
+
 // OpenInstancePorts implements Firewaller interface.
 func (c *rackspaceFirewaller) OpenInstancePorts(ctx context.ProviderCallContext, inst instances.Instance, machineId string, rules []network.IngressRule) error {
 	return c.changeIngressRules(ctx, inst, true, rules)
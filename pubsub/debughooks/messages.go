@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package debughooks defines the messages which relay a "juju
+// debug-hooks" session, attached through the API, between the API
+// server connection of the client and that of the unit agent.
+package debughooks
+
+// ToAgentTopic is the topic name for the messages sent by a debug-hooks
+// client, and by the API server on its behalf, to the unit agent.
+// data: `Message`
+const ToAgentTopic = "debughooks.to-agent"
+
+// ToClientTopic is the topic name for the messages sent by a unit agent,
+// and by the API server on its behalf, to the debug-hooks client.
+// data: `Message`
+const ToClientTopic = "debughooks.to-client"
+
+// Message types, as sent on the websockets of the client and the agent.
+const (
+	// Attached is sent to the client when the agent starts a shell
+	// for the named hook.
+	Attached = "attached"
+
+	// Input holds input for the shell, sent by the client.
+	Input = "input"
+
+	// EOF is sent by the client to close the input of the shell.
+	EOF = "eof"
+
+	// Output holds output of the shell, sent by the agent.
+	Output = "output"
+
+	// Finished is sent to the client when the shell has exited,
+	// with the exit code which is the result of the hook.
+	Finished = "finished"
+
+	// Ended is sent to the agent when the client has gone away,
+	// and the shell should be killed.
+	Ended = "ended"
+)
+
+// Message types only ever exchanged between API servers.
+const (
+	// Ping asks the API server of a client to reply with a Pong,
+	// to show that the client is still connected.
+	Ping = "ping"
+
+	// Pong is the reply to a Ping.
+	Pong = "pong"
+)
+
+// Message is relayed between the client and the agent of a
+// debug-hooks session.
+type Message struct {
+	// SessionID identifies the debug-hooks session.
+	SessionID string `yaml:"session-id"`
+
+	// Type is one of the message types above.
+	Type string `yaml:"type"`
+
+	// Data holds the base64 encoded input or output of the shell.
+	Data string `yaml:"data,omitempty"`
+
+	// Hook is the name of the hook or action being debugged.
+	Hook string `yaml:"hook,omitempty"`
+
+	// Code is the exit code of the shell.
+	Code int `yaml:"code,omitempty"`
+
+	// Error describes why the shell did not finish cleanly.
+	Error string `yaml:"error,omitempty"`
+}
@@ -18,6 +18,9 @@ import (
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state/storage"
 )
 
 const (
@@ -102,6 +105,11 @@ type actionDoc struct {
 	// against the schema defined by the named action in the unit's charm.
 	Parameters map[string]interface{} `bson:"parameters"`
 
+	// ExecutionTimeout is how long the action may run for before it is
+	// cancelled by the unit agent and marked failed. Zero means the
+	// action may run for as long as it takes.
+	ExecutionTimeout time.Duration `bson:"execution-timeout,omitempty"`
+
 	// Enqueued is the time the action was added.
 	Enqueued time.Time `bson:"enqueued"`
 
@@ -125,8 +133,20 @@ type actionDoc struct {
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
 
+	// ResultsPath is the blobstore path of the action's results,
+	// if they were too large to store in this document.
+	ResultsPath string `bson:"results-path,omitempty"`
+
 	// Logs holds the progress messages logged by the action.
 	Logs []ActionMessage `bson:"messages"`
+
+	// Output holds the chunks of output streamed by the action
+	// while it is running.
+	Output []ActionOutputChunk `bson:"output,omitempty"`
+
+	// OutputTruncated records whether streamed output has been
+	// dropped because the limits on streamed output were reached.
+	OutputTruncated bool `bson:"output-truncated,omitempty"`
 }
 
 // ActionMessage represents a progress message logged by an action.
@@ -145,6 +165,29 @@ func (m ActionMessage) Message() string {
 	return m.MessageValue
 }
 
+// ActionOutputChunk represents a chunk of output written by a running
+// action to its stdout or stderr.
+type ActionOutputChunk struct {
+	StreamValue    string    `bson:"stream"`
+	DataValue      string    `bson:"data"`
+	TimestampValue time.Time `bson:"timestamp"`
+}
+
+// Stream returns the name of the stream the output was written to.
+func (c ActionOutputChunk) Stream() string {
+	return c.StreamValue
+}
+
+// Data returns the output.
+func (c ActionOutputChunk) Data() string {
+	return c.DataValue
+}
+
+// Timestamp returns the time the output was recorded.
+func (c ActionOutputChunk) Timestamp() time.Time {
+	return c.TimestampValue
+}
+
 // action represents an instruction to do some "action" and is expected
 // to match an action definition in a charm.
 type action struct {
@@ -192,6 +235,12 @@ func (a *action) Parameters() map[string]interface{} {
 	return a.doc.Parameters
 }
 
+// ExecutionTimeout returns how long the action may run for before it
+// is cancelled and marked failed. Zero means there is no limit.
+func (a *action) ExecutionTimeout() time.Duration {
+	return a.doc.ExecutionTimeout
+}
+
 // Enqueued returns the time the action was added to state as a pending
 // Action.
 func (a *action) Enqueued() time.Time {
@@ -213,9 +262,18 @@ func (a *action) Status() ActionStatus {
 	return a.doc.Status
 }
 
-// Results returns the structured output of the action and any error.
-func (a *action) Results() (map[string]interface{}, string) {
-	return a.doc.Results, a.doc.Message
+// Results returns the structured output of the action and any error
+// message. Results too large to store with the action are read from
+// the blobstore; an error is returned if they cannot be read.
+func (a *action) Results() (map[string]interface{}, string, error) {
+	if a.doc.ResultsPath == "" {
+		return a.doc.Results, a.doc.Message, nil
+	}
+	results, err := readActionResults(a.st, a.doc.ResultsPath)
+	if err != nil {
+		return nil, a.doc.Message, errors.Annotatef(err, "cannot read results of task %q", a.Id())
+	}
+	return results, a.doc.Message, nil
 }
 
 // Tag implements the Entity interface and returns a names.Tag that
@@ -320,7 +378,7 @@ func (a *action) Cancel() (Action, error) {
 	}
 
 	cancelTime := a.st.nowToTheSecond()
-	removeAndLog := a.removeAndLogBuildTxn(ActionCancelled, nil, "action cancelled via the API",
+	removeAndLog := a.removeAndLogBuildTxn(ActionCancelled, nil, "", "action cancelled via the API",
 		m, parentOperation, cancelTime)
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		err := a.Refresh()
@@ -373,16 +431,34 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 		return nil, errors.Trace(err)
 	}
 
+	results, resultsPath, err := storeActionResults(a.st, a.Id(), results)
+	if errors.IsNotValid(err) {
+		// The results are discarded, so the action can't succeed.
+		finalStatus = ActionFailed
+		if message != "" {
+			message += "; "
+		}
+		message += err.Error()
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	completedTime := a.st.nowToTheSecond()
-	buildTxn := a.removeAndLogBuildTxn(finalStatus, results, message, m, parentOperation, completedTime)
+	buildTxn := a.removeAndLogBuildTxn(finalStatus, results, resultsPath, message, m, parentOperation, completedTime)
 	if err = m.st.db().Run(buildTxn); err != nil {
+		if resultsPath != "" {
+			stor := storage.NewStorage(a.st.ModelUUID(), a.st.MongoSession())
+			if err := stor.Remove(resultsPath); err != nil {
+				actionLogger.Warningf("cannot remove results of task %q: %v", a.Id(), err)
+			}
+		}
 		return nil, errors.Trace(err)
 	}
 	return m.Action(a.Id())
 }
 
 // removeAndLogBuildTxn is shared by Cancel and removeAndLog to correctly finalise an action and it's parent op.
-func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[string]interface{}, message string,
+func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[string]interface{}, resultsPath, message string,
 	m *Model, parentOperation Operation, completedTime time.Time) jujutxn.TransactionSource {
 	return func(attempt int) ([]txn.Op, error) {
 		assertNotComplete := bson.D{{"status", bson.D{
@@ -413,7 +489,10 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 					numComplete++
 				}
 			}
-			if numComplete == len(tasks)-1 {
+			// The operation of a runbook is completed when
+			// the runbook is, rather than by its tasks.
+			isRunbook := len(parentOperation.(*operation).doc.Steps) > 0
+			if numComplete == len(tasks)-1 && !isRunbook {
 				// Set the operation status based on the individual
 				// task status values. eg if any task is failed,
 				// the entire operation is considered failed.
@@ -445,17 +524,26 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 				}
 			}
 		}
+		update := bson.D{
+			{"status", finalStatus},
+			{"message", message},
+			{"results", results},
+			{"completed", completedTime},
+		}
+		if resultsPath != "" {
+			update = append(update, bson.DocElem{"results-path", resultsPath})
+		}
 		ops := []txn.Op{
 			{
 				C:      actionsC,
 				Id:     a.doc.DocId,
 				Assert: assertNotComplete,
-				Update: bson.D{{"$set", bson.D{
-					{"status", finalStatus},
-					{"message", message},
-					{"results", results},
-					{"completed", completedTime},
-				}}},
+				// The output streamed while the action ran is
+				// discarded, as the full output is in its results.
+				Update: bson.D{
+					{"$set", update},
+					{"$unset", bson.D{{"output", nil}}},
+				},
 			}, {
 				C:      actionNotificationsC,
 				Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
@@ -464,6 +552,9 @@ func (a *action) removeAndLogBuildTxn(finalStatus ActionStatus, results map[stri
 		if updateOperationOp != nil {
 			ops = append(ops, *updateOperationOp)
 		}
+		if resultsPath != "" {
+			ops = append(ops, addActionResultsBlobOp(a.st, a.Id(), resultsPath))
+		}
 		return ops, nil
 	}
 }
@@ -522,6 +613,109 @@ func (a *action) Log(message string) error {
 	return errors.Trace(err)
 }
 
+// Output returns the chunks of output streamed by the action while
+// it runs. They are discarded once the action has finished, when its
+// full output is in its results.
+func (a *action) Output() []ActionOutputChunk {
+	// Timestamps are not decoded as UTC, so we need to convert :-(
+	result := make([]ActionOutputChunk, len(a.doc.Output))
+	for i, c := range a.doc.Output {
+		result[i] = ActionOutputChunk{
+			StreamValue:    c.StreamValue,
+			DataValue:      c.DataValue,
+			TimestampValue: c.TimestampValue.UTC(),
+		}
+	}
+	return result
+}
+
+// maxActionOutputChunks is the most chunks of output recorded against
+// an action. The full output is still available from the action's
+// results once it has finished.
+var maxActionOutputChunks = 1000
+
+// actionOutputSize returns the total size of the data in chunks.
+func actionOutputSize(chunks []ActionOutputChunk) int {
+	var size int
+	for _, c := range chunks {
+		size += len(c.DataValue)
+	}
+	return size
+}
+
+// LogOutput adds a chunk of output written to the named stream
+// to the action's streamed output. The streamed output is held in the
+// action document, so output beyond the size of results stored there
+// is dropped; the full output is still available from the action's
+// results, which may be stored in the blobstore.
+func (a *action) LogOutput(stream, data string) error {
+	if stream != actions.StdoutStream && stream != actions.StderrStream {
+		return errors.NotValidf("output stream %q", stream)
+	}
+	m, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	running := bson.D{{"$or", []bson.D{
+		{{"status", ActionRunning}},
+		{{"status", ActionAborting}},
+	}}}
+	var truncated bool
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		truncated = false
+		if attempt > 0 {
+			anAction, err := m.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			a = anAction.(*action)
+		}
+		if s := a.Status(); s != ActionRunning && s != ActionAborting {
+			return nil, errors.Errorf("cannot log output to task %q with status %v", a.Id(), s)
+		}
+		if len(a.doc.Output) >= maxActionOutputChunks ||
+			actionOutputSize(a.doc.Output)+len(data) > maxInlineActionResultsSize {
+			if a.doc.OutputTruncated {
+				return nil, jujutxn.ErrNoOperations
+			}
+			truncated = true
+			return []txn.Op{{
+				C:      actionsC,
+				Id:     a.doc.DocId,
+				Assert: append(bson.D{{"output-truncated", bson.D{{"$ne", true}}}}, running...),
+				Update: bson.D{{"$set", bson.D{{"output-truncated", true}}}},
+			}}, nil
+		}
+		chunk := ActionOutputChunk{
+			StreamValue:    stream,
+			DataValue:      data,
+			TimestampValue: a.st.nowToTheSecond().UTC(),
+		}
+		ops := []txn.Op{
+			{
+				C:  actionsC,
+				Id: a.doc.DocId,
+				// The output array must not have grown to the
+				// chunk limit since the action was read.
+				Assert: append(bson.D{{
+					"output." + strconv.Itoa(maxActionOutputChunks-1), bson.D{{"$exists", false}},
+				}}, running...),
+				Update: bson.D{{"$push", bson.D{{"output", chunk}}}},
+			}}
+		return ops, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	if truncated {
+		actionLogger.Warningf(
+			"task %q exceeded the limit of %d output chunks or %d bytes of output, dropping further output",
+			a.Id(), maxActionOutputChunks, maxInlineActionResultsSize,
+		)
+	}
+	return nil
+}
+
 // newAction builds an Action for the given State and actionDoc.
 func newAction(st *State, adoc actionDoc) Action {
 	return &action{
@@ -540,7 +734,7 @@ func IsNewActionIDSupported(ver version.Number) bool {
 }
 
 // newActionDoc builds the actionDoc with the given name and parameters.
-func newActionDoc(mb modelBackend, operationID string, receiverTag names.Tag, actionName string, parameters map[string]interface{}, modelAgentVersion version.Number) (actionDoc, actionNotificationDoc, error) {
+func newActionDoc(mb modelBackend, operationID string, receiverTag names.Tag, actionName string, parameters map[string]interface{}, executionTimeout time.Duration, modelAgentVersion version.Number) (actionDoc, actionNotificationDoc, error) {
 	prefix := ensureActionMarker(receiverTag.Id())
 	// For actions run on units, we want to use a user friendly action id.
 	// Theoretically, an action receiver could also be a machine, but for
@@ -564,14 +758,15 @@ func newActionDoc(mb modelBackend, operationID string, receiverTag names.Tag, ac
 	actionLogger.Debugf("newActionDoc name: '%s', receiver: '%s', actionId: '%s'", actionName, receiverTag, actionId)
 	modelUUID := mb.modelUUID()
 	return actionDoc{
-			DocId:      mb.docID(actionId),
-			ModelUUID:  modelUUID,
-			Receiver:   receiverTag.Id(),
-			Name:       actionName,
-			Parameters: parameters,
-			Enqueued:   mb.nowToTheSecond(),
-			Operation:  operationID,
-			Status:     ActionPending,
+			DocId:            mb.docID(actionId),
+			ModelUUID:        modelUUID,
+			Receiver:         receiverTag.Id(),
+			Name:             actionName,
+			Parameters:       parameters,
+			ExecutionTimeout: executionTimeout,
+			Enqueued:         mb.nowToTheSecond(),
+			Operation:        operationID,
+			Status:           ActionPending,
 		}, actionNotificationDoc{
 			DocId:     mb.docID(prefix + actionId),
 			ModelUUID: modelUUID,
@@ -688,8 +883,9 @@ func (m *Model) FindActionsByName(name string) ([]Action, error) {
 	return results, errors.Trace(iter.Close())
 }
 
-// EnqueueAction caches the action doc to the database.
-func (m *Model) EnqueueAction(operationID string, receiver names.Tag, actionName string, payload map[string]interface{}) (Action, error) {
+// EnqueueAction caches the action doc to the database. A non-zero
+// executionTimeout limits how long the action may run for.
+func (m *Model) EnqueueAction(operationID string, receiver names.Tag, actionName string, payload map[string]interface{}, executionTimeout time.Duration) (Action, error) {
 	if len(actionName) == 0 {
 		return nil, errors.New("action name required")
 	}
@@ -702,7 +898,7 @@ func (m *Model) EnqueueAction(operationID string, receiver names.Tag, actionName
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	doc, ndoc, err := newActionDoc(m.st, operationID, receiver, actionName, payload, agentVersion)
+	doc, ndoc, err := newActionDoc(m.st, operationID, receiver, actionName, payload, executionTimeout, agentVersion)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -818,6 +1014,11 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 // only logs newer than <maxLogTime> remain and also ensures
 // that the actions collection is smaller than <maxLogsMB> after the deletion.
 func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
+	// Runbooks whose clients have gone away are finished first,
+	// so that they can be pruned like any other operation.
+	if err := failAbandonedRunbooks(st); err != nil {
+		return errors.Trace(err)
+	}
 	// There may be older actions without parent operations so try those first.
 	hasNoOperation := bson.D{{"$or", []bson.D{
 		{{"operation", ""}},
@@ -847,5 +1048,10 @@ func PruneOperations(st *State, maxHistoryTime time.Duration, maxHistoryMB int)
 	sizeFactor := float64(actionsCount) / float64(operationsCount)
 
 	err = pruneCollectionAndChildren(st, maxHistoryTime, maxHistoryMB, operationsC, "completed", actionsC, "operation", nil, sizeFactor, GoTime)
-	return errors.Trace(err)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Results stored in the blobstore don't count towards the size
+	// of the actions collection, but go with the pruned actions.
+	return errors.Trace(pruneActionResultBlobs(st))
 }
@@ -12,6 +12,7 @@ import (
 	"unicode"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/txn"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/storage"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -91,7 +93,7 @@ func (s *ActionSuite) SetUpTest(c *gc.C) {
 func (s *ActionSuite) TestActionTag(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.unit.AddAction(operationID, "snapshot", nil)
+	action, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	tag := action.Tag()
@@ -155,7 +157,7 @@ func (s *ActionSuite) TestAddAction(c *gc.C) {
 		// Verify we can add an Action
 		operationID, err := s.Model.EnqueueOperation("a test")
 		c.Assert(err, jc.ErrorIsNil)
-		a, err := t.whichUnit.AddAction(operationID, t.name, params)
+		a, err := t.whichUnit.AddAction(operationID, t.name, params, 0)
 
 		if t.expectedErr == "" {
 			c.Assert(err, jc.ErrorIsNil)
@@ -276,7 +278,7 @@ act:
 		// is tested in the gojsonschema package.
 		operationID, err := s.Model.EnqueueOperation("a test")
 		c.Assert(err, jc.ErrorIsNil)
-		action, err := u.AddAction(operationID, "act", t.params)
+		action, err := u.AddAction(operationID, "act", t.params, 0)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Check(action.Parameters(), jc.DeepEquals, t.expectedParams)
 	}
@@ -291,9 +293,9 @@ func (s *ActionSuite) TestActionBeginStartsOperation(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	anAction, err = anAction.Begin()
@@ -323,9 +325,9 @@ func (s *ActionSuite) TestActionBeginStartsOperationRace(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	defer state.SetBeforeHooks(c, s.State, func() {
@@ -353,9 +355,9 @@ func (s *ActionSuite) TestLastActionFinishCompletesOperation(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	anAction, err = anAction.Begin()
@@ -395,9 +397,9 @@ func (s *ActionSuite) TestLastActionFinishCompletesOperationRace(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction2, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	anAction, err = anAction.Begin()
@@ -440,7 +442,7 @@ func (s *ActionSuite) TestActionMessages(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(anAction.Messages(), gc.HasLen, 0)
 
@@ -472,6 +474,110 @@ func (s *ActionSuite) TestActionMessages(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot log message to task "2" with status completed`)
 }
 
+func (s *ActionSuite) TestActionOutput(c *gc.C) {
+	s.toSupportNewActionID(c)
+
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(anAction.Output(), gc.HasLen, 0)
+
+	// Cannot log output until action is running.
+	err = anAction.LogOutput("stdout", "hello")
+	c.Assert(err, gc.ErrorMatches, `cannot log output to task "2" with status pending`)
+
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = anAction.LogOutput("stdin", "hello")
+	c.Assert(err, gc.ErrorMatches, `output stream "stdin" not valid`)
+
+	chunks := []struct{ stream, data string }{
+		{"stdout", "one\n"},
+		{"stderr", "two\n"},
+		{"stdout", "three"},
+	}
+	for i, chunk := range chunks {
+		err = anAction.LogOutput(chunk.stream, chunk.data)
+		c.Assert(err, jc.ErrorIsNil)
+
+		a, err := s.Model.Action(anAction.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		obtained := a.Output()
+		c.Assert(obtained, gc.HasLen, i+1)
+		for j, ao := range obtained {
+			c.Assert(ao.Timestamp(), gc.Equals, clock.Now().UTC())
+			c.Assert(ao.Stream(), gc.Equals, chunks[j].stream)
+			c.Assert(ao.Data(), gc.Equals, chunks[j].data)
+		}
+	}
+
+	// The streamed output is discarded when the action finishes,
+	// leaving the full output in its results.
+	finished, err := anAction.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(finished.Output(), gc.HasLen, 0)
+
+	// Cannot log output after action finishes.
+	err = anAction.LogOutput("stdout", "hello")
+	c.Assert(err, gc.ErrorMatches, `cannot log output to task "2" with status completed`)
+}
+
+func (s *ActionSuite) TestLogOutputSizeLimit(c *gc.C) {
+	s.PatchValue(state.MaxInlineActionResultsSize, 10)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = anAction.LogOutput("stdout", "12345")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err = s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = anAction.LogOutput("stdout", "1234567")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Output which would take the streamed output beyond the
+	// size of results stored with the action is dropped.
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	obtained := a.Output()
+	c.Assert(obtained, gc.HasLen, 1)
+	c.Assert(obtained[0].Data(), gc.Equals, "12345")
+}
+
+func (s *ActionSuite) TestLogOutputChunkLimit(c *gc.C) {
+	s.PatchValue(state.MaxActionOutputChunks, 2)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, data := range []string{"one", "two", "three", "four"} {
+		anAction, err = s.Model.Action(anAction.Id())
+		c.Assert(err, jc.ErrorIsNil)
+		err = anAction.LogOutput("stdout", data)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Only the chunks up to the limit are recorded.
+	a, err := s.Model.Action(anAction.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	obtained := a.Output()
+	c.Assert(obtained, gc.HasLen, 2)
+	c.Assert(obtained[0].Data(), gc.Equals, "one")
+	c.Assert(obtained[1].Data(), gc.Equals, "two")
+}
+
 func (s *ActionSuite) toSupportNewActionID(c *gc.C) {
 	ver, err := s.Model.AgentVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -501,7 +607,7 @@ func (s *ActionSuite) TestActionLogMessageRace(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction, err := s.unit.AddAction(operationID, "snapshot", nil)
+	anAction, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(anAction.Messages(), gc.HasLen, 0)
 
@@ -555,12 +661,12 @@ func (s *ActionSuite) TestEnqueueActionRequiresName(c *gc.C) {
 	// verify can not enqueue an Action without a name
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = s.model.EnqueueAction(operationID, s.unit.Tag(), name, nil)
+	_, err = s.model.EnqueueAction(operationID, s.unit.Tag(), name, nil, 0)
 	c.Assert(err, gc.ErrorMatches, "action name required")
 }
 
 func (s *ActionSuite) TestEnqueueActionRequiresValidOperation(c *gc.C) {
-	_, err := s.model.EnqueueAction("666", s.unit.Tag(), "test", nil)
+	_, err := s.model.EnqueueAction("666", s.unit.Tag(), "test", nil, 0)
 	c.Assert(err, gc.ErrorMatches, `operation "666" not found`)
 }
 
@@ -572,10 +678,10 @@ func (s *ActionSuite) TestAddActionAcceptsDuplicateNames(c *gc.C) {
 	// verify can add two actions with same name
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	a1, err := s.unit.AddAction(operationID, name, params1)
+	a1, err := s.unit.AddAction(operationID, name, params1, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
-	a2, err := s.unit.AddAction(operationID, name, params2)
+	a2, err := s.unit.AddAction(operationID, name, params2, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	c.Assert(a1.Id(), gc.Not(gc.Equals), a2.Id())
@@ -617,7 +723,7 @@ func (s *ActionSuite) TestAddActionLifecycle(c *gc.C) {
 	// can add action to a dying unit
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{})
+	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{}, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// make sure unit is dead
@@ -625,7 +731,7 @@ func (s *ActionSuite) TestAddActionLifecycle(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// cannot add action to a dead unit
-	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{})
+	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{}, 0)
 	c.Assert(err, gc.Equals, state.ErrDead)
 }
 
@@ -644,7 +750,7 @@ func (s *ActionSuite) TestAddActionFailsOnDeadUnitInTransaction(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{})
+	_, err = unit.AddAction(operationID, "snapshot", map[string]interface{}{}, 0)
 	c.Assert(err, gc.Equals, state.ErrDead)
 }
 
@@ -656,7 +762,7 @@ func (s *ActionSuite) TestFail(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	a, err := unit.AddAction(operationID, "snapshot", nil)
+	a, err := unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := s.State.Model()
@@ -690,7 +796,9 @@ func (s *ActionSuite) TestFail(c *gc.C) {
 	c.Assert(diff >= 0, jc.IsTrue)
 	c.Assert(diff < testing.LongWait, jc.IsTrue)
 
-	res, errstr := results[0].Results()
+	res, errstr, err := results[0].Results()
+
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errstr, gc.Equals, reason)
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 
@@ -708,7 +816,7 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	a, err := unit.AddAction(operationID, "snapshot", nil)
+	a, err := unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := s.State.Model()
@@ -735,7 +843,8 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 
 	c.Assert(results[0].Name(), gc.Equals, action.Name())
 	c.Assert(results[0].Status(), gc.Equals, state.ActionCompleted)
-	res, errstr := results[0].Results()
+	res, errstr, err := results[0].Results()
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errstr, gc.Equals, "")
 	c.Assert(res, gc.DeepEquals, output)
 
@@ -745,6 +854,83 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestCompleteStoresLargeResults(c *gc.C) {
+	s.PatchValue(state.MaxInlineActionResultsSize, 100)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{"Stdout": strings.Repeat("x", 200)}
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionCompleted)
+
+	path, inline := state.ActionResultsPath(a)
+	c.Assert(path, gc.Equals, "actionresults/"+a.Id())
+	c.Assert(inline, gc.IsNil)
+	res, errstr, err := a.Results()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errstr, gc.Equals, "")
+	c.Assert(res, gc.DeepEquals, output)
+	c.Assert(state.ActionResultBlobCount(c, s.State), gc.Equals, 1)
+}
+
+func (s *ActionSuite) TestResultsUnreadable(c *gc.C) {
+	s.PatchValue(state.MaxInlineActionResultsSize, 100)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{"Stdout": strings.Repeat("x", 200)}
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+
+	path, _ := state.ActionResultsPath(a)
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	err = stor.Remove(path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	res, _, err := a.Results()
+	c.Assert(err, gc.ErrorMatches, `cannot read results of task "`+a.Id()+`": .*`)
+	c.Assert(res, gc.IsNil)
+}
+
+func (s *ActionSuite) TestCompleteSmallResultsInline(c *gc.C) {
+	s.PatchValue(state.MaxInlineActionResultsSize, 100)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{"Stdout": "x"}
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+
+	path, inline := state.ActionResultsPath(a)
+	c.Assert(path, gc.Equals, "")
+	c.Assert(inline, gc.DeepEquals, output)
+	c.Assert(state.ActionResultBlobCount(c, s.State), gc.Equals, 0)
+}
+
+func (s *ActionSuite) TestCompleteResultsTooLarge(c *gc.C) {
+	s.PatchValue(state.MaxActionResultsSize, 100)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := map[string]interface{}{"Stdout": strings.Repeat("x", 200)}
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Status(), gc.Equals, state.ActionFailed)
+	res, errstr, err := a.Results()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errstr, gc.Matches, `action results of \d+ bytes exceed the limit of 100 bytes`)
+	c.Assert(res, gc.HasLen, 0)
+}
+
 func (s *ActionSuite) TestFindActionTagsById(c *gc.C) {
 	s.toSupportNewActionID(c)
 
@@ -761,7 +947,7 @@ func (s *ActionSuite) TestFindActionTagsById(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	for _, action := range actions {
-		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), action.Name, action.Parameters)
+		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), action.Name, action.Parameters, 0)
 		c.Check(err, gc.Equals, nil)
 	}
 
@@ -785,7 +971,7 @@ func (s *ActionSuite) TestFindActionTagsByLegacyId(c *gc.C) {
 	var actionToUse state.Action
 	var uuid string
 	for {
-		a, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "action-1", nil)
+		a, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "action-1", nil, 0)
 		c.Assert(err, jc.ErrorIsNil)
 		if unicode.IsDigit(rune(a.Id()[0])) {
 			idNum, _ := strconv.Atoi(a.Id()[0:1])
@@ -804,7 +990,7 @@ func (s *ActionSuite) TestFindActionTagsByLegacyId(c *gc.C) {
 	s.toSupportNewActionID(c)
 	idNum, _ := strconv.Atoi(actionToUse.Id()[0:1])
 	for i := 1; i <= idNum; i++ {
-		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "action-1", nil)
+		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "action-1", nil, 0)
 		c.Assert(err, jc.ErrorIsNil)
 	}
 
@@ -839,7 +1025,7 @@ func (s *ActionSuite) TestFindActionsByName(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	for _, action := range actions {
-		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), action.Name, action.Parameters)
+		_, err := s.model.EnqueueAction(operationID, s.unit.Tag(), action.Name, action.Parameters, 0)
 		c.Assert(err, gc.Equals, nil)
 	}
 
@@ -874,9 +1060,9 @@ func (s *ActionSuite) TestActionsWatcherEmitsInitialChanges(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	// queue up actions
-	a1, err := u.AddAction(operationID, "snapshot", nil)
+	a1, err := u.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	a2, err := u.AddAction(operationID, "snapshot", nil)
+	a2, err := u.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// start watcher but don't consume Changes() yet
@@ -909,9 +1095,9 @@ func (s *ActionSuite) TestUnitWatchActionNotifications(c *gc.C) {
 	// queue some actions before starting the watcher
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	fa1, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa1, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	fa2, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa2, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	s.WaitForModelWatchersIdle(c, s.State.ModelUUID())
 
@@ -933,7 +1119,7 @@ func (s *ActionSuite) TestUnitWatchActionNotifications(c *gc.C) {
 
 	// add action on unit2 and makes sure unit1 watcher doesn't trigger
 	// and unit2 watcher does
-	fa3, err := unit2.AddAction(operationID, "snapshot", nil)
+	fa3, err := unit2.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertNoChange()
 	expect2 := expectActionIds(fa3)
@@ -941,9 +1127,9 @@ func (s *ActionSuite) TestUnitWatchActionNotifications(c *gc.C) {
 	wc2.AssertNoChange()
 
 	// add a couple actions on unit1 and make sure watcher sees events
-	fa4, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa4, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	fa5, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa5, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	expect = expectActionIds(fa4, fa5)
@@ -1082,11 +1268,11 @@ func (s *ActionSuite) TestWatchActionNotifications(c *gc.C) {
 	// add 3 actions
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	fa1, err := u.AddAction(operationID, "snapshot", nil)
+	fa1, err := u.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	fa2, err := u.AddAction(operationID, "snapshot", nil)
+	fa2, err := u.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	fa3, err := u.AddAction(operationID, "snapshot", nil)
+	fa3, err := u.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 
 	model, err := s.State.Model()
@@ -1146,7 +1332,7 @@ func (s *ActionSuite) TestActionStatusWatcher(c *gc.C) {
 	for _, tcase := range testCase {
 		operationID, err := s.Model.EnqueueOperation("a test")
 		c.Assert(err, jc.ErrorIsNil)
-		a, err := tcase.receiver.AddAction(operationID, tcase.name, nil)
+		a, err := tcase.receiver.AddAction(operationID, tcase.name, nil, 0)
 		c.Assert(err, jc.ErrorIsNil)
 
 		model, err := s.State.Model()
@@ -1190,7 +1376,7 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	// queue some actions before starting the watcher
-	fa1, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa1, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	fa1, err = fa1.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1198,7 +1384,7 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Ensure no cross contamination - add another action.
-	fa2, err := unit1.AddAction(operationID, "snapshot", nil)
+	fa2, err := unit1.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	fa2, err = fa2.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1292,6 +1478,77 @@ func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
 	checkExpected(wc2, expected)
 }
 
+func (s *ActionSuite) TestWatchActionOutput(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	fa1, err = fa1.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput("stdout", "first\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ensure no cross contamination - add another action.
+	fa2, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	fa2, err = fa2.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa2.LogOutput("stdout", "another\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.WaitForModelWatchersIdle(c, s.State.ModelUUID())
+
+	checkExpected := func(wc statetesting.StringsWatcherC, expected []actions.ActionOutput) {
+		var ch []string
+		s.State.StartSync()
+		select {
+		case ch = <-wc.Watcher.Changes():
+		case <-time.After(testing.LongWait):
+			c.Fatalf("watcher did not send change")
+		}
+		var output []actions.ActionOutput
+		for _, chStr := range ch {
+			var gotOutput actions.ActionOutput
+			err := json.Unmarshal([]byte(chStr), &gotOutput)
+			c.Assert(err, jc.ErrorIsNil)
+			// We can't control the actual time so check
+			// it is set and then ignore it.
+			c.Assert(gotOutput.Timestamp.IsZero(), jc.IsFalse)
+			gotOutput.Timestamp = time.Time{}
+			output = append(output, gotOutput)
+		}
+		c.Assert(output, jc.DeepEquals, expected)
+		wc.AssertNoChange()
+	}
+
+	w := s.State.WatchActionOutput(fa1.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	checkExpected(wc, []actions.ActionOutput{
+		{Stream: "stdout", Data: "first\n"},
+	})
+
+	// Only new output is reported.
+	err = fa1.LogOutput("stderr", "oops\n")
+	c.Assert(err, jc.ErrorIsNil)
+	err = fa1.LogOutput("stdout", "second\n")
+	c.Assert(err, jc.ErrorIsNil)
+	checkExpected(wc, []actions.ActionOutput{
+		{Stream: "stderr", Data: "oops\n"},
+		{Stream: "stdout", Data: "second\n"},
+	})
+
+	// But a new watcher sees all the output.
+	w2 := s.State.WatchActionOutput(fa1.Id())
+	defer statetesting.AssertStop(c, w2)
+	wc2 := statetesting.NewStringsWatcherC(c, s.State, w2)
+	checkExpected(wc2, []actions.ActionOutput{
+		{Stream: "stdout", Data: "first\n"},
+		{Stream: "stderr", Data: "oops\n"},
+		{Stream: "stdout", Data: "second\n"},
+	})
+}
+
 func (s *ActionSuite) TestWatchActionResults(c *gc.C) {
 	w := s.Model.WatchActionResultsFilteredBy(s.unit)
 	defer statetesting.AssertStop(c, w)
@@ -1300,7 +1557,7 @@ func (s *ActionSuite) TestWatchActionResults(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	// Queue some actions before starting the watcher.
-	fa1, err := s.unit.AddAction(operationID, "snapshot", nil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	fa1, err = fa1.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1322,14 +1579,14 @@ func (s *ActionSuite) TestWatchFilteredActionResults(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
 	// Queue some actions before starting the watcher.
-	fa1, err := s.unit.AddAction(operationID, "snapshot", nil)
+	fa1, err := s.unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	fa1, err = fa1.Begin()
 	c.Assert(err, jc.ErrorIsNil)
 	// Initial event.
 	wc.AssertChange()
 
-	fa2, err := s.unit2.AddAction(operationID, "snapshot", nil)
+	fa2, err := s.unit2.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	fa2, err = fa2.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1388,7 +1645,7 @@ type mockAR struct {
 
 var _ state.ActionReceiver = (*mockAR)(nil)
 
-func (r mockAR) AddAction(operationID, name string, payload map[string]interface{}) (state.Action, error) {
+func (r mockAR) AddAction(operationID, name string, payload map[string]interface{}, executionTimeout time.Duration) (state.Action, error) {
 	return nil, nil
 }
 func (r mockAR) CancelAction(state.Action) (state.Action, error)       { return nil, nil }
@@ -1534,6 +1791,32 @@ func (s *ActionPruningSuite) TestPruneOperationsByAge(c *gc.C) {
 	c.Assert(ops, gc.HasLen, numCurrentOperationEntries)
 }
 
+func (s *ActionPruningSuite) TestPruneOperationsRemovesStoredResults(c *gc.C) {
+	s.PatchValue(state.MaxInlineActionResultsSize, 100)
+	clock := testclock.NewClock(time.Now().Add(-10 * time.Hour))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := unit.AddAction(operationID, "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	output := map[string]interface{}{"Stdout": strings.Repeat("x", 200)}
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(state.ActionResultBlobCount(c, s.State), gc.Equals, 1)
+
+	clock.Advance(10 * time.Hour)
+	err = state.PruneOperations(s.State, 1*time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.Model.Action(a.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(state.ActionResultBlobCount(c, s.State), gc.Equals, 0)
+}
+
 // Pruner should not prune operations with age of epoch time since the epoch is a
 // special value denoting an incomplete operation.
 func (s *ActionPruningSuite) TestDoNotPruneIncompleteOperations(c *gc.C) {
@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+var (
+	// maxInlineActionResultsSize is the largest size, encoded, of
+	// results stored in the action document. Larger results are
+	// stored in the model's blobstore.
+	maxInlineActionResultsSize = 64 * 1024
+
+	// maxActionResultsSize is the largest size, encoded, of the
+	// results of an action. An action which returns larger results
+	// fails, and its results are discarded.
+	maxActionResultsSize = 8 * 1024 * 1024
+)
+
+// actionResultsBlobDoc records the results of an action which are
+// stored in the model's blobstore. Its id is that of the action, and it
+// outlives the action document so that the blob can be removed once the
+// action has been pruned.
+type actionResultsBlobDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	ActionID  string `bson:"action-id"`
+	Path      string `bson:"path"`
+}
+
+// actionResultsBlob is the content of a stored results blob.
+type actionResultsBlob struct {
+	Results map[string]interface{} `bson:"results"`
+}
+
+// actionResultsPath returns the blobstore path
+// holding the results of the identified action.
+func actionResultsPath(actionID string) string {
+	return "actionresults/" + actionID
+}
+
+// storeActionResults checks the size of an action's results, and stores
+// them in the blobstore if they are too large for the action document.
+// It returns the results to store in the action document, and the path
+// of the stored blob, which is empty if the results were not stored. If
+// the results are larger than the limit, a NotValid error is returned.
+func storeActionResults(st *State, actionID string, results map[string]interface{}) (map[string]interface{}, string, error) {
+	if len(results) == 0 {
+		return results, "", nil
+	}
+	data, err := bson.Marshal(actionResultsBlob{Results: results})
+	if err != nil {
+		return nil, "", errors.Annotate(err, "encoding action results")
+	}
+	if len(data) > maxActionResultsSize {
+		return nil, "", errors.NewNotValid(nil, fmt.Sprintf(
+			"action results of %d bytes exceed the limit of %d bytes", len(data), maxActionResultsSize))
+	}
+	if len(data) <= maxInlineActionResultsSize {
+		return results, "", nil
+	}
+	path := actionResultsPath(actionID)
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(path, bytes.NewReader(data), int64(len(data))); err != nil {
+		return nil, "", errors.Annotate(err, "storing action results")
+	}
+	return nil, path, nil
+}
+
+// addActionResultsBlobOp returns the operation which records that
+// the results of the identified action are stored at path.
+func addActionResultsBlobOp(st *State, actionID, path string) txn.Op {
+	return txn.Op{
+		C:      actionResultBlobsC,
+		Id:     st.docID(actionID),
+		Assert: txn.DocMissing,
+		Insert: &actionResultsBlobDoc{
+			ActionID: actionID,
+			Path:     path,
+		},
+	}
+}
+
+// readActionResults returns the results of an action stored at path.
+func readActionResults(st *State, path string) (map[string]interface{}, error) {
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	r, _, err := stor.Get(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var blob actionResultsBlob
+	if err := bson.Unmarshal(data, &blob); err != nil {
+		return nil, errors.Annotate(err, "decoding action results")
+	}
+	return blob.Results, nil
+}
+
+// pruneActionResultBlobs removes the stored results of actions which
+// no longer exist.
+func pruneActionResultBlobs(st *State) error {
+	blobs, closer := st.db().GetCollection(actionResultBlobsC)
+	defer closer()
+	actions, closer := st.db().GetCollection(actionsC)
+	defer closer()
+
+	var docs []actionResultsBlobDoc
+	if err := blobs.Find(nil).All(&docs); err != nil {
+		return errors.Annotate(err, "reading stored action results")
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	var removed int
+	for _, doc := range docs {
+		n, err := actions.FindId(doc.ActionID).Count()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if n > 0 {
+			continue
+		}
+		if err := stor.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing results of action %q", doc.ActionID)
+		}
+		ops := []txn.Op{{
+			C:      actionResultBlobsC,
+			Id:     doc.DocId,
+			Remove: true,
+		}}
+		if err := st.db().RunTransaction(ops); err != nil {
+			return errors.Trace(err)
+		}
+		removed++
+	}
+	if removed > 0 {
+		logger.Debugf("removed the stored results of %d pruned actions", removed)
+	}
+	return nil
+}
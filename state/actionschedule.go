@@ -0,0 +1,529 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// ActionScheduleOverlap determines what is done when a scheduled
+// action is due while the operation of its previous run is still
+// pending or running.
+type ActionScheduleOverlap string
+
+const (
+	// ActionScheduleSkip skips the run, which is recorded as skipped.
+	ActionScheduleSkip ActionScheduleOverlap = "skip"
+
+	// ActionScheduleQueue queues the run behind the previous one.
+	ActionScheduleQueue ActionScheduleOverlap = "queue"
+)
+
+// actionScheduleHistoryLimit is the number of runs
+// recorded in the history of an action schedule.
+const actionScheduleHistoryLimit = 20
+
+// AddActionScheduleArgs holds the parameters for adding
+// an action schedule to a model.
+type AddActionScheduleArgs struct {
+	// Receiver is the unit on which the action is run, the
+	// application on all of whose units it is run, or the
+	// application leader, as <application>/leader.
+	Receiver string
+
+	// ActionName is the name of the action to run.
+	ActionName string
+
+	// Parameters holds the parameters of the action.
+	Parameters map[string]interface{}
+
+	// Schedule is the cron schedule on which the action is run.
+	Schedule string
+
+	// Overlap determines what is done when the action is due while
+	// its previous run is not complete. It defaults to skipping the run.
+	Overlap ActionScheduleOverlap
+
+	// Owner is the name of the user who added the schedule.
+	Owner string
+}
+
+// ActionScheduleRun records a time a scheduled action was due.
+type ActionScheduleRun struct {
+	// Time is the time the action was due.
+	Time time.Time
+
+	// OperationId is the id of the operation enqueued
+	// to run the action, if it was not skipped.
+	OperationId string
+
+	// Skipped is true if the run was skipped because
+	// the previous run was not complete.
+	Skipped bool
+
+	// Error holds the reason the action could not be
+	// enqueued on any of the receivers.
+	Error string
+}
+
+type actionScheduleDoc struct {
+	DocId      string                 `bson:"_id"`
+	Id         string                 `bson:"schedule-id"`
+	Receiver   string                 `bson:"receiver"`
+	ActionName string                 `bson:"action-name"`
+	Parameters map[string]interface{} `bson:"parameters"`
+	Schedule   string                 `bson:"schedule"`
+	Overlap    ActionScheduleOverlap  `bson:"overlap"`
+	Owner      string                 `bson:"owner"`
+	Created    int64                  `bson:"created"`
+	Paused     bool                   `bson:"paused"`
+
+	// LastRun is the time at which the action was last due, used to
+	// ensure that each run is triggered only once.
+	LastRun       int64                  `bson:"last-run"`
+	LastOperation string                 `bson:"last-operation"`
+	History       []actionScheduleRunDoc `bson:"history"`
+}
+
+type actionScheduleRunDoc struct {
+	Time        int64  `bson:"time"`
+	OperationId string `bson:"operation-id,omitempty"`
+	Skipped     bool   `bson:"skipped,omitempty"`
+	Error       string `bson:"error,omitempty"`
+}
+
+// ActionSchedule runs an action on a cron schedule. The controller
+// enqueues an operation to run the action each time it is due.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the id of the schedule, unique within the model.
+func (s *ActionSchedule) Id() string {
+	return s.doc.Id
+}
+
+// Receiver returns the unit, application or application leader
+// on which the action is run.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// ActionName returns the name of the action to run.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters of the action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Schedule returns the cron schedule on which the action is run.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Overlap returns what is done when the action is due
+// while its previous run is not complete.
+func (s *ActionSchedule) Overlap() ActionScheduleOverlap {
+	return s.doc.Overlap
+}
+
+// Owner returns the name of the user who added the schedule.
+func (s *ActionSchedule) Owner() string {
+	return s.doc.Owner
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return time.Unix(0, s.doc.Created).UTC()
+}
+
+// Paused returns whether the action is not run when due.
+func (s *ActionSchedule) Paused() bool {
+	return s.doc.Paused
+}
+
+// LastRun returns the time the action was last due, or the
+// zero time if it has never been due.
+func (s *ActionSchedule) LastRun() time.Time {
+	if s.doc.LastRun == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, s.doc.LastRun).UTC()
+}
+
+// NextRun returns the first time the action is due after
+// it was last due, or after the schedule was created.
+func (s *ActionSchedule) NextRun() (time.Time, error) {
+	schedule, err := actions.ParseSchedule(s.doc.Schedule)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	from := s.Created()
+	if lastRun := s.LastRun(); lastRun.After(from) {
+		from = lastRun
+	}
+	return schedule.Next(from).UTC(), nil
+}
+
+// History returns the most recent times the action was due, oldest first.
+func (s *ActionSchedule) History() []ActionScheduleRun {
+	history := make([]ActionScheduleRun, len(s.doc.History))
+	for i, run := range s.doc.History {
+		history[i] = ActionScheduleRun{
+			Time:        time.Unix(0, run.Time).UTC(),
+			OperationId: run.OperationId,
+			Skipped:     run.Skipped,
+			Error:       run.Error,
+		}
+	}
+	return history
+}
+
+// Refresh refreshes the contents of the schedule.
+func (s *ActionSchedule) Refresh() error {
+	doc, err := getActionScheduleDoc(s.st, s.doc.Id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	s.doc = *doc
+	return nil
+}
+
+// SetPaused sets whether the action is run when due. The runs
+// due while the schedule is paused are not made up when it is
+// resumed.
+func (s *ActionSchedule) SetPaused(paused bool) error {
+	update := bson.D{{"paused", paused}}
+	if !paused {
+		// Start afresh from now, rather than from the last run.
+		update = append(update, bson.DocElem{"last-run", s.st.clock().Now().UnixNano()})
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", update}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", s.doc.Id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot update action schedule %q", s.doc.Id)
+	}
+	return s.Refresh()
+}
+
+// Remove removes the schedule. Operations already
+// enqueued by the schedule are not affected.
+func (s *ActionSchedule) Remove() error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Remove: true,
+	}}
+	return errors.Annotatef(s.st.db().RunTransaction(ops), "cannot remove action schedule %q", s.doc.Id)
+}
+
+// Trigger enqueues an operation to run the action, which was due at the
+// specified time. If the operation of the previous run is not complete,
+// the run is skipped or queued according to the schedule's overlap.
+// Each run is claimed before its operation is enqueued, so that it is
+// triggered only once, and a controller taking over from another does
+// not repeat runs. The run is recorded in the history of the schedule,
+// and returned.
+func (s *ActionSchedule) Trigger(due time.Time) (ActionScheduleRun, error) {
+	if err := s.Refresh(); err != nil {
+		return ActionScheduleRun{}, errors.Trace(err)
+	}
+	if s.doc.Paused {
+		return ActionScheduleRun{}, errors.NotValidf("triggering paused action schedule %q", s.doc.Id)
+	}
+	if due.UnixNano() <= s.doc.LastRun {
+		return ActionScheduleRun{}, errors.AlreadyExistsf("run of action schedule %q due at %v", s.doc.Id, due)
+	}
+	overlapping, err := s.previousRunIncomplete()
+	if err != nil {
+		return ActionScheduleRun{}, errors.Trace(err)
+	}
+
+	claimOps := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"last-run", s.doc.LastRun}},
+		Update: bson.D{{"$set", bson.D{{"last-run", due.UnixNano()}}}},
+	}}
+	if err := s.st.db().RunTransaction(claimOps); err == txn.ErrAborted {
+		return ActionScheduleRun{}, errors.AlreadyExistsf("run of action schedule %q due at %v", s.doc.Id, due)
+	} else if err != nil {
+		return ActionScheduleRun{}, errors.Annotatef(err, "cannot claim run of action schedule %q", s.doc.Id)
+	}
+	s.doc.LastRun = due.UnixNano()
+
+	run := ActionScheduleRun{Time: due.UTC()}
+	if overlapping && s.doc.Overlap != ActionScheduleQueue {
+		run.Skipped = true
+	} else {
+		run.OperationId, err = s.enqueue()
+		if err != nil {
+			run.Error = err.Error()
+		}
+	}
+
+	// Only an operation with tasks is waited
+	// for by the overlap of later runs.
+	lastOperation := s.doc.LastOperation
+	if run.OperationId != "" && run.Error == "" {
+		lastOperation = run.OperationId
+	}
+	history := append(s.doc.History, actionScheduleRunDoc{
+		Time:        due.UnixNano(),
+		OperationId: run.OperationId,
+		Skipped:     run.Skipped,
+		Error:       run.Error,
+	})
+	if n := len(history); n > actionScheduleHistoryLimit {
+		history = history[n-actionScheduleHistoryLimit:]
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"last-run", due.UnixNano()}},
+		Update: bson.D{{"$set", bson.D{
+			{"last-operation", lastOperation},
+			{"history", history},
+		}}},
+	}}
+	if err := s.st.db().RunTransaction(ops); err != nil {
+		return ActionScheduleRun{}, errors.Annotatef(err, "cannot record run of action schedule %q", s.doc.Id)
+	}
+	s.doc.LastOperation = lastOperation
+	s.doc.History = history
+	return run, nil
+}
+
+// previousRunIncomplete returns whether the operation
+// enqueued by the previous run is pending or running.
+func (s *ActionSchedule) previousRunIncomplete() (bool, error) {
+	if s.doc.LastOperation == "" {
+		return false, nil
+	}
+	m, err := s.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	op, err := m.Operation(s.doc.LastOperation)
+	if errors.IsNotFound(err) {
+		// The operation has been pruned.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	switch op.Status() {
+	case ActionPending, ActionRunning, ActionAborting:
+		return true, nil
+	}
+	return false, nil
+}
+
+// enqueue enqueues an operation running the action on each unit
+// of the receiver, and returns its id. An error is returned if the
+// action could not be enqueued on any unit, in which case the
+// operation, having no tasks, is marked as failed.
+func (s *ActionSchedule) enqueue() (string, error) {
+	units, err := s.receiverUnits()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	m, err := s.st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	summary := fmt.Sprintf("%v run on %v by schedule %v", s.doc.ActionName, s.doc.Receiver, s.doc.Id)
+	operationId, err := m.EnqueueOperation(summary)
+	if err != nil {
+		return "", errors.Annotate(err, "creating operation for scheduled action")
+	}
+	var failures []string
+	for _, unit := range units {
+		// AddAction inserts defaults into the parameters,
+		// so each unit is given its own copy.
+		parameters := make(map[string]interface{})
+		for k, v := range s.doc.Parameters {
+			parameters[k] = v
+		}
+		if _, err := unit.AddAction(operationId, s.doc.ActionName, parameters, 0); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", unit.Name(), err))
+		}
+	}
+	if len(failures) == len(units) {
+		if err := m.failOperation(operationId); err != nil {
+			actionLogger.Warningf("cannot mark operation %q of action schedule %q as failed: %v", operationId, s.doc.Id, err)
+		}
+		return operationId, errors.Errorf("cannot enqueue action: %s", strings.Join(failures, "; "))
+	}
+	return operationId, nil
+}
+
+// receiverUnits returns the units on which the action is run.
+func (s *ActionSchedule) receiverUnits() ([]*Unit, error) {
+	receiver := s.doc.Receiver
+	if strings.HasSuffix(receiver, "/leader") {
+		appName := strings.TrimSuffix(receiver, "/leader")
+		leaders, err := s.st.ApplicationLeaders()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[appName]
+		if !ok {
+			return nil, errors.Errorf("could not determine leader for %q", appName)
+		}
+		receiver = leader
+	}
+	if names.IsValidUnit(receiver) {
+		unit, err := s.st.Unit(receiver)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []*Unit{unit}, nil
+	}
+	app, err := s.st.Application(receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(units) == 0 {
+		return nil, errors.Errorf("application %q has no units", receiver)
+	}
+	return units, nil
+}
+
+// validateActionScheduleReceiver checks that the receiver is
+// a unit, application or application leader in the model.
+func validateActionScheduleReceiver(st *State, receiver string) error {
+	appName := strings.TrimSuffix(receiver, "/leader")
+	switch {
+	case names.IsValidUnit(receiver):
+		_, err := st.Unit(receiver)
+		return errors.Trace(err)
+	case names.IsValidApplication(appName):
+		_, err := st.Application(appName)
+		return errors.Trace(err)
+	}
+	return errors.NotValidf("action receiver %q", receiver)
+}
+
+// AddActionSchedule adds a schedule on which an action is run,
+// and returns it.
+func (m *Model) AddActionSchedule(args AddActionScheduleArgs) (*ActionSchedule, error) {
+	if args.ActionName == "" {
+		return nil, errors.NotValidf("empty action name")
+	}
+	if _, err := actions.ParseSchedule(args.Schedule); err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch args.Overlap {
+	case "":
+		args.Overlap = ActionScheduleSkip
+	case ActionScheduleSkip, ActionScheduleQueue:
+	default:
+		return nil, errors.NotValidf("schedule overlap %q", args.Overlap)
+	}
+	if err := validateActionScheduleReceiver(m.st, args.Receiver); err != nil {
+		return nil, errors.Trace(err)
+	}
+	seq, err := sequenceWithMin(m.st, "actionschedule", 1)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := strconv.Itoa(seq)
+	doc := actionScheduleDoc{
+		DocId:      m.st.docID(id),
+		Id:         id,
+		Receiver:   args.Receiver,
+		ActionName: args.ActionName,
+		Parameters: args.Parameters,
+		Schedule:   strings.TrimSpace(args.Schedule),
+		Overlap:    args.Overlap,
+		Owner:      args.Owner,
+		Created:    m.st.clock().Now().UnixNano(),
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "cannot add action schedule")
+	}
+	return &ActionSchedule{st: m.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the specified id.
+func (m *Model) ActionSchedule(id string) (*ActionSchedule, error) {
+	doc, err := getActionScheduleDoc(m.st, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionSchedule{st: m.st, doc: *doc}, nil
+}
+
+// AllActionSchedules returns the action schedules of the model.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	coll, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "reading action schedules")
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		a, _ := strconv.Atoi(docs[i].Id)
+		b, _ := strconv.Atoi(docs[j].Id)
+		return a < b
+	})
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{st: m.st, doc: doc}
+	}
+	return result, nil
+}
+
+// WatchActionSchedules returns a watcher which notifies
+// when the action schedules of the model change.
+func (m *Model) WatchActionSchedules() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, actionSchedulesC, nil)
+}
+
+func getActionScheduleDoc(st *State, id string) (*actionScheduleDoc, error) {
+	coll, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "reading action schedule %q", id)
+	}
+	return &doc, nil
+}
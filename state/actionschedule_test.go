@@ -0,0 +1,312 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	clock       *testclock.Clock
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.NonZeroTime().Round(time.Hour))
+	err := s.State.SetClockForTesting(s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ver, err := s.Model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	if !state.IsNewActionIDSupported(ver) {
+		err := s.State.SetModelAgentVersion(state.MinVersionSupportNewActionID, true)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	s.application = s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	curl, _ := s.application.CharmURL()
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit2.SetCharmURL(curl)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, receiver string, overlap state.ActionScheduleOverlap) *state.ActionSchedule {
+	schedule, err := s.Model.AddActionSchedule(state.AddActionScheduleArgs{
+		Receiver:   receiver,
+		ActionName: "snapshot",
+		Parameters: map[string]interface{}{"outfile": "/tmp/out"},
+		Schedule:   "0 3 * * *",
+		Overlap:    overlap,
+		Owner:      "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy", "")
+	c.Assert(schedule.Id(), gc.Equals, "1")
+	c.Assert(schedule.Receiver(), gc.Equals, "dummy")
+	c.Assert(schedule.ActionName(), gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "/tmp/out"})
+	c.Assert(schedule.Schedule(), gc.Equals, "0 3 * * *")
+	c.Assert(schedule.Overlap(), gc.Equals, state.ActionScheduleSkip)
+	c.Assert(schedule.Owner(), gc.Equals, "admin")
+	c.Assert(schedule.Created(), gc.Equals, s.clock.Now().UTC())
+	c.Assert(schedule.Paused(), jc.IsFalse)
+	c.Assert(schedule.LastRun().IsZero(), jc.IsTrue)
+	c.Assert(schedule.History(), gc.HasLen, 0)
+
+	next, err := schedule.NextRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.After(schedule.Created()), jc.IsTrue)
+	c.Assert(next.Hour(), gc.Equals, 3)
+
+	other := s.addSchedule(c, "dummy/0", state.ActionScheduleQueue)
+	c.Assert(other.Id(), gc.Equals, "2")
+
+	all, err := s.Model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].Id(), gc.Equals, "1")
+	c.Assert(all[1].Id(), gc.Equals, "2")
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.AddActionScheduleArgs
+		err  string
+	}{{
+		args: state.AddActionScheduleArgs{Receiver: "dummy", Schedule: "@daily"},
+		err:  "empty action name not valid",
+	}, {
+		args: state.AddActionScheduleArgs{Receiver: "dummy", ActionName: "snapshot", Schedule: "0 3 * *"},
+		err:  `schedule "0 3 \* \*" with 4 fields, expected 5 not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Receiver: "dummy", ActionName: "snapshot", Schedule: "@daily", Overlap: "cancel"},
+		err:  `schedule overlap "cancel" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Receiver: "missing/leader", ActionName: "snapshot", Schedule: "@daily"},
+		err:  `application "missing" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{Receiver: "dummy/9", ActionName: "snapshot", Schedule: "@daily"},
+		err:  `unit "dummy/9" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{Receiver: "machine-0", ActionName: "snapshot", Schedule: "@daily"},
+		err:  `action receiver "machine-0" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.Model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestActionScheduleNotFound(c *gc.C) {
+	_, err := s.Model.ActionSchedule("42")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `action schedule "42" not found`)
+}
+
+func (s *ActionScheduleSuite) TestTriggerEnqueuesOperation(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy", "")
+	due, err := schedule.NextRun()
+	c.Assert(err, jc.ErrorIsNil)
+
+	run, err := schedule.Trigger(due)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Time, gc.Equals, due)
+	c.Assert(run.Skipped, jc.IsFalse)
+	c.Assert(run.Error, gc.Equals, "")
+	c.Assert(run.OperationId, gc.Not(gc.Equals), "")
+
+	info, err := s.Model.OperationWithActions(run.OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Operation.Summary(), gc.Equals, "snapshot run on dummy by schedule 1")
+	c.Assert(info.Actions, gc.HasLen, 2)
+	for _, a := range info.Actions {
+		c.Check(a.Name(), gc.Equals, "snapshot")
+		c.Check(a.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "/tmp/out"})
+	}
+
+	err = schedule.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.LastRun(), gc.Equals, due)
+	c.Assert(schedule.History(), jc.DeepEquals, []state.ActionScheduleRun{run})
+}
+
+func (s *ActionScheduleSuite) TestTriggerOnlyOnce(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0", "")
+	due, err := schedule.NextRun()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = schedule.Trigger(due)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Another controller holding a stale copy of the schedule
+	// does not run the action again.
+	stale, err := s.Model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stale.Trigger(due)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(schedule.Refresh(), jc.ErrorIsNil)
+	c.Assert(schedule.History(), gc.HasLen, 1)
+
+	operations, err := s.Model.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations, gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestTriggerSkipsOverlappingRun(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0", state.ActionScheduleSkip)
+	first, err := schedule.Trigger(s.clock.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	second, err := schedule.Trigger(s.clock.Now().Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.Skipped, jc.IsTrue)
+	c.Assert(second.OperationId, gc.Equals, "")
+
+	// Once the first run completes, the next run is not skipped.
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	_, err = actions[0].Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	third, err := schedule.Trigger(s.clock.Now().Add(3 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(third.Skipped, jc.IsFalse)
+	c.Assert(third.OperationId, gc.Not(gc.Equals), first.OperationId)
+	c.Assert(schedule.History(), gc.HasLen, 3)
+}
+
+func (s *ActionScheduleSuite) TestTriggerQueuesOverlappingRun(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0", state.ActionScheduleQueue)
+	_, err := schedule.Trigger(s.clock.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	run, err := schedule.Trigger(s.clock.Now().Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.Skipped, jc.IsFalse)
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 2)
+}
+
+func (s *ActionScheduleSuite) TestTriggerRecordsEnqueueError(c *gc.C) {
+	schedule, err := s.Model.AddActionSchedule(state.AddActionScheduleArgs{
+		Receiver:   "dummy/0",
+		ActionName: "no-such-action",
+		Schedule:   "@hourly",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	run, err := schedule.Trigger(s.clock.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.OperationId, gc.Not(gc.Equals), "")
+	c.Assert(run.Error, gc.Matches, "cannot enqueue action: dummy/0: .*")
+
+	// The operation, with no tasks, is failed rather than left
+	// pending, and does not cause later runs to be skipped.
+	op, err := s.Model.Operation(run.OperationId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(op.Completed().IsZero(), jc.IsFalse)
+	next, err := schedule.Trigger(s.clock.Now().Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Skipped, jc.IsFalse)
+}
+
+func (s *ActionScheduleSuite) TestTriggerNoUnits(c *gc.C) {
+	s.AddTestingApplication(c, "empty", s.AddTestingCharm(c, "dummy"))
+	schedule := s.addSchedule(c, "empty", state.ActionScheduleSkip)
+	run, err := schedule.Trigger(s.clock.Now().Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(run.OperationId, gc.Equals, "")
+	c.Assert(run.Error, gc.Equals, `application "empty" has no units`)
+
+	// No operation is enqueued, and the next run is not skipped.
+	operations, err := s.Model.AllOperations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operations, gc.HasLen, 0)
+	next, err := schedule.Trigger(s.clock.Now().Add(2 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Skipped, jc.IsFalse)
+}
+
+func (s *ActionScheduleSuite) TestHistoryIsLimited(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy/0", state.ActionScheduleSkip)
+	for i := 1; i <= 25; i++ {
+		_, err := schedule.Trigger(s.clock.Now().Add(time.Duration(i) * time.Hour))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	history := schedule.History()
+	c.Assert(history, gc.HasLen, 20)
+	c.Assert(history[0].Time, gc.Equals, s.clock.Now().Add(6*time.Hour).UTC())
+	c.Assert(history[19].Time, gc.Equals, s.clock.Now().Add(25*time.Hour).UTC())
+}
+
+func (s *ActionScheduleSuite) TestPauseAndResume(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy", "")
+	err := schedule.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsTrue)
+
+	_, err = schedule.Trigger(s.clock.Now().Add(time.Hour))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	// Runs due while paused are not made up.
+	s.clock.Advance(48 * time.Hour)
+	err = schedule.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Paused(), jc.IsFalse)
+	c.Assert(schedule.LastRun(), gc.Equals, s.clock.Now().UTC())
+	next, err := schedule.NextRun()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.After(s.clock.Now()), jc.IsTrue)
+}
+
+func (s *ActionScheduleSuite) TestRemove(c *gc.C) {
+	schedule := s.addSchedule(c, "dummy", "")
+	err := schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.Model.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ActionScheduleSuite) TestWatchActionSchedules(c *gc.C) {
+	w := s.Model.WatchActionSchedules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	schedule := s.addSchedule(c, "dummy", "")
+	wc.AssertOneChange()
+
+	err := schedule.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = schedule.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
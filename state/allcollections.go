@@ -282,6 +282,22 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid"},
 			}},
 		},
+
+		// This collection holds a bounded history of the hooks,
+		// actions and commands run by each unit.
+		unitHookHistoryC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
+
+		// This collection holds the "juju debug-hooks" sessions
+		// waiting, through the API, to debug each unit's hooks.
+		debugHooksSessionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
 		minUnitsC: {},
 
 		// This collection holds documents that indicate units which are queued
@@ -414,6 +430,12 @@ func allCollections() CollectionSchema {
 				Key: []string{"model-uuid", "_id"},
 			}},
 		},
+		actionSchedulesC: {},
+		actionResultBlobsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
 
 		// -----
 
@@ -522,6 +544,14 @@ func allCollections() CollectionSchema {
 		// for applications.
 		podSpecsC: {},
 
+		// applicationBackupsC holds the volume snapshots taken
+		// of the storage of CAAS applications.
+		applicationBackupsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "application"},
+			}},
+		},
+
 		// cloudContainersC holds the CAAS container (pod) information
 		// for units, eg address, ports.
 		cloudContainersC: {},
@@ -549,6 +579,8 @@ const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
+	actionSchedulesC           = "actionSchedules"
+	actionResultBlobsC         = "actionResultBlobs"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
@@ -604,6 +636,7 @@ const (
 	restoreInfoC               = "restoreInfo"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
+	applicationBackupsC        = "applicationBackups"
 	endpointBindingsC          = "endpointbindings"
 	settingsC                  = "settings"
 	generationsC               = "generations"
@@ -625,6 +658,8 @@ const (
 	txnsC                      = "txns"
 	unitsC                     = "units"
 	unitStatesC                = "unitstates"
+	unitHookHistoryC           = "unithookhistory"
+	debugHooksSessionsC        = "debughookssessions"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -935,7 +935,7 @@ func (s *allWatcherStateSuite) TestChangeActions(c *gc.C) {
 			c.Assert(err, jc.ErrorIsNil)
 			operationID, err := m.EnqueueOperation("a test")
 			c.Assert(err, jc.ErrorIsNil)
-			action, err := m.EnqueueAction(operationID, u.Tag(), "vacuumdb", map[string]interface{}{})
+			action, err := m.EnqueueAction(operationID, u.Tag(), "vacuumdb", map[string]interface{}{}, 0)
 			c.Assert(err, jc.ErrorIsNil)
 			enqueued := makeActionInfo(action, st)
 			action, err = action.Begin()
@@ -3684,7 +3684,7 @@ func deltaMap(deltas []multiwatcher.Delta) map[interface{}]multiwatcher.EntityIn
 }
 
 func makeActionInfo(a Action, st *State) multiwatcher.ActionInfo {
-	results, message := a.Results()
+	results, message, _ := a.Results()
 	return multiwatcher.ActionInfo{
 		ModelUUID:  st.ModelUUID(),
 		ID:         a.Id(),
@@ -585,6 +585,13 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove the records of the application's backups.
+	removeBackupOps, err := removeApplicationBackupsOps(a.st, a.doc.Name)
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, removeBackupOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -2286,6 +2293,8 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeUnitStateOp(a.st, u.globalKey()),
+		removeUnitHookHistoryOp(a.st, u.globalKey()),
+		removeDebugHooksSessionOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
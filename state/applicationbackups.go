@@ -0,0 +1,203 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ApplicationBackup records the volume snapshots taken of the
+// storage of a container application at the same time, from
+// which the storage may later be restored.
+type ApplicationBackup struct {
+	// Id uniquely identifies the backup amongst
+	// those of the application.
+	Id string
+
+	// Application is the name of the application
+	// whose storage was snapshotted.
+	Application string
+
+	// Created is the time at which the snapshots were taken.
+	Created time.Time
+
+	// Snapshots holds the snapshots taken, one for
+	// each volume claim of the application.
+	Snapshots []VolumeSnapshot
+}
+
+// VolumeSnapshot records a snapshot of a volume claim
+// owned by the storage of a container application.
+type VolumeSnapshot struct {
+	// StorageName is the name of the charm storage
+	// which owns the claim.
+	StorageName string
+
+	// ClaimName is the name of the snapshotted claim.
+	ClaimName string
+
+	// SnapshotName is the name of the snapshot.
+	SnapshotName string
+
+	// Size is the size of the claim, in MiB.
+	Size uint64
+}
+
+type applicationBackupDoc struct {
+	DocID       string              `bson:"_id"`
+	Id          string              `bson:"backup-id"`
+	Application string              `bson:"application"`
+	Created     int64               `bson:"created"`
+	Snapshots   []volumeSnapshotDoc `bson:"snapshots"`
+}
+
+type volumeSnapshotDoc struct {
+	StorageName  string `bson:"storage-name"`
+	ClaimName    string `bson:"claim-name"`
+	SnapshotName string `bson:"snapshot-name"`
+	Size         uint64 `bson:"size"`
+}
+
+func applicationBackupKey(appName, id string) string {
+	return appName + "#" + id
+}
+
+func (doc *applicationBackupDoc) backup() ApplicationBackup {
+	backup := ApplicationBackup{
+		Id:          doc.Id,
+		Application: doc.Application,
+		Created:     time.Unix(0, doc.Created).UTC(),
+		Snapshots:   make([]VolumeSnapshot, len(doc.Snapshots)),
+	}
+	for i, s := range doc.Snapshots {
+		backup.Snapshots[i] = VolumeSnapshot{
+			StorageName:  s.StorageName,
+			ClaimName:    s.ClaimName,
+			SnapshotName: s.SnapshotName,
+			Size:         s.Size,
+		}
+	}
+	return backup
+}
+
+// AddBackup records a backup of the application's storage, made up of
+// the specified volume snapshots. The id must not be that of another
+// backup of the application.
+func (a *Application) AddBackup(id string, created time.Time, snapshots []VolumeSnapshot) error {
+	if id == "" {
+		return errors.NotValidf("empty backup id")
+	}
+	doc := applicationBackupDoc{
+		DocID:       a.st.docID(applicationBackupKey(a.doc.Name, id)),
+		Id:          id,
+		Application: a.doc.Name,
+		Created:     created.UnixNano(),
+		Snapshots:   make([]volumeSnapshotDoc, len(snapshots)),
+	}
+	for i, s := range snapshots {
+		doc.Snapshots[i] = volumeSnapshotDoc{
+			StorageName:  s.StorageName,
+			ClaimName:    s.ClaimName,
+			SnapshotName: s.SnapshotName,
+			Size:         s.Size,
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, errors.Trace(err)
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
+			if _, err := a.Backup(id); err == nil {
+				return nil, errors.AlreadyExistsf("backup %q of application %q", id, a.doc.Name)
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Trace(err)
+			}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      applicationBackupsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot add backup of application %q", a.doc.Name)
+	}
+	return nil
+}
+
+// Backup returns the backup of the application's storage with
+// the specified id.
+func (a *Application) Backup(id string) (ApplicationBackup, error) {
+	coll, closer := a.st.db().GetCollection(applicationBackupsC)
+	defer closer()
+
+	var doc applicationBackupDoc
+	err := coll.FindId(applicationBackupKey(a.doc.Name, id)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ApplicationBackup{}, errors.NotFoundf("backup %q of application %q", id, a.doc.Name)
+	} else if err != nil {
+		return ApplicationBackup{}, errors.Annotatef(err, "reading backup %q of application %q", id, a.doc.Name)
+	}
+	return doc.backup(), nil
+}
+
+// Backups returns the backups of the application's storage,
+// oldest first.
+func (a *Application) Backups() ([]ApplicationBackup, error) {
+	docs, err := applicationBackupDocs(a.st, a.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backups := make([]ApplicationBackup, len(docs))
+	for i, doc := range docs {
+		backups[i] = doc.backup()
+	}
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Created.Before(backups[j].Created)
+	})
+	return backups, nil
+}
+
+func applicationBackupDocs(st *State, appName string) ([]applicationBackupDoc, error) {
+	coll, closer := st.db().GetCollection(applicationBackupsC)
+	defer closer()
+
+	var docs []applicationBackupDoc
+	if err := coll.Find(bson.D{{"application", appName}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading backups of application %q", appName)
+	}
+	return docs, nil
+}
+
+// removeApplicationBackupsOps returns the operations to remove the
+// records of the backups of the application. The snapshots themselves
+// are left in the cluster.
+func removeApplicationBackupsOps(st *State, appName string) ([]txn.Op, error) {
+	docs, err := applicationBackupDocs(st, appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      applicationBackupsC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ApplicationBackupSuite struct {
+	CAASFixture
+
+	State       *state.State
+	application *state.Application
+}
+
+var _ = gc.Suite(&ApplicationBackupSuite{})
+
+func (s *ApplicationBackupSuite) SetUpTest(c *gc.C) {
+	s.CAASFixture.SetUpTest(c)
+	_, s.State = s.newCAASModel(c)
+	f := factory.NewFactory(s.State, s.StatePool)
+	s.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
+
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	s.application = f.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+}
+
+var testSnapshots = []state.VolumeSnapshot{{
+	StorageName:  "database",
+	ClaimName:    "database-gitlab-0",
+	SnapshotName: "database-gitlab-0-20200102-030405",
+	Size:         1024,
+}, {
+	StorageName:  "database",
+	ClaimName:    "database-gitlab-1",
+	SnapshotName: "database-gitlab-1-20200102-030405",
+	Size:         1024,
+}}
+
+func (s *ApplicationBackupSuite) TestAddBackup(c *gc.C) {
+	created := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := s.application.AddBackup("20200102-030405", created, testSnapshots)
+	c.Assert(err, jc.ErrorIsNil)
+
+	backup, err := s.application.Backup("20200102-030405")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backup, jc.DeepEquals, state.ApplicationBackup{
+		Id:          "20200102-030405",
+		Application: "gitlab",
+		Created:     created,
+		Snapshots:   testSnapshots,
+	})
+}
+
+func (s *ApplicationBackupSuite) TestAddBackupDuplicate(c *gc.C) {
+	err := s.application.AddBackup("20200102-030405", time.Now(), testSnapshots)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.AddBackup("20200102-030405", time.Now(), nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationBackupSuite) TestAddBackupNotAlive(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit, gc.NotNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.AddBackup("20200102-030405", time.Now(), testSnapshots)
+	c.Assert(err, gc.ErrorMatches, `cannot add backup of application "gitlab": application is not found or not alive`)
+}
+
+func (s *ApplicationBackupSuite) TestBackupNotFound(c *gc.C) {
+	_, err := s.application.Backup("20200102-030405")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ApplicationBackupSuite) TestBackups(c *gc.C) {
+	older := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	err := s.application.AddBackup("second", older.Add(time.Hour), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.AddBackup("first", older, testSnapshots)
+	c.Assert(err, jc.ErrorIsNil)
+
+	backups, err := s.application.Backups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backups, gc.HasLen, 2)
+	c.Assert(backups[0].Id, gc.Equals, "first")
+	c.Assert(backups[1].Id, gc.Equals, "second")
+}
+
+func (s *ApplicationBackupSuite) TestRemoveApplicationRemovesBackups(c *gc.C) {
+	err := s.application.AddBackup("20200102-030405", time.Now(), testSnapshots)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	// App removal requires cluster resources to be cleared.
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.ClearResources()
+	c.Assert(err, jc.ErrorIsNil)
+	assertCleanupCount(c, s.State, 2)
+
+	backups, err := s.application.Backups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(backups, gc.HasLen, 0)
+}
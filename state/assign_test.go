@@ -1030,7 +1030,26 @@ func (s *assignCleanSuite) TestAssignToMachineErrors(c *gc.C) {
 	}, machine.Id(), instance.LXD)
 	c.Assert(err, jc.ErrorIsNil)
 	err = unit.AssignToMachine(container)
-	c.Assert(err, gc.ErrorMatches, `cannot assign unit "storage-filesystem/0" to machine 0/lxd/0: adding storage to lxd container not supported`)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "storage-filesystem/0" to machine 0/lxd/0: adding storage to lxd container: "static" storage provider not supported`)
+}
+
+func (s *assignCleanSuite) TestAssignToContainerWithContainerStorage(c *gc.C) {
+	_, unit, _ := s.setupSingleStorage(c, "filesystem", "zfs")
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystemAttachments, err := sb.MachineFilesystemAttachments(container.MachineTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filesystemAttachments, gc.HasLen, 1)
 }
 
 func (s *assignCleanSuite) TestAssignUnitWithNonDynamicStorageCleanAvailable(c *gc.C) {
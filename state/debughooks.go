@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// DebugHooksSession describes a "juju debug-hooks" client waiting,
+// through the API, to debug a unit's hooks and actions.
+type DebugHooksSession struct {
+	// ID identifies the session.
+	ID string
+
+	// Hooks holds the names of the hooks and actions to debug;
+	// if empty, all of them are debugged.
+	Hooks []string
+
+	// Created records when the session started.
+	Created time.Time
+}
+
+// debugHooksSessionDoc records the debug-hooks session of a unit.
+type debugHooksSessionDoc struct {
+	// DocID is always the same as a unit's global key.
+	DocID     string   `bson:"_id"`
+	SessionID string   `bson:"session-id"`
+	Hooks     []string `bson:"hooks,omitempty"`
+	Created   int64    `bson:"created"`
+}
+
+func (doc debugHooksSessionDoc) session() DebugHooksSession {
+	return DebugHooksSession{
+		ID:      doc.SessionID,
+		Hooks:   doc.Hooks,
+		Created: time.Unix(0, doc.Created).UTC(),
+	}
+}
+
+// removeDebugHooksSessionOp returns the operation needed to remove the
+// debug-hooks session document associated with the given globalKey.
+func removeDebugHooksSessionOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      debugHooksSessionsC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// StartDebugHooksSession records that a debug-hooks client is waiting
+// to debug the given hooks and actions of the unit, or all of them if
+// none are given, and returns the ID of the new session. It returns an
+// AlreadyExists error if the unit already has a session.
+func (u *Unit) StartDebugHooksSession(hooks []string) (string, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	id := uuid.String()
+	unitGlobalKey := u.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.NotFoundf("unit %s", u.Name())
+		}
+		if _, err := u.DebugHooksSession(); err == nil {
+			return nil, errors.AlreadyExistsf("debug-hooks session for unit %q", u.Name())
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}, {
+			C:      debugHooksSessionsC,
+			Id:     unitGlobalKey,
+			Assert: txn.DocMissing,
+			Insert: debugHooksSessionDoc{
+				DocID:     unitGlobalKey,
+				SessionID: id,
+				Hooks:     hooks,
+				Created:   u.st.clock().Now().UnixNano(),
+			},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return "", errors.Annotatef(err, "cannot start debug-hooks session for unit %q", u)
+	}
+	return id, nil
+}
+
+// EndDebugHooksSession removes the unit's debug-hooks session, if it
+// has the given ID. It is not an error if there is no such session.
+func (u *Unit) EndDebugHooksSession(id string) error {
+	unitGlobalKey := u.globalKey()
+	buildTxn := func(int) ([]txn.Op, error) {
+		session, err := u.DebugHooksSession()
+		if errors.IsNotFound(err) || err == nil && session.ID != id {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      debugHooksSessionsC,
+			Id:     unitGlobalKey,
+			Assert: bson.D{{"session-id", id}},
+			Remove: true,
+		}}, nil
+	}
+	err := u.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot end debug-hooks session for unit %q", u)
+}
+
+// DebugHooksSession returns the unit's debug-hooks session, or
+// a NotFound error if it has none.
+func (u *Unit) DebugHooksSession() (DebugHooksSession, error) {
+	coll, closer := u.st.db().GetCollection(debugHooksSessionsC)
+	defer closer()
+
+	var doc debugHooksSessionDoc
+	if err := coll.FindId(u.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return DebugHooksSession{}, errors.NotFoundf("debug-hooks session for unit %q", u.Name())
+	} else if err != nil {
+		return DebugHooksSession{}, errors.Annotatef(err, "cannot get debug-hooks session for unit %q", u)
+	}
+	return doc.session(), nil
+}
+
+// WatchDebugHooksSession returns a watcher which notifies
+// when the unit's debug-hooks session starts or ends.
+func (u *Unit) WatchDebugHooksSession() NotifyWatcher {
+	return newEntityWatcher(u.st, debugHooksSessionsC, u.st.docID(u.globalKey()))
+}
@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type DebugHooksSessionSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&DebugHooksSessionSuite{})
+
+func (s *DebugHooksSessionSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *DebugHooksSessionSuite) TestNoSession(c *gc.C) {
+	_, err := s.unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *DebugHooksSessionSuite) TestStartDebugHooksSession(c *gc.C) {
+	id, err := s.unit.StartDebugHooksSession([]string{"install", "start"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Not(gc.Equals), "")
+
+	session, err := s.unit.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.ID, gc.Equals, id)
+	c.Assert(session.Hooks, jc.DeepEquals, []string{"install", "start"})
+	c.Assert(session.Created.IsZero(), jc.IsFalse)
+}
+
+func (s *DebugHooksSessionSuite) TestStartDebugHooksSessionAlreadyExists(c *gc.C) {
+	_, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *DebugHooksSessionSuite) TestStartDebugHooksSessionDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, gc.ErrorMatches, `cannot start debug-hooks session for unit ".*": unit .* not found`)
+}
+
+func (s *DebugHooksSessionSuite) TestEndDebugHooksSession(c *gc.C) {
+	id, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Ending another session leaves the unit's session in place.
+	err = s.unit.EndDebugHooksSession("another")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.DebugHooksSession()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.EndDebugHooksSession(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Ending a session twice is not an error.
+	err = s.unit.EndDebugHooksSession(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DebugHooksSessionSuite) TestSessionRemovedWithUnit(c *gc.C) {
+	_, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.unit.DebugHooksSession()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *DebugHooksSessionSuite) TestWatchDebugHooksSession(c *gc.C) {
+	w := s.unit.WatchDebugHooksSession()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	id, err := s.unit.StartDebugHooksSession(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.unit.EndDebugHooksSession(id)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -52,6 +52,8 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxHookHistory = maxHookHistory
 )
 
 var (
@@ -70,6 +72,9 @@ var (
 	NewEntityWatcher              = newEntityWatcher
 	ApplicationHasConnectedOffers = applicationHasConnectedOffers
 	NewActionNotificationWatcher  = newActionNotificationWatcher
+	MaxInlineActionResultsSize    = &maxInlineActionResultsSize
+	MaxActionOutputChunks         = &maxActionOutputChunks
+	MaxActionResultsSize          = &maxActionResultsSize
 )
 
 type (
@@ -1011,3 +1016,20 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 	}
 	return nil
 }
+
+// ActionResultsPath returns the blobstore path of the action's
+// results, and the results stored in the action document.
+func ActionResultsPath(a Action) (string, map[string]interface{}) {
+	doc := a.(*action).doc
+	return doc.ResultsPath, doc.Results
+}
+
+// ActionResultBlobCount returns the number of actions
+// with results stored in the blobstore.
+func ActionResultBlobCount(c *gc.C, st *State) int {
+	blobs, closer := st.db().GetCollection(actionResultBlobsC)
+	defer closer()
+	n, err := blobs.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	return n
+}
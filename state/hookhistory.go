@@ -0,0 +1,191 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// maxHookHistory is the number of executions kept in
+// the hook history of each unit.
+const maxHookHistory = 100
+
+// HookExecution records a hook, action or command run by a unit.
+type HookExecution struct {
+	// Kind is one of "hook", "action" or "exec".
+	Kind string
+
+	// Name is the name of the hook or action, or the
+	// commands run.
+	Name string
+
+	// Trigger describes the change which caused the
+	// execution, if it is known.
+	Trigger string
+
+	// Started and Finished record when the execution
+	// started and finished.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the process run.
+	ExitCode int
+
+	// Error holds the error reported by the execution,
+	// if it failed.
+	Error string
+
+	// Skipped is true if a hook was not run because
+	// the charm does not implement it.
+	Skipped bool
+}
+
+// Duration returns how long the execution took.
+func (e HookExecution) Duration() time.Duration {
+	return e.Finished.Sub(e.Started)
+}
+
+// unitHookHistoryDoc records the most recent executions of a unit.
+type unitHookHistoryDoc struct {
+	// DocID is always the same as a unit's global key.
+	DocID      string             `bson:"_id"`
+	Executions []hookExecutionDoc `bson:"executions"`
+}
+
+type hookExecutionDoc struct {
+	Kind     string `bson:"kind"`
+	Name     string `bson:"name"`
+	Trigger  string `bson:"trigger,omitempty"`
+	Started  int64  `bson:"started"`
+	Finished int64  `bson:"finished"`
+	ExitCode int    `bson:"exit-code"`
+	Error    string `bson:"error,omitempty"`
+	Skipped  bool   `bson:"skipped,omitempty"`
+}
+
+func newHookExecutionDoc(e HookExecution) hookExecutionDoc {
+	return hookExecutionDoc{
+		Kind:     e.Kind,
+		Name:     e.Name,
+		Trigger:  e.Trigger,
+		Started:  e.Started.UnixNano(),
+		Finished: e.Finished.UnixNano(),
+		ExitCode: e.ExitCode,
+		Error:    e.Error,
+		Skipped:  e.Skipped,
+	}
+}
+
+func (doc hookExecutionDoc) execution() HookExecution {
+	return HookExecution{
+		Kind:     doc.Kind,
+		Name:     doc.Name,
+		Trigger:  doc.Trigger,
+		Started:  time.Unix(0, doc.Started).UTC(),
+		Finished: time.Unix(0, doc.Finished).UTC(),
+		ExitCode: doc.ExitCode,
+		Error:    doc.Error,
+		Skipped:  doc.Skipped,
+	}
+}
+
+// removeUnitHookHistoryOp returns the operation needed to remove the
+// hook history document associated with the given globalKey.
+func removeUnitHookHistoryOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitHookHistoryC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// RecordHookExecutions adds the given executions to the unit's hook
+// history. Only the most recent executions are kept.
+func (u *Unit) RecordHookExecutions(executions []HookExecution) error {
+	if len(executions) == 0 {
+		return nil
+	}
+	docs := make([]hookExecutionDoc, len(executions))
+	for i, e := range executions {
+		if e.Kind != "hook" && e.Kind != "action" && e.Kind != "exec" {
+			return errors.NotValidf("execution kind %q", e.Kind)
+		}
+		docs[i] = newHookExecutionDoc(e)
+	}
+	if len(docs) > maxHookHistory {
+		docs = docs[len(docs)-maxHookHistory:]
+	}
+
+	coll, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	unitGlobalKey := u.globalKey()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		// Hooks are still run while the unit is dying.
+		if u.Life() == Dead {
+			return nil, errors.NotFoundf("unit %s", u.Name())
+		}
+		unitNotDeadOp := txn.Op{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}
+
+		n, err := coll.FindId(unitGlobalKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			return []txn.Op{unitNotDeadOp, {
+				C:      unitHookHistoryC,
+				Id:     unitGlobalKey,
+				Assert: txn.DocMissing,
+				Insert: unitHookHistoryDoc{
+					DocID:      unitGlobalKey,
+					Executions: docs,
+				},
+			}}, nil
+		}
+		return []txn.Op{unitNotDeadOp, {
+			C:      unitHookHistoryC,
+			Id:     unitGlobalKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$push", bson.D{{"executions", bson.D{
+				{"$each", docs},
+				{"$slice", -maxHookHistory},
+			}}}}},
+		}}, nil
+	}
+	err := u.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record hook executions for unit %q", u)
+}
+
+// HookHistory returns the most recent executions of the unit's hooks,
+// actions and commands, oldest first.
+func (u *Unit) HookHistory() ([]HookExecution, error) {
+	coll, closer := u.st.db().GetCollection(unitHookHistoryC)
+	defer closer()
+
+	var doc unitHookHistoryDoc
+	if err := coll.FindId(u.globalKey()).One(&doc); err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get hook history for unit %q", u)
+	}
+	executions := make([]HookExecution, len(doc.Executions))
+	for i, e := range doc.Executions {
+		executions[i] = e.execution()
+	}
+	return executions, nil
+}
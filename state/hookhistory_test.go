@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type HookHistorySuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&HookHistorySuite{})
+
+func (s *HookHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *HookHistorySuite) execution(name string, started time.Time) state.HookExecution {
+	return state.HookExecution{
+		Kind:     "hook",
+		Name:     name,
+		Trigger:  "config change",
+		Started:  started,
+		Finished: started.Add(2 * time.Second),
+	}
+}
+
+func (s *HookHistorySuite) TestNoHistory(c *gc.C) {
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *HookHistorySuite) TestRecordHookExecutions(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := s.execution("install", started)
+	second := state.HookExecution{
+		Kind:     "action",
+		Name:     "backup",
+		Trigger:  "action 1",
+		Started:  started.Add(time.Minute),
+		Finished: started.Add(2 * time.Minute),
+		ExitCode: 1,
+		Error:    "exit status 1",
+	}
+	third := s.execution("leader-elected", started.Add(3*time.Minute))
+	third.Skipped = true
+	err := s.unit.RecordHookExecutions([]state.HookExecution{first})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecutions([]state.HookExecution{second, third})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.HookExecution{first, second, third})
+	c.Assert(history[1].Duration(), gc.Equals, time.Minute)
+}
+
+func (s *HookHistorySuite) TestRecordHookExecutionsBounded(c *gc.C) {
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	var executions []state.HookExecution
+	for i := 0; i < state.MaxHookHistory+10; i++ {
+		executions = append(executions, s.execution(fmt.Sprintf("hook-%d", i), started.Add(time.Duration(i)*time.Minute)))
+	}
+	for _, e := range executions {
+		err := s.unit.RecordHookExecutions([]state.HookExecution{e})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, executions[10:])
+}
+
+func (s *HookHistorySuite) TestRecordHookExecutionsInvalidKind(c *gc.C) {
+	e := s.execution("install", time.Now())
+	e.Kind = "magic"
+	err := s.unit.RecordHookExecutions([]state.HookExecution{e})
+	c.Assert(err, gc.ErrorMatches, `execution kind "magic" not valid`)
+}
+
+func (s *HookHistorySuite) TestRecordHookExecutionsDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.RecordHookExecutions([]state.HookExecution{s.execution("stop", time.Now())})
+	c.Assert(err, gc.ErrorMatches, `cannot record hook executions for unit ".*": unit .* not found`)
+}
+
+func (s *HookHistorySuite) TestHistoryRemovedWithUnit(c *gc.C) {
+	err := s.unit.RecordHookExecutions([]state.HookExecution{s.execution("install", time.Now())})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.HookHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
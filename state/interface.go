@@ -144,8 +144,9 @@ type ActionReceiver interface {
 	Entity
 
 	// AddAction queues an action belonging to the specified operation,
-	// with the given name and payload for this ActionReceiver.
-	AddAction(operationID, name string, payload map[string]interface{}) (Action, error)
+	// with the given name and payload for this ActionReceiver. A non-zero
+	// executionTimeout limits how long the action may run for.
+	AddAction(operationID, name string, payload map[string]interface{}, executionTimeout time.Duration) (Action, error)
 
 	// CancelAction removes a pending Action from the queue for this
 	// ActionReceiver and marks it as cancelled.
@@ -202,6 +203,10 @@ type Action interface {
 	// definition of the Action.
 	Parameters() map[string]interface{}
 
+	// ExecutionTimeout returns how long the action may run for before it
+	// is cancelled and marked failed. Zero means there is no limit.
+	ExecutionTimeout() time.Duration
+
 	// Enqueued returns the time the action was added to state as a pending
 	// Action.
 	Enqueued() time.Time
@@ -215,8 +220,9 @@ type Action interface {
 	// Status returns the final state of the action.
 	Status() ActionStatus
 
-	// Results returns the structured output of the action and any error.
-	Results() (map[string]interface{}, string)
+	// Results returns the structured output of the action and any error
+	// message, or an error if the output cannot be read.
+	Results() (map[string]interface{}, string, error)
 
 	// ActionTag returns an ActionTag constructed from this action's
 	// Prefix and Sequence.
@@ -236,6 +242,14 @@ type Action interface {
 	// Messages returns the action's progress messages.
 	Messages() []ActionMessage
 
+	// LogOutput adds a chunk of output written to stdout or stderr
+	// to the action's streamed output.
+	LogOutput(stream, data string) error
+
+	// Output returns the chunks of output streamed by the action
+	// while it runs, which are discarded once it has finished.
+	Output() []ActionOutputChunk
+
 	// Cancel or Abort the action.
 	Cancel() (Action, error)
 
@@ -1397,6 +1397,60 @@ func (m *Machine) SetProvisioned(
 	return fmt.Errorf("already set")
 }
 
+// MarkInstanceReclaimed records that the machine's instance was reclaimed by
+// the cloud, as happens to spot or preemptible instances when the cloud needs
+// the capacity back. The machine's provider-specific details are removed, so
+// that it is no longer considered provisioned, and the instance status is set
+// to describe what happened. If replace is true, the instance status is marked
+// as transient so that the provisioner will start a replacement instance for
+// the machine, and the units assigned to it will be deployed there.
+func (m *Machine) MarkInstanceReclaimed(replace bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot mark instance of machine %q as reclaimed", m)
+
+	if m.IsManager() {
+		return errors.NotSupportedf("reclaiming controller machine instances")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life != Alive {
+			return nil, machineNotAliveErr
+		}
+		if _, err := m.InstanceId(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"nonce", ""}}}},
+		}, {
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	m.doc.Nonce = ""
+
+	now := m.st.clock().Now()
+	return m.SetInstanceStatus(status.StatusInfo{
+		Status:  status.ProvisioningError,
+		Message: "instance reclaimed by cloud",
+		Data: map[string]interface{}{
+			"reclaimed": true,
+			"transient": replace,
+		},
+		Since: &now,
+	})
+}
+
 // SetInstanceInfo is used to provision a machine and in one step sets its
 // instance ID, nonce, hardware characteristics, add link-layer devices and set
 // their addresses as needed.  After, set charm profiles if needed.
@@ -2049,7 +2103,7 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 }
 
 // AddAction is part of the ActionReceiver interface.
-func (m *Machine) AddAction(operationID, name string, payload map[string]interface{}) (Action, error) {
+func (m *Machine) AddAction(operationID, name string, payload map[string]interface{}, executionTimeout time.Duration) (Action, error) {
 	spec, ok := actions.PredefinedActionsSpec[name]
 	if !ok {
 		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
@@ -2070,7 +2124,7 @@ func (m *Machine) AddAction(operationID, name string, payload map[string]interfa
 		return nil, errors.Trace(err)
 	}
 
-	return model.EnqueueAction(operationID, m.Tag(), name, payloadWithDefaults)
+	return model.EnqueueAction(operationID, m.Tag(), name, payloadWithDefaults, executionTimeout)
 }
 
 // CancelAction is part of the ActionReceiver interface.
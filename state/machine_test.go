@@ -988,6 +988,64 @@ func (s *MachineSuite) TestMachineSetProvisionedWhenNotAlive(c *gc.C) {
 	})
 }
 
+func (s *MachineSuite) TestMachineMarkInstanceReclaimed(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.MarkInstanceReclaimed(false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.machine.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(s.machine.CheckProvisioned("fake_nonce"), jc.IsFalse)
+
+	instanceStatus, err := s.machine.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceStatus.Status, gc.Equals, status.ProvisioningError)
+	c.Assert(instanceStatus.Message, gc.Equals, "instance reclaimed by cloud")
+	c.Assert(instanceStatus.Data, jc.DeepEquals, map[string]interface{}{
+		"reclaimed": true,
+		"transient": false,
+	})
+
+	// The machine can then be provisioned again.
+	err = s.machine.SetProvisioned("umbrella/1", "", "fake_nonce2", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineSuite) TestMachineMarkInstanceReclaimedReplace(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.MarkInstanceReclaimed(true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	instanceStatus, err := s.machine.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instanceStatus.Data["transient"], jc.IsTrue)
+}
+
+func (s *MachineSuite) TestMachineMarkInstanceReclaimedNotProvisioned(c *gc.C) {
+	err := s.machine.MarkInstanceReclaimed(false)
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *MachineSuite) TestMachineMarkInstanceReclaimedController(c *gc.C) {
+	err := s.machine0.MarkInstanceReclaimed(false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachineSuite) TestMachineMarkInstanceReclaimedWhenNotAlive(c *gc.C) {
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.MarkInstanceReclaimed(false)
+	c.Assert(err, gc.ErrorMatches, `cannot mark instance of machine "1" as reclaimed: machine is not alive`)
+}
+
 func (s *MachineSuite) TestMachineSetInstanceStatus(c *gc.C) {
 	// Machine needs to be provisioned first.
 	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", nil)
@@ -2555,7 +2613,7 @@ func (s *MachineSuite) TestMachineValidActions(c *gc.C) {
 		c.Logf("running test %d", i)
 		operationID, err := s.Model.EnqueueOperation("a test")
 		c.Assert(err, jc.ErrorIsNil)
-		action, err := m.AddAction(operationID, t.actionName, t.givenPayload)
+		action, err := m.AddAction(operationID, t.actionName, t.givenPayload, 0)
 		if t.errString != "" {
 			c.Assert(err.Error(), gc.Equals, t.errString)
 			continue
@@ -2570,7 +2628,7 @@ func (s *MachineSuite) TestMachineAddDifferentAction(c *gc.C) {
 	m, err := s.State.AddMachine("trusty", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
 
-	_, err = m.AddAction("666", "benchmark", nil)
+	_, err = m.AddAction("666", "benchmark", nil, 0)
 	c.Assert(err, gc.ErrorMatches, `cannot add action "benchmark" to a machine; only predefined actions allowed`)
 }
 
@@ -1591,7 +1591,10 @@ func (e *exporter) actions() error {
 	}
 	e.logger.Debugf("read %d actions", len(actions))
 	for _, a := range actions {
-		results, message := a.Results()
+		results, message, err := a.Results()
+		if err != nil {
+			return errors.Trace(err)
+		}
 		arg := description.ActionArgs{
 			Receiver:   a.Receiver(),
 			Name:       a.Name(),
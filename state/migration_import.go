@@ -1954,6 +1954,12 @@ func (i *importer) addAction(action description.Action) error {
 		Receiver:  action.Receiver(),
 		ActionID:  action.Id(),
 	}
+	results, resultsPath, err := storeActionResults(i.st, action.Id(), newDoc.Results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDoc.Results = results
+	newDoc.ResultsPath = resultsPath
 	ops := []txn.Op{{
 		C:      actionsC,
 		Id:     newDoc.DocId,
@@ -1963,6 +1969,9 @@ func (i *importer) addAction(action description.Action) error {
 		Id:     notificationDoc.DocId,
 		Insert: notificationDoc,
 	}}
+	if resultsPath != "" {
+		ops = append(ops, addActionResultsBlobOp(i.st, action.Id(), resultsPath))
+	}
 
 	if err := i.st.db().RunTransaction(ops); err != nil {
 		return errors.Trace(err)
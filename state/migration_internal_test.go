@@ -210,6 +210,29 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// running within a unit. This is a new feature that is not
 		// backwards compatible with older controllers.
 		unitStatesC,
+
+		// Hook history is diagnostic only, and is
+		// started afresh in the target model.
+		unitHookHistoryC,
+
+		// Debug-hooks sessions belong to a client
+		// connected to the source controller.
+		debugHooksSessionsC,
+
+		// The records of application backups cannot be held by
+		// the model description, so the migration prechecks
+		// refuse to migrate a model with backups.
+		applicationBackupsC,
+
+		// Action schedules cannot be held by the model
+		// description, so the migration prechecks refuse
+		// to migrate a model with action schedules.
+		actionSchedulesC,
+
+		// Action results stored in the blobstore are
+		// exported with their actions, and stored again
+		// on import.
+		actionResultBlobsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -582,6 +605,9 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Spaces",
 		"VirtType",
 		"Zones",
+		// InstanceLifecycle is not yet supported by the model
+		// description, so it isn't migrated.
+		"InstanceLifecycle",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -725,6 +751,12 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// The execution timeout isn't yet part of the model
+		// description; migrated actions run without a limit.
+		"ExecutionTimeout",
+		// Streamed output is only of interest while the action
+		// is running; the full output is part of the results.
+		"Output",
 	)
 	migrated := set.NewStrings(
 		"DocId",
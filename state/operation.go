@@ -4,12 +4,14 @@
 package state
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -47,10 +49,26 @@ type Operation interface {
 	// OperationTag returns the operation's tag.
 	OperationTag() names.OperationTag
 
+	// Steps returns the progress of the steps of the runbook
+	// run as the operation, if it is a runbook.
+	Steps() []OperationStep
+
 	// Refresh refreshes the contents of the operation.
 	Refresh() error
 }
 
+// OperationStep records the progress of a step of a runbook.
+type OperationStep struct {
+	// Name is the name of the step.
+	Name string `bson:"name"`
+
+	// Status is the status of the step.
+	Status ActionStatus `bson:"status"`
+
+	// Message explains the status of the step.
+	Message string `bson:"message,omitempty"`
+}
+
 type operationDoc struct {
 	DocId     string `bson:"_id"`
 	ModelUUID string `bson:"model-uuid"`
@@ -75,6 +93,17 @@ type operationDoc struct {
 	// If not explicitly set, this is derived from the
 	// status of the associated actions.
 	Status ActionStatus `bson:"status"`
+
+	// Steps records the progress of the steps of a runbook run as
+	// the operation. An operation with steps is completed when the
+	// runbook is finished, rather than when its last task is.
+	Steps []OperationStep `bson:"steps,omitempty"`
+
+	// Heartbeat is the last time the client running a runbook
+	// reported that it was still running it. A runbook whose
+	// client has gone away is failed when the operations are
+	// next pruned.
+	Heartbeat time.Time `bson:"heartbeat,omitempty"`
 }
 
 // operation represents a group of associated actions.
@@ -140,6 +169,14 @@ func (op *operation) Status() ActionStatus {
 	return op.doc.Status
 }
 
+// Steps returns the progress of the steps of the runbook
+// run as the operation, if it is a runbook.
+func (op *operation) Steps() []OperationStep {
+	steps := make([]OperationStep, len(op.doc.Steps))
+	copy(steps, op.doc.Steps)
+	return steps
+}
+
 // Refresh refreshes the contents of the operation.
 func (op *operation) Refresh() error {
 	doc, taskStatus, err := op.st.getOperationDoc(op.Id())
@@ -196,6 +233,34 @@ func newOperationDoc(mb modelBackend, summary string) (operationDoc, string, err
 
 // EnqueueOperation records the start of an operation.
 func (m *Model) EnqueueOperation(summary string) (string, error) {
+	return m.enqueueOperation(summary, nil)
+}
+
+// runbookHeartbeatTimeout is the time after the last heartbeat of a
+// runbook's client that the runbook is considered abandoned.
+const runbookHeartbeatTimeout = 10 * time.Minute
+
+// EnqueueRunbookOperation records the start of a runbook with the
+// named steps, run as an operation. The operation is completed by
+// FinishRunbookOperation, once all the steps needed have been run.
+// Until then, the client running the runbook must record heartbeats
+// with RunbookOperationHeartbeat, or the runbook is failed as
+// abandoned when the operations are next pruned.
+func (m *Model) EnqueueRunbookOperation(summary string, steps []string) (string, error) {
+	if len(steps) == 0 {
+		return "", errors.NotValidf("runbook with no steps")
+	}
+	operationSteps := make([]OperationStep, len(steps))
+	for i, name := range steps {
+		operationSteps[i] = OperationStep{
+			Name:   name,
+			Status: ActionPending,
+		}
+	}
+	return m.enqueueOperation(summary, operationSteps)
+}
+
+func (m *Model) enqueueOperation(summary string, steps []OperationStep) (string, error) {
 	var operationID string
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		var doc operationDoc
@@ -204,6 +269,10 @@ func (m *Model) EnqueueOperation(summary string) (string, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		doc.Steps = steps
+		if len(steps) > 0 {
+			doc.Heartbeat = doc.Enqueued
+		}
 
 		ops := []txn.Op{{
 			C:      operationsC,
@@ -217,6 +286,193 @@ func (m *Model) EnqueueOperation(summary string) (string, error) {
 	return operationID, errors.Trace(err)
 }
 
+// operationNotCompleted asserts that an operation has not been completed.
+var operationNotCompleted = bson.D{{"status", bson.D{
+	{"$nin", []interface{}{
+		ActionCompleted,
+		ActionCancelled,
+		ActionFailed,
+		ActionAborted,
+	}}}}}
+
+// SetOperationStepStatus records the status of a step of the runbook
+// run as the identified operation. The runbook must not have finished.
+func (m *Model) SetOperationStepStatus(id string, step int, status ActionStatus, message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, _, err := m.st.getOperationDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if step < 0 || step >= len(doc.Steps) {
+			return nil, errors.NotFoundf("step %d of operation %q", step, id)
+		}
+		if isOperationCompleted(doc.Status) {
+			return nil, errors.Errorf("operation %q has finished", id)
+		}
+		field := fmt.Sprintf("steps.%d", step)
+		return []txn.Op{{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: append(bson.D{{field + ".name", doc.Steps[step].Name}}, operationNotCompleted...),
+			Update: bson.D{{"$set", bson.D{
+				{field + ".status", status},
+				{field + ".message", message},
+				{"heartbeat", m.st.nowToTheSecond()},
+			}}},
+		}}, nil
+	}
+	return errors.Annotatef(m.st.db().Run(buildTxn), "cannot set status of step %d of operation %q", step, id)
+}
+
+// RunbookOperationHeartbeat records that the client running the
+// runbook of the identified operation is still running it.
+func (m *Model) RunbookOperationHeartbeat(id string) error {
+	doc, _, err := m.st.getOperationDoc(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(doc.Steps) == 0 {
+		return errors.NotValidf("operation %q without steps", id)
+	}
+	if isOperationCompleted(doc.Status) {
+		return errors.Errorf("operation %q has finished", id)
+	}
+	ops := []txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: operationNotCompleted,
+		Update: bson.D{{"$set", bson.D{{"heartbeat", m.st.nowToTheSecond()}}}},
+	}}
+	err = m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.Errorf("operation %q has finished", id)
+	}
+	return errors.Annotatef(err, "cannot record heartbeat of operation %q", id)
+}
+
+// FinishRunbookOperation completes the runbook run as the identified
+// operation, with the specified status. Any steps which were not run
+// are recorded as cancelled.
+func (m *Model) FinishRunbookOperation(id string, status ActionStatus) error {
+	if !isOperationCompleted(status) {
+		return errors.NotValidf("runbook status %q", status)
+	}
+	return errors.Annotatef(m.finishRunbookOperation(id, status, time.Time{}), "cannot finish operation %q", id)
+}
+
+// finishRunbookOperation completes the runbook run as the identified
+// operation. If abandonedBefore is set, the runbook is only completed
+// if its last heartbeat was before then, and its running steps are
+// failed as abandoned.
+func (m *Model) finishRunbookOperation(id string, status ActionStatus, abandonedBefore time.Time) error {
+	completed := m.st.nowToTheSecond()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, _, err := m.st.getOperationDoc(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(doc.Steps) == 0 {
+			return nil, errors.NotValidf("operation %q without steps", id)
+		}
+		if isOperationCompleted(doc.Status) {
+			return nil, errors.Errorf("operation %q has finished", id)
+		}
+		assert := operationNotCompleted
+		if !abandonedBefore.IsZero() {
+			if !doc.Heartbeat.Before(abandonedBefore) {
+				return nil, jujutxn.ErrNoOperations
+			}
+			assert = append(bson.D{{"heartbeat", bson.D{{"$lt", abandonedBefore}}}}, operationNotCompleted...)
+		}
+		update := bson.D{
+			{"status", status},
+			{"completed", completed},
+		}
+		if doc.Started.IsZero() {
+			update = append(update, bson.DocElem{"started", completed})
+		}
+		for i, step := range doc.Steps {
+			switch {
+			case step.Status == ActionPending:
+				update = append(update, bson.DocElem{fmt.Sprintf("steps.%d.status", i), ActionCancelled})
+			case step.Status == ActionRunning && !abandonedBefore.IsZero():
+				update = append(update,
+					bson.DocElem{fmt.Sprintf("steps.%d.status", i), ActionFailed},
+					bson.DocElem{fmt.Sprintf("steps.%d.message", i), "runbook abandoned by its client"},
+				)
+			}
+		}
+		return []txn.Op{{
+			C:      operationsC,
+			Id:     doc.DocId,
+			Assert: assert,
+			Update: bson.D{{"$set", update}},
+		}}, nil
+	}
+	return m.st.db().Run(buildTxn)
+}
+
+// failAbandonedRunbooks fails the runbooks whose clients have not
+// recorded a heartbeat for longer than runbookHeartbeatTimeout, as
+// nothing else would ever finish them.
+func failAbandonedRunbooks(st *State) error {
+	m, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	operations, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	abandonedBefore := st.nowToTheSecond().Add(-runbookHeartbeatTimeout)
+	sel := append(bson.D{
+		{"steps", bson.D{{"$exists", true}}},
+		{"heartbeat", bson.D{{"$lt", abandonedBefore}}},
+	}, operationNotCompleted...)
+	var docs []operationDoc
+	if err := operations.Find(sel).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+		return errors.Annotate(err, "finding abandoned runbooks")
+	}
+	for _, doc := range docs {
+		id := st.localID(doc.DocId)
+		logger.Infof("failing runbook of operation %q: no heartbeat since before %v", id, abandonedBefore)
+		if err := m.finishRunbookOperation(id, ActionFailed, abandonedBefore); err != nil {
+			return errors.Annotatef(err, "failing abandoned runbook of operation %q", id)
+		}
+	}
+	return nil
+}
+
+// failOperation marks an operation to which no tasks could be
+// added as failed, so that it is not left pending forever.
+func (m *Model) failOperation(id string) error {
+	doc, _, err := m.st.getOperationDoc(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	completed := m.st.nowToTheSecond()
+	ops := []txn.Op{{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"status", ActionPending}, {"complete-task-count", 0}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", ActionFailed},
+			{"started", completed},
+			{"completed", completed},
+		}}},
+	}}
+	return errors.Annotatef(m.st.db().RunTransaction(ops), "cannot fail operation %q", id)
+}
+
+// isOperationCompleted returns whether an
+// operation with the given status has finished.
+func isOperationCompleted(status ActionStatus) bool {
+	switch status {
+	case ActionCompleted, ActionCancelled, ActionFailed, ActionAborted:
+		return true
+	}
+	return false
+}
+
 // Operation returns an Operation by Id.
 func (m *Model) Operation(id string) (Operation, error) {
 	doc, taskStatus, err := m.st.getOperationDoc(id)
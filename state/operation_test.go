@@ -71,7 +71,7 @@ func (s *OperationSuite) TestOperationStatus(c *gc.C) {
 	operationID, err := s.Model.EnqueueOperation("an operation")
 	c.Assert(err, jc.ErrorIsNil)
 	clock.Advance(5 * time.Second)
-	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil)
+	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = anAction.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -94,7 +94,7 @@ func (s *OperationSuite) TestRefresh(c *gc.C) {
 	operation, err := s.Model.Operation(operationID)
 	c.Assert(err, jc.ErrorIsNil)
 
-	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil)
+	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = anAction.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -104,6 +104,135 @@ func (s *OperationSuite) TestRefresh(c *gc.C) {
 	c.Assert(operation.Status(), gc.Equals, state.ActionRunning)
 }
 
+func (s *OperationSuite) TestEnqueueRunbookOperation(c *gc.C) {
+	operationID, err := s.Model.EnqueueRunbookOperation("a runbook", []string{"pause", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Summary(), gc.Equals, "a runbook")
+	c.Assert(operation.Status(), gc.Equals, state.ActionPending)
+	c.Assert(operation.Steps(), jc.DeepEquals, []state.OperationStep{
+		{Name: "pause", Status: state.ActionPending},
+		{Name: "backup", Status: state.ActionPending},
+	})
+}
+
+func (s *OperationSuite) TestEnqueueRunbookOperationNoSteps(c *gc.C) {
+	_, err := s.Model.EnqueueRunbookOperation("a runbook", nil)
+	c.Assert(err, gc.ErrorMatches, "runbook with no steps not valid")
+}
+
+func (s *OperationSuite) TestSetOperationStepStatus(c *gc.C) {
+	operationID, err := s.Model.EnqueueRunbookOperation("a runbook", []string{"pause", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.SetOperationStepStatus(operationID, 1, state.ActionFailed, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Steps(), jc.DeepEquals, []state.OperationStep{
+		{Name: "pause", Status: state.ActionPending},
+		{Name: "backup", Status: state.ActionFailed, Message: "boom"},
+	})
+
+	err = s.Model.SetOperationStepStatus(operationID, 2, state.ActionRunning, "")
+	c.Assert(err, gc.ErrorMatches, `cannot set status of step 2 of operation ".*": step 2 of operation ".*" not found`)
+}
+
+func (s *OperationSuite) TestRunbookOperationOutlivesTasks(c *gc.C) {
+	charm := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", charm)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, err := s.Model.EnqueueRunbookOperation("a runbook", []string{"backup", "restore"})
+	c.Assert(err, jc.ErrorIsNil)
+	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "snapshot", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = anAction.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The runbook's operation is still running once its only task
+	// has completed, so that later steps can add tasks to it.
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionRunning)
+	c.Assert(operation.Completed(), gc.Equals, time.Time{})
+}
+
+func (s *OperationSuite) TestFinishRunbookOperation(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operationID, err := s.Model.EnqueueRunbookOperation("a runbook", []string{"pause", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetOperationStepStatus(operationID, 0, state.ActionFailed, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.FinishRunbookOperation(operationID, state.ActionFailed)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(operationID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Completed(), gc.Equals, clock.Now())
+	c.Assert(operation.Steps(), jc.DeepEquals, []state.OperationStep{
+		{Name: "pause", Status: state.ActionFailed, Message: "boom"},
+		{Name: "backup", Status: state.ActionCancelled},
+	})
+
+	err = s.Model.FinishRunbookOperation(operationID, state.ActionCompleted)
+	c.Assert(err, gc.ErrorMatches, `cannot finish operation ".*": operation ".*" has finished`)
+	err = s.Model.SetOperationStepStatus(operationID, 1, state.ActionRunning, "")
+	c.Assert(err, gc.ErrorMatches, `cannot set status of step 1 of operation ".*": operation ".*" has finished`)
+}
+
+func (s *OperationSuite) TestPruneFailsAbandonedRunbook(c *gc.C) {
+	clock := testclock.NewClock(coretesting.NonZeroTime().Round(time.Second))
+	err := s.State.SetClockForTesting(clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	abandonedID, err := s.Model.EnqueueRunbookOperation("a runbook", []string{"pause", "backup"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetOperationStepStatus(abandonedID, 0, state.ActionRunning, "")
+	c.Assert(err, jc.ErrorIsNil)
+	runningID, err := s.Model.EnqueueRunbookOperation("another runbook", []string{"pause"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	clock.Advance(9 * time.Minute)
+	err = s.Model.RunbookOperationHeartbeat(runningID)
+	c.Assert(err, jc.ErrorIsNil)
+	clock.Advance(2 * time.Minute)
+	err = state.PruneOperations(s.State, time.Hour, 1000)
+	c.Assert(err, jc.ErrorIsNil)
+
+	operation, err := s.Model.Operation(abandonedID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionFailed)
+	c.Assert(operation.Completed(), gc.Equals, clock.Now())
+	c.Assert(operation.Steps(), jc.DeepEquals, []state.OperationStep{
+		{Name: "pause", Status: state.ActionFailed, Message: "runbook abandoned by its client"},
+		{Name: "backup", Status: state.ActionCancelled},
+	})
+
+	operation, err = s.Model.Operation(runningID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(operation.Status(), gc.Equals, state.ActionPending)
+
+	err = s.Model.RunbookOperationHeartbeat(abandonedID)
+	c.Assert(err, gc.ErrorMatches, `operation ".*" has finished`)
+}
+
+func (s *OperationSuite) TestFinishRunbookOperationNotRunbook(c *gc.C) {
+	operationID, err := s.Model.EnqueueOperation("an operation")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.FinishRunbookOperation(operationID, state.ActionCompleted)
+	c.Assert(err, gc.ErrorMatches, `cannot finish operation ".*": operation ".*" without steps not valid`)
+}
+
 func (s *OperationSuite) setupOperations(c *gc.C) names.Tag {
 	ver, err := s.Model.AgentVersion()
 	c.Assert(err, jc.ErrorIsNil)
@@ -128,11 +257,11 @@ func (s *OperationSuite) setupOperations(c *gc.C) names.Tag {
 	c.Assert(err, jc.ErrorIsNil)
 
 	clock.Advance(5 * time.Second)
-	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil)
+	anAction, err := s.Model.EnqueueAction(operationID, unit.Tag(), "backup", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = anAction.Begin()
 	c.Assert(err, jc.ErrorIsNil)
-	anAction2, err := s.Model.EnqueueAction(operationID2, unit.Tag(), "restore", nil)
+	anAction2, err := s.Model.EnqueueAction(operationID2, unit.Tag(), "restore", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	a, err := anAction2.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -149,7 +278,7 @@ func (s *OperationSuite) setupOperations(c *gc.C) names.Tag {
 	c.Assert(err, jc.ErrorIsNil)
 	operationID3, err := s.Model.EnqueueOperation("yet another operation")
 	c.Assert(err, jc.ErrorIsNil)
-	anAction3, err := s.Model.EnqueueAction(operationID3, unit2.Tag(), "backup", nil)
+	anAction3, err := s.Model.EnqueueAction(operationID3, unit2.Tag(), "backup", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = anAction3.Begin()
 
@@ -167,7 +296,8 @@ func (s *OperationSuite) assertActions(c *gc.C, operations []state.OperationInfo
 			}
 			c.Assert(a.Messages(), gc.HasLen, 0)
 			c.Assert(a.Messages(), gc.HasLen, 0)
-			results, _ := a.Results()
+			results, _, err := a.Results()
+			c.Assert(err, jc.ErrorIsNil)
 			c.Assert(results, gc.HasLen, 0)
 		}
 	}
@@ -259,7 +389,8 @@ func (s *OperationSuite) TestOperationWithActions(c *gc.C) {
 	c.Assert(operation.Actions, gc.HasLen, 1)
 	c.Assert(operation.Actions[0].Id(), gc.Equals, "4")
 	c.Assert(operation.Actions[0].Status(), gc.Equals, state.ActionCompleted)
-	results, message := operation.Actions[0].Results()
+	results, message, err := operation.Actions[0].Results()
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
 	c.Assert(message, gc.Equals, "done")
 	c.Assert(operation.Actions[0].Messages(), gc.HasLen, 1)
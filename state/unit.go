@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/core/status"
 	mgoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/storage/provider"
 	"github.com/juju/juju/tools"
 )
 
@@ -1947,19 +1948,33 @@ func validateDynamicMachineStoragePools(sb *storageBackend, m *Machine, pools se
 		return nil
 	}
 	if m.ContainerType() != "" {
-		// TODO(axw) consult storage providers to check if they
-		// support adding storage to containers. Loop is fine,
-		// for example.
-		//
 		// TODO(axw) later we might allow *any* storage, and
 		// passthrough/bindmount storage. That would imply either
 		// container creation time only, or requiring containers
 		// to be restarted to pick up new configuration.
-		return errors.NotSupportedf("adding storage to %s container", m.ContainerType())
+		if err := validateContainerStoragePools(sb, pools); err != nil {
+			return errors.Annotatef(err, "adding storage to %s container", m.ContainerType())
+		}
 	}
 	return validateDynamicStoragePools(sb, pools)
 }
 
+// validateContainerStoragePools validates that all of the specified storage
+// pools use providers which can provision storage from within a container.
+// If any provider can't, then an IsNotSupported error is returned.
+func validateContainerStoragePools(sb *storageBackend, pools set.Strings) error {
+	for pool := range pools {
+		providerType, _, _, err := poolStorageProvider(sb, pool)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !provider.SupportsContainers(providerType) {
+			return errors.NotSupportedf("%q storage provider", providerType)
+		}
+	}
+	return nil
+}
+
 // validateDynamicStoragePools validates that all of the specified storage
 // providers support dynamic storage provisioning. If any provider doesn't
 // support dynamic storage, then an IsNotSupported error is returned.
@@ -2784,7 +2799,7 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // AddAction adds a new Action of type name and using arguments payload to
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
-func (u *Unit) AddAction(operationID, name string, payload map[string]interface{}) (Action, error) {
+func (u *Unit) AddAction(operationID, name string, payload map[string]interface{}, executionTimeout time.Duration) (Action, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2830,7 +2845,7 @@ func (u *Unit) AddAction(operationID, name string, payload map[string]interface{
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return m.EnqueueAction(operationID, u.Tag(), name, payloadWithDefaults)
+	return m.EnqueueAction(operationID, u.Tag(), name, payloadWithDefaults, executionTimeout)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -2018,6 +2018,12 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's application configuration settings.
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -2686,13 +2692,24 @@ func (st *State) WatchActionLogs(actionId string) StringsWatcher {
 	return newActionLogsWatcher(st, actionId)
 }
 
-// actionLogsWatcher reports new action progress messages.
+// WatchActionOutput starts and returns a StringsWatcher that
+// notifies on new chunks of output streamed by a specified action.
+// The strings are json encoded action output chunks.
+func (st *State) WatchActionOutput(actionId string) StringsWatcher {
+	return newActionOutputWatcher(st, actionId)
+}
+
+// actionLogsWatcher reports new action progress messages,
+// or new chunks of action output.
 type actionLogsWatcher struct {
 	commonWatcher
 	coll func() (mongo.Collection, func())
 	out  chan []string
 
 	actionId string
+
+	// read returns all the strings recorded so far.
+	read func() ([]string, error)
 }
 
 var _ Watcher = (*actionLogsWatcher)(nil)
@@ -2704,6 +2721,22 @@ func newActionLogsWatcher(st *State, actionId string) StringsWatcher {
 		out:           make(chan []string),
 		actionId:      actionId,
 	}
+	w.read = w.messages
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+func newActionOutputWatcher(st *State, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(st),
+		coll:          collFactory(st.db(), actionsC),
+		out:           make(chan []string),
+		actionId:      actionId,
+	}
+	w.read = w.output
 	w.tomb.Go(func() error {
 		defer close(w.out)
 		return w.loop()
@@ -2742,6 +2775,32 @@ func (w *actionLogsWatcher) messages() ([]string, error) {
 	return changes, nil
 }
 
+func (w *actionLogsWatcher) output() ([]string, error) {
+	type outputDoc struct {
+		Output []ActionOutputChunk `bson:"output"`
+	}
+	coll, closer := w.coll()
+	defer closer()
+	var doc outputDoc
+	err := coll.FindId(w.backend.docID(w.actionId)).Select(bson.D{{"output", 1}}).One(&doc)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var changes []string
+	for _, c := range doc.Output {
+		cjson, err := json.Marshal(actions.ActionOutput{
+			Stream:    c.StreamValue,
+			Data:      c.DataValue,
+			Timestamp: c.TimestampValue.UTC(),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		changes = append(changes, string(cjson))
+	}
+	return changes, nil
+}
+
 func (w *actionLogsWatcher) loop() error {
 	in := make(chan watcher.Change)
 	filter := func(id interface{}) bool {
@@ -2755,7 +2814,7 @@ func (w *actionLogsWatcher) loop() error {
 	w.watcher.WatchCollectionWithFilter(actionsC, in, filter)
 	defer w.watcher.UnwatchCollection(actionsC, in)
 
-	changes, err := w.messages()
+	changes, err := w.read()
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -2771,7 +2830,7 @@ func (w *actionLogsWatcher) loop() error {
 		case <-w.tomb.Dying():
 			return tomb.ErrDying
 		case <-in:
-			messages, err := w.messages()
+			messages, err := w.read()
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -3767,6 +3826,30 @@ func (st *State) WatchMachineRemovals() NotifyWatcher {
 	return newNotifyCollWatcher(st, machineRemovalsC, isLocalID(st))
 }
 
+// WatchApplicationConfigs returns a NotifyWatcher which triggers
+// whenever the application config of any application in the model
+// changes.
+func (st *State) WatchApplicationConfigs() NotifyWatcher {
+	return newNotifyCollWatcher(st, settingsC, func(id interface{}) bool {
+		key, ok := id.(string)
+		if !ok {
+			return false
+		}
+		key, err := st.strictLocalID(key)
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(key, applicationGlobalKey("")) && strings.HasSuffix(key, "#application")
+	})
+}
+
+// WatchModelMachineChanges returns a NotifyWatcher which triggers
+// whenever any machine in the model changes, including when units
+// are assigned to or removed from the machine.
+func (st *State) WatchModelMachineChanges() NotifyWatcher {
+	return newNotifyCollWatcher(st, machinesC, isLocalID(st))
+}
+
 // notifyCollWatcher implements NotifyWatcher, triggering when a
 // change is seen in a specific collection matching the provided
 // filter function.
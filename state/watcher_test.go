@@ -47,7 +47,7 @@ func (s *watcherSuite) TestLegacyActionNotificationWatcher(c *gc.C) {
 
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := unit.AddAction(operationID, "snapshot", nil)
+	action, err := unit.AddAction(operationID, "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	wc.AssertChange(action.Id())
 
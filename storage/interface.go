@@ -215,6 +215,19 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeTagger provides an interface for updating the resource
+// tags of existing volumes.
+type VolumeTagger interface {
+	// TagVolume sets the given resource tags on the volume with
+	// the specified volume provider ID. Tags already set on the
+	// volume that are not in the given map are left untouched.
+	TagVolume(
+		ctx context.ProviderCallContext,
+		volumeId string,
+		resourceTags map[string]string,
+	) error
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
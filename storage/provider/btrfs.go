@@ -0,0 +1,285 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	BtrfsProviderType = storage.ProviderType("btrfs")
+
+	// BtrfsPath is the attribute name for the path to a mounted
+	// btrfs filesystem, under which subvolumes are created. If this
+	// is not provided, subvolumes are created in the storage directory,
+	// which must then reside on a btrfs filesystem.
+	BtrfsPath = "btrfs-path"
+)
+
+var btrfsConfigFields = schema.Fields{
+	BtrfsPath: schema.String(),
+}
+
+var btrfsConfigChecker = schema.FieldMap(
+	btrfsConfigFields,
+	schema.Defaults{
+		BtrfsPath: schema.Omit,
+	},
+)
+
+// btrfsProvider creates storage sources which provide access to
+// quota-limited btrfs subvolumes on the host machine.
+type btrfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*btrfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (p *btrfsProvider) ValidateConfig(cfg *storage.Config) error {
+	coerced, err := btrfsConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return errors.Annotate(err, "validating btrfs storage config")
+	}
+	if btrfsPath, ok := coerced.(map[string]interface{})[BtrfsPath].(string); ok {
+		if !filepath.IsAbs(btrfsPath) {
+			return errors.Errorf("%s %q must be an absolute path", BtrfsPath, btrfsPath)
+		}
+	}
+	return nil
+}
+
+// validateFullConfig validates a fully-constructed storage config,
+// combining the user-specified config and any internally specified
+// config.
+func (p *btrfsProvider) validateFullConfig(cfg *storage.Config) error {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	if btrfsPath, _ := cfg.ValueString(BtrfsPath); btrfsPath != "" {
+		return nil
+	}
+	storageDir, ok := cfg.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return errors.New("storage directory not specified")
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *btrfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *btrfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// One of btrfsPath or storageDir is validated by validateFullConfig.
+	btrfsPath, _ := sourceConfig.ValueString(BtrfsPath)
+	if btrfsPath == "" {
+		btrfsPath, _ = sourceConfig.ValueString(storage.ConfigStorageDir)
+	}
+	return &btrfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		btrfsPath,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*btrfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*btrfsProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*btrfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*btrfsProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*btrfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+type btrfsFilesystemSource struct {
+	dirFuncs  dirFuncs
+	run       runCommandFunc
+	btrfsPath string
+}
+
+var _ storage.FilesystemSource = (*btrfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// ValidateFilesystemParams may be called on a machine other than the
+	// machine where the filesystem will be mounted, so we cannot check
+	// the btrfs filesystem until we get to createFilesystem.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *btrfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := ensureDir(s.dirFuncs, s.btrfsPath); err != nil {
+		return nil, errors.Trace(err)
+	}
+	subvolume := filepath.Join(s.btrfsPath, params.Tag.String())
+	if _, err := s.run("btrfs", "subvolume", "create", subvolume); err != nil {
+		return nil, errors.Annotatef(err, "creating btrfs subvolume %q", subvolume)
+	}
+
+	// Quotas must be enabled on the filesystem before a limit
+	// can be applied to the subvolume's qgroup. Enabling quotas
+	// when they are already enabled is a no-op.
+	if _, err := s.run("btrfs", "quota", "enable", s.btrfsPath); err != nil {
+		s.deleteSubvolume(subvolume)
+		return nil, errors.Annotatef(err, "enabling btrfs quotas on %q", s.btrfsPath)
+	}
+	if _, err := s.run(
+		"btrfs", "qgroup", "limit", fmt.Sprintf("%dM", params.Size), subvolume,
+	); err != nil {
+		s.deleteSubvolume(subvolume)
+		return nil, errors.Annotatef(err, "limiting btrfs subvolume %q", subvolume)
+	}
+	return &storage.Filesystem{
+		Tag: params.Tag,
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: subvolume,
+			Size:         params.Size,
+		},
+	}, nil
+}
+
+func (s *btrfsFilesystemSource) deleteSubvolume(subvolume string) {
+	if _, err := s.run("btrfs", "subvolume", "delete", subvolume); err != nil {
+		logger.Warningf("cannot delete btrfs subvolume %q: %v", subvolume, err)
+	}
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, subvolume := range filesystemIds {
+		if _, err := s.run("btrfs", "subvolume", "delete", subvolume); err != nil {
+			results[i] = errors.Annotatef(err, "deleting btrfs subvolume %q", subvolume)
+		}
+	}
+	return results, nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *btrfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	subvolume := arg.FilesystemId
+	if subvolume == "" {
+		subvolume = filepath.Join(s.btrfsPath, arg.Filesystem.String())
+	}
+	if mountPoint == subvolume {
+		return nil, errors.Errorf("cannot mount btrfs subvolume %q over itself", subvolume)
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	mounted, _, err := isMounted(s.dirFuncs, mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !mounted {
+		if err := ensureEmptyDir(s.dirFuncs, mountPoint); err != nil {
+			return nil, err
+		}
+		options := "bind"
+		if arg.ReadOnly {
+			options += ",ro"
+		}
+		if _, err := s.run("mount", "-o", options, subvolume, mountPoint); err != nil {
+			return nil, errors.Annotate(err, "cannot mount btrfs subvolume")
+		}
+		// Record the mount in /etc/fstab so that it is
+		// available after a reboot.
+		entry := fmt.Sprintf("%s %s none %s 0 0", subvolume, mountPoint, options)
+		if err := addFstabEntry(s.dirFuncs.etcDir(), subvolume, mountPoint, entry); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *btrfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
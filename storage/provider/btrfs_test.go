@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&btrfsSuite{})
+
+type btrfsSuite struct {
+	testing.BaseSuite
+	commands   *mockRunCommand
+	fakeEtcDir string
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *btrfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.fakeEtcDir = c.MkDir()
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *btrfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *btrfsSuite) btrfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.BtrfsProvider(s.commands.run)
+}
+
+func (s *btrfsSuite) btrfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.BtrfsFilesystemSource(s.fakeEtcDir, "/srv/btrfs", s.commands.run)
+}
+
+func (s *btrfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.btrfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.BtrfsProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+
+	cfg, err = storage.NewConfig("name", provider.BtrfsProviderType, map[string]interface{}{
+		"storage-dir": c.MkDir(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg, err = storage.NewConfig("name", provider.BtrfsProviderType, map[string]interface{}{
+		"btrfs-path": "/srv/btrfs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *btrfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.btrfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.BtrfsProviderType, map[string]interface{}{
+		"btrfs-path": "/srv/btrfs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *btrfsSuite) TestValidateConfigRelativePath(c *gc.C) {
+	p := s.btrfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.BtrfsProviderType, map[string]interface{}{
+		"btrfs-path": "srv/btrfs",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `btrfs-path "srv/btrfs" must be an absolute path`)
+}
+
+func (s *btrfsSuite) TestSupports(c *gc.C) {
+	p := s.btrfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *btrfsSuite) TestScope(c *gc.C) {
+	p := s.btrfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *btrfsSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	s.commands.expect("btrfs", "subvolume", "create", "/srv/btrfs/filesystem-6")
+	s.commands.expect("btrfs", "quota", "enable", "/srv/btrfs")
+	s.commands.expect("btrfs", "qgroup", "limit", "512M", "/srv/btrfs/filesystem-6")
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 512,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "/srv/btrfs/filesystem-6",
+				Size:         512,
+			},
+		},
+	}})
+}
+
+func (s *btrfsSuite) TestCreateFilesystemsQuotaFails(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	s.commands.expect("btrfs", "subvolume", "create", "/srv/btrfs/filesystem-6")
+	cmd := s.commands.expect("btrfs", "quota", "enable", "/srv/btrfs")
+	cmd.respond("", errors.New("not a btrfs filesystem"))
+	s.commands.expect("btrfs", "subvolume", "delete", "/srv/btrfs/filesystem-6")
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 512,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `enabling btrfs quotas on "/srv/btrfs": not a btrfs filesystem`)
+}
+
+func (s *btrfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	s.commands.expect("btrfs", "subvolume", "delete", "/srv/btrfs/filesystem-1")
+
+	results, err := source.DestroyFilesystems(s.callCtx, []string{"/srv/btrfs/filesystem-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *btrfsSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("header\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("header\n/dev/sda1", nil)
+	s.commands.expect("mount", "-o", "bind", "/srv/btrfs/filesystem-1", "/srv/data")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: "/srv/btrfs/filesystem-1",
+		Path:         "/srv/data",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("1"),
+			Machine:    names.NewMachineTag("0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path: "/srv/data",
+			},
+		},
+	}})
+
+	fstab, err := ioutil.ReadFile(filepath.Join(s.fakeEtcDir, "fstab"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(fstab), gc.Equals, "\n/srv/btrfs/filesystem-1 /srv/data none bind 0 0\n")
+}
+
+func (s *btrfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("header\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("header\n/dev/sdb1", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *btrfsSuite) TestAttachFilesystemsOverSubvolume(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "/srv/btrfs/filesystem-1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `cannot mount btrfs subvolume "/srv/btrfs/filesystem-1" over itself`)
+}
+
+func (s *btrfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.btrfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true, s.fakeEtcDir, "")
+}
@@ -4,6 +4,7 @@
 package provider
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/storage"
@@ -16,7 +17,18 @@ var (
 		LoopProviderType:   &loopProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		ZFSProviderType:    &zfsProvider{logAndExec},
+		BtrfsProviderType:  &btrfsProvider{logAndExec},
 	}
+
+	// containerStorageProviderTypes are the machine-scoped storage
+	// provider types whose storage can be provisioned by the agent
+	// of a container, using a pool or filesystem delegated to the
+	// container by its host.
+	containerStorageProviderTypes = set.NewStrings(
+		string(ZFSProviderType),
+		string(BtrfsProviderType),
+	)
 )
 
 // CommonStorageProviders returns a storage.ProviderRegistry that contains
@@ -25,6 +37,12 @@ func CommonStorageProviders() storage.ProviderRegistry {
 	return storage.StaticProviderRegistry{Providers: commonStorageProviders}
 }
 
+// SupportsContainers reports whether storage of the specified
+// provider type can be added to units on container machines.
+func SupportsContainers(providerType storage.ProviderType) bool {
+	return containerStorageProviderTypes.Contains(string(providerType))
+}
+
 // ValidateConfig performs storage provider config validation, including
 // any common validation.
 func ValidateConfig(p storage.Provider, cfg *storage.Config) error {
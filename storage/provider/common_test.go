@@ -36,9 +36,18 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 		provider.LoopProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
+		provider.ZFSProviderType,
+		provider.BtrfsProviderType,
 	})
 }
 
+func (s *providerCommonSuite) TestSupportsContainers(c *gc.C) {
+	c.Assert(provider.SupportsContainers(provider.ZFSProviderType), jc.IsTrue)
+	c.Assert(provider.SupportsContainers(provider.BtrfsProviderType), jc.IsTrue)
+	c.Assert(provider.SupportsContainers(provider.LoopProviderType), jc.IsFalse)
+	c.Assert(provider.SupportsContainers(provider.RootfsProviderType), jc.IsFalse)
+}
+
 // testDetachFilesystems is a test-case for detaching filesystems that use
 // the common "maybeUnmount" method.
 func testDetachFilesystems(
@@ -139,3 +139,35 @@ func TmpfsFilesystemSource(etcDir, storageDir string, run func(string, ...string
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
+
+func ZFSFilesystemSource(etcDir, pool string, run func(string, ...string) (string, error)) storage.FilesystemSource {
+	return &zfsFilesystemSource{
+		&MockDirFuncs{
+			osDirFuncs{run},
+			etcDir,
+			set.NewStrings(),
+		},
+		run,
+		pool,
+	}
+}
+
+func ZFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &zfsProvider{run}
+}
+
+func BtrfsFilesystemSource(etcDir, btrfsPath string, run func(string, ...string) (string, error)) storage.FilesystemSource {
+	return &btrfsFilesystemSource{
+		&MockDirFuncs{
+			osDirFuncs{run},
+			etcDir,
+			set.NewStrings(),
+		},
+		run,
+		btrfsPath,
+	}
+}
+
+func BtrfsProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &btrfsProvider{run}
+}
@@ -0,0 +1,262 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	ZFSProviderType = storage.ProviderType("zfs")
+
+	// ZFSPool is the attribute name for the ZFS pool, or dataset
+	// within a pool, under which filesystem datasets are created.
+	// If this is not provided, the pool name will be set to "juju".
+	ZFSPool = "zfs-pool"
+
+	defaultZFSPool = "juju"
+)
+
+var zfsConfigFields = schema.Fields{
+	ZFSPool: schema.String(),
+}
+
+var zfsConfigChecker = schema.FieldMap(
+	zfsConfigFields,
+	schema.Defaults{
+		ZFSPool: defaultZFSPool,
+	},
+)
+
+// zfsProvider creates storage sources which provide access to
+// quota-limited ZFS datasets carved out of a pool on the host machine.
+type zfsProvider struct {
+	// run is a function type used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider = (*zfsProvider)(nil)
+)
+
+// zfsPoolName returns the validated ZFS pool name from the
+// specified storage config.
+func zfsPoolName(cfg *storage.Config) (string, error) {
+	coerced, err := zfsConfigChecker.Coerce(cfg.Attrs(), nil)
+	if err != nil {
+		return "", errors.Annotate(err, "validating zfs storage config")
+	}
+	pool := coerced.(map[string]interface{})[ZFSPool].(string)
+	if pool == "" {
+		return "", errors.Errorf("%s must not be empty", ZFSPool)
+	}
+	if strings.HasPrefix(pool, "/") || strings.HasSuffix(pool, "/") {
+		return "", errors.Errorf("%s %q must not begin or end with a slash", ZFSPool, pool)
+	}
+	return pool, nil
+}
+
+// ValidateConfig is defined on the Provider interface.
+func (p *zfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := zfsPoolName(cfg)
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *zfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *zfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	pool, err := zfsPoolName(sourceConfig)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &zfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		pool,
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*zfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*zfsProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*zfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*zfsProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*zfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+type zfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+	pool     string
+}
+
+var _ storage.FilesystemSource = (*zfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// ValidateFilesystemParams may be called on a machine other than the
+	// machine where the filesystem will be mounted, so we cannot check
+	// that the pool exists until we get to createFilesystem.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *zfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	dataset := path.Join(s.pool, params.Tag.String())
+
+	// The dataset is created with a legacy mount point, so that
+	// the mount is under our control rather than ZFS's. The quota
+	// limits the dataset, including any descendants and snapshots,
+	// to the requested size.
+	if _, err := s.run(
+		"zfs", "create",
+		"-o", fmt.Sprintf("quota=%dM", params.Size),
+		"-o", "mountpoint=legacy",
+		dataset,
+	); err != nil {
+		return nil, errors.Annotatef(err, "creating zfs dataset %q", dataset)
+	}
+	return &storage.Filesystem{
+		Tag: params.Tag,
+		FilesystemInfo: storage.FilesystemInfo{
+			FilesystemId: dataset,
+			Size:         params.Size,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, dataset := range filesystemIds {
+		if _, err := s.run("zfs", "destroy", "-r", dataset); err != nil {
+			results[i] = errors.Annotatef(err, "destroying zfs dataset %q", dataset)
+		}
+	}
+	return results, nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *zfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	mountPoint := arg.Path
+	if mountPoint == "" {
+		return nil, errNoMountPoint
+	}
+	dataset := arg.FilesystemId
+	if dataset == "" {
+		dataset = path.Join(s.pool, arg.Filesystem.String())
+	}
+	if err := ensureDir(s.dirFuncs, mountPoint); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Check if the mount already exists.
+	source, err := s.dirFuncs.mountPointSource(mountPoint)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if source != dataset {
+		if err := ensureEmptyDir(s.dirFuncs, mountPoint); err != nil {
+			return nil, err
+		}
+		options := "defaults"
+		if arg.ReadOnly {
+			options = "ro"
+		}
+		if _, err := s.run(
+			"mount", "-t", "zfs", "-o", options, dataset, mountPoint,
+		); err != nil {
+			return nil, errors.Annotate(err, "cannot mount zfs dataset")
+		}
+		// Record the mount in /etc/fstab so that it is
+		// available after a reboot.
+		entry := fmt.Sprintf("%s %s zfs %s 0 0", dataset, mountPoint, options)
+		if err := addFstabEntry(s.dirFuncs.etcDir(), dataset, mountPoint, entry); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     mountPoint,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
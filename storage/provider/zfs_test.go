@@ -0,0 +1,224 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/names/v4"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&zfsSuite{})
+
+type zfsSuite struct {
+	testing.BaseSuite
+	commands   *mockRunCommand
+	fakeEtcDir string
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *zfsSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("Tests relevant only on *nix systems")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.fakeEtcDir = c.MkDir()
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *zfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *zfsSuite) zfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.ZFSProvider(s.commands.run)
+}
+
+func (s *zfsSuite) zfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.ZFSFilesystemSource(s.fakeEtcDir, "tank/juju", s.commands.run)
+}
+
+func (s *zfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.zfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *zfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.zfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{
+		"zfs-pool": "tank/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *zfsSuite) TestValidateConfigInvalidPool(c *gc.C) {
+	p := s.zfsProvider(c)
+	for _, pool := range []interface{}{"", "/tank", "tank/", 123} {
+		cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{
+			"zfs-pool": pool,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		c.Assert(err, gc.NotNil, gc.Commentf("pool %v", pool))
+	}
+}
+
+func (s *zfsSuite) TestSupports(c *gc.C) {
+	p := s.zfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *zfsSuite) TestScope(c *gc.C) {
+	p := s.zfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *zfsSuite) TestCreateFilesystems(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	s.commands.expect("zfs", "create", "-o", "quota=2048M", "-o", "mountpoint=legacy", "tank/juju/filesystem-6")
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("6"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "tank/juju/filesystem-6",
+				Size:         2048,
+			},
+		},
+	}})
+}
+
+func (s *zfsSuite) TestCreateFilesystemsFails(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("zfs", "create", "-o", "quota=1M", "-o", "mountpoint=legacy", "tank/juju/filesystem-6")
+	cmd.respond("", errors.New("dataset already exists"))
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("6"),
+		Size: 1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating zfs dataset "tank/juju/filesystem-6": dataset already exists`)
+}
+
+func (s *zfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	s.commands.expect("zfs", "destroy", "-r", "tank/juju/filesystem-1")
+	cmd := s.commands.expect("zfs", "destroy", "-r", "tank/juju/filesystem-2")
+	cmd.respond("", errors.New("dataset is busy"))
+
+	results, err := source.DestroyFilesystems(s.callCtx, []string{
+		"tank/juju/filesystem-1", "tank/juju/filesystem-2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `destroying zfs dataset "tank/juju/filesystem-2": dataset is busy`)
+}
+
+func (s *zfsSuite) TestAttachFilesystems(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("header\nvalue", nil)
+	s.commands.expect("mount", "-t", "zfs", "-o", "ro", "tank/juju/filesystem-1", "/srv/data")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: "tank/juju/filesystem-1",
+		Path:         "/srv/data",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0/lxd/0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			Filesystem: names.NewFilesystemTag("1"),
+			Machine:    names.NewMachineTag("0/lxd/0"),
+			FilesystemAttachmentInfo: storage.FilesystemAttachmentInfo{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	}})
+
+	fstab, err := ioutil.ReadFile(filepath.Join(s.fakeEtcDir, "fstab"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(fstab), gc.Equals, "\ntank/juju/filesystem-1 /srv/data zfs ro 0 0\n")
+}
+
+func (s *zfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "exists")
+	cmd.respond("header\ntank/juju/filesystem-1", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "exists",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *zfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv/data")
+	cmd.respond("header\nvalue", nil)
+	cmd = s.commands.expect("mount", "-t", "zfs", "-o", "defaults", "tank/juju/filesystem-1", "/srv/data")
+	cmd.respond("", errors.New("mount failed"))
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "cannot mount zfs dataset: mount failed")
+}
+
+func (s *zfsSuite) TestAttachFilesystemsNoPathSpecified(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("6"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "filesystem mount point not specified")
+}
+
+func (s *zfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true, s.fakeEtcDir, "")
+}
+
+func (s *zfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source := s.zfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
+}
@@ -0,0 +1,189 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Logger represents the methods used by the worker to log information.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade defines the capabilities required by the worker.
+type Facade interface {
+
+	// WatchActionSchedules returns a watcher that notifies
+	// of changes to the action schedules of the model.
+	WatchActionSchedules() (watcher.NotifyWatcher, error)
+
+	// ActionScheduleTimes returns the time at which the
+	// action of each schedule of the model is next due.
+	ActionScheduleTimes() ([]params.ActionScheduleTime, error)
+
+	// TriggerActionSchedule enqueues the action of the
+	// specified schedule, which was due at the specified time.
+	TriggerActionSchedule(id string, due time.Time) error
+}
+
+// Config defines a worker's dependencies.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate returns an error if the config can't be expected
+// to run a functional worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker enqueues the actions of a model when they are due
+// on their schedules.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that enqueues the actions of the
+// model when they are due on their schedules. The runs that were
+// due while no worker was running are made up by a single run.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	schedulesWatcher, err := w.config.Facade.WatchActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(schedulesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var timeout <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-schedulesWatcher.Changes():
+			if !ok {
+				return errors.New("action schedules watcher closed")
+			}
+		case <-timeout:
+		}
+		next, err := w.triggerDue()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		timeout = nil
+		if !next.IsZero() {
+			timeout = w.config.Clock.After(next.Sub(w.config.Clock.Now()))
+		}
+	}
+}
+
+// triggerDue triggers the runs of the actions which are due, and
+// returns the time the next action is due, or the zero time if
+// there is none.
+func (w *Worker) triggerDue() (time.Time, error) {
+	schedules, err := w.config.Facade.ActionScheduleTimes()
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	var next time.Time
+	for _, schedule := range schedules {
+		if schedule.Paused {
+			continue
+		}
+		if schedule.NextRun.After(now) {
+			if next.IsZero() || schedule.NextRun.Before(next) {
+				next = schedule.NextRun
+			}
+			continue
+		}
+		due, err := latestDue(schedule, now)
+		if err != nil {
+			w.config.Logger.Errorf("cannot run action schedule %q: %v", schedule.Id, err)
+			continue
+		}
+		if !due.Equal(schedule.NextRun) {
+			w.config.Logger.Infof("action schedule %q missed runs since %v", schedule.Id, schedule.NextRun)
+		}
+		err = w.config.Facade.TriggerActionSchedule(schedule.Id, due)
+		switch {
+		case params.IsCodeAlreadyExists(err), params.IsCodeNotFound(err):
+			// The run was already triggered, or the
+			// schedule was removed since it was read.
+			w.config.Logger.Debugf("not running action schedule %q: %v", schedule.Id, err)
+		case err != nil:
+			return time.Time{}, errors.Annotatef(err, "running action schedule %q", schedule.Id)
+		default:
+			w.config.Logger.Debugf("ran action schedule %q due at %v", schedule.Id, due)
+		}
+		// The schedule will be reported by the watcher once the
+		// run is recorded, and the time it is next due read again.
+	}
+	return next, nil
+}
+
+// latestDue returns the last time the action of the schedule
+// was due, no later than now.
+func latestDue(schedule params.ActionScheduleTime, now time.Time) (time.Time, error) {
+	spec, err := actions.ParseSchedule(schedule.Schedule)
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	due := schedule.NextRun
+	for {
+		next := spec.Next(due)
+		if next.IsZero() || next.After(now) {
+			return due, nil
+		}
+		due = next
+	}
+}
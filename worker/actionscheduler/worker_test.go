@@ -0,0 +1,193 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	facade *mockFacade
+	config actionscheduler.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 1, 2, 3, 30, 0, 0, time.UTC))
+	s.facade = &mockFacade{
+		changes:  make(chan struct{}, 1),
+		triggers: make(chan trigger, 10),
+	}
+	s.facade.changes <- struct{}{}
+	s.config = actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+	config = s.config
+	config.Clock = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Clock not valid")
+	config = s.config
+	config.Logger = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Logger not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) *actionscheduler.Worker {
+	w, err := actionscheduler.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w.(*actionscheduler.Worker)
+}
+
+func (s *WorkerSuite) assertTrigger(c *gc.C, id string, due time.Time) {
+	select {
+	case t := <-s.facade.triggers:
+		c.Assert(t, jc.DeepEquals, trigger{id: id, due: due})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedule %q to be triggered", id)
+	}
+}
+
+func (s *WorkerSuite) assertNoTrigger(c *gc.C) {
+	select {
+	case t := <-s.facade.triggers:
+		c.Fatalf("unexpected trigger %v", t)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestTriggersDueSchedule(c *gc.C) {
+	due := s.clock.Now().Add(-time.Minute)
+	s.facade.setTimes(params.ActionScheduleTime{Id: "1", Schedule: "29 * * * *", NextRun: due})
+	s.startWorker(c)
+	s.assertTrigger(c, "1", due)
+}
+
+func (s *WorkerSuite) TestTriggersMissedRunsOnce(c *gc.C) {
+	s.facade.setTimes(params.ActionScheduleTime{
+		Id:       "1",
+		Schedule: "0 * * * *",
+		NextRun:  time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+	})
+	s.startWorker(c)
+	s.assertTrigger(c, "1", time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC))
+	s.assertNoTrigger(c)
+}
+
+func (s *WorkerSuite) TestWaitsForNextRun(c *gc.C) {
+	due := time.Date(2020, 1, 2, 4, 0, 0, 0, time.UTC)
+	s.facade.setTimes(
+		params.ActionScheduleTime{Id: "1", Schedule: "0 * * * *", NextRun: due},
+		params.ActionScheduleTime{Id: "2", Schedule: "0 5 * * *", NextRun: due.Add(time.Hour)},
+	)
+	s.startWorker(c)
+	err := s.clock.WaitAdvance(29*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoTrigger(c)
+
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertTrigger(c, "1", due)
+	s.assertNoTrigger(c)
+}
+
+func (s *WorkerSuite) TestRereadsSchedulesOnChange(c *gc.C) {
+	s.startWorker(c)
+	s.assertNoTrigger(c)
+
+	due := s.clock.Now()
+	s.facade.setTimes(params.ActionScheduleTime{Id: "1", Schedule: "30 * * * *", NextRun: due})
+	s.facade.changes <- struct{}{}
+	s.assertTrigger(c, "1", due)
+}
+
+func (s *WorkerSuite) TestSkipsPausedSchedules(c *gc.C) {
+	s.facade.setTimes(params.ActionScheduleTime{
+		Id:       "1",
+		Schedule: "0 * * * *",
+		Paused:   true,
+		NextRun:  s.clock.Now().Add(-time.Hour),
+	})
+	s.startWorker(c)
+	s.assertNoTrigger(c)
+}
+
+func (s *WorkerSuite) TestIgnoresAlreadyTriggered(c *gc.C) {
+	s.facade.triggerErr = &params.Error{Code: params.CodeAlreadyExists, Message: "run already exists"}
+	due := s.clock.Now()
+	s.facade.setTimes(params.ActionScheduleTime{Id: "1", Schedule: "30 * * * *", NextRun: due})
+	w := s.startWorker(c)
+	s.assertTrigger(c, "1", due)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestTriggerError(c *gc.C) {
+	s.facade.triggerErr = errors.New("boom")
+	due := s.clock.Now()
+	s.facade.setTimes(params.ActionScheduleTime{Id: "1", Schedule: "30 * * * *", NextRun: due})
+	w := s.startWorker(c)
+	s.assertTrigger(c, "1", due)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `running action schedule "1": boom`)
+}
+
+type trigger struct {
+	id  string
+	due time.Time
+}
+
+type mockFacade struct {
+	mu         sync.Mutex
+	times      []params.ActionScheduleTime
+	changes    chan struct{}
+	triggers   chan trigger
+	triggerErr error
+}
+
+func (f *mockFacade) setTimes(times ...params.ActionScheduleTime) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.times = times
+}
+
+func (f *mockFacade) WatchActionSchedules() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *mockFacade) ActionScheduleTimes() ([]params.ActionScheduleTime, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.times, nil
+}
+
+func (f *mockFacade) TriggerActionSchedule(id string, due time.Time) error {
+	f.triggers <- trigger{id: id, due: due}
+	return f.triggerErr
+}
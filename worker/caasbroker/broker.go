@@ -8,12 +8,14 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/worker/v2/catacomb"
+	"github.com/juju/worker/v2/dependency"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
 )
 
 // Logger is here to stop the desire of creating a package level Logger.
@@ -24,6 +26,7 @@ var logger interface{}
 // that allows clients to be informed of changes to the configuration.
 type ConfigAPI interface {
 	CloudSpec() (environs.CloudSpec, error)
+	ZoneCloudSpec(zone string) (environs.CloudSpec, error)
 	ModelConfig() (*config.Config, error)
 	ControllerConfig() (controller.Config, error)
 	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
@@ -61,6 +64,7 @@ type Tracker struct {
 	catacomb         catacomb.Catacomb
 	broker           caas.Broker
 	currentCloudSpec environs.CloudSpec
+	currentZones     interface{}
 }
 
 // NewTracker returns a new Tracker, or an error if anything goes wrong.
@@ -92,11 +96,18 @@ func NewTracker(config Config) (*Tracker, error) {
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot create caas broker")
 	}
+	zones := caas.ClusterZones(cfg)
+	if len(zones) > 0 {
+		if broker, err = newZonedBroker(config, ctrlCfg.ControllerUUID(), cloudSpec.Name, broker, zones, cfg); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
 
 	t := &Tracker{
 		config:           config,
 		broker:           broker,
 		currentCloudSpec: cloudSpec,
+		currentZones:     cfg.AllAttrs()[caas.ClusterZonesKey],
 	}
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &t.catacomb,
@@ -108,6 +119,36 @@ func NewTracker(config Config) (*Tracker, error) {
 	return t, nil
 }
 
+// newZonedBroker returns a broker spanning the cluster of the model's
+// cloud, the primary zone, and the clusters of the specified zones.
+// The model's namespace is created in any zone lacking it, such as
+// a zone added after the model was created.
+func newZonedBroker(
+	config Config, controllerUUID, primaryZone string, primary caas.Broker, zones []string, cfg *config.Config,
+) (caas.Broker, error) {
+	brokers := make(map[string]caas.Broker)
+	for _, zone := range zones {
+		cloudSpec, err := config.ConfigAPI.ZoneCloudSpec(zone)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get cloud information for cluster zone %q", zone)
+		}
+		broker, err := config.NewContainerBrokerFunc(environs.OpenParams{
+			ControllerUUID: controllerUUID,
+			Cloud:          cloudSpec,
+			Config:         cfg,
+		})
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot create caas broker for cluster zone %q", zone)
+		}
+		err = broker.Create(context.NewCloudCallContext(), environs.CreateParams{ControllerUUID: controllerUUID})
+		if err != nil && !errors.IsAlreadyExists(err) {
+			return nil, errors.Annotatef(err, "cannot create model in cluster zone %q", zone)
+		}
+		brokers[zone] = broker
+	}
+	return caas.NewZonedBroker(primaryZone, primary, brokers), nil
+}
+
 // Broker returns the encapsulated Broker. It will continue to be updated in
 // the background for as long as the Tracker continues to run.
 func (t *Tracker) Broker() caas.Broker {
@@ -158,6 +199,13 @@ func (t *Tracker) loop() error {
 			if err != nil {
 				return errors.Annotate(err, "cannot read model config")
 			}
+			if zones := modelConfig.AllAttrs()[caas.ClusterZonesKey]; zones != t.currentZones {
+				// The clusters spanned by the model, or the credentials
+				// used for them, have changed, so restart to create a
+				// broker for each of them.
+				logger.Debugf("cluster zones changed from %v to %v", t.currentZones, zones)
+				return dependency.ErrBounce
+			}
 			if err = t.broker.SetConfig(modelConfig); err != nil {
 				return errors.Annotate(err, "cannot update model config")
 			}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/worker/v2/dependency"
 	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
@@ -391,3 +392,88 @@ func (s *TrackerSuite) TestWatchedCloudSpecUpdates(c *gc.C) {
 		}
 	})
 }
+
+func (s *TrackerSuite) zonedFixture() *fixture {
+	fix := s.validFixture()
+	fix.initialConfig["cluster-zones"] = "west:west-cred"
+	fix.zoneSpecs = map[string]environs.CloudSpec{
+		"west": {Name: "west", Type: "kubernetes"},
+	}
+	return fix
+}
+
+func (s *TrackerSuite) TestClusterZones(c *gc.C) {
+	fix := s.zonedFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+			Logger:                 loggo.GetLogger("test"),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.CleanKill(c, tracker)
+
+		zoned, ok := tracker.Broker().(caas.ZonedBroker)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(zoned.Zones(), jc.DeepEquals, []string{"foo", "west"})
+		west, err := zoned.ZoneBroker("west")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(west.(*mockBroker).CloudSpec(), jc.DeepEquals, fix.zoneSpecs["west"])
+		west.(*mockBroker).CheckCall(c, 0, "Create", environs.CreateParams{ControllerUUID: coretesting.ControllerTag.Id()})
+		context.CheckCallNames(c, "CloudSpec", "ModelConfig", "ControllerConfig", "ZoneCloudSpec")
+	})
+}
+
+func (s *TrackerSuite) TestClusterZoneCredentialChangeBounces(c *gc.C) {
+	fix := s.zonedFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+			Logger:                 loggo.GetLogger("test"),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		attrs := s.validFixture().initialConfig
+		attrs["cluster-zones"] = "west:other-cred"
+		context.SetConfig(c, attrs)
+		context.SendModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.Equals, dependency.ErrBounce)
+	})
+}
+
+func (s *TrackerSuite) TestClusterZoneCloudSpecFails(c *gc.C) {
+	fix := s.zonedFixture()
+	fix.zoneSpecs = nil
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+			Logger:                 loggo.GetLogger("test"),
+		})
+		c.Check(err, gc.ErrorMatches, `cannot get cloud information for cluster zone "west": cluster zone "west" not found`)
+		c.Check(tracker, gc.IsNil)
+	})
+}
+
+func (s *TrackerSuite) TestClusterZonesChangeBounces(c *gc.C) {
+	fix := s.zonedFixture()
+	fix.Run(c, func(context *runContext) {
+		tracker, err := caasbroker.NewTracker(caasbroker.Config{
+			ConfigAPI:              context,
+			NewContainerBrokerFunc: newMockBroker,
+			Logger:                 loggo.GetLogger("test"),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		defer workertest.DirtyKill(c, tracker)
+
+		attrs := s.validFixture().initialConfig
+		attrs["cluster-zones"] = "west:west-cred,north:north-cred"
+		context.SetConfig(c, attrs)
+		context.SendModelConfigNotify()
+		err = workertest.CheckKilled(c, tracker)
+		c.Check(err, gc.Equals, dependency.ErrBounce)
+	})
+}
@@ -6,6 +6,7 @@ package caasbroker_test
 import (
 	"sync"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	"github.com/juju/worker/v2"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
 	coretesting "github.com/juju/juju/testing"
 
 	jujutesting "github.com/juju/juju/testing"
@@ -28,6 +30,7 @@ type fixture struct {
 	cloud         environs.CloudSpec
 	initialConfig map[string]interface{}
 	initialSpec   environs.CloudSpec
+	zoneSpecs     map[string]environs.CloudSpec
 }
 
 func (fix *fixture) Run(c *gc.C, test func(*runContext)) {
@@ -37,6 +40,7 @@ func (fix *fixture) Run(c *gc.C, test func(*runContext)) {
 	defer workertest.DirtyKill(c, cloudWatcher)
 	context := &runContext{
 		cloud:        fix.initialSpec,
+		zoneSpecs:    fix.zoneSpecs,
 		config:       newModelConfig(c, fix.initialConfig),
 		watcher:      watcher,
 		cloudWatcher: cloudWatcher,
@@ -49,6 +53,7 @@ type runContext struct {
 	mu           sync.Mutex
 	stub         testing.Stub
 	cloud        environs.CloudSpec
+	zoneSpecs    map[string]environs.CloudSpec
 	config       map[string]interface{}
 	watcher      *notifyWatcher
 	cloudWatcher *notifyWatcher
@@ -80,6 +85,21 @@ func (context *runContext) CloudSpec() (environs.CloudSpec, error) {
 	return context.cloud, nil
 }
 
+// ZoneCloudSpec is part of the environ.ConfigObserver interface.
+func (context *runContext) ZoneCloudSpec(zone string) (environs.CloudSpec, error) {
+	context.mu.Lock()
+	defer context.mu.Unlock()
+	context.stub.AddCall("ZoneCloudSpec", zone)
+	if err := context.stub.NextErr(); err != nil {
+		return environs.CloudSpec{}, err
+	}
+	spec, ok := context.zoneSpecs[zone]
+	if !ok {
+		return environs.CloudSpec{}, errors.NotFoundf("cluster zone %q", zone)
+	}
+	return spec, nil
+}
+
 // ModelConfig is part of the environ.ConfigObserver interface.
 func (context *runContext) ModelConfig() (*config.Config, error) {
 	context.mu.Lock()
@@ -263,3 +283,10 @@ func (e *mockBroker) SetConfig(cfg *config.Config) error {
 	e.cfg = cfg
 	return nil
 }
+
+func (e *mockBroker) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.MethodCall(e, "Create", args)
+	return e.NextErr()
+}
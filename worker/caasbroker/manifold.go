@@ -71,9 +71,9 @@ func manifoldOutput(in worker.Worker, out interface{}) error {
 	case *caas.Broker:
 		*result = inTracker.Broker()
 	case *caasadmission.K8sBroker:
-		*result = inTracker.Broker().(caasadmission.K8sBroker)
+		*result = primaryBroker(inTracker.Broker()).(caasadmission.K8sBroker)
 	case *caasrbacmapper.K8sBroker:
-		*result = inTracker.Broker().(caasrbacmapper.K8sBroker)
+		*result = primaryBroker(inTracker.Broker()).(caasrbacmapper.K8sBroker)
 	case *environs.CloudDestroyer:
 		*result = inTracker.Broker()
 	case *storage.ProviderRegistry:
@@ -83,3 +83,16 @@ func manifoldOutput(in worker.Worker, out interface{}) error {
 	}
 	return nil
 }
+
+// primaryBroker returns the broker of the model's own cluster.
+func primaryBroker(broker caas.Broker) caas.Broker {
+	zoned, ok := broker.(caas.ZonedBroker)
+	if !ok {
+		return broker
+	}
+	primary, err := zoned.ZoneBroker(zoned.Zones()[0])
+	if err != nil {
+		return broker
+	}
+	return primary
+}
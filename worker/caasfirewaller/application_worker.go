@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -11,6 +12,9 @@ import (
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
 
+	"github.com/juju/juju/caas"
+	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -21,12 +25,27 @@ type applicationWorker struct {
 	application       string
 	applicationGetter ApplicationGetter
 	serviceExposer    ServiceExposer
+	policyEnsurer     NetworkPolicyEnsurer
 
-	lifeGetter LifeGetter
+	lifeGetter        LifeGetter
+	modelConfigGetter ModelConfigGetter
 
 	initial           bool
 	previouslyExposed bool
 
+	// exposedConfig holds the application config last used
+	// to expose the application, so that the application is
+	// exposed again if the config changes, eg to rotate the
+	// ingress TLS certificate.
+	exposedConfig application.ConfigAttributes
+
+	// networkPolicies records whether the model config enables
+	// network policies, and networkPolicy holds the policy last
+	// applied, if policyEnsured is true.
+	networkPolicies bool
+	networkPolicy   *caas.NetworkPolicy
+	policyEnsured   bool
+
 	logger Logger
 }
 
@@ -36,7 +55,9 @@ func newApplicationWorker(
 	application string,
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
+	policyEnsurer NetworkPolicyEnsurer,
 	lifeGetter LifeGetter,
+	modelConfigGetter ModelConfigGetter,
 	logger Logger,
 ) (worker.Worker, error) {
 	w := &applicationWorker{
@@ -45,7 +66,9 @@ func newApplicationWorker(
 		application:       application,
 		applicationGetter: applicationGetter,
 		serviceExposer:    applicationExposer,
+		policyEnsurer:     policyEnsurer,
 		lifeGetter:        lifeGetter,
+		modelConfigGetter: modelConfigGetter,
 		initial:           true,
 		logger:            logger,
 	}
@@ -83,6 +106,32 @@ func (w *applicationWorker) loop() (err error) {
 	if err := w.catacomb.Add(appWatcher); err != nil {
 		return errors.Trace(err)
 	}
+	relationsWatcher, err := w.applicationGetter.WatchApplicationRelations(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(relationsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	modelConfigWatcher, err := w.modelConfigGetter.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	// Read the model config up front so that an existing network
+	// policy is not removed while waiting for the first config change.
+	if err := w.processModelConfigChange(); err != nil {
+		return errors.Trace(err)
+	}
 
 	for {
 		select {
@@ -98,26 +147,100 @@ func (w *applicationWorker) loop() (err error) {
 				}
 				return errors.Trace(err)
 			}
+			// The application's service ports may have changed
+			// too, so always update the network policy.
+			if err := w.processNetworkPolicyChange(true); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-appConfigWatcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed")
+			}
+			if err := w.processApplicationConfigChange(); err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					return nil
+				}
+				return errors.Trace(err)
+			}
+		case _, ok := <-relationsWatcher.Changes():
+			if !ok {
+				return errors.New("relations watcher closed")
+			}
+			if err := w.processNetworkPolicyChange(false); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.processModelConfigChange(); err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.processNetworkPolicyChange(false); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
-func (w *applicationWorker) processApplicationChange() (err error) {
+func (w *applicationWorker) processModelConfigChange() error {
+	cfg, err := w.modelConfigGetter.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.networkPolicies = k8sprovider.NetworkPoliciesEnabled(cfg)
+	return nil
+}
+
+// processNetworkPolicyChange ensures that the network policy of the
+// application admits ingress only from its related applications and,
+// if it is exposed, from any source. The policy is removed if network
+// policies are not enabled for the model. Unless force is true, the
+// policy is only applied if it differs from the one last applied.
+func (w *applicationWorker) processNetworkPolicyChange(force bool) (err error) {
 	defer func() {
-		// Not found could be because the app got removed or there's
-		// no container service created yet as the app is still being set up.
+		// Not found could be because the app got removed.
 		if errors.IsNotFound(err) {
-			// Perhaps the app got removed while we were processing.
 			if _, err2 := w.lifeGetter.Life(w.application); err2 != nil {
 				err = err2
 				return
 			}
-			// Ignore not found error because the ip could be not ready yet at this stage.
-			w.logger.Warningf("processing change for application %q, %v", w.application, err)
+			w.logger.Warningf("processing network policy for application %q, %v", w.application, err)
 			err = nil
 		}
 	}()
 
+	var policy *caas.NetworkPolicy
+	if w.networkPolicies {
+		exposed, err := w.applicationGetter.IsExposed(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		related, err := w.applicationGetter.RelatedApplications(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		policy = &caas.NetworkPolicy{
+			AllowedApplications: related,
+			Exposed:             exposed,
+		}
+	}
+	if w.policyEnsured && reflect.DeepEqual(policy, w.networkPolicy) && (!force || policy == nil) {
+		return nil
+	}
+	if err := w.policyEnsurer.EnsureNetworkPolicy(w.application, policy); err != nil {
+		return errors.Annotate(err, "ensuring network policy")
+	}
+	w.networkPolicy = policy
+	w.policyEnsured = true
+	return nil
+}
+
+func (w *applicationWorker) processApplicationChange() (err error) {
+	defer func() {
+		err = w.handleNotFound(err)
+	}()
+
 	exposed, err := w.applicationGetter.IsExposed(w.application)
 	if err != nil {
 		return errors.Trace(err)
@@ -133,17 +256,64 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		resourceTags := tags.ResourceTags(
-			names.NewModelTag(w.modelUUID),
-			names.NewControllerTag(w.controllerUUID),
-		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
-			return errors.Trace(err)
-		}
-		return nil
+		return errors.Trace(w.exposeService(appConfig))
 	}
+	w.exposedConfig = nil
 	if err := w.serviceExposer.UnexposeService(w.application); err != nil {
 		return errors.Trace(err)
 	}
 	return nil
 }
+
+// processApplicationConfigChange exposes an exposed application
+// again if its config has changed since it was last exposed.
+func (w *applicationWorker) processApplicationConfigChange() (err error) {
+	defer func() {
+		err = w.handleNotFound(err)
+	}()
+
+	if !w.previouslyExposed {
+		return nil
+	}
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if reflect.DeepEqual(appConfig, w.exposedConfig) {
+		return nil
+	}
+	return errors.Trace(w.exposeService(appConfig))
+}
+
+func (w *applicationWorker) exposeService(appConfig application.ConfigAttributes) error {
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(w.modelUUID),
+		names.NewControllerTag(w.controllerUUID),
+	)
+	err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig)
+	if errors.IsNotValid(err) {
+		// Invalid config can only be fixed by the user, so
+		// wait for the config to change rather than failing.
+		w.logger.Errorf("cannot expose application %q: %v", w.application, err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	w.exposedConfig = appConfig
+	return nil
+}
+
+// handleNotFound ignores not found errors, which could be because
+// the app got removed or there's no container service created yet
+// as the app is still being set up.
+func (w *applicationWorker) handleNotFound(err error) error {
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	// Perhaps the app got removed while we were processing.
+	if _, err2 := w.lifeGetter.Life(w.application); err2 != nil {
+		return err2
+	}
+	// Ignore not found error because the ip could be not ready yet at this stage.
+	w.logger.Warningf("processing change for application %q, %v", w.application, err)
+	return nil
+}
@@ -3,9 +3,18 @@
 
 package caasfirewaller
 
-import "github.com/juju/juju/core/application"
+import (
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
+)
 
 type ServiceExposer interface {
 	ExposeService(appName string, resourceTags map[string]string, config application.ConfigAttributes) error
 	UnexposeService(appName string) error
 }
+
+// NetworkPolicyEnsurer provides an interface for restricting
+// ingress to an application's units.
+type NetworkPolicyEnsurer interface {
+	EnsureNetworkPolicy(appName string, policy *caas.NetworkPolicy) error
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 // Client provides an interface for interacting with the
@@ -15,6 +16,7 @@ import (
 type Client interface {
 	ApplicationGetter
 	LifeGetter
+	ModelConfigGetter
 }
 
 // ApplicationGetter provides an interface for
@@ -26,6 +28,9 @@ type ApplicationGetter interface {
 	WatchApplication(string) (watcher.NotifyWatcher, error)
 	IsExposed(string) (bool, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
+	WatchApplicationRelations(string) (watcher.StringsWatcher, error)
+	RelatedApplications(string) ([]string, error)
 }
 
 // LifeGetter provides an interface for getting the
@@ -33,3 +38,10 @@ type ApplicationGetter interface {
 type LifeGetter interface {
 	Life(string) (life.Value, error)
 }
+
+// ModelConfigGetter provides an interface for
+// watching and getting the model config.
+type ModelConfigGetter interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+}
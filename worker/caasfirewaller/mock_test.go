@@ -4,6 +4,8 @@
 package caasfirewaller_test
 
 import (
+	"sync"
+
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/api/base"
@@ -12,6 +14,8 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/caasfirewaller"
 )
 
@@ -45,11 +49,42 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+type mockNetworkPolicyEnsurer struct {
+	testing.Stub
+	ensured chan<- struct{}
+}
+
+func (m *mockNetworkPolicyEnsurer) EnsureNetworkPolicy(appName string, policy *caas.NetworkPolicy) error {
+	m.MethodCall(m, "EnsureNetworkPolicy", appName, policy)
+	if m.ensured != nil {
+		m.ensured <- struct{}{}
+	}
+	return m.NextErr()
+}
+
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher       *watchertest.MockStringsWatcher
+	appWatcher       *watchertest.MockNotifyWatcher
+	relationsWatcher *watchertest.MockStringsWatcher
+	configWatcher    *watchertest.MockNotifyWatcher
+	exposed          bool
+
+	mu      sync.Mutex
+	related []string
+	config  application.ConfigAttributes
+}
+
+func (m *mockApplicationGetter) setConfig(config application.ConfigAttributes) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
+}
+
+func (m *mockApplicationGetter) setRelated(related []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.related = related
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -77,10 +112,65 @@ func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 }
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
 }
 
+func (m *mockApplicationGetter) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.configWatcher, nil
+}
+
+func (m *mockApplicationGetter) WatchApplicationRelations(appName string) (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchApplicationRelations", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.relationsWatcher, nil
+}
+
+func (m *mockApplicationGetter) RelatedApplications(appName string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.MethodCall(m, "RelatedApplications", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.related, nil
+}
+
+type mockModelConfigGetter struct {
+	testing.Stub
+	watcher         *watchertest.MockNotifyWatcher
+	networkPolicies bool
+}
+
+func (m *mockModelConfigGetter) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+func (m *mockModelConfigGetter) ModelConfig() (*config.Config, error) {
+	m.MethodCall(m, "ModelConfig")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"network-policies": m.networkPolicies,
+	}))
+}
+
 type mockLifeGetter struct {
 	testing.Stub
 	life life.Value
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/worker/v2/workertest"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
@@ -26,10 +27,15 @@ type WorkerSuite struct {
 	config            caasfirewaller.Config
 	applicationGetter mockApplicationGetter
 	serviceExposer    mockServiceExposer
+	policyEnsurer     mockNetworkPolicyEnsurer
 	lifeGetter        mockLifeGetter
+	modelConfigGetter mockModelConfigGetter
 
 	applicationChanges chan []string
 	appExposedChange   chan struct{}
+	relationsChanges   chan []string
+	appConfigChanges   chan struct{}
+	modelConfigChanges chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
 }
@@ -43,13 +49,23 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
+	s.relationsChanges = make(chan []string)
+	s.appConfigChanges = make(chan struct{})
+	s.modelConfigChanges = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:       watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:       watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		relationsWatcher: watchertest.NewMockStringsWatcher(s.relationsChanges),
+		configWatcher:    watchertest.NewMockNotifyWatcher(s.appConfigChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
 
+	s.modelConfigGetter = mockModelConfigGetter{
+		watcher: watchertest.NewMockNotifyWatcher(s.modelConfigChanges),
+	}
+	s.policyEnsurer = mockNetworkPolicyEnsurer{}
+
 	s.lifeGetter = mockLifeGetter{
 		life: life.Alive,
 	}
@@ -59,12 +75,14 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.config = caasfirewaller.Config{
-		ControllerUUID:    coretesting.ControllerTag.Id(),
-		ModelUUID:         coretesting.ModelTag.Id(),
-		ApplicationGetter: &s.applicationGetter,
-		ServiceExposer:    &s.serviceExposer,
-		LifeGetter:        &s.lifeGetter,
-		Logger:            loggo.GetLogger("test"),
+		ControllerUUID:       coretesting.ControllerTag.Id(),
+		ModelUUID:            coretesting.ModelTag.Id(),
+		ApplicationGetter:    &s.applicationGetter,
+		ServiceExposer:       &s.serviceExposer,
+		NetworkPolicyEnsurer: &s.policyEnsurer,
+		LifeGetter:           &s.lifeGetter,
+		ModelConfigGetter:    &s.modelConfigGetter,
+		Logger:               loggo.GetLogger("test"),
 	}
 }
 
@@ -76,6 +94,14 @@ func (s *WorkerSuite) sendApplicationExposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) sendApplicationConfigChange(c *gc.C) {
+	select {
+	case s.appConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending application config change")
+	}
+}
+
 func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.ControllerUUID = ""
@@ -97,6 +123,14 @@ func (s *WorkerSuite) TestValidateConfig(c *gc.C) {
 		config.LifeGetter = nil
 	}, `missing LifeGetter not valid`)
 
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.ModelConfigGetter = nil
+	}, `missing ModelConfigGetter not valid`)
+
+	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
+		config.NetworkPolicyEnsurer = nil
+	}, `missing NetworkPolicyEnsurer not valid`)
+
 	s.testValidateConfig(c, func(config *caasfirewaller.Config) {
 		config.Logger = nil
 	}, `missing Logger not valid`)
@@ -194,6 +228,119 @@ func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestConfigChangeExposesAgain(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// An unchanged config does not expose the service again.
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	rotated := application.ConfigAttributes{
+		"juju-external-hostname":        "exthost",
+		"kubernetes-ingress-tls-secret": "gitlab-tls",
+	}
+	s.applicationGetter.setConfig(rotated)
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		rotated)
+}
+
+func (s *WorkerSuite) TestInvalidConfigDoesNotFail(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	s.serviceExposer.SetErrors(errors.NotValidf("ingress config"))
+	s.applicationGetter.setConfig(application.ConfigAttributes{
+		"kubernetes-ingress-tls-issuer": "letsencrypt",
+		"kubernetes-ingress-tls-secret": "gitlab-tls",
+	})
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// The invalid config is not applied again until it changes.
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestConfigChangeUnexposed(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceUnexposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be unexposed")
+	}
+
+	s.applicationGetter.setConfig(application.ConfigAttributes{"juju-external-hostname": "otherhost"})
+	s.sendApplicationConfigChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -246,7 +393,7 @@ func (s *WorkerSuite) TestRemoveApplicationStopsWorker(c *gc.C) {
 	// with the worker loop. First time around the loop the
 	// application's alive, then it's gone.
 	//s.lifeGetter.life = life.Dead
-	s.applicationGetter.SetErrors(nil, nil, errors.NotFoundf("application"))
+	s.applicationGetter.SetErrors(nil, nil, nil, nil, errors.NotFoundf("application"))
 
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
@@ -284,3 +431,109 @@ func (s *WorkerSuite) TestWatcherErrorStopsWorker(c *gc.C) {
 	err = workertest.CheckKilled(c, w)
 	c.Assert(err, gc.ErrorMatches, "splat")
 }
+
+func (s *WorkerSuite) TestNetworkPolicy(c *gc.C) {
+	ensured := make(chan struct{})
+	s.policyEnsurer.ensured = ensured
+	s.modelConfigGetter.networkPolicies = true
+	s.applicationGetter.related = []string{"mysql"}
+
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	select {
+	case s.relationsChanges <- []string{"gitlab:db mysql:server"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	s.assertNetworkPolicyEnsured(c, ensured)
+
+	// A relation change which does not change the
+	// related applications does not update the policy.
+	select {
+	case s.relationsChanges <- []string{"gitlab:db mysql:server"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	select {
+	case <-ensured:
+		c.Fatal("network policy ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	s.applicationGetter.setRelated([]string{"mysql", "redis"})
+	select {
+	case s.relationsChanges <- []string{"gitlab:cache redis:cache"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending relations change")
+	}
+	s.assertNetworkPolicyEnsured(c, ensured)
+
+	s.modelConfigGetter.networkPolicies = false
+	select {
+	case s.modelConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending model config change")
+	}
+	s.assertNetworkPolicyEnsured(c, ensured)
+
+	s.policyEnsurer.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy", "EnsureNetworkPolicy")
+	s.policyEnsurer.CheckCall(c, 0, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicy{
+		AllowedApplications: []string{"mysql"},
+	})
+	s.policyEnsurer.CheckCall(c, 1, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicy{
+		AllowedApplications: []string{"mysql", "redis"},
+	})
+	s.policyEnsurer.CheckCall(c, 2, "EnsureNetworkPolicy", "gitlab", (*caas.NetworkPolicy)(nil))
+}
+
+func (s *WorkerSuite) TestNetworkPolicyExposed(c *gc.C) {
+	ensured := make(chan struct{})
+	s.policyEnsurer.ensured = ensured
+	s.modelConfigGetter.networkPolicies = true
+
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.assertNetworkPolicyEnsured(c, ensured)
+
+	// Application changes always update the policy,
+	// as the application's ports may have changed.
+	s.sendApplicationExposedChange(c)
+	s.assertNetworkPolicyEnsured(c, ensured)
+
+	s.policyEnsurer.CheckCallNames(c, "EnsureNetworkPolicy", "EnsureNetworkPolicy")
+	for i := 0; i < 2; i++ {
+		s.policyEnsurer.CheckCall(c, i, "EnsureNetworkPolicy", "gitlab", &caas.NetworkPolicy{
+			Exposed: true,
+		})
+	}
+}
+
+func (s *WorkerSuite) assertNetworkPolicyEnsured(c *gc.C, ensured <-chan struct{}) {
+	select {
+	case <-ensured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for network policy to be ensured")
+	}
+}
@@ -12,6 +12,7 @@ var (
 	JujudSymlinks        = jujudSymlinks
 	InitializeUnit       = initializeUnit
 	RunnerWithRetry      = runnerWithRetry
+	NewZonedExecClient   = newZonedExecClient
 )
 
 type (
@@ -21,6 +21,7 @@ import (
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/caasagent"
 	apileadership "github.com/juju/juju/api/leadership"
 	apiuniter "github.com/juju/juju/api/uniter"
 	"github.com/juju/juju/apiserver/params"
@@ -29,6 +30,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider/exec"
 	coreleadership "github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/machinelock"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/leadership"
@@ -65,7 +67,11 @@ type ManifoldConfig struct {
 	NewClient          func(base.APICaller) Client
 	NewCharmDownloader func(base.APICaller) Downloader
 
-	NewExecClient     func(namespace string) (exec.Executor, error)
+	NewExecClient func(namespace string) (exec.Executor, error)
+	// NewZoneExecClient returns an exec client for the units placed in
+	// another cluster zone of the model, using the cloud spec of the
+	// zone. If it is nil, such units cannot be reached.
+	NewZoneExecClient func(namespace string, cloudSpec environs.CloudSpec) (exec.Executor, error)
 	RunListenerSocket func(*uniter.SocketConfig) (*sockets.Socket, error)
 
 	LoadOperatorInfo func(paths Paths) (*caas.OperatorInfo, error)
@@ -211,7 +217,25 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				LeadershipTrackerFunc: leadershipTrackerFunc,
 				UniterFacadeFunc:      newUniterFunc,
 				ExecClientGetter: func() (exec.Executor, error) {
-					return config.NewExecClient(os.Getenv(provider.OperatorNamespaceEnvName))
+					namespace := os.Getenv(provider.OperatorNamespaceEnvName)
+					execClient, err := config.NewExecClient(namespace)
+					if err != nil {
+						return nil, errors.Trace(err)
+					}
+					return newZonedExecClient(execClient, func(zone string) (exec.Executor, error) {
+						if config.NewZoneExecClient == nil {
+							return nil, errors.NotSupportedf("running commands in other cluster zones")
+						}
+						agentClient, err := caasagent.NewClient(apiCaller)
+						if err != nil {
+							return nil, errors.Trace(err)
+						}
+						cloudSpec, err := agentClient.ZoneCloudSpec(zone)
+						if err != nil {
+							return nil, errors.Trace(err)
+						}
+						return config.NewZoneExecClient(namespace, cloudSpec)
+					}), nil
 				},
 			}
 
@@ -68,5 +68,5 @@ func (m *MockExecutor) Status(arg0 exec.StatusParams) (*exec.Status, error) {
 
 // Status indicates an expected call of Status
 func (mr *MockExecutorMockRecorder) Status(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockExecutor)(nil).Exec), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockExecutor)(nil).Status), arg0)
 }
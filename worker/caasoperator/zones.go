@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperator
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider/exec"
+)
+
+// zonedExecClient is an exec client for the pods of units placed in
+// any cluster zone of the model. Pods in other zones than the
+// operator's own cluster have provider ids qualified by their zone,
+// and are reached with a client for the zone's cluster.
+type zonedExecClient struct {
+	exec.Executor
+
+	newZoneClient func(zone string) (exec.Executor, error)
+
+	mu          sync.Mutex
+	zoneClients map[string]exec.Executor
+}
+
+func newZonedExecClient(client exec.Executor, newZoneClient func(zone string) (exec.Executor, error)) exec.Executor {
+	return &zonedExecClient{
+		Executor:      client,
+		newZoneClient: newZoneClient,
+		zoneClients:   make(map[string]exec.Executor),
+	}
+}
+
+// client returns the exec client for the cluster running the pod
+// with the specified provider id, and the pod's name in the cluster.
+func (c *zonedExecClient) client(providerID string) (exec.Executor, string, error) {
+	zone, podName := caas.ParseZoneProviderId(providerID)
+	if zone == "" {
+		return c.Executor, podName, nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.zoneClients[zone]; ok {
+		return client, podName, nil
+	}
+	client, err := c.newZoneClient(zone)
+	if err != nil {
+		return nil, "", errors.Annotatef(err, "cannot get exec client for cluster zone %q", zone)
+	}
+	c.zoneClients[zone] = client
+	return client, podName, nil
+}
+
+// Status is part of the exec.Executor interface.
+func (c *zonedExecClient) Status(params exec.StatusParams) (*exec.Status, error) {
+	zone, _ := caas.ParseZoneProviderId(params.PodName)
+	client, podName, err := c.client(params.PodName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	params.PodName = podName
+	status, err := client.Status(params)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if zone != "" {
+		// Qualify the pod by its zone, so that callers
+		// using the name keep addressing the right cluster.
+		status.PodName = zone + "/" + status.PodName
+	}
+	return status, nil
+}
+
+// Exec is part of the exec.Executor interface.
+func (c *zonedExecClient) Exec(params exec.ExecParams, cancel <-chan struct{}) error {
+	client, podName, err := c.client(params.PodName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	params.PodName = podName
+	return client.Exec(params, cancel)
+}
+
+// Copy is part of the exec.Executor interface. The pod
+// copied to or from determines the cluster used.
+func (c *zonedExecClient) Copy(params exec.CopyParams, cancel <-chan struct{}) error {
+	pod := &params.Dest
+	if pod.PodName == "" {
+		pod = &params.Src
+	}
+	client, podName, err := c.client(pod.PodName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pod.PodName = podName
+	return client.Copy(params, cancel)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperator_test
+
+import (
+	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider/exec"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasoperator"
+	"github.com/juju/juju/worker/caasoperator/mocks"
+)
+
+type zonedExecClientSuite struct {
+	testing.BaseSuite
+
+	primary *mocks.MockExecutor
+	west    *mocks.MockExecutor
+	zones   []string
+	client  exec.Executor
+}
+
+var _ = gc.Suite(&zonedExecClientSuite{})
+
+func (s *zonedExecClientSuite) setup(c *gc.C) *gomock.Controller {
+	ctrl := gomock.NewController(c)
+	s.primary = mocks.NewMockExecutor(ctrl)
+	s.west = mocks.NewMockExecutor(ctrl)
+	s.zones = nil
+	s.client = caasoperator.NewZonedExecClient(s.primary, func(zone string) (exec.Executor, error) {
+		s.zones = append(s.zones, zone)
+		if zone != "west" {
+			return nil, errors.NotFoundf("cluster zone %q", zone)
+		}
+		return s.west, nil
+	})
+	return ctrl
+}
+
+func (s *zonedExecClientSuite) TestExecPrimaryZone(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.primary.EXPECT().Exec(exec.ExecParams{PodName: "app-0", Commands: []string{"ls"}}, nil).Return(nil)
+
+	err := s.client.Exec(exec.ExecParams{PodName: "app-0", Commands: []string{"ls"}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.zones, gc.HasLen, 0)
+}
+
+func (s *zonedExecClientSuite) TestExecOtherZone(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.west.EXPECT().Exec(exec.ExecParams{PodName: "app-0", Commands: []string{"ls"}}, nil).Return(nil),
+		s.west.EXPECT().Exec(exec.ExecParams{PodName: "app-1", Commands: []string{"ls"}}, nil).Return(nil),
+	)
+
+	err := s.client.Exec(exec.ExecParams{PodName: "west/app-0", Commands: []string{"ls"}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.client.Exec(exec.ExecParams{PodName: "west/app-1", Commands: []string{"ls"}}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.zones, jc.DeepEquals, []string{"west"})
+}
+
+func (s *zonedExecClientSuite) TestExecUnknownZone(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	err := s.client.Exec(exec.ExecParams{PodName: "north/app-0"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot get exec client for cluster zone "north": cluster zone "north" not found`)
+}
+
+func (s *zonedExecClientSuite) TestStatusOtherZone(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.west.EXPECT().Status(exec.StatusParams{PodName: "app-0"}).Return(&exec.Status{PodName: "app-0"}, nil)
+
+	status, err := s.client.Status(exec.StatusParams{PodName: "west/app-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, &exec.Status{PodName: "west/app-0"})
+}
+
+func (s *zonedExecClientSuite) TestCopyOtherZone(c *gc.C) {
+	ctrl := s.setup(c)
+	defer ctrl.Finish()
+
+	s.west.EXPECT().Copy(exec.CopyParams{
+		Src:  exec.FileResource{Path: "/tmp/src"},
+		Dest: exec.FileResource{Path: "/tmp/dest", PodName: "app-0"},
+	}, nil).Return(nil)
+
+	err := s.client.Copy(exec.CopyParams{
+		Src:  exec.FileResource{Path: "/tmp/src"},
+		Dest: exec.FileResource{Path: "/tmp/dest", PodName: "west/app-0"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasoperatorprovisioner
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+// appConfigWorker sends the name of an application
+// to the provisioner whenever its config changes.
+type appConfigWorker struct {
+	catacomb catacomb.Catacomb
+	appName  string
+	watcher  watcher.NotifyWatcher
+	changes  chan<- string
+}
+
+func newAppConfigWorker(appName string, configWatcher watcher.NotifyWatcher, changes chan<- string) (worker.Worker, error) {
+	w := &appConfigWorker{
+		appName: appName,
+		watcher: configWatcher,
+		changes: changes,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+		Init: []worker.Worker{configWatcher},
+	})
+	return w, err
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *appConfigWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *appConfigWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *appConfigWorker) loop() error {
+	// The initial event reports the config the
+	// operator was provisioned with, so skip it.
+	initial := true
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-w.watcher.Changes():
+			if !ok {
+				return errors.New("application config watcher closed channel")
+			}
+			if initial {
+				initial = false
+				continue
+			}
+			select {
+			case w.changes <- w.appName:
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			}
+		}
+	}
+}
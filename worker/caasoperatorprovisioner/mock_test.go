@@ -29,8 +29,11 @@ type mockProvisionerFacade struct {
 	caasoperatorprovisioner.CAASProvisionerFacade
 	applicationsWatcher *mockStringsWatcher
 	apiWatcher          *mockNotifyWatcher
+	modelConfigWatcher  *mockNotifyWatcher
+	appConfigWatcher    *mockNotifyWatcher
 	life                life.Value
 	withStorage         bool
+	podConfig           *caas.OperatorPodConfig
 }
 
 func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
@@ -38,10 +41,39 @@ func newMockProvisionerFacade(stub *testing.Stub) *mockProvisionerFacade {
 		stub:                stub,
 		applicationsWatcher: newMockStringsWatcher(),
 		apiWatcher:          newMockNotifyWatcher(),
+		modelConfigWatcher:  newMockNotifyWatcher(),
+		appConfigWatcher:    newMockNotifyWatcher(),
 		withStorage:         true,
+		podConfig:           &caas.OperatorPodConfig{MemoryLimit: "256Mi"},
 	}
 }
 
+func (m *mockProvisionerFacade) setPodConfig(podConfig *caas.OperatorPodConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.podConfig = podConfig
+}
+
+func (m *mockProvisionerFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stub.MethodCall(m, "WatchForModelConfigChanges")
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.modelConfigWatcher, nil
+}
+
+func (m *mockProvisionerFacade) WatchApplicationConfig(appName string) (watcher.NotifyWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stub.MethodCall(m, "WatchApplicationConfig", appName)
+	if err := m.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.appConfigWatcher, nil
+}
+
 func (m *mockProvisionerFacade) WatchApplications() (watcher.StringsWatcher, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -64,6 +96,7 @@ func (m *mockProvisionerFacade) OperatorProvisioningInfo(appName string) (apicaa
 		Version:      version.MustParse("2.99.0"),
 		APIAddresses: []string{"10.0.0.1:17070", "192.18.1.1:17070"},
 		Tags:         map[string]string{"fred": "mary"},
+		PodConfig:    m.podConfig,
 	}
 	if m.withStorage {
 		result.CharmStorage = &storage.KubernetesFilesystemParams{
@@ -4,6 +4,7 @@
 package caasoperatorprovisioner
 
 import (
+	"reflect"
 	"strings"
 	"time"
 
@@ -34,6 +35,8 @@ var logger interface{}
 type CAASProvisionerFacade interface {
 	OperatorProvisioningInfo(string) (apicaasprovisioner.OperatorProvisioningInfo, error)
 	WatchApplications() (watcher.StringsWatcher, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 	SetPasswords([]apicaasprovisioner.ApplicationPassword) (params.ErrorResults, error)
 	Life(string) (life.Value, error)
 	IssueOperatorCertificate(string) (apicaasprovisioner.OperatorCertificate, error)
@@ -58,6 +61,9 @@ func NewProvisionerWorker(config Config) (worker.Worker, error) {
 		agentConfig:       config.AgentConfig,
 		clock:             config.Clock,
 		logger:            config.Logger,
+		podConfigs:        make(map[string]*caas.OperatorPodConfig),
+		appConfigWatchers: make(map[string]worker.Worker),
+		appConfigChanges:  make(chan string),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &p.catacomb,
@@ -75,6 +81,15 @@ type provisioner struct {
 
 	modelTag    names.ModelTag
 	agentConfig agent.Config
+
+	// podConfigs holds the resources and placement of the
+	// operator pods provisioned, keyed by application name.
+	podConfigs map[string]*caas.OperatorPodConfig
+
+	// appConfigWatchers holds the workers notifying appConfigChanges
+	// of changes to the config of the applications provisioned.
+	appConfigWatchers map[string]worker.Worker
+	appConfigChanges  chan string
 }
 
 // Kill is part of the worker.Worker interface.
@@ -101,11 +116,44 @@ func (p *provisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Changes to the operator pod config in the model config or in the
+	// application config are applied to the running operators.
+	modelConfigWatcher, err := p.provisionerFacade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	modelConfigChanges := modelConfigWatcher.Changes()
+	initialModelConfig := true
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
 			return p.catacomb.ErrDying()
 
+		case _, ok := <-modelConfigChanges:
+			if !ok {
+				return errors.New("model config watcher closed channel")
+			}
+			if initialModelConfig {
+				initialModelConfig = false
+				continue
+			}
+			apps := make([]string, 0, len(p.podConfigs))
+			for app := range p.podConfigs {
+				apps = append(apps, app)
+			}
+			if err := p.updateOperatorPodConfig(apps); err != nil {
+				return errors.Trace(err)
+			}
+
+		case app := <-p.appConfigChanges:
+			if err := p.updateOperatorPodConfig([]string{app}); err != nil {
+				return errors.Trace(err)
+			}
+
 		// CAAS applications changed so either create or remove pods as appropriate.
 		case apps, ok := <-appWatcher.Changes():
 			if !ok {
@@ -118,6 +166,9 @@ func (p *provisioner) loop() error {
 					return errors.Trace(err)
 				}
 				if err != nil || appLife == life.Dead {
+					if err := p.stopWatchingApplicationConfig(app); err != nil {
+						return errors.Trace(err)
+					}
 					p.logger.Debugf("deleting operator for %q", app)
 					if err := p.broker.DeleteOperator(app); err != nil {
 						return errors.Annotatef(err, "failed to stop operator for %q", app)
@@ -139,6 +190,65 @@ func (p *provisioner) loop() error {
 	}
 }
 
+// updateOperatorPodConfig updates the operators of the specified
+// applications whose pod resources or placement have changed.
+func (p *provisioner) updateOperatorPodConfig(apps []string) error {
+	var changed []string
+	for _, app := range apps {
+		if _, ok := p.podConfigs[app]; !ok {
+			continue
+		}
+		info, err := p.provisionerFacade.OperatorProvisioningInfo(app)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "fetching operator provisioning info for %q", app)
+		}
+		if reflect.DeepEqual(info.PodConfig, p.podConfigs[app]) {
+			continue
+		}
+		p.logger.Debugf("updating operator pod config for %q", app)
+		changed = append(changed, app)
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	return errors.Trace(p.ensureOperators(changed))
+}
+
+// watchApplicationConfig starts watching the config of the
+// specified application, if it isn't already watched.
+func (p *provisioner) watchApplicationConfig(app string) error {
+	if _, ok := p.appConfigWatchers[app]; ok {
+		return nil
+	}
+	configWatcher, err := p.provisionerFacade.WatchApplicationConfig(app)
+	if err != nil {
+		return errors.Annotatef(err, "watching config of %q", app)
+	}
+	w, err := newAppConfigWorker(app, configWatcher, p.appConfigChanges)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.catacomb.Add(w); err != nil {
+		return errors.Trace(err)
+	}
+	p.appConfigWatchers[app] = w
+	return nil
+}
+
+// stopWatchingApplicationConfig stops watching the
+// config of the specified application.
+func (p *provisioner) stopWatchingApplicationConfig(app string) error {
+	delete(p.podConfigs, app)
+	w, ok := p.appConfigWatchers[app]
+	if !ok {
+		return nil
+	}
+	delete(p.appConfigWatchers, app)
+	return errors.Trace(worker.Stop(w))
+}
+
 func (p *provisioner) waitForOperatorTerminated(app string) error {
 	tryAgain := errors.New("try again")
 	existsFunc := func() error {
@@ -210,6 +320,7 @@ func (p *provisioner) ensureOperators(apps []string) error {
 			return errors.Annotatef(err, "failed to generate operator config for %q", app)
 		}
 		operatorConfig[i] = config
+		p.podConfigs[app] = config.PodConfig
 	}
 	// If we did create any passwords for new operators, first they need
 	// to be saved so the agent can login when it starts up.
@@ -223,6 +334,12 @@ func (p *provisioner) ensureOperators(apps []string) error {
 		}
 	}
 
+	for _, app := range apps {
+		if err := p.watchApplicationConfig(app); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Now that any new config/passwords are done, create or update
 	// the operators themselves.
 	var errorStrings []string
@@ -270,6 +387,7 @@ func (p *provisioner) updateOperatorConfig(appName, password string, prevCfg caa
 		ResourceTags:        info.Tags,
 		CharmStorage:        charmStorageParams(info.CharmStorage),
 		ConfigMapGeneration: prevCfg.ConfigMapGeneration,
+		PodConfig:           info.PodConfig,
 	}
 
 	cfg.AgentConf, err = p.updateAgentConf(appName, password, info, prevCfg.AgentConf)
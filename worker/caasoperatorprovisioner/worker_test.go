@@ -81,6 +81,7 @@ func (s *CAASProvisionerSuite) assertWorker(c *gc.C) worker.Worker {
 	c.Assert(err, jc.ErrorIsNil)
 	expected := []jujutesting.StubCall{
 		{"WatchApplications", nil},
+		{"WatchForModelConfigChanges", nil},
 	}
 	s.waitForWorkerStubCalls(c, expected)
 	s.stub.ResetCalls()
@@ -126,6 +127,7 @@ func (s *CAASProvisionerSuite) assertOperatorCreated(c *gc.C, exists, updateCert
 	c.Assert(config.OperatorImagePath, gc.Equals, "juju-operator-image")
 	c.Assert(config.Version, gc.Equals, version.MustParse("2.99.0"))
 	c.Assert(config.ResourceTags, jc.DeepEquals, map[string]string{"fred": "mary"})
+	c.Assert(config.PodConfig, jc.DeepEquals, &caas.OperatorPodConfig{MemoryLimit: "256Mi"})
 	if s.provisionerFacade.withStorage {
 		c.Assert(config.CharmStorage, jc.DeepEquals, &caas.CharmStorageParams{
 			Provider:     "kubernetes",
@@ -169,13 +171,15 @@ func (s *CAASProvisionerSuite) assertOperatorCreated(c *gc.C, exists, updateCert
 		if updateCerts {
 			callNames = append(callNames, "IssueOperatorCertificate")
 		}
+		callNames = append(callNames, "WatchApplicationConfig")
 		s.provisionerFacade.stub.CheckCallNames(c, callNames...)
 		c.Assert(s.provisionerFacade.stub.Calls()[0].Args[0], gc.Equals, "myapp")
 		c.Assert(s.provisionerFacade.stub.Calls()[1].Args[0], gc.Equals, "myapp")
 		return
 	}
 
-	s.provisionerFacade.stub.CheckCallNames(c, "Life", "OperatorProvisioningInfo", "IssueOperatorCertificate", "SetPasswords")
+	s.provisionerFacade.stub.CheckCallNames(c,
+		"Life", "OperatorProvisioningInfo", "IssueOperatorCertificate", "SetPasswords", "WatchApplicationConfig")
 	c.Assert(s.provisionerFacade.stub.Calls()[0].Args[0], gc.Equals, "myapp")
 	passwords := s.provisionerFacade.stub.Calls()[3].Args[0].([]apicaasprovisioner.ApplicationPassword)
 
@@ -317,3 +321,65 @@ func (s *CAASProvisionerSuite) TestApplicationDeletedRemovesOperator(c *gc.C) {
 	s.caasClient.CheckCallNames(c, "DeleteOperator")
 	c.Assert(s.caasClient.Calls()[0].Args[0], gc.Equals, "myapp")
 }
+
+func (s *CAASProvisionerSuite) assertOperatorPodConfigUpdated(c *gc.C, notify func()) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c, false, false)
+	s.caasClient.mu.Lock()
+	s.caasClient.config = s.caasClient.Calls()[2].Args[2].(*caas.OperatorConfig)
+	s.caasClient.mu.Unlock()
+	s.caasClient.ResetCalls()
+	s.provisionerFacade.stub.ResetCalls()
+	s.caasClient.setOperatorExists(true)
+
+	// An unchanged pod config leaves the operator alone.
+	notify()
+	waitForStubCalls(c, s.provisionerFacade.stub, []jujutesting.StubCall{
+		{"OperatorProvisioningInfo", []interface{}{"myapp"}},
+	})
+	s.caasClient.CheckNoCalls(c)
+
+	s.provisionerFacade.setPodConfig(&caas.OperatorPodConfig{MemoryLimit: "1Gi"})
+	notify()
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.caasClient.Calls()) >= 3 {
+			break
+		}
+	}
+	s.caasClient.CheckCallNames(c, "OperatorExists", "Operator", "EnsureOperator")
+	config := s.caasClient.Calls()[2].Args[2].(*caas.OperatorConfig)
+	c.Assert(config.PodConfig, jc.DeepEquals, &caas.OperatorPodConfig{MemoryLimit: "1Gi"})
+}
+
+func (s *CAASProvisionerSuite) TestModelConfigChangeUpdatesOperator(c *gc.C) {
+	s.provisionerFacade.modelConfigWatcher.changes <- struct{}{}
+	s.assertOperatorPodConfigUpdated(c, func() {
+		s.provisionerFacade.modelConfigWatcher.changes <- struct{}{}
+	})
+}
+
+func (s *CAASProvisionerSuite) TestApplicationConfigChangeUpdatesOperator(c *gc.C) {
+	s.provisionerFacade.appConfigWatcher.changes <- struct{}{}
+	s.assertOperatorPodConfigUpdated(c, func() {
+		s.provisionerFacade.appConfigWatcher.changes <- struct{}{}
+	})
+}
+
+func (s *CAASProvisionerSuite) TestApplicationDeletedStopsWatchingConfig(c *gc.C) {
+	w := s.assertWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.assertOperatorCreated(c, false, false)
+	s.provisionerFacade.stub.SetErrors(errors.NotFoundf("myapp"))
+	s.provisionerFacade.life = "dead"
+	s.provisionerFacade.applicationsWatcher.changes <- []string{"myapp"}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if s.provisionerFacade.appConfigWatcher.killed() {
+			return
+		}
+	}
+	c.Fatal("application config watcher not stopped")
+}
@@ -98,6 +98,9 @@ func (aw *applicationWorker) loop() error {
 		brokerUnitsWatcher watcher.NotifyWatcher
 		brokerUnitsChannel watcher.NotifyChannel
 
+		brokerEventsWatcher watcher.NotifyWatcher
+		brokerEventsChannel watcher.NotifyChannel
+
 		appOperatorWatcher watcher.NotifyWatcher
 		appOperatorChannel watcher.NotifyChannel
 
@@ -111,6 +114,9 @@ func (aw *applicationWorker) loop() error {
 		if brokerUnitsWatcher != nil {
 			worker.Stop(brokerUnitsWatcher)
 		}
+		if brokerEventsWatcher != nil {
+			worker.Stop(brokerEventsWatcher)
+		}
 		if appOperatorWatcher != nil {
 			worker.Stop(appOperatorWatcher)
 		}
@@ -139,6 +145,17 @@ func (aw *applicationWorker) loop() error {
 			}
 			brokerUnitsChannel = brokerUnitsWatcher.Changes()
 		}
+		if brokerEventsWatcher == nil {
+			brokerEventsWatcher, err = aw.containerBroker.WatchEvents(aw.application, aw.mode)
+			if err != nil {
+				if strings.Contains(err.Error(), "unexpected EOF") {
+					logger.Warningf("k8s cloud hosting %q has disappeared", aw.application)
+					return nil
+				}
+				return errors.Annotatef(err, "failed to start event watcher for %q", aw.application)
+			}
+			brokerEventsChannel = brokerEventsWatcher.Changes()
+		}
 		if appOperatorWatcher == nil && aw.mode == caas.ModeWorkload {
 			appOperatorWatcher, err = aw.containerBroker.WatchOperator(aw.application)
 			if err != nil {
@@ -184,6 +201,24 @@ func (aw *applicationWorker) loop() error {
 				// TODO(caas): change the shouldSetScale to false here once appDeploymentWatcher can get all events from k8s.
 				return errors.Trace(err)
 			}
+		case _, ok := <-brokerEventsChannel:
+			logger.Debugf("warning events: %#v", ok)
+			if !ok {
+				logger.Debugf("%v", brokerEventsWatcher.Wait())
+				worker.Stop(brokerEventsWatcher)
+				brokerEventsWatcher = nil
+				continue
+			}
+			// Warning events are reflected in the unit and application
+			// status messages, so refresh them. The scale is left to
+			// the deployment watcher.
+			service, err := aw.serviceBroker.GetService(aw.application, aw.mode, false)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Trace(err)
+			}
+			if err := aw.clusterChanged(service, lastReportedStatus, false); err != nil {
+				return errors.Trace(err)
+			}
 		case _, ok := <-appDeploymentChannel:
 			logger.Debugf("deployment changed: %#v", ok)
 			if !ok {
@@ -16,6 +16,7 @@ type ContainerBroker interface {
 	Operator(string) (*caas.Operator, error)
 
 	WatchUnits(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error)
+	WatchEvents(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error)
 	Units(appName string, mode caas.DeploymentMode) ([]caas.Unit, error)
 	AnnotateUnit(appName string, mode caas.DeploymentMode, podName string, unit names.UnitTag) error
 }
@@ -25,7 +26,21 @@ type ServiceBroker interface {
 	EnsureService(appName string, statusCallback caas.StatusCallbackFunc, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes) error
 	DeleteService(appName string) error
 	UnexposeService(appName string) error
+	EnsureAutoscaler(appName string, policy *caas.AutoscalingPolicy) error
 
 	GetService(appName string, mode caas.DeploymentMode, includeClusterIP bool) (*caas.Service, error)
 	WatchService(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error)
 }
+
+// ZonedBroker is implemented by the brokers of models spanning
+// several clusters, each of which is a placement zone.
+type ZonedBroker interface {
+	Zones() []string
+	ZoneBroker(zone string) (caas.Broker, error)
+}
+
+// ImportedServiceScaler is implemented by brokers which can scale
+// the workloads of applications imported from the cluster.
+type ImportedServiceScaler interface {
+	ScaleImportedService(appName string, scale int) error
+}
@@ -35,6 +35,7 @@ type ApplicationGetter interface {
 	DeploymentMode(string) (caas.DeploymentMode, error)
 	WatchApplicationScale(string) (watcher.NotifyWatcher, error)
 	ApplicationScale(string) (int, error)
+	WatchApplicationConfig(string) (watcher.NotifyWatcher, error)
 }
 
 // ApplicationUpdater provides an interface for updating
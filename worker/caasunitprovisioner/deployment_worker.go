@@ -4,8 +4,10 @@
 package caasunitprovisioner
 
 import (
+	"fmt"
 	"reflect"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -31,6 +34,12 @@ type deploymentWorker struct {
 	applicationUpdater       ApplicationUpdater
 	provisioningInfoGetter   ProvisioningInfoGetter
 	logger                   Logger
+
+	// autoscalingPolicy is the autoscaling policy last applied to
+	// the cluster, and autoscalerEnsured records whether a policy
+	// has been applied at all.
+	autoscalingPolicy *caas.AutoscalingPolicy
+	autoscalerEnsured bool
 }
 
 func newDeploymentWorker(
@@ -77,6 +86,12 @@ func (w *deploymentWorker) loop() error {
 	}
 	w.catacomb.Add(appScaleWatcher)
 
+	appConfigWatcher, err := w.applicationGetter.WatchApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.catacomb.Add(appConfigWatcher)
+
 	var (
 		pw            watcher.NotifyWatcher
 		provisionChan watcher.NotifyChannel
@@ -117,6 +132,22 @@ func (w *deploymentWorker) loop() error {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-appConfigWatcher.Changes():
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			if currentScale == 0 || currentInfo == nil {
+				// The autoscaler is applied once the service is ensured.
+				continue
+			}
+			appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if err := w.ensureAutoscaler(appConfig); err != nil {
+				return errors.Trace(err)
+			}
+			continue
 		}
 		if desiredScale > 0 && !gotSpecNotify {
 			continue
@@ -124,7 +155,27 @@ func (w *deploymentWorker) loop() error {
 		info, err := w.provisioningInfoGetter.ProvisioningInfo(w.application)
 		if errors.IsNotFound(err) {
 			// No pod spec defined for a unit yet;
-			// wait for one to be set.
+			// wait for one to be set. Workloads imported
+			// from the cluster never have one, and are
+			// scaled in place.
+			if desiredScale == currentScale {
+				continue
+			}
+			imported, err := w.scaleImportedService(desiredScale)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if !imported {
+				continue
+			}
+			logger.Debugf("scaled imported workload for %s to %v units", w.application, desiredScale)
+			currentScale = desiredScale
+			if !serviceUpdated {
+				if err := w.updateService(); err != nil {
+					return errors.Trace(err)
+				}
+				serviceUpdated = true
+			}
 			continue
 		} else if err != nil {
 			return errors.Trace(err)
@@ -136,7 +187,7 @@ func (w *deploymentWorker) loop() error {
 				provisionChan = nil
 			}
 			logger.Debugf("no units for %v", w.application)
-			err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, &caas.ServiceParams{}, 0, nil)
+			err = w.ensureService(&caas.ServiceParams{}, 0, nil)
 			if err != nil {
 				return errors.Trace(err)
 			}
@@ -186,7 +237,7 @@ func (w *deploymentWorker) loop() error {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, desiredScale, appConfig)
+		err = w.ensureService(serviceParams, desiredScale, appConfig)
 		if err != nil {
 			// Some errors we don't want to exit the worker.
 			if k8sprovider.MaskError(err) {
@@ -196,17 +247,14 @@ func (w *deploymentWorker) loop() error {
 			return errors.Trace(err)
 		}
 		logger.Debugf("ensured deployment for %s for %v units", w.application, desiredScale)
+		if err := w.ensureAutoscaler(appConfig); err != nil {
+			return errors.Trace(err)
+		}
 		if serviceParams.PodSpec == nil {
 			continue
 		}
 		if !serviceUpdated && !serviceParams.PodSpec.OmitServiceFrontend {
-			service, err := w.broker.GetService(w.application, caas.ModeWorkload, false)
-			if err != nil && !errors.IsNotFound(err) {
-				return errors.Annotate(err, "cannot get new service details")
-			}
-			if err = updateApplicationService(
-				names.NewApplicationTag(w.application), service, w.applicationUpdater,
-			); err != nil {
+			if err := w.updateService(); err != nil {
 				return errors.Trace(err)
 			}
 			serviceUpdated = true
@@ -214,6 +262,135 @@ func (w *deploymentWorker) loop() error {
 	}
 }
 
+// updateService records the details of the application's service,
+// such as its addresses, with the application.
+func (w *deploymentWorker) updateService() error {
+	service, err := w.broker.GetService(w.application, caas.ModeWorkload, false)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "cannot get new service details")
+	}
+	return errors.Trace(updateApplicationService(
+		names.NewApplicationTag(w.application), service, w.applicationUpdater,
+	))
+}
+
+// scaleImportedService scales the workload of an application imported
+// from the cluster, and reports whether the application has one.
+func (w *deploymentWorker) scaleImportedService(scale int) (bool, error) {
+	scaler, ok := w.broker.(ImportedServiceScaler)
+	if !ok {
+		return false, nil
+	}
+	err := scaler.ScaleImportedService(w.application, scale)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "scaling imported workload")
+	}
+	return true, nil
+}
+
+// ensureService ensures the application's service runs the specified
+// number of units. When the model spans several clusters, the units are
+// spread across the clusters named in the application's zones constraint,
+// or run in the model's own cluster if none are named, and the service
+// is scaled to zero in the other clusters.
+func (w *deploymentWorker) ensureService(
+	serviceParams *caas.ServiceParams, numUnits int, appConfig application.ConfigAttributes,
+) error {
+	zoned, ok := w.broker.(ZonedBroker)
+	if !ok {
+		return w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, numUnits, appConfig)
+	}
+	zones := zoned.Zones()
+	placement, serviceParams := zonePlacement(zones, serviceParams)
+	spread := caas.SpreadUnits(numUnits, placement)
+	for _, zone := range zones {
+		broker, err := zoned.ZoneBroker(zone)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		zoneParams, zoneConfig := serviceParams, appConfig
+		if spread[zone] == 0 {
+			zoneParams, zoneConfig = &caas.ServiceParams{}, nil
+		}
+		w.logger.Debugf("ensuring %d units of %s in cluster zone %q", spread[zone], w.application, zone)
+		err = broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, zoneParams, spread[zone], zoneConfig)
+		if err != nil {
+			return errors.Annotatef(err, "cluster zone %q", zone)
+		}
+	}
+	return nil
+}
+
+// zonePlacement returns the cluster zones in which to place the units
+// of an application, and the service params to use in each of them.
+// The cluster zones are removed from the zones constraint, leaving any
+// zones of the nodes within each cluster.
+func zonePlacement(clusterZones []string, serviceParams *caas.ServiceParams) ([]string, *caas.ServiceParams) {
+	if serviceParams.Constraints.Zones == nil {
+		return clusterZones[:1], serviceParams
+	}
+	known := set.NewStrings(clusterZones...)
+	placed := set.NewStrings()
+	var placement, nodeZones []string
+	for _, zone := range *serviceParams.Constraints.Zones {
+		switch {
+		case !known.Contains(zone):
+			nodeZones = append(nodeZones, zone)
+		case !placed.Contains(zone):
+			placed.Add(zone)
+			placement = append(placement, zone)
+		}
+	}
+	if len(placement) == 0 {
+		return clusterZones[:1], serviceParams
+	}
+	result := *serviceParams
+	result.Constraints.Zones = nil
+	if len(nodeZones) > 0 {
+		result.Constraints.Zones = &nodeZones
+	}
+	return placement, &result
+}
+
+// ensureAutoscaler applies the autoscaling policy in the application
+// config to the cluster, if it differs from the policy last applied.
+// The policy is always applied the first time, to remove any autoscaler
+// left behind by a policy that was unset while the worker was stopped.
+// The cluster then scales the application within the policy's bounds,
+// and the scale it chooses is reported back to the model along with
+// the units.
+func (w *deploymentWorker) ensureAutoscaler(appConfig application.ConfigAttributes) error {
+	policy, err := caas.AutoscalingPolicyFromConfig(appConfig)
+	if err != nil {
+		return w.provisioningStatusSetter.SetOperatorStatus(
+			w.application,
+			status.Error,
+			fmt.Sprintf("invalid autoscaling policy: %v", err),
+			nil,
+		)
+	}
+	if w.autoscalerEnsured && reflect.DeepEqual(policy, w.autoscalingPolicy) {
+		return nil
+	}
+	if err := w.broker.EnsureAutoscaler(w.application, policy); err != nil {
+		if errors.IsNotFound(err) || errors.IsNotValid(err) {
+			return w.provisioningStatusSetter.SetOperatorStatus(
+				w.application,
+				status.Error,
+				fmt.Sprintf("cannot autoscale application: %v", err),
+				nil,
+			)
+		}
+		return errors.Annotate(err, "ensuring autoscaler")
+	}
+	w.logger.Debugf("ensured autoscaling policy for %s: %+v", w.application, policy)
+	w.autoscalingPolicy = policy
+	w.autoscalerEnsured = true
+	return nil
+}
+
 func provisionInfoToServiceParams(info *apicaasunitprovisioner.ProvisioningInfo) (serviceParams *caas.ServiceParams, err error) {
 	if len(info.PodSpec) > 0 && len(info.RawK8sSpec) > 0 {
 		// This should never happen.
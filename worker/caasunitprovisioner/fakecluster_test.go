@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package caasunitprovisioner_test
+
+import (
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/clock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apicaasunitprovisioner "github.com/juju/juju/api/caasunitprovisioner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	providertesting "github.com/juju/juju/caas/kubernetes/provider/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/caasunitprovisioner"
+)
+
+// setupFakeCluster replaces the mock brokers with a broker
+// for an in-process fake cluster, which is returned.
+func (s *WorkerSuite) setupFakeCluster(c *gc.C) *providertesting.FakeCluster {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.NameKey:              "test",
+		provider.OperatorStorageKey: "",
+		provider.WorkloadStorageKey: "",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	cluster := providertesting.NewFakeCluster("test", &storagev1.StorageClass{
+		ObjectMeta: v1.ObjectMeta{Name: "workload-storage"},
+	})
+	c.Assert(cluster.AddNode("node-1", nil), jc.ErrorIsNil)
+	broker, err := cluster.NewBroker(coretesting.ControllerTag.Id(), cfg, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.ServiceBroker = broker
+	s.config.ContainerBroker = broker
+	return cluster
+}
+
+func (s *WorkerSuite) TestUnitsProvisionedInFakeCluster(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	cluster := s.setupFakeCluster(c)
+	s.statusSetter.EXPECT().SetOperatorStatus("gitlab", gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
+
+	s.podSpecGetter.setProvisioningInfo(apicaasunitprovisioner.ProvisioningInfo{
+		PodSpec: containerSpec,
+		DeploymentInfo: apicaasunitprovisioner.DeploymentInfo{
+			DeploymentType: "stateful",
+			ServiceType:    "cluster",
+		},
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attributes:  map[string]interface{}{"storage-class": "workload-storage"},
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "/path-to-here",
+			},
+		}},
+	})
+	s.unitUpdater.unitsInfo = &params.UpdateApplicationUnitsInfo{
+		Units: []params.ApplicationUnitInfo{
+			{ProviderId: "gitlab-0", UnitTag: "unit-gitlab-0"},
+		},
+	}
+	// The service is updated each time it changes in the cluster.
+	go func() {
+		for range s.serviceUpdated {
+		}
+	}()
+	defer close(s.serviceUpdated)
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	// Once the worker has created the stateful set, start its pod,
+	// with the storage bound, and wait for the unit to be reported.
+	for a := coretesting.LongAttempt.Start(); ; {
+		if _, err := cluster.Clientset.AppsV1().StatefulSets("test").Get("gitlab", v1.GetOptions{}); err == nil {
+			break
+		}
+		if !a.Next() {
+			c.Fatal("timed out waiting for stateful set")
+		}
+	}
+	c.Assert(cluster.SyncWorkloads(), jc.ErrorIsNil)
+	claims, err := cluster.Clientset.CoreV1().PersistentVolumeClaims("test").List(v1.ListOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(claims.Items, gc.HasLen, 1)
+	c.Assert(cluster.BindClaim(claims.Items[0].Name), jc.ErrorIsNil)
+	c.Assert(cluster.SetPodPhase("gitlab-0", core.PodRunning), jc.ErrorIsNil)
+
+	var unit params.ApplicationUnitParams
+	for a := coretesting.LongAttempt.Start(); ; {
+		var ok bool
+		if unit, ok = s.reportedUnit("running"); ok {
+			break
+		}
+		if !a.Next() {
+			c.Fatal("timed out waiting for running unit")
+		}
+	}
+	c.Assert(unit.ProviderId, gc.Equals, "gitlab-0")
+	c.Assert(unit.Address, gc.Not(gc.Equals), "")
+	var database []params.KubernetesFilesystemInfo
+	for _, fs := range unit.FilesystemInfo {
+		if fs.StorageName == "database" {
+			database = append(database, fs)
+		}
+	}
+	c.Assert(database, gc.HasLen, 1)
+	c.Assert(database[0].MountPoint, gc.Equals, "/path-to-here")
+	c.Assert(database[0].Status, gc.Equals, "attached")
+
+	// The pod is annotated with the unit it belongs to.
+	pod, err := cluster.Clientset.CoreV1().Pods("test").Get("gitlab-0", v1.GetOptions{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Annotations["juju.io/unit"], gc.Equals, "gitlab/0")
+}
+
+// reportedUnit returns the first unit reported to
+// the unit updater with the specified status.
+func (s *WorkerSuite) reportedUnit(unitStatus string) (params.ApplicationUnitParams, bool) {
+	for _, call := range s.unitUpdater.Calls() {
+		for _, unit := range call.Args[0].(params.UpdateApplicationUnits).Units {
+			if unit.Status == unitStatus {
+				return unit, true
+			}
+		}
+	}
+	return params.ApplicationUnitParams{}, false
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
@@ -47,6 +48,7 @@ type mockServiceBroker struct {
 	caas.ContainerEnvironProvider
 	ensured        chan<- struct{}
 	deleted        chan<- struct{}
+	autoscaled     chan<- struct{}
 	serviceStatus  status.StatusInfo
 	serviceWatcher *watchertest.MockNotifyWatcher
 }
@@ -62,6 +64,14 @@ func (m *mockServiceBroker) EnsureService(appName string, statusCallback caas.St
 	return m.NextErr()
 }
 
+func (m *mockServiceBroker) EnsureAutoscaler(appName string, policy *caas.AutoscalingPolicy) error {
+	m.MethodCall(m, "EnsureAutoscaler", appName, policy)
+	if m.autoscaled != nil {
+		m.autoscaled <- struct{}{}
+	}
+	return m.NextErr()
+}
+
 func (m *mockServiceBroker) GetService(appName string, mode caas.DeploymentMode, includeClusterIP bool) (*caas.Service, error) {
 	m.MethodCall(m, "GetService", appName, mode)
 	scale := 4
@@ -91,10 +101,57 @@ func (m *mockServiceBroker) UnexposeService(appName string) error {
 	return m.NextErr()
 }
 
+// mockZonedServiceBroker is the service broker
+// of a model spanning several cluster zones.
+type mockZonedServiceBroker struct {
+	*mockServiceBroker
+	zones       []string
+	zoneBrokers map[string]*mockZoneBroker
+}
+
+func (m *mockZonedServiceBroker) Zones() []string {
+	return m.zones
+}
+
+func (m *mockZonedServiceBroker) ZoneBroker(zone string) (caas.Broker, error) {
+	broker, ok := m.zoneBrokers[zone]
+	if !ok {
+		return nil, errors.NotFoundf("cluster zone %q", zone)
+	}
+	return broker, nil
+}
+
+// mockImportingServiceBroker is the service broker of a
+// model with applications imported from the cluster.
+type mockImportingServiceBroker struct {
+	*mockServiceBroker
+	scaled chan<- int
+}
+
+func (m *mockImportingServiceBroker) ScaleImportedService(appName string, scale int) error {
+	m.MethodCall(m, "ScaleImportedService", appName, scale)
+	m.scaled <- scale
+	return m.NextErr()
+}
+
+type mockZoneBroker struct {
+	caas.Broker
+	testing.Stub
+	ensured chan<- string
+	zone    string
+}
+
+func (m *mockZoneBroker) EnsureService(appName string, statusCallback caas.StatusCallbackFunc, params *caas.ServiceParams, numUnits int, config application.ConfigAttributes) error {
+	m.MethodCall(m, "EnsureService", appName, params, numUnits, config)
+	m.ensured <- m.zone
+	return m.NextErr()
+}
+
 type mockContainerBroker struct {
 	testing.Stub
 	caas.ContainerEnvironProvider
 	unitsWatcher           *watchertest.MockNotifyWatcher
+	eventsWatcher          *watchertest.MockNotifyWatcher
 	operatorWatcher        *watchertest.MockNotifyWatcher
 	reportedUnitStatus     status.Status
 	reportedOperatorStatus status.Status
@@ -110,6 +167,11 @@ func (m *mockContainerBroker) WatchUnits(appName string, mode caas.DeploymentMod
 	return m.unitsWatcher, m.NextErr()
 }
 
+func (m *mockContainerBroker) WatchEvents(appName string, mode caas.DeploymentMode) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchEvents", appName, mode)
+	return m.eventsWatcher, m.NextErr()
+}
+
 func (m *mockContainerBroker) Units(appName string, mode caas.DeploymentMode) ([]caas.Unit, error) {
 	m.MethodCall(m, "Units", appName, mode)
 	for i, u := range m.units {
@@ -149,8 +211,18 @@ type mockApplicationGetter struct {
 	testing.Stub
 	watcher        *watchertest.MockStringsWatcher
 	scaleWatcher   *watchertest.MockNotifyWatcher
+	configWatcher  *watchertest.MockNotifyWatcher
 	deploymentMode caas.DeploymentMode
 	scale          int
+
+	mu     sync.Mutex
+	config application.ConfigAttributes
+}
+
+func (a *mockApplicationGetter) setConfig(config application.ConfigAttributes) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = config
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -162,10 +234,14 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 }
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.MethodCall(a, "ApplicationConfig", appName)
-	return application.ConfigAttributes{
-		"juju-external-hostname": "exthost",
-	}, a.NextErr()
+	config := make(application.ConfigAttributes)
+	for k, v := range a.config {
+		config[k] = v
+	}
+	return config, a.NextErr()
 }
 
 func (a *mockApplicationGetter) DeploymentMode(appName string) (caas.DeploymentMode, error) {
@@ -181,6 +257,14 @@ func (a *mockApplicationGetter) WatchApplicationScale(application string) (watch
 	return a.scaleWatcher, nil
 }
 
+func (a *mockApplicationGetter) WatchApplicationConfig(application string) (watcher.NotifyWatcher, error) {
+	a.MethodCall(a, "WatchApplicationConfig", application)
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.configWatcher, nil
+}
+
 func (a *mockApplicationGetter) ApplicationScale(application string) (int, error) {
 	a.MethodCall(a, "ApplicationScale", application)
 	if err := a.NextErr(); err != nil {
@@ -46,17 +46,19 @@ type WorkerSuite struct {
 	unitUpdater        mockUnitUpdater
 	statusSetter       *caasunitprovisioner.MockProvisioningStatusSetter
 
-	applicationChanges      chan []string
-	applicationScaleChanges chan struct{}
-	caasUnitsChanges        chan struct{}
-	caasServiceChanges      chan struct{}
-	caasOperatorChanges     chan struct{}
-	containerSpecChanges    chan struct{}
-	serviceDeleted          chan struct{}
-	serviceEnsured          chan struct{}
-	serviceUpdated          chan struct{}
-	resourcesCleared        chan struct{}
-	clock                   *testclock.Clock
+	applicationChanges       chan []string
+	applicationScaleChanges  chan struct{}
+	applicationConfigChanges chan struct{}
+	caasUnitsChanges         chan struct{}
+	caasEventsChanges        chan struct{}
+	caasServiceChanges       chan struct{}
+	caasOperatorChanges      chan struct{}
+	containerSpecChanges     chan struct{}
+	serviceDeleted           chan struct{}
+	serviceEnsured           chan struct{}
+	serviceUpdated           chan struct{}
+	resourcesCleared         chan struct{}
+	clock                    *testclock.Clock
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -118,7 +120,9 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.applicationChanges = make(chan []string)
 	s.applicationScaleChanges = make(chan struct{})
+	s.applicationConfigChanges = make(chan struct{})
 	s.caasUnitsChanges = make(chan struct{})
+	s.caasEventsChanges = make(chan struct{})
 	s.caasServiceChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
@@ -130,7 +134,11 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.applicationGetter = mockApplicationGetter{
 		watcher:        watchertest.NewMockStringsWatcher(s.applicationChanges),
 		scaleWatcher:   watchertest.NewMockNotifyWatcher(s.applicationScaleChanges),
+		configWatcher:  watchertest.NewMockNotifyWatcher(s.applicationConfigChanges),
 		deploymentMode: caas.ModeWorkload,
+		config: application.ConfigAttributes{
+			"juju-external-hostname": "exthost",
+		},
 	}
 	s.applicationUpdater = mockApplicationUpdater{
 		updated: s.serviceUpdated,
@@ -158,6 +166,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 
 	s.containerBroker = mockContainerBroker{
 		unitsWatcher:    watchertest.NewMockNotifyWatcher(s.caasUnitsChanges),
+		eventsWatcher:   watchertest.NewMockNotifyWatcher(s.caasEventsChanges),
 		operatorWatcher: watchertest.NewMockNotifyWatcher(s.caasOperatorChanges),
 		units: []caas.Unit{
 			{
@@ -301,17 +310,18 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
 
-	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "ApplicationScale", "ApplicationConfig")
+	s.applicationGetter.CheckCallNames(c, "WatchApplications", "DeploymentMode", "WatchApplicationScale", "WatchApplicationConfig", "ApplicationScale", "ApplicationConfig")
 	s.podSpecGetter.CheckCallNames(c, "WatchPodSpec", "ProvisioningInfo", "ProvisioningInfo")
 	s.podSpecGetter.CheckCall(c, 0, "WatchPodSpec", "gitlab")
 	s.podSpecGetter.CheckCall(c, 1, "ProvisioningInfo", "gitlab") // not found
 	s.podSpecGetter.CheckCall(c, 2, "ProvisioningInfo", "gitlab")
 	s.lifeGetter.CheckCallNames(c, "Life")
 	s.lifeGetter.CheckCall(c, 0, "Life", "gitlab")
-	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureService", "GetService")
+	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureService", "EnsureAutoscaler", "GetService")
 	s.serviceBroker.CheckCall(c, 1, "EnsureService",
 		"gitlab", getExpectedServiceParams(), 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
-	s.serviceBroker.CheckCall(c, 2, "GetService", "gitlab", caas.ModeWorkload)
+	s.serviceBroker.CheckCall(c, 2, "EnsureAutoscaler", "gitlab", (*caas.AutoscalingPolicy)(nil))
+	s.serviceBroker.CheckCall(c, 3, "GetService", "gitlab", caas.ModeWorkload)
 
 	s.serviceBroker.ResetCalls()
 	// Add another unit.
@@ -353,6 +363,79 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 		"gitlab", newExpectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestAutoscalingPolicyChanged(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	autoscaled := make(chan struct{})
+	s.serviceBroker.autoscaled = autoscaled
+	s.serviceBroker.ResetCalls()
+	s.applicationGetter.ResetCalls()
+
+	s.applicationGetter.setConfig(application.ConfigAttributes{
+		"juju-external-hostname":   "exthost",
+		caas.AutoscaleMinUnitsKey:  2,
+		caas.AutoscaleMaxUnitsKey:  5,
+		caas.AutoscaleCPUTargetKey: 70,
+	})
+	select {
+	case s.applicationConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+	select {
+	case <-autoscaled:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for autoscaler to be ensured")
+	}
+	s.applicationGetter.CheckCallNames(c, "ApplicationConfig")
+	s.serviceBroker.CheckCallNames(c, "EnsureAutoscaler")
+	s.serviceBroker.CheckCall(c, 0, "EnsureAutoscaler", "gitlab", &caas.AutoscalingPolicy{
+		MinUnits:         2,
+		MaxUnits:         5,
+		CPUTargetPercent: 70,
+	})
+
+	// An unrelated config change does not touch the autoscaler.
+	s.serviceBroker.ResetCalls()
+	s.applicationGetter.setConfig(application.ConfigAttributes{
+		"juju-external-hostname":   "otherhost",
+		caas.AutoscaleMinUnitsKey:  2,
+		caas.AutoscaleMaxUnitsKey:  5,
+		caas.AutoscaleCPUTargetKey: 70,
+	})
+	select {
+	case s.applicationConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+	select {
+	case <-autoscaled:
+		c.Fatal("autoscaler ensured unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.serviceBroker.CheckNoCalls(c)
+
+	// Removing the policy removes the autoscaler.
+	s.applicationGetter.setConfig(application.ConfigAttributes{
+		"juju-external-hostname": "otherhost",
+	})
+	select {
+	case s.applicationConfigChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+	select {
+	case <-autoscaled:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for autoscaler to be removed")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureAutoscaler")
+	s.serviceBroker.CheckCall(c, 0, "EnsureAutoscaler", "gitlab", (*caas.AutoscalingPolicy)(nil))
+}
+
 func intPtr(i int) *int {
 	return &i
 }
@@ -615,6 +698,120 @@ func (s *WorkerSuite) TestScaleZero(c *gc.C) {
 		"gitlab", &caas.ServiceParams{}, 0, application.ConfigAttributes(nil))
 }
 
+func (s *WorkerSuite) TestScaleSpreadAcrossClusterZones(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	zoneEnsured := make(chan string, 2)
+	zoned := &mockZonedServiceBroker{
+		mockServiceBroker: &s.serviceBroker,
+		zones:             []string{"east", "west"},
+		zoneBrokers: map[string]*mockZoneBroker{
+			"east": {ensured: zoneEnsured, zone: "east"},
+			"west": {ensured: zoneEnsured, zone: "west"},
+		},
+	}
+	s.config.ServiceBroker = zoned
+	info := s.podSpecGetter.provisioningInfo
+	info.Constraints = constraints.MustParse("mem=4G zones=west,east,node-a")
+	s.podSpecGetter.setProvisioningInfo(info)
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.applicationGetter.scale = 3
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+
+	for _, zone := range []string{"east", "west"} {
+		select {
+		case ensured := <-zoneEnsured:
+			c.Assert(ensured, gc.Equals, zone)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for service to be ensured in %q", zone)
+		}
+	}
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+
+	expectedParams := getExpectedServiceParams()
+	expectedParams.Constraints = constraints.MustParse("mem=4G zones=node-a")
+	appConfig := application.ConfigAttributes{"juju-external-hostname": "exthost"}
+	zoned.zoneBrokers["east"].CheckCalls(c, []testing.StubCall{
+		{"EnsureService", []interface{}{"gitlab", expectedParams, 1, appConfig}},
+	})
+	zoned.zoneBrokers["west"].CheckCalls(c, []testing.StubCall{
+		{"EnsureService", []interface{}{"gitlab", expectedParams, 2, appConfig}},
+	})
+	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureAutoscaler", "GetService")
+}
+
+func (s *WorkerSuite) TestScaleImportedWorkload(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	scaled := make(chan int, 1)
+	s.config.ServiceBroker = &mockImportingServiceBroker{
+		mockServiceBroker: &s.serviceBroker,
+		scaled:            scaled,
+	}
+	s.podSpecGetter.SetErrors(nil, errors.NotFoundf("spec"), errors.NotFoundf("spec"))
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	s.applicationGetter.scale = 3
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	s.sendContainerSpecChange(c)
+
+	select {
+	case scale := <-scaled:
+		c.Assert(scale, gc.Equals, 3)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for workload to be scaled")
+	}
+	select {
+	case <-s.serviceUpdated:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be updated")
+	}
+
+	s.applicationGetter.scale = 1
+	select {
+	case s.applicationScaleChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending scale change")
+	}
+	select {
+	case scale := <-scaled:
+		c.Assert(scale, gc.Equals, 1)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for workload to be scaled")
+	}
+	s.serviceBroker.CheckCallNames(c, "WatchService", "ScaleImportedService", "GetService", "ScaleImportedService")
+}
+
 func (s *WorkerSuite) TestApplicationDeadRemovesService(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -914,16 +1111,84 @@ func (s *WorkerSuite) TestUnitsChange(c *gc.C) {
 	defer workertest.CleanKill(c, w)
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
+		if len(s.containerBroker.Calls()) >= 3 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchEvents", "WatchOperator")
 
 	s.assertUnitChange(c, status.Allocating, status.Allocating)
 	s.assertUnitChange(c, status.Allocating, status.Unknown)
 }
 
+func (s *WorkerSuite) TestWarningEventsUpdateStatus(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	w, err := caasunitprovisioner.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+	defer workertest.CleanKill(c, w)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.containerBroker.Calls()) >= 3 {
+			break
+		}
+	}
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchEvents", "WatchOperator")
+	c.Assert(s.containerBroker.Calls()[1].Args, jc.DeepEquals, []interface{}{"gitlab", caas.ModeWorkload})
+
+	s.containerBroker.ResetCalls()
+	s.serviceBroker.ResetCalls()
+	s.containerBroker.reportedUnitStatus = status.Waiting
+	s.serviceBroker.serviceStatus = status.StatusInfo{
+		Status:  status.Waiting,
+		Message: "FailedScheduling: 0/1 nodes are available",
+	}
+
+	select {
+	case s.caasEventsChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending events change")
+	}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.unitUpdater.Calls()) > 0 {
+			break
+		}
+	}
+	s.serviceBroker.CheckCallNames(c, "GetService")
+	s.containerBroker.CheckCallNames(c, "Units")
+	s.unitUpdater.CheckCallNames(c, "UpdateUnits")
+	// Warning events do not change the scale of the application.
+	c.Assert(s.unitUpdater.Calls()[0].Args, jc.DeepEquals, []interface{}{
+		params.UpdateApplicationUnits{
+			ApplicationTag: names.NewApplicationTag("gitlab").String(),
+			Status: params.EntityStatus{
+				Status: status.Waiting,
+				Info:   "FailedScheduling: 0/1 nodes are available",
+			},
+			Units: []params.ApplicationUnitParams{
+				{ProviderId: "u1", Address: "10.0.0.1", Ports: []string(nil), Status: "waiting",
+					Stateful: true,
+					FilesystemInfo: []params.KubernetesFilesystemInfo{
+						{StorageName: "database", MountPoint: "/path-to-here", ReadOnly: true,
+							FilesystemId: "fs-id", Size: 100, Pool: "",
+							Volume: params.KubernetesVolumeInfo{
+								VolumeId: "vol-id", Size: 200,
+								Persistent: true, Status: "error", Info: "vol not ready"},
+							Status: "attaching", Info: "not ready"},
+					}},
+			},
+		},
+	})
+}
+
 func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 
@@ -941,11 +1206,11 @@ func (s *WorkerSuite) TestOperatorChange(c *gc.C) {
 	}
 
 	for a := coretesting.LongAttempt.Start(); a.Next(); {
-		if len(s.containerBroker.Calls()) >= 2 {
+		if len(s.containerBroker.Calls()) >= 3 {
 			break
 		}
 	}
-	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchOperator")
+	s.containerBroker.CheckCallNames(c, "WatchUnits", "WatchEvents", "WatchOperator")
 	s.containerBroker.ResetCalls()
 
 	// Initial event
@@ -54,6 +54,10 @@ func (e environWithoutNetworking) Instances(ctx context.ProviderCallContext, ids
 	return e.env.Instances(ctx, ids)
 }
 
+func (e environWithoutNetworking) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	return e.env.StopInstances(ctx, ids...)
+}
+
 func (e environWithoutNetworking) NetworkInterfaces(context.ProviderCallContext, []instance.Id) ([]network.InterfaceInfos, error) {
 	return nil, errNetworkingNotSupported
 }
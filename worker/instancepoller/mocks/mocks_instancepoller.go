@@ -66,6 +66,23 @@ func (mr *MockEnvironMockRecorder) NetworkInterfaces(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NetworkInterfaces", reflect.TypeOf((*MockEnviron)(nil).NetworkInterfaces), arg0, arg1)
 }
 
+// StopInstances mocks base method
+func (m *MockEnviron) StopInstances(arg0 context.ProviderCallContext, arg1 ...instance.Id) error {
+	varargs := []interface{}{arg0}
+	for _, a := range arg1 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopInstances", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopInstances indicates an expected call of StopInstances
+func (mr *MockEnvironMockRecorder) StopInstances(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0}, arg1...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopInstances", reflect.TypeOf((*MockEnviron)(nil).StopInstances), varargs...)
+}
+
 // MockMachine is a mock of Machine interface
 type MockMachine struct {
 	ctrl     *gomock.Controller
@@ -152,6 +169,18 @@ func (mr *MockMachineMockRecorder) Life() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Life", reflect.TypeOf((*MockMachine)(nil).Life))
 }
 
+// MarkInstanceReclaimed mocks base method
+func (m *MockMachine) MarkInstanceReclaimed() error {
+	ret := m.ctrl.Call(m, "MarkInstanceReclaimed")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkInstanceReclaimed indicates an expected call of MarkInstanceReclaimed
+func (mr *MockMachineMockRecorder) MarkInstanceReclaimed() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkInstanceReclaimed", reflect.TypeOf((*MockMachine)(nil).MarkInstanceReclaimed))
+}
+
 // Refresh mocks base method
 func (m *MockMachine) Refresh() error {
 	ret := m.ctrl.Call(m, "Refresh")
@@ -49,6 +49,7 @@ var (
 type Environ interface {
 	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
 	NetworkInterfaces(ctx context.ProviderCallContext, ids []instance.Id) ([]network.InterfaceInfos, error)
+	StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error
 }
 
 // Machine specifies an interface for machine instances processed by the
@@ -64,6 +65,7 @@ type Machine interface {
 	Status() (params.StatusResult, error)
 	Life() life.Value
 	IsManual() (bool, error)
+	MarkInstanceReclaimed() error
 }
 
 // FacadeAPI specifies the api-server methods needed by the instance
@@ -117,6 +119,12 @@ type pollGroupEntry struct {
 	tag        names.MachineTag
 	instanceID instance.Id
 
+	// seenByProvider is true once the provider has reported the
+	// machine's instance, and reclaimNotSupported is true if the
+	// instance cannot be marked as reclaimed when it disappears.
+	seenByProvider      bool
+	reclaimNotSupported bool
+
 	shortPollInterval time.Duration
 	shortPollAt       time.Time
 }
@@ -359,6 +367,9 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 	if err != nil && !isPartialOrNoInstancesError(err) {
 		return errors.Trace(err)
 	}
+	if len(infoList) == 0 && errors.Cause(err) == environs.ErrNoInstances {
+		infoList = make([]instances.Instance, len(instList))
+	}
 
 	netList, err := u.config.Environ.NetworkInterfaces(u.callContext, instList)
 	if err != nil && !(errors.IsNotSupported(errors.Cause(err)) || isPartialOrNoInstancesError(err)) {
@@ -370,7 +381,9 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		// that the unit has been killed and we haven't been notified
 		// yet. Log the error and keep going.
 		if info == nil {
-			u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", instList[idx])
+			if err := u.maybeMarkInstanceReclaimed(u.instanceIDToGroupEntry[instList[idx]]); err != nil {
+				return errors.Trace(err)
+			}
 			continue
 		}
 
@@ -380,6 +393,7 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 		}
 
 		entry := u.instanceIDToGroupEntry[instList[idx]]
+		entry.seenByProvider = true
 		providerStatus, providerAddrCount, err := u.processProviderInfo(entry, info, ifList)
 		if err != nil {
 			return errors.Trace(err)
@@ -396,6 +410,42 @@ func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
 	return nil
 }
 
+// maybeMarkInstanceReclaimed is called when the provider no longer knows
+// about an entry's instance. If the instance was previously seen and the
+// machine is still alive, the instance is assumed to have been reclaimed
+// by the cloud, as happens to spot instances, and the machine is marked
+// accordingly. Machines whose instances cannot be reclaimed are left alone.
+func (u *updaterWorker) maybeMarkInstanceReclaimed(entry *pollGroupEntry) error {
+	if !entry.seenByProvider || entry.reclaimNotSupported || entry.m.Life() != life.Alive {
+		u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", entry.instanceID)
+		return nil
+	}
+
+	if err := entry.m.MarkInstanceReclaimed(); err != nil {
+		if params.IsCodeNotSupported(err) {
+			entry.reclaimNotSupported = true
+			u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", entry.instanceID)
+			return nil
+		}
+		return errors.Annotatef(err, "cannot mark instance %q of machine %q as reclaimed", entry.instanceID, entry.m)
+	}
+	u.config.Logger.Infof("machine %q (instance ID %q) instance was reclaimed by the cloud", entry.m.Id(), entry.instanceID)
+
+	// Some providers keep a stopped instance around when it is reclaimed,
+	// which would prevent a replacement with the same name being started.
+	if err := u.config.Environ.StopInstances(u.callContext, entry.instanceID); err != nil {
+		u.config.Logger.Warningf("cannot stop reclaimed instance %q: %v", entry.instanceID, err)
+	}
+
+	// Forget the old instance; the machine will be polled again once
+	// a replacement instance has been provisioned.
+	delete(u.instanceIDToGroupEntry, entry.instanceID)
+	entry.instanceID = ""
+	entry.seenByProvider = false
+	u.moveEntryToPollGroup(shortPollGroup, entry)
+	return nil
+}
+
 func (u *updaterWorker) resolveInstanceID(entry *pollGroupEntry) error {
 	if entry.instanceID != "" {
 		return nil // already resolved
@@ -565,6 +565,93 @@ func (s *workerSuite) TestLongPollNoMachineInGroupKnownByProvider(c *gc.C) {
 	})
 }
 
+func (s *workerSuite) TestLongPollReclaimedInstance(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+
+	// Add a machine whose instance was previously seen by the provider
+	// to the long poll group.
+	instID := instance.Id("d3adc0de")
+	updWorker.appendToShortPollGroup(machineTag, machine)
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	entry.instanceID = instID
+	entry.seenByProvider = true
+	updWorker.instanceIDToGroupEntry[instID] = entry
+	updWorker.pollGroup[longPollGroup][machineTag] = entry
+	delete(updWorker.pollGroup[shortPollGroup], machineTag)
+
+	// The provider no longer knows about the instance, so the machine
+	// is marked as reclaimed and the left-over instance is stopped.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{instID}).Return(
+		nil, environs.ErrNoInstances,
+	)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{instID}).Return(
+		nil, errors.NotSupportedf("network interfaces"),
+	)
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().MarkInstanceReclaimed().Return(nil)
+	machine.EXPECT().Id().Return("0")
+	mocked.environ.EXPECT().StopInstances(gomock.Any(), instID).Return(nil)
+
+	// The machine is then polled in the short poll group until it is
+	// provisioned again.
+	machine.EXPECT().InstanceId().Return(instance.Id(""), &params.Error{Code: params.CodeNotProvisioned}).AnyTimes()
+
+	s.assertWorkerCompletesLoops(c, updWorker, 2, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 0)
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 1)
+	c.Assert(updWorker.instanceIDToGroupEntry, gc.HasLen, 0)
+	c.Assert(entry.instanceID, gc.Equals, instance.Id(""))
+}
+
+func (s *workerSuite) TestLongPollReclaimNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+
+	instID := instance.Id("d3adc0de")
+	updWorker.appendToShortPollGroup(machineTag, machine)
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	entry.instanceID = instID
+	entry.seenByProvider = true
+	updWorker.instanceIDToGroupEntry[instID] = entry
+	updWorker.pollGroup[longPollGroup][machineTag] = entry
+	delete(updWorker.pollGroup[shortPollGroup], machineTag)
+
+	// The machine's instance is not a spot instance, so it cannot be
+	// marked as reclaimed; the machine stays where it is.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{instID}).Return(
+		[]instances.Instance{nil}, environs.ErrPartialInstances,
+	)
+	mocked.environ.EXPECT().NetworkInterfaces(gomock.Any(), []instance.Id{instID}).Return(
+		nil, errors.NotSupportedf("network interfaces"),
+	)
+	machine.EXPECT().Life().Return(life.Alive)
+	machine.EXPECT().MarkInstanceReclaimed().Return(&params.Error{Code: params.CodeNotSupported})
+
+	s.assertWorkerCompletesLoops(c, updWorker, 2, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+	c.Assert(entry.reclaimNotSupported, jc.IsTrue)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 1)
+	c.Assert(entry.instanceID, gc.Equals, instID)
+}
+
 func (s *workerSuite) assertWorkerCompletesLoop(c *gc.C, w *updaterWorker, triggerFn func()) {
 	s.assertWorkerCompletesLoops(c, w, 1, triggerFn)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -97,6 +98,16 @@ func (ctx *limitedContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// LogActionOutput implements runner.Context.
+func (ctx *limitedContext) LogActionOutput(stream, data string) error {
+	return jujuc.ErrRestrictedContext
+}
+
+// RemoteDebugSession implements runner.Context.
+func (ctx *limitedContext) RemoteDebugSession() (*debug.RemoteSession, error) {
+	return nil, nil
+}
+
 // Flush implements runner.Context.
 func (ctx *limitedContext) Flush(_ string, err error) error {
 	return err
@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/metrics/spool"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -111,6 +112,16 @@ func (ctx *hookContext) ActionData() (*context.ActionData, error) {
 	return nil, jujuc.ErrRestrictedContext
 }
 
+// LogActionOutput implements runner.Context.
+func (ctx *hookContext) LogActionOutput(stream, data string) error {
+	return jujuc.ErrRestrictedContext
+}
+
+// RemoteDebugSession implements runner.Context.
+func (ctx *hookContext) RemoteDebugSession() (*debug.RemoteSession, error) {
+	return nil, nil
+}
+
 // HasExecutionSetUnitStatus implements runner.Context.
 func (ctx *hookContext) HasExecutionSetUnitStatus() bool { return false }
 
@@ -1089,6 +1089,17 @@ func (task *provisionerTask) startMachine(
 		return errors.Trace(task.setErrorStatus("%v", machine, err))
 	}
 
+	// Refuse to start the instance if doing so would exceed the cloud's
+	// quotas, rather than leaving the machine to fail in StartInstance.
+	if err := environs.CheckInstanceQuotas(
+		task.cloudCallCtx, task.broker, startInstanceParams.Constraints, startInstanceParams.Volumes,
+	); err != nil {
+		if errors.IsQuotaLimitExceeded(err) {
+			return errors.Trace(task.setErrorStatus("cannot start instance for machine %q: %v", machine, err))
+		}
+		task.logger.Warningf("cannot check cloud quotas for machine %q: %v", machine, err)
+	}
+
 	// Figure out if the zones available to use for a new instance are
 	// restricted based on placement, and if so exclude those machines
 	// from being started in any other zone.
@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/common/mocks"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/provisioner"
 )
@@ -204,6 +205,39 @@ func (s *ProvisionerTaskSuite) TestProvisionerRetries(c *gc.C) {
 	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
 }
 
+func (s *ProvisionerTaskSuite) TestProvisionerQuotaExceeded(c *gc.C) {
+	broker := &quotaInstanceBroker{
+		testInstanceBroker: s.instanceBroker,
+		quotas:             environs.Quotas{Cores: uint64p(1)},
+		usage:              environs.QuotaUsage{Instances: 1, Cores: 2},
+	}
+	task := s.newProvisionerTaskWithBroker(c, broker, nil)
+
+	m0 := &testMachine{
+		id: "0",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"RemainingQuotas", "InstanceQuotaUsage"})
+	for attempt := coretesting.LongAttempt.Start(); attempt.Next(); {
+		_, msg, err := m0.InstanceStatus()
+		c.Assert(err, jc.ErrorIsNil)
+		if msg != "" {
+			break
+		}
+	}
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "RemainingQuotas", "InstanceQuotaUsage")
+	_, msg, err := m0.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(msg, gc.Equals, "cloud quota exceeded for cores (2 required, 1 remaining)")
+}
+
 func (s *ProvisionerTaskSuite) TestMultipleSpaceConstraints(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -752,6 +786,33 @@ func (t *testInstanceBroker) MaintainInstance(ctx context.ProviderCallContext, a
 	return nil
 }
 
+// quotaInstanceBroker is a testInstanceBroker that
+// also implements environs.QuotaChecker.
+type quotaInstanceBroker struct {
+	*testInstanceBroker
+
+	quotas environs.Quotas
+	usage  environs.QuotaUsage
+}
+
+func (t *quotaInstanceBroker) RemainingQuotas(ctx context.ProviderCallContext) (environs.Quotas, error) {
+	t.AddCall("RemainingQuotas", ctx)
+	t.callsChan <- "RemainingQuotas"
+	return t.quotas, t.NextErr()
+}
+
+func (t *quotaInstanceBroker) InstanceQuotaUsage(
+	ctx context.ProviderCallContext, cons constraints.Value, volumes []storage.VolumeParams,
+) (environs.QuotaUsage, error) {
+	t.AddCall("InstanceQuotaUsage", ctx, cons, volumes)
+	t.callsChan <- "InstanceQuotaUsage"
+	return t.usage, t.NextErr()
+}
+
+func uint64p(v uint64) *uint64 {
+	return &v
+}
+
 type testInstance struct {
 	instances.Instance
 	id string
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/common"
+)
+
+// ManifoldConfig defines the resource tagger's configuration and
+// dependencies.
+type ManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	Logger        Logger
+
+	NewFacade                    func(base.APICaller) (Facade, error)
+	NewWorker                    func(Config) (worker.Worker, error)
+	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+}
+
+// Validate returns an error if the config cannot be used to start
+// the manifold's worker.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.EnvironName == "" {
+		return errors.NotValidf("empty EnvironName")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewCredentialValidatorFacade == nil {
+		return errors.NotValidf("nil NewCredentialValidatorFacade")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs a resource tagger.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.APICallerName, config.EnvironName},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			if err := config.Validate(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var environ environs.Environ
+			if err := context.Get(config.EnvironName, &environ); err != nil {
+				return nil, errors.Trace(err)
+			}
+			facade, err := config.NewFacade(apiCaller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			credentialAPI, err := config.NewCredentialValidatorFacade(apiCaller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			w, err := config.NewWorker(Config{
+				Facade:      facade,
+				Environ:     environ,
+				CallContext: common.NewCloudCallContext(credentialAPI, nil),
+				Logger:      config.Logger,
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return w, nil
+		},
+	}
+}
+
+// NewFacade returns a resource tagger facade backed by the given API
+// caller.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return resourcetagger.NewClient(apiCaller), nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2"
+	"github.com/juju/worker/v2/dependency"
+	dt "github.com/juju/worker/v2/dependency/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/common"
+	"github.com/juju/juju/worker/resourcetagger"
+)
+
+type manifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&manifoldSuite{})
+
+func (*manifoldSuite) TestInputs(c *gc.C) {
+	manifold := makeManifold(nil, nil)
+	c.Assert(manifold.Inputs, jc.SameContents, []string{"the-caller", "the-environ"})
+}
+
+func (*manifoldSuite) TestMissingCaller(c *gc.C) {
+	manifold := makeManifold(nil, nil)
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  dependency.ErrMissing,
+		"the-environ": &fakeModelEnviron{},
+	}))
+	c.Assert(result, gc.IsNil)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (*manifoldSuite) TestMissingEnviron(c *gc.C) {
+	manifold := makeManifold(nil, nil)
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  apitesting.APICallerFunc(nil),
+		"the-environ": dependency.ErrMissing,
+	}))
+	c.Assert(result, gc.IsNil)
+	c.Assert(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (*manifoldSuite) TestWorkerError(c *gc.C) {
+	manifold := makeManifold(nil, errors.New("boglodite"))
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  apitesting.APICallerFunc(nil),
+		"the-environ": &fakeModelEnviron{},
+	}))
+	c.Assert(result, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "boglodite")
+}
+
+func (*manifoldSuite) TestSuccess(c *gc.C) {
+	w := &fakeWorker{}
+	environ := &fakeModelEnviron{}
+	var config resourcetagger.Config
+	manifold := resourcetagger.Manifold(resourcetagger.ManifoldConfig{
+		APICallerName: "the-caller",
+		EnvironName:   "the-environ",
+		Logger:        loggo.GetLogger("test"),
+		NewFacade: func(base.APICaller) (resourcetagger.Facade, error) {
+			return &fakeFacade{}, nil
+		},
+		NewWorker: func(c resourcetagger.Config) (worker.Worker, error) {
+			config = c
+			return w, nil
+		},
+		NewCredentialValidatorFacade: func(base.APICaller) (common.CredentialAPI, error) {
+			return &fakeCredentialAPI{}, nil
+		},
+	})
+	result, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"the-caller":  apitesting.APICallerFunc(nil),
+		"the-environ": environ,
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.Equals, w)
+	c.Assert(config.Environ, gc.Equals, environ)
+	c.Assert(config.Facade, gc.NotNil)
+	c.Assert(config.CallContext, gc.NotNil)
+}
+
+func makeManifold(w worker.Worker, workerErr error) dependency.Manifold {
+	return resourcetagger.Manifold(resourcetagger.ManifoldConfig{
+		APICallerName: "the-caller",
+		EnvironName:   "the-environ",
+		Logger:        loggo.GetLogger("test"),
+		NewFacade: func(base.APICaller) (resourcetagger.Facade, error) {
+			return &fakeFacade{}, nil
+		},
+		NewWorker: func(resourcetagger.Config) (worker.Worker, error) {
+			return w, workerErr
+		},
+		NewCredentialValidatorFacade: func(base.APICaller) (common.CredentialAPI, error) {
+			return &fakeCredentialAPI{}, nil
+		},
+	})
+}
+
+type fakeModelEnviron struct {
+	environs.Environ
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
+
+type fakeCredentialAPI struct{}
+
+func (*fakeCredentialAPI) InvalidateModelCredential(reason string) error {
+	return nil
+}
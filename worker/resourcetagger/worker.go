@@ -0,0 +1,234 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package resourcetagger provides a worker that keeps the tags of a
+// model's cloud instances and volumes up to date, re-applying them
+// whenever the model's or applications' resource tags change, or
+// units are added to or removed from the model's machines.
+package resourcetagger
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/worker/v2"
+
+	"github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Errorf(string, ...interface{})
+}
+
+// Facade defines the interface we require from the resource tagger
+// facade.
+type Facade interface {
+	WatchResourceTags() (watcher.NotifyWatcher, error)
+	InstanceTags() ([]resourcetagger.InstanceTags, error)
+	VolumeTags() ([]resourcetagger.VolumeTags, error)
+}
+
+// Environ defines the interface we require from the model's environ.
+// If the environ also implements environs.InstanceTagger, the model's
+// instances are tagged.
+type Environ interface {
+	StorageProvider(storage.ProviderType) (storage.Provider, error)
+}
+
+// Config holds the dependencies and configuration of the resource
+// tagger worker.
+type Config struct {
+	Facade      Facade
+	Environ     Environ
+	CallContext context.ProviderCallContext
+	Logger      Logger
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that tags the model's instances and
+// volumes with the tags computed by the controller, whenever those
+// tags may have changed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: NewTagger(config),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Tagger is the watcher.NotifyHandler that applies resource tags.
+// It remembers the tags last applied to each resource, so that
+// resources are only re-tagged when their tags change.
+type Tagger struct {
+	config       Config
+	instanceTags map[instance.Id]map[string]string
+	volumeTags   map[names.VolumeTag]map[string]string
+}
+
+// NewTagger returns a new Tagger with the given config.
+func NewTagger(config Config) *Tagger {
+	return &Tagger{
+		config:       config,
+		instanceTags: make(map[instance.Id]map[string]string),
+		volumeTags:   make(map[names.VolumeTag]map[string]string),
+	}
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (t *Tagger) SetUp() (watcher.NotifyWatcher, error) {
+	return t.config.Facade.WatchResourceTags()
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (t *Tagger) Handle(<-chan struct{}) error {
+	if err := t.tagInstances(); err != nil {
+		return errors.Annotate(err, "tagging instances")
+	}
+	if err := t.tagVolumes(); err != nil {
+		return errors.Annotate(err, "tagging volumes")
+	}
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (t *Tagger) TearDown() error {
+	return nil
+}
+
+// tagInstances tags the instances whose tags have changed since they
+// were last tagged. Failures to tag individual instances are logged,
+// and those instances are retried the next time the tags change.
+func (t *Tagger) tagInstances() error {
+	instanceTagger, ok := t.config.Environ.(environs.InstanceTagger)
+	if !ok {
+		return nil
+	}
+	results, err := t.config.Facade.InstanceTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	seen := make(map[instance.Id]bool)
+	for _, result := range results {
+		if result.Error != nil {
+			t.config.Logger.Errorf("cannot get tags for %s: %v", names.ReadableString(result.MachineTag), result.Error)
+			continue
+		}
+		seen[result.InstanceId] = true
+		if reflect.DeepEqual(t.instanceTags[result.InstanceId], result.Tags) {
+			continue
+		}
+		t.config.Logger.Debugf("tagging instance %q of %s", result.InstanceId, names.ReadableString(result.MachineTag))
+		if err := instanceTagger.TagInstance(t.config.CallContext, result.InstanceId, result.Tags); err != nil {
+			t.config.Logger.Errorf("cannot tag instance %q: %v", result.InstanceId, err)
+			delete(t.instanceTags, result.InstanceId)
+			continue
+		}
+		t.instanceTags[result.InstanceId] = result.Tags
+	}
+	for id := range t.instanceTags {
+		if !seen[id] {
+			delete(t.instanceTags, id)
+		}
+	}
+	return nil
+}
+
+// tagVolumes tags the volumes whose tags have changed since they were
+// last tagged. Volumes managed by storage providers that do not support
+// tagging existing volumes are skipped.
+func (t *Tagger) tagVolumes() error {
+	results, err := t.config.Facade.VolumeTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	seen := make(map[names.VolumeTag]bool)
+	for _, result := range results {
+		if result.Error != nil {
+			t.config.Logger.Errorf("cannot get tags for %s: %v", names.ReadableString(result.VolumeTag), result.Error)
+			continue
+		}
+		seen[result.VolumeTag] = true
+		if reflect.DeepEqual(t.volumeTags[result.VolumeTag], result.Tags) {
+			continue
+		}
+		tagged, err := t.tagVolume(result)
+		if err != nil {
+			t.config.Logger.Errorf("cannot tag %s: %v", names.ReadableString(result.VolumeTag), err)
+			delete(t.volumeTags, result.VolumeTag)
+			continue
+		}
+		if tagged {
+			t.config.Logger.Debugf("tagged %s", names.ReadableString(result.VolumeTag))
+		}
+		t.volumeTags[result.VolumeTag] = result.Tags
+	}
+	for tag := range t.volumeTags {
+		if !seen[tag] {
+			delete(t.volumeTags, tag)
+		}
+	}
+	return nil
+}
+
+// tagVolume tags the volume described by the given result, and
+// reports whether the volume's storage provider supports tagging.
+func (t *Tagger) tagVolume(result resourcetagger.VolumeTags) (bool, error) {
+	provider, err := t.config.Environ.StorageProvider(result.Provider)
+	if errors.IsNotFound(err) {
+		// The volume is not managed by the environ's storage
+		// providers, e.g. it is a loop device.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron || !provider.Supports(storage.StorageKindBlock) {
+		return false, nil
+	}
+	cfg, err := storage.NewConfig(string(result.Provider), result.Provider, result.Attributes)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	volumeTagger, ok := source.(storage.VolumeTagger)
+	if !ok {
+		return false, nil
+	}
+	if err := volumeTagger.TagVolume(t.config.CallContext, result.VolumeId, result.Tags); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
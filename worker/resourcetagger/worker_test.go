@@ -0,0 +1,263 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resourcetagger_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/worker/v2/workertest"
+	gc "gopkg.in/check.v1"
+
+	apiresourcetagger "github.com/juju/juju/api/resourcetagger"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/resourcetagger"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	facade  *fakeFacade
+	environ *fakeEnviron
+	config  resourcetagger.Config
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{Stub: &testing.Stub{}}
+	s.environ = &fakeEnviron{Stub: &testing.Stub{}}
+	s.environ.provider = &fakeProvider{
+		scope:  storage.ScopeEnviron,
+		source: &fakeVolumeSource{Stub: s.environ.Stub},
+	}
+	s.config = resourcetagger.Config{
+		Facade:      s.facade,
+		Environ:     s.environ,
+		CallContext: context.NewCloudCallContext(),
+		Logger:      loggo.GetLogger("test"),
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Facade = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+	config = s.config
+	config.Environ = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Environ not valid")
+	config = s.config
+	config.CallContext = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil CallContext not valid")
+	config = s.config
+	config.Logger = nil
+	c.Assert(config.Validate(), gc.ErrorMatches, "nil Logger not valid")
+}
+
+func (s *workerSuite) TestWorkerTagsResources(c *gc.C) {
+	changes := make(chan struct{}, 1)
+	changes <- struct{}{}
+	s.facade.watcher = watchertest.NewMockNotifyWatcher(changes)
+	s.facade.instanceTags = []apiresourcetagger.InstanceTags{{
+		MachineTag: names.NewMachineTag("0"),
+		InstanceId: "i-0",
+		Tags:       map[string]string{"owner": "fred"},
+	}}
+
+	w, err := resourcetagger.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if len(s.environ.Calls()) > 0 {
+			break
+		}
+	}
+	workertest.CleanKill(c, w)
+	s.facade.CheckCallNames(c, "WatchResourceTags", "InstanceTags", "VolumeTags")
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{FuncName: "TagInstance", Args: []interface{}{instance.Id("i-0"), map[string]string{"owner": "fred"}}},
+	})
+}
+
+func (s *workerSuite) TestTagInstancesOnlyWhenChanged(c *gc.C) {
+	s.facade.instanceTags = []apiresourcetagger.InstanceTags{{
+		MachineTag: names.NewMachineTag("0"),
+		InstanceId: "i-0",
+		Tags:       map[string]string{"owner": "fred"},
+	}, {
+		MachineTag: names.NewMachineTag("1"),
+		Error:      errors.New("boom"),
+	}}
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCallNames(c, "TagInstance")
+
+	s.facade.instanceTags[0].Tags = map[string]string{"owner": "mary"}
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCallNames(c, "TagInstance", "TagInstance")
+	s.environ.CheckCall(c, 1, "TagInstance", instance.Id("i-0"), map[string]string{"owner": "mary"})
+}
+
+func (s *workerSuite) TestTagInstanceErrorRetried(c *gc.C) {
+	s.facade.instanceTags = []apiresourcetagger.InstanceTags{{
+		MachineTag: names.NewMachineTag("0"),
+		InstanceId: "i-0",
+		Tags:       map[string]string{"owner": "fred"},
+	}}
+	s.environ.SetErrors(errors.New("boom"))
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCallNames(c, "TagInstance", "TagInstance")
+}
+
+func (s *workerSuite) TestTagInstancesNotSupported(c *gc.C) {
+	s.config.Environ = &fakeNoInstanceTaggerEnviron{s.environ}
+	s.facade.instanceTags = []apiresourcetagger.InstanceTags{{
+		MachineTag: names.NewMachineTag("0"),
+		InstanceId: "i-0",
+		Tags:       map[string]string{"owner": "fred"},
+	}}
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.facade.CheckCallNames(c, "VolumeTags")
+	s.environ.CheckNoCalls(c)
+}
+
+func (s *workerSuite) TestTagVolumes(c *gc.C) {
+	s.facade.volumeTags = []apiresourcetagger.VolumeTags{{
+		VolumeTag:  names.NewVolumeTag("0"),
+		VolumeId:   "vol-0",
+		Provider:   "ebs",
+		Attributes: map[string]interface{}{"volume-type": "gp2"},
+		Tags:       map[string]string{"owner": "fred"},
+	}}
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCalls(c, []testing.StubCall{
+		{FuncName: "StorageProvider", Args: []interface{}{storage.ProviderType("ebs")}},
+		{FuncName: "VolumeSource", Args: []interface{}{"ebs", map[string]interface{}{"volume-type": "gp2"}}},
+		{FuncName: "TagVolume", Args: []interface{}{"vol-0", map[string]string{"owner": "fred"}}},
+	})
+}
+
+func (s *workerSuite) TestTagVolumesUnknownProvider(c *gc.C) {
+	s.facade.volumeTags = []apiresourcetagger.VolumeTags{{
+		VolumeTag: names.NewVolumeTag("0"),
+		VolumeId:  "loop0",
+		Provider:  "loop",
+		Tags:      map[string]string{"owner": "fred"},
+	}}
+	s.environ.SetErrors(errors.NotFoundf(`storage provider "loop"`))
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCallNames(c, "StorageProvider")
+}
+
+func (s *workerSuite) TestTagVolumesMachineScopedProvider(c *gc.C) {
+	s.environ.provider.scope = storage.ScopeMachine
+	s.facade.volumeTags = []apiresourcetagger.VolumeTags{{
+		VolumeTag: names.NewVolumeTag("0"),
+		VolumeId:  "vol-0",
+		Provider:  "ebs",
+		Tags:      map[string]string{"owner": "fred"},
+	}}
+	tagger := resourcetagger.NewTagger(s.config)
+	c.Assert(tagger.Handle(nil), jc.ErrorIsNil)
+	s.environ.CheckCallNames(c, "StorageProvider")
+}
+
+func (s *workerSuite) TestFacadeError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	tagger := resourcetagger.NewTagger(s.config)
+	err := tagger.Handle(nil)
+	c.Assert(err, gc.ErrorMatches, "tagging instances: boom")
+}
+
+type fakeFacade struct {
+	*testing.Stub
+	watcher      watcher.NotifyWatcher
+	instanceTags []apiresourcetagger.InstanceTags
+	volumeTags   []apiresourcetagger.VolumeTags
+}
+
+func (f *fakeFacade) WatchResourceTags() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchResourceTags")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.watcher, nil
+}
+
+func (f *fakeFacade) InstanceTags() ([]apiresourcetagger.InstanceTags, error) {
+	f.MethodCall(f, "InstanceTags")
+	return f.instanceTags, f.NextErr()
+}
+
+func (f *fakeFacade) VolumeTags() ([]apiresourcetagger.VolumeTags, error) {
+	f.MethodCall(f, "VolumeTags")
+	return f.volumeTags, f.NextErr()
+}
+
+type fakeEnviron struct {
+	*testing.Stub
+	provider *fakeProvider
+}
+
+func (e *fakeEnviron) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	e.MethodCall(e, "StorageProvider", t)
+	if err := e.NextErr(); err != nil {
+		return nil, err
+	}
+	return e.provider, nil
+}
+
+func (e *fakeEnviron) TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error {
+	e.MethodCall(e, "TagInstance", id, tags)
+	return e.NextErr()
+}
+
+type fakeNoInstanceTaggerEnviron struct {
+	resourcetagger.Environ
+}
+
+type fakeProvider struct {
+	storage.Provider
+	scope  storage.Scope
+	source *fakeVolumeSource
+}
+
+func (p *fakeProvider) Scope() storage.Scope {
+	return p.scope
+}
+
+func (p *fakeProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+func (p *fakeProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	p.source.MethodCall(p.source, "VolumeSource", cfg.Name(), cfg.Attrs())
+	return p.source, p.source.NextErr()
+}
+
+type fakeVolumeSource struct {
+	storage.VolumeSource
+	*testing.Stub
+}
+
+func (s *fakeVolumeSource) TagVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) error {
+	s.MethodCall(s, "TagVolume", volumeId, tags)
+	return s.NextErr()
+}
@@ -45,6 +45,10 @@ type Info struct {
 	// DepartingUnit is the name of the unit that goes away. It is only set
 	// when Kind indicates a relation-departed hook.
 	DepartingUnit string `yaml:"departee,omitempty"`
+
+	// Trigger describes the change in remote state which caused the
+	// hook to be run, if it is known.
+	Trigger string `yaml:"trigger,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/names/v4"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/dependency"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api"
@@ -50,6 +51,10 @@ type ManifoldConfig struct {
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
 	Logger                Logger
+
+	// PrometheusRegisterer, if set, is used to register metrics
+	// about the hooks run by the unit.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate ensures all the required values for the config are set.
@@ -142,6 +147,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Clock:                 manifoldConfig.Clock,
 				RebootQuerier:         reboot.NewMonitor(agentConfig.TransientDataDir()),
 				Logger:                config.Logger,
+				PrometheusRegisterer:  config.PrometheusRegisterer,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/uniter/operation"
+)
+
+const metricsNamespace = "juju_uniter"
+
+// executionMetrics is a prometheus.Collector that collects the
+// durations of the hooks, actions and commands run by a unit.
+type executionMetrics struct {
+	duration *prometheus.SummaryVec
+	failures *prometheus.CounterVec
+}
+
+// newExecutionMetrics returns a new executionMetrics for the named
+// unit. The unit's name is a constant label on every metric, so that
+// the uniters of several units may share a registry.
+func newExecutionMetrics(unitName string) *executionMetrics {
+	labels := prometheus.Labels{"unit": unitName}
+	return &executionMetrics{
+		duration: prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Namespace:   metricsNamespace,
+			Name:        "hook_duration_seconds",
+			Help:        "Time taken to run hooks, actions and commands in seconds.",
+			ConstLabels: labels,
+			Objectives: map[float64]float64{
+				0.5:  0.05,
+				0.9:  0.01,
+				0.99: 0.001,
+			},
+		}, []string{"kind", "name"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   metricsNamespace,
+			Name:        "hook_failures_total",
+			Help:        "Count of hooks, actions and commands which failed.",
+			ConstLabels: labels,
+		}, []string{"kind", "name"}),
+	}
+}
+
+// observe records the given execution.
+func (m *executionMetrics) observe(e operation.Execution) {
+	if e.Skipped {
+		// Hooks missing from the charm are not run.
+		return
+	}
+	name := e.Name
+	if e.Kind == operation.CommandsExecution {
+		// The commands run are arbitrary, so are not
+		// suitable for use as a label.
+		name = ""
+	}
+	m.duration.WithLabelValues(e.Kind, name).Observe(e.Duration().Seconds())
+	if e.Err != "" || e.ExitCode != 0 {
+		m.failures.WithLabelValues(e.Kind, name).Inc()
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *executionMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.duration.Describe(ch)
+	m.failures.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *executionMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.duration.Collect(ch)
+	m.failures.Collect(ch)
+}
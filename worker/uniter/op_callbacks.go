@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/worker/uniter/charm"
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
 )
@@ -96,6 +97,27 @@ func (opc *operationCallbacks) NotifyHookFailed(hook string, ctx runner.Context)
 	}
 }
 
+// RecordExecution is part of the operation.Callbacks interface.
+func (opc *operationCallbacks) RecordExecution(e operation.Execution) {
+	if opc.u.executionMetrics != nil {
+		opc.u.executionMetrics.observe(e)
+	}
+	err := opc.u.unit.RecordHookExecutions([]params.HookExecution{{
+		Kind:     e.Kind,
+		Name:     e.Name,
+		Trigger:  e.Trigger,
+		Started:  e.Started,
+		Finished: e.Finished,
+		ExitCode: e.ExitCode,
+		Error:    e.Err,
+		Skipped:  e.Skipped,
+	}})
+	// Older controllers don't keep a hook history.
+	if err != nil && !errors.IsNotImplemented(err) {
+		opc.u.logger.Warningf("cannot record %s %q: %v", e.Kind, e.Name, err)
+	}
+}
+
 // FailAction is part of the operation.Callbacks interface.
 func (opc *operationCallbacks) FailAction(actionId, message string) error {
 	if !names.IsValidAction(actionId) {
@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operation
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/worker/uniter/hook"
+)
+
+const (
+	// HookExecution is the kind of an Execution which ran a hook.
+	HookExecution = "hook"
+
+	// ActionExecution is the kind of an Execution which ran an action.
+	ActionExecution = "action"
+
+	// CommandsExecution is the kind of an Execution which ran
+	// commands on behalf of juju exec.
+	CommandsExecution = "exec"
+)
+
+// Execution records a hook, action or set of commands run by an
+// operation, so that slow or failing executions may be traced.
+type Execution struct {
+	// Kind is one of HookExecution, ActionExecution or
+	// CommandsExecution.
+	Kind string
+
+	// Name is the name of the hook or action, or the commands run.
+	Name string
+
+	// Trigger describes the change which caused the execution.
+	Trigger string
+
+	// Started and Finished record when the execution
+	// started and finished.
+	Started  time.Time
+	Finished time.Time
+
+	// ExitCode is the exit code of the process run.
+	ExitCode int
+
+	// Err holds the reason the execution failed, if it did.
+	Err string
+
+	// Skipped is true if a hook was not run because the
+	// charm does not implement it.
+	Skipped bool
+}
+
+// Duration returns how long the execution took.
+func (e Execution) Duration() time.Duration {
+	return e.Finished.Sub(e.Started)
+}
+
+// hookTrigger describes the change in remote state which caused
+// the specified hook to be run. The trigger recorded in the hook info
+// by the resolver is used if there is one; otherwise the trigger of a
+// relation or storage hook is described by the hook's details.
+func hookTrigger(info hook.Info) string {
+	if info.Trigger != "" {
+		return info.Trigger
+	}
+	switch info.Kind {
+	case hooks.RelationCreated:
+		return fmt.Sprintf("relation %d created", info.RelationId)
+	case hooks.RelationJoined:
+		return fmt.Sprintf("unit %s joined relation %d", info.RemoteUnit, info.RelationId)
+	case hooks.RelationChanged:
+		if info.RemoteUnit == "" {
+			return fmt.Sprintf("application %s settings changed in relation %d", info.RemoteApplication, info.RelationId)
+		}
+		return fmt.Sprintf(
+			"unit %s settings changed (version %d) in relation %d",
+			info.RemoteUnit, info.ChangeVersion, info.RelationId,
+		)
+	case hooks.RelationDeparted:
+		return fmt.Sprintf("unit %s departed relation %d", info.DepartingUnit, info.RelationId)
+	case hooks.RelationBroken:
+		return fmt.Sprintf("relation %d removed", info.RelationId)
+	case hooks.StorageAttached:
+		return fmt.Sprintf("storage %s attached", info.StorageId)
+	case hooks.StorageDetaching:
+		return fmt.Sprintf("storage %s detaching", info.StorageId)
+	case hooks.Install, hooks.Start:
+		return "unit deployed"
+	}
+	return ""
+}
+
+// exitCode returns the exit code of a process which
+// finished with the given error.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	if exitErr, ok := errors.Cause(err).(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			return status.ExitStatus()
+		}
+	}
+	return -1
+}
+
+// actionExitCode returns the exit code recorded in the
+// results of an action.
+func actionExitCode(results map[string]interface{}) int {
+	code, ok := results["Code"].(string)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.Atoi(code)
+	if err != nil {
+		return -1
+	}
+	return n
+}
@@ -5,6 +5,7 @@ package operation
 
 import (
 	corecharm "github.com/juju/charm/v7"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/names/v4"
 
@@ -22,11 +23,15 @@ type FactoryParams struct {
 	Abort          <-chan struct{}
 	MetricSpoolDir string
 	Logger         Logger
+	Clock          clock.Clock
 }
 
 // NewFactory returns a Factory that creates Operations backed by the supplied
 // parameters.
 func NewFactory(params FactoryParams) Factory {
+	if params.Clock == nil {
+		params.Clock = clock.WallClock
+	}
 	return &factory{
 		config: params,
 	}
@@ -105,6 +110,7 @@ func (f *factory) NewRunHook(hookInfo hook.Info) (Operation, error) {
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
 		logger:        f.config.Logger,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -127,6 +133,7 @@ func (f *factory) NewAction(actionId string) (Operation, error) {
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
 		logger:        f.config.Logger,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -154,6 +161,7 @@ func (f *factory) NewCommands(args CommandArgs, sendResponse CommandResponseFunc
 		callbacks:     f.config.Callbacks,
 		runnerFactory: f.config.RunnerFactory,
 		logger:        f.config.Logger,
+		clock:         f.config.Clock,
 	}, nil
 }
 
@@ -190,6 +190,11 @@ type Callbacks interface {
 	NotifyHookCompleted(string, runner.Context)
 	NotifyHookFailed(string, runner.Context)
 
+	// RecordExecution records a hook, action or set of commands
+	// which has been run, for tracing. It's used by RunHook,
+	// RunAction and RunCommands operations.
+	RecordExecution(Execution)
+
 	// The following methods exist primarily to allow us to test operation code
 	// without using a live api connection.
 
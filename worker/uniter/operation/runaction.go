@@ -5,7 +5,9 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
@@ -17,16 +19,19 @@ import (
 type runAction struct {
 	actionId string
 
-	change  int
-	changed chan struct{}
-	cancel  chan struct{}
+	change   int
+	changed  chan struct{}
+	cancel   chan struct{}
+	timedOut chan struct{}
 
 	callbacks     Callbacks
 	runnerFactory runner.Factory
 
-	name   string
-	runner runner.Runner
-	logger Logger
+	name    string
+	timeout time.Duration
+	runner  runner.Runner
+	logger  Logger
+	clock   clock.Clock
 
 	RequiresMachineLock
 }
@@ -43,7 +48,8 @@ func (ra *runAction) String() string {
 func (ra *runAction) Prepare(state State) (*State, error) {
 	ra.changed = make(chan struct{}, 1)
 	ra.cancel = make(chan struct{})
-	rnr, err := ra.runnerFactory.NewActionRunner(ra.actionId, ra.cancel)
+	ra.timedOut = make(chan struct{})
+	rnr, err := ra.runnerFactory.NewActionRunner(ra.actionId, ra.cancel, ra.timedOut)
 	if cause := errors.Cause(err); charmrunner.IsBadActionError(cause) {
 		if err := ra.callbacks.FailAction(ra.actionId, err.Error()); err != nil {
 			return nil, err
@@ -64,6 +70,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 	ra.name = actionData.Name
+	ra.timeout = actionData.Timeout
 	ra.runner = rnr
 	return stateChange{
 		Kind:     RunAction,
@@ -74,6 +81,7 @@ func (ra *runAction) Prepare(state State) (*State, error) {
 }
 
 // Execute runs the action, and preserves any hook recorded in the supplied state.
+// The action is cancelled if it is aborted, or runs for longer than its timeout.
 // Execute is part of the Operation interface.
 func (ra *runAction) Execute(state State) (*State, error) {
 	message := fmt.Sprintf("running action %s", ra.name)
@@ -81,6 +89,10 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		return nil, err
 	}
 
+	var timeout <-chan time.Time
+	if ra.timeout > 0 {
+		timeout = ra.clock.After(ra.timeout)
+	}
 	done := make(chan struct{})
 	wait := make(chan struct{})
 	go func() {
@@ -89,6 +101,11 @@ func (ra *runAction) Execute(state State) (*State, error) {
 			select {
 			case <-done:
 				return
+			case <-timeout:
+				ra.logger.Infof("action %s timed out after %v", ra.actionId, ra.timeout)
+				close(ra.timedOut)
+				close(ra.cancel)
+				return
 			case <-ra.changed:
 			}
 			status, err := ra.callbacks.ActionStatus(ra.actionId)
@@ -104,15 +121,20 @@ func (ra *runAction) Execute(state State) (*State, error) {
 		}
 	}()
 
+	started := ra.clock.Now()
 	handlerType, err := ra.runner.RunAction(ra.name)
+	finished := ra.clock.Now()
 	close(done)
 	<-wait
 
 	if err != nil {
 		// This indicates an actual error -- an action merely failing should
 		// be handled inside the Runner, and returned as nil.
-		return nil, errors.Annotatef(err, "action %q (via %s) failed", ra.name, handlerType)
+		err = errors.Annotatef(err, "action %q (via %s) failed", ra.name, handlerType)
+		ra.recordExecution(started, finished, err)
+		return nil, err
 	}
+	ra.recordExecution(started, finished, nil)
 	return stateChange{
 		Kind:     RunAction,
 		Step:     Done,
@@ -121,6 +143,28 @@ func (ra *runAction) Execute(state State) (*State, error) {
 	}.apply(state), nil
 }
 
+// recordExecution records the running of the action, which
+// could not be run if err is not nil.
+func (ra *runAction) recordExecution(started, finished time.Time, err error) {
+	execution := Execution{
+		Kind:     ActionExecution,
+		Name:     ra.name,
+		Trigger:  fmt.Sprintf("action %s queued", ra.actionId),
+		Started:  started,
+		Finished: finished,
+	}
+	if err != nil {
+		execution.ExitCode = -1
+		execution.Err = err.Error()
+	} else if actionData, err := ra.runner.Context().ActionData(); err == nil && actionData != nil {
+		execution.ExitCode = actionExitCode(actionData.ResultsMap)
+		if actionData.Failed {
+			execution.Err = actionData.ResultsMessage
+		}
+	}
+	ra.callbacks.RecordExecution(execution)
+}
+
 // Commit preserves the recorded hook, and returns a neutral state.
 // Commit is part of the Operation interface.
 func (ra *runAction) Commit(state State) (*State, error) {
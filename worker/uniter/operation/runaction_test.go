@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -223,6 +224,11 @@ func (s *RunActionSuite) TestExecuteSuccess(c *gc.C) {
 		c.Assert(callbacks.executingMessage, gc.Equals, "running action some-action-name")
 		c.Assert(*runnerFactory.MockNewActionRunner.runner.MockRunAction.gotName, gc.Equals, "some-action-name")
 		c.Assert(runnerFactory.MockNewActionRunner.gotCancel, gc.NotNil)
+		c.Assert(callbacks.executions, gc.HasLen, 1)
+		c.Assert(callbacks.executions[0].Kind, gc.Equals, operation.ActionExecution)
+		c.Assert(callbacks.executions[0].Name, gc.Equals, "some-action-name")
+		c.Assert(callbacks.executions[0].Trigger, gc.Equals, "action "+someActionId+" queued")
+		c.Assert(callbacks.executions[0].Err, gc.Equals, "")
 	}
 }
 
@@ -285,6 +291,61 @@ func (s *RunActionSuite) TestExecuteCancel(c *gc.C) {
 	}
 }
 
+func (s *RunActionSuite) TestExecuteTimeout(c *gc.C) {
+	actionChan := make(chan error)
+	defer close(actionChan)
+	runnerFactory := NewRunActionWaitRunnerFactory(actionChan)
+	runnerFactory.runner.context.(*MockContext).actionData.Timeout = time.Minute
+	clock := testclock.NewClock(time.Now())
+	factory := operation.NewFactory(operation.FactoryParams{
+		RunnerFactory: runnerFactory,
+		Callbacks:     &RunActionCallbacks{actionStatus: "running"},
+		Logger:        loggo.GetLogger("test"),
+		Clock:         clock,
+	})
+	op, err := factory.NewAction(someActionId)
+	c.Assert(err, jc.ErrorIsNil)
+	midState, err := op.Prepare(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	killedErr := errors.Errorf("killed")
+	wait := make(chan struct{})
+	go func() {
+		defer close(wait)
+		newState, err := op.Execute(*midState)
+		c.Check(errors.Cause(err), gc.Equals, killedErr)
+		c.Check(newState, gc.IsNil)
+	}()
+
+	err = clock.WaitAdvance(59*time.Second, testing.ShortWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-runnerFactory.gotCancel:
+		c.Fatalf("action cancelled before its timeout")
+	case <-time.After(testing.ShortWait):
+	}
+
+	clock.Advance(time.Second)
+	for _, ch := range []<-chan struct{}{runnerFactory.gotTimedOut, runnerFactory.gotCancel} {
+		select {
+		case <-ch:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("waiting for timeout")
+		}
+	}
+
+	select {
+	case actionChan <- killedErr:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("waiting for send")
+	}
+	select {
+	case <-wait:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("waiting for finish")
+	}
+}
+
 func (s *RunActionSuite) TestCommit(c *gc.C) {
 	var stateChangeTests = []struct {
 		description string
@@ -5,8 +5,12 @@ package operation
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
 
 	"github.com/juju/juju/worker/uniter/remotestate"
 	"github.com/juju/juju/worker/uniter/runner"
@@ -22,6 +26,7 @@ type runCommands struct {
 
 	runner runner.Runner
 	logger Logger
+	clock  clock.Clock
 
 	RequiresMachineLock
 }
@@ -69,7 +74,9 @@ func (rc *runCommands) Execute(state State) (*State, error) {
 		return nil, errors.Trace(err)
 	}
 
+	started := rc.clock.Now()
 	response, err := rc.runner.RunCommands(rc.args.Commands, rc.args.RunLocation)
+	rc.recordExecution(started, rc.clock.Now(), response, err)
 	switch err {
 	case context.ErrRequeueAndReboot:
 		rc.logger.Warningf("cannot requeue external commands")
@@ -86,6 +93,42 @@ func (rc *runCommands) Execute(state State) (*State, error) {
 	return nil, err
 }
 
+// maxCommandsNameLength is the most of the commands
+// recorded as the name of their execution.
+const maxCommandsNameLength = 64
+
+// recordExecution records the running of the commands.
+func (rc *runCommands) recordExecution(started, finished time.Time, response *utilexec.ExecResponse, err error) {
+	name := strings.TrimSpace(rc.args.Commands)
+	if i := strings.IndexByte(name, '\n'); i >= 0 {
+		name = name[:i] + "..."
+	}
+	if len(name) > maxCommandsNameLength {
+		name = name[:maxCommandsNameLength] + "..."
+	}
+	trigger := "juju exec"
+	if rc.args.RelationId != -1 {
+		trigger = fmt.Sprintf("juju exec in relation %d", rc.args.RelationId)
+	}
+	execution := Execution{
+		Kind:     CommandsExecution,
+		Name:     name,
+		Trigger:  trigger,
+		Started:  started,
+		Finished: finished,
+	}
+	switch {
+	case response != nil:
+		execution.ExitCode = response.Code
+	case err != nil:
+		execution.ExitCode = -1
+	}
+	if err != nil && err != context.ErrReboot && err != context.ErrRequeueAndReboot {
+		execution.Err = err.Error()
+	}
+	rc.callbacks.RecordExecution(execution)
+}
+
 // Commit does nothing.
 // Commit is part of the Operation interface.
 func (rc *runCommands) Commit(state State) (*State, error) {
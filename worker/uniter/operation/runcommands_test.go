@@ -128,6 +128,9 @@ func (s *RunCommandsSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*runnerFactory.MockNewCommandRunner.runner.MockRunCommands.gotRunLocation, gc.Equals, runner.Workload)
 	c.Assert(*sendResponse.gotResponse, gc.IsNil)
 	c.Assert(*sendResponse.gotErr, gc.ErrorMatches, "sneh")
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Assert(callbacks.executions[0].ExitCode, gc.Equals, -1)
+	c.Assert(callbacks.executions[0].Err, gc.Equals, "sneh")
 }
 
 func (s *RunCommandsSuite) TestExecuteConsumeOtherError(c *gc.C) {
@@ -172,6 +175,15 @@ func (s *RunCommandsSuite) TestExecuteSuccess(c *gc.C) {
 	c.Assert(*runnerFactory.MockNewCommandRunner.runner.MockRunCommands.gotRunLocation, gc.Equals, runner.Workload)
 	c.Assert(*sendResponse.gotResponse, gc.DeepEquals, &utilexec.ExecResponse{Code: 222})
 	c.Assert(*sendResponse.gotErr, jc.ErrorIsNil)
+
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	execution := callbacks.executions[0]
+	c.Assert(execution.Kind, gc.Equals, operation.CommandsExecution)
+	c.Assert(execution.Name, gc.Equals, "do something")
+	c.Assert(execution.Trigger, gc.Equals, "juju exec in relation 123")
+	c.Assert(execution.ExitCode, gc.Equals, 222)
+	c.Assert(execution.Err, gc.Equals, "")
+	c.Assert(execution.Finished.Before(execution.Started), jc.IsFalse)
 }
 
 func (s *RunCommandsSuite) TestExecuteSuccessOperator(c *gc.C) {
@@ -5,8 +5,10 @@ package operation
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/core/model"
@@ -29,6 +31,7 @@ type runHook struct {
 	name   string
 	runner runner.Runner
 	logger Logger
+	clock  clock.Clock
 
 	hookFound bool
 
@@ -120,7 +123,9 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	rh.hookFound = true
 	step := Done
 
+	started := rh.clock.Now()
 	handlerType, err := rh.runner.RunHook(rh.name)
+	finished := rh.clock.Now()
 	cause := errors.Cause(err)
 	switch {
 	case charmrunner.IsMissingHookError(cause):
@@ -134,15 +139,18 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	case err == nil:
 	default:
 		rh.logger.Errorf("hook %q (via %s) failed: %v", rh.name, handlerType, err)
+		rh.recordExecution(started, finished, err)
 		rh.callbacks.NotifyHookFailed(rh.name, rh.runner.Context())
 		return nil, ErrHookFailed
 	}
 
 	if rh.hookFound {
 		rh.logger.Infof("ran %q hook (via %s)", rh.name, handlerType)
+		rh.recordExecution(started, finished, nil)
 		rh.callbacks.NotifyHookCompleted(rh.name, rh.runner.Context())
 	} else {
 		rh.logger.Infof("skipped %q hook (missing)", rh.name)
+		rh.recordExecution(started, finished, nil)
 	}
 
 	var hasRunStatusSet bool
@@ -158,6 +166,24 @@ func (rh *runHook) Execute(state State) (*State, error) {
 	}.apply(state), err
 }
 
+// recordExecution records the running of the hook, which failed
+// if err is not nil, or was skipped if the charm does not have it.
+func (rh *runHook) recordExecution(started, finished time.Time, err error) {
+	execution := Execution{
+		Kind:     HookExecution,
+		Name:     rh.name,
+		Trigger:  hookTrigger(rh.info),
+		Started:  started,
+		Finished: finished,
+		ExitCode: exitCode(err),
+		Skipped:  !rh.hookFound,
+	}
+	if err != nil {
+		execution.Err = err.Error()
+	}
+	rh.callbacks.RecordExecution(execution)
+}
+
 func (rh *runHook) beforeHook(state State) error {
 	var err error
 	switch rh.info.Kind {
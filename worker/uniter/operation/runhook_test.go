@@ -219,6 +219,9 @@ func (s *RunHookSuite) TestExecuteMissingHookError(c *gc.C) {
 		c.Assert(*runnerFactory.MockNewHookRunner.runner.MockRunHook.gotName, gc.Equals, "some-hook-name")
 		c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
 		c.Assert(callbacks.MockNotifyHookFailed.gotName, gc.IsNil)
+		c.Assert(callbacks.executions, gc.HasLen, 1)
+		c.Assert(callbacks.executions[0].Name, gc.Equals, "some-hook-name")
+		c.Assert(callbacks.executions[0].Skipped, jc.IsTrue)
 
 		status, err := runnerFactory.MockNewHookRunner.runner.Context().UnitStatus()
 		c.Assert(err, jc.ErrorIsNil)
@@ -277,6 +280,54 @@ func (s *RunHookSuite) TestExecuteOtherError(c *gc.C) {
 	c.Assert(*callbacks.MockNotifyHookFailed.gotName, gc.Equals, "some-hook-name")
 	c.Assert(*callbacks.MockNotifyHookFailed.gotContext, gc.Equals, runnerFactory.MockNewHookRunner.runner.context)
 	c.Assert(callbacks.MockNotifyHookCompleted.gotName, gc.IsNil)
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	execution := callbacks.executions[0]
+	c.Assert(execution.Kind, gc.Equals, operation.HookExecution)
+	c.Assert(execution.Name, gc.Equals, "some-hook-name")
+	// The trigger of a config-changed hook is recorded by the resolver.
+	c.Assert(execution.Trigger, gc.Equals, "")
+	c.Assert(execution.ExitCode, gc.Equals, -1)
+	c.Assert(execution.Err, gc.Equals, "graaargh")
+	c.Assert(execution.Skipped, jc.IsFalse)
+}
+
+func (s *RunHookSuite) TestExecuteRecordsTrigger(c *gc.C) {
+	for i, test := range []struct {
+		info    hook.Info
+		trigger string
+	}{{
+		info:    hook.Info{Kind: hooks.ConfigChanged, Trigger: "application config changed"},
+		trigger: "application config changed",
+	}, {
+		info: hook.Info{
+			Kind:              hooks.RelationChanged,
+			RelationId:        123,
+			RemoteUnit:        "foo/1",
+			RemoteApplication: "foo",
+			ChangeVersion:     4,
+		},
+		trigger: "unit foo/1 settings changed (version 4) in relation 123",
+	}, {
+		info:    hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"},
+		trigger: "storage data/0 attached",
+	}} {
+		c.Logf("test %d: %v", i, test.info.Kind)
+		runnerFactory := NewRunHookRunnerFactory(nil)
+		callbacks := &ExecuteHookCallbacks{
+			PrepareHookCallbacks:    NewPrepareHookCallbacks(),
+			MockNotifyHookCompleted: &MockNotify{},
+			MockNotifyHookFailed:    &MockNotify{},
+		}
+		factory := newOpFactory(runnerFactory, callbacks)
+		op, err := factory.NewRunHook(test.info)
+		c.Assert(err, jc.ErrorIsNil)
+		midState, err := op.Prepare(operation.State{})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = op.Execute(*midState)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(callbacks.executions, gc.HasLen, 1)
+		c.Check(callbacks.executions[0].Trigger, gc.Equals, test.trigger)
+	}
 }
 
 func (s *RunHookSuite) TestInstallHookPreservesStatus(c *gc.C) {
@@ -330,6 +381,10 @@ func (s *RunHookSuite) testExecuteSuccess(
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(newState, gc.DeepEquals, &after)
 	c.Check(callbacks.executingMessage, gc.Equals, "running some-hook-name hook")
+	c.Assert(callbacks.executions, gc.HasLen, 1)
+	c.Check(callbacks.executions[0].Name, gc.Equals, "some-hook-name")
+	c.Check(callbacks.executions[0].ExitCode, gc.Equals, 0)
+	c.Check(callbacks.executions[0].Err, gc.Equals, "")
 }
 
 func (s *RunHookSuite) TestExecuteSuccess_BlankSlate(c *gc.C) {
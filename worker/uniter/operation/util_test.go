@@ -123,6 +123,7 @@ type RunActionCallbacks struct {
 	executingMessage string
 	actionStatus     string
 	actionStatusErr  error
+	executions       []operation.Execution
 	mut              sync.Mutex
 }
 
@@ -130,6 +131,12 @@ func (cb *RunActionCallbacks) FailAction(actionId, message string) error {
 	return cb.MockFailAction.Call(actionId, message)
 }
 
+func (cb *RunActionCallbacks) RecordExecution(e operation.Execution) {
+	cb.mut.Lock()
+	defer cb.mut.Unlock()
+	cb.executions = append(cb.executions, e)
+}
+
 func (cb *RunActionCallbacks) SetExecutingStatus(message string) error {
 	cb.mut.Lock()
 	defer cb.mut.Unlock()
@@ -153,6 +160,11 @@ func (cb *RunActionCallbacks) setActionStatus(status string, err error) {
 type RunCommandsCallbacks struct {
 	operation.Callbacks
 	executingMessage string
+	executions       []operation.Execution
+}
+
+func (cb *RunCommandsCallbacks) RecordExecution(e operation.Execution) {
+	cb.executions = append(cb.executions, e)
 }
 
 func (cb *RunCommandsCallbacks) SetExecutingStatus(message string) error {
@@ -204,6 +216,11 @@ type ExecuteHookCallbacks struct {
 	*PrepareHookCallbacks
 	MockNotifyHookCompleted *MockNotify
 	MockNotifyHookFailed    *MockNotify
+	executions              []operation.Execution
+}
+
+func (cb *ExecuteHookCallbacks) RecordExecution(e operation.Execution) {
+	cb.executions = append(cb.executions, e)
 }
 
 func (cb *ExecuteHookCallbacks) NotifyHookCompleted(hookName string, ctx runner.Context) {
@@ -236,27 +253,32 @@ func (cb *CommitHookCallbacks) CommitHook(hookInfo hook.Info) error {
 type MockNewActionRunner struct {
 	gotActionId *string
 	gotCancel   <-chan struct{}
+	gotTimedOut <-chan struct{}
 	runner      *MockRunner
 	err         error
 }
 
-func (mock *MockNewActionRunner) Call(actionId string, cancel <-chan struct{}) (runner.Runner, error) {
+func (mock *MockNewActionRunner) Call(actionId string, cancel, timedOut <-chan struct{}) (runner.Runner, error) {
 	mock.gotActionId = &actionId
 	mock.gotCancel = cancel
+	mock.gotTimedOut = timedOut
 	return mock.runner, mock.err
 }
 
 type MockNewActionWaitRunner struct {
 	gotActionId *string
 	gotCancel   <-chan struct{}
+	gotTimedOut <-chan struct{}
 	runner      *MockActionWaitRunner
 	err         error
 }
 
-func (mock *MockNewActionWaitRunner) Call(actionId string, cancel <-chan struct{}) (runner.Runner, error) {
+func (mock *MockNewActionWaitRunner) Call(actionId string, cancel, timedOut <-chan struct{}) (runner.Runner, error) {
 	mock.gotActionId = &actionId
 	mock.gotCancel = cancel
+	mock.gotTimedOut = timedOut
 	mock.runner.context.(*MockContext).actionData.Cancel = cancel
+	mock.runner.context.(*MockContext).actionData.TimedOut = timedOut
 	return mock.runner, mock.err
 }
 
@@ -288,8 +310,8 @@ type MockRunnerFactory struct {
 	*MockNewCommandRunner
 }
 
-func (f *MockRunnerFactory) NewActionRunner(actionId string, cancel <-chan struct{}) (runner.Runner, error) {
-	return f.MockNewActionRunner.Call(actionId, cancel)
+func (f *MockRunnerFactory) NewActionRunner(actionId string, cancel, timedOut <-chan struct{}) (runner.Runner, error) {
+	return f.MockNewActionRunner.Call(actionId, cancel, timedOut)
 }
 
 func (f *MockRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
@@ -305,8 +327,8 @@ type MockRunnerActionWaitFactory struct {
 	*MockNewActionWaitRunner
 }
 
-func (f *MockRunnerActionWaitFactory) NewActionRunner(actionId string, cancel <-chan struct{}) (runner.Runner, error) {
-	return f.MockNewActionWaitRunner.Call(actionId, cancel)
+func (f *MockRunnerActionWaitFactory) NewActionRunner(actionId string, cancel, timedOut <-chan struct{}) (runner.Runner, error) {
+	return f.MockNewActionWaitRunner.Call(actionId, cancel, timedOut)
 }
 
 type MockContext struct {
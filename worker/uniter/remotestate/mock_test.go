@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/charm/v7"
+	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
@@ -227,6 +228,8 @@ type mockUnit struct {
 	actionWatcher                    *mockStringsWatcher
 	relationsWatcher                 *mockStringsWatcher
 	instanceDataWatcher              *mockNotifyWatcher
+	debugHooksSessionWatcher         *mockNotifyWatcher
+	debugHooksSession                *params.DebugHooksSession
 	lxdProfileName                   string
 }
 
@@ -294,6 +297,20 @@ func (u *mockUnit) WatchInstanceData() (watcher.NotifyWatcher, error) {
 	return u.instanceDataWatcher, nil
 }
 
+func (u *mockUnit) WatchDebugHooksSession() (watcher.NotifyWatcher, error) {
+	if u.debugHooksSessionWatcher == nil {
+		return nil, errors.NotImplementedf("WatchDebugHooksSession")
+	}
+	return u.debugHooksSessionWatcher, nil
+}
+
+func (u *mockUnit) DebugHooksSession() (params.DebugHooksSession, error) {
+	if u.debugHooksSession == nil {
+		return params.DebugHooksSession{}, errors.NotFoundf("debug-hooks session")
+	}
+	return *u.debugHooksSession, nil
+}
+
 func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesPrepareStarted, nil
 }
@@ -103,6 +103,10 @@ type Snapshot struct {
 
 	// CharmProfileRequired is true if the charm has a lxdprofile.yaml.
 	CharmProfileRequired bool
+
+	// DebugHooksSession is true if a "juju debug-hooks" session,
+	// attached through the API, is waiting to debug the unit's hooks.
+	DebugHooksSession bool
 }
 
 // RelationSnapshot tracks the state of a relationship from the viewpoint of the local unit.
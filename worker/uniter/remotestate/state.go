@@ -54,6 +54,10 @@ type Unit interface {
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	// WatchDebugHooksSession returns a watcher that fires when the
+	// unit's "juju debug-hooks" session starts or ends.
+	WatchDebugHooksSession() (watcher.NotifyWatcher, error)
+	DebugHooksSession() (params.DebugHooksSession, error)
 }
 
 type Application interface {
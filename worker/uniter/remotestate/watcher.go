@@ -404,6 +404,25 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	var (
+		seenDebugHooksSessionChange bool
+		debugHooksSessionChanges    watcher.NotifyChannel
+	)
+	debugHooksSessionw, err := w.unit.WatchDebugHooksSession()
+	if errors.IsNotImplemented(err) {
+		// The controller is too old to attach debug-hooks
+		// sessions through the API, so there are none to watch.
+		w.logger.Debugf("not watching debug-hooks sessions: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(debugHooksSessionw); err != nil {
+			return errors.Trace(err)
+		}
+		debugHooksSessionChanges = debugHooksSessionw.Changes()
+		requiredEvents++
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -611,6 +630,16 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				continue
 			}
 
+		case _, ok := <-debugHooksSessionChanges:
+			w.logger.Debugf("got debug-hooks session change: ok=%t", ok)
+			if !ok {
+				return errors.New("debug-hooks session watcher closed")
+			}
+			if err := w.debugHooksSessionChanged(); err != nil {
+				return errors.Trace(err)
+			}
+			observedEvent(&seenDebugHooksSessionChange)
+
 		case <-waitMinion:
 			w.logger.Debugf("got leadership change for %v: minion", unitTag.Id())
 			w.leadershipChanged(false)
@@ -690,6 +719,24 @@ func (w *RemoteStateWatcher) upgradeSeriesStatus() (model.UpgradeSeriesStatus, e
 	return status, nil
 }
 
+// debugHooksSessionChanged is called when the unit's debug-hooks
+// session starts or ends.
+func (w *RemoteStateWatcher) debugHooksSessionChanged() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.unit.DebugHooksSession()
+	if errors.IsNotFound(err) {
+		w.current.DebugHooksSession = false
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.current.DebugHooksSession = true
+	return nil
+}
+
 // updateStatusChanged is called when the update status timer expires.
 func (w *RemoteStateWatcher) updateStatusChanged() {
 	w.mu.Lock()
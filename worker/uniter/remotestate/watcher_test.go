@@ -68,6 +68,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			actionWatcher:                    newMockStringsWatcher(),
 			relationsWatcher:                 newMockStringsWatcher(),
 			instanceDataWatcher:              newMockNotifyWatcher(),
+			debugHooksSessionWatcher:         newMockNotifyWatcher(),
 		},
 		relations:                   make(map[names.RelationTag]*mockRelation),
 		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -191,6 +192,7 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
 	s.st.unit.relationsWatcher.changes <- []string{}
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
+	s.st.unit.debugHooksSessionWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
 }
@@ -204,6 +206,7 @@ func (s *WatcherSuite) signalAll() {
 	s.st.unit.relationsWatcher.changes <- []string{}
 	s.st.unit.addressesWatcher.changes <- []string{"addresseshash"}
 	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
+	s.st.unit.debugHooksSessionWatcher.changes <- struct{}{}
 	s.leadership.claimTicket.ch <- struct{}{}
 	s.st.unit.storageWatcher.changes <- []string{}
 	if s.st.modelType == model.IAAS {
@@ -373,6 +376,11 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	s.st.unit.relationsWatcher.changes <- []string{}
 	assertOneChange()
 
+	s.st.unit.debugHooksSession = &params.DebugHooksSession{ID: "session-id"}
+	s.st.unit.debugHooksSessionWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().DebugHooksSession, jc.IsTrue)
+
 	if s.modelType == model.IAAS {
 		s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 		assertOneChange()
@@ -794,6 +802,47 @@ func (s *WatcherSuite) TestRelationUnitsDontLeakReferences(c *gc.C) {
 	)
 }
 
+func (s *WatcherSuite) TestDebugHooksSessionChanged(c *gc.C) {
+	s.st.unit.debugHooksSession = &params.DebugHooksSession{ID: "session-id", Hooks: []string{"install"}}
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DebugHooksSession, jc.IsTrue)
+
+	s.st.unit.debugHooksSession = nil
+	s.st.unit.debugHooksSessionWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DebugHooksSession, jc.IsFalse)
+}
+
+func (s *WatcherSuiteIAAS) TestDebugHooksSessionNotImplemented(c *gc.C) {
+	// Replace the suite's watcher with one whose controller
+	// cannot watch debug-hooks sessions.
+	s.watcher.Kill()
+	c.Assert(s.watcher.Wait(), jc.ErrorIsNil)
+	s.st.unit.debugHooksSessionWatcher = nil
+	w, err := remotestate.NewWatcher(s.setupWatcherConfig())
+	c.Assert(err, jc.ErrorIsNil)
+	s.watcher = w
+
+	// The initial event doesn't wait on the debug-hooks
+	// session watcher.
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	s.st.unit.configSettingsWatcher.changes <- []string{"confighash"}
+	s.st.unit.applicationConfigSettingsWatcher.changes <- []string{"trusthash"}
+	s.st.unit.actionWatcher.changes <- []string{}
+	s.st.unit.application.leaderSettingsWatcher.changes <- struct{}{}
+	s.st.unit.relationsWatcher.changes <- []string{}
+	s.st.unit.addressesWatcher.changes <- []string{"addresseshash"}
+	s.st.updateStatusIntervalWatcher.changes <- struct{}{}
+	s.leadership.claimTicket.ch <- struct{}{}
+	s.st.unit.storageWatcher.changes <- []string{}
+	s.applicationWatcher.changes <- struct{}{}
+	s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+	s.st.unit.instanceDataWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().DebugHooksSession, jc.IsFalse)
+}
+
 func (s *WatcherSuite) TestUpdateStatusTicker(c *gc.C) {
 	s.signalAll()
 	initial := s.watcher.Snapshot()
@@ -4,10 +4,14 @@
 package resolver
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -31,6 +35,9 @@ type resolverOpFactory struct {
 }
 
 func (s *resolverOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
+	if info.Trigger == "" {
+		info.Trigger = hookTrigger(info.Kind, *s.LocalState, s.RemoteState)
+	}
 	op, err := s.Factory.NewRunHook(info)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -199,6 +206,51 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 	return op
 }
 
+// hookTrigger describes the difference between the local and remote
+// state which causes a hook of the specified kind to be run. An empty
+// string is returned if the hook is not run because of a change in
+// remote state, or if the hook's own details describe the change.
+func hookTrigger(kind hooks.Kind, localState LocalState, remoteState remotestate.Snapshot) string {
+	switch kind {
+	case hooks.ConfigChanged:
+		var changes []string
+		if localState.ConfigHash != remoteState.ConfigHash {
+			changes = append(changes, "application config changed")
+		}
+		if localState.TrustHash != remoteState.TrustHash {
+			changes = append(changes, "application trust changed")
+		}
+		if localState.AddressesHash != remoteState.AddressesHash {
+			changes = append(changes, "unit addresses changed")
+		}
+		return strings.Join(changes, ", ")
+	case hooks.UpgradeCharm:
+		if remoteState.CharmURL != nil && (localState.CharmURL == nil || *remoteState.CharmURL != *localState.CharmURL) {
+			return fmt.Sprintf("charm changed to %s", remoteState.CharmURL)
+		}
+		if remoteState.CharmModifiedVersion != localState.CharmModifiedVersion {
+			return fmt.Sprintf("charm modified (version %d)", remoteState.CharmModifiedVersion)
+		}
+	case hooks.UpdateStatus:
+		if remoteState.UpdateStatusVersion != localState.UpdateStatusVersion {
+			return "update-status timer fired"
+		}
+	case hooks.Stop, hooks.Remove:
+		if remoteState.Life != life.Alive {
+			return fmt.Sprintf("unit is %s", remoteState.Life)
+		}
+	case hooks.PreSeriesUpgrade, hooks.PostSeriesUpgrade:
+		return fmt.Sprintf("series upgrade %s", remoteState.UpgradeSeriesStatus)
+	case hook.LeaderElected:
+		return "unit became leader"
+	case hook.LeaderDeposed:
+		return "unit is no longer leader"
+	case hook.LeaderSettingsChanged:
+		return fmt.Sprintf("leader settings changed (version %d)", remoteState.LeaderSettingsVersion)
+	}
+	return ""
+}
+
 type onCommitWrapper struct {
 	operation.Operation
 	onCommit func(*operation.State)
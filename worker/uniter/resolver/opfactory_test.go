@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/charm/v7"
 	"github.com/juju/charm/v7/hooks"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -66,6 +67,42 @@ func (s *ResolverOpFactorySuite) TestConfigChanged(c *gc.C) {
 	s.testConfigChanged(c, resolver.ResolverOpFactory.NewSkipHook)
 }
 
+func (s *ResolverOpFactorySuite) TestRunHookTrigger(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.ConfigHash = "confighash"
+	f.RemoteState.AddressesHash = "addresseshash"
+	f.RemoteState.CharmURL = charm.MustParseURL("cs:wordpress-2")
+	f.LocalState.CharmURL = charm.MustParseURL("cs:wordpress-1")
+
+	_, err := f.NewRunHook(hook.Info{Kind: hooks.ConfigChanged})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = f.NewRunHook(hook.Info{Kind: hooks.UpgradeCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	// A trigger already recorded for the hook is kept.
+	_, err = f.NewRunHook(hook.Info{Kind: hooks.ConfigChanged, Trigger: "charm changed to cs:wordpress-2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.opFactory.CheckCalls(c, []jujutesting.StubCall{{
+		FuncName: "NewRunHook",
+		Args: []interface{}{hook.Info{
+			Kind:    hooks.ConfigChanged,
+			Trigger: "application config changed, unit addresses changed",
+		}},
+	}, {
+		FuncName: "NewRunHook",
+		Args: []interface{}{hook.Info{
+			Kind:    hooks.UpgradeCharm,
+			Trigger: "charm changed to cs:wordpress-2",
+		}},
+	}, {
+		FuncName: "NewRunHook",
+		Args: []interface{}{hook.Info{
+			Kind:    hooks.ConfigChanged,
+			Trigger: "charm changed to cs:wordpress-2",
+		}},
+	}})
+}
+
 func (s *ResolverOpFactorySuite) TestUpgradeSeriesStatusChanged(c *gc.C) {
 	f := resolver.NewResolverOpFactory(s.opFactory)
 
@@ -47,6 +47,8 @@ type mockCallbacks struct {
 	operation.Callbacks
 }
 
+func (c *mockCallbacks) RecordExecution(operation.Execution) {}
+
 func (c *mockCallbacks) SetExecutingStatus(status string) error {
 	c.MethodCall(c, "SetExecutingStatus", status)
 	return c.NextErr()
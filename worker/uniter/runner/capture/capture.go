@@ -0,0 +1,224 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package capture records the hook tool calls made by a hook, along with
+// the environment the hook ran in, so that a failing hook can later be
+// replayed away from the unit it ran on.
+package capture
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/names/v4"
+	"github.com/juju/utils/exec"
+	goyaml "gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+const (
+	// dirName is the name of the directory, inside a unit agent's
+	// base directory, which holds the captures of that unit's hooks.
+	dirName = "captures"
+
+	// requestFile is the name of the file, inside the captures
+	// directory, which holds the hooks to capture.
+	requestFile = "request"
+
+	// bundleSuffix is the file name suffix of a capture bundle.
+	bundleSuffix = ".json"
+
+	// MaxBundles is the number of capture bundles kept for a unit;
+	// when a new bundle is written, the oldest are removed.
+	MaxBundles = 20
+)
+
+// Dir returns the directory which holds the captures of the unit whose
+// agent uses the given base directory.
+func Dir(baseDir string) string {
+	return filepath.Join(baseDir, dirName)
+}
+
+// UnitDir returns the directory, on a unit's machine, which holds the
+// captures of that unit, given the machine's juju data directory.
+func UnitDir(dataDir string, unit names.UnitTag) string {
+	// Note: must use path, not filepath, as this
+	// function is used by the client on Windows.
+	return path.Join(agent.Dir(dataDir, unit), dirName)
+}
+
+// Call records a single hook tool invocation and the response
+// the hook tool received.
+type Call struct {
+	Command  string   `json:"command"`
+	Args     []string `json:"args,omitempty"`
+	StdinSet bool     `json:"stdin-set,omitempty"`
+	Stdin    []byte   `json:"stdin,omitempty"`
+	Code     int      `json:"code"`
+	Stdout   []byte   `json:"stdout,omitempty"`
+	Stderr   []byte   `json:"stderr,omitempty"`
+}
+
+// String returns the command line of the call.
+func (c Call) String() string {
+	return strings.Join(append([]string{c.Command}, c.Args...), " ")
+}
+
+// Bundle holds everything captured about a single hook execution.
+type Bundle struct {
+	Unit     string    `json:"unit"`
+	Hook     string    `json:"hook"`
+	CharmURL string    `json:"charm-url,omitempty"`
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	Env      []string  `json:"env"`
+	Calls    []Call    `json:"calls"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Name returns the file name under which the bundle is written.
+func (b Bundle) Name() string {
+	return fmt.Sprintf("%s-%s%s", b.Hook, b.Started.UTC().Format("20060102T150405.000000000Z"), bundleSuffix)
+}
+
+// Recorder collects the hook tool calls served by a jujuc server.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// NewRecorder returns a new, empty, Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+// Record records the request and response of a hook tool call. It
+// has the signature of a jujuc.CallRecorder.
+func (r *Recorder) Record(req jujuc.Request, resp exec.ExecResponse) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{
+		Command:  req.CommandName,
+		Args:     req.Args,
+		StdinSet: req.StdinSet,
+		Stdin:    req.Stdin,
+		Code:     resp.Code,
+		Stdout:   resp.Stdout,
+		Stderr:   resp.Stderr,
+	})
+}
+
+// Calls returns the calls recorded so far.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Request holds the hooks whose execution should be captured.
+type Request struct {
+	Hooks []string `yaml:"hooks,omitempty"`
+}
+
+// MatchHook returns true if the named hook should be captured.
+func (r Request) MatchHook(hookName string) bool {
+	return len(r.Hooks) == 0 || set.NewStrings(r.Hooks...).Contains(hookName)
+}
+
+// ReadRequest returns the capture request in dir, or nil if
+// no hooks are to be captured.
+func ReadRequest(dir string) (*Request, error) {
+	data, err := ioutil.ReadFile(filepath.Join(dir, requestFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading capture request")
+	}
+	var req Request
+	if err := goyaml.Unmarshal(data, &req); err != nil {
+		return nil, errors.Annotate(err, "parsing capture request")
+	}
+	return &req, nil
+}
+
+func encodeRequest(hooks []string) []byte {
+	data, err := goyaml.Marshal(Request{Hooks: hooks})
+	if err != nil {
+		// This should not happen: we're in full control.
+		panic(err)
+	}
+	return data
+}
+
+// Write writes the bundle to dir, and removes the oldest bundles
+// there so that no more than MaxBundles remain. It returns the path
+// of the bundle written.
+func Write(dir string, b Bundle) (string, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", errors.Trace(err)
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	bundlePath := filepath.Join(dir, b.Name())
+	if err := ioutil.WriteFile(bundlePath, data, 0600); err != nil {
+		return "", errors.Trace(err)
+	}
+	return bundlePath, errors.Trace(prune(dir))
+}
+
+// prune removes the oldest bundles in dir, so that
+// no more than MaxBundles remain.
+func prune(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var bundles []string
+	for _, info := range infos {
+		if !info.IsDir() && strings.HasSuffix(info.Name(), bundleSuffix) {
+			bundles = append(bundles, info.Name())
+		}
+	}
+	// Bundle names end with the time the hook started,
+	// which sorts lexically.
+	started := func(name string) string {
+		return name[strings.LastIndex(name, "-")+1:]
+	}
+	sort.SliceStable(bundles, func(i, j int) bool {
+		return started(bundles[i]) < started(bundles[j])
+	})
+	for len(bundles) > MaxBundles {
+		if err := os.Remove(filepath.Join(dir, bundles[0])); err != nil {
+			return errors.Trace(err)
+		}
+		bundles = bundles[1:]
+	}
+	return nil
+}
+
+// Read reads the bundle at the given path.
+func Read(bundlePath string) (Bundle, error) {
+	data, err := ioutil.ReadFile(bundlePath)
+	if err != nil {
+		return Bundle{}, errors.Trace(err)
+	}
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return Bundle{}, errors.Annotatef(err, "parsing capture %q", bundlePath)
+	}
+	return b, nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capture_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/names/v4"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/capture"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type CaptureSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CaptureSuite{})
+
+func (s *CaptureSuite) TestUnitDir(c *gc.C) {
+	dir := capture.UnitDir("/var/lib/juju", names.NewUnitTag("mysql/0"))
+	c.Assert(dir, gc.Equals, "/var/lib/juju/agents/unit-mysql-0/captures")
+}
+
+func (s *CaptureSuite) TestRecorder(c *gc.C) {
+	recorder := capture.NewRecorder()
+	recorder.Record(jujuc.Request{
+		CommandName: "config-get",
+		Args:        []string{"--format", "json"},
+	}, exec.ExecResponse{Stdout: []byte(`{"foo":"bar"}`)})
+	recorder.Record(jujuc.Request{
+		CommandName: "relation-set",
+		StdinSet:    true,
+		Stdin:       []byte("a: b"),
+	}, exec.ExecResponse{Code: 1, Stderr: []byte("boom")})
+
+	c.Assert(recorder.Calls(), jc.DeepEquals, []capture.Call{{
+		Command: "config-get",
+		Args:    []string{"--format", "json"},
+		Stdout:  []byte(`{"foo":"bar"}`),
+	}, {
+		Command:  "relation-set",
+		StdinSet: true,
+		Stdin:    []byte("a: b"),
+		Code:     1,
+		Stderr:   []byte("boom"),
+	}})
+}
+
+func (s *CaptureSuite) TestReadRequestNone(c *gc.C) {
+	req, err := capture.ReadRequest(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req, gc.IsNil)
+}
+
+func (s *CaptureSuite) TestReadRequest(c *gc.C) {
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "request"), []byte("hooks: [install, config-changed]\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	req, err := capture.ReadRequest(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req.MatchHook("install"), jc.IsTrue)
+	c.Assert(req.MatchHook("config-changed"), jc.IsTrue)
+	c.Assert(req.MatchHook("start"), jc.IsFalse)
+}
+
+func (s *CaptureSuite) TestRequestMatchesAllHooks(c *gc.C) {
+	c.Assert(capture.Request{}.MatchHook("start"), jc.IsTrue)
+}
+
+func (s *CaptureSuite) bundle(hook string, started time.Time) capture.Bundle {
+	return capture.Bundle{
+		Unit:     "mysql/0",
+		Hook:     hook,
+		CharmURL: "cs:mysql-42",
+		Started:  started,
+		Finished: started.Add(time.Second),
+		Env:      []string{"JUJU_UNIT_NAME=mysql/0"},
+		Calls: []capture.Call{{
+			Command: "config-get",
+			Args:    []string{"foo"},
+			Stdout:  []byte("bar\n"),
+		}},
+		Error: "exit status 1",
+	}
+}
+
+func (s *CaptureSuite) TestWriteRead(c *gc.C) {
+	dir := filepath.Join(c.MkDir(), "captures")
+	b := s.bundle("config-changed", time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	path, err := capture.Write(dir, b)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(path, gc.Equals, filepath.Join(dir, "config-changed-20200601T120000.000000000Z.json"))
+
+	read, err := capture.Read(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, b)
+}
+
+func (s *CaptureSuite) TestWritePrunes(c *gc.C) {
+	dir := c.MkDir()
+	started := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	var names []string
+	for i := 0; i < capture.MaxBundles+2; i++ {
+		// Alternate hook names so that the names of
+		// the bundles do not sort by time.
+		hook := []string{"update-status", "config-changed"}[i%2]
+		b := s.bundle(hook, started.Add(time.Duration(i)*time.Minute))
+		_, err := capture.Write(dir, b)
+		c.Assert(err, jc.ErrorIsNil)
+		names = append(names, b.Name())
+	}
+
+	infos, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, capture.MaxBundles)
+	for _, name := range names[:2] {
+		_, err := capture.Read(filepath.Join(dir, name))
+		c.Assert(err, gc.ErrorMatches, fmt.Sprintf(".*%s: no such file or directory", name))
+	}
+}
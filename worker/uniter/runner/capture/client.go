@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capture
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+// EnableScript returns a bash script suitable for executing on the unit
+// system to capture the named hooks, or all hooks if none are named, the
+// next time they run.
+func EnableScript(dir string, hooks []string) string {
+	// If any argument is "*", then the client is interested in all.
+	for _, h := range hooks {
+		if h == "*" {
+			hooks = nil
+			break
+		}
+	}
+	request := base64.StdEncoding.EncodeToString(encodeRequest(hooks))
+	return fmt.Sprintf("mkdir -p -m 0700 %[1]s && echo %[2]s | base64 -d > %[1]s/%[3]s",
+		dir, request, requestFile)
+}
+
+// DisableScript returns a bash script suitable for executing
+// on the unit system to stop capturing hooks.
+func DisableScript(dir string) string {
+	return fmt.Sprintf("rm -f %s/%s", dir, requestFile)
+}
+
+// ListScript returns a bash script suitable for executing on the unit
+// system to list the names of the captured hooks, oldest first.
+func ListScript(dir string) string {
+	return fmt.Sprintf("cd %s 2>/dev/null && ls -1rt -- *%s 2>/dev/null; true", dir, bundleSuffix)
+}
+
+// FetchScript returns a bash script suitable for executing on
+// the unit system to write the named capture to stdout.
+func FetchScript(dir, name string) (string, error) {
+	if name == "" || name != path.Base(name) || strings.HasPrefix(name, ".") {
+		return "", errors.NotValidf("capture name %q", name)
+	}
+	if !strings.HasSuffix(name, bundleSuffix) {
+		name += bundleSuffix
+	}
+	return "cat " + utils.ShQuote(path.Join(dir, name)), nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capture_test
+
+import (
+	"encoding/base64"
+	"regexp"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/capture"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+const captureDir = "/var/lib/juju/agents/unit-mysql-0/captures"
+
+func (s *ClientSuite) TestEnableScript(c *gc.C) {
+	for _, t := range []struct {
+		hooks    []string
+		expected string
+	}{
+		{nil, "{}\n"},
+		{[]string{"install", "*"}, "{}\n"},
+		{[]string{"install", "start"}, "hooks:\n- install\n- start\n"},
+	} {
+		script := capture.EnableScript(captureDir, t.hooks)
+		re := regexp.MustCompile(`^mkdir -p -m 0700 (\S+) && echo (\S+) \| base64 -d > (\S+)$`)
+		match := re.FindStringSubmatch(script)
+		c.Assert(match, gc.HasLen, 4, gc.Commentf("script %q", script))
+		c.Assert(match[1], gc.Equals, captureDir)
+		c.Assert(match[3], gc.Equals, captureDir+"/request")
+		request, err := base64.StdEncoding.DecodeString(match[2])
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(request), gc.Equals, t.expected)
+	}
+}
+
+func (s *ClientSuite) TestDisableScript(c *gc.C) {
+	c.Assert(capture.DisableScript(captureDir), gc.Equals, "rm -f "+captureDir+"/request")
+}
+
+func (s *ClientSuite) TestFetchScript(c *gc.C) {
+	script, err := capture.FetchScript(captureDir, "install-20200601T120000.000000000Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "cat '"+captureDir+"/install-20200601T120000.000000000Z.json'")
+
+	// Shell metacharacters in the name are quoted.
+	script, err = capture.FetchScript(captureDir, "a;b|c&d>e'f")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(script, gc.Equals, "cat '"+captureDir+"/a;b|c&d>e'\"'\"'f.json'")
+
+	for _, name := range []string{"", "../request", ".hidden", "$(rm -rf /)"} {
+		_, err := capture.FetchScript(captureDir, name)
+		c.Assert(err, gc.ErrorMatches, `capture name ".*" not valid`)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capture
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/juju/utils/exec"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Replayer answers hook tool invocations with the responses
+// recorded in a capture bundle.
+type Replayer struct {
+	mu         sync.Mutex
+	calls      []Call
+	used       []bool
+	unexpected []Call
+}
+
+// NewReplayer returns a Replayer which answers from the calls
+// in the given bundle.
+func NewReplayer(b Bundle) *Replayer {
+	return &Replayer{
+		calls: b.Calls,
+		used:  make([]bool, len(b.Calls)),
+	}
+}
+
+// Respond answers the hook tool request with the response to the first
+// recorded call with the same command, arguments and stdin that has not
+// yet been replayed. Once every such call has been replayed, the last
+// is replayed again. It has the signature of a jujuc.Responder.
+func (r *Replayer) Respond(req jujuc.Request) (exec.ExecResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := -1
+	for i, call := range r.calls {
+		if call.Command != req.CommandName || !sameArgs(call.Args, req.Args) {
+			continue
+		}
+		if call.StdinSet && !req.StdinSet {
+			// The hook tool read its stdin when the
+			// call was recorded; have it send it again.
+			return exec.ExecResponse{}, jujuc.ErrNoStdin
+		}
+		if !bytes.Equal(call.Stdin, req.Stdin) {
+			continue
+		}
+		found = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if found == -1 {
+		call := Call{
+			Command:  req.CommandName,
+			Args:     req.Args,
+			StdinSet: req.StdinSet,
+			Stdin:    req.Stdin,
+		}
+		r.unexpected = append(r.unexpected, call)
+		return exec.ExecResponse{
+			Code:   1,
+			Stderr: []byte(fmt.Sprintf("ERROR no recorded response for %q\n", call.String())),
+		}, nil
+	}
+	r.used[found] = true
+	call := r.calls[found]
+	return exec.ExecResponse{
+		Code:   call.Code,
+		Stdout: call.Stdout,
+		Stderr: call.Stderr,
+	}, nil
+}
+
+// Unreplayed returns the recorded calls which have not been replayed.
+func (r *Replayer) Unreplayed() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for i, call := range r.calls {
+		if !r.used[i] {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// Unexpected returns the calls made for which no
+// response was recorded.
+func (r *Replayer) Unexpected() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.unexpected...)
+}
+
+func sameArgs(a, b []string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package capture_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/exec"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/capture"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type ReplaySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReplaySuite{})
+
+var replayBundle = capture.Bundle{
+	Calls: []capture.Call{{
+		Command: "is-leader",
+		Stdout:  []byte("False\n"),
+	}, {
+		Command: "is-leader",
+		Stdout:  []byte("True\n"),
+	}, {
+		Command:  "relation-set",
+		Args:     []string{"--file", "-"},
+		StdinSet: true,
+		Stdin:    []byte("foo: bar\n"),
+	}, {
+		Command: "status-set",
+		Args:    []string{"blocked", "waiting"},
+		Code:    2,
+		Stderr:  []byte("boom\n"),
+	}},
+}
+
+func (s *ReplaySuite) TestRespondInOrder(c *gc.C) {
+	r := capture.NewReplayer(replayBundle)
+	req := jujuc.Request{CommandName: "is-leader"}
+	for _, expected := range []string{"False\n", "True\n", "True\n"} {
+		resp, err := r.Respond(req)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(resp.Stdout), gc.Equals, expected)
+	}
+}
+
+func (s *ReplaySuite) TestRespondCodeAndStderr(c *gc.C) {
+	r := capture.NewReplayer(replayBundle)
+	resp, err := r.Respond(jujuc.Request{
+		CommandName: "status-set",
+		Args:        []string{"blocked", "waiting"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp, jc.DeepEquals, exec.ExecResponse{Code: 2, Stderr: []byte("boom\n")})
+}
+
+func (s *ReplaySuite) TestRespondAsksForStdin(c *gc.C) {
+	r := capture.NewReplayer(replayBundle)
+	req := jujuc.Request{
+		CommandName: "relation-set",
+		Args:        []string{"--file", "-"},
+	}
+	_, err := r.Respond(req)
+	c.Assert(err, gc.Equals, jujuc.ErrNoStdin)
+
+	req.StdinSet = true
+	req.Stdin = []byte("foo: bar\n")
+	resp, err := r.Respond(req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 0)
+}
+
+func (s *ReplaySuite) TestUnexpectedAndUnreplayed(c *gc.C) {
+	r := capture.NewReplayer(replayBundle)
+	_, err := r.Respond(jujuc.Request{CommandName: "is-leader"})
+	c.Assert(err, jc.ErrorIsNil)
+	resp, err := r.Respond(jujuc.Request{CommandName: "config-get", Args: []string{"foo"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Code, gc.Equals, 1)
+	c.Assert(string(resp.Stderr), gc.Equals, "ERROR no recorded response for \"config-get foo\"\n")
+
+	c.Assert(r.Unexpected(), jc.DeepEquals, []capture.Call{{
+		Command: "config-get",
+		Args:    []string{"foo"},
+	}})
+	c.Assert(r.Unreplayed(), jc.DeepEquals, replayBundle.Calls[1:])
+}
@@ -3,7 +3,11 @@
 
 package context
 
-import "github.com/juju/names/v4"
+import (
+	"time"
+
+	"github.com/juju/names/v4"
+)
 
 // ActionData contains the tag, parameters, and results of an Action.
 type ActionData struct {
@@ -14,17 +18,28 @@ type ActionData struct {
 	ResultsMessage string
 	ResultsMap     map[string]interface{}
 	Cancel         <-chan struct{}
+
+	// Timeout is how long the Action may run for; zero means
+	// there is no limit. TimedOut is closed, along with Cancel,
+	// when the Action is cancelled for running past its timeout.
+	Timeout  time.Duration
+	TimedOut <-chan struct{}
 }
 
 // NewActionData builds a suitable ActionData struct with no nil members.
 // this should only be called in the event that an Action hook is being requested.
-func NewActionData(name string, tag *names.ActionTag, params map[string]interface{}, cancel <-chan struct{}) *ActionData {
+func NewActionData(
+	name string, tag *names.ActionTag, params map[string]interface{},
+	timeout time.Duration, cancel, timedOut <-chan struct{},
+) *ActionData {
 	return &ActionData{
 		Name:       name,
 		Tag:        *tag,
 		Params:     params,
 		ResultsMap: map[string]interface{}{},
 		Cancel:     cancel,
+		Timeout:    timeout,
+		TimedOut:   timedOut,
 	}
 }
 
@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/juju/sockets"
 	"github.com/juju/juju/version"
 	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
 )
 
@@ -127,7 +128,10 @@ type HookUnit interface {
 	ApplicationName() string
 	ClosePorts(protocol string, fromPort, toPort int) error
 	ConfigSettings() (charm.Settings, error)
+	ConnectDebugHooks(sessionID, hookName string) (base.Stream, error)
+	DebugHooksSession() (params.DebugHooksSession, error)
 	LogActionMessage(names.ActionTag, string) error
+	LogActionOutput(tag names.ActionTag, stream, data string) error
 	Name() string
 	NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error)
 	OpenPorts(protocol string, fromPort, toPort int) error
@@ -280,6 +284,10 @@ type HookContext struct {
 
 	logger loggo.Logger
 
+	// debugHooksSession, if set, reports whether a debug-hooks
+	// session attached through the API may be waiting.
+	debugHooksSession func() bool
+
 	componentDir   func(string) string
 	componentFuncs map[string]ComponentFunc
 
@@ -849,6 +857,38 @@ func (ctx *HookContext) LogActionMessage(message string) error {
 	return ctx.unit.LogActionMessage(ctx.actionData.Tag, message)
 }
 
+// LogActionOutput records a chunk of output written by the Action
+// to the named stream, so that it may be followed while the Action runs.
+// Implements runner.Context.
+func (ctx *HookContext) LogActionOutput(stream, data string) error {
+	ctx.actionDataMu.Lock()
+	defer ctx.actionDataMu.Unlock()
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.unit.LogActionOutput(ctx.actionData.Tag, stream, data)
+}
+
+// RemoteDebugSession returns the "juju debug-hooks" session, attached
+// through the API, which is waiting to debug the unit's hooks, or nil
+// if there is none.
+// Implements runner.Context.
+func (ctx *HookContext) RemoteDebugSession() (*debug.RemoteSession, error) {
+	if ctx.debugHooksSession != nil && !ctx.debugHooksSession() {
+		return nil, nil
+	}
+	session, err := ctx.unit.DebugHooksSession()
+	if errors.IsNotFound(err) || errors.IsNotImplemented(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	connect := func(hookName string) (debug.Stream, error) {
+		return ctx.unit.ConnectDebugHooks(session.ID, hookName)
+	}
+	return debug.NewRemoteSession(session.Hooks, connect), nil
+}
+
 // SetActionMessage sets a message for the Action, usually an error message.
 // Implements jujuc.ActionHookContext.actionHookContext, part of runner.Context.
 func (ctx *HookContext) SetActionMessage(message string) error {
@@ -1201,12 +1241,7 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 	tag := ctx.actionData.Tag
 	actionStatus := params.ActionCompleted
 	if ctx.actionData.Failed {
-		select {
-		case <-ctx.actionData.Cancel:
-			actionStatus = params.ActionAborted
-		default:
-			actionStatus = params.ActionFailed
-		}
+		actionStatus = params.ActionFailed
 	}
 
 	// If the action completed without an error but we failed to flush the
@@ -1224,11 +1259,21 @@ func (ctx *HookContext) finalizeAction(err, unhandledErr error) error {
 		if charmrunner.IsMissingHookError(err) {
 			message = fmt.Sprintf("action not implemented on unit %q", ctx.unitName)
 		}
+		actionStatus = params.ActionFailed
+	}
+
+	// An action that failed because it was cancelled was aborted,
+	// unless it was cancelled for running past its timeout.
+	if actionStatus == params.ActionFailed {
 		select {
-		case <-ctx.actionData.Cancel:
-			actionStatus = params.ActionAborted
+		case <-ctx.actionData.TimedOut:
+			message = fmt.Sprintf("action timed out after %v", ctx.actionData.Timeout)
 		default:
-			actionStatus = params.ActionFailed
+			select {
+			case <-ctx.actionData.Cancel:
+				actionStatus = params.ActionAborted
+			default:
+			}
 		}
 	}
 
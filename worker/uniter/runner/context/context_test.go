@@ -397,7 +397,7 @@ func (s *InterfaceSuite) TestLogActionMessage(c *gc.C) {
 	s.toSupportNewActionID(c)
 	operationID, err := s.Model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.unit.AddAction(operationID, "fakeaction", nil)
+	action, err := s.unit.AddAction(operationID, "fakeaction", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = action.Begin()
 	c.Assert(err, jc.ErrorIsNil)
@@ -413,6 +413,28 @@ func (s *InterfaceSuite) TestLogActionMessage(c *gc.C) {
 	c.Assert(messages[0].Message(), gc.Equals, "hello world")
 }
 
+// TestLogActionOutput ensures LogActionOutput works properly.
+func (s *InterfaceSuite) TestLogActionOutput(c *gc.C) {
+	s.toSupportNewActionID(c)
+	operationID, err := s.Model.EnqueueOperation("a test")
+	c.Assert(err, jc.ErrorIsNil)
+	action, err := s.unit.AddAction(operationID, "fakeaction", nil, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	hctx := s.getHookContext(c, s.State.ModelUUID(), -1, "")
+	context.WithActionContext(hctx, nil, nil)
+	err = hctx.LogActionOutput("stdout", "hello world\n")
+	c.Assert(err, jc.ErrorIsNil)
+	a, err := s.Model.Action(action.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	output := a.Output()
+	c.Assert(output, gc.HasLen, 1)
+	c.Assert(output[0].Stream(), gc.Equals, "stdout")
+	c.Assert(output[0].Data(), gc.Equals, "hello world\n")
+}
+
 func (s *InterfaceSuite) TestRequestRebootAfterHook(c *gc.C) {
 	var killed bool
 	p := &mockProcess{func() error {
@@ -830,6 +852,29 @@ func (s *mockHookContextSuite) TestActionAbort(c *gc.C) {
 	}
 }
 
+func (s *mockHookContextSuite) TestActionTimeout(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(request, gc.Equals, "FinishActions")
+		c.Assert(arg, gc.DeepEquals, params.ActionExecutionResults{
+			Results: []params.ActionExecutionResult{{
+				ActionTag: "action-2",
+				Status:    "failed",
+				Message:   "action timed out after 5m0s",
+			}}})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	s.mockUnit.EXPECT().Tag().Return(names.NewUnitTag("wordpress/0")).Times(1)
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	hookContext := context.NewMockUnitHookContextWithState(s.mockUnit, st)
+	context.WithTimedOutActionContext(hookContext, 5*time.Minute)
+	err := hookContext.Flush("", errors.Errorf("signal: killed"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *mockHookContextSuite) TestMissingAction(c *gc.C) {
 	defer s.setupMocks(c).Finish()
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -857,3 +902,48 @@ func (s *mockHookContextSuite) TestMissingAction(c *gc.C) {
 	err := hookContext.Flush("action", charmrunner.NewMissingHookError("noaction"))
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *mockHookContextSuite) TestRemoteDebugSessionNone(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockUnit.EXPECT().DebugHooksSession().Return(params.DebugHooksSession{}, errors.NotFoundf("debug-hooks session"))
+
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+	session, err := hookContext.RemoteDebugSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, gc.IsNil)
+}
+
+func (s *mockHookContextSuite) TestRemoteDebugSessionNoneWaiting(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+
+	// The unit isn't asked for the session when none is waiting.
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+	context.WithDebugHooksSession(hookContext, func() bool { return false })
+	session, err := hookContext.RemoteDebugSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, gc.IsNil)
+}
+
+func (s *mockHookContextSuite) TestRemoteDebugSessionError(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockUnit.EXPECT().DebugHooksSession().Return(params.DebugHooksSession{}, errors.New("boom"))
+
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+	_, err := hookContext.RemoteDebugSession()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *mockHookContextSuite) TestRemoteDebugSession(c *gc.C) {
+	defer s.setupMocks(c).Finish()
+	s.mockUnit.EXPECT().DebugHooksSession().Return(params.DebugHooksSession{ID: "session-id", Hooks: []string{"install"}}, nil)
+	s.mockUnit.EXPECT().ConnectDebugHooks("session-id", "install").Return(nil, errors.New("boom"))
+
+	hookContext := context.NewMockUnitHookContext(s.mockUnit)
+	session, err := hookContext.RemoteDebugSession()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+	c.Assert(session.MatchHook("start"), jc.IsFalse)
+
+	err = session.RunHook("install", c.MkDir(), nil, "hooks/install")
+	c.Assert(err, gc.ErrorMatches, "attaching to debug-hooks session: boom")
+}
@@ -81,6 +81,9 @@ type contextFactory struct {
 	zone       string
 	principal  string
 
+	// Callback to check whether a debug-hooks session is waiting.
+	debugHooksSession func() bool
+
 	// Callback to get relation state snapshot.
 	getRelationInfos RelationsFunc
 	relationCaches   map[int]*RelationCache
@@ -100,6 +103,11 @@ type FactoryConfig struct {
 	Paths            Paths
 	Clock            Clock
 	Logger           loggo.Logger
+
+	// DebugHooksSession, if set, reports whether a "juju debug-hooks"
+	// session attached through the API is waiting to debug the unit's
+	// hooks, so contexts need not ask the controller before every hook.
+	DebugHooksSession func() bool
 }
 
 // NewContextFactory returns a ContextFactory capable of creating execution contexts backed
@@ -148,6 +156,8 @@ func NewContextFactory(config FactoryConfig) (ContextFactory, error) {
 		zone:             zone,
 		principal:        principal,
 		modelType:        m.ModelType,
+
+		debugHooksSession: config.DebugHooksSession,
 	}
 	return f, nil
 }
@@ -184,6 +194,7 @@ func (f *contextFactory) coreContext() (*HookContext, error) {
 		componentFuncs:     registeredComponentFuncs,
 		availabilityzone:   f.zone,
 		principal:          f.principal,
+		debugHooksSession:  f.debugHooksSession,
 	}
 	if err := f.updateContext(ctx); err != nil {
 		return nil, err
@@ -4,6 +4,8 @@
 package context
 
 import (
+	"time"
+
 	"github.com/juju/charm/v7"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -145,6 +147,21 @@ func WithActionContext(ctx *HookContext, in map[string]interface{}, cancel <-cha
 	}
 }
 
+func WithDebugHooksSession(ctx *HookContext, debugHooksSession func() bool) {
+	ctx.debugHooksSession = debugHooksSession
+}
+
+func WithTimedOutActionContext(ctx *HookContext, timeout time.Duration) {
+	timedOut := make(chan struct{})
+	close(timedOut)
+	ctx.actionData = &ActionData{
+		Tag:      names.NewActionTag("2"),
+		Cancel:   timedOut,
+		Timeout:  timeout,
+		TimedOut: timedOut,
+	}
+}
+
 type LeadershipContextFunc func(LeadershipSettingsAccessor, leadership.Tracker, string) LeadershipContext
 
 func PatchNewLeadershipContext(f LeadershipContextFunc) func() {
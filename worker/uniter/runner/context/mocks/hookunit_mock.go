@@ -7,6 +7,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	charm "github.com/juju/charm/v7"
+	base "github.com/juju/juju/api/base"
 	uniter "github.com/juju/juju/api/uniter"
 	params "github.com/juju/juju/apiserver/params"
 	status "github.com/juju/juju/core/status"
@@ -109,6 +110,36 @@ func (mr *MockHookUnitMockRecorder) ConfigSettings() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfigSettings", reflect.TypeOf((*MockHookUnit)(nil).ConfigSettings))
 }
 
+// ConnectDebugHooks mocks base method
+func (m *MockHookUnit) ConnectDebugHooks(arg0, arg1 string) (base.Stream, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectDebugHooks", arg0, arg1)
+	ret0, _ := ret[0].(base.Stream)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConnectDebugHooks indicates an expected call of ConnectDebugHooks
+func (mr *MockHookUnitMockRecorder) ConnectDebugHooks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectDebugHooks", reflect.TypeOf((*MockHookUnit)(nil).ConnectDebugHooks), arg0, arg1)
+}
+
+// DebugHooksSession mocks base method
+func (m *MockHookUnit) DebugHooksSession() (params.DebugHooksSession, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DebugHooksSession")
+	ret0, _ := ret[0].(params.DebugHooksSession)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DebugHooksSession indicates an expected call of DebugHooksSession
+func (mr *MockHookUnitMockRecorder) DebugHooksSession() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugHooksSession", reflect.TypeOf((*MockHookUnit)(nil).DebugHooksSession))
+}
+
 // LogActionMessage mocks base method
 func (m *MockHookUnit) LogActionMessage(arg0 names.ActionTag, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -123,6 +154,20 @@ func (mr *MockHookUnitMockRecorder) LogActionMessage(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActionMessage", reflect.TypeOf((*MockHookUnit)(nil).LogActionMessage), arg0, arg1)
 }
 
+// LogActionOutput mocks base method
+func (m *MockHookUnit) LogActionOutput(arg0 names.ActionTag, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogActionOutput", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogActionOutput indicates an expected call of LogActionOutput
+func (mr *MockHookUnitMockRecorder) LogActionOutput(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActionOutput", reflect.TypeOf((*MockHookUnit)(nil).LogActionOutput), arg0, arg1, arg2)
+}
+
 // Name mocks base method
 func (m *MockHookUnit) Name() string {
 	m.ctrl.T.Helper()
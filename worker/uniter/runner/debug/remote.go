@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug
+
+import (
+	"fmt"
+	"os/exec"
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/debughooks"
+)
+
+// Stream is a connection which attaches a hook to a debug-hooks
+// session over the API. It is implemented by api/base.Stream.
+type Stream interface {
+	ReadJSON(v interface{}) error
+	WriteJSON(v interface{}) error
+	Close() error
+}
+
+// RemoteSession represents a "juju debug-hooks" session attached through
+// the API, rather than through tmux over SSH, so that a unit's hooks can
+// be debugged without SSH access to the unit.
+type RemoteSession struct {
+	hooks   set.Strings
+	connect func(hookName string) (Stream, error)
+}
+
+// NewRemoteSession returns a RemoteSession which debugs the named hooks,
+// or all hooks if none are named, attaching each to the session with the
+// stream returned by connect.
+func NewRemoteSession(hooks []string, connect func(hookName string) (Stream, error)) *RemoteSession {
+	return &RemoteSession{
+		hooks:   set.NewStrings(hooks...),
+		connect: connect,
+	}
+}
+
+// MatchHook returns true if the specified hook name matches
+// the hook specified by the debug-hooks client.
+func (s *RemoteSession) MatchHook(hookName string) bool {
+	return s.hooks.IsEmpty() || s.hooks.Contains(hookName)
+}
+
+// RunHook "runs" the hook with the specified name via the debug-hooks
+// session. In place of the hook, a shell is started in the charm
+// directory with the hook's environment, and its input and output are
+// relayed through the session until it exits. The user can run the
+// hook with hookRunner, and the exit status of the shell is the result
+// of the hook.
+func (s *RemoteSession) RunHook(hookName, charmDir string, env []string, hookRunner string) error {
+	stream, err := s.connect(hookName)
+	if err != nil {
+		return &notAttachedError{err}
+	}
+	defer func() { _ = stream.Close() }()
+	out := &streamWriter{stream: stream}
+
+	// Without a terminal, bash must be told that it is
+	// interactive, so that it prompts for commands.
+	cmd := exec.Command("/bin/bash", "--norc", "--noprofile", "-i")
+	cmd.Env = utils.Setenv(env, `PS1=$JUJU_UNIT_NAME:$JUJU_DISPATCH_PATH % `)
+	cmd.Dir = charmDir
+	cmd.Stdout = out
+	cmd.Stderr = out
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	help := buildRunHookCmd(hookName, hookRunner)
+	if _, err := fmt.Fprintf(out, remoteWelcomeMessage, hookName, help); err != nil {
+		return errors.Trace(err)
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.Trace(err)
+	}
+
+	var mu sync.Mutex
+	var ended bool
+	go func() {
+		kill := func() {
+			mu.Lock()
+			ended = true
+			mu.Unlock()
+			// The only likely error is that the
+			// shell has already exited.
+			_ = cmd.Process.Kill()
+		}
+		for {
+			var m params.DebugHooksMessage
+			if err := stream.ReadJSON(&m); err != nil {
+				// The stream is closed once the shell
+				// has exited, so this is usually expected.
+				kill()
+				return
+			}
+			switch m.Type {
+			case debughooks.Input:
+				_, _ = stdin.Write(m.Data)
+			case debughooks.EOF:
+				_ = stdin.Close()
+			case debughooks.Ended:
+				kill()
+				return
+			}
+		}
+	}()
+	err = cmd.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if ended {
+		return errors.New("debug-hooks session ended")
+	}
+	code := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		code = exitErr.ExitCode()
+	}
+	// If the client has gone, the hook's result stands regardless.
+	_ = stream.WriteJSON(params.DebugHooksMessage{
+		Type: debughooks.Finished,
+		Hook: hookName,
+		Code: code,
+	})
+	return err
+}
+
+// notAttachedError is returned by RunHook when the hook could not
+// be attached to the session, and so was not run.
+type notAttachedError struct {
+	err error
+}
+
+// Error is part of the error interface.
+func (e *notAttachedError) Error() string {
+	return "attaching to debug-hooks session: " + e.err.Error()
+}
+
+// IsNotAttached returns true if the error was returned by RunHook
+// because the hook could not be attached to the session. This happens
+// when the session has ended, and the hook should then be run as if
+// there were no session.
+func IsNotAttached(err error) bool {
+	_, ok := errors.Cause(err).(*notAttachedError)
+	return ok
+}
+
+// streamWriter sends what is written to it as
+// output of the shell of a debug-hooks session.
+type streamWriter struct {
+	mu     sync.Mutex
+	stream Stream
+}
+
+// Write is part of io.Writer.
+func (w *streamWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	m := params.DebugHooksMessage{
+		Type: debughooks.Output,
+		Data: p,
+	}
+	if err := w.stream.WriteJSON(m); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return len(p), nil
+}
+
+const remoteWelcomeMessage = `This is a Juju debug-hooks session for the %s hook. Remember:
+1. The hook has not run; run it with:
+
+%s
+
+2. When you are finished with the hook, run 'exit' to allow Juju to
+continue processing events for this unit. The hook succeeds only if
+the shell exits successfully.
+3. There is no terminal; commands which need one will not work.
+
+`
@@ -0,0 +1,183 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package debug_test
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/debughooks"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/debug"
+)
+
+type RemoteSessionSuite struct {
+	testing.BaseSuite
+	stream   *fakeStream
+	charmDir string
+	hooks    []string
+}
+
+var _ = gc.Suite(&RemoteSessionSuite{})
+
+func (s *RemoteSessionSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	s.BaseSuite.SetUpTest(c)
+	s.stream = newFakeStream()
+	s.charmDir = c.MkDir()
+	s.hooks = nil
+}
+
+func (s *RemoteSessionSuite) session(hooks ...string) *debug.RemoteSession {
+	return debug.NewRemoteSession(hooks, func(hookName string) (debug.Stream, error) {
+		s.hooks = append(s.hooks, hookName)
+		return s.stream, nil
+	})
+}
+
+func (s *RemoteSessionSuite) runHook(c *gc.C) error {
+	env := []string{"PATH=/usr/bin:/bin", "JUJU_UNIT_NAME=mysql/0", "JUJU_DISPATCH_PATH=hooks/install"}
+	return s.session().RunHook("install", s.charmDir, env, "hooks/install")
+}
+
+func (s *RemoteSessionSuite) TestMatchHook(c *gc.C) {
+	session := s.session()
+	c.Assert(session.MatchHook("install"), jc.IsTrue)
+	session = s.session("start", "stop")
+	c.Assert(session.MatchHook("install"), jc.IsFalse)
+	c.Assert(session.MatchHook("start"), jc.IsTrue)
+}
+
+func (s *RemoteSessionSuite) TestRunHook(c *gc.C) {
+	s.stream.send(debughooks.Input, "echo $JUJU_UNIT_NAME in $PWD\n")
+	s.stream.send(debughooks.Input, "exit 3\n")
+	err := s.runHook(c)
+	c.Assert(err, gc.ErrorMatches, "exit status 3")
+	c.Assert(err, gc.Not(jc.Satisfies), debug.IsNotAttached)
+	c.Assert(s.hooks, jc.DeepEquals, []string{"install"})
+
+	output, finished := s.stream.received()
+	c.Assert(output, jc.Contains, "This is a Juju debug-hooks session for the install hook.")
+	c.Assert(output, jc.Contains, "./$JUJU_DISPATCH_PATH")
+	c.Assert(output, jc.Contains, "mysql/0 in "+s.charmDir+"\n")
+	c.Assert(finished, jc.DeepEquals, &params.DebugHooksMessage{
+		Type: debughooks.Finished,
+		Hook: "install",
+		Code: 3,
+	})
+	c.Assert(s.stream.isClosed(), jc.IsTrue)
+}
+
+func (s *RemoteSessionSuite) TestRunHookEOF(c *gc.C) {
+	s.stream.send(debughooks.Input, "echo hello\n")
+	s.stream.send(debughooks.EOF, "")
+	err := s.runHook(c)
+	c.Assert(err, jc.ErrorIsNil)
+
+	output, finished := s.stream.received()
+	c.Assert(output, jc.Contains, "hello\n")
+	c.Assert(finished, jc.DeepEquals, &params.DebugHooksMessage{
+		Type: debughooks.Finished,
+		Hook: "install",
+	})
+}
+
+func (s *RemoteSessionSuite) TestRunHookSessionEnded(c *gc.C) {
+	s.stream.send(debughooks.Ended, "")
+	err := s.runHook(c)
+	c.Assert(err, gc.ErrorMatches, "debug-hooks session ended")
+	_, finished := s.stream.received()
+	c.Assert(finished, gc.IsNil)
+}
+
+func (s *RemoteSessionSuite) TestRunHookConnectError(c *gc.C) {
+	session := debug.NewRemoteSession(nil, func(string) (debug.Stream, error) {
+		return nil, errors.New("boom")
+	})
+	err := session.RunHook("install", s.charmDir, nil, "hooks/install")
+	c.Assert(err, gc.ErrorMatches, "attaching to debug-hooks session: boom")
+	c.Assert(err, jc.Satisfies, debug.IsNotAttached)
+}
+
+// fakeStream gives the messages sent to it to the RemoteSession
+// reading it, and records the messages the RemoteSession writes.
+type fakeStream struct {
+	in     chan params.DebugHooksMessage
+	closed chan struct{}
+
+	mu  sync.Mutex
+	out []params.DebugHooksMessage
+}
+
+func newFakeStream() *fakeStream {
+	return &fakeStream{
+		in:     make(chan params.DebugHooksMessage, 10),
+		closed: make(chan struct{}),
+	}
+}
+
+func (s *fakeStream) send(messageType, data string) {
+	s.in <- params.DebugHooksMessage{Type: messageType, Data: []byte(data)}
+}
+
+// received returns the output written to the stream,
+// and the finished message if there was one.
+func (s *fakeStream) received() (string, *params.DebugHooksMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var output strings.Builder
+	var finished *params.DebugHooksMessage
+	for _, m := range s.out {
+		switch m.Type {
+		case debughooks.Output:
+			output.Write(m.Data)
+		case debughooks.Finished:
+			m := m
+			finished = &m
+		}
+	}
+	return output.String(), finished
+}
+
+func (s *fakeStream) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *fakeStream) ReadJSON(v interface{}) error {
+	select {
+	case m := <-s.in:
+		*(v.(*params.DebugHooksMessage)) = m
+		return nil
+	case <-s.closed:
+		return errors.New("stream closed")
+	}
+}
+
+func (s *fakeStream) WriteJSON(v interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Like a real stream, don't hold on to the data written.
+	m := v.(params.DebugHooksMessage)
+	m.Data = append([]byte(nil), m.Data...)
+	s.out = append(s.out, m)
+	return nil
+}
+
+func (s *fakeStream) Close() error {
+	close(s.closed)
+	return nil
+}
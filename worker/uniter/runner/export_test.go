@@ -4,6 +4,9 @@
 package runner
 
 import (
+	"github.com/juju/clock"
+	"github.com/juju/loggo"
+
 	"github.com/juju/juju/worker/uniter/runner/context"
 )
 
@@ -14,6 +17,17 @@ var (
 	LookPath                = lookPath
 )
 
+const (
+	OutputFlushInterval = outputFlushInterval
+	MaxOutputChunkSize  = maxOutputChunkSize
+)
+
+type OutputStreamer = outputStreamer
+
+func NewOutputStreamer(stream string, log func(stream, data string) error, clock clock.Clock) *OutputStreamer {
+	return newOutputStreamer(stream, log, loggo.GetLogger("juju.worker.uniter.runner"), clock)
+}
+
 func RunnerPaths(rnr Runner) context.Paths {
 	return rnr.(*runner).paths
 }
@@ -29,8 +29,10 @@ type Factory interface {
 	NewHookRunner(hookInfo hook.Info) (Runner, error)
 
 	// NewActionRunner returns an execution context suitable for running the
-	// action identified by the supplied id.
-	NewActionRunner(actionId string, cancel <-chan struct{}) (Runner, error)
+	// action identified by the supplied id. The action is aborted when cancel
+	// is closed; timedOut is closed with it when the action has run past its
+	// execution timeout.
+	NewActionRunner(actionId string, cancel, timedOut <-chan struct{}) (Runner, error)
 }
 
 // NewFactory returns a Factory capable of creating runners for executing
@@ -92,7 +94,7 @@ func (f *factory) NewHookRunner(hookInfo hook.Info) (Runner, error) {
 }
 
 // NewActionRunner exists to satisfy the Factory interface.
-func (f *factory) NewActionRunner(actionId string, cancel <-chan struct{}) (Runner, error) {
+func (f *factory) NewActionRunner(actionId string, cancel, timedOut <-chan struct{}) (Runner, error) {
 	ch, err := getCharm(f.paths.GetCharmDir())
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -128,7 +130,7 @@ func (f *factory) NewActionRunner(actionId string, cancel <-chan struct{}) (Runn
 		return nil, charmrunner.NewBadActionError(name, err.Error())
 	}
 
-	actionData := context.NewActionData(name, &tag, params, cancel)
+	actionData := context.NewActionData(name, &tag, params, action.ExecutionTimeout(), cancel, timedOut)
 	ctx, err := f.contextFactory.ActionContext(actionData)
 	if err != nil {
 		return nil, charmrunner.NewBadActionError(name, err.Error())
@@ -241,9 +241,9 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 		c.Logf("test %d", i)
 		operationID, err := s.model.EnqueueOperation("a test")
 		c.Assert(err, jc.ErrorIsNil)
-		action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), test.actionName, test.payload)
+		action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), test.actionName, test.payload, 0)
 		c.Assert(err, jc.ErrorIsNil)
-		rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+		rnr, err := s.factory.NewActionRunner(action.Id(), nil, nil)
 		c.Assert(err, jc.ErrorIsNil)
 		s.AssertPaths(c, rnr)
 		ctx := rnr.Context()
@@ -274,7 +274,7 @@ func (s *FactorySuite) TestNewActionRunnerGood(c *gc.C) {
 }
 
 func (s *FactorySuite) TestNewActionRunnerBadCharm(c *gc.C) {
-	rnr, err := s.factory.NewActionRunner("irrelevant", nil)
+	rnr, err := s.factory.NewActionRunner("irrelevant", nil, nil)
 	c.Assert(rnr, gc.IsNil)
 	c.Assert(errors.Cause(err), jc.Satisfies, os.IsNotExist)
 	c.Assert(err, gc.Not(jc.Satisfies), charmrunner.IsBadActionError)
@@ -284,9 +284,9 @@ func (s *FactorySuite) TestNewActionRunnerBadName(c *gc.C) {
 	s.SetCharm(c, "dummy")
 	operationID, err := s.model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "no-such-action", nil)
+	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "no-such-action", nil, 0)
 	c.Assert(err, jc.ErrorIsNil) // this will fail when using AddAction on unit
-	rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+	rnr, err := s.factory.NewActionRunner(action.Id(), nil, nil)
 	c.Check(rnr, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "cannot run \"no-such-action\" action: not defined")
 	c.Check(err, jc.Satisfies, charmrunner.IsBadActionError)
@@ -298,9 +298,9 @@ func (s *FactorySuite) TestNewActionRunnerBadParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "snapshot", map[string]interface{}{
 		"outfile": 123,
-	})
+	}, 0)
 	c.Assert(err, jc.ErrorIsNil) // this will fail when state is done right
-	rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+	rnr, err := s.factory.NewActionRunner(action.Id(), nil, nil)
 	c.Check(rnr, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "cannot run \"snapshot\" action: .*")
 	c.Check(err, jc.Satisfies, charmrunner.IsBadActionError)
@@ -310,11 +310,11 @@ func (s *FactorySuite) TestNewActionRunnerMissingAction(c *gc.C) {
 	s.SetCharm(c, "dummy")
 	operationID, err := s.model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "snapshot", nil)
+	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
 	_, err = s.unit.CancelAction(action)
 	c.Assert(err, jc.ErrorIsNil)
-	rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+	rnr, err := s.factory.NewActionRunner(action.Id(), nil, nil)
 	c.Check(rnr, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "action no longer available")
 	c.Check(err, gc.Equals, charmrunner.ErrActionNotAvailable)
@@ -326,9 +326,9 @@ func (s *FactorySuite) TestNewActionRunnerUnauthAction(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	operationID, err := s.model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.model.EnqueueAction(operationID, otherUnit.Tag(), "snapshot", nil)
+	action, err := s.model.EnqueueAction(operationID, otherUnit.Tag(), "snapshot", nil, 0)
 	c.Assert(err, jc.ErrorIsNil)
-	rnr, err := s.factory.NewActionRunner(action.Id(), nil)
+	rnr, err := s.factory.NewActionRunner(action.Id(), nil, nil)
 	c.Check(rnr, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "action no longer available")
 	c.Check(err, gc.Equals, charmrunner.ErrActionNotAvailable)
@@ -341,11 +341,12 @@ func (s *FactorySuite) TestNewActionRunnerWithCancel(c *gc.C) {
 		"outfile": "/some/file.bz2",
 	}
 	cancel := make(chan struct{})
+	timedOut := make(chan struct{})
 	operationID, err := s.model.EnqueueOperation("a test")
 	c.Assert(err, jc.ErrorIsNil)
-	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), actionName, payload)
+	action, err := s.model.EnqueueAction(operationID, s.unit.Tag(), actionName, payload, 5*time.Minute)
 	c.Assert(err, jc.ErrorIsNil)
-	rnr, err := s.factory.NewActionRunner(action.Id(), cancel)
+	rnr, err := s.factory.NewActionRunner(action.Id(), cancel, timedOut)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AssertPaths(c, rnr)
 	ctx := rnr.Context()
@@ -357,6 +358,8 @@ func (s *FactorySuite) TestNewActionRunnerWithCancel(c *gc.C) {
 		Params:     payload,
 		ResultsMap: map[string]interface{}{},
 		Cancel:     cancel,
+		Timeout:    5 * time.Minute,
+		TimedOut:   timedOut,
 	})
 	vars, err := ctx.HookVars(s.paths, false, func(k string) string {
 		switch k {
@@ -160,11 +160,21 @@ type Request struct {
 // CmdGetter looks up a Command implementation connected to a particular Context.
 type CmdGetter func(contextId, cmdName string) (cmd.Command, error)
 
+// CallRecorder is told about each hook tool invocation run by a Server,
+// along with the response sent back to the hook tool.
+type CallRecorder func(req Request, resp exec.ExecResponse)
+
+// Responder answers a hook tool invocation without running a Command,
+// for example by replaying a previously recorded response. It may return
+// ErrNoStdin to have the hook tool resend the request with its stdin.
+type Responder func(req Request) (exec.ExecResponse, error)
+
 // Jujuc implements the jujuc command in the form required by net/rpc.
 type Jujuc struct {
 	mu     sync.Mutex
 	getCmd CmdGetter
 	token  string
+	record CallRecorder
 }
 
 // badReqErrorf returns an error indicating a bad Request.
@@ -217,6 +227,36 @@ func (j *Jujuc) Main(req Request, resp *exec.ExecResponse) error {
 	}
 	resp.Stdout = stdout.Bytes()
 	resp.Stderr = stderr.Bytes()
+	if j.record != nil {
+		req.Token = ""
+		j.record(req, *resp)
+	}
+	return nil
+}
+
+// responderJujuc implements the jujuc command in the form required by
+// net/rpc, answering requests with a Responder.
+type responderJujuc struct {
+	mu      sync.Mutex
+	respond Responder
+	token   string
+}
+
+// Main answers the request with the responder, and fills in resp.
+func (j *responderJujuc) Main(req Request, resp *exec.ExecResponse) error {
+	if req.Token != j.token {
+		return badReqErrorf("token does not match")
+	}
+	if req.CommandName == "" {
+		return badReqErrorf("command not specified")
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	result, err := j.respond(req)
+	if err != nil {
+		return err
+	}
+	*resp = result
 	return nil
 }
 
@@ -235,8 +275,25 @@ type Server struct {
 // remote command invocations against an appropriate Context. It will not
 // actually do so until Run is called.
 func NewServer(getCmd CmdGetter, socket sockets.Socket, token string) (*Server, error) {
+	return NewRecordingServer(getCmd, socket, token, nil)
+}
+
+// NewRecordingServer is like NewServer, but also passes each command
+// invocation and its response to record, if it is not nil.
+func NewRecordingServer(getCmd CmdGetter, socket sockets.Socket, token string, record CallRecorder) (*Server, error) {
+	return newServer(&Jujuc{getCmd: getCmd, token: token, record: record}, socket)
+}
+
+// NewResponderServer creates an RPC server bound to socketPath, which
+// answers remote command invocations with respond instead of running
+// them. It will not actually do so until Run is called.
+func NewResponderServer(respond Responder, socket sockets.Socket, token string) (*Server, error) {
+	return newServer(&responderJujuc{respond: respond, token: token}, socket)
+}
+
+func newServer(jujuc interface{}, socket sockets.Socket) (*Server, error) {
 	server := rpc.NewServer()
-	if err := server.Register(&Jujuc{getCmd: getCmd, token: token}); err != nil {
+	if err := server.RegisterName("Jujuc", jujuc); err != nil {
 		return nil, err
 	}
 	listener, err := sockets.Listen(socket)
@@ -242,6 +242,94 @@ func (s *ServerSuite) TestBrokenCommand(c *gc.C) {
 	c.Assert(string(resp.Stderr), gc.Equals, "ERROR blam\n")
 }
 
+type OtherServersSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&OtherServersSuite{})
+
+func (s *OtherServersSuite) serve(c *gc.C, newServer func(sockets.Socket) (*jujuc.Server, error)) sockets.Socket {
+	socket := (&ServerSuite{}).osDependentSockPath(c)
+	srv, err := newServer(socket)
+	c.Assert(err, jc.ErrorIsNil)
+	done := make(chan error)
+	go func() { done <- srv.Run() }()
+	s.AddCleanup(func(c *gc.C) {
+		srv.Close()
+		c.Assert(<-done, gc.IsNil)
+	})
+	return socket
+}
+
+func (s *OtherServersSuite) call(c *gc.C, socket sockets.Socket, req jujuc.Request) (resp exec.ExecResponse, err error) {
+	client, err := sockets.Dial(socket)
+	c.Assert(err, jc.ErrorIsNil)
+	defer client.Close()
+	err = client.Call("Jujuc.Main", req, &resp)
+	return resp, err
+}
+
+func (s *OtherServersSuite) TestRecordingServer(c *gc.C) {
+	var recorded []jujuc.Request
+	var responses []exec.ExecResponse
+	record := func(req jujuc.Request, resp exec.ExecResponse) {
+		recorded = append(recorded, req)
+		responses = append(responses, resp)
+	}
+	socket := s.serve(c, func(socket sockets.Socket) (*jujuc.Server, error) {
+		return jujuc.NewRecordingServer(factory, socket, "secret", record)
+	})
+
+	req := jujuc.Request{
+		ContextId:   "validCtx",
+		Dir:         c.MkDir(),
+		CommandName: "remote",
+		Args:        []string{"--value", "something"},
+		Token:       "secret",
+	}
+	resp, err := s.call(c, socket, req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(resp.Stdout), gc.Equals, "eye of newt\n")
+
+	req.Token = ""
+	c.Assert(recorded, jc.DeepEquals, []jujuc.Request{req})
+	c.Assert(responses, jc.DeepEquals, []exec.ExecResponse{resp})
+}
+
+func (s *OtherServersSuite) TestResponderServer(c *gc.C) {
+	respond := func(req jujuc.Request) (exec.ExecResponse, error) {
+		if !req.StdinSet {
+			return exec.ExecResponse{}, jujuc.ErrNoStdin
+		}
+		return exec.ExecResponse{
+			Code:   3,
+			Stdout: []byte(req.CommandName),
+			Stderr: req.Stdin,
+		}, nil
+	}
+	socket := s.serve(c, func(socket sockets.Socket) (*jujuc.Server, error) {
+		return jujuc.NewResponderServer(respond, socket, "secret")
+	})
+
+	req := jujuc.Request{CommandName: "remote", Token: "secret"}
+	_, err := s.call(c, socket, req)
+	c.Assert(err, gc.ErrorMatches, jujuc.ErrNoStdin.Error())
+
+	req.StdinSet = true
+	req.Stdin = []byte("wool of bat")
+	resp, err := s.call(c, socket, req)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp, jc.DeepEquals, exec.ExecResponse{
+		Code:   3,
+		Stdout: []byte("remote"),
+		Stderr: []byte("wool of bat"),
+	})
+
+	req.Token = "wrong"
+	_, err = s.call(c, socket, req)
+	c.Assert(err, gc.ErrorMatches, "bad request: token does not match")
+}
+
 type NewCommandSuite struct {
 	relationSuite
 }
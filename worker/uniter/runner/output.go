@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"bytes"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+)
+
+const (
+	// outputFlushInterval is how often output written by a
+	// running action is sent to the controller.
+	outputFlushInterval = time.Second
+
+	// maxOutputChunkSize is the most output sent to the
+	// controller in a single chunk.
+	maxOutputChunkSize = 4096
+)
+
+// outputStreamer is an io.Writer which records the output written
+// to one of an action's streams against the action in chunks, so
+// that it may be followed while the action is running. Output is
+// sent when a full chunk is available, and at least once every
+// outputFlushInterval.
+type outputStreamer struct {
+	stream string
+	log    func(stream, data string) error
+	logger loggo.Logger
+	clock  clock.Clock
+
+	mu       sync.Mutex
+	pending  bytes.Buffer
+	disabled bool
+
+	full    chan struct{}
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+func newOutputStreamer(
+	stream string, log func(stream, data string) error, logger loggo.Logger, clock clock.Clock,
+) *outputStreamer {
+	s := &outputStreamer{
+		stream:  stream,
+		log:     log,
+		logger:  logger,
+		clock:   clock,
+		full:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go s.loop()
+	return s
+}
+
+// Write is part of io.Writer. It never fails, as streaming the
+// output is best effort; the full output is always available
+// from the action's results.
+func (s *outputStreamer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.disabled {
+		return len(p), nil
+	}
+	s.pending.Write(p)
+	if s.pending.Len() >= maxOutputChunkSize {
+		select {
+		case s.full <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Stop sends any output not yet sent and stops the streamer.
+func (s *outputStreamer) Stop() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	<-s.stopped
+}
+
+func (s *outputStreamer) loop() {
+	defer close(s.stopped)
+	for {
+		select {
+		case <-s.done:
+			s.flush()
+			return
+		case <-s.full:
+		case <-s.clock.After(outputFlushInterval):
+		}
+		s.flush()
+	}
+}
+
+func (s *outputStreamer) flush() {
+	for {
+		s.mu.Lock()
+		if s.disabled || s.pending.Len() == 0 {
+			s.mu.Unlock()
+			return
+		}
+		data := string(s.pending.Next(chunkSize(s.pending.Bytes())))
+		s.mu.Unlock()
+
+		if err := s.log(s.stream, data); err != nil {
+			// Stop streaming rather than flood the controller,
+			// or the log, with output that can't be recorded.
+			if !errors.IsNotImplemented(err) {
+				s.logger.Warningf("cannot stream action %s: %v", s.stream, err)
+			}
+			s.mu.Lock()
+			s.disabled = true
+			s.pending.Reset()
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// chunkSize returns how much of the pending output to send in the
+// next chunk, taking care not to split a multi-byte character.
+func chunkSize(pending []byte) int {
+	if len(pending) <= maxOutputChunkSize {
+		return len(pending)
+	}
+	n := maxOutputChunkSize
+	for n > maxOutputChunkSize-utf8.UTFMax && !utf8.RuneStart(pending[n]) {
+		n--
+	}
+	return n
+}
@@ -0,0 +1,124 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner"
+)
+
+type OutputStreamerSuite struct {
+	testing.IsolationSuite
+
+	clock  *testclock.Clock
+	logged chan string
+	err    error
+}
+
+var _ = gc.Suite(&OutputStreamerSuite{})
+
+func (s *OutputStreamerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.logged = make(chan string, 10)
+	s.err = nil
+}
+
+func (s *OutputStreamerSuite) newStreamer(c *gc.C) *runner.OutputStreamer {
+	return runner.NewOutputStreamer("stdout", func(stream, data string) error {
+		c.Check(stream, gc.Equals, "stdout")
+		s.logged <- data
+		return s.err
+	}, s.clock)
+}
+
+func (s *OutputStreamerSuite) assertLogged(c *gc.C, expected string) {
+	select {
+	case data := <-s.logged:
+		c.Assert(data, gc.Equals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for output to be logged")
+	}
+}
+
+func (s *OutputStreamerSuite) assertNothingLogged(c *gc.C) {
+	select {
+	case data := <-s.logged:
+		c.Fatalf("unexpected output logged: %q", data)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *OutputStreamerSuite) TestFlushesOnInterval(c *gc.C) {
+	streamer := s.newStreamer(c)
+	defer streamer.Stop()
+
+	_, err := streamer.Write([]byte("hello "))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = streamer.Write([]byte("world\n"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNothingLogged(c)
+
+	err = s.clock.WaitAdvance(runner.OutputFlushInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogged(c, "hello world\n")
+}
+
+func (s *OutputStreamerSuite) TestFlushesFullChunks(c *gc.C) {
+	streamer := s.newStreamer(c)
+	defer streamer.Stop()
+
+	chunk := strings.Repeat("x", runner.MaxOutputChunkSize)
+	_, err := streamer.Write([]byte(chunk + "y"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogged(c, chunk)
+	s.assertLogged(c, "y")
+}
+
+func (s *OutputStreamerSuite) TestChunksDoNotSplitCharacters(c *gc.C) {
+	streamer := s.newStreamer(c)
+	defer streamer.Stop()
+
+	chunk := strings.Repeat("x", runner.MaxOutputChunkSize-1)
+	_, err := streamer.Write([]byte(chunk + "€"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogged(c, chunk)
+	s.assertLogged(c, "€")
+}
+
+func (s *OutputStreamerSuite) TestStopFlushes(c *gc.C) {
+	streamer := s.newStreamer(c)
+	_, err := streamer.Write([]byte("bye"))
+	c.Assert(err, jc.ErrorIsNil)
+	streamer.Stop()
+	s.assertLogged(c, "bye")
+
+	// Stopping again is fine.
+	streamer.Stop()
+}
+
+func (s *OutputStreamerSuite) TestStopsStreamingOnError(c *gc.C) {
+	s.err = errors.NotImplementedf("LogActionOutput")
+	streamer := s.newStreamer(c)
+	_, err := streamer.Write([]byte("hello"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.clock.WaitAdvance(runner.OutputFlushInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertLogged(c, "hello")
+
+	// Output is still accepted, but no longer sent.
+	_, err = streamer.Write([]byte("world"))
+	c.Assert(err, jc.ErrorIsNil)
+	streamer.Stop()
+	s.assertNothingLogged(c)
+}
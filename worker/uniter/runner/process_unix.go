@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package runner
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/juju/errors"
+	utilexec "github.com/juju/utils/exec"
+)
+
+// setProcessGroup starts the command in a process group of its
+// own, so that the processes it spawns can be killed with it.
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+// killProcessTree kills the process group led by the given
+// process, which was started with setProcessGroup.
+func killProcessTree(proc *os.Process) error {
+	// The negative pid signals every process in the group.
+	return syscall.Kill(-proc.Pid, syscall.SIGKILL)
+}
+
+// execOnMachineStreamed runs the commands with bash, as utils/exec
+// does, but writes their output to the stdout and stderr in params
+// as it is produced, rather than once the commands have finished.
+func execOnMachineStreamed(params ExecParams) (*utilexec.ExecResponse, error) {
+	tempDir, err := ioutil.TempDir("", "juju-exec")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	script := filepath.Join(tempDir, "script.sh")
+	if err := ioutil.WriteFile(script, []byte(strings.Join(params.Commands, " ")), 0644); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ps := exec.Command("/bin/bash", script)
+	ps.Env = params.Env
+	ps.Dir = params.WorkingDir
+	ps.Stdout = params.Stdout
+	ps.Stderr = params.Stderr
+	setProcessGroup(ps)
+	if err := ps.Start(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	params.ProcessSetter(hookProcess{ps.Process})
+
+	done := make(chan error, 1)
+	go func() {
+		done <- ps.Wait()
+	}()
+	var cancelled bool
+	select {
+	case err = <-done:
+	case <-params.Cancel:
+		// The only likely error is that the commands have
+		// just finished, which Wait will tell us anyway.
+		_ = killProcessTree(ps.Process)
+		err = <-done
+		cancelled = true
+	}
+
+	stdout, _ := ioutil.ReadAll(params.Stdout)
+	stderr, _ := ioutil.ReadAll(params.Stderr)
+	resp := &utilexec.ExecResponse{
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		// A non-zero return code isn't considered an error here.
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Exited() {
+			resp.Code = status.ExitStatus()
+			err = nil
+		}
+	}
+	if cancelled {
+		return resp, utilexec.ErrCancelled
+	}
+	return resp, errors.Trace(err)
+}
@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package runner
+
+import (
+	"os"
+	"os/exec"
+
+	utilexec "github.com/juju/utils/exec"
+)
+
+// setProcessGroup does nothing on Windows, where
+// processes are killed without their children.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+// killProcessTree kills the given process.
+func killProcessTree(proc *os.Process) error {
+	return proc.Kill()
+}
+
+// execOnMachineStreamed runs the commands with utils/exec, which
+// buffers their output, and then writes the output to the stdout
+// and stderr in params.
+func execOnMachineStreamed(params ExecParams) (*utilexec.ExecResponse, error) {
+	resp, err := execOnMachineBuffered(params)
+	if resp != nil {
+		_, _ = params.Stdout.Write(resp.Stdout)
+		_, _ = params.Stderr.Write(resp.Stderr)
+	}
+	return resp, err
+}
@@ -30,6 +30,8 @@ import (
 	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/common/charmrunner"
+	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/runner/capture"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/debug"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -111,6 +113,8 @@ type Context interface {
 	Id() string
 	HookVars(paths context.Paths, remote bool, getEnvFunc context.GetEnvFunc) ([]string, error)
 	ActionData() (*context.ActionData, error)
+	LogActionOutput(stream, data string) error
+	RemoteDebugSession() (*debug.RemoteSession, error)
 	SetProcess(process context.HookProcess)
 	HasExecutionSetUnitStatus() bool
 	ResetExecutionSetUnitStatus()
@@ -148,6 +152,15 @@ type ExecParams struct {
 
 // execOnMachine executes commands on current machine.
 func execOnMachine(params ExecParams) (*utilexec.ExecResponse, error) {
+	if params.Stdout != nil && params.Stderr != nil {
+		return execOnMachineStreamed(params)
+	}
+	return execOnMachineBuffered(params)
+}
+
+// execOnMachineBuffered executes commands on current machine,
+// returning their output once they have finished.
+func execOnMachineBuffered(params ExecParams) (*utilexec.ExecResponse, error) {
 	command := utilexec.RunParams{
 		Commands:    strings.Join(params.Commands, " "),
 		WorkingDir:  params.WorkingDir,
@@ -268,7 +281,14 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	var stdout, stderr bytes.Buffer
+	var stdout, stderr streamingBuffer
+	if data, err := runner.context.ActionData(); err == nil && data != nil {
+		stdoutStreamer, stderrStreamer := runner.newActionOutputStreamers()
+		defer stdoutStreamer.Stop()
+		defer stderrStreamer.Stop()
+		stdout.stream = stdoutStreamer
+		stderr.stream = stderrStreamer
+	}
 	return executor(ExecParams{
 		Commands:      []string{commands},
 		Env:           env,
@@ -281,6 +301,26 @@ func (runner *runner) runCommandsWithTimeout(commands string, timeout time.Durat
 	})
 }
 
+// streamingBuffer is an io.ReadWriter which buffers what is
+// written to it, and also sends it to a stream, if set.
+type streamingBuffer struct {
+	buf    bytes.Buffer
+	stream io.Writer
+}
+
+// Read is part of io.Reader.
+func (b *streamingBuffer) Read(p []byte) (int, error) {
+	return b.buf.Read(p)
+}
+
+// Write is part of io.Writer.
+func (b *streamingBuffer) Write(p []byte) (int, error) {
+	if b.stream != nil {
+		_, _ = b.stream.Write(p)
+	}
+	return b.buf.Write(p)
+}
+
 // runJujuRunAction is the function that executes when a juju-run action is ran.
 func (runner *runner) runJujuRunAction() (err error) {
 	logger := runner.logger()
@@ -397,7 +437,12 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 			return InvalidHookHandler, errors.Trace(err)
 		}
 	}
-	srv, err := runner.startJujucServer(token, rMode)
+	// Hooks, but not actions, may be captured for replay.
+	var recorder *capture.Recorder
+	if charmLocation == "hooks" {
+		recorder = runner.captureRecorder(hookName)
+	}
+	srv, err := runner.startRecordingJujucServer(token, rMode, recorder)
 	if err != nil {
 		return InvalidHookHandler, errors.Trace(err)
 	}
@@ -438,6 +483,14 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 	defer func() {
 		err = runner.context.Flush(hookName, err)
 	}()
+	if recorder != nil {
+		// Deferred after the flush, so that it runs
+		// first and records the hook's own error.
+		started := time.Now()
+		defer func() {
+			runner.writeCapture(hookName, env, started, recorder, err)
+		}()
+	}
 
 	logger := runner.logger()
 	debugctx := debug.NewHooksContext(runner.context.UnitName())
@@ -458,6 +511,27 @@ func (runner *runner) runCharmHookWithLocation(hookName, charmLocation string, r
 	}
 
 	charmDir := runner.paths.GetCharmDir()
+	// The context only asks the controller for a debug-hooks session
+	// when the remote state watcher has seen one start.
+	if jujuos.HostOS() != jujuos.Windows {
+		remote, err := runner.context.RemoteDebugSession()
+		if err != nil {
+			logger.Warningf("cannot check for a debug-hooks session: %v", err)
+		} else if remote != nil && remote.MatchHook(hookName) && rMode == runOnRemote {
+			// Hooks and actions run in a workload pod can't be debugged,
+			// as there is nowhere to run a shell with their environment.
+			// The client rejects such actions; hooks are run as usual.
+			logger.Warningf("not debugging %s: it runs in the workload pod", hookName)
+		} else if remote != nil && remote.MatchHook(hookName) {
+			hookHandlerType, hookScript, _ := runner.discoverHookHandler(hookName, charmDir, charmLocation)
+			logger.Infof("executing %s via remote debug-hooks; %s", hookName, hookHandlerType)
+			err := remote.RunHook(hookName, charmDir, env, hookScript)
+			if !debug.IsNotAttached(err) {
+				return hookHandlerType, err
+			}
+			logger.Warningf("not debugging %s: %v", hookName, err)
+		}
+	}
 	hookHandlerType, hookScript, err := runner.discoverHookHandler(hookName, charmDir, charmLocation)
 	if err != nil {
 		return InvalidHookHandler, err
@@ -485,6 +559,9 @@ func (l *loggerAdaptor) Messagef(isPrefix bool, message string, args ...interfac
 type bufferAdaptor struct {
 	io.ReadWriter
 
+	// stream, if set, is also sent the output as it arrives.
+	stream io.Writer
+
 	mu      sync.Mutex
 	outCopy bytes.Buffer
 }
@@ -507,10 +584,32 @@ func (b *bufferAdaptor) Messagef(isPrefix bool, message string, args ...interfac
 	b.mu.Lock()
 	defer b.mu.Unlock()
 	b.outCopy.WriteString(formattedMessage)
+	if b.stream != nil {
+		_, _ = io.WriteString(b.stream, formattedMessage)
+	}
+}
+
+// newActionOutputStreamers returns writers which stream the output of
+// the running action to the controller. They must be stopped once all
+// the output has been written.
+func (runner *runner) newActionOutputStreamers() (stdout, stderr *outputStreamer) {
+	logger := runner.logger()
+	stdout = newOutputStreamer(actions.StdoutStream, runner.context.LogActionOutput, logger, clock.WallClock)
+	stderr = newOutputStreamer(actions.StderrStream, runner.context.LogActionOutput, logger, clock.WallClock)
+	return stdout, stderr
 }
 
 func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, env []string) error {
 	var cancel <-chan struct{}
+	var stdoutStreamer, stderrStreamer *outputStreamer
+	actionData, err := runner.context.ActionData()
+	runningAction := err == nil && actionData != nil
+	if runningAction {
+		stdoutStreamer, stderrStreamer = runner.newActionOutputStreamers()
+		defer stdoutStreamer.Stop()
+		defer stderrStreamer.Stop()
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make stdout logging pipe: %v", err)
@@ -518,6 +617,9 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	defer func() { _ = outWriter.Close() }()
 
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
+	if runningAction {
+		actionOut.stream = stdoutStreamer
+	}
 	hookOutLogger := charmrunner.NewHookLogger(outReader,
 		&loggerAdaptor{runner.getLogger(hookName)},
 		actionOut,
@@ -529,8 +631,6 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 	// separately to pass back.
 	var actionErr = actionOut
 	var hookErrLogger *charmrunner.HookLogger
-	actionData, err := runner.context.ActionData()
-	runningAction := err == nil && actionData != nil
 	if runningAction {
 		cancel = actionData.Cancel
 
@@ -540,7 +640,7 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 		}
 		defer func() { _ = errWriter.Close() }()
 
-		actionErr = &bufferAdaptor{ReadWriter: errWriter}
+		actionErr = &bufferAdaptor{ReadWriter: errWriter, stream: stderrStreamer}
 		hookErrLogger = charmrunner.NewHookLogger(errReader,
 			&loggerAdaptor{runner.getLogger(hookName)},
 			actionErr,
@@ -568,6 +668,9 @@ func (runner *runner) runCharmProcessOnRemote(hook, hookName, charmDir string, e
 
 	// If we are running an action, record stdout and stderr.
 	if runningAction && resp != nil {
+		// Send the last of the streamed output before the results.
+		stdoutStreamer.Stop()
+		stderrStreamer.Stop()
 		if err := runner.updateActionResults(resp); err != nil {
 			return errors.Trace(err)
 		}
@@ -582,6 +685,17 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	ps := exec.Command(hookCmd[0], hookCmd[1:]...)
 	ps.Env = env
 	ps.Dir = charmDir
+	setProcessGroup(ps)
+
+	var stdoutStreamer, stderrStreamer *outputStreamer
+	actionData, err := runner.context.ActionData()
+	runningAction := err == nil && actionData != nil
+	if runningAction {
+		stdoutStreamer, stderrStreamer = runner.newActionOutputStreamers()
+		defer stdoutStreamer.Stop()
+		defer stderrStreamer.Stop()
+	}
+
 	outReader, outWriter, err := os.Pipe()
 	if err != nil {
 		return errors.Errorf("cannot make logging pipe: %v", err)
@@ -591,6 +705,9 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	ps.Stdout = outWriter
 	ps.Stderr = outWriter
 	actionOut := &bufferAdaptor{ReadWriter: outWriter}
+	if runningAction {
+		actionOut.stream = stdoutStreamer
+	}
 	hookOutLogger := charmrunner.NewHookLogger(outReader,
 		&loggerAdaptor{runner.getLogger(hookName)},
 		actionOut,
@@ -603,8 +720,6 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 	var actionErr io.Reader
 	var hookErrLogger *charmrunner.HookLogger
 	var cancel <-chan struct{}
-	actionData, err := runner.context.ActionData()
-	runningAction := err == nil && actionData != nil
 	if runningAction {
 		cancel = actionData.Cancel
 
@@ -615,7 +730,7 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 		defer func() { _ = errWriter.Close() }()
 
 		ps.Stderr = errWriter
-		errBuf := &bufferAdaptor{ReadWriter: errWriter}
+		errBuf := &bufferAdaptor{ReadWriter: errWriter, stream: stderrStreamer}
 		actionErr = errBuf
 		hookErrLogger = charmrunner.NewHookLogger(errReader,
 			&loggerAdaptor{runner.getLogger(hookName)},
@@ -633,7 +748,11 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 			go func() {
 				select {
 				case <-cancel:
-					ps.Process.Kill()
+					// Kill the processes spawned by the hook too,
+					// as they may be what is keeping it running.
+					if err := killProcessTree(ps.Process); err != nil {
+						runner.logger().Warningf("cannot kill action %q: %v", hookName, err)
+					}
 				case <-done:
 				}
 			}()
@@ -654,6 +773,10 @@ func (runner *runner) runCharmProcessOnLocal(hook, hookName, charmDir string, en
 
 	// If we are running an action, record stdout and stderr.
 	if runningAction {
+		// Send the last of the streamed output before the results.
+		stdoutStreamer.Stop()
+		stderrStreamer.Stop()
+
 		readBytes := func(r io.Reader) []byte {
 			var o bytes.Buffer
 			_, _ = o.ReadFrom(r)
@@ -703,6 +826,12 @@ func (runner *runner) discoverHookHandler(hookName, charmDir, charmLocation stri
 }
 
 func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Server, error) {
+	return runner.startRecordingJujucServer(token, rMode, nil)
+}
+
+// startRecordingJujucServer starts a jujuc server which, if recorder
+// is not nil, records the hook tool calls it serves.
+func (runner *runner) startRecordingJujucServer(token string, rMode runMode, recorder *capture.Recorder) (*jujuc.Server, error) {
 	// Prepare server.
 	getCmd := func(ctxId, cmdName string) (cmd.Command, error) {
 		if ctxId != runner.context.Id() {
@@ -713,7 +842,11 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 
 	socket := runner.paths.GetJujucServerSocket(rMode == runOnRemote)
 	runner.logger().Debugf("starting jujuc server %s %v", token, socket)
-	srv, err := jujuc.NewServer(getCmd, socket, token)
+	var record jujuc.CallRecorder
+	if recorder != nil {
+		record = recorder.Record
+	}
+	srv, err := jujuc.NewRecordingServer(getCmd, socket, token, record)
 	if err != nil {
 		return nil, errors.Annotate(err, "starting jujuc server")
 	}
@@ -721,6 +854,54 @@ func (runner *runner) startJujucServer(token string, rMode runMode) (*jujuc.Serv
 	return srv, nil
 }
 
+// captureRecorder returns a recorder for the hook tool calls made by the
+// named hook, if a capture of the hook was requested, or nil otherwise.
+func (runner *runner) captureRecorder(hookName string) *capture.Recorder {
+	req, err := capture.ReadRequest(capture.Dir(runner.paths.GetBaseDir()))
+	if err != nil {
+		runner.logger().Warningf("cannot capture hook %q: %v", hookName, err)
+		return nil
+	}
+	if req == nil || !req.MatchHook(hookName) {
+		return nil
+	}
+	return capture.NewRecorder()
+}
+
+// writeCapture writes a bundle holding the hook tool calls made by the
+// named hook, and the environment it ran in, so that it may be replayed.
+func (runner *runner) writeCapture(hookName string, env []string, started time.Time, recorder *capture.Recorder, hookErr error) {
+	logger := runner.logger()
+	bundle := capture.Bundle{
+		Unit:     runner.context.UnitName(),
+		Hook:     hookName,
+		Started:  started,
+		Finished: time.Now(),
+		Calls:    recorder.Calls(),
+	}
+	for _, v := range env {
+		// The agent token is only valid for this execution.
+		if !strings.HasPrefix(v, "JUJU_AGENT_TOKEN=") {
+			bundle.Env = append(bundle.Env, v)
+		}
+	}
+	if hookErr != nil {
+		bundle.Error = hookErr.Error()
+	}
+	charmURLPath := filepath.Join(runner.paths.GetCharmDir(), charm.CharmURLPath)
+	if curl, err := charm.ReadCharmURL(charmURLPath); err != nil {
+		logger.Warningf("cannot read charm URL for capture of hook %q: %v", hookName, err)
+	} else {
+		bundle.CharmURL = curl.String()
+	}
+	path, err := capture.Write(capture.Dir(runner.paths.GetBaseDir()), bundle)
+	if err != nil {
+		logger.Warningf("cannot write capture of hook %q: %v", hookName, err)
+		return
+	}
+	logger.Infof("captured hook %q in %s", hookName, path)
+}
+
 // getKigger returns the logger for a particular unit's hook.
 func (runner *runner) getLogger(hookName string) loggo.Logger {
 	return runner.context.GetLogger(fmt.Sprintf("unit.%s.%s", runner.context.UnitName(), hookName))
@@ -7,12 +7,15 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/charm/v7/hooks"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/proxy"
@@ -21,11 +24,16 @@ import (
 	"github.com/juju/utils/exec"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/pubsub/debughooks"
+	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/common/charmrunner"
 	"github.com/juju/juju/worker/uniter/hook"
 	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/capture"
 	"github.com/juju/juju/worker/uniter/runner/context"
+	"github.com/juju/juju/worker/uniter/runner/debug"
 	runnertesting "github.com/juju/juju/worker/uniter/runner/testing"
 )
 
@@ -195,6 +203,11 @@ type MockContext struct {
 	flushFailure    error
 	flushResult     error
 	modelType       model.ModelType
+
+	remoteDebugSession *debug.RemoteSession
+
+	mu           sync.Mutex
+	actionOutput map[string]string
 }
 
 func (ctx *MockContext) GetLogger(module string) loggo.Logger {
@@ -249,6 +262,20 @@ func (ctx *MockContext) UpdateActionResults(keys []string, value string) error {
 	return nil
 }
 
+func (ctx *MockContext) LogActionOutput(stream, data string) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.actionOutput == nil {
+		ctx.actionOutput = make(map[string]string)
+	}
+	ctx.actionOutput[stream] += data
+	return nil
+}
+
+func (ctx *MockContext) RemoteDebugSession() (*debug.RemoteSession, error) {
+	return ctx.remoteDebugSession, nil
+}
+
 func (ctx *MockContext) ModelType() model.ModelType {
 	if ctx.modelType == "" {
 		return model.IAAS
@@ -311,6 +338,133 @@ func (s *RunMockContextSuite) TestRunHookFlushFailure(c *gc.C) {
 	s.assertRecordedPid(c, ctx.expectPid)
 }
 
+func (s *RunMockContextSuite) TestRunHookCaptured(c *gc.C) {
+	captureDir := capture.Dir(s.paths.GetBaseDir())
+	err := os.MkdirAll(captureDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(captureDir, "request"), []byte("hooks: [something-happened]\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	_, err = runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+
+	matches, err := filepath.Glob(filepath.Join(captureDir, "something-happened-*.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(matches, gc.HasLen, 1)
+	bundle, err := capture.Read(matches[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle.Unit, gc.Equals, "some-unit/999")
+	c.Assert(bundle.Hook, gc.Equals, "something-happened")
+	c.Assert(bundle.Error, gc.Equals, "exit status 123")
+	env := set.NewStrings(bundle.Env...)
+	c.Assert(env.Contains("VAR=value"), jc.IsTrue)
+	c.Assert(env.Contains("JUJU_DISPATCH_PATH=hooks/something-happened"), jc.IsTrue)
+	c.Assert(bundle.Calls, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookNotCaptured(c *gc.C) {
+	captureDir := capture.Dir(s.paths.GetBaseDir())
+	err := os.MkdirAll(captureDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(captureDir, "request"), []byte("hooks: [install]\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := &MockContext{}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err = runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+
+	matches, err := filepath.Glob(filepath.Join(captureDir, "*.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(matches, gc.HasLen, 0)
+}
+
+func (s *RunMockContextSuite) TestRunHookRemoteDebug(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("bug 1403084: Currently debug does not work on windows")
+	}
+	stream := &remoteDebugStream{
+		in:     []params.DebugHooksMessage{{Type: debughooks.Input, Data: []byte("exit 4\n")}},
+		closed: make(chan struct{}),
+	}
+	ctx := &MockContext{
+		remoteDebugSession: debug.NewRemoteSession(nil, func(string) (debug.Stream, error) {
+			return stream, nil
+		}),
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	// The shell ran in place of the hook.
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 4")
+	_, err = os.Stat(filepath.Join(s.paths.GetCharmDir(), "pid"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *RunMockContextSuite) TestRunHookRemoteDebugNotAttached(c *gc.C) {
+	ctx := &MockContext{
+		remoteDebugSession: debug.NewRemoteSession(nil, func(string) (debug.Stream, error) {
+			return nil, errors.New("session ended")
+		}),
+	}
+	makeCharm(c, hookSpec{
+		dir:  "hooks",
+		name: hookName,
+		perm: 0700,
+		code: 123,
+	}, s.paths.GetCharmDir())
+	_, err := runner.NewRunner(ctx, s.paths, nil).RunHook("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "exit status 123")
+	s.assertRecordedPid(c, ctx.expectPid)
+}
+
+// remoteDebugStream gives its messages to a remote debug
+// session, then waits to be closed.
+type remoteDebugStream struct {
+	mu     sync.Mutex
+	in     []params.DebugHooksMessage
+	closed chan struct{}
+}
+
+func (s *remoteDebugStream) ReadJSON(v interface{}) error {
+	s.mu.Lock()
+	if len(s.in) > 0 {
+		*(v.(*params.DebugHooksMessage)) = s.in[0]
+		s.in = s.in[1:]
+		s.mu.Unlock()
+		return nil
+	}
+	s.mu.Unlock()
+	<-s.closed
+	return errors.New("stream closed")
+}
+
+func (s *remoteDebugStream) WriteJSON(interface{}) error {
+	return nil
+}
+
+func (s *remoteDebugStream) Close() error {
+	close(s.closed)
+	return nil
+}
+
 func (s *RunHookSuite) TestRunActionDispatchingHookHandler(c *gc.C) {
 	ctx := &MockContext{
 		actionData:    &context.ActionData{},
@@ -354,6 +508,9 @@ func (s *RunMockContextSuite) TestRunActionFlushSuccess(c *gc.C) {
 	c.Assert(ctx.actionResults, jc.DeepEquals, map[string]interface{}{
 		"Code": "0", "Stderr": "world\n", "Stdout": "hello\n",
 	})
+	c.Assert(ctx.actionOutput, jc.DeepEquals, map[string]string{
+		"stdout": "hello\n", "stderr": "world\n",
+	})
 }
 
 func (s *RunMockContextSuite) TestRunActionFlushCharmActionsCAASSuccess(c *gc.C) {
@@ -474,6 +631,7 @@ func (s *RunMockContextSuite) TestRunActionSuccessful(c *gc.C) {
 	c.Assert(ctx.actionResults["Code"], gc.Equals, "0")
 	c.Assert(strings.TrimRight(ctx.actionResults["Stdout"].(string), "\r\n"), gc.Equals, "1")
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
+	c.Assert(strings.TrimRight(ctx.actionOutput["stdout"], "\r\n"), gc.Equals, "1")
 }
 
 func (s *RunMockContextSuite) TestRunActionError(c *gc.C) {
@@ -519,6 +677,41 @@ func (s *RunMockContextSuite) TestRunActionCancelled(c *gc.C) {
 	c.Assert(ctx.actionResults["Stderr"], gc.Equals, nil)
 }
 
+func (s *RunMockContextSuite) TestRunActionCancelKillsProcessTree(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("the processes spawned by actions are not killed on windows")
+	}
+	cancel := make(chan struct{})
+	ctx := &MockContext{
+		actionData:    &context.ActionData{Cancel: cancel},
+		actionResults: map[string]interface{}{},
+	}
+	charmDir := s.paths.GetCharmDir()
+	err := os.Mkdir(filepath.Join(charmDir, "actions"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	script := "#!/bin/bash\nPATH=/usr/bin:/bin\n(sleep 0.5; touch survived) &\ntouch started\nwait\n"
+	err = ioutil.WriteFile(filepath.Join(charmDir, "actions", "something-happened"), []byte(script), 0700)
+	c.Assert(err, jc.ErrorIsNil)
+
+	go func() {
+		defer close(cancel)
+		for a := coretesting.LongAttempt.Start(); a.Next(); {
+			if _, err := os.Stat(filepath.Join(charmDir, "started")); err == nil {
+				return
+			}
+		}
+	}()
+	_, err = runner.NewRunner(ctx, s.paths, nil).RunAction("something-happened")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ctx.flushFailure, gc.ErrorMatches, "signal: killed")
+
+	// Give the background process time to outlive the action, had
+	// it not been killed with it.
+	time.Sleep(time.Second)
+	_, err = os.Stat(filepath.Join(charmDir, "survived"))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
 func (s *RunMockContextSuite) TestRunCommandsFlushSuccess(c *gc.C) {
 	expectErr := errors.New("pew pew pew")
 	ctx := &MockContext{
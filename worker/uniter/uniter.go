@@ -17,6 +17,7 @@ import (
 	"github.com/juju/utils/exec"
 	"github.com/juju/worker/v2"
 	"github.com/juju/worker/v2/catacomb"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/juju/juju/agent/tools"
 	"github.com/juju/juju/api/uniter"
@@ -92,6 +93,11 @@ type Uniter struct {
 	lastReportedStatus  status.Status
 	lastReportedMessage string
 
+	// The current remote state watcher, which hook contexts
+	// consult for a waiting debug-hooks session.
+	remoteStateMutex   sync.Mutex
+	remoteStateWatcher *remotestate.RemoteStateWatcher
+
 	operationFactory        operation.Factory
 	operationExecutor       operation.Executor
 	newOperationExecutor    NewOperationExecutorFunc
@@ -149,6 +155,11 @@ type Uniter struct {
 	// rebooted so we can notify the charms accordingly.
 	rebootQuerier RebootQuerier
 	logger        Logger
+
+	// prometheusRegisterer, if set, is used to register the
+	// metrics collected about the hooks run by the unit.
+	prometheusRegisterer prometheus.Registerer
+	executionMetrics     *executionMetrics
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -179,9 +190,10 @@ type UniterParams struct {
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
-	Observer      UniterExecutionObserver
-	RebootQuerier RebootQuerier
-	Logger        Logger
+	Observer             UniterExecutionObserver
+	RebootQuerier        RebootQuerier
+	Logger               Logger
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // NewOperationExecutorFunc is a func which returns an operations.Executor.
@@ -245,6 +257,7 @@ func newUniter(uniterParams *UniterParams) func() (worker.Worker, error) {
 			runListener:                   uniterParams.RunListener,
 			rebootQuerier:                 uniterParams.RebootQuerier,
 			logger:                        uniterParams.Logger,
+			prometheusRegisterer:          uniterParams.PrometheusRegisterer,
 		}
 		plan := catacomb.Plan{
 			Site: &u.catacomb,
@@ -297,6 +310,16 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	}
 	u.logger.Infof("unit %q started", u.unit)
 
+	if u.prometheusRegisterer != nil {
+		metrics := newExecutionMetrics(u.unit.Name())
+		if err := u.prometheusRegisterer.Register(metrics); err != nil {
+			u.logger.Warningf("cannot register hook metrics: %v", err)
+		} else {
+			u.executionMetrics = metrics
+			defer u.prometheusRegisterer.Unregister(metrics)
+		}
+	}
+
 	// Install is a special case, as it must run before there
 	// is any remote state, and before the remote state watcher
 	// is started.
@@ -395,6 +418,9 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if err := u.catacomb.Add(watcher); err != nil {
 			return errors.Trace(err)
 		}
+		u.remoteStateMutex.Lock()
+		u.remoteStateWatcher = watcher
+		u.remoteStateMutex.Unlock()
 		return nil
 	}
 
@@ -721,14 +747,15 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		return errors.Annotatef(err, "cannot create deployer")
 	}
 	contextFactory, err := context.NewContextFactory(context.FactoryConfig{
-		State:            u.st,
-		Unit:             u.unit,
-		Tracker:          u.leadershipTracker,
-		GetRelationInfos: u.relationStateTracker.GetInfo,
-		Storage:          u.storage,
-		Paths:            u.paths,
-		Clock:            u.clock,
-		Logger:           u.logger.Child("context"),
+		State:             u.st,
+		Unit:              u.unit,
+		Tracker:           u.leadershipTracker,
+		GetRelationInfos:  u.relationStateTracker.GetInfo,
+		Storage:           u.storage,
+		Paths:             u.paths,
+		Clock:             u.clock,
+		Logger:            u.logger.Child("context"),
+		DebugHooksSession: u.debugHooksSession,
 	})
 	if err != nil {
 		return err
@@ -750,6 +777,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 		Abort:          u.catacomb.Dying(),
 		MetricSpoolDir: u.paths.GetMetricsSpoolDir(),
 		Logger:         u.logger.Child("operation"),
+		Clock:          u.clock,
 	})
 
 	charmURL, err := u.getApplicationCharmURL()
@@ -822,6 +850,19 @@ func (u *Uniter) getApplicationCharmURL() (*corecharm.URL, error) {
 	return charmURL, err
 }
 
+// debugHooksSession reports whether the remote state records a
+// "juju debug-hooks" session waiting to debug the unit's hooks. Before
+// the remote state is known, it reports true so that the controller
+// is asked.
+func (u *Uniter) debugHooksSession() bool {
+	u.remoteStateMutex.Lock()
+	defer u.remoteStateMutex.Unlock()
+	if u.remoteStateWatcher == nil {
+		return true
+	}
+	return u.remoteStateWatcher.Snapshot().DebugHooksSession
+}
+
 // RunCommands executes the supplied commands in a hook context.
 func (u *Uniter) RunCommands(args RunCommandsArgs) (results *exec.ExecResponse, err error) {
 	// TODO(axw) drop this when we move the run-listener to an independent